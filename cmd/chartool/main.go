@@ -0,0 +1,130 @@
+// Command chartool exports characters to JSON and imports them again.
+//
+//	chartool export <character> [file]
+//	chartool import [-overwrite <character>] <file> <username>
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/elidor/dungeogo/config"
+	"github.com/elidor/dungeogo/pkg/game/character"
+	"github.com/elidor/dungeogo/pkg/game/items"
+	"github.com/elidor/dungeogo/pkg/persistence/postgres"
+	"github.com/elidor/dungeogo/pkg/persistence/transfer"
+)
+
+func main() {
+	log.SetFlags(0)
+	if len(os.Args) < 2 {
+		usage()
+	}
+
+	cfg := config.NewConfig(config.NewFileProvider(".env"))
+	databaseURL := cfg.GetValue(config.DatabaseURL)
+	if databaseURL == "" {
+		log.Fatal("DATABASE_URL is required")
+	}
+
+	repoManager, err := postgres.NewPostgreSQLRepositoryManager(databaseURL)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer repoManager.Close()
+
+	switch os.Args[1] {
+	case "export":
+		err = runExport(repoManager, os.Args[2:])
+	case "import":
+		err = runImport(repoManager, cfg, os.Args[2:])
+	default:
+		usage()
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func usage() {
+	log.Fatal("usage: chartool export <character> [file]\n       chartool import [-overwrite <character>] <file> <username>")
+}
+
+func runExport(repoManager *postgres.PostgreSQLRepositoryManager, args []string) error {
+	if len(args) < 1 || len(args) > 2 {
+		usage()
+	}
+
+	c, err := repoManager.Characters().GetCharacterByName(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to find character %s: %w", args[0], err)
+	}
+
+	data, err := transfer.ExportCharacter(repoManager, c.ID)
+	if err != nil {
+		return err
+	}
+
+	var out io.Writer = os.Stdout
+	if len(args) == 2 {
+		file, err := os.Create(args[1])
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", args[1], err)
+		}
+		defer file.Close()
+		out = file
+	}
+	_, err = fmt.Fprintln(out, string(data))
+	return err
+}
+
+func runImport(repoManager *postgres.PostgreSQLRepositoryManager, cfg *config.Config, args []string) error {
+	flags := flag.NewFlagSet("import", flag.ExitOnError)
+	overwrite := flags.String("overwrite", "", "name of an existing character to replace")
+	flags.Parse(args)
+	if flags.NArg() != 2 {
+		usage()
+	}
+
+	data, err := os.ReadFile(flags.Arg(0))
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", flags.Arg(0), err)
+	}
+
+	owner, err := repoManager.Players().GetPlayerByUsername(flags.Arg(1))
+	if err != nil {
+		return fmt.Errorf("failed to find player %s: %w", flags.Arg(1), err)
+	}
+
+	// Load the content the server plays with, so characters using custom
+	// races, classes or items can be imported
+	factory := items.NewItemFactory()
+	if _, err := items.NewTemplateLoader(cfg.GetString(config.ItemTemplates, items.DefaultTemplatePath), factory.Registry()).Load(); err != nil {
+		return fmt.Errorf("failed to load item templates: %w", err)
+	}
+	if _, err := character.NewRaceLoader(cfg.GetString(config.RaceDefinitions, character.DefaultRacePath)).Load(); err != nil {
+		return fmt.Errorf("failed to load races: %w", err)
+	}
+	if _, err := character.NewClassLoader(cfg.GetString(config.ClassDefinitions, character.DefaultClassPath), nil).Load(); err != nil {
+		return fmt.Errorf("failed to load classes: %w", err)
+	}
+
+	opts := transfer.ImportOptions{PlayerID: owner.ID, Items: factory}
+	if *overwrite != "" {
+		existing, err := repoManager.Characters().GetCharacterByName(*overwrite)
+		if err != nil {
+			return fmt.Errorf("failed to find character %s: %w", *overwrite, err)
+		}
+		opts.OverwriteID = existing.ID
+	}
+
+	c, err := transfer.ImportCharacter(repoManager, data, opts)
+	if err != nil {
+		return err
+	}
+	log.Printf("Imported %s (%s) for %s", c.Name, c.ID, owner.Username)
+	return nil
+}
@@ -9,7 +9,18 @@ import (
 	"time"
 
 	"github.com/elidor/dungeogo/config"
+	"github.com/elidor/dungeogo/pkg/auth"
 	"github.com/elidor/dungeogo/pkg/game"
+	"github.com/elidor/dungeogo/pkg/game/achievement"
+	"github.com/elidor/dungeogo/pkg/game/character"
+	"github.com/elidor/dungeogo/pkg/game/combat"
+	"github.com/elidor/dungeogo/pkg/game/items"
+	"github.com/elidor/dungeogo/pkg/game/player"
+	"github.com/elidor/dungeogo/pkg/game/reward"
+	"github.com/elidor/dungeogo/pkg/game/world"
+	"github.com/elidor/dungeogo/pkg/logging"
+	"github.com/elidor/dungeogo/pkg/mail"
+	"github.com/elidor/dungeogo/pkg/metrics"
 	"github.com/elidor/dungeogo/pkg/persistence/postgres"
 	"github.com/elidor/dungeogo/pkg/server"
 )
@@ -35,24 +46,207 @@ func main() {
 	
 	address := fmt.Sprintf("%s:%s", bindAddress, port)
 	
+	logLevel, err := logging.ParseLevel(cfg.GetValue(config.LogLevel))
+	if err != nil {
+		log.Printf("Invalid LOG_LEVEL, defaulting to info: %v", err)
+	}
+	logger := logging.New(os.Stdout, logLevel)
+	
 	// Initialize database connection
 	log.Println("Connecting to database...")
-	repoManager, err := postgres.NewPostgreSQLRepositoryManager(databaseURL)
+	pool := postgres.PoolConfig{
+		MaxOpenConns:    cfg.GetInt(config.MaxConnections, postgres.DefaultMaxOpenConns),
+		MaxIdleConns:    cfg.GetInt(config.DBMaxIdleConnections, postgres.DefaultMaxIdleConns),
+		ConnMaxLifetime: cfg.GetDuration(config.DBConnMaxLifetime, postgres.DefaultConnMaxLifetime),
+	}
+	repoManager, err := postgres.NewPostgreSQLRepositoryManagerWithPool(databaseURL, pool)
 	if err != nil {
 		log.Fatalf("Failed to connect to database: %v", err)
 	}
 	defer repoManager.Close()
 	
+	applied, err := repoManager.Migrate()
+	if err != nil {
+		log.Fatalf("Failed to migrate database: %v", err)
+	}
+	log.Printf("Database schema up to date (%d migrations applied)", applied)
+	
+	dbMonitor := postgres.NewMonitor(repoManager,
+		cfg.GetDuration(config.DBHealthInterval, postgres.DefaultHealthInterval), logger)
+	dbMonitor.Start()
+	
 	// Initialize game engine
 	log.Println("Starting game engine...")
 	gameEngine := game.NewEngine(repoManager)
+	itemTemplates := items.NewTemplateLoader(cfg.GetString(config.ItemTemplates, items.DefaultTemplatePath), gameEngine.Items().Registry())
+	if _, err := itemTemplates.Load(); err != nil {
+		log.Fatalf("Failed to load item templates: %v", err)
+	}
+	// Templates classes give as starting kit can't be reloaded away
+	itemTemplates.SetInUse(character.KitTemplateIDs)
+	gameEngine.SetReloader("items", itemTemplates)
+	races := character.NewRaceLoader(cfg.GetString(config.RaceDefinitions, character.DefaultRacePath))
+	races.SetInUse(repoManager.Characters().RaceIDsInUse)
+	if _, err := races.Load(); err != nil {
+		log.Fatalf("Failed to load races: %v", err)
+	}
+	gameEngine.SetReloader("races", races)
+	classes := character.NewClassLoader(cfg.GetString(config.ClassDefinitions, character.DefaultClassPath), func(templateID string) bool {
+		_, err := gameEngine.Items().GetTemplate(templateID)
+		return err == nil
+	})
+	classes.SetInUse(repoManager.Characters().ClassIDsInUse)
+	if _, err := classes.Load(); err != nil {
+		log.Fatalf("Failed to load classes: %v", err)
+	}
+	gameEngine.SetReloader("classes", classes)
+	achievements := achievement.NewLoader(cfg.GetString(config.AchievementDefinitions, achievement.DefaultPath), func(templateID string) bool {
+		_, err := gameEngine.Items().GetTemplate(templateID)
+		return err == nil
+	})
+	if _, err := achievements.Load(); err != nil {
+		log.Fatalf("Failed to load achievements: %v", err)
+	}
+	gameEngine.SetReloader("achievements", achievements)
+	levelingMode, err := character.ParseLevelingMode(cfg.GetValue(config.LevelingMode))
+	if err != nil {
+		log.Fatalf("Invalid LEVELING_MODE: %v", err)
+	}
+	gameEngine.SetLevelingMode(levelingMode)
+	pvpMode, err := combat.ParsePvPMode(cfg.GetValue(config.PvPMode))
+	if err != nil {
+		log.Fatalf("Invalid PVP_MODE: %v", err)
+	}
+	gameEngine.SetPvPMode(pvpMode)
+	gameEngine.SetNewbieProtection(combat.NewbieProtection{
+		Level:    cfg.GetInt(config.NewbieLevel, combat.DefaultNewbieLevel),
+		LevelGap: cfg.GetInt(config.NewbieLevelGap, combat.DefaultNewbieLevelGap),
+	})
+	gameEngine.SetDeathPenalty(character.DeathPenalty{
+		ExperiencePercent: cfg.GetInt(config.DeathExperienceLoss, character.DefaultDeathExperiencePercent),
+		GoldPercent:       cfg.GetInt(config.DeathGoldLoss, character.DefaultDeathGoldPercent),
+		Corpse:            cfg.GetBool(config.DeathCorpses, true),
+		CorpseDecay:       cfg.GetDuration(config.CorpseDecay, character.DefaultCorpseDecay),
+	})
+	startLocations, err := world.StartLocations(cfg.GetValue(config.StartRoom), cfg.GetValue(config.StartRooms))
+	if err != nil {
+		log.Fatalf("Invalid start locations: %v", err)
+	}
+	gameEngine.SetStartLocations(startLocations)
+	experienceRate, err := reward.ParseMultiplier(cfg.GetValue(config.ExperienceRate))
+	if err != nil {
+		log.Fatalf("Invalid EXPERIENCE_RATE: %v", err)
+	}
+	goldRate, err := reward.ParseMultiplier(cfg.GetValue(config.GoldRate))
+	if err != nil {
+		log.Fatalf("Invalid GOLD_RATE: %v", err)
+	}
+	gameEngine.SetRewardRates(reward.Rates{Experience: experienceRate, Gold: goldRate})
+	if seed := cfg.GetInt(config.RandomSeed, 0); seed != 0 {
+		log.Printf("Rolling dice from fixed seed %d", seed)
+		gameEngine.SetSeed(int64(seed))
+	}
 	
 	// Initialize session handler
 	sessionHandler := server.NewSessionHandler(repoManager, gameEngine)
+	sessionHandler.SetPasswordPolicy(auth.NewPasswordPolicy(
+		cfg.GetInt(config.PasswordMinLength, auth.DefaultMinPasswordLength),
+		cfg.GetInt(config.PasswordMinCharClasses, auth.DefaultMinCharClasses),
+		cfg.GetInt(config.BcryptCost, 0),
+	))
+	sessionHandler.SetLoginLimiter(auth.NewLoginLimiter(
+		cfg.GetInt(config.LoginMaxAttempts, auth.DefaultMaxLoginAttempts),
+		cfg.GetDuration(config.LoginAttemptWindow, auth.DefaultAttemptWindow),
+		cfg.GetDuration(config.LoginLockoutDuration, auth.DefaultLockoutDuration),
+	))
+	sessionHandler.SetLogger(logger)
+	sessionHandler.SetStartLocations(startLocations)
+	
+	benefits := &player.Benefits{
+		ExtraCharacters:    cfg.GetInt(config.PremiumExtraCharacters, player.DefaultExtraCharacters),
+		CommandRate:        cfg.GetInt(config.CommandRate, player.DefaultCommandRate),
+		PremiumCommandRate: cfg.GetInt(config.PremiumCommandRate, player.DefaultPremiumCommandRate),
+		ReservedSlots:      cfg.GetInt(config.PremiumReservedSlots, player.DefaultReservedSlots),
+	}
+	sessionHandler.SetBenefits(benefits)
+	
+	loginAuditor := server.NewLoginAuditor(repoManager.Players(), server.DefaultAuditBuffer, logger)
+	sessionHandler.SetLoginAuditor(loginAuditor)
+	
+	verificationMode, err := auth.ParseVerificationMode(cfg.GetValue(config.EmailVerification))
+	if err != nil {
+		log.Fatalf("Invalid EMAIL_VERIFICATION: %v", err)
+	}
+	var mailer mail.Mailer = &mail.NoopMailer{}
+	if smtpAddress := cfg.GetValue(config.SMTPAddress); smtpAddress != "" {
+		mailer = mail.NewSMTPMailer(smtpAddress, cfg.GetValue(config.SMTPFrom),
+			cfg.GetValue(config.SMTPUsername), cfg.GetValue(config.SMTPPassword))
+	} else if verificationMode != auth.VerificationOff {
+		logger.Warnf("EMAIL_VERIFICATION is enabled but SMTP_ADDRESS is not set; codes will not be delivered")
+	}
+	sessionHandler.SetEmailVerification(verificationMode, mailer)
+	
+	motdFile := cfg.GetValue(config.MOTDFile)
+	if motdFile == "" {
+		motdFile = "motd.txt"
+	}
+	motd, err := server.LoadMOTD(motdFile)
+	if err != nil {
+		log.Fatalf("Failed to load message of the day: %v", err)
+	}
+	sessionHandler.SetMOTD(motd)
+	gameEngine.SetReloader("motd", motd)
+	sessionHandler.SetItemFactory(gameEngine.Items())
 	
 	// Initialize connection manager
-	connectionManager := server.NewConnectionManager(100, 30*time.Minute)
+	connectionManager := server.NewConnectionManager(
+		cfg.GetInt(config.MaxClients, server.DefaultMaxClients), 30*time.Minute)
 	connectionManager.SetHandler(sessionHandler)
+	connectionManager.SetReservedSlots(benefits.ReservedSlots)
+	connectionManager.SetQueueSize(cfg.GetInt(config.WaitQueueSize, server.DefaultQueueSize))
+	connectionManager.SetQueuePriority(sessionHandler.PremiumLogin)
+	connectionManager.SetMaxLineLength(cfg.GetInt(config.MaxLineLength, server.DefaultMaxLineLength))
+	connectionManager.SetWriteTimeout(cfg.GetDuration(config.WriteTimeout, server.DefaultWriteTimeout))
+	sessionHandler.SetConnectionManager(connectionManager)
+	gameEngine.SetMessenger(connectionManager)
+	if err := gameEngine.Start(); err != nil {
+		log.Fatalf("Failed to start game engine: %v", err)
+	}
+	connectionManager.SetLogger(logger)
+	
+	var metricsServer *metrics.Server
+	if metricsAddress := cfg.GetValue(config.MetricsAddress); metricsAddress != "" {
+		registry := metrics.NewRegistry()
+		connectionManager.RegisterMetrics(registry)
+		gameEngine.SetMetrics(registry)
+		queryLatency := registry.NewHistogram("dungeogo_db_query_duration_seconds",
+			"Time taken by database statements, by operation.", metrics.DefaultBuckets, "operation")
+		repoManager.SetQueryObserver(func(operation string, duration time.Duration) {
+			queryLatency.Observe(duration.Seconds(), operation)
+		})
+		
+		metricsServer = metrics.NewServer(registry)
+		if err := metricsServer.Start(metricsAddress); err != nil {
+			log.Fatalf("Failed to start metrics endpoint: %v", err)
+		}
+		log.Printf("Metrics available on %s/metrics", metricsAddress)
+	}
+	
+	var statusServer *server.StatusServer
+	if statusAddress := cfg.GetValue(config.StatusAddress); statusAddress != "" {
+		statusServer = server.NewStatusServer(connectionManager, repoManager.Characters(), dbMonitor, logger)
+		if err := statusServer.Start(statusAddress); err != nil {
+			log.Fatalf("Failed to start status API: %v", err)
+		}
+		log.Printf("Status API listening on %s", statusAddress)
+	}
+	
+	if wsAddress := cfg.GetValue(config.WebSocketAddress); wsAddress != "" {
+		if err := connectionManager.StartWebSocket(wsAddress); err != nil {
+			log.Fatalf("Failed to start WebSocket listener: %v", err)
+		}
+		log.Printf("Accepting WebSocket clients on %s/ws", wsAddress)
+	}
 	
 	// Start server
 	log.Printf("Starting DungeoGo server on %s", address)
@@ -65,6 +259,15 @@ func main() {
 		
 		log.Println("Shutting down server...")
 		connectionManager.Stop()
+		gameEngine.Stop()
+		if statusServer != nil {
+			statusServer.Stop()
+		}
+		if metricsServer != nil {
+			metricsServer.Stop()
+		}
+		loginAuditor.Close()
+		dbMonitor.Stop()
 		os.Exit(0)
 	}()
 	
@@ -2,6 +2,8 @@ package config
 
 import (
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -16,12 +18,125 @@ const (
 	DatabaseURL    = "DATABASE_URL"
 	MaxConnections = "MAX_CONNECTIONS"
 	MaxThreads     = "MAX_THREADS"
+	MaxClients     = "MAX_CLIENTS"
+	WaitQueueSize  = "WAIT_QUEUE_SIZE"
+	MaxLineLength  = "MAX_LINE_LENGTH"
+	WriteTimeout   = "WRITE_TIMEOUT"
+
+	StartRoom  = "START_ROOM"
+	StartRooms = "START_ROOMS"
+
+	PasswordMinLength      = "PASSWORD_MIN_LENGTH"
+	PasswordMinCharClasses = "PASSWORD_MIN_CHAR_CLASSES"
+	BcryptCost             = "BCRYPT_COST"
+
+	LogLevel = "LOG_LEVEL"
+
+	LoginMaxAttempts     = "LOGIN_MAX_ATTEMPTS"
+	LoginAttemptWindow   = "LOGIN_ATTEMPT_WINDOW"
+	LoginLockoutDuration = "LOGIN_LOCKOUT_DURATION"
+
+	EmailVerification = "EMAIL_VERIFICATION"
+	SMTPAddress       = "SMTP_ADDRESS"
+	SMTPFrom          = "SMTP_FROM"
+	SMTPUsername      = "SMTP_USERNAME"
+	SMTPPassword      = "SMTP_PASSWORD"
+
+	MOTDFile = "MOTD_FILE"
+
+	ItemTemplates          = "ITEM_TEMPLATES"
+	RaceDefinitions        = "RACE_DEFINITIONS"
+	ClassDefinitions       = "CLASS_DEFINITIONS"
+	AchievementDefinitions = "ACHIEVEMENT_DEFINITIONS"
+
+	DBHealthInterval = "DB_HEALTH_INTERVAL"
+
+	DBMaxIdleConnections = "DB_MAX_IDLE_CONNECTIONS"
+	DBConnMaxLifetime    = "DB_CONN_MAX_LIFETIME"
+
+	StatusAddress    = "STATUS_ADDRESS"
+	WebSocketAddress = "WEBSOCKET_ADDRESS"
+	MetricsAddress   = "METRICS_ADDRESS"
+
+	PremiumExtraCharacters = "PREMIUM_EXTRA_CHARACTERS"
+	CommandRate            = "COMMAND_RATE"
+	PremiumCommandRate     = "PREMIUM_COMMAND_RATE"
+	PremiumReservedSlots   = "PREMIUM_RESERVED_SLOTS"
+
+	LevelingMode = "LEVELING_MODE"
+	PvPMode      = "PVP_MODE"
+
+	NewbieLevel    = "NEWBIE_LEVEL"
+	NewbieLevelGap = "NEWBIE_LEVEL_GAP"
+
+	DeathExperienceLoss = "DEATH_EXPERIENCE_LOSS"
+	DeathGoldLoss       = "DEATH_GOLD_LOSS"
+	DeathCorpses        = "DEATH_CORPSES"
+	CorpseDecay         = "CORPSE_DECAY"
+
+	RandomSeed = "RANDOM_SEED"
+
+	ExperienceRate = "EXPERIENCE_RATE"
+	GoldRate       = "GOLD_RATE"
 )
 
 func (c *Config) GetValue(key string) string {
 	return c.cfgProvider.GetValue(key)
 }
 
+// GetString returns the value for key, or defaultValue when the key is unset.
+func (c *Config) GetString(key string, defaultValue string) string {
+	if value := c.GetValue(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+// GetInt returns the value for key parsed as an integer, or defaultValue when
+// the key is unset or not a valid integer.
+func (c *Config) GetInt(key string, defaultValue int) int {
+	value := c.GetValue(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		return defaultValue
+	}
+	return parsed
+}
+
+// GetDuration returns the value for key parsed with time.ParseDuration (e.g.
+// "15m"), or defaultValue when the key is unset or invalid.
+func (c *Config) GetDuration(key string, defaultValue time.Duration) time.Duration {
+	value := c.GetValue(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	parsed, err := time.ParseDuration(value)
+	if err != nil {
+		return defaultValue
+	}
+	return parsed
+}
+
+// GetBool returns the value for key parsed with strconv.ParseBool (e.g.
+// "true" or "0"), or defaultValue when the key is unset or invalid.
+func (c *Config) GetBool(key string, defaultValue bool) bool {
+	value := c.GetValue(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return defaultValue
+	}
+	return parsed
+}
+
 type ConfigProvider interface {
 	GetValue(key string) string
 }
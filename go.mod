@@ -10,4 +10,6 @@ require (
 	github.com/lib/pq v1.10.9
 )
 
-require golang.org/x/crypto v0.42.0 // indirect
+require golang.org/x/crypto v0.42.0
+
+require golang.org/x/text v0.29.0
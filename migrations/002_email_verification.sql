@@ -0,0 +1,7 @@
+-- Email verification for player accounts
+
+ALTER TABLE players ADD COLUMN email_verified BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE players ADD COLUMN verification_code VARCHAR(64);
+
+-- Accounts created before verification existed are treated as verified
+UPDATE players SET email_verified = TRUE;
@@ -0,0 +1,5 @@
+-- Staff roles for player accounts
+
+ALTER TABLE players ADD COLUMN role INTEGER NOT NULL DEFAULT 0; -- 0=Player, 1=Moderator, 2=Admin
+
+UPDATE players SET role = 2 WHERE username = 'admin';
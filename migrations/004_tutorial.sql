@@ -0,0 +1,3 @@
+-- Onboarding tutorial progress for characters
+
+ALTER TABLE characters ADD COLUMN tutorial_step INTEGER NOT NULL DEFAULT 0; -- 0 = finished or skipped
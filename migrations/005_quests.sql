@@ -0,0 +1,4 @@
+-- Character gold and quest journal
+
+ALTER TABLE characters ADD COLUMN gold INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE characters ADD COLUMN quests JSONB NOT NULL DEFAULT '{}';
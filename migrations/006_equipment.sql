@@ -0,0 +1,3 @@
+-- Equipped items, stored as a map of slot to item instance ID
+
+ALTER TABLE characters ADD COLUMN equipment JSONB NOT NULL DEFAULT '{}';
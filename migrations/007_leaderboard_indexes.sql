@@ -0,0 +1,5 @@
+-- Indexes backing the character leaderboards
+
+CREATE INDEX idx_characters_level ON characters(level DESC, experience DESC);
+CREATE INDEX idx_characters_kill_count ON characters(kill_count DESC);
+CREATE INDEX idx_characters_play_time ON characters(play_time DESC);
@@ -0,0 +1,3 @@
+-- Case-insensitive character name lookups
+
+CREATE INDEX idx_characters_name_lower ON characters(LOWER(name));
@@ -0,0 +1,12 @@
+-- Login audit history for accounts
+
+CREATE TABLE login_history (
+    id BIGSERIAL PRIMARY KEY,
+    player_id UUID NOT NULL REFERENCES players(id) ON DELETE CASCADE,
+    ip_address VARCHAR(64) NOT NULL DEFAULT '',
+    success BOOLEAN NOT NULL,
+    reason VARCHAR(64) NOT NULL DEFAULT '', -- e.g. bad_password, locked
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_login_history_player ON login_history(player_id, created_at DESC);
@@ -0,0 +1,3 @@
+-- Items lying in a room are owned by the room's ID, which is not a UUID
+
+ALTER TABLE item_instances ALTER COLUMN owner_id TYPE VARCHAR(100) USING owner_id::text;
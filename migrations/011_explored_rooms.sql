@@ -0,0 +1,3 @@
+-- Rooms each character has visited, stored as a map of room ID to true
+
+ALTER TABLE characters ADD COLUMN explored JSONB NOT NULL DEFAULT '{}';
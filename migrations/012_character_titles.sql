@@ -0,0 +1,3 @@
+-- Titles shown after character names, such as "the Brave"
+
+ALTER TABLE characters ADD COLUMN title VARCHAR(40) NOT NULL DEFAULT '';
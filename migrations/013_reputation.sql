@@ -0,0 +1,3 @@
+-- Reputation with each faction, stored as a map of faction ID to reputation
+
+ALTER TABLE characters ADD COLUMN reputation JSONB NOT NULL DEFAULT '{}';
@@ -0,0 +1,3 @@
+-- Practice sessions left to spend with trainers
+
+ALTER TABLE characters ADD COLUMN practices INTEGER NOT NULL DEFAULT 5;
@@ -0,0 +1,3 @@
+-- Whether each character has chosen to fight other players
+
+ALTER TABLE characters ADD COLUMN pvp BOOLEAN NOT NULL DEFAULT FALSE;
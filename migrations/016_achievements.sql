@@ -0,0 +1,3 @@
+-- Each character's achievement progress and the achievements they have earned
+
+ALTER TABLE characters ADD COLUMN achievements JSONB NOT NULL DEFAULT '{}';
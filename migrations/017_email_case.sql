@@ -0,0 +1,9 @@
+-- Emails are compared without regard to case. Lowercase the stored ones
+-- where that clashes with no other account, and index them for lookups.
+
+UPDATE players p SET email = LOWER(TRIM(p.email))
+WHERE p.email <> LOWER(TRIM(p.email)) AND NOT EXISTS (
+    SELECT 1 FROM players o
+    WHERE o.id <> p.id AND LOWER(TRIM(o.email)) = LOWER(TRIM(p.email)));
+
+CREATE INDEX idx_players_email_lower ON players (LOWER(email));
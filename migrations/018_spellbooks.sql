@@ -0,0 +1,4 @@
+-- Each character's spellbook: the spells they know and the castings they
+-- have prepared
+
+ALTER TABLE characters ADD COLUMN spellbook JSONB NOT NULL DEFAULT '{}';
@@ -0,0 +1,4 @@
+-- Attribute points characters have earned by levelling and not yet spent
+-- raising their stats with trainers
+
+ALTER TABLE characters ADD COLUMN stat_points INTEGER NOT NULL DEFAULT 0;
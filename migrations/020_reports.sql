@@ -0,0 +1,20 @@
+-- Players' reports about other players, for moderators to review. The
+-- characters' names are kept with their IDs so reports outlive renames and
+-- deletions.
+
+CREATE TABLE reports (
+    id BIGSERIAL PRIMARY KEY,
+    reporter_id UUID NOT NULL,
+    reporter_name VARCHAR(50) NOT NULL,
+    target_id UUID NOT NULL,
+    target_name VARCHAR(50) NOT NULL,
+    reason TEXT NOT NULL,
+    room_id VARCHAR(100) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    resolved_at TIMESTAMP WITH TIME ZONE, -- NULL while the report is open
+    resolved_by VARCHAR(50) NOT NULL DEFAULT '',
+    resolution TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX idx_reports_open ON reports(created_at) WHERE resolved_at IS NULL;
+CREATE INDEX idx_reports_reporter ON reports(reporter_id, created_at DESC);
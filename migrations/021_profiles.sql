@@ -0,0 +1,3 @@
+-- The short public biography players write for others to read with finger
+
+ALTER TABLE characters ADD COLUMN profile TEXT NOT NULL DEFAULT '';
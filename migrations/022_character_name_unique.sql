@@ -0,0 +1,14 @@
+-- Character names are unique without regard to case, so no one can pass
+-- for another by changing the case of their name. The oldest character
+-- with each name keeps it; any later ones that differ only in case get a
+-- suffix made from their ID, for an administrator to sort out with them.
+
+UPDATE characters c
+SET name = c.name || '-' || TRANSLATE(SUBSTR(MD5(c.id::TEXT), 1, 6), '0123456789', 'ghijklmnop')
+WHERE EXISTS (
+    SELECT 1 FROM characters o
+    WHERE LOWER(o.name) = LOWER(c.name)
+      AND (COALESCE(o.created_at, 'epoch'), o.id) < (COALESCE(c.created_at, 'epoch'), c.id));
+
+DROP INDEX idx_characters_name_lower;
+CREATE UNIQUE INDEX idx_characters_name_lower ON characters(LOWER(name));
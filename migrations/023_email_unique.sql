@@ -0,0 +1,20 @@
+-- Emails are unique without regard to case. Accounts whose emails differ
+-- only in case are left by 017 for an administrator to resolve, as only the
+-- players can say which address is theirs, so this stops until they are.
+
+DO $$
+DECLARE
+    clashes TEXT;
+BEGIN
+    SELECT STRING_AGG(email, ', ') INTO clashes FROM (
+        SELECT LOWER(TRIM(email)) AS email FROM players
+        GROUP BY LOWER(TRIM(email)) HAVING COUNT(*) > 1) c;
+    IF clashes IS NOT NULL THEN
+        RAISE EXCEPTION 'accounts share these emails in different cases, change all but one and migrate again: %', clashes;
+    END IF;
+END $$;
+
+UPDATE players SET email = LOWER(TRIM(email)) WHERE email <> LOWER(TRIM(email));
+
+DROP INDEX idx_players_email_lower;
+CREATE UNIQUE INDEX idx_players_email_lower ON players (LOWER(email));
@@ -0,0 +1,10 @@
+// Package migrations embeds the numbered SQL files that build the DungeoGo
+// schema, so the server and the tests apply exactly the same steps.
+package migrations
+
+import "embed"
+
+// Files holds every NNN_name.sql migration in this directory.
+//
+//go:embed *.sql
+var Files embed.FS
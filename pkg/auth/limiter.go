@@ -0,0 +1,132 @@
+package auth
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	DefaultMaxLoginAttempts = 5
+	DefaultAttemptWindow    = 10 * time.Minute
+	DefaultLockoutDuration  = 15 * time.Minute
+)
+
+// LoginLimiter tracks failed login attempts per key (an account ID or a
+// remote IP) in memory and locks a key out once it accumulates too many
+// failures inside the attempt window.
+type LoginLimiter struct {
+	maxAttempts int
+	window      time.Duration
+	lockout     time.Duration
+	entries     map[string]*attemptRecord
+	mutex       sync.Mutex
+	now         func() time.Time
+}
+
+type attemptRecord struct {
+	failures    []time.Time
+	lockedUntil time.Time
+}
+
+func NewLoginLimiter(maxAttempts int, window, lockout time.Duration) *LoginLimiter {
+	if maxAttempts <= 0 {
+		maxAttempts = DefaultMaxLoginAttempts
+	}
+	if window <= 0 {
+		window = DefaultAttemptWindow
+	}
+	if lockout <= 0 {
+		lockout = DefaultLockoutDuration
+	}
+
+	return &LoginLimiter{
+		maxAttempts: maxAttempts,
+		window:      window,
+		lockout:     lockout,
+		entries:     make(map[string]*attemptRecord),
+		now:         time.Now,
+	}
+}
+
+func DefaultLoginLimiter() *LoginLimiter {
+	return NewLoginLimiter(DefaultMaxLoginAttempts, DefaultAttemptWindow, DefaultLockoutDuration)
+}
+
+// IsLocked reports whether any of the keys is currently locked out, and if so
+// how long until the longest lockout expires.
+func (l *LoginLimiter) IsLocked(keys ...string) (bool, time.Duration) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	now := l.now()
+	var remaining time.Duration
+	for _, key := range keys {
+		record, exists := l.entries[key]
+		if !exists {
+			continue
+		}
+		if wait := record.lockedUntil.Sub(now); wait > remaining {
+			remaining = wait
+		}
+	}
+
+	return remaining > 0, remaining
+}
+
+// RecordFailure registers a failed attempt against each key, locking out any
+// key that reaches the attempt limit within the window.
+func (l *LoginLimiter) RecordFailure(keys ...string) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	now := l.now()
+	for _, key := range keys {
+		record, exists := l.entries[key]
+		if !exists {
+			record = &attemptRecord{}
+			l.entries[key] = record
+		}
+
+		record.failures = pruneBefore(record.failures, now.Add(-l.window))
+		record.failures = append(record.failures, now)
+
+		if len(record.failures) >= l.maxAttempts {
+			record.lockedUntil = now.Add(l.lockout)
+			record.failures = nil
+		}
+	}
+
+	l.cleanup(now)
+}
+
+// Reset clears the failure history for the given keys, e.g. after a
+// successful login.
+func (l *LoginLimiter) Reset(keys ...string) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	for _, key := range keys {
+		delete(l.entries, key)
+	}
+}
+
+// cleanup drops records with no recent failures and no active lockout so the
+// map doesn't grow without bound.
+func (l *LoginLimiter) cleanup(now time.Time) {
+	for key, record := range l.entries {
+		record.failures = pruneBefore(record.failures, now.Add(-l.window))
+		if len(record.failures) == 0 && !record.lockedUntil.After(now) {
+			delete(l.entries, key)
+		}
+	}
+}
+
+func pruneBefore(times []time.Time, cutoff time.Time) []time.Time {
+	kept := times[:0]
+	for _, t := range times {
+		if t.After(cutoff) {
+			kept = append(kept, t)
+		}
+	}
+	return kept
+}
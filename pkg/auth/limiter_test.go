@@ -0,0 +1,91 @@
+package auth
+
+import (
+	"testing"
+	"time"
+)
+
+func newTestLimiter(start time.Time) (*LoginLimiter, *time.Time) {
+	current := start
+	limiter := NewLoginLimiter(3, time.Minute, 5*time.Minute)
+	limiter.now = func() time.Time { return current }
+	return limiter, &current
+}
+
+func TestLoginLimiter_LocksAfterMaxAttempts(t *testing.T) {
+	limiter, _ := newTestLimiter(time.Now())
+
+	for i := 0; i < 2; i++ {
+		limiter.RecordFailure("account:1")
+		if locked, _ := limiter.IsLocked("account:1"); locked {
+			t.Fatalf("Expected account to remain unlocked after %d failures", i+1)
+		}
+	}
+
+	limiter.RecordFailure("account:1")
+	locked, remaining := limiter.IsLocked("account:1")
+	if !locked {
+		t.Fatalf("Expected account to be locked after 3 failures")
+	}
+
+	if remaining != 5*time.Minute {
+		t.Errorf("Expected 5m lockout, got %v", remaining)
+	}
+}
+
+func TestLoginLimiter_WindowExpiry(t *testing.T) {
+	limiter, now := newTestLimiter(time.Now())
+
+	limiter.RecordFailure("ip:10.0.0.1")
+	limiter.RecordFailure("ip:10.0.0.1")
+
+	// Failures outside the window no longer count
+	*now = now.Add(2 * time.Minute)
+	limiter.RecordFailure("ip:10.0.0.1")
+
+	if locked, _ := limiter.IsLocked("ip:10.0.0.1"); locked {
+		t.Errorf("Expected stale failures to expire from the window")
+	}
+}
+
+func TestLoginLimiter_LockoutExpires(t *testing.T) {
+	limiter, now := newTestLimiter(time.Now())
+
+	for i := 0; i < 3; i++ {
+		limiter.RecordFailure("account:1")
+	}
+
+	*now = now.Add(6 * time.Minute)
+	if locked, _ := limiter.IsLocked("account:1"); locked {
+		t.Errorf("Expected lockout to expire")
+	}
+}
+
+func TestLoginLimiter_AnyKeyLocks(t *testing.T) {
+	limiter, _ := newTestLimiter(time.Now())
+
+	for i := 0; i < 3; i++ {
+		limiter.RecordFailure("ip:10.0.0.1")
+	}
+
+	if locked, _ := limiter.IsLocked("account:2", "ip:10.0.0.1"); !locked {
+		t.Errorf("Expected a locked IP to block any account from it")
+	}
+
+	if locked, _ := limiter.IsLocked("account:2", "ip:10.0.0.2"); locked {
+		t.Errorf("Expected unrelated keys to be unaffected")
+	}
+}
+
+func TestLoginLimiter_Reset(t *testing.T) {
+	limiter, _ := newTestLimiter(time.Now())
+
+	limiter.RecordFailure("account:1")
+	limiter.RecordFailure("account:1")
+	limiter.Reset("account:1")
+	limiter.RecordFailure("account:1")
+
+	if locked, _ := limiter.IsLocked("account:1"); locked {
+		t.Errorf("Expected reset to clear previous failures")
+	}
+}
@@ -0,0 +1,139 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	ErrPasswordTooShort    = errors.New("password too short")
+	ErrPasswordTooSimple   = errors.New("password too simple")
+	ErrPasswordCommon      = errors.New("password too common")
+	ErrPasswordMatchesName = errors.New("password matches username")
+)
+
+const (
+	DefaultMinPasswordLength = 8
+	DefaultMinCharClasses    = 2
+)
+
+// commonPasswords is a small blocklist of passwords that appear at the top of
+// every breach corpus. Entries are compared case-insensitively.
+var commonPasswords = []string{
+	"password", "password1", "password123", "passw0rd", "123456", "1234567",
+	"12345678", "123456789", "1234567890", "qwerty", "qwerty123", "abc123",
+	"111111", "123123", "letmein", "welcome", "welcome1", "iloveyou",
+	"admin", "admin123", "monkey", "dragon", "master", "sunshine",
+	"football", "baseball", "trustno1", "changeme", "dungeon", "dungeogo",
+}
+
+// PasswordPolicy describes the rules a new password must satisfy and the
+// bcrypt cost used to hash it.
+type PasswordPolicy struct {
+	MinLength      int
+	MinCharClasses int
+	BcryptCost     int
+	blocklist      map[string]struct{}
+}
+
+func NewPasswordPolicy(minLength, minCharClasses, bcryptCost int) *PasswordPolicy {
+	if minLength <= 0 {
+		minLength = DefaultMinPasswordLength
+	}
+	if minCharClasses < 1 {
+		minCharClasses = 1
+	}
+	if minCharClasses > 4 {
+		minCharClasses = 4
+	}
+	if bcryptCost < bcrypt.MinCost || bcryptCost > bcrypt.MaxCost {
+		bcryptCost = bcrypt.DefaultCost
+	}
+
+	blocklist := make(map[string]struct{}, len(commonPasswords))
+	for _, pw := range commonPasswords {
+		blocklist[pw] = struct{}{}
+	}
+
+	return &PasswordPolicy{
+		MinLength:      minLength,
+		MinCharClasses: minCharClasses,
+		BcryptCost:     bcryptCost,
+		blocklist:      blocklist,
+	}
+}
+
+func DefaultPasswordPolicy() *PasswordPolicy {
+	return NewPasswordPolicy(DefaultMinPasswordLength, DefaultMinCharClasses, bcrypt.DefaultCost)
+}
+
+// Validate checks a candidate password for the given username. The returned
+// error wraps one of the ErrPassword* values and its message is suitable for
+// showing to the player as guidance.
+func (p *PasswordPolicy) Validate(password, username string) error {
+	if len(password) < p.MinLength {
+		return fmt.Errorf("%w: password must be at least %d characters long", ErrPasswordTooShort, p.MinLength)
+	}
+
+	lowered := strings.ToLower(password)
+	if username != "" && strings.Contains(lowered, strings.ToLower(username)) {
+		return fmt.Errorf("%w: password must not contain your username", ErrPasswordMatchesName)
+	}
+
+	if _, blocked := p.blocklist[lowered]; blocked {
+		return fmt.Errorf("%w: that password is too common, please choose something less guessable", ErrPasswordCommon)
+	}
+
+	if classes := countCharClasses(password); classes < p.MinCharClasses {
+		return fmt.Errorf("%w: password must mix at least %d of: lowercase letters, uppercase letters, digits, symbols",
+			ErrPasswordTooSimple, p.MinCharClasses)
+	}
+
+	return nil
+}
+
+// Describe returns a one-line summary of the policy for prompts
+func (p *PasswordPolicy) Describe() string {
+	if p.MinCharClasses <= 1 {
+		return fmt.Sprintf("minimum %d characters", p.MinLength)
+	}
+	return fmt.Sprintf("minimum %d characters, mixing at least %d of lowercase, uppercase, digits, symbols",
+		p.MinLength, p.MinCharClasses)
+}
+
+// Hash hashes the password with the policy's bcrypt cost
+func (p *PasswordPolicy) Hash(password string) (string, error) {
+	hashed, err := bcrypt.GenerateFromPassword([]byte(password), p.BcryptCost)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash password: %w", err)
+	}
+	return string(hashed), nil
+}
+
+func countCharClasses(password string) int {
+	var lower, upper, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			symbol = true
+		}
+	}
+
+	count := 0
+	for _, present := range []bool{lower, upper, digit, symbol} {
+		if present {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,80 @@
+package auth
+
+import (
+	"errors"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestPasswordPolicy_Validate(t *testing.T) {
+	policy := NewPasswordPolicy(8, 2, bcrypt.MinCost)
+
+	tests := []struct {
+		name     string
+		password string
+		username string
+		wantErr  error
+	}{
+		{"valid mixed", "Tr0ubadour", "bob", nil},
+		{"valid letters and symbols", "horse-battery", "bob", nil},
+		{"too short", "Ab1!", "bob", ErrPasswordTooShort},
+		{"single class", "abcdefghij", "bob", ErrPasswordTooSimple},
+		{"common password", "Password1", "bob", ErrPasswordCommon},
+		{"common case-insensitive", "QWERTY123", "bob", ErrPasswordCommon},
+		{"contains username", "MyNameIsBob1", "bob", ErrPasswordMatchesName},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := policy.Validate(tt.password, tt.username)
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Errorf("Expected password to be accepted, got: %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestNewPasswordPolicy_Defaults(t *testing.T) {
+	policy := NewPasswordPolicy(0, 0, 1000)
+
+	if policy.MinLength != DefaultMinPasswordLength {
+		t.Errorf("Expected min length %d, got %d", DefaultMinPasswordLength, policy.MinLength)
+	}
+
+	if policy.MinCharClasses != 1 {
+		t.Errorf("Expected min char classes to be clamped to 1, got %d", policy.MinCharClasses)
+	}
+
+	if policy.BcryptCost != bcrypt.DefaultCost {
+		t.Errorf("Expected invalid bcrypt cost to fall back to %d, got %d", bcrypt.DefaultCost, policy.BcryptCost)
+	}
+}
+
+func TestPasswordPolicy_Hash(t *testing.T) {
+	policy := NewPasswordPolicy(8, 2, bcrypt.MinCost)
+
+	hash, err := policy.Hash("Tr0ubadour")
+	if err != nil {
+		t.Fatalf("Failed to hash password: %v", err)
+	}
+
+	cost, err := bcrypt.Cost([]byte(hash))
+	if err != nil {
+		t.Fatalf("Failed to read bcrypt cost: %v", err)
+	}
+
+	if cost != bcrypt.MinCost {
+		t.Errorf("Expected bcrypt cost %d, got %d", bcrypt.MinCost, cost)
+	}
+
+	if bcrypt.CompareHashAndPassword([]byte(hash), []byte("Tr0ubadour")) != nil {
+		t.Errorf("Expected hash to verify against original password")
+	}
+}
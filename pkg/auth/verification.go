@@ -0,0 +1,50 @@
+package auth
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// VerificationMode controls how unverified email addresses are treated
+type VerificationMode int
+
+const (
+	// VerificationOff skips email verification entirely
+	VerificationOff VerificationMode = iota
+	// VerificationOptional sends a code and flags the account until verified
+	VerificationOptional
+	// VerificationRequired keeps unverified accounts out of the game world
+	VerificationRequired
+)
+
+const verificationCodeDigits = 6
+
+func ParseVerificationMode(name string) (VerificationMode, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "off", "disabled":
+		return VerificationOff, nil
+	case "optional":
+		return VerificationOptional, nil
+	case "required":
+		return VerificationRequired, nil
+	default:
+		return VerificationOff, fmt.Errorf("unknown email verification mode: %s", name)
+	}
+}
+
+// GenerateVerificationCode returns a random numeric code for email verification
+func GenerateVerificationCode() (string, error) {
+	max := big.NewInt(1)
+	for i := 0; i < verificationCodeDigits; i++ {
+		max.Mul(max, big.NewInt(10))
+	}
+
+	n, err := rand.Int(rand.Reader, max)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate verification code: %w", err)
+	}
+
+	return fmt.Sprintf("%0*d", verificationCodeDigits, n), nil
+}
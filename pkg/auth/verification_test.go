@@ -0,0 +1,46 @@
+package auth
+
+import (
+	"testing"
+)
+
+func TestGenerateVerificationCode(t *testing.T) {
+	code, err := GenerateVerificationCode()
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+
+	if len(code) != verificationCodeDigits {
+		t.Errorf("Expected %d digit code, got %q", verificationCodeDigits, code)
+	}
+
+	for _, r := range code {
+		if r < '0' || r > '9' {
+			t.Errorf("Expected numeric code, got %q", code)
+			break
+		}
+	}
+}
+
+func TestParseVerificationMode(t *testing.T) {
+	tests := map[string]VerificationMode{
+		"":         VerificationOff,
+		"off":      VerificationOff,
+		"Optional": VerificationOptional,
+		"required": VerificationRequired,
+	}
+
+	for input, expected := range tests {
+		mode, err := ParseVerificationMode(input)
+		if err != nil {
+			t.Errorf("Unexpected error parsing %q: %v", input, err)
+		}
+		if mode != expected {
+			t.Errorf("Expected %q to parse as %v, got %v", input, expected, mode)
+		}
+	}
+
+	if _, err := ParseVerificationMode("sometimes"); err == nil {
+		t.Errorf("Expected error for unknown mode")
+	}
+}
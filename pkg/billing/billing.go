@@ -0,0 +1,24 @@
+// Package billing takes payment for premium subscriptions.
+package billing
+
+import (
+	"errors"
+
+	"github.com/elidor/dungeogo/pkg/game/player"
+)
+
+var ErrPaymentDeclined = errors.New("payment declined")
+
+// Processor charges a player for months of premium. A nil error means the
+// payment went through.
+type Processor interface {
+	Charge(p *player.Player, months int) error
+}
+
+// NoopProcessor approves every payment without charging anything, for tests.
+// A server without a real payment provider sells no premium at all.
+type NoopProcessor struct{}
+
+func (NoopProcessor) Charge(p *player.Player, months int) error {
+	return nil
+}
@@ -0,0 +1,166 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+	"github.com/elidor/dungeogo/pkg/game/combat"
+	"github.com/elidor/dungeogo/pkg/game/cooldown"
+	"github.com/elidor/dungeogo/pkg/game/damage"
+	"github.com/elidor/dungeogo/pkg/game/world"
+)
+
+// abilityEffect rolls the damage a class ability does and its type
+type abilityEffect func(h *UseHandler, char *character.Character, ability *character.ClassAbility) (int, damage.Type)
+
+// abilityEffects carry out each type of class ability, so any ability a
+// class defines can be used without code of its own
+var abilityEffects = map[character.AbilityType]abilityEffect{
+	character.AbilityCombat: weaponAbility,
+	character.AbilityMagic:  magicAbility,
+}
+
+// weaponAbility is a blow with the wielded weapon that does the ability's
+// damage on top
+func weaponAbility(h *UseHandler, char *character.Character, ability *character.ClassAbility) (int, damage.Type) {
+	return combat.Damage(h.killer.strike(char), h.roll) + ability.Roll(h.roll), damage.Physical
+}
+
+// magicAbility does the ability's damage of its element
+func magicAbility(h *UseHandler, char *character.Character, ability *character.ClassAbility) (int, damage.Type) {
+	return ability.Roll(h.roll), ability.Element
+}
+
+// abilityCooldownKey returns the cooldown using ability is kept under. A
+// magic ability shares the cooldown of the spell with its ID, such as Magic
+// Missile, so using one and casting the other can't skip the wait.
+func abilityCooldownKey(ability *character.ClassAbility) string {
+	if ability.Type == character.AbilityMagic {
+		return "spell:" + ability.ID
+	}
+	return "ability:" + ability.ID
+}
+
+// AbilitiesHandler lists the character's class abilities.
+type AbilitiesHandler struct{}
+
+func (h *AbilitiesHandler) Execute(ctx *HandlerContext, cmd *Command) (*CommandResult, error) {
+	char := ctx.Character
+	if char == nil || char.Class == nil {
+		return Reply("Error retrieving character information."), nil
+	}
+	if len(char.Class.Abilities) == 0 {
+		return Reply("Your class has no abilities."), nil
+	}
+
+	response := []string{fmt.Sprintf("%s abilities:", char.Class.Name)}
+	for _, ability := range char.Class.Abilities {
+		var details string
+		switch {
+		case char.Level < ability.Level:
+			details = fmt.Sprintf("level %d", ability.Level)
+		case ability.Passive:
+			details = "passive"
+		default:
+			details = fmt.Sprintf("%d mana, %s cooldown", ability.ManaCost, waitText(ability.CooldownDuration()))
+		}
+		response = append(response, fmt.Sprintf("  %-16s %-28s %s", ability.Name, details, ability.Description))
+	}
+	return Reply(response...), nil
+}
+
+// UseHandler uses one of the character's class abilities on an NPC in the
+// room, or on the foe they are fighting if none is named. The ability must
+// not be passive, and the character needs its level, its mana and for its
+// cooldown to have passed. Magic abilities fail where magic does.
+type UseHandler struct {
+	// killer settles fights the abilities start or finish
+	killer    *KillHandler
+	cooldowns *cooldown.Tracker
+	// roll returns a number from 0 to n-1
+	roll func(n int) int
+	now  func() time.Time
+}
+
+func (h *UseHandler) Execute(ctx *HandlerContext, cmd *Command) (*CommandResult, error) {
+	char := ctx.Character
+	if char == nil {
+		return Reply("Error retrieving character information."), nil
+	}
+
+	ability, targetName := parseAbility(char.Class, cmd.Args)
+	if ability == nil {
+		return Reply(fmt.Sprintf("You have no ability called '%s'.", strings.Join(cmd.Args, " "))), nil
+	}
+	effect, ok := abilityEffects[ability.Type]
+	if ability.Passive || !ok {
+		return Reply(fmt.Sprintf("%s works by itself; there is no need to use it.", ability.Name)), nil
+	}
+	if char.Level < ability.Level {
+		return Reply(fmt.Sprintf("You must reach level %d to use %s.", ability.Level, ability.Name)), nil
+	}
+	// use is a combat command, so the executor's check for magic commands
+	// doesn't cover it
+	if ability.Type == character.AbilityMagic && roomHas(ctx, world.FlagNoMagic) {
+		return Reply("Your magic fizzles and dies here."), nil
+	}
+	if wait := h.cooldowns.Remaining(char.ID, abilityCooldownKey(ability), h.now()); wait > 0 {
+		return Reply(fmt.Sprintf("You must wait %s before you can use %s again.", waitText(wait), ability.Name)), nil
+	}
+	if char.Stats == nil || char.Stats.Mana < ability.ManaCost {
+		return Reply(fmt.Sprintf("You don't have enough mana to use %s.", ability.Name)), nil
+	}
+
+	foe, other := h.killer.foe(ctx, targetName)
+	if other != nil {
+		return Reply(fmt.Sprintf("You can only use %s on creatures.", ability.Name)), nil
+	}
+	if foe == nil {
+		if targetName == "" {
+			return Reply(fmt.Sprintf("Use %s on whom?", ability.Name)), nil
+		}
+		return Reply(fmt.Sprintf("You don't see %s here.", targetName)), nil
+	}
+
+	char.Stats.Mana -= ability.ManaCost
+	h.cooldowns.Start(char.ID, abilityCooldownKey(ability), ability.CooldownDuration(), h.now())
+	response := h.killer.stopFollowing(char)
+	killed := false
+	if ability.Lands(h.roll) {
+		amount, kind := effect(h, char, ability)
+		dealt := foe.Template.Resist(amount, kind)
+		response = append(response, fmt.Sprintf("Your %s hits %s for %s.", ability.Name, foe.Template.Name, damage.Describe(dealt, kind)))
+		dead, err := h.killer.npcs.Damage(foe.ID, dealt)
+		if err != nil {
+			return Reply("Error using ability."), nil
+		}
+		killed = dead
+	} else {
+		response = append(response, fmt.Sprintf("Your %s misses %s.", ability.Name, foe.Template.Name))
+	}
+
+	if killed {
+		return h.killer.slay(ctx, foe, response)
+	}
+	h.killer.npcs.Engage(foe.ID, char.ID)
+	char.State = character.CharacterInCombat
+	return Reply(response...).
+		ToRoom("", fmt.Sprintf("%s uses %s on %s.", ctx.ActorName(), ability.Name, foe.Template.Name), char.ID), nil
+}
+
+// parseAbility splits args into the class ability they start with and the
+// name of the target after it. Ability names can be several words, so the
+// longest match wins.
+func parseAbility(class *character.Class, args []string) (*character.ClassAbility, string) {
+	if class == nil {
+		return nil, ""
+	}
+	for n := len(args); n > 0; n-- {
+		if ability, ok := class.FindAbility(strings.Join(args[:n], " ")); ok {
+			return ability, strings.Join(args[n:], " ")
+		}
+	}
+	return nil, ""
+}
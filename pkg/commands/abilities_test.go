@@ -0,0 +1,143 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+	"github.com/elidor/dungeogo/pkg/game/world"
+	"github.com/elidor/dungeogo/pkg/persistence/interfaces"
+)
+
+// newUseHandler returns the executor's use handler, rolling roll on a clock
+// the test controls
+func newUseHandler(t *testing.T, roll func(n int) int) (*UseHandler, *time.Time) {
+	executor, _ := newFightExecutor(t)
+	handler := executor.handlers["use"].(*UseHandler)
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	handler.roll = roll
+	handler.now = func() time.Time { return now }
+	return handler, &now
+}
+
+func useAbility(t *testing.T, handler *UseHandler, char *character.Character, args ...string) []string {
+	t.Helper()
+	result, err := handler.Execute(&HandlerContext{Character: char}, &Command{Verb: "use", Args: args})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return result.Messages
+}
+
+func TestPowerAttack(t *testing.T) {
+	handler, now := newUseHandler(t, func(n int) int { return 0 })
+	char := testCharacter("riverbank")
+	char.Stats.Strength = 10
+
+	// The lowest bare-handed blow does 1, and Power Attack adds 4
+	messages := useAbility(t, handler, char, "power", "attack", "goblin")
+	if messages[0] != "Your Power Attack hits a goblin for 5 damage." {
+		t.Fatalf("Expected a power attack, got %v", messages)
+	}
+	if char.State != character.CharacterInCombat {
+		t.Errorf("Expected the attack to start a fight")
+	}
+
+	*now = now.Add(2 * time.Second)
+	messages = useAbility(t, handler, char, "power_attack")
+	if messages[0] != "You must wait 4 seconds before you can use Power Attack again." {
+		t.Errorf("Expected Power Attack to be cooling down, got %v", messages)
+	}
+
+	// It trades accuracy for damage; with no target named it goes at the
+	// foe being fought
+	*now = now.Add(4 * time.Second)
+	handler.roll = func(n int) int { return n - 1 }
+	messages = useAbility(t, handler, char, "power", "attack")
+	if messages[0] != "Your Power Attack misses a goblin." {
+		t.Errorf("Expected Power Attack to miss, got %v", messages)
+	}
+}
+
+func TestMagicMissileAbility(t *testing.T) {
+	handler, now := newUseHandler(t, func(n int) int { return 0 })
+	char := testMage("riverbank")
+	mana := char.Stats.Mana
+
+	messages := useAbility(t, handler, char, "magic", "missile", "goblin")
+	if messages[0] != "Your Magic Missile hits a goblin for 4 arcane damage." {
+		t.Fatalf("Expected the missile to hit, got %v", messages)
+	}
+	if char.Stats.Mana != mana-5 {
+		t.Errorf("Expected Magic Missile to cost 5 mana, got %d", mana-char.Stats.Mana)
+	}
+
+	*now = now.Add(3 * time.Second)
+	char.Stats.Mana = 4
+	messages = useAbility(t, handler, char, "magic", "missile")
+	if messages[0] != "You don't have enough mana to use Magic Missile." {
+		t.Errorf("Expected to run out of mana, got %v", messages)
+	}
+}
+
+func TestMagicAbilityIsMagic(t *testing.T) {
+	handler, now := newUseHandler(t, func(n int) int { return 0 })
+	char := testMage("riverbank")
+
+	room := &interfaces.RoomState{ID: "riverbank", Flags: map[string]interface{}{world.FlagNoMagic: true}}
+	result, err := handler.Execute(&HandlerContext{Character: char, Room: room}, &Command{Verb: "use", Args: []string{"magic", "missile", "goblin"}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.Messages[0] != "Your magic fizzles and dies here." {
+		t.Errorf("Expected magic to fail in a nomagic room, got %v", result.Messages)
+	}
+	if messages := useAbility(t, handler, char, "power", "attack", "goblin"); strings.HasPrefix(messages[0], "Your magic") {
+		t.Errorf("Expected only magic abilities to be stopped, got %v", messages)
+	}
+
+	// The ability and the spell share a cooldown
+	handler.cooldowns.Start(char.ID, "spell:magic_missile", 3*time.Second, *now)
+	if messages := useAbility(t, handler, char, "magic", "missile", "goblin"); messages[0] != "You must wait 3 seconds before you can use Magic Missile again." {
+		t.Errorf("Expected casting the spell to hold back the ability, got %v", messages)
+	}
+}
+
+func TestUseAbilityRefusals(t *testing.T) {
+	handler, _ := newUseHandler(t, func(n int) int { return 0 })
+
+	warrior := testCharacter("riverbank")
+	warrior.Class.Abilities[0].Level = 5
+	if messages := useAbility(t, handler, warrior, "power", "attack", "goblin"); messages[0] != "You must reach level 5 to use Power Attack." {
+		t.Errorf("Expected Power Attack to need level 5, got %v", messages)
+	}
+	if messages := useAbility(t, handler, warrior, "magic", "missile"); messages[0] != "You have no ability called 'magic missile'." {
+		t.Errorf("Expected a warrior not to have Magic Missile, got %v", messages)
+	}
+
+	rogue := testCharacter("riverbank")
+	rogue.Class, _ = character.GetClassByID("rogue")
+	if messages := useAbility(t, handler, rogue, "sneak", "attack"); messages[0] != "Sneak Attack works by itself; there is no need to use it." {
+		t.Errorf("Expected Sneak Attack to be passive, got %v", messages)
+	}
+
+	mage := testMage("riverbank")
+	if messages := useAbility(t, handler, mage, "magic", "missile"); messages[0] != "Use Magic Missile on whom?" {
+		t.Errorf("Expected to be asked for a target, got %v", messages)
+	}
+}
+
+func TestAbilitiesList(t *testing.T) {
+	executor := NewExecutor(newMemoryRepos())
+	result, err := executor.handlers["abilities"].Execute(&HandlerContext{Character: testCharacter(character.DefaultStartRoomID)}, &Command{Verb: "abilities"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	output := strings.Join(result.Messages, "\n")
+	for _, want := range []string{"Warrior abilities:", "Power Attack", "0 mana, 6 seconds cooldown"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected %q in:\n%s", want, output)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package commands
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/elidor/dungeogo/pkg/game/achievement"
+	"github.com/elidor/dungeogo/pkg/game/character"
+	"github.com/elidor/dungeogo/pkg/game/event"
+	"github.com/elidor/dungeogo/pkg/game/items"
+	"github.com/elidor/dungeogo/pkg/persistence/interfaces"
+)
+
+// AchievementsHandler lists the achievements the character has earned and
+// their progress towards the rest.
+type AchievementsHandler struct{}
+
+func (h *AchievementsHandler) Execute(ctx *HandlerContext, cmd *Command) (*CommandResult, error) {
+	char := ctx.Character
+	if char == nil {
+		return Reply("Error retrieving character information."), nil
+	}
+	log := char.Achievements
+	if log == nil {
+		log = achievement.NewLog()
+	}
+
+	earned := []string{"Earned:"}
+	locked := []string{"Locked:"}
+	for _, a := range achievement.GetAllAchievements() {
+		if log.IsEarned(a.ID) {
+			earned = append(earned, fmt.Sprintf("  %-20s %s (%s)", a.Name, a.Description, log.Earned[a.ID].Format("2006-01-02")))
+			continue
+		}
+		locked = append(locked, fmt.Sprintf("  %-20s %s (%d/%d)", a.Name, a.Description, log.Progress(a), a.Count))
+	}
+	if len(earned) == 1 {
+		earned = append(earned, "  None yet.")
+	}
+	if len(locked) == 1 {
+		locked = append(locked, "  None. You have earned them all!")
+	}
+	return Reply(append(append([]string{"Achievements:"}, earned...), locked...)...), nil
+}
+
+// achievementTracker counts game events towards each character's
+// achievements and hands out the rewards for those they earn.
+type achievementTracker struct {
+	repoManager interfaces.RepositoryManager
+	factory     *items.ItemFactory
+	now         func() time.Time
+}
+
+// subscribe makes the tracker react to every event achievements count
+func (t *achievementTracker) subscribe(bus *event.Bus) {
+	bus.Subscribe(event.TypeKill, func(e event.Event) []string {
+		return t.record(e.Subject(), achievement.TriggerKill, e.(event.Kill).TemplateID, 1)
+	})
+	bus.Subscribe(event.TypeLevelUp, func(e event.Event) []string {
+		return t.record(e.Subject(), achievement.TriggerLevel, "", e.(event.LevelUp).Level)
+	})
+	bus.Subscribe(event.TypeCraft, func(e event.Event) []string {
+		return t.record(e.Subject(), achievement.TriggerCraft, e.(event.Craft).TemplateID, 1)
+	})
+	bus.Subscribe(event.TypeDeath, func(e event.Event) []string {
+		return t.record(e.Subject(), achievement.TriggerDeath, "", 1)
+	})
+}
+
+func (t *achievementTracker) record(char *character.Character, trigger achievement.Trigger, target string, value int) []string {
+	if char.Achievements == nil {
+		char.Achievements = achievement.NewLog()
+	}
+	var messages []string
+	for _, a := range char.Achievements.Record(trigger, target, value, t.now()) {
+		messages = append(messages, fmt.Sprintf("Achievement unlocked: %s!", a.Name))
+		messages = append(messages, t.reward(char, a.Reward)...)
+	}
+	return messages
+}
+
+// reward gives the character reward and returns the lines telling them
+// about it. A title is only given to a character without one, so a title
+// they chose is never replaced. The engine saves the character's gold and
+// title once the command has run; items are saved as they are given.
+func (t *achievementTracker) reward(char *character.Character, reward achievement.Reward) []string {
+	var messages []string
+	switch {
+	case reward.Title == "" || character.ValidateTitle(reward.Title) != nil:
+	case char.Title != "":
+		messages = append(messages, fmt.Sprintf("You have earned the title '%s'; use 'title %s' to take it.", reward.Title, reward.Title))
+	case char.SetTitle(reward.Title) == nil:
+		messages = append(messages, fmt.Sprintf("You are now known as %s.", char.DisplayName()))
+	}
+	if reward.Gold > 0 {
+		char.Gold += reward.Gold
+		messages = append(messages, fmt.Sprintf("You receive %d gold.", reward.Gold))
+	}
+	for _, templateID := range reward.Items {
+		item, err := giveItem(t.repoManager, t.factory, char, templateID)
+		if err != nil {
+			messages = append(messages, "Error giving achievement reward.")
+			continue
+		}
+		messages = append(messages, fmt.Sprintf("You receive %s.", itemName(t.factory, item)))
+	}
+	return messages
+}
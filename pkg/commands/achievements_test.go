@@ -0,0 +1,97 @@
+package commands
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/elidor/dungeogo/pkg/game/achievement"
+	"github.com/elidor/dungeogo/pkg/game/character"
+)
+
+func TestFirstKillEarnsAchievement(t *testing.T) {
+	executor, _ := newFightExecutor(t)
+	char := testCharacter("riverbank")
+
+	messages := killUntilDead(t, executor, &HandlerContext{Character: char}, "goblin")
+	if !slices.Contains(messages, "Achievement unlocked: First Blood!") {
+		t.Fatalf("Expected First Blood to be announced, got %v", messages)
+	}
+	if !slices.Contains(messages, "You receive 10 gold.") {
+		t.Errorf("Expected the gold reward, got %v", messages)
+	}
+	if !char.Achievements.IsEarned("first_blood") {
+		t.Errorf("Expected First Blood to be recorded as earned")
+	}
+
+	// A second kill earns nothing new
+	messages = killUntilDead(t, executor, &HandlerContext{Character: char}, "goblin")
+	for _, line := range messages {
+		if strings.HasPrefix(line, "Achievement unlocked") {
+			t.Errorf("Expected no second unlock, got %q", line)
+		}
+	}
+}
+
+func TestAchievementRewards(t *testing.T) {
+	repos := newMemoryRepos()
+	executor := NewExecutor(repos)
+	tracker := &achievementTracker{repoManager: repos, factory: executor.Items(), now: time.Now}
+	char := testCharacter(character.DefaultStartRoomID)
+
+	messages := tracker.record(char, achievement.TriggerLevel, "", 10)
+	expected := []string{"Achievement unlocked: Seasoned Adventurer!", "You receive 100 gold.", "You receive Health Potion."}
+	if strings.Join(messages, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Expected %v, got %v", expected, messages)
+	}
+	carried, _ := repos.items.GetCarriedItems(char.ID)
+	if len(carried) != 1 || carried[0].TemplateID != "health_potion" {
+		t.Errorf("Expected a health potion to be given, got %v", carried)
+	}
+
+	for i := 0; i < 25; i++ {
+		messages = tracker.record(char, achievement.TriggerKill, "goblin", 1)
+	}
+	if char.Title != "the Goblin Bane" {
+		t.Errorf("Expected the Goblin Bane title, got %q", char.Title)
+	}
+	if !slices.Contains(messages, "You are now known as Alice the Goblin Bane.") {
+		t.Errorf("Expected the new title announced, got %v", messages)
+	}
+
+	// A title the player chose is kept
+	char.Title = "the Brave"
+	messages = tracker.record(char, achievement.TriggerCraft, "iron_ingot", 100)
+	if char.Title != "the Brave" {
+		t.Errorf("Expected the chosen title to be kept, got %q", char.Title)
+	}
+	if !slices.Contains(messages, "You have earned the title 'the Artisan'; use 'title the Artisan' to take it.") {
+		t.Errorf("Expected to be told how to take the title, got %v", messages)
+	}
+}
+
+func TestAchievementsListsEarnedAndLocked(t *testing.T) {
+	char := testCharacter(character.DefaultStartRoomID)
+	earnedAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	char.Achievements.Record(achievement.TriggerKill, "rat", 1, earnedAt)
+	char.Achievements.Record(achievement.TriggerCraft, "iron_sword", 3, earnedAt)
+
+	result, err := (&AchievementsHandler{}).Execute(&HandlerContext{Character: char}, &Command{Verb: "achievements"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	output := strings.Join(result.Messages, "\n")
+	for _, want := range []string{
+		"First Blood          Kill your first foe (2024-03-01)",
+		"Artisan              Craft 100 items (3/100)",
+		"Goblin Bane          Kill 25 goblins (0/25)",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected %q in:\n%s", want, output)
+		}
+	}
+	if strings.Index(output, "First Blood") > strings.Index(output, "Locked:") {
+		t.Errorf("Expected First Blood listed as earned, got:\n%s", output)
+	}
+}
@@ -0,0 +1,189 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+	"github.com/elidor/dungeogo/pkg/game/combat"
+	"github.com/elidor/dungeogo/pkg/game/npc"
+	"github.com/elidor/dungeogo/pkg/game/world"
+	"github.com/elidor/dungeogo/pkg/persistence/interfaces"
+)
+
+// RoomFlagHandler shows the flags of the administrator's room, or changes
+// one of them while the server runs. Changes are saved with the room's state
+// and override how the room was built until reset.
+type RoomFlagHandler struct {
+	repoManager interfaces.RepositoryManager
+}
+
+func (h *RoomFlagHandler) Execute(ctx *HandlerContext, cmd *Command) (*CommandResult, error) {
+	char := ctx.Character
+	if char == nil {
+		return Reply("Error retrieving character information."), nil
+	}
+	account, err := h.repoManager.Players().GetPlayer(char.PlayerID)
+	if err != nil {
+		return Reply("Error retrieving account."), nil
+	}
+	if !account.IsAdmin() {
+		return Reply("Only administrators can change room flags."), nil
+	}
+	if ctx.Room == nil {
+		return Reply("Error retrieving room information."), nil
+	}
+
+	if len(cmd.Args) == 0 {
+		return Reply(h.listFlags(ctx)...), nil
+	}
+
+	flag := strings.ToLower(cmd.Args[0])
+	if !world.IsFlag(flag) {
+		return Reply(fmt.Sprintf("Unknown room flag '%s'. Flags: %s.", flag, strings.Join(world.FlagNames(), ", "))), nil
+	}
+	if len(cmd.Args) < 2 {
+		return Reply("Usage: roomflag [<flag> on|off|reset]"), nil
+	}
+
+	if ctx.Room.Flags == nil {
+		ctx.Room.Flags = make(map[string]interface{})
+	}
+	switch strings.ToLower(cmd.Args[1]) {
+	case "on":
+		ctx.Room.Flags[flag] = true
+	case "off":
+		ctx.Room.Flags[flag] = false
+	case "reset":
+		delete(ctx.Room.Flags, flag)
+	default:
+		return Reply("Usage: roomflag [<flag> on|off|reset]"), nil
+	}
+	if err := h.repoManager.World().SaveRoomState(ctx.Room.ID, ctx.Room); err != nil {
+		return Reply("Error saving room."), nil
+	}
+	return Reply(fmt.Sprintf("The %s flag is now %s here.", flag, onOff(roomHas(ctx, flag)))), nil
+}
+
+func (h *RoomFlagHandler) listFlags(ctx *HandlerContext) []string {
+	response := []string{fmt.Sprintf("Room flags for %s:", world.RoomName(ctx.RoomID()))}
+	for _, flag := range world.FlagNames() {
+		line := fmt.Sprintf("  %-9s %s", flag, onOff(roomHas(ctx, flag)))
+		if _, changed := ctx.Room.Flags[flag]; changed {
+			line += " (changed)"
+		}
+		response = append(response, line)
+	}
+	return response
+}
+
+// PeaceHandler lets a moderator end every fight in their room, or in a room
+// they name: NPCs stop fighting and characters leave combat.
+type PeaceHandler struct {
+	repoManager interfaces.RepositoryManager
+	npcs        *npc.Manager
+	stances     *combat.Stances
+	fights      *fights
+	locks       *character.Locks
+}
+
+func (h *PeaceHandler) Execute(ctx *HandlerContext, cmd *Command) (*CommandResult, error) {
+	char := ctx.Character
+	if char == nil {
+		return Reply("Error retrieving character information."), nil
+	}
+	account, err := h.repoManager.Players().GetPlayer(char.PlayerID)
+	if err != nil {
+		return Reply("Error retrieving account."), nil
+	}
+	if !account.IsModerator() {
+		return Reply("Only moderators can stop fights."), nil
+	}
+
+	roomID := ctx.RoomID()
+	if len(cmd.Args) > 0 {
+		room, err := world.GetRoom(strings.ToLower(cmd.Args[0]))
+		if err != nil {
+			return Reply(fmt.Sprintf("There is no room '%s'.", cmd.Args[0])), nil
+		}
+		roomID = room.ID
+	}
+
+	var calmed, busy []string
+	if ctx.Messenger != nil {
+		for _, id := range ctx.Messenger.CharactersInRoom(roomID) {
+			name, ok, err := h.calm(ctx, id)
+			if err != nil {
+				return Reply("Error stopping the fighting."), nil
+			}
+			switch {
+			case !ok:
+				busy = append(busy, name)
+			case name != "":
+				calmed = append(calmed, name)
+			}
+		}
+	}
+	for _, n := range h.npcs.Pacify(roomID) {
+		calmed = append(calmed, n.Template.Name)
+	}
+
+	roomName := world.RoomName(roomID)
+	var response []string
+	switch {
+	case len(calmed) > 0:
+		response = append(response, fmt.Sprintf("You bring peace to %s. The fighting stops for: %s.", roomName, strings.Join(calmed, ", ")))
+	case len(busy) == 0:
+		return Reply(fmt.Sprintf("There is no fighting in %s.", roomName)), nil
+	}
+	if len(busy) > 0 {
+		// Nobody fights them any more, so their next command settles them
+		response = append(response, fmt.Sprintf("These fighters were busy and leave combat with their next command: %s.", strings.Join(busy, ", ")))
+	}
+	return Reply(response...).
+		ToRoom(roomID, fmt.Sprintf("%s commands peace, and the fighting stops at once.", ctx.ActorName()), char.ID), nil
+}
+
+// calm takes characterID out of combat, returning their name, or "" if they
+// were not fighting. If they are busy elsewhere, their fights with other
+// players still end but ok is false and the name is returned so the
+// moderator can be told.
+func (h *PeaceHandler) calm(ctx *HandlerContext, characterID string) (name string, ok bool, err error) {
+	fighter := ctx.Character
+	if characterID != fighter.ID {
+		unlock, locked := h.locks.TryLock(characterID, foeLockWait)
+		if !locked {
+			return h.calmBusy(characterID)
+		}
+		defer unlock()
+		if fighter, err = h.repoManager.Characters().GetCharacter(characterID); err != nil {
+			return "", false, err
+		}
+	}
+	if fighter.State != character.CharacterInCombat {
+		return "", true, nil
+	}
+
+	fighter.State = character.CharacterAlive
+	h.stances.Clear(fighter.ID)
+	h.fights.duels.Forget(fighter.ID)
+	if err := h.repoManager.Characters().UpdateCharacter(fighter); err != nil {
+		return "", false, err
+	}
+	return fighter.Name, true, nil
+}
+
+// calmBusy ends the duels of characterID, whose lock is held elsewhere, and
+// returns their name if they are fighting. Their state is left for their
+// next command to settle.
+func (h *PeaceHandler) calmBusy(characterID string) (string, bool, error) {
+	h.fights.duels.Forget(characterID)
+	fighter, err := h.repoManager.Characters().GetCharacter(characterID)
+	if err != nil {
+		return "", false, err
+	}
+	if fighter.State != character.CharacterInCombat {
+		return "", true, nil
+	}
+	return fighter.Name, false, nil
+}
@@ -0,0 +1,169 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+	"github.com/elidor/dungeogo/pkg/game/player"
+	"github.com/elidor/dungeogo/pkg/game/world"
+	"github.com/elidor/dungeogo/pkg/persistence/interfaces"
+)
+
+func runRoomFlag(t *testing.T, executor *Executor, ctx *HandlerContext, args ...string) []string {
+	result, err := executor.handlers["roomflag"].Execute(ctx, &Command{Verb: "roomflag", Args: args})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return result.Messages
+}
+
+func TestRoomFlagNeedsAdmin(t *testing.T) {
+	executor, repos := newFightExecutor(t)
+	ctx := &HandlerContext{Character: testCharacter("riverbank"), Room: &interfaces.RoomState{ID: "riverbank"}}
+
+	messages := runRoomFlag(t, executor, ctx, world.FlagNoMagic, "on")
+	if messages[0] != "Only administrators can change room flags." {
+		t.Errorf("Expected a player to be refused, got %v", messages)
+	}
+	if _, saved := repos.world.rooms["riverbank"]; saved {
+		t.Errorf("Expected the room to be left alone")
+	}
+}
+
+func TestRoomFlagSetsAndResets(t *testing.T) {
+	executor, repos := newFightExecutor(t)
+	repos.players.player.Role = player.RoleAdmin
+	ctx := &HandlerContext{Character: testCharacter("riverbank"), Room: &interfaces.RoomState{ID: "riverbank"}}
+
+	messages := runRoomFlag(t, executor, ctx, "NoMagic", "on")
+	if messages[0] != "The nomagic flag is now on here." {
+		t.Errorf("Expected the flag to be set, got %v", messages)
+	}
+	saved := repos.world.rooms["riverbank"]
+	if saved == nil || !world.HasFlag("riverbank", saved.Flags, world.FlagNoMagic) {
+		t.Fatalf("Expected the flag to be saved with the room")
+	}
+
+	runRoomFlag(t, executor, ctx, world.FlagWater, "off")
+	listing := strings.Join(runRoomFlag(t, executor, ctx), "\n")
+	for _, want := range []string{"nomagic   on (changed)", "water     off (changed)", "safe      off"} {
+		if !strings.Contains(listing, want) {
+			t.Errorf("Expected %q in:\n%s", want, listing)
+		}
+	}
+
+	runRoomFlag(t, executor, ctx, world.FlagWater, "reset")
+	if !world.HasFlag("riverbank", repos.world.rooms["riverbank"].Flags, world.FlagWater) {
+		t.Errorf("Expected reset to restore the riverbank's water")
+	}
+
+	if messages := runRoomFlag(t, executor, ctx, "haunted", "on"); !strings.HasPrefix(messages[0], "Unknown room flag 'haunted'.") {
+		t.Errorf("Expected an unknown flag to be refused, got %v", messages)
+	}
+}
+
+func TestNoMagicRoomsStopMagic(t *testing.T) {
+	executor, _ := newFightExecutor(t)
+	char := testCharacter("riverbank")
+	room := &interfaces.RoomState{ID: "riverbank", Flags: map[string]interface{}{world.FlagNoMagic: true}}
+	cmd := NewParser().Parse("recall", "player1", "char1")
+
+	result, err := executor.Execute(&HandlerContext{Character: char, Room: room}, cmd)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.Messages[0] != "Your magic fizzles and dies here." {
+		t.Errorf("Expected magic to fail, got %v", result.Messages)
+	}
+	if char.Location.RoomID != "riverbank" {
+		t.Errorf("Expected to stay put, got %s", char.Location.RoomID)
+	}
+}
+
+func TestWeatherIndoors(t *testing.T) {
+	handler := &WeatherHandler{}
+	for roomID, expected := range map[string]string{
+		character.DefaultStartRoomID: "You can't see the sky from in here.",
+		"riverbank":                  "The weather is clear and pleasant.",
+	} {
+		result, _ := handler.Execute(&HandlerContext{Character: testCharacter(roomID)}, &Command{Verb: "weather"})
+		if result.Messages[0] != expected {
+			t.Errorf("Expected %q in %s, got %v", expected, roomID, result.Messages)
+		}
+	}
+}
+
+func TestPeaceEndsFightsInRoom(t *testing.T) {
+	executor, repos := newFightExecutor(t)
+	repos.players.player.Role = player.RoleModerator
+	moderator := testCharacter(character.DefaultStartRoomID)
+	moderator.ID, moderator.Name = "char3", "Mira"
+	fighter := testCharacter("riverbank")
+	fighter.State = character.CharacterInCombat
+	repos.characters.stored = map[string]*character.Character{fighter.ID: fighter}
+	executor.Stances().Toggle(fighter.ID)
+	goblin := executor.NPCs().Find("riverbank", "goblin")
+	executor.NPCs().Engage(goblin.ID, fighter.ID)
+
+	ctx := &HandlerContext{Character: moderator, Messenger: roomMessenger{ids: []string{fighter.ID}}}
+	result, err := executor.handlers["peace"].Execute(ctx, &Command{Verb: "peace", Args: []string{"riverbank"}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if expected := "You bring peace to Riverbank. The fighting stops for: Alice, a goblin."; result.Messages[0] != expected {
+		t.Errorf("Expected %q, got %v", expected, result.Messages)
+	}
+	if len(result.Room) != 1 || result.Room[0].RoomID != "riverbank" {
+		t.Errorf("Expected the riverbank to hear of it, got %v", result.Room)
+	}
+	if fighter.State == character.CharacterInCombat || executor.Stances().Defending(fighter.ID) {
+		t.Errorf("Expected Alice to leave combat")
+	}
+	if goblin.Target != "" {
+		t.Errorf("Expected the goblin to stop fighting, got target %q", goblin.Target)
+	}
+
+	result, _ = executor.handlers["peace"].Execute(ctx, &Command{Verb: "peace", Args: []string{"riverbank"}})
+	if result.Messages[0] != "There is no fighting in Riverbank." {
+		t.Errorf("Expected nothing left to stop, got %v", result.Messages)
+	}
+}
+
+func TestPeaceReportsBusyFighters(t *testing.T) {
+	executor, repos := newFightExecutor(t)
+	repos.players.player.Role = player.RoleModerator
+	moderator := testCharacter("riverbank")
+	moderator.ID, moderator.Name = "char3", "Mira"
+	fighter := testCharacter("riverbank")
+	fighter.State = character.CharacterInCombat
+	repos.characters.stored = map[string]*character.Character{fighter.ID: fighter}
+	executor.fights.duels.Start(fighter.ID, "char2")
+
+	peace := executor.handlers["peace"].(*PeaceHandler)
+	unlock := peace.locks.Lock(fighter.ID)
+	defer unlock()
+	ctx := &HandlerContext{Character: moderator, Messenger: roomMessenger{ids: []string{fighter.ID}}}
+	result, err := peace.Execute(ctx, &Command{Verb: "peace"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if expected := "These fighters were busy and leave combat with their next command: Alice."; len(result.Messages) != 1 || result.Messages[0] != expected {
+		t.Errorf("Expected %q, got %v", expected, result.Messages)
+	}
+	if foes := executor.fights.duels.Foes(fighter.ID); len(foes) != 0 {
+		t.Errorf("Expected Alice's duels to end, got %v", foes)
+	}
+	if !executor.fights.settle(fighter) {
+		t.Errorf("Expected Alice's next command to take them out of combat")
+	}
+}
+
+func TestPeaceNeedsModerator(t *testing.T) {
+	executor, _ := newFightExecutor(t)
+	ctx := &HandlerContext{Character: testCharacter("riverbank"), Messenger: NopMessenger{}}
+	result, _ := executor.handlers["peace"].Execute(ctx, &Command{Verb: "peace"})
+	if result.Messages[0] != "Only moderators can stop fights." {
+		t.Errorf("Expected a player to be refused, got %v", result.Messages)
+	}
+}
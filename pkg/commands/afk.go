@@ -0,0 +1,79 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+)
+
+// afkMemory holds the auto-reply of each character who is AFK, for as long
+// as they are in the game
+type afkMemory struct {
+	mutex    sync.Mutex
+	messages map[string]string
+}
+
+func newAfkMemory() *afkMemory {
+	return &afkMemory{messages: make(map[string]string)}
+}
+
+func (m *afkMemory) set(characterID, message string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.messages[characterID] = message
+}
+
+func (m *afkMemory) message(characterID string) string {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.messages[characterID]
+}
+
+func (m *afkMemory) forget(characterID string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	delete(m.messages, characterID)
+}
+
+// autoReply returns what someone telling char is told back, or "" if char
+// is not AFK
+func (m *afkMemory) autoReply(char *character.Character) string {
+	if char.State != character.CharacterAfk {
+		return ""
+	}
+	if message := m.message(char.ID); message != "" {
+		return fmt.Sprintf("%s is AFK: %s.", char.Name, strings.TrimRight(message, "."))
+	}
+	return fmt.Sprintf("%s is AFK.", char.Name)
+}
+
+// AfkHandler marks the character as away from the keyboard, with a message
+// for anyone who sends them a tell. Their next command brings them back.
+type AfkHandler struct {
+	afk *afkMemory
+}
+
+func (h *AfkHandler) Execute(ctx *HandlerContext, cmd *Command) (*CommandResult, error) {
+	char := ctx.Character
+	if char == nil {
+		return Reply("Error retrieving character information."), nil
+	}
+	if char.State == character.CharacterInCombat {
+		return Reply("You can't go AFK in the middle of a fight!"), nil
+	}
+
+	message := strings.Join(cmd.Args, " ")
+	h.afk.set(char.ID, message)
+	reply := "You are now AFK."
+	if message != "" {
+		reply = fmt.Sprintf("You are now AFK: %s", message)
+	}
+	if char.State == character.CharacterAfk {
+		return Reply(reply), nil
+	}
+	char.State = character.CharacterAfk
+	return Reply(reply).
+		ToRoom("", fmt.Sprintf("%s is now away from the keyboard.", ctx.ActorName()), char.ID), nil
+}
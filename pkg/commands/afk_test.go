@@ -0,0 +1,68 @@
+package commands
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+)
+
+func TestAfkAutoReplyAndReturn(t *testing.T) {
+	repos := newMemoryRepos()
+	executor := NewExecutor(repos)
+	parser := NewParser()
+	alice := testCharacter(character.DefaultStartRoomID)
+	bob := testCharacter(character.DefaultStartRoomID)
+	bob.ID, bob.Name = "char2", "Bob"
+	repos.characters.stored = map[string]*character.Character{alice.ID: alice, bob.ID: bob}
+	online := onlineMessenger{ids: []string{alice.ID, bob.ID}}
+
+	run := func(char *character.Character, input string) []string {
+		ctx := &HandlerContext{Character: char, Messenger: online}
+		result, err := executor.Execute(ctx, parser.Parse(input, char.PlayerID, char.ID))
+		if err != nil {
+			t.Fatalf("Unexpected error for %q: %v", input, err)
+		}
+		return result.Messages
+	}
+
+	if got := run(bob, "afk getting tea"); got[0] != "You are now AFK: getting tea" {
+		t.Errorf("Unexpected reply: %q", got[0])
+	}
+	if bob.State != character.CharacterAfk {
+		t.Errorf("Expected Bob to be flagged AFK, got state %d", bob.State)
+	}
+
+	expected := []string{"You tell Bob: are you there?", "Bob is AFK: getting tea."}
+	if got := run(alice, "tell bob are you there?"); strings.Join(got, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+	if got := run(alice, "who"); !slices.Contains(got, "  Bob (Human Warrior, Level 1) [AFK]") {
+		t.Errorf("Expected who to mark Bob AFK, got %v", got)
+	}
+
+	if !executor.ReturnFromAfk(bob) || bob.State != character.CharacterAlive {
+		t.Fatalf("Expected Bob to be brought back from AFK")
+	}
+	if executor.ReturnFromAfk(bob) {
+		t.Errorf("Expected Bob to be back already")
+	}
+	if got := run(alice, "tell bob welcome back"); len(got) != 1 {
+		t.Errorf("Expected no auto-reply once Bob is back, got %v", got)
+	}
+}
+
+func TestAfkRefusedInCombat(t *testing.T) {
+	executor := NewExecutor(newMemoryRepos())
+	char := testCharacter(character.DefaultStartRoomID)
+	char.State = character.CharacterInCombat
+
+	result, err := executor.Execute(&HandlerContext{Character: char}, NewParser().Parse("afk", "player1", "char1"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.Messages[0] != "You can't go AFK in the middle of a fight!" || char.State != character.CharacterInCombat {
+		t.Errorf("Expected AFK to be refused in a fight, got %q", result.Messages[0])
+	}
+}
@@ -0,0 +1,169 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+	"github.com/elidor/dungeogo/pkg/persistence/interfaces"
+)
+
+// AppearanceHandler shows or sets the fields others see when they look at a
+// character, such as their height or eye colour.
+type AppearanceHandler struct{}
+
+func (h *AppearanceHandler) Execute(ctx *HandlerContext, cmd *Command) (*CommandResult, error) {
+	char := ctx.Character
+	if char == nil {
+		return Reply("Error retrieving character information."), nil
+	}
+
+	if len(cmd.Args) == 0 {
+		result := Reply("Your appearance:")
+		for _, name := range character.AppearanceFields {
+			value, _ := char.Appearance.Get(name)
+			if value == "" {
+				value = "(not set)"
+			}
+			result.Add(fmt.Sprintf("  %-7s %s", name+":", value))
+		}
+		return result.Add("Use 'appearance <field> <text>' to change a field, or 'appearance <field> none' to clear it."), nil
+	}
+
+	name := strings.ToLower(cmd.Args[0])
+	value := strings.Join(cmd.Args[1:], " ")
+	if strings.EqualFold(value, "none") || strings.EqualFold(value, "clear") {
+		value = ""
+	}
+	if err := char.Appearance.Set(name, value); err != nil {
+		return Reply(appearanceError(err)), nil
+	}
+
+	if value == "" {
+		return Reply(fmt.Sprintf("You clear your %s.", name)), nil
+	}
+	value, _ = char.Appearance.Get(name)
+	return Reply(fmt.Sprintf("You set your %s to %s.", name, value)), nil
+}
+
+// appearanceError explains why an appearance, description or profile was
+// refused
+func appearanceError(err error) string {
+	switch {
+	case errors.Is(err, character.ErrUnknownAppearanceField):
+		return fmt.Sprintf("You can set your %s.", strings.Join(character.AppearanceFields, ", "))
+	case errors.Is(err, character.ErrAppearanceTooLong):
+		return fmt.Sprintf("Each part of your appearance may be at most %d characters long.", character.MaxAppearanceLength)
+	case errors.Is(err, character.ErrDescriptionTooLong):
+		return fmt.Sprintf("Descriptions may be at most %d lines and %d characters long.",
+			character.MaxDescriptionLines, character.MaxDescriptionLength)
+	case errors.Is(err, character.ErrProfileTooLong):
+		return fmt.Sprintf("Profiles may be at most %d characters long.", character.MaxProfileLength)
+	case errors.Is(err, character.ErrAppearanceProfane):
+		return "That language is not allowed here."
+	default:
+		return "Only letters, numbers, spaces and punctuation are allowed."
+	}
+}
+
+// DescriptionHandler shows or sets the description others see when they
+// look at a character. Long descriptions are written a line at a time in
+// an editor, which takes over the character's input until it is closed.
+type DescriptionHandler struct {
+	repoManager interfaces.RepositoryManager
+
+	mutex sync.Mutex
+	// drafts holds the lines written so far, by character ID, for each
+	// character with the editor open
+	drafts map[string][]string
+}
+
+func (h *DescriptionHandler) Execute(ctx *HandlerContext, cmd *Command) (*CommandResult, error) {
+	char := ctx.Character
+	if char == nil {
+		return Reply("Error retrieving character information."), nil
+	}
+
+	if len(cmd.Args) == 0 {
+		if char.Description == "" {
+			return Reply("You have no description. Use 'description <text>' or 'description edit' to write one."), nil
+		}
+		result := Reply("Your description:")
+		result.Add(strings.Split(char.Description, "\n")...)
+		return result, nil
+	}
+
+	switch text := strings.Join(cmd.Args, " "); strings.ToLower(text) {
+	case "edit":
+		h.mutex.Lock()
+		h.drafts[char.ID] = []string{}
+		h.mutex.Unlock()
+		return Reply(
+			fmt.Sprintf("Write your description, up to %d lines.", character.MaxDescriptionLines),
+			"Type '.' on a line by itself to save it, or '~q' to discard it.",
+		), nil
+	case "none", "clear":
+		return h.save(char, nil)
+	default:
+		return h.save(char, []string{text})
+	}
+}
+
+// edit takes a line of input for the character's open editor. It reports
+// false if the character has no editor open.
+func (h *DescriptionHandler) edit(ctx *HandlerContext, line string) (*CommandResult, bool, error) {
+	char := ctx.Character
+	if char == nil {
+		return nil, false, nil
+	}
+
+	h.mutex.Lock()
+	draft, open := h.drafts[char.ID]
+	if !open {
+		h.mutex.Unlock()
+		return nil, false, nil
+	}
+	switch strings.TrimSpace(line) {
+	case ".", "~q":
+		delete(h.drafts, char.ID)
+	default:
+		if len(draft) >= character.MaxDescriptionLines {
+			h.mutex.Unlock()
+			return Reply(fmt.Sprintf("Descriptions may be at most %d lines long. Type '.' to save or '~q' to discard.",
+				character.MaxDescriptionLines)), true, nil
+		}
+		h.drafts[char.ID] = append(draft, line)
+		h.mutex.Unlock()
+		return Reply(), true, nil
+	}
+	h.mutex.Unlock()
+
+	if strings.TrimSpace(line) == "~q" {
+		return Reply("Your description was discarded."), true, nil
+	}
+	result, err := h.save(char, draft)
+	return result, true, err
+}
+
+// discard closes the character's editor without saving
+func (h *DescriptionHandler) discard(characterID string) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	delete(h.drafts, characterID)
+}
+
+// save validates and stores the character's description
+func (h *DescriptionHandler) save(char *character.Character, lines []string) (*CommandResult, error) {
+	if err := char.SetDescription(lines); err != nil {
+		return Reply(appearanceError(err)), nil
+	}
+	if err := h.repoManager.Characters().UpdateCharacter(char); err != nil {
+		return Reply("Error saving your description."), nil
+	}
+	if char.Description == "" {
+		return Reply("Your description has been cleared."), nil
+	}
+	return Reply("Your description has been saved."), nil
+}
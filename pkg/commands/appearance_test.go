@@ -0,0 +1,115 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+)
+
+func TestAppearanceCommand(t *testing.T) {
+	repos := newMemoryRepos()
+	executor := NewExecutor(repos)
+	parser := NewParser()
+	char := testCharacter(character.DefaultStartRoomID)
+	ctx := &HandlerContext{Character: char, Messenger: NopMessenger{}}
+
+	run := func(input string) []string {
+		result, err := executor.Execute(ctx, parser.Parse(input, "player1", "char1"))
+		if err != nil {
+			t.Fatalf("Unexpected error for %q: %v", input, err)
+		}
+		return result.Messages
+	}
+
+	if got := run("appearance eyes storm grey"); got[0] != "You set your eyes to storm grey." {
+		t.Errorf("Unexpected reply: %q", got[0])
+	}
+	if char.Appearance.EyeColor != "storm grey" {
+		t.Errorf("Expected eye colour to be set, got %q", char.Appearance.EyeColor)
+	}
+	if repos.characters.saves != 0 {
+		t.Errorf("Expected the engine to save the appearance, got %d handler saves", repos.characters.saves)
+	}
+	if got := run("appearance tail long"); !strings.HasPrefix(got[0], "You can set your height") {
+		t.Errorf("Expected an unknown field to be refused, got %q", got[0])
+	}
+	if got := run("appearance"); !strings.Contains(strings.Join(got, "\n"), "eyes:   storm grey") {
+		t.Errorf("Expected the appearance to be listed, got %v", got)
+	}
+	if got := run("appearance eyes none"); got[0] != "You clear your eyes." || char.Appearance.EyeColor != "" {
+		t.Errorf("Expected eye colour to be cleared, got %q", got[0])
+	}
+}
+
+func TestDescriptionEditor(t *testing.T) {
+	repos := newMemoryRepos()
+	executor := NewExecutor(repos)
+	parser := NewParser()
+	char := testCharacter(character.DefaultStartRoomID)
+	ctx := &HandlerContext{Character: char, Messenger: NopMessenger{}}
+
+	edit := func(line string) []string {
+		result, editing, err := executor.Edit(ctx, line)
+		if err != nil || !editing {
+			t.Fatalf("Expected %q to go to the editor, got editing=%v err=%v", line, editing, err)
+		}
+		return result.Messages
+	}
+
+	if _, editing, _ := executor.Edit(ctx, "look"); editing {
+		t.Fatalf("Expected no editor to be open")
+	}
+	if _, err := executor.Execute(ctx, parser.Parse("description edit", "player1", "char1")); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	edit("A lean figure in a patched cloak.")
+	edit("look")
+	if got := edit("."); got[0] != "Your description has been saved." {
+		t.Errorf("Unexpected reply: %q", got[0])
+	}
+	if char.Description != "A lean figure in a patched cloak.\nlook" {
+		t.Errorf("Unexpected description: %q", char.Description)
+	}
+	if _, editing, _ := executor.Edit(ctx, "look"); editing {
+		t.Errorf("Expected the editor to close once saved")
+	}
+
+	executor.Execute(ctx, parser.Parse("description edit", "player1", "char1"))
+	edit("Something else entirely.")
+	if got := edit("~q"); got[0] != "Your description was discarded." {
+		t.Errorf("Unexpected reply: %q", got[0])
+	}
+	if char.Description != "A lean figure in a patched cloak.\nlook" {
+		t.Errorf("Expected a discarded draft to leave the description, got %q", char.Description)
+	}
+
+	executor.Execute(ctx, parser.Parse("description edit", "player1", "char1"))
+	executor.CloseEditor(char.ID)
+	if _, editing, _ := executor.Edit(ctx, "look"); editing {
+		t.Errorf("Expected CloseEditor to close the editor")
+	}
+}
+
+func TestLookShowsAppearance(t *testing.T) {
+	repos := newMemoryRepos()
+	executor := NewExecutor(repos)
+	alice := testCharacter(character.DefaultStartRoomID)
+	bob := testCharacter(character.DefaultStartRoomID)
+	bob.ID, bob.Name = "char2", "Bob"
+	bob.Appearance.Height = "tall"
+	bob.Description = "A weathered traveller.\nMud cakes their boots."
+	repos.characters.stored = map[string]*character.Character{alice.ID: alice, bob.ID: bob}
+
+	ctx := &HandlerContext{Character: alice, Messenger: roomMessenger{ids: []string{alice.ID, bob.ID}}}
+	result, err := executor.Execute(ctx, &Command{Verb: "look", Args: []string{"bob"}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	listing := strings.Join(result.Messages, "\n")
+	for _, want := range []string{"Height: tall.", "A weathered traveller.\nMud cakes their boots."} {
+		if !strings.Contains(listing, want) {
+			t.Errorf("Expected %q when looking, got %s", want, listing)
+		}
+	}
+}
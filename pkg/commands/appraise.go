@@ -0,0 +1,60 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/elidor/dungeogo/pkg/game/items"
+	"github.com/elidor/dungeogo/pkg/persistence/interfaces"
+)
+
+// AppraiseHandler tells the character what one of their items is worth
+type AppraiseHandler struct {
+	repoManager interfaces.RepositoryManager
+	factory     *items.ItemFactory
+}
+
+func (h *AppraiseHandler) Execute(ctx *HandlerContext, cmd *Command) (*CommandResult, error) {
+	char := ctx.Character
+	if char == nil {
+		return Reply("Error retrieving character information."), nil
+	}
+
+	name := strings.Join(cmd.Args, " ")
+	owned, err := h.repoManager.Items().GetPlayerItems(char.ID)
+	if err != nil {
+		return Reply("Error retrieving inventory."), nil
+	}
+	for _, item := range owned {
+		template, err := h.factory.GetTemplate(item.TemplateID)
+		if err != nil || !matchesItemName(template, name) {
+			continue
+		}
+		response := appraisal(template, item)
+		// There are no shops yet, so nobody can make an offer
+		response = append(response, "There is no shop here to make you an offer.")
+		return Reply(response...), nil
+	}
+	return Reply(fmt.Sprintf("You don't have %s.", name)), nil
+}
+
+// appraisal describes what item is worth and why
+func appraisal(template *items.ItemTemplate, item *items.ItemInstance) []string {
+	value := items.Appraise(template, item)
+	response := []string{
+		fmt.Sprintf("%s (%s %s)", template.Name, items.GetRarityName(template.Rarity), strings.ToLower(items.GetItemTypeName(template.Type))),
+		fmt.Sprintf("  Base value: %d gold", template.Value),
+	}
+	if template.Durability > 0 {
+		response = append(response, fmt.Sprintf("  Condition:  %d/%d", item.Durability, template.Durability))
+	}
+	if len(item.Enchantments) > 0 {
+		response = append(response, fmt.Sprintf("  Enchanted:  %d enchantment(s)", len(item.Enchantments)))
+	}
+	if item.Quantity > 1 {
+		response = append(response, fmt.Sprintf("  Worth:      %d gold each, %d gold for all %d", value, value*item.Quantity, item.Quantity))
+	} else {
+		response = append(response, fmt.Sprintf("  Worth:      %d gold", value))
+	}
+	return response
+}
@@ -0,0 +1,52 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/elidor/dungeogo/pkg/game/items"
+)
+
+func TestAppraise(t *testing.T) {
+	executor, repos := newFightExecutor(t)
+	char := testCharacter("riverbank")
+	sword, _ := executor.itemFactory.CreateInstance("rusty_sword", char.ID, 1)
+	sword.Durability = 25
+	sword.AddEnchantment(items.Enchantment{ID: "keen", Type: items.EnchantmentDamage, Power: 1})
+	potion, _ := executor.itemFactory.CreateInstance("health_potion", char.ID, 3)
+	repos.items.CreateItemInstance(sword)
+	repos.items.CreateItemInstance(potion)
+
+	appraise := func(name string) []string {
+		result, err := executor.handlers["appraise"].Execute(&HandlerContext{Character: char},
+			&Command{Verb: "appraise", Args: strings.Fields(name)})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		return result.Messages
+	}
+
+	expected := []string{
+		"Rusty Sword (Common weapon)",
+		"  Base value: 10 gold",
+		"  Condition:  25/50",
+		"  Enchanted:  1 enchantment(s)",
+		"  Worth:      10 gold",
+		"There is no shop here to make you an offer.",
+	}
+	if got := appraise("sword"); strings.Join(got, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+
+	template, _ := executor.itemFactory.GetTemplate("health_potion")
+	each := items.Appraise(template, potion)
+	stack := fmt.Sprintf("  Worth:      %d gold each, %d gold for all 3", each, each*3)
+	if got := appraise("health potion"); got[len(got)-2] != stack {
+		t.Errorf("Expected the whole stack valued, got %v", got)
+	}
+
+	if got := appraise("shield"); got[0] != "You don't have shield." {
+		t.Errorf("Unexpected reply: %v", got)
+	}
+}
@@ -0,0 +1,357 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+	"github.com/elidor/dungeogo/pkg/game/combat"
+	"github.com/elidor/dungeogo/pkg/game/damage"
+	"github.com/elidor/dungeogo/pkg/game/event"
+	"github.com/elidor/dungeogo/pkg/game/follow"
+	"github.com/elidor/dungeogo/pkg/game/items"
+	"github.com/elidor/dungeogo/pkg/game/lock"
+	"github.com/elidor/dungeogo/pkg/game/npc"
+	"github.com/elidor/dungeogo/pkg/game/player"
+	"github.com/elidor/dungeogo/pkg/game/stealth"
+	"github.com/elidor/dungeogo/pkg/game/world"
+	"github.com/elidor/dungeogo/pkg/persistence/interfaces"
+	"github.com/elidor/dungeogo/pkg/textutil"
+)
+
+// KillHandler attacks an NPC in the room, leaving its loot behind when it
+// dies, or another player where the PvP rules allow it.
+type KillHandler struct {
+	repoManager interfaces.RepositoryManager
+	factory     *items.ItemFactory
+	stealth     *stealth.Tracker
+	follow      *follow.Tracker
+	stances     *combat.Stances
+	targets     *targetResolver
+	npcs        *npc.Manager
+	experience  *experienceRules
+	pvp         *pvpRules
+	fights      *fights
+	// locks guards the other player in a fight, whose changes are saved
+	// along with the attacker's
+	locks *character.Locks
+	// roll returns a number from 0 to n-1
+	roll func(n int) int
+}
+
+func (h *KillHandler) Execute(ctx *HandlerContext, cmd *Command) (*CommandResult, error) {
+	char := ctx.Character
+	if char == nil {
+		return Reply("Error retrieving character information."), nil
+	}
+
+	name := strings.Join(cmd.Args, " ")
+	foe, other := h.targets.resolve(ctx, name)
+	if foe == nil && other != nil {
+		return h.attackPlayer(ctx, other)
+	}
+	if foe == nil {
+		return Reply(fmt.Sprintf("You don't see %s here.", name)), nil
+	}
+
+	strike := h.strike(char)
+	response := h.stopFollowing(char)
+	if combat.SneakAttackBonus(strike) > 0 {
+		response = append(response, fmt.Sprintf("You catch %s off guard!", foe.Template.Name))
+	}
+	dealt := foe.Template.Resist(combat.Damage(strike, h.roll), damage.Physical)
+	response = append(response, fmt.Sprintf("You hit %s for %d damage.", foe.Template.Name, dealt))
+
+	killed, err := h.npcs.Damage(foe.ID, dealt)
+	if err != nil {
+		return Reply("Error attacking."), nil
+	}
+	// Holding back to defend leaves no opening for the off hand
+	if offHand := h.offHandWeapon(char); offHand != nil && !killed && !strike.Defensive {
+		lines, dead, err := h.strikeOffHand(char, foe, offHand)
+		if err != nil {
+			return Reply("Error attacking."), nil
+		}
+		response = append(response, lines...)
+		killed = dead
+	}
+	if !killed {
+		h.npcs.Engage(foe.ID, char.ID)
+		char.State = character.CharacterInCombat
+		result := Reply(response...).
+			ToRoom("", fmt.Sprintf("%s attacks %s.", ctx.ActorName(), foe.Template.Name), char.ID)
+		if prefs := h.preferences(char); prefs != nil && prefs.CombatPrompts {
+			result.WithPrompt(combat.StatusLine(char, foe.Health, foe.Template.MaxHealth, strike.Defensive))
+		}
+		return result, nil
+	}
+
+	return h.slay(ctx, foe, response)
+}
+
+// slay deals with foe having been killed by the character, adding the
+// experience, reputation and loot they earn to response
+func (h *KillHandler) slay(ctx *HandlerContext, foe *npc.NPC, response []string) (*CommandResult, error) {
+	char := ctx.Character
+	h.targets.memory.forgetNPC(foe.ID)
+	foeName := textutil.Capitalize(foe.Template.Name)
+	response = append(response, fmt.Sprintf("%s dies!", foeName))
+	char.State = character.CharacterAlive
+	if h.stances.Clear(char.ID) {
+		response = append(response, "You lower your guard.")
+	}
+	char.KillCount++
+	response = append(response, h.experience.award(char, foe.Template.Experience)...)
+	response = append(response, adjustReputation(char, foe.Template.KillReputation)...)
+
+	looted, dropped, err := h.dropLoot(ctx, foe)
+	if err != nil {
+		return Reply("Error dropping loot."), nil
+	}
+	response = append(response, looted...)
+	response = append(response, h.experience.events.Publish(event.Kill{Character: char, TemplateID: foe.Template.ID, RoomID: ctx.RoomID()})...)
+
+	result := Reply(response...).
+		ToRoom("", fmt.Sprintf("%s kills %s.", ctx.ActorName(), foe.Template.Name), char.ID)
+	for _, line := range dropped {
+		result.ToRoom("", line, char.ID)
+	}
+	return result, nil
+}
+
+// strike sets up a blow from the character's main hand
+func (h *KillHandler) strike(char *character.Character) combat.Strike {
+	strike := combat.Strike{
+		Attacker:    char,
+		FromStealth: h.stealth.IsHidden(char.ID),
+		Opening:     char.State != character.CharacterInCombat,
+		Defensive:   h.stances.Defending(char.ID),
+	}
+	if weapon := char.Equipment[items.SlotMainHand]; weapon != nil {
+		if template, err := h.factory.GetTemplate(weapon.TemplateID); err == nil {
+			strike.Weapon = template
+		}
+	}
+	return strike
+}
+
+// foe returns the NPC or character name refers to, or with no name, the
+// living NPC in the room the character is fighting
+func (h *KillHandler) foe(ctx *HandlerContext, name string) (*npc.NPC, *character.Character) {
+	if name != "" {
+		return h.targets.resolve(ctx, name)
+	}
+	for _, n := range h.npcs.InRoom(ctx.RoomID()) {
+		if n.IsAlive() && n.Target == ctx.Character.ID {
+			return n, nil
+		}
+	}
+	return nil, nil
+}
+
+// stopFollowing breaks off the character's following, so they stay in the
+// fight, and returns the line telling them so.
+func (h *KillHandler) stopFollowing(char *character.Character) []string {
+	if leaderID, ok := h.follow.Stop(char.ID); ok {
+		return []string{fmt.Sprintf("You stop following %s.", characterName(h.repoManager, leaderID))}
+	}
+	return nil
+}
+
+// offHandWeapon returns the template of the weapon the character holds in
+// their off hand, or nil if they hold none.
+func (h *KillHandler) offHandWeapon(char *character.Character) *items.ItemTemplate {
+	item := char.Equipment[items.SlotOffHand]
+	if item == nil {
+		return nil
+	}
+	template, err := h.factory.GetTemplate(item.TemplateID)
+	if err != nil || template.Type != items.ItemWeapon {
+		return nil
+	}
+	return template
+}
+
+// strikeOffHand rolls a follow-up blow with the off-hand weapon, which
+// lands less often and hits less hard than the main blow. It reports
+// whether the blow killed foe.
+func (h *KillHandler) strikeOffHand(char *character.Character, foe *npc.NPC, weapon *items.ItemTemplate) ([]string, bool, error) {
+	if !combat.OffHandHits(char, h.roll) {
+		return []string{fmt.Sprintf("Your off-hand swing misses %s.", foe.Template.Name)}, false, nil
+	}
+	dealt := foe.Template.Resist(combat.Damage(combat.Strike{Attacker: char, Weapon: weapon, OffHand: true}, h.roll), damage.Physical)
+	killed, err := h.npcs.Damage(foe.ID, dealt)
+	if err != nil {
+		return nil, false, err
+	}
+	return []string{fmt.Sprintf("You strike %s with your %s for %d damage.", foe.Template.Name, weapon.Name, dealt)}, killed, nil
+}
+
+// dropLoot rolls the dead NPC's loot. It lands on the floor for anyone to
+// pick up, unless the killer has auto-loot on, in which case they take the
+// gold and whatever they can carry straight away. It returns the lines for
+// the killer and for the room.
+func (h *KillHandler) dropLoot(ctx *HandlerContext, foe *npc.NPC) ([]string, []string, error) {
+	char := ctx.Character
+	drops, gold := foe.Template.RollLoot(h.roll)
+	gold = h.experience.gold(gold)
+	autoLoot := h.autoLoot(char)
+	foeName := textutil.Capitalize(foe.Template.Name)
+
+	var carried float64
+	if autoLoot {
+		weight, err := carriedWeight(h.repoManager, h.factory, char)
+		if err != nil {
+			return nil, nil, err
+		}
+		carried = weight
+	} else if gold > 0 {
+		drops = append(drops, npc.Drop{TemplateID: items.GoldTemplateID, Quantity: gold})
+	}
+
+	var looted, dropped []string
+	for _, drop := range drops {
+		item, err := h.factory.CreateInstance(drop.TemplateID, ctx.RoomID(), drop.Quantity)
+		if err != nil {
+			return nil, nil, err
+		}
+		weight := itemWeight(h.factory, item)
+		take := autoLoot && carried+weight <= char.CarryCapacity()
+		if take {
+			item.OwnerID = char.ID
+			carried += weight
+		}
+		if err := h.repoManager.Items().CreateItemInstance(item); err != nil {
+			return nil, nil, err
+		}
+
+		if take {
+			looted = append(looted, fmt.Sprintf("You take %s from %s.", describeItem(h.factory, item), foe.Template.Name))
+			continue
+		}
+		line := fmt.Sprintf("%s drops %s.", foeName, describeItem(h.factory, item))
+		looted = append(looted, line)
+		dropped = append(dropped, line)
+		if autoLoot {
+			looted = append(looted, fmt.Sprintf("%s is too heavy for you to carry, so you leave it.", itemName(h.factory, item)))
+		}
+	}
+
+	// Coins go into the purse and weigh nothing there
+	if gold > 0 && autoLoot {
+		char.Gold += gold
+		looted = append(looted, fmt.Sprintf("You take %d gold from %s.", gold, foe.Template.Name))
+	}
+	return looted, dropped, nil
+}
+
+// autoLoot reports whether the character's player has asked to take loot
+// from their kills automatically.
+func (h *KillHandler) autoLoot(char *character.Character) bool {
+	prefs := h.preferences(char)
+	return prefs != nil && prefs.AutoLoot
+}
+
+// preferences loads the preferences of the character's player, or nil if
+// they cannot be loaded.
+func (h *KillHandler) preferences(char *character.Character) *player.PlayerPrefs {
+	p, err := h.repoManager.Players().GetPlayer(char.PlayerID)
+	if err != nil {
+		return nil
+	}
+	return &p.Preferences
+}
+
+// FleeHandler tries to escape a fight through a random way out of the room.
+// Escaping costs stamina, and aggressive foes may give chase.
+type FleeHandler struct {
+	repoManager interfaces.RepositoryManager
+	view        *roomViewer
+	stances     *combat.Stances
+	npcs        *npc.Manager
+	// roll returns a number from 0 to n-1
+	roll func(n int) int
+}
+
+func (h *FleeHandler) Execute(ctx *HandlerContext, cmd *Command) (*CommandResult, error) {
+	char := ctx.Character
+	if char == nil {
+		return Reply("Error retrieving character information."), nil
+	}
+	if char.State != character.CharacterInCombat {
+		return Reply("You aren't fighting anyone."), nil
+	}
+
+	fromRoom := ctx.RoomID()
+	room, err := world.GetRoom(fromRoom)
+	if err != nil {
+		return Reply("There is nowhere to flee!"), nil
+	}
+	// Locked doors are no way out
+	var exits []string
+	for _, direction := range room.SortedExits() {
+		exit := room.Exits[direction]
+		if exit.DoorID == "" || world.DoorState(roomFlags(ctx), exit.DoorID) == lock.Unlocked {
+			exits = append(exits, direction)
+		}
+	}
+	if len(exits) == 0 {
+		return Reply("There is nowhere to flee!"), nil
+	}
+
+	if !combat.Flees(char, h.roll) {
+		return Reply("You failed to flee!").
+			ToRoom("", fmt.Sprintf("%s tries to flee, but can't get away.", ctx.ActorName()), char.ID), nil
+	}
+
+	direction := exits[h.roll(len(exits))]
+	destination, err := world.GetRoom(room.Exits[direction].To)
+	if err != nil {
+		return Reply("You failed to flee!"), nil
+	}
+	pursuers := h.npcs.Flee(char.ID, fromRoom, destination.ID)
+	combat.SpendFleeStamina(char)
+	if len(pursuers) == 0 {
+		char.State = character.CharacterAlive
+		h.stances.Clear(char.ID)
+	}
+	destinationState, err := enterRoom(h.repoManager, ctx, destination)
+	if err != nil {
+		return Reply("Error fleeing."), nil
+	}
+
+	response := append([]string{fmt.Sprintf("You flee %s!", direction)}, describeRoom(destination, destinationState)...)
+	response = append(response, h.view.contents(ctx)...)
+	result := Reply(response...).
+		ToRoom(fromRoom, fmt.Sprintf("%s flees %s!", ctx.ActorName(), direction), char.ID).
+		ToRoom(destination.ID, arriveMessage(ctx.ActorName(), direction), char.ID)
+	for _, n := range pursuers {
+		result.Add(fmt.Sprintf("%s chases after you!", textutil.Capitalize(n.Template.Name)))
+		result.ToRoom(fromRoom, fmt.Sprintf("%s chases after %s.", textutil.Capitalize(n.Template.Name), ctx.ActorName()))
+	}
+	return result, nil
+}
+
+// DefendHandler switches the character's defensive stance during a fight.
+// Defending turns aside part of each blow, more with skill at Parry, but
+// the character's own blows land softer.
+type DefendHandler struct {
+	stances *combat.Stances
+}
+
+func (h *DefendHandler) Execute(ctx *HandlerContext, cmd *Command) (*CommandResult, error) {
+	char := ctx.Character
+	if char == nil {
+		return Reply("Error retrieving character information."), nil
+	}
+	if char.State != character.CharacterInCombat {
+		return Reply("You aren't fighting anyone."), nil
+	}
+
+	if !h.stances.Toggle(char.ID) {
+		return Reply("You lower your guard and press the attack.").
+			ToRoom("", fmt.Sprintf("%s lowers their guard.", ctx.ActorName()), char.ID), nil
+	}
+	return Reply("You raise your guard, focusing on defense.").
+		ToRoom("", fmt.Sprintf("%s takes a defensive stance.", ctx.ActorName()), char.ID), nil
+}
@@ -0,0 +1,642 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/elidor/dungeogo/pkg/game/achievement"
+	"github.com/elidor/dungeogo/pkg/game/character"
+	"github.com/elidor/dungeogo/pkg/game/combat"
+	"github.com/elidor/dungeogo/pkg/game/items"
+	"github.com/elidor/dungeogo/pkg/game/npc"
+	"github.com/elidor/dungeogo/pkg/game/player"
+	"github.com/elidor/dungeogo/pkg/persistence/interfaces"
+)
+
+// memoryRepos keeps just enough in memory for fights to be resolved without
+// a database. Unused repository methods are left nil.
+type memoryRepos struct {
+	interfaces.RepositoryManager
+	world      *memoryWorld
+	items      *memoryItems
+	characters *memoryCharacters
+	players    *memoryPlayers
+	reports    *memoryReports
+}
+
+func newMemoryRepos() *memoryRepos {
+	characters := &memoryCharacters{equipment: make(map[string]map[items.EquipSlot]string)}
+	return &memoryRepos{
+		world:      &memoryWorld{npcs: make(map[string]*interfaces.NPCState), rooms: make(map[string]*interfaces.RoomState)},
+		items:      &memoryItems{items: make(map[string]*items.ItemInstance), characters: characters},
+		characters: characters,
+		players:    &memoryPlayers{player: player.NewPlayer("alice", "alice@example.com", "")},
+		reports:    &memoryReports{},
+	}
+}
+
+func (r *memoryRepos) World() interfaces.WorldRepository          { return r.world }
+func (r *memoryRepos) Items() interfaces.ItemRepository           { return r.items }
+func (r *memoryRepos) Characters() interfaces.CharacterRepository { return r.characters }
+func (r *memoryRepos) Players() interfaces.PlayerRepository       { return r.players }
+func (r *memoryRepos) Reports() interfaces.ReportRepository       { return r.reports }
+
+type memoryWorld struct {
+	interfaces.WorldRepository
+	npcs  map[string]*interfaces.NPCState
+	rooms map[string]*interfaces.RoomState
+}
+
+func (w *memoryWorld) SaveNPCState(npcID string, state *interfaces.NPCState) error {
+	w.npcs[npcID] = state
+	return nil
+}
+
+func (w *memoryWorld) LoadNPCState(npcID string) (*interfaces.NPCState, error) {
+	if state, ok := w.npcs[npcID]; ok {
+		return state, nil
+	}
+	return nil, interfaces.ErrNPCNotFound
+}
+
+func (w *memoryWorld) LoadRoomState(roomID string) (*interfaces.RoomState, error) {
+	if state, ok := w.rooms[roomID]; ok {
+		return state, nil
+	}
+	return &interfaces.RoomState{ID: roomID}, nil
+}
+
+func (w *memoryWorld) SaveRoomState(roomID string, state *interfaces.RoomState) error {
+	w.rooms[roomID] = state
+	return nil
+}
+
+type memoryItems struct {
+	interfaces.ItemRepository
+	items map[string]*items.ItemInstance
+	// characters holds the saved equipment GetCarriedItems leaves out
+	characters *memoryCharacters
+	// updateErr fails every UpdateItemInstance when set
+	updateErr error
+}
+
+func (r *memoryItems) CreateItemInstance(item *items.ItemInstance) error {
+	r.items[item.ID] = item
+	return nil
+}
+
+func (r *memoryItems) UpdateItemInstance(item *items.ItemInstance) error {
+	if r.updateErr != nil {
+		return r.updateErr
+	}
+	r.items[item.ID] = item
+	return nil
+}
+
+func (r *memoryItems) DeleteItemInstance(itemID string) error {
+	delete(r.items, itemID)
+	return nil
+}
+
+func (r *memoryItems) GetPlayerItems(ownerID string) ([]*items.ItemInstance, error) {
+	var owned []*items.ItemInstance
+	for _, item := range r.items {
+		if item.OwnerID == ownerID {
+			owned = append(owned, item)
+		}
+	}
+	return owned, nil
+}
+
+func (r *memoryItems) GetCarriedItems(characterID string) ([]*items.ItemInstance, error) {
+	owned, _ := r.GetPlayerItems(characterID)
+	worn := make(map[string]bool)
+	for _, itemID := range r.characters.equipment[characterID] {
+		worn[itemID] = true
+	}
+	var carried []*items.ItemInstance
+	for _, item := range owned {
+		if !worn[item.ID] {
+			carried = append(carried, item)
+		}
+	}
+	return carried, nil
+}
+
+func (r *memoryItems) GetRoomItems(roomID string) ([]*items.ItemInstance, error) {
+	return r.GetPlayerItems(roomID)
+}
+
+func (r *memoryItems) TransferItem(itemID, newOwnerID string) error {
+	r.items[itemID].OwnerID = newOwnerID
+	return nil
+}
+
+type memoryCharacters struct {
+	interfaces.CharacterRepository
+	saves int
+	// stored holds the characters GetCharacter can find, by ID
+	stored map[string]*character.Character
+	// equipment holds what each character wore when last saved, by ID
+	equipment map[string]map[items.EquipSlot]string
+}
+
+func (r *memoryCharacters) GetCharacter(characterID string) (*character.Character, error) {
+	if char, ok := r.stored[characterID]; ok {
+		return char, nil
+	}
+	return nil, interfaces.ErrCharacterNotFound
+}
+
+func (r *memoryCharacters) GetCharacterByName(name string) (*character.Character, error) {
+	for _, char := range r.stored {
+		if strings.EqualFold(char.Name, name) {
+			return char, nil
+		}
+	}
+	return nil, interfaces.ErrCharacterNotFound
+}
+
+func (r *memoryCharacters) UpdateCharacter(char *character.Character) error {
+	r.saves++
+	r.equipment[char.ID] = char.EquipmentIDs()
+	return nil
+}
+
+func (r *memoryCharacters) UpdateCharacterStats(characterID string, stats *character.CharacterStats) error {
+	return nil
+}
+
+func (r *memoryCharacters) UpdateCharacterLocation(characterID string, location *character.Location) error {
+	return nil
+}
+
+type memoryPlayers struct {
+	interfaces.PlayerRepository
+	player *player.Player
+	// accounts holds accounts by ID for tests that need more than one;
+	// any other ID finds player
+	accounts map[string]*player.Player
+}
+
+func (r *memoryPlayers) GetPlayer(playerID string) (*player.Player, error) {
+	if account, ok := r.accounts[playerID]; ok {
+		return account, nil
+	}
+	return r.player, nil
+}
+
+func (r *memoryPlayers) UpdatePlayer(p *player.Player) error {
+	r.player = p
+	return nil
+}
+
+// newFightExecutor returns an executor over in-memory repositories with the
+// world's NPCs spawned and every roll at its lowest.
+func newFightExecutor(t *testing.T) (*Executor, *memoryRepos) {
+	repos := newMemoryRepos()
+	executor := NewExecutor(repos)
+	if err := executor.NPCs().Populate(); err != nil {
+		t.Fatalf("Failed to populate NPCs: %v", err)
+	}
+	executor.handlers["kill"].(*KillHandler).roll = func(n int) int { return 0 }
+	return executor, repos
+}
+
+// killUntilDead attacks target until it dies, returning the final reply.
+func killUntilDead(t *testing.T, executor *Executor, ctx *HandlerContext, target string) []string {
+	for i := 0; i < 50; i++ {
+		result, err := executor.handlers["kill"].Execute(ctx, &Command{Verb: "kill", Args: []string{target}})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		for _, line := range result.Messages {
+			if strings.HasSuffix(line, " dies!") {
+				return result.Messages
+			}
+		}
+	}
+	t.Fatalf("Expected %s to die", target)
+	return nil
+}
+
+func TestKillUnknownTarget(t *testing.T) {
+	executor, _ := newFightExecutor(t)
+	ctx := &HandlerContext{Character: testCharacter(character.DefaultStartRoomID)}
+
+	result, err := executor.handlers["kill"].Execute(ctx, &Command{Verb: "kill", Args: []string{"dragon"}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.Messages[0] != "You don't see dragon here." {
+		t.Errorf("Expected missing target message, got %v", result.Messages)
+	}
+}
+
+func TestKillDropsLootInRoom(t *testing.T) {
+	executor, repos := newFightExecutor(t)
+	char := testCharacter("riverbank")
+	ctx := &HandlerContext{Character: char}
+
+	messages := killUntilDead(t, executor, ctx, "goblin")
+	if !strings.Contains(strings.Join(messages, "\n"), "A goblin drops Rusty Sword.") {
+		t.Errorf("Expected the goblin to drop its sword, got %v", messages)
+	}
+	if char.State != character.CharacterAlive || char.KillCount != 1 || char.Experience != 25 {
+		t.Errorf("Expected the kill to be credited, got state %v, %d kills, %d XP", char.State, char.KillCount, char.Experience)
+	}
+
+	floor, _ := repos.items.GetRoomItems("riverbank")
+	if len(floor) != 3 {
+		t.Fatalf("Expected sword, potion and gold on the floor, got %d items", len(floor))
+	}
+
+	gold := char.Gold
+	result, _ := executor.handlers["get"].Execute(ctx, &Command{Verb: "get", Args: []string{"gold"}})
+	if result.Messages[0] != "You pick up 1 gold." || char.Gold != gold+1 {
+		t.Errorf("Expected to pick up the gold, got %v and %d gold", result.Messages, char.Gold)
+	}
+	result, _ = executor.handlers["get"].Execute(ctx, &Command{Verb: "get", Args: []string{"sword"}})
+	if result.Messages[0] != "You get Rusty Sword." {
+		t.Errorf("Expected to pick up the sword, got %v", result.Messages)
+	}
+
+	carried, _ := repos.items.GetPlayerItems(char.ID)
+	floor, _ = repos.items.GetRoomItems("riverbank")
+	if len(carried) != 1 || len(floor) != 1 {
+		t.Errorf("Expected one item carried and one left, got %d and %d", len(carried), len(floor))
+	}
+}
+
+func TestKillAutoLoot(t *testing.T) {
+	executor, repos := newFightExecutor(t)
+	repos.players.player.Preferences.AutoLoot = true
+	char := testCharacter("riverbank")
+	// Earned already, so its reward doesn't add to the loot
+	char.Achievements.Record(achievement.TriggerKill, "", 1, time.Now())
+	gold := char.Gold
+
+	messages := killUntilDead(t, executor, &HandlerContext{Character: char}, "goblin")
+	if !strings.Contains(strings.Join(messages, "\n"), "You take 1 gold from a goblin.") {
+		t.Errorf("Expected to take the gold, got %v", messages)
+	}
+	if char.Gold != gold+1 {
+		t.Errorf("Expected the gold to go straight to the purse, got %d", char.Gold)
+	}
+
+	carried, _ := repos.items.GetPlayerItems(char.ID)
+	floor, _ := repos.items.GetRoomItems("riverbank")
+	if len(carried) != 2 || len(floor) != 0 {
+		t.Errorf("Expected the loot carried rather than dropped, got %d carried and %d on the floor", len(carried), len(floor))
+	}
+}
+
+func TestAutoLootLeavesWhatIsTooHeavy(t *testing.T) {
+	executor, repos := newFightExecutor(t)
+	repos.players.player.Preferences.AutoLoot = true
+	char := testCharacter("riverbank")
+	// A lockbox leaves room for the potion but not the sword
+	char.Stats.Strength = 1
+	lockbox, _ := executor.itemFactory.CreateInstance("iron_lockbox", char.ID, 1)
+	repos.items.CreateItemInstance(lockbox)
+
+	messages := killUntilDead(t, executor, &HandlerContext{Character: char}, "goblin")
+	if !strings.Contains(strings.Join(messages, "\n"), "Rusty Sword is too heavy for you to carry, so you leave it.") {
+		t.Errorf("Expected the sword to be left behind, got %v", messages)
+	}
+
+	floor, _ := repos.items.GetRoomItems("riverbank")
+	if len(floor) != 1 || floor[0].TemplateID != "rusty_sword" {
+		t.Errorf("Expected only the sword on the floor, got %v", floor)
+	}
+}
+
+func TestKillShowsCombatPrompt(t *testing.T) {
+	executor, repos := newFightExecutor(t)
+	char := testCharacter(character.TutorialRoomID)
+	char.Stats.Health, char.Stats.MaxHealth = 45, 80
+	ctx := &HandlerContext{Character: char}
+
+	result, err := executor.handlers["kill"].Execute(ctx, &Command{Verb: "kill", Args: []string{"dummy"}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	dummy := executor.NPCs().Find(character.TutorialRoomID, "dummy")
+	expected := fmt.Sprintf("[HP: 45/80] [Enemy: %d/20]", dummy.Health)
+	if result.Prompt != expected {
+		t.Errorf("Expected prompt %q, got %q", expected, result.Prompt)
+	}
+
+	repos.players.player.Preferences.CombatPrompts = false
+	result, _ = executor.handlers["kill"].Execute(ctx, &Command{Verb: "kill", Args: []string{"dummy"}})
+	if result.Prompt != "" {
+		t.Errorf("Expected no prompt with combat prompts off, got %q", result.Prompt)
+	}
+}
+
+func TestKillWithOffHandWeapon(t *testing.T) {
+	executor, repos := newFightExecutor(t)
+	char := testCharacter("riverbank")
+	char.Class, _ = character.GetClassByID("rogue")
+	ctx := &HandlerContext{Character: char}
+	for i := 0; i < 2; i++ {
+		dagger, _ := executor.itemFactory.CreateInstance("worn_dagger", char.ID, 1)
+		repos.items.CreateItemInstance(dagger)
+	}
+
+	wear := func(args ...string) string {
+		result, err := executor.handlers["wear"].Execute(ctx, &Command{Verb: "wear", Args: args, CharacterID: char.ID})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		return result.Messages[len(result.Messages)-1]
+	}
+	if got := wear("dagger"); got != "You wear Worn Dagger." {
+		t.Fatalf("Unexpected reply: %q", got)
+	}
+	char.Stats.Dexterity = character.OffHandMinDexterity - 1
+	if got := wear("dagger", "offhand"); got != "You aren't nimble enough to fight with a weapon in each hand." {
+		t.Errorf("Expected a clumsy character to be refused, got %q", got)
+	}
+	char.Stats.Dexterity = character.OffHandMinDexterity
+	if got := wear("dagger", "offhand"); got != "You wield Worn Dagger in your off hand." {
+		t.Fatalf("Unexpected reply: %q", got)
+	}
+
+	kill := func() []string {
+		result, err := executor.handlers["kill"].Execute(ctx, &Command{Verb: "kill", Args: []string{"goblin"}})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		return result.Messages
+	}
+
+	// The opening blow gets the rogue's sneak attack; the off hand does not
+	expected := []string{
+		"You catch a goblin off guard!",
+		"You hit a goblin for 7 damage.",
+		"You strike a goblin with your Worn Dagger for 1 damage.",
+	}
+	if got := kill(); strings.Join(got, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+
+	executor.handlers["kill"].(*KillHandler).roll = func(n int) int { return n - 1 }
+	expected = []string{
+		"You hit a goblin for 3 damage.",
+		"Your off-hand swing misses a goblin.",
+	}
+	if got := kill(); strings.Join(got, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+}
+
+func TestWearOffHandNeedsProficiency(t *testing.T) {
+	executor, repos := newFightExecutor(t)
+	char := testCharacter("riverbank")
+	char.Stats.Dexterity = character.OffHandMinDexterity
+	dagger, _ := executor.itemFactory.CreateInstance("worn_dagger", char.ID, 1)
+	repos.items.CreateItemInstance(dagger)
+
+	result, err := executor.handlers["wear"].Execute(&HandlerContext{Character: char},
+		&Command{Verb: "wear", Args: []string{"dagger", "offhand"}, CharacterID: char.ID})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.Messages[0] != "You don't know how to fight with Worn Dagger." {
+		t.Errorf("Expected a warrior to be refused an off-hand dagger, got %v", result.Messages)
+	}
+	if char.Equipment[items.SlotOffHand] != nil {
+		t.Errorf("Expected nothing in the off hand")
+	}
+}
+
+func TestWearTwoHandedNeedsBothHands(t *testing.T) {
+	executor, repos := newFightExecutor(t)
+	char := testMage("riverbank")
+	char.Stats.Dexterity = character.OffHandMinDexterity
+	for _, templateID := range []string{"worn_dagger", "worn_dagger", "magic_staff"} {
+		item, _ := executor.itemFactory.CreateInstance(templateID, char.ID, 1)
+		repos.items.CreateItemInstance(item)
+	}
+	wear := func(args ...string) string {
+		result, err := executor.handlers["wear"].Execute(&HandlerContext{Character: char}, &Command{Verb: "wear", Args: args, CharacterID: char.ID})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		return result.Messages[len(result.Messages)-1]
+	}
+
+	if got := wear("dagger", "offhand"); got != "You wield Worn Dagger in your off hand." {
+		t.Fatalf("Unexpected reply: %q", got)
+	}
+	if got := wear("staff"); got != "You need both hands free to wield Magic Staff." {
+		t.Errorf("Expected the staff to need both hands, got %q", got)
+	}
+	if char.Equipment[items.SlotMainHand] != nil {
+		t.Errorf("Expected nothing in the main hand")
+	}
+
+	char.Unequip(items.SlotOffHand)
+	if got := wear("staff"); got != "You wear Magic Staff." {
+		t.Fatalf("Unexpected reply: %q", got)
+	}
+	if got := wear("dagger", "offhand"); got != "Magic Staff needs both hands." {
+		t.Errorf("Expected the staff to leave no hand free, got %q", got)
+	}
+	if char.Equipment[items.SlotOffHand] != nil {
+		t.Errorf("Expected nothing in the off hand")
+	}
+}
+
+func TestFlee(t *testing.T) {
+	executor, _ := newFightExecutor(t)
+	flee := executor.handlers["flee"].(*FleeHandler)
+	char := testCharacter(character.TutorialRoomID)
+	ctx := &HandlerContext{Character: char, Messenger: NopMessenger{}}
+	run := func() *CommandResult {
+		result, err := flee.Execute(ctx, &Command{Verb: "flee"})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		return result
+	}
+
+	if got := run().Messages[0]; got != "You aren't fighting anyone." {
+		t.Errorf("Unexpected reply: %q", got)
+	}
+
+	dummy := executor.NPCs().Find(character.TutorialRoomID, "dummy")
+	executor.NPCs().Engage(dummy.ID, char.ID)
+	char.State = character.CharacterInCombat
+
+	flee.roll = func(n int) int { return n - 1 }
+	if got := run().Messages[0]; got != "You failed to flee!" {
+		t.Errorf("Expected the escape to fail, got %q", got)
+	}
+	if char.Location.RoomID != character.TutorialRoomID || char.State != character.CharacterInCombat {
+		t.Errorf("Expected a failed escape to leave the character fighting where they were")
+	}
+
+	stamina := char.Stats.Stamina
+	flee.roll = func(n int) int { return 0 }
+	result := run()
+	if result.Messages[0] != "You flee north!" {
+		t.Errorf("Unexpected reply: %v", result.Messages)
+	}
+	if char.Location.RoomID != "tutorial_yard" || char.State != character.CharacterAlive {
+		t.Errorf("Expected the character to escape to the yard, got %s in state %v", char.Location.RoomID, char.State)
+	}
+	if char.Stats.Stamina != stamina-combat.FleeStaminaCost {
+		t.Errorf("Expected escaping to cost %d stamina, got %d", combat.FleeStaminaCost, stamina-char.Stats.Stamina)
+	}
+	if dummy.State != npc.StateIdle || dummy.Target != "" {
+		t.Errorf("Expected the dummy to stop fighting, got %s %s", dummy.State, dummy.Target)
+	}
+	if len(result.Room) != 2 || result.Room[0].RoomID != character.TutorialRoomID || result.Room[0].Text != "Alice flees north!" {
+		t.Errorf("Expected the old room to see the escape, got %v", result.Room)
+	}
+	if len(result.Room) == 2 && (result.Room[1].RoomID != "tutorial_yard" || result.Room[1].Text != "Alice arrives from the south.") {
+		t.Errorf("Expected the yard to see Alice arrive, got %v", result.Room[1])
+	}
+}
+
+func TestSettleFight(t *testing.T) {
+	executor, _ := newFightExecutor(t)
+	char := testCharacter(character.TutorialRoomID)
+	ctx := &HandlerContext{Character: char, Messenger: NopMessenger{}}
+
+	dummy := executor.NPCs().Find(character.TutorialRoomID, "dummy")
+	executor.NPCs().Engage(dummy.ID, char.ID)
+	char.State = character.CharacterInCombat
+	if executor.SettleFight(ctx) || char.State != character.CharacterInCombat {
+		t.Errorf("Expected the fight to go on while the dummy fights")
+	}
+	if foe, left := executor.LeaveFights(char); foe != dummy || left {
+		t.Errorf("Expected leaving to be forfeit to the dummy, got %v", foe)
+	}
+
+	executor.NPCs().Disengage(char.ID)
+	if !executor.SettleFight(ctx) || char.State != character.CharacterAlive {
+		t.Errorf("Expected a fight with no foe left to end, got state %v", char.State)
+	}
+	if executor.SettleFight(ctx) {
+		t.Errorf("Expected settling a character out of combat to change nothing")
+	}
+}
+
+func TestDefendStance(t *testing.T) {
+	executor, _ := newFightExecutor(t)
+	char := testCharacter("riverbank")
+	ctx := &HandlerContext{Character: char}
+	defend := func() string {
+		result, err := executor.handlers["defend"].Execute(ctx, &Command{Verb: "defend"})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		return result.Messages[0]
+	}
+
+	if got := defend(); got != "You aren't fighting anyone." {
+		t.Errorf("Unexpected reply: %q", got)
+	}
+
+	char.State = character.CharacterInCombat
+	if got := defend(); got != "You raise your guard, focusing on defense." {
+		t.Errorf("Unexpected reply: %q", got)
+	}
+	if !executor.Stances().Defending(char.ID) {
+		t.Fatalf("Expected the character to be defending")
+	}
+
+	// A defending character's bare-handed blow is halved
+	executor.handlers["kill"].(*KillHandler).roll = func(n int) int { return n - 1 }
+	result, err := executor.handlers["kill"].Execute(ctx, &Command{Verb: "kill", Args: []string{"goblin"}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.Messages[0] != "You hit a goblin for 1 damage." {
+		t.Errorf("Expected a defensive blow to be halved, got %v", result.Messages)
+	}
+	if !strings.HasSuffix(result.Prompt, " [Defending]") {
+		t.Errorf("Expected the status line to show the stance, got %q", result.Prompt)
+	}
+
+	messages := killUntilDead(t, executor, ctx, "goblin")
+	if !strings.Contains(strings.Join(messages, "\n"), "You lower your guard.") || executor.Stances().Defending(char.ID) {
+		t.Errorf("Expected the stance to end with the fight, got %v", messages)
+	}
+
+	char.State = character.CharacterInCombat
+	defend()
+	if got := defend(); got != "You lower your guard and press the attack." || executor.Stances().Defending(char.ID) {
+		t.Errorf("Expected defend to toggle the stance off, got %q", got)
+	}
+}
+
+func TestSeededFightsRepeat(t *testing.T) {
+	fight := func() []string {
+		executor := NewExecutor(newMemoryRepos())
+		if err := executor.NPCs().Populate(); err != nil {
+			t.Fatalf("Failed to populate NPCs: %v", err)
+		}
+		executor.SetSeed(42)
+		ctx := &HandlerContext{Character: testCharacter("riverbank")}
+		var transcript []string
+		for i := 0; i < 10; i++ {
+			result, err := executor.handlers["kill"].Execute(ctx, &Command{Verb: "kill", Args: []string{"goblin"}})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			transcript = append(transcript, result.Messages...)
+		}
+		return transcript
+	}
+
+	first, second := fight(), fight()
+	if strings.Join(first, "\n") != strings.Join(second, "\n") {
+		t.Errorf("Expected the same fight from the same seed, got %v and %v", first, second)
+	}
+}
+
+func TestWearStatItem(t *testing.T) {
+	executor, repos := newFightExecutor(t)
+	char := testCharacter("riverbank")
+	char.Stats.Strength = 10
+	ctx := &HandlerContext{Character: char}
+	sword, _ := executor.itemFactory.CreateEnchantedInstance("rusty_sword", char.ID, []items.Enchantment{
+		{ID: "might", Name: "Might", Type: items.EnchantmentStat, Stat: items.StatStrength, Power: 3},
+	})
+	repos.items.CreateItemInstance(sword)
+
+	run := func(verb string, args ...string) []string {
+		result, err := executor.handlers[verb].Execute(ctx, &Command{Verb: verb, Args: args, CharacterID: char.ID})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		return result.Messages
+	}
+
+	run("wear", "sword")
+	if got := char.Stats.Effective(character.StatStrength); got != 13 {
+		t.Errorf("Expected the sword to raise Strength to 13, got %d", got)
+	}
+	if got := char.CarryCapacity(); got != 65 {
+		t.Errorf("Expected the extra Strength to raise carry capacity to 65, got %v", got)
+	}
+	// The lowest roll of a Rusty Sword does 1, and 3 Strength above the
+	// base adds 1 more
+	if messages := run("kill", "goblin"); messages[0] != "You hit a goblin for 2 damage." {
+		t.Errorf("Expected the Strength bonus in the blow, got %v", messages)
+	}
+
+	run("remove", "sword")
+	if got := char.Stats.Effective(character.StatStrength); got != 10 {
+		t.Errorf("Expected removing the sword to restore Strength 10, got %d", got)
+	}
+	if got := char.CarryCapacity(); got != 50 {
+		t.Errorf("Expected carry capacity back at 50, got %v", got)
+	}
+}
@@ -0,0 +1,137 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/elidor/dungeogo/pkg/persistence/interfaces"
+)
+
+const (
+	// combatLogSize is how many lines each character's combat log keeps
+	combatLogSize = 100
+	// combatLogShown is how many lines combatlog shows unless asked for
+	// more
+	combatLogShown = 20
+)
+
+type combatLine struct {
+	at   time.Time
+	text string
+}
+
+// combatRing holds the last combatLogSize lines of one character's fights,
+// overwriting the oldest once full
+type combatRing struct {
+	lines [combatLogSize]combatLine
+	next  int
+	count int
+}
+
+// combatLog keeps a scrollback of what happened in each character's fights:
+// the blows they struck and took and how each fight ended. It lives in
+// memory only while the character is in the game.
+type combatLog struct {
+	mutex sync.Mutex
+	rings map[string]*combatRing
+	now   func() time.Time
+}
+
+func newCombatLog() *combatLog {
+	return &combatLog{rings: make(map[string]*combatRing), now: time.Now}
+}
+
+// record adds lines to characterID's log, skipping blank ones
+func (l *combatLog) record(characterID string, lines ...string) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	ring := l.rings[characterID]
+	if ring == nil {
+		ring = &combatRing{}
+		l.rings[characterID] = ring
+	}
+	at := l.now()
+	for _, text := range lines {
+		if text == "" {
+			continue
+		}
+		ring.lines[ring.next] = combatLine{at: at, text: text}
+		ring.next = (ring.next + 1) % combatLogSize
+		ring.count = min(ring.count+1, combatLogSize)
+	}
+}
+
+// recent returns up to n of characterID's latest lines, oldest first
+func (l *combatLog) recent(characterID string, n int) []combatLine {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	ring := l.rings[characterID]
+	if ring == nil {
+		return nil
+	}
+	n = min(n, ring.count)
+	lines := make([]combatLine, n)
+	for i := range lines {
+		lines[i] = ring.lines[(ring.next-n+i+combatLogSize)%combatLogSize]
+	}
+	return lines
+}
+
+func (l *combatLog) forget(characterID string) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	delete(l.rings, characterID)
+}
+
+// CombatLogHandler shows the character the latest lines of their combat
+// log, so they can read back a fight too fast to follow. Moderators can
+// read anyone's while they are in the game.
+type CombatLogHandler struct {
+	repoManager interfaces.RepositoryManager
+	log         *combatLog
+}
+
+func (h *CombatLogHandler) Execute(ctx *HandlerContext, cmd *Command) (*CommandResult, error) {
+	char := ctx.Character
+	if char == nil {
+		return Reply("Error retrieving character information."), nil
+	}
+
+	args := cmd.Args
+	subject, subjectID := "You have", char.ID
+	if len(args) > 0 {
+		if _, err := strconv.Atoi(args[0]); err != nil {
+			account, err := h.repoManager.Players().GetPlayer(char.PlayerID)
+			if err != nil || !account.IsModerator() {
+				return Reply("Usage: combatlog [count]"), nil
+			}
+			other, err := h.repoManager.Characters().GetCharacterByName(args[0])
+			if err != nil {
+				return Reply(fmt.Sprintf("There is no character named '%s'.", args[0])), nil
+			}
+			subject, subjectID = other.Name+" has", other.ID
+			args = args[1:]
+		}
+	}
+
+	count := combatLogShown
+	if len(args) > 0 {
+		n, err := strconv.Atoi(args[0])
+		if err != nil || n < 1 {
+			return Reply("Usage: combatlog [count]"), nil
+		}
+		count = min(n, combatLogSize)
+	}
+
+	lines := h.log.recent(subjectID, count)
+	if len(lines) == 0 {
+		return Reply(subject + " not fought recently."), nil
+	}
+	result := Reply(fmt.Sprintf("Combat log (last %d lines):", len(lines)))
+	for _, line := range lines {
+		result.Add(fmt.Sprintf("  [%s] %s", line.at.Format("15:04:05"), line.text))
+	}
+	return result, nil
+}
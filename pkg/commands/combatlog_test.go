@@ -0,0 +1,103 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+	"github.com/elidor/dungeogo/pkg/game/player"
+)
+
+func TestCombatLogKeepsTheLatestLines(t *testing.T) {
+	log := newCombatLog()
+	log.now = func() time.Time { return time.Date(2024, 1, 1, 12, 30, 5, 0, time.UTC) }
+	for i := 1; i <= combatLogSize+5; i++ {
+		log.record("char1", fmt.Sprintf("Blow %d", i), "")
+	}
+
+	lines := log.recent("char1", combatLogSize+10)
+	if len(lines) != combatLogSize || lines[0].text != "Blow 6" || lines[len(lines)-1].text != fmt.Sprintf("Blow %d", combatLogSize+5) {
+		t.Fatalf("Expected the last %d blows, oldest first, got %d from %q", combatLogSize, len(lines), lines[0].text)
+	}
+	if lines := log.recent("char1", 2); lines[0].text != fmt.Sprintf("Blow %d", combatLogSize+4) {
+		t.Errorf("Expected the two latest blows, got %v", lines)
+	}
+
+	log.forget("char1")
+	if lines := log.recent("char1", 5); len(lines) != 0 {
+		t.Errorf("Expected the log to be cleared, got %v", lines)
+	}
+}
+
+func TestCombatLogCommand(t *testing.T) {
+	executor, repos := newFightExecutor(t)
+	char := testCharacter("riverbank")
+	repos.characters.stored = map[string]*character.Character{char.ID: char}
+	ctx := &HandlerContext{Character: char}
+	parser := NewParser()
+	run := func(input string) []string {
+		result, err := executor.Execute(ctx, parser.Parse(input, char.PlayerID, char.ID))
+		if err != nil {
+			t.Fatalf("Unexpected error for %q: %v", input, err)
+		}
+		return result.Messages
+	}
+
+	if got := run("combatlog"); got[0] != "You have not fought recently." {
+		t.Errorf("Expected an empty log, got %v", got)
+	}
+
+	attack := run("kill goblin")
+	executor.LogCombat(char.ID, "A goblin hits you for 2 damage.")
+	run("score")
+
+	got := run("combatlog")
+	if len(got) != len(attack)+2 || !strings.HasSuffix(got[1], "] "+attack[0]) {
+		t.Errorf("Expected the attack and the goblin's blow but not the score, got %v", got)
+	}
+	if !strings.HasSuffix(got[len(got)-1], "] A goblin hits you for 2 damage.") {
+		t.Errorf("Expected the latest blow last, got %v", got)
+	}
+	if got := run("combatlog 1"); len(got) != 2 || got[0] != "Combat log (last 1 lines):" {
+		t.Errorf("Expected just the latest line, got %v", got)
+	}
+	if got := run("combatlog Alice"); got[0] != "Usage: combatlog [count]" {
+		t.Errorf("Expected players not to read others' logs, got %v", got)
+	}
+
+	repos.players.player.Role = player.RoleModerator
+	if got := run("combatlog Alice 1"); len(got) != 2 || !strings.HasSuffix(got[1], "A goblin hits you for 2 damage.") {
+		t.Errorf("Expected a moderator to read Alice's log, got %v", got)
+	}
+
+	executor.ForgetCombatLog(char.ID)
+	if got := run("combatlog"); got[0] != "You have not fought recently." {
+		t.Errorf("Expected the log to be cleared on leaving, got %v", got)
+	}
+}
+
+func TestCombatLogRecordsSpells(t *testing.T) {
+	executor, repos := newFightExecutor(t)
+	executor.handlers["cast"].(*CastHandler).roll = func(n int) int { return 0 }
+	stopCastClock(executor)
+	char := testMage("riverbank")
+	repos.characters.stored = map[string]*character.Character{char.ID: char}
+	ctx := &HandlerContext{Character: char}
+	parser := NewParser()
+	run := func(input string) []string {
+		result, err := executor.Execute(ctx, parser.Parse(input, char.PlayerID, char.ID))
+		if err != nil {
+			t.Fatalf("Unexpected error for %q: %v", input, err)
+		}
+		return result.Messages
+	}
+
+	run("prepare magic missile")
+	cast := run("cast magic missile goblin")
+	got := run("combatlog")
+	if len(got) != len(cast)+1 || !strings.HasSuffix(got[1], "] "+cast[0]) {
+		t.Errorf("Expected the spell but not preparing it, got %v", got)
+	}
+}
@@ -0,0 +1,116 @@
+package commands
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/elidor/dungeogo/pkg/game/achievement"
+	"github.com/elidor/dungeogo/pkg/game/character"
+	"github.com/elidor/dungeogo/pkg/game/magic"
+	"github.com/elidor/dungeogo/pkg/game/quest"
+	"github.com/elidor/dungeogo/pkg/persistence/interfaces"
+)
+
+// Messenger reaches connected players directly, for handlers that need to
+// know who is around rather than just queue messages on their result. The
+// server's connection manager implements it.
+type Messenger interface {
+	SendToCharacter(characterID, message string) bool
+	BroadcastToRoom(roomID, message string, excludeCharacterIDs ...string)
+	CharactersInRoom(roomID string) []string
+	OnlineCharacterIDs() []string
+}
+
+// SessionClock is implemented by messengers that know when each connected
+// character entered the game.
+type SessionClock interface {
+	SessionStart(characterID string) (time.Time, bool)
+}
+
+// RoomTracker is implemented by messengers that keep track of which room
+// each character is in, so moves the world makes between commands, such as
+// a death or a recall, reach them straight away.
+type RoomTracker interface {
+	SetCharacterRoom(characterID, roomID string)
+}
+
+// sessionLength returns how long characterID has been in the game, if the
+// messenger keeps track
+func sessionLength(messenger Messenger, characterID string, now time.Time) (time.Duration, bool) {
+	clock, ok := messenger.(SessionClock)
+	if !ok {
+		return 0, false
+	}
+	start, ok := clock.SessionStart(characterID)
+	if !ok || start.IsZero() {
+		return 0, false
+	}
+	return now.Sub(start), true
+}
+
+// NopMessenger is used when no players are connected, such as in tests.
+type NopMessenger struct{}
+
+func (NopMessenger) SendToCharacter(characterID, message string) bool { return false }
+
+func (NopMessenger) BroadcastToRoom(roomID, message string, excludeCharacterIDs ...string) {}
+
+func (NopMessenger) CharactersInRoom(roomID string) []string { return nil }
+
+func (NopMessenger) OnlineCharacterIDs() []string { return nil }
+
+// HandlerContext is the game state a command runs against. Handlers change
+// Character in place and the engine saves it afterwards as the command's
+// SavePolicy says, so handlers must not save it again themselves.
+type HandlerContext struct {
+	Character *character.Character
+	Room      *interfaces.RoomState
+	Messenger Messenger
+}
+
+// RoomID returns the acting character's room, or "" if it is unknown.
+func (ctx *HandlerContext) RoomID() string {
+	if ctx.Character == nil || ctx.Character.Location == nil {
+		return ""
+	}
+	return ctx.Character.Location.RoomID
+}
+
+// ActorName is how other players see the acting character named.
+func (ctx *HandlerContext) ActorName() string {
+	if ctx.Character == nil {
+		return "Someone"
+	}
+	return ctx.Character.Name
+}
+
+// LoadContext loads the character and room a command from characterID runs
+// against.
+func (e *Executor) LoadContext(characterID string) (*HandlerContext, error) {
+	char, err := e.repoManager.Characters().GetCharacter(characterID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load character: %w", err)
+	}
+	if char.Quests == nil {
+		char.Quests = quest.NewLog()
+	}
+	if char.Achievements == nil {
+		char.Achievements = achievement.NewLog()
+	}
+	if char.Spellbook == nil {
+		char.Spellbook = magic.NewSpellbook()
+	}
+	// Bonuses from equipment are not saved, and follow any change to the
+	// items' templates
+	char.ApplyEquipmentBonuses(e.itemFactory)
+
+	ctx := &HandlerContext{Character: char, Messenger: e.messenger}
+	if roomID := ctx.RoomID(); roomID != "" {
+		room, err := e.repoManager.World().LoadRoomState(roomID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load room: %w", err)
+		}
+		ctx.Room = room
+	}
+	return ctx, nil
+}
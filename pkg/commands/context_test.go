@@ -0,0 +1,47 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+)
+
+func TestHandlerContextWithoutCharacter(t *testing.T) {
+	ctx := &HandlerContext{Messenger: NopMessenger{}}
+
+	if ctx.RoomID() != "" {
+		t.Errorf("Expected no room, got %q", ctx.RoomID())
+	}
+	if ctx.ActorName() != "Someone" {
+		t.Errorf("Expected anonymous actor, got %q", ctx.ActorName())
+	}
+}
+
+func TestHandlerContextWithCharacter(t *testing.T) {
+	race, _ := character.GetRaceByID("human")
+	class, _ := character.GetClassByID("warrior")
+	char := character.NewCharacter("player1", "Alice", race, class)
+	ctx := &HandlerContext{Character: char, Messenger: NopMessenger{}}
+
+	if ctx.RoomID() != char.Location.RoomID {
+		t.Errorf("Expected room %q, got %q", char.Location.RoomID, ctx.RoomID())
+	}
+	if ctx.ActorName() != "Alice" {
+		t.Errorf("Expected Alice, got %q", ctx.ActorName())
+	}
+}
+
+func TestSayUsesContextCharacter(t *testing.T) {
+	race, _ := character.GetRaceByID("human")
+	class, _ := character.GetClassByID("warrior")
+	char := character.NewCharacter("player1", "Alice", race, class)
+	ctx := &HandlerContext{Character: char, Messenger: NopMessenger{}}
+
+	result, err := (&SayHandler{}).Execute(ctx, &Command{Verb: "say", Args: []string{"hi"}, CharacterID: char.ID})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(result.Room) != 1 || result.Room[0].Text != "Alice says: hi" {
+		t.Errorf("Unexpected room messages: %+v", result.Room)
+	}
+}
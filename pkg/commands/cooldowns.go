@@ -0,0 +1,23 @@
+package commands
+
+import (
+	"time"
+
+	"github.com/elidor/dungeogo/pkg/game/magic"
+	"github.com/elidor/dungeogo/pkg/textutil"
+)
+
+// recallCooldownKey is the cooldown recall is kept under
+const recallCooldownKey = "recall"
+
+// spellCooldownKey returns the cooldown casting spell is kept under. Magic
+// class abilities with the spell's ID share it.
+func spellCooldownKey(spell *magic.Spell) string {
+	return "spell:" + spell.ID
+}
+
+// waitText describes how long is left of a cooldown. It rounds up to whole
+// seconds, so that a fraction of a second isn't shown as no wait at all.
+func waitText(wait time.Duration) string {
+	return textutil.Duration((wait + time.Second - 1).Truncate(time.Second))
+}
@@ -0,0 +1,130 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+	"github.com/elidor/dungeogo/pkg/game/crafting"
+	"github.com/elidor/dungeogo/pkg/game/event"
+	"github.com/elidor/dungeogo/pkg/game/items"
+	"github.com/elidor/dungeogo/pkg/persistence/interfaces"
+)
+
+type CraftHandler struct {
+	repoManager interfaces.RepositoryManager
+	factory     *items.ItemFactory
+	recipes     *crafting.RecipeRegistry
+	events      *event.Bus
+	experience  *experienceRules
+	// roll returns a number from 0 to n-1
+	roll func(n int) int
+}
+
+func (h *CraftHandler) Execute(ctx *HandlerContext, cmd *Command) (*CommandResult, error) {
+	char := ctx.Character
+	if char == nil {
+		return Reply("Error retrieving character information."), nil
+	}
+	skill := char.Skills.GetEffectiveSkillLevel(character.SkillCrafting)
+
+	if len(cmd.Args) == 0 {
+		return Reply(h.listRecipes(skill)...), nil
+	}
+
+	name := strings.Join(cmd.Args, " ")
+	recipe, err := h.recipes.FindRecipe(name)
+	if err != nil {
+		return Reply(fmt.Sprintf("You don't know how to craft %s.", name)), nil
+	}
+
+	// Never melt down what the character is wearing
+	available, err := h.repoManager.Items().GetCarriedItems(char.ID)
+	if err != nil {
+		return Reply("Error retrieving inventory."), nil
+	}
+
+	result, err := crafting.Attempt(recipe, skill, available, h.roll(100))
+	switch {
+	case errors.Is(err, crafting.ErrSkillTooLow):
+		return Reply(fmt.Sprintf("You need at least %d in Crafting to make %s.", recipe.MinSkill, recipe.Name)), nil
+	case errors.Is(err, crafting.ErrMissingMaterials):
+		return Reply(fmt.Sprintf("You need %s to make %s.", h.describeInputs(recipe), recipe.Name)), nil
+	case err != nil:
+		return Reply("Error crafting item."), nil
+	}
+
+	// The output is made before the materials go, so a failure part way
+	// never costs the character materials for nothing
+	var output *items.ItemInstance
+	if result.Success {
+		if output, err = h.factory.CreateInstance(recipe.Output, char.ID, recipe.OutputQuantity); err != nil {
+			return Reply("Error crafting item."), nil
+		}
+		if err := h.repoManager.Items().CreateItemInstance(output); err != nil {
+			return Reply("Error crafting item."), nil
+		}
+	}
+	if err := h.consume(available, result.Consumed); err != nil {
+		if output != nil {
+			h.repoManager.Items().DeleteItemInstance(output.ID)
+		}
+		return Reply("Error crafting item."), nil
+	}
+
+	var response []string
+	if result.Success {
+		response = append(response, fmt.Sprintf("You craft %s.", itemName(h.factory, output)))
+		if h.events != nil {
+			response = append(response, h.events.Publish(event.Craft{Character: char, TemplateID: recipe.Output})...)
+		}
+	} else if len(result.Consumed) > 0 {
+		response = append(response, fmt.Sprintf("Your attempt to craft %s fails, spoiling some of your materials.", recipe.Name))
+	} else {
+		response = append(response, fmt.Sprintf("Your attempt to craft %s fails.", recipe.Name))
+	}
+
+	if result.Experience > 0 && h.experience.train(char, character.SkillCrafting, result.Experience) {
+		response = append(response, fmt.Sprintf("Your Crafting skill improves to %d.",
+			char.Skills.GetSkillLevel(character.SkillCrafting)))
+	}
+
+	return Reply(response...), nil
+}
+
+func (h *CraftHandler) listRecipes(skill int) []string {
+	response := []string{"You know how to craft:"}
+	for _, recipe := range h.recipes.GetAllRecipes() {
+		line := fmt.Sprintf("  %s - %s", recipe.Name, h.describeInputs(recipe))
+		if recipe.MinSkill > skill {
+			line += fmt.Sprintf(" (needs Crafting %d)", recipe.MinSkill)
+		}
+		response = append(response, line)
+	}
+	return append(response, "Use 'craft <recipe>' to make one.")
+}
+
+func (h *CraftHandler) describeInputs(recipe *crafting.Recipe) string {
+	var parts []string
+	for _, input := range recipe.Inputs {
+		name := input.TemplateID
+		if template, err := h.factory.GetTemplate(input.TemplateID); err == nil {
+			name = template.Name
+		}
+		parts = append(parts, fmt.Sprintf("%d %s", input.Quantity, name))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// consume removes the used quantities from carried stacks.
+func (h *CraftHandler) consume(carried []*items.ItemInstance, used map[string]int) error {
+	for _, item := range carried {
+		if quantity, ok := used[item.ID]; ok {
+			if err := removeItem(h.repoManager, item, quantity); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package commands
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCraftKeepsMaterialsOnFailure(t *testing.T) {
+	executor, repos := newFightExecutor(t)
+	executor.handlers["craft"].(*CraftHandler).roll = func(n int) int { return 0 }
+	char := testCharacter("riverbank")
+	ore, _ := executor.itemFactory.CreateInstance("iron_ore", char.ID, 3)
+	repos.items.CreateItemInstance(ore)
+	repos.items.updateErr = errors.New("database is down")
+
+	result, err := executor.handlers["craft"].Execute(&HandlerContext{Character: char}, &Command{Verb: "craft", Args: []string{"iron", "ingot"}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.Messages[0] != "Error crafting item." {
+		t.Errorf("Expected the craft to fail, got %v", result.Messages)
+	}
+	counts := make(map[string]int)
+	for _, item := range repos.items.items {
+		counts[item.TemplateID] += item.Quantity
+	}
+	if counts["iron_ore"] != 3 || counts["iron_ingot"] != 0 {
+		t.Errorf("Expected the ore kept and no ingot made, got %v", counts)
+	}
+}
@@ -0,0 +1,128 @@
+package commands
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+	"github.com/elidor/dungeogo/pkg/game/event"
+	"github.com/elidor/dungeogo/pkg/game/items"
+	"github.com/elidor/dungeogo/pkg/game/world"
+	"github.com/elidor/dungeogo/pkg/persistence/interfaces"
+	"github.com/elidor/dungeogo/pkg/textutil"
+)
+
+// SetDeathPenalty sets what dying to an NPC costs
+func (e *Executor) SetDeathPenalty(penalty character.DeathPenalty) {
+	e.death = penalty
+}
+
+// Die handles a character killed by an NPC called killer: the death penalty
+// is taken, their fights end, what they carried is left in a corpse where
+// they fell and they wake up where they first started. It returns the lines
+// telling them what happened.
+func (e *Executor) Die(char *character.Character, killer string, now time.Time) ([]string, error) {
+	deathRoom := char.Location.RoomID
+	experience, gold := char.Die(e.death)
+	e.npcs.Disengage(char.ID)
+	e.stances.Clear(char.ID)
+	e.follow.Stop(char.ID)
+	e.ForgetTarget(char.ID)
+
+	response := []string{"You have died!"}
+	if experience > 0 {
+		response = append(response, fmt.Sprintf("You lose %d experience.", experience))
+	}
+	if gold > 0 {
+		response = append(response, fmt.Sprintf("You lose %d gold.", gold))
+	}
+
+	if e.death.Corpse {
+		left, err := e.leaveCorpse(char, deathRoom, now)
+		if err != nil {
+			return nil, fmt.Errorf("failed to leave corpse: %w", err)
+		}
+		if left {
+			response = append(response, fmt.Sprintf("Your belongings lie with your corpse in %s.", world.RoomName(deathRoom)))
+		}
+	}
+
+	destination, err := world.GetRoom(e.starts.StartLocation(char).RoomID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find respawn room: %w", err)
+	}
+	ctx := &HandlerContext{Character: char, Messenger: e.messenger}
+	if _, err := enterRoom(e.repoManager, ctx, destination); err != nil {
+		return nil, fmt.Errorf("failed to move character: %w", err)
+	}
+	response = append(response, fmt.Sprintf("You awaken in %s, weak but alive.", destination.Name))
+	response = append(response, e.events.Publish(event.Death{Character: char, Killer: killer, RoomID: deathRoom})...)
+	if err := e.repoManager.Characters().UpdateCharacter(char); err != nil {
+		return nil, fmt.Errorf("failed to save character: %w", err)
+	}
+
+	return response, nil
+}
+
+// leaveCorpse moves everything the character carries, apart from what they
+// wear, into a new corpse in roomID. No corpse is left if they carry
+// nothing. It reports whether a corpse was left.
+func (e *Executor) leaveCorpse(char *character.Character, roomID string, now time.Time) (bool, error) {
+	dropped, err := e.repoManager.Items().GetCarriedItems(char.ID)
+	if err != nil {
+		return false, err
+	}
+	if len(dropped) == 0 {
+		return false, nil
+	}
+
+	corpse := items.NewCorpse(char.ID, char.Name, roomID, now.Add(e.death.CorpseDecay))
+	if err := e.repoManager.Items().CreateItemInstance(corpse); err != nil {
+		return false, err
+	}
+	for _, item := range dropped {
+		if err := e.repoManager.Items().TransferItem(item.ID, corpse.ID); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// DecayCorpses scatters the contents of every corpse whose time has run out
+// onto the floor of its room and removes the corpse. It returns the line to
+// show each room a corpse decayed in, by room ID.
+func (e *Executor) DecayCorpses(now time.Time) (map[string][]string, error) {
+	decayed := make(map[string][]string)
+	for _, roomID := range world.RoomIDs() {
+		floor, err := e.repoManager.Items().GetRoomItems(roomID)
+		if err != nil {
+			return decayed, fmt.Errorf("failed to load items in %s: %w", roomID, err)
+		}
+		for _, item := range floor {
+			if !item.IsCorpse() || !item.CorpseDecayed(now) {
+				continue
+			}
+			if err := emptyCorpse(e.repoManager, item, roomID); err != nil {
+				return decayed, fmt.Errorf("failed to decay corpse %s: %w", item.ID, err)
+			}
+			decayed[roomID] = append(decayed[roomID],
+				fmt.Sprintf("%s crumbles to dust, scattering its contents.", textutil.Capitalize(item.CustomName)))
+		}
+	}
+	return decayed, nil
+}
+
+// emptyCorpse moves everything in corpse to ownerID, a character or a room,
+// and removes the corpse.
+func emptyCorpse(repoManager interfaces.RepositoryManager, corpse *items.ItemInstance, ownerID string) error {
+	contents, err := repoManager.Items().GetPlayerItems(corpse.ID)
+	if err != nil {
+		return err
+	}
+	for _, item := range contents {
+		if err := repoManager.Items().TransferItem(item.ID, ownerID); err != nil {
+			return err
+		}
+	}
+	return repoManager.Items().DeleteItemInstance(corpse.ID)
+}
@@ -0,0 +1,146 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+	"github.com/elidor/dungeogo/pkg/game/items"
+)
+
+func TestDieLeavesCorpse(t *testing.T) {
+	executor, repos := newFightExecutor(t)
+	char := testCharacter("riverbank")
+	char.Experience, char.Gold = 50, 40
+	sword, _ := executor.itemFactory.CreateInstance("rusty_sword", char.ID, 1)
+	potion, _ := executor.itemFactory.CreateInstance("health_potion", char.ID, 1)
+	repos.items.CreateItemInstance(sword)
+	repos.items.CreateItemInstance(potion)
+	char.Equip(items.SlotMainHand, sword)
+	repos.characters.UpdateCharacter(char)
+
+	goblin := executor.NPCs().Find("riverbank", "goblin")
+	executor.NPCs().Engage(goblin.ID, char.ID)
+
+	now := time.Now()
+	lines, err := executor.Die(char, "a goblin", now)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := []string{
+		"You have died!",
+		"You lose 5 experience.",
+		"You lose 4 gold.",
+		"Your belongings lie with your corpse in Riverbank.",
+		"You awaken in A Simple Room, weak but alive.",
+	}
+	if strings.Join(lines, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Expected %v, got %v", expected, lines)
+	}
+	if char.Location.RoomID != character.DefaultStartRoomID || char.DeathCount != 1 {
+		t.Errorf("Expected one death and a respawn, got %d deaths in %s", char.DeathCount, char.Location.RoomID)
+	}
+	if goblin.Target != "" {
+		t.Errorf("Expected the goblin to stop fighting the dead, got target %q", goblin.Target)
+	}
+
+	floor, _ := repos.items.GetRoomItems("riverbank")
+	if len(floor) != 1 || !floor[0].IsCorpse() || floor[0].CustomName != "the corpse of Alice" {
+		t.Fatalf("Expected a corpse at the riverbank, got %v", floor)
+	}
+	corpse := floor[0]
+	if potion.OwnerID != corpse.ID || sword.OwnerID != char.ID {
+		t.Errorf("Expected the potion in the corpse and the worn sword kept, got %s and %s", potion.OwnerID, sword.OwnerID)
+	}
+
+	// Only the owner may take their belongings back
+	bob := testCharacter("riverbank")
+	bob.ID, bob.Name = "char2", "Bob"
+	get := func(who *character.Character) string {
+		result, err := executor.handlers["get"].Execute(&HandlerContext{Character: who}, &Command{Verb: "get", Args: []string{"corpse"}})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		return strings.Join(result.Messages, "\n")
+	}
+	if got := get(bob); got != "You can't carry a corpse." {
+		t.Errorf("Expected Bob to be refused, got %q", got)
+	}
+	char.Location.RoomID = "riverbank"
+	if got := get(char); got != "You recover your belongings from your corpse." {
+		t.Errorf("Expected Alice to recover the belongings, got %q", got)
+	}
+	if potion.OwnerID != char.ID {
+		t.Errorf("Expected the potion back, got owner %s", potion.OwnerID)
+	}
+	if floor, _ := repos.items.GetRoomItems("riverbank"); len(floor) != 0 {
+		t.Errorf("Expected the corpse to be gone, got %v", floor)
+	}
+}
+
+func TestDecayCorpses(t *testing.T) {
+	executor, repos := newFightExecutor(t)
+	char := testCharacter("riverbank")
+	potion, _ := executor.itemFactory.CreateInstance("health_potion", char.ID, 1)
+	repos.items.CreateItemInstance(potion)
+
+	now := time.Now()
+	if _, err := executor.Die(char, "a goblin", now); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	decayed, err := executor.DecayCorpses(now.Add(character.DefaultCorpseDecay - time.Second))
+	if err != nil || len(decayed) != 0 {
+		t.Fatalf("Expected no corpse to decay early, got %v (%v)", decayed, err)
+	}
+
+	decayed, err = executor.DecayCorpses(now.Add(character.DefaultCorpseDecay))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if lines := decayed["riverbank"]; len(lines) != 1 || lines[0] != "The corpse of Alice crumbles to dust, scattering its contents." {
+		t.Errorf("Expected the riverbank to see the corpse decay, got %v", decayed)
+	}
+	floor, _ := repos.items.GetRoomItems("riverbank")
+	if len(floor) != 1 || floor[0].ID != potion.ID {
+		t.Errorf("Expected only the potion left on the floor, got %v", floor)
+	}
+}
+
+func TestDieWithoutCorpse(t *testing.T) {
+	executor, repos := newFightExecutor(t)
+	executor.SetDeathPenalty(character.DeathPenalty{})
+	char := testCharacter("riverbank")
+	char.Gold = 40
+	potion, _ := executor.itemFactory.CreateInstance("health_potion", char.ID, 1)
+	repos.items.CreateItemInstance(potion)
+
+	lines, err := executor.Die(char, "a goblin", time.Now())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if strings.Join(lines, "\n") != "You have died!\nYou awaken in A Simple Room, weak but alive." {
+		t.Errorf("Unexpected lines %v", lines)
+	}
+	if char.Gold != 40 || potion.OwnerID != char.ID {
+		t.Errorf("Expected to keep gold and items with penalties off")
+	}
+}
+
+func TestDieReturnsToStartLocation(t *testing.T) {
+	executor, _ := newFightExecutor(t)
+	char := testCharacter("riverbank")
+	char.Race, _ = character.GetRaceByID("dwarf")
+
+	lines, err := executor.Die(char, "a goblin", time.Now())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if char.Location.RoomID != character.DwarfStartRoomID {
+		t.Errorf("Expected a dwarf to awaken in the hold, got %s", char.Location.RoomID)
+	}
+	if last := lines[len(lines)-1]; last != "You awaken in The Great Hall of Ironpeak, weak but alive." {
+		t.Errorf("Unexpected awakening %q", last)
+	}
+}
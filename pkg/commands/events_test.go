@@ -0,0 +1,83 @@
+package commands
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+	"github.com/elidor/dungeogo/pkg/game/event"
+	"github.com/elidor/dungeogo/pkg/game/quest"
+)
+
+func TestKillPublishesEvent(t *testing.T) {
+	executor, _ := newFightExecutor(t)
+	char := testCharacter("riverbank")
+	var kills []event.Kill
+	executor.Events().Subscribe(event.TypeKill, func(e event.Event) []string {
+		kills = append(kills, e.(event.Kill))
+		return []string{"The goblin's tribe will remember this."}
+	})
+
+	messages := killUntilDead(t, executor, &HandlerContext{Character: char}, "goblin")
+	if len(kills) != 1 || kills[0].TemplateID != "goblin" || kills[0].RoomID != "riverbank" || kills[0].Character != char {
+		t.Fatalf("Expected one goblin kill at the riverbank, got %v", kills)
+	}
+	if !slices.Contains(messages, "The goblin's tribe will remember this.") {
+		t.Errorf("Expected the subscriber's line in the reply, got %v", messages)
+	}
+}
+
+func TestKillCountsTowardsQuest(t *testing.T) {
+	executor, _ := newFightExecutor(t)
+	char := testCharacter("riverbank")
+	q, _ := quest.GetQuestByID("goblin_menace")
+	char.Quests.Accept(q)
+
+	messages := killUntilDead(t, executor, &HandlerContext{Character: char}, "goblin")
+	if !slices.Contains(messages, "[Goblin Menace] Kill goblins: 1/3") {
+		t.Errorf("Expected the kill to count towards the quest, got %v", messages)
+	}
+}
+
+func TestAwardExperiencePublishesLevelUps(t *testing.T) {
+	bus := event.NewBus()
+	var levels []int
+	bus.Subscribe(event.TypeLevelUp, func(e event.Event) []string {
+		levels = append(levels, e.(event.LevelUp).Level)
+		return nil
+	})
+	rules := &experienceRules{events: bus}
+	char := testCharacter(character.DefaultStartRoomID)
+
+	rules.award(char, 50)
+	if len(levels) != 0 {
+		t.Errorf("Expected no level-up yet, got %v", levels)
+	}
+	rules.award(char, character.ExperienceForLevel(2)+character.ExperienceForLevel(3))
+	if !slices.Equal(levels, []int{2, 3}) {
+		t.Errorf("Expected levels 2 and 3 announced, got %v", levels)
+	}
+}
+
+func TestDiePublishesDeath(t *testing.T) {
+	executor, _ := newFightExecutor(t)
+	char := testCharacter("riverbank")
+	var deaths []event.Death
+	executor.Events().Subscribe(event.TypeDeath, func(e event.Event) []string {
+		deaths = append(deaths, e.(event.Death))
+		return []string{"You feel the loss keenly."}
+	})
+
+	lines, err := executor.Die(char, "a goblin", time.Now())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(deaths) != 1 || deaths[0].Killer != "a goblin" || deaths[0].RoomID != "riverbank" {
+		t.Fatalf("Expected one death at the riverbank, got %v", deaths)
+	}
+	if last := lines[len(lines)-1]; last != "You feel the loss keenly." {
+		t.Errorf("Expected the subscriber's line last, got %v", strings.Join(lines, "\n"))
+	}
+}
@@ -1,98 +1,513 @@
 package commands
 
 import (
+	"errors"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 	
+	"github.com/elidor/dungeogo/pkg/game/character"
+	"github.com/elidor/dungeogo/pkg/game/combat"
+	"github.com/elidor/dungeogo/pkg/game/cooldown"
+	"github.com/elidor/dungeogo/pkg/game/crafting"
+	"github.com/elidor/dungeogo/pkg/game/dice"
+	"github.com/elidor/dungeogo/pkg/game/event"
+	"github.com/elidor/dungeogo/pkg/game/follow"
+	"github.com/elidor/dungeogo/pkg/game/items"
+	"github.com/elidor/dungeogo/pkg/game/lock"
+	"github.com/elidor/dungeogo/pkg/game/npc"
+	"github.com/elidor/dungeogo/pkg/game/player"
+	"github.com/elidor/dungeogo/pkg/game/quest"
+	"github.com/elidor/dungeogo/pkg/game/reward"
+	"github.com/elidor/dungeogo/pkg/game/stealth"
+	"github.com/elidor/dungeogo/pkg/game/world"
 	"github.com/elidor/dungeogo/pkg/persistence/interfaces"
+	"github.com/elidor/dungeogo/pkg/textutil"
 )
 
+// fishingDelay is how long a cast takes to come to anything
+const fishingDelay = 3 * time.Second
+
 type Executor struct {
 	repoManager interfaces.RepositoryManager
+	itemFactory *items.ItemFactory
+	messenger   Messenger
+	stealth     *stealth.Tracker
+	follow      *follow.Tracker
+	stances     *combat.Stances
+	fights      *fights
+	targets     *targetMemory
+	replies     *replyMemory
+	afk         *afkMemory
+	combatLog   *combatLog
+	experience  *experienceRules
+	pvp         *pvpRules
+	death       character.DeathPenalty
+	starts      *character.StartLocations
+	description *DescriptionHandler
+	reload      *ReloadHandler
+	keybindings *keybindingCache
+	npcs        *npc.Manager
+	// dice is where every handler's rolls come from
+	dice        *dice.Source
+	locks       *character.Locks
+	// cooldowns holds back actions that need time to recover between uses
+	cooldowns   *cooldown.Tracker
+	events      *event.Bus
 	handlers    map[string]CommandHandler
 }
 
 type CommandHandler interface {
-	Execute(cmd *Command) ([]string, error)
-}
-
-type CommandResponse struct {
-	Messages []string
-	Error    error
+	Execute(ctx *HandlerContext, cmd *Command) (*CommandResult, error)
 }
 
 func NewExecutor(repoManager interfaces.RepositoryManager) *Executor {
+	events := event.NewBus()
 	e := &Executor{
 		repoManager: repoManager,
+		itemFactory: items.NewItemFactory(),
+		messenger:   NopMessenger{},
+		stealth:     stealth.NewTracker(),
+		follow:      follow.NewTracker(),
+		stances:     combat.NewStances(),
+		targets:     newTargetMemory(),
+		replies:     newReplyMemory(),
+		afk:         newAfkMemory(),
+		combatLog:   newCombatLog(),
+		experience:  &experienceRules{events: events, rewards: reward.NewMultiplier(reward.Normal)},
+		pvp:         &pvpRules{},
+		death:       character.DefaultDeathPenalty(),
+		starts:      character.DefaultStartLocations(),
+		keybindings: newKeybindingCache(),
+		npcs:        npc.NewManager(repoManager),
+		dice:        dice.NewTimeSeeded(),
+		locks:       character.NewLocks(),
+		cooldowns:   cooldown.NewTracker(cooldown.DefaultGlobal),
+		events:      events,
 		handlers:    make(map[string]CommandHandler),
 	}
+	e.npcs.SetRoll(e.dice.Intn)
+	e.fights = &fights{repoManager: repoManager, npcs: e.npcs, stances: e.stances, duels: combat.NewDuels(), locks: e.locks}
+	e.events.Subscribe(event.TypeKill, questKill)
+	e.events.Subscribe(event.TypeLevelUp, learnSpells)
+	e.events.Subscribe(event.TypeLogin, e.experience.announceRates)
+	e.events.Subscribe(event.TypeLogin, e.pvp.announceProtection)
+	e.events.Subscribe(event.TypeLevelUp, e.pvp.endProtection)
+	(&achievementTracker{repoManager: repoManager, factory: e.itemFactory, now: time.Now}).subscribe(e.events)
 	
 	e.initializeHandlers()
 	return e
 }
 
-func (e *Executor) Execute(cmd *Command) ([]string, error) {
+// NPCs returns the manager of the world's NPCs
+func (e *Executor) NPCs() *npc.Manager {
+	return e.npcs
+}
+
+// Items returns the factory of item templates and instances
+func (e *Executor) Items() *items.ItemFactory {
+	return e.itemFactory
+}
+
+// Stealth returns the tracker of hidden characters
+func (e *Executor) Stealth() *stealth.Tracker {
+	return e.stealth
+}
+
+// Follows returns the tracker of who is following whom
+func (e *Executor) Follows() *follow.Tracker {
+	return e.follow
+}
+
+// Stances returns the tracker of who is fighting defensively
+func (e *Executor) Stances() *combat.Stances {
+	return e.stances
+}
+
+// SettleFight ends the fights of the character in ctx with players no
+// longer in their room, as after they move or flee, and takes them out of
+// combat if nobody is fighting them any more. It reports whether they left
+// combat.
+func (e *Executor) SettleFight(ctx *HandlerContext) bool {
+	e.fights.leave(ctx.Messenger, ctx.Character, ctx.Messenger.CharactersInRoom(ctx.RoomID()))
+	return e.fights.settle(ctx.Character)
+}
+
+// LeaveFights ends every fight char, whose lock is held, has with other
+// players, as when they leave the game. It returns an NPC still fighting
+// them, if any, or otherwise takes them out of combat, reporting whether
+// they left it.
+func (e *Executor) LeaveFights(char *character.Character) (*npc.NPC, bool) {
+	e.fights.leave(e.messenger, char, nil)
+	if foe := e.npcs.Foe(char.ID); foe != nil {
+		return foe, false
+	}
+	return nil, e.fights.settle(char)
+}
+
+// ForgetTarget clears the target "it" refers to for characterID, as when
+// they leave the game
+func (e *Executor) ForgetTarget(characterID string) {
+	e.targets.forget(characterID)
+}
+
+// Edit passes a line of input to the character's open description editor.
+// It reports false if they have no editor open, so the line is a command.
+func (e *Executor) Edit(ctx *HandlerContext, input string) (*CommandResult, bool, error) {
+	return e.description.edit(ctx, input)
+}
+
+// CloseEditor discards the description characterID was writing, if any, as
+// when they leave the game
+func (e *Executor) CloseEditor(characterID string) {
+	e.description.discard(characterID)
+}
+
+// ForgetReplies clears who last sent characterID a tell, as when they leave
+// the game
+func (e *Executor) ForgetReplies(characterID string) {
+	e.replies.forget(characterID)
+}
+
+// LogCombat adds lines to characterID's combat log, for what happens in a
+// fight outside their commands, such as an NPC's blows
+func (e *Executor) LogCombat(characterID string, lines ...string) {
+	e.combatLog.record(characterID, lines...)
+}
+
+// ForgetCombatLog clears characterID's combat log, as when they leave the
+// game
+func (e *Executor) ForgetCombatLog(characterID string) {
+	e.combatLog.forget(characterID)
+}
+
+// ForgetAfk drops characterID's AFK message, as when they leave the game
+func (e *Executor) ForgetAfk(characterID string) {
+	e.afk.forget(characterID)
+}
+
+// ReturnFromAfk brings char back from being AFK, as their input shows they
+// are, reporting whether they were away.
+func (e *Executor) ReturnFromAfk(char *character.Character) bool {
+	e.afk.forget(char.ID)
+	if char.State != character.CharacterAfk {
+		return false
+	}
+	char.State = character.CharacterAlive
+	return true
+}
+
+// LoadKeybindings loads the keybindings of char's player, to be expanded in
+// their commands until ForgetKeybindings is called.
+func (e *Executor) LoadKeybindings(char *character.Character) error {
+	p, err := e.repoManager.Players().GetPlayer(char.PlayerID)
+	if err != nil {
+		return fmt.Errorf("failed to get player: %w", err)
+	}
+	e.keybindings.store(char.ID, p.Preferences.Keybindings)
+	return nil
+}
+
+// ExpandKeybinding returns the command characterID has bound to input, or
+// input unchanged if it is not a bound key.
+func (e *Executor) ExpandKeybinding(characterID, input string) string {
+	return e.keybindings.expand(characterID, input)
+}
+
+// ForgetKeybindings drops characterID's keybindings, as when they leave the
+// game
+func (e *Executor) ForgetKeybindings(characterID string) {
+	e.keybindings.forget(characterID)
+}
+
+// SetMessenger gives handlers a way to reach connected players
+func (e *Executor) SetMessenger(messenger Messenger) {
+	e.messenger = messenger
+}
+
+// SetLevelingMode sets whether characters level up automatically or must
+// gain each level at a trainer
+func (e *Executor) SetLevelingMode(mode character.LevelingMode) {
+	e.experience.mode = mode
+}
+
+// SetRewardRates sets the server's base experience and gold multipliers,
+// which reward events can override
+func (e *Executor) SetRewardRates(rates reward.Rates) {
+	e.experience.rewards.SetBase(rates)
+}
+
+// RewardRates returns the experience and gold multipliers in force
+func (e *Executor) RewardRates() reward.Rates {
+	return e.experience.rates()
+}
+
+// RefreshRewards applies the reward events running now to the base rates
+func (e *Executor) RefreshRewards() error {
+	events, err := e.repoManager.World().GetActiveWorldEvents()
+	if err != nil {
+		return fmt.Errorf("failed to load world events: %w", err)
+	}
+	e.experience.rewards.Refresh(events)
+	return nil
+}
+
+// SetPvPMode sets whether and when players may attack each other
+func (e *Executor) SetPvPMode(mode combat.PvPMode) {
+	e.pvp.mode = mode
+}
+
+// SetNewbieProtection sets which new characters are shielded from other
+// players and from NPCs far above their level
+func (e *Executor) SetNewbieProtection(protection combat.NewbieProtection) {
+	e.pvp.protection = protection
+}
+
+// NewbieProtection returns the protection new characters are under
+func (e *Executor) NewbieProtection() combat.NewbieProtection {
+	return e.pvp.protection
+}
+
+// SetReloader lets administrators reload content by name with the reload
+// command
+func (e *Executor) SetReloader(name string, reloader Reloader) {
+	e.reload.add(name, reloader)
+}
+
+// SetStartLocations sets where characters begin, and so where they return
+// after the tutorial, a death or a recall
+func (e *Executor) SetStartLocations(starts *character.StartLocations) {
+	*e.starts = *starts
+}
+
+// SetSeed restarts the game's random numbers from seed, so the same
+// commands have the same outcomes each run
+func (e *Executor) SetSeed(seed int64) {
+	e.dice.Seed(seed)
+}
+
+// Cooldowns returns the tracker of actions recovering between uses
+func (e *Executor) Cooldowns() *cooldown.Tracker {
+	return e.cooldowns
+}
+
+// Locks returns the locks held by whoever is changing a character
+func (e *Executor) Locks() *character.Locks {
+	return e.locks
+}
+
+// Events returns the bus game events are published on
+func (e *Executor) Events() *event.Bus {
+	return e.events
+}
+
+// Dice returns the source every roll in the game comes from
+func (e *Executor) Dice() *dice.Source {
+	return e.dice
+}
+
+// StartLocations returns where characters begin
+func (e *Executor) StartLocations() *character.StartLocations {
+	return e.starts
+}
+
+func (e *Executor) Execute(ctx *HandlerContext, cmd *Command) (*CommandResult, error) {
 	if cmd.Type == CommandUnknown {
-		return []string{fmt.Sprintf("Unknown command: %s", cmd.Verb)}, nil
+		return Reply(fmt.Sprintf("Unknown command: %s", cmd.Verb)), nil
 	}
 	
 	if !cmd.ValidateArgs() {
-		return []string{"Invalid command syntax. Type 'help' for usage information."}, nil
+		return Reply("Invalid command syntax. Type 'help' for usage information."), nil
 	}
 	
 	handler, exists := e.handlers[cmd.Verb]
 	if !exists {
-		return []string{fmt.Sprintf("Command '%s' is not implemented yet.", cmd.Verb)}, nil
+		return Reply(fmt.Sprintf("Command '%s' is not implemented yet.", cmd.Verb)), nil
 	}
 	
-	return handler.Execute(cmd)
+	if cmd.Type == CommandMagic && roomHas(ctx, world.FlagNoMagic) {
+		return Reply("Your magic fizzles and dies here."), nil
+	}
+	
+	// Handlers see whether the actor was hidden before stealth is broken,
+	// so an attack can strike from the shadows
+	result, err := handler.Execute(ctx, cmd)
+	if err != nil {
+		return nil, err
+	}
+	if logsFight(cmd) && ctx.Character != nil {
+		e.logFight(ctx.Character.ID, result)
+	}
+	if ctx.Character != nil && breaksStealth(cmd) && e.stealth.Reveal(ctx.Character.ID) {
+		result.Messages = append([]string{"You step out of the shadows."}, result.Messages...)
+	}
+	return result, nil
+}
+
+// logsFight reports whether cmd belongs in the combat log: combat commands
+// other than the pvp setting, and spells, which deal damage the same way
+func logsFight(cmd *Command) bool {
+	return cmd.Type == CommandCombat && cmd.Verb != "pvp" || cmd.Verb == "cast"
+}
+
+// logFight records a command that started, carried on or ended a fight in
+// the combat logs of the actor and of anyone it spoke to, such as the
+// player they attacked
+func (e *Executor) logFight(actorID string, result *CommandResult) {
+	e.combatLog.record(actorID, result.Messages...)
+	for _, message := range result.Targeted {
+		e.combatLog.record(message.CharacterID, message.Text)
+	}
+}
+
+// hasHandler reports whether verb has a handler to run it
+func (e *Executor) hasHandler(verb string) bool {
+	_, exists := e.handlers[verb]
+	return exists
 }
 
 func (e *Executor) initializeHandlers() {
+	// view describes what is in a room for look and movement
+	view := &roomViewer{
+		repoManager: e.repoManager,
+		factory:     e.itemFactory,
+		stealth:     e.stealth,
+		npcs:        e.npcs,
+	}
+	// parser documents the registered commands for help
+	parser := NewParser()
+	// targets resolves the names and pronouns given to targeted commands
+	targets := &targetResolver{
+		repoManager: e.repoManager,
+		npcs:        e.npcs,
+		stealth:     e.stealth,
+		memory:      e.targets,
+	}
+	
 	// Movement handlers
-	e.handlers["north"] = &MovementHandler{direction: "north"}
-	e.handlers["south"] = &MovementHandler{direction: "south"}
-	e.handlers["east"] = &MovementHandler{direction: "east"}
-	e.handlers["west"] = &MovementHandler{direction: "west"}
-	e.handlers["up"] = &MovementHandler{direction: "up"}
-	e.handlers["down"] = &MovementHandler{direction: "down"}
-	e.handlers["northeast"] = &MovementHandler{direction: "northeast"}
-	e.handlers["northwest"] = &MovementHandler{direction: "northwest"}
-	e.handlers["southeast"] = &MovementHandler{direction: "southeast"}
-	e.handlers["southwest"] = &MovementHandler{direction: "southwest"}
+	e.handlers["north"] = &MovementHandler{repoManager: e.repoManager, view: view, stealth: e.stealth, direction: "north"}
+	e.handlers["south"] = &MovementHandler{repoManager: e.repoManager, view: view, stealth: e.stealth, direction: "south"}
+	e.handlers["east"] = &MovementHandler{repoManager: e.repoManager, view: view, stealth: e.stealth, direction: "east"}
+	e.handlers["west"] = &MovementHandler{repoManager: e.repoManager, view: view, stealth: e.stealth, direction: "west"}
+	e.handlers["up"] = &MovementHandler{repoManager: e.repoManager, view: view, stealth: e.stealth, direction: "up"}
+	e.handlers["down"] = &MovementHandler{repoManager: e.repoManager, view: view, stealth: e.stealth, direction: "down"}
+	e.handlers["northeast"] = &MovementHandler{repoManager: e.repoManager, view: view, stealth: e.stealth, direction: "northeast"}
+	e.handlers["northwest"] = &MovementHandler{repoManager: e.repoManager, view: view, stealth: e.stealth, direction: "northwest"}
+	e.handlers["southeast"] = &MovementHandler{repoManager: e.repoManager, view: view, stealth: e.stealth, direction: "southeast"}
+	e.handlers["southwest"] = &MovementHandler{repoManager: e.repoManager, view: view, stealth: e.stealth, direction: "southwest"}
+	e.handlers["sneak"] = &SneakHandler{repoManager: e.repoManager, stealth: e.stealth, view: view, experience: e.experience, roll: e.dice.Intn}
 	
 	// Communication handlers
 	e.handlers["say"] = &SayHandler{}
-	e.handlers["tell"] = &TellHandler{repoManager: e.repoManager}
+	e.handlers["tell"] = &TellHandler{repoManager: e.repoManager, replies: e.replies, afk: e.afk}
+	e.handlers["reply"] = &ReplyHandler{repoManager: e.repoManager, replies: e.replies, afk: e.afk}
+	e.handlers["afk"] = &AfkHandler{afk: e.afk}
 	e.handlers["yell"] = &YellHandler{}
 	e.handlers["whisper"] = &WhisperHandler{}
 	e.handlers["chat"] = &ChatHandler{}
+	e.handlers["report"] = &ReportHandler{repoManager: e.repoManager, now: time.Now}
+	e.handlers["follow"] = &FollowHandler{repoManager: e.repoManager, follow: e.follow, stealth: e.stealth}
+	e.handlers["lead"] = &LeadHandler{follow: e.follow}
+	e.handlers["nofollow"] = &NoFollowHandler{repoManager: e.repoManager, follow: e.follow}
+	e.handlers["lose"] = &LoseHandler{repoManager: e.repoManager, follow: e.follow}
 	
 	// Information handlers
-	e.handlers["look"] = &LookHandler{repoManager: e.repoManager}
-	e.handlers["examine"] = &ExamineHandler{repoManager: e.repoManager}
-	e.handlers["who"] = &WhoHandler{}
-	e.handlers["score"] = &ScoreHandler{repoManager: e.repoManager}
+	e.handlers["look"] = &LookHandler{repoManager: e.repoManager, view: view, targets: targets}
+	e.handlers["examine"] = &ExamineHandler{repoManager: e.repoManager, targets: targets}
+	e.handlers["who"] = &WhoHandler{repoManager: e.repoManager, pvp: e.pvp}
+	e.handlers["whois"] = &WhoisHandler{repoManager: e.repoManager, now: time.Now}
+	e.handlers["finger"] = &FingerHandler{repoManager: e.repoManager, stealth: e.stealth, now: time.Now}
+	e.handlers["combatlog"] = &CombatLogHandler{repoManager: e.repoManager, log: e.combatLog}
+	e.handlers["where"] = &WhereHandler{repoManager: e.repoManager, stealth: e.stealth}
+	e.handlers["location"] = &LocationHandler{repoManager: e.repoManager}
+	e.handlers["title"] = &TitleHandler{}
+	e.handlers["profile"] = &ProfileHandler{}
+	e.description = &DescriptionHandler{repoManager: e.repoManager, drafts: make(map[string][]string)}
+	e.handlers["description"] = e.description
+	e.handlers["appearance"] = &AppearanceHandler{}
+	e.handlers["bind"] = &BindHandler{repoManager: e.repoManager, cache: e.keybindings}
+	e.handlers["unbind"] = &UnbindHandler{repoManager: e.repoManager, cache: e.keybindings}
+	e.handlers["reputation"] = &ReputationHandler{}
+	e.handlers["score"] = &ScoreHandler{repoManager: e.repoManager, experience: e.experience, pvp: e.pvp}
 	e.handlers["time"] = &TimeHandler{}
 	e.handlers["weather"] = &WeatherHandler{}
+	e.handlers["map"] = &MapHandler{repoManager: e.repoManager}
+	e.handlers["leaderboard"] = &LeaderboardHandler{repoManager: e.repoManager}
 	
 	// Inventory handlers
 	e.handlers["inventory"] = &InventoryHandler{repoManager: e.repoManager}
-	e.handlers["get"] = &GetHandler{repoManager: e.repoManager}
+	e.handlers["get"] = &GetHandler{repoManager: e.repoManager, factory: e.itemFactory}
 	e.handlers["drop"] = &DropHandler{repoManager: e.repoManager}
 	e.handlers["give"] = &GiveHandler{repoManager: e.repoManager}
-	e.handlers["wear"] = &WearHandler{repoManager: e.repoManager}
-	e.handlers["remove"] = &RemoveHandler{repoManager: e.repoManager}
+	e.handlers["wear"] = &WearHandler{repoManager: e.repoManager, factory: e.itemFactory}
+	e.handlers["remove"] = &RemoveHandler{repoManager: e.repoManager, factory: e.itemFactory}
+	e.handlers["achievements"] = &AchievementsHandler{}
+	e.handlers["appraise"] = &AppraiseHandler{repoManager: e.repoManager, factory: e.itemFactory}
+	e.handlers["lock"] = &KeyHandler{repoManager: e.repoManager, factory: e.itemFactory, lock: true}
+	e.handlers["unlock"] = &KeyHandler{repoManager: e.repoManager, factory: e.itemFactory}
 	
 	// Skill handlers
 	e.handlers["skills"] = &SkillsHandler{repoManager: e.repoManager}
-	e.handlers["practice"] = &PracticeHandler{repoManager: e.repoManager}
+	e.handlers["practice"] = &PracticeHandler{repoManager: e.repoManager, npcs: e.npcs}
+	e.handlers["train"] = &TrainHandler{npcs: e.npcs}
+	e.handlers["gain"] = &GainHandler{repoManager: e.repoManager, npcs: e.npcs, experience: e.experience}
+	e.handlers["craft"] = &CraftHandler{
+		repoManager: e.repoManager,
+		factory:     e.itemFactory,
+		recipes:     crafting.NewRecipeRegistry(),
+		events:      e.events,
+		experience:  e.experience,
+		roll:        e.dice.Intn,
+	}
+	e.handlers["mine"] = &GatherHandler{
+		repoManager: e.repoManager,
+		factory:     e.itemFactory,
+		skill:       character.SkillMining,
+		action:      "mine",
+		experience:  e.experience,
+		roll:        e.dice.Intn,
+		now:         time.Now,
+	}
+	e.handlers["fish"] = &FishHandler{
+		repoManager: e.repoManager,
+		factory:     e.itemFactory,
+		delay:       fishingDelay,
+		experience:  e.experience,
+		roll:        e.dice.Intn,
+		waiting:     make(map[string]bool),
+	}
+	e.handlers["hide"] = &HideHandler{repoManager: e.repoManager, stealth: e.stealth, experience: e.experience, roll: e.dice.Intn}
+	e.handlers["pick"] = &PickHandler{repoManager: e.repoManager, factory: e.itemFactory, experience: e.experience, roll: e.dice.Intn}
 	
 	// System handlers
-	e.handlers["help"] = &HelpHandler{}
-	e.handlers["commands"] = &CommandsHandler{}
-	e.handlers["quit"] = &QuitHandler{}
+	e.handlers["help"] = &HelpHandler{parser: parser}
+	e.handlers["commands"] = &CommandsHandler{parser: parser, implemented: e.hasHandler}
+	e.handlers["quit"] = &QuitHandler{repoManager: e.repoManager}
 	e.handlers["save"] = &SaveHandler{repoManager: e.repoManager}
+	e.handlers["skip"] = &SkipHandler{repoManager: e.repoManager, starts: e.starts}
+	e.handlers["autoloot"] = &PreferenceHandler{
+		repoManager: e.repoManager,
+		name:        "Auto-loot",
+		setting:     func(prefs *player.PlayerPrefs) *bool { return &prefs.AutoLoot },
+	}
+	e.handlers["prompts"] = &PreferenceHandler{
+		repoManager: e.repoManager,
+		name:        "Combat prompts",
+		setting:     func(prefs *player.PlayerPrefs) *bool { return &prefs.CombatPrompts },
+	}
+	e.handlers["confirmquit"] = &PreferenceHandler{
+		repoManager: e.repoManager,
+		name:        "Quit confirmation",
+		setting:     func(prefs *player.PlayerPrefs) *bool { return &prefs.ConfirmQuit },
+	}
+	
+	// Quest handlers
+	e.handlers["quest"] = &QuestHandler{repoManager: e.repoManager}
+	e.handlers["accept"] = &AcceptHandler{repoManager: e.repoManager}
+	e.handlers["abandon"] = &AbandonHandler{repoManager: e.repoManager}
+	e.handlers["complete"] = &CompleteHandler{repoManager: e.repoManager, factory: e.itemFactory, experience: e.experience}
 	
 	// Social handlers
 	e.handlers["emote"] = &EmoteHandler{}
@@ -101,154 +516,440 @@ func (e *Executor) initializeHandlers() {
 	e.handlers["bow"] = &SocialHandler{action: "bow"}
 	
 	// Combat handlers (basic implementations)
-	e.handlers["kill"] = &KillHandler{repoManager: e.repoManager}
-	e.handlers["flee"] = &FleeHandler{}
-	e.handlers["defend"] = &DefendHandler{}
+	killer := &KillHandler{
+		repoManager: e.repoManager,
+		factory:     e.itemFactory,
+		stealth:     e.stealth,
+		follow:      e.follow,
+		stances:     e.stances,
+		targets:     targets,
+		npcs:        e.npcs,
+		experience:  e.experience,
+		pvp:         e.pvp,
+		fights:      e.fights,
+		locks:       e.locks,
+		roll:        e.dice.Intn,
+	}
+	e.handlers["kill"] = killer
+	e.handlers["use"] = &UseHandler{killer: killer, cooldowns: e.cooldowns, roll: e.dice.Intn, now: time.Now}
+	e.handlers["flee"] = &FleeHandler{
+		repoManager: e.repoManager,
+		view:        view,
+		stances:     e.stances,
+		npcs:        e.npcs,
+		roll:        e.dice.Intn,
+	}
+	e.handlers["defend"] = &DefendHandler{stances: e.stances}
+	e.handlers["pvp"] = &PvPHandler{repoManager: e.repoManager, rules: e.pvp}
+	
+	// Admin handlers
+	e.handlers["roomflag"] = &RoomFlagHandler{repoManager: e.repoManager}
+	e.reload = &ReloadHandler{repoManager: e.repoManager, reloaders: make(map[string]Reloader)}
+	e.handlers["reload"] = e.reload
+	e.handlers["reports"] = &ReportsHandler{repoManager: e.repoManager}
+	e.handlers["peace"] = &PeaceHandler{repoManager: e.repoManager, npcs: e.npcs, stances: e.stances, fights: e.fights, locks: e.locks}
+	
+	// Magic handlers
+	e.handlers["spells"] = &SpellsHandler{}
+	e.handlers["abilities"] = &AbilitiesHandler{}
+	e.handlers["prepare"] = &PrepareHandler{}
+	e.handlers["cast"] = &CastHandler{killer: killer, cooldowns: e.cooldowns, roll: e.dice.Intn, now: time.Now}
+	e.handlers["recall"] = &RecallHandler{
+		repoManager: e.repoManager,
+		view:        view,
+		starts:      e.starts,
+		locks:       e.locks,
+		load:        e.LoadContext,
+		delay:       recallDelay,
+		cooldown:    recallCooldown,
+		cooldowns:   e.cooldowns,
+		now:         time.Now,
+		praying:     make(map[string]bool),
+	}
 }
 
 // Basic handler implementations
 
+// MovementHandler steps the character through an exit. Others in both
+// rooms see them leave and arrive, unless they move hidden.
 type MovementHandler struct {
-	direction string
+	repoManager interfaces.RepositoryManager
+	view        *roomViewer
+	stealth     *stealth.Tracker
+	direction   string
+	// quiet leaves the leave and arrive messages to the caller, for sneaking
+	quiet bool
+}
+
+func (h *MovementHandler) Execute(ctx *HandlerContext, cmd *Command) (*CommandResult, error) {
+	char := ctx.Character
+	if char == nil {
+		return Reply("Error retrieving character information."), nil
+	}
+	
+	room, err := world.GetRoom(ctx.RoomID())
+	if err != nil {
+		return Reply("You can't go that way."), nil
+	}
+	exit, ok := room.Exits[h.direction]
+	if !ok {
+		return Reply("You can't go that way."), nil
+	}
+	destination, err := world.GetRoom(exit.To)
+	if err != nil {
+		return Reply("You can't go that way."), nil
+	}
+	
+	if exit.DoorID != "" && world.DoorState(roomFlags(ctx), exit.DoorID) != lock.Unlocked {
+		name := "door"
+		if door, err := world.GetDoor(exit.DoorID); err == nil {
+			name = door.Name
+		}
+		return Reply(fmt.Sprintf("The %s is locked.", name)), nil
+	}
+	
+	destinationState, err := enterRoom(h.repoManager, ctx, destination)
+	if err != nil {
+		return Reply("Error moving to the next room."), nil
+	}
+	
+	response := append([]string{fmt.Sprintf("You go %s.", h.direction)}, describeRoom(destination, destinationState)...)
+	result := Reply(append(response, h.view.contents(ctx)...)...)
+	if !h.quiet && !h.stealth.IsHidden(char.ID) {
+		result.ToRoom(room.ID, leaveMessage(ctx.ActorName(), h.direction), char.ID).
+			ToRoom(destination.ID, arriveMessage(ctx.ActorName(), h.direction), char.ID)
+	}
+	return result, nil
 }
 
-func (h *MovementHandler) Execute(cmd *Command) ([]string, error) {
-	return []string{fmt.Sprintf("You attempt to move %s.", h.direction)}, nil
+// leaveMessage tells a room that name has left in direction
+func leaveMessage(name, direction string) string {
+	switch direction {
+	case world.Up:
+		return fmt.Sprintf("%s leaves upwards.", name)
+	case world.Down:
+		return fmt.Sprintf("%s leaves downwards.", name)
+	}
+	return fmt.Sprintf("%s leaves to the %s.", name, direction)
+}
+
+// arriveMessage tells a room that name has arrived, having moved in
+// direction to get there
+func arriveMessage(name, direction string) string {
+	switch from := world.Opposite(direction); from {
+	case "":
+		return fmt.Sprintf("%s arrives.", name)
+	case world.Up:
+		return fmt.Sprintf("%s arrives from above.", name)
+	case world.Down:
+		return fmt.Sprintf("%s arrives from below.", name)
+	default:
+		return fmt.Sprintf("%s arrives from the %s.", name, from)
+	}
+}
+
+// enterRoom moves the acting character into destination, saving where they
+// are, and returns the room's state.
+func enterRoom(repoManager interfaces.RepositoryManager, ctx *HandlerContext, destination *world.Room) (*interfaces.RoomState, error) {
+	char := ctx.Character
+	destinationState, err := repoManager.World().LoadRoomState(destination.ID)
+	if err != nil {
+		return nil, err
+	}
+	char.Location = &character.Location{
+		RoomID: destination.ID,
+		ZoneID: destination.ZoneID,
+		X:      destination.X,
+		Y:      destination.Y,
+	}
+	if char.Explore(destination.ID) {
+		// A first visit also adds the room to the character's map
+		err = repoManager.Characters().UpdateCharacter(char)
+	} else {
+		err = repoManager.Characters().UpdateCharacterLocation(char.ID, char.Location)
+	}
+	if err != nil {
+		return nil, err
+	}
+	ctx.Room = destinationState
+	return destinationState, nil
 }
 
 type SayHandler struct{}
 
-func (h *SayHandler) Execute(cmd *Command) ([]string, error) {
+func (h *SayHandler) Execute(ctx *HandlerContext, cmd *Command) (*CommandResult, error) {
 	message := strings.Join(cmd.Args, " ")
-	return []string{fmt.Sprintf("You say: %s", message)}, nil
+	return Reply(fmt.Sprintf("You say: %s", message)).
+		ToRoom("", fmt.Sprintf("%s says: %s", ctx.ActorName(), message), cmd.CharacterID), nil
 }
 
 type TellHandler struct {
 	repoManager interfaces.RepositoryManager
+	replies     *replyMemory
+	afk         *afkMemory
 }
 
-func (h *TellHandler) Execute(cmd *Command) ([]string, error) {
+func (h *TellHandler) Execute(ctx *HandlerContext, cmd *Command) (*CommandResult, error) {
 	if len(cmd.Args) < 2 {
-		return []string{"Usage: tell <player> <message>"}, nil
+		return Reply("Usage: tell <player> <message>"), nil
 	}
 	
 	target := cmd.Args[0]
 	message := strings.Join(cmd.Args[1:], " ")
 	
-	return []string{fmt.Sprintf("You tell %s: %s", target, message)}, nil
+	recipient, err := h.repoManager.Characters().GetCharacterByName(target)
+	if err != nil || ctx.Character == nil {
+		return Reply(fmt.Sprintf("You tell %s: %s", target, message)), nil
+	}
+	return tell(h.replies, h.afk, ctx, recipient, message), nil
 }
 
 type YellHandler struct{}
 
-func (h *YellHandler) Execute(cmd *Command) ([]string, error) {
+func (h *YellHandler) Execute(ctx *HandlerContext, cmd *Command) (*CommandResult, error) {
 	message := strings.Join(cmd.Args, " ")
-	return []string{fmt.Sprintf("You yell: %s", message)}, nil
+	return Reply(fmt.Sprintf("You yell: %s", message)).
+		ToRoom("", fmt.Sprintf("%s yells: %s", ctx.ActorName(), message), cmd.CharacterID), nil
 }
 
 type WhisperHandler struct{}
 
-func (h *WhisperHandler) Execute(cmd *Command) ([]string, error) {
+func (h *WhisperHandler) Execute(ctx *HandlerContext, cmd *Command) (*CommandResult, error) {
 	if len(cmd.Args) < 2 {
-		return []string{"Usage: whisper <player> <message>"}, nil
+		return Reply("Usage: whisper <player> <message>"), nil
 	}
 	
 	target := cmd.Args[0]
 	message := strings.Join(cmd.Args[1:], " ")
 	
-	return []string{fmt.Sprintf("You whisper to %s: %s", target, message)}, nil
+	return Reply(fmt.Sprintf("You whisper to %s: %s", target, message)), nil
 }
 
 type ChatHandler struct{}
 
-func (h *ChatHandler) Execute(cmd *Command) ([]string, error) {
+func (h *ChatHandler) Execute(ctx *HandlerContext, cmd *Command) (*CommandResult, error) {
 	message := strings.Join(cmd.Args, " ")
-	return []string{fmt.Sprintf("[Chat] You: %s", message)}, nil
+	return Reply(fmt.Sprintf("[Chat] You: %s", message)), nil
 }
 
 type LookHandler struct {
 	repoManager interfaces.RepositoryManager
+	view        *roomViewer
+	targets     *targetResolver
 }
 
-func (h *LookHandler) Execute(cmd *Command) ([]string, error) {
+func (h *LookHandler) Execute(ctx *HandlerContext, cmd *Command) (*CommandResult, error) {
 	if len(cmd.Args) == 0 {
 		// Look at room
-		return []string{
+		if room, err := world.GetRoom(ctx.RoomID()); err == nil {
+			response := describeRoom(room, ctx.Room)
+			return Reply(append(response, h.view.contents(ctx)...)...), nil
+		}
+		response := []string{
 			"A Simple Room",
 			"You are in a basic room with stone walls and a dirt floor.",
 			"There are exits to the north, south, east, and west.",
-		}, nil
+		}
+		
+		for _, node := range world.ResourceNodes(ctx.RoomID()) {
+			response = append(response, node.Description)
+		}
+		for _, giver := range quest.GetGiversInRoom(ctx.RoomID()) {
+			response = append(response, giver.Greeting)
+		}
+		return Reply(response...), nil
 	}
 	
 	target := strings.Join(cmd.Args, " ")
-	return []string{fmt.Sprintf("You look at %s.", target)}, nil
+	if lines := lookAt(h.targets, ctx, target, false); lines != nil {
+		return Reply(lines...), nil
+	}
+	return Reply(fmt.Sprintf("You look at %s.", target)), nil
 }
 
 type ExamineHandler struct {
 	repoManager interfaces.RepositoryManager
+	targets     *targetResolver
 }
 
-func (h *ExamineHandler) Execute(cmd *Command) ([]string, error) {
+func (h *ExamineHandler) Execute(ctx *HandlerContext, cmd *Command) (*CommandResult, error) {
 	target := strings.Join(cmd.Args, " ")
-	return []string{fmt.Sprintf("You examine %s closely.", target)}, nil
+	if lines := lookAt(h.targets, ctx, target, true); lines != nil {
+		return Reply(lines...), nil
+	}
+	return Reply(fmt.Sprintf("You examine %s closely.", target)), nil
 }
 
-type WhoHandler struct{}
+type WhoHandler struct {
+	repoManager interfaces.RepositoryManager
+	pvp         *pvpRules
+}
 
-func (h *WhoHandler) Execute(cmd *Command) ([]string, error) {
-	return []string{
-		"Players currently online:",
-		"  TestPlayer (Human Warrior, Level 1)",
-		"",
-		"1 player online.",
-	}, nil
+func (h *WhoHandler) Execute(ctx *HandlerContext, cmd *Command) (*CommandResult, error) {
+	var online []*character.Character
+	if ctx.Messenger != nil {
+		for _, id := range ctx.Messenger.OnlineCharacterIDs() {
+			if char, err := h.repoManager.Characters().GetCharacter(id); err == nil {
+				online = append(online, char)
+			}
+		}
+	}
+	sort.Slice(online, func(i, j int) bool { return online[i].Name < online[j].Name })
+	
+	// "who time" also shows how long each player has been online
+	showTime := len(cmd.Args) > 0 && strings.EqualFold(cmd.Args[0], "time")
+	if len(cmd.Args) > 0 && !showTime {
+		return Reply("Usage: who [time]"), nil
+	}
+	
+	now := time.Now()
+	response := []string{"Players currently online:"}
+	for _, char := range online {
+		line := fmt.Sprintf("  %s (%s %s, Level %d)",
+			char.DisplayName(), char.Race.Name, char.Class.Name, char.Level)
+		if h.pvp.protection.Protects(char) {
+			line += " [Newbie]"
+		}
+		if char.State == character.CharacterAfk {
+			line += " [AFK]"
+		}
+		if session, ok := sessionLength(ctx.Messenger, char.ID, now); showTime && ok {
+			line += fmt.Sprintf(" - online %s", textutil.Duration(session))
+		}
+		response = append(response, line)
+	}
+	response = append(response, "")
+	if len(online) == 1 {
+		response = append(response, "1 player online.")
+	} else {
+		response = append(response, fmt.Sprintf("%d players online.", len(online)))
+	}
+	return Reply(response...), nil
 }
 
 type ScoreHandler struct {
 	repoManager interfaces.RepositoryManager
+	experience  *experienceRules
+	pvp         *pvpRules
 }
 
-func (h *ScoreHandler) Execute(cmd *Command) ([]string, error) {
-	// Get character information
-	char, err := h.repoManager.Characters().GetCharacter(cmd.CharacterID)
-	if err != nil {
-		return []string{"Error retrieving character information."}, nil
+func (h *ScoreHandler) Execute(ctx *HandlerContext, cmd *Command) (*CommandResult, error) {
+	char := ctx.Character
+	if char == nil {
+		return Reply("Error retrieving character information."), nil
 	}
 	
-	return []string{
-		fmt.Sprintf("Name: %s", char.Name),
+	result := Reply(
+		fmt.Sprintf("Name: %s", char.DisplayName()),
 		fmt.Sprintf("Race: %s, Class: %s", char.Race.Name, char.Class.Name),
-		fmt.Sprintf("Level: %d, Experience: %d", char.Level, char.Experience),
+		fmt.Sprintf("Level: %d, Experience: %s", char.Level, char.ExperienceProgress()),
+		fmt.Sprintf("Practices: %d, Attribute points: %d", char.Practices, char.StatPoints),
+		fmt.Sprintf("Gold: %d", char.Gold),
 		fmt.Sprintf("Health: %d/%d", char.Stats.Health, char.Stats.MaxHealth),
 		fmt.Sprintf("Mana: %d/%d", char.Stats.Mana, char.Stats.MaxMana),
 		fmt.Sprintf("Stamina: %d/%d", char.Stats.Stamina, char.Stats.MaxStamina),
-	}, nil
+		fmt.Sprintf("Reputation: %s", standingSummary(char)),
+		playedSummary(ctx, time.Now()),
+	)
+	if rates := h.experience.rates(); !rates.IsNormal() {
+		result.Add(fmt.Sprintf("Reward rates: %s", rates))
+	}
+	if h.pvp.protection.Protects(char) {
+		result.Add(fmt.Sprintf("Newbie protection: until level %d", h.pvp.protection.Level))
+	}
+	return result, nil
+}
+
+// playedSummary shows the character's total play time and, when the server
+// knows it, how long this session has lasted
+func playedSummary(ctx *HandlerContext, now time.Time) string {
+	played := fmt.Sprintf("Played: %s", textutil.Duration(ctx.Character.CurrentPlayTime(now)))
+	if session, ok := sessionLength(ctx.Messenger, ctx.Character.ID, now); ok {
+		played += fmt.Sprintf(" (this session: %s)", textutil.Duration(session))
+	}
+	return played
 }
 
 type TimeHandler struct{}
 
-func (h *TimeHandler) Execute(cmd *Command) ([]string, error) {
-	return []string{"It is midday in the realm."}, nil
+func (h *TimeHandler) Execute(ctx *HandlerContext, cmd *Command) (*CommandResult, error) {
+	return Reply("It is midday in the realm."), nil
 }
 
 type WeatherHandler struct{}
 
-func (h *WeatherHandler) Execute(cmd *Command) ([]string, error) {
-	return []string{"The weather is clear and pleasant."}, nil
+func (h *WeatherHandler) Execute(ctx *HandlerContext, cmd *Command) (*CommandResult, error) {
+	if roomHas(ctx, world.FlagIndoor) {
+		return Reply("You can't see the sky from in here."), nil
+	}
+	return Reply("The weather is clear and pleasant."), nil
+}
+
+const (
+	defaultLeaderboardSize = 10
+	maxLeaderboardSize     = 25
+)
+
+type LeaderboardHandler struct {
+	repoManager interfaces.RepositoryManager
+}
+
+func (h *LeaderboardHandler) Execute(ctx *HandlerContext, cmd *Command) (*CommandResult, error) {
+	category := interfaces.LeaderboardLevel
+	limit := defaultLeaderboardSize
+	
+	for _, arg := range cmd.Args {
+		if n, err := strconv.Atoi(arg); err == nil {
+			if n < 1 || n > maxLeaderboardSize {
+				return Reply(fmt.Sprintf("You can list between 1 and %d characters.", maxLeaderboardSize)), nil
+			}
+			limit = n
+			continue
+		}
+		
+		parsed, ok := interfaces.ParseLeaderboardCategory(strings.ToLower(arg))
+		if !ok {
+			return Reply("Usage: leaderboard [level|kills|playtime] [count]"), nil
+		}
+		category = parsed
+	}
+	
+	entries, err := h.repoManager.Characters().GetLeaderboard(category, limit)
+	if err != nil {
+		return Reply("Error retrieving the leaderboard."), nil
+	}
+	
+	if len(entries) == 0 {
+		return Reply("Nobody has made their mark yet."), nil
+	}
+	
+	response := []string{
+		fmt.Sprintf("Top characters by %s:", category),
+		"Rank Name           Race      Class     Level  Kills  Played",
+		"------------------------------------------------------------",
+	}
+	for _, entry := range entries {
+		response = append(response, fmt.Sprintf("%-4d %s %-9s %-9s %-6d %-6d %s",
+			entry.Rank, textutil.PadRight(entry.Name, 14), entry.Race, entry.Class, entry.Level,
+			entry.KillCount, entry.PlayTime.Truncate(time.Minute)))
+	}
+	
+	return Reply(response...), nil
 }
 
 type InventoryHandler struct {
 	repoManager interfaces.RepositoryManager
 }
 
-func (h *InventoryHandler) Execute(cmd *Command) ([]string, error) {
+func (h *InventoryHandler) Execute(ctx *HandlerContext, cmd *Command) (*CommandResult, error) {
 	// Get character's items
-	items, err := h.repoManager.Items().GetPlayerItems(cmd.CharacterID)
+	items, err := h.repoManager.Items().GetCarriedItems(cmd.CharacterID)
 	if err != nil {
-		return []string{"Error retrieving inventory."}, nil
+		return Reply("Error retrieving inventory."), nil
 	}
 	
 	if len(items) == 0 {
-		return []string{"You are carrying nothing."}, nil
+		return Reply("You are carrying nothing."), nil
 	}
 	
 	response := []string{"You are carrying:"}
@@ -256,201 +957,398 @@ func (h *InventoryHandler) Execute(cmd *Command) ([]string, error) {
 		response = append(response, fmt.Sprintf("  %s", item.GetDisplayName()))
 	}
 	
-	return response, nil
+	return Reply(response...), nil
 }
 
 type GetHandler struct {
 	repoManager interfaces.RepositoryManager
+	factory     *items.ItemFactory
 }
 
-func (h *GetHandler) Execute(cmd *Command) ([]string, error) {
-	item := strings.Join(cmd.Args, " ")
-	return []string{fmt.Sprintf("You get %s.", item)}, nil
+func (h *GetHandler) Execute(ctx *HandlerContext, cmd *Command) (*CommandResult, error) {
+	char := ctx.Character
+	if char == nil {
+		return Reply("Error retrieving character information."), nil
+	}
+	
+	name := strings.Join(cmd.Args, " ")
+	floor, err := h.repoManager.Items().GetRoomItems(ctx.RoomID())
+	if err != nil {
+		return Reply("Error looking around the room."), nil
+	}
+	
+	for _, item := range floor {
+		template, err := h.factory.GetTemplate(item.TemplateID)
+		if err != nil || !matchesItemName(template, name) {
+			continue
+		}
+		
+		// Corpses stay where they fell; only their owner may take back what
+		// they hold
+		if item.IsCorpse() {
+			if item.CorpseOwner() != char.ID {
+				return Reply("You can't carry a corpse."), nil
+			}
+			if err := emptyCorpse(h.repoManager, item, char.ID); err != nil {
+				return Reply("Error recovering your belongings."), nil
+			}
+			return Reply("You recover your belongings from your corpse.").
+				ToRoom("", fmt.Sprintf("%s recovers their belongings from a corpse.", ctx.ActorName()), char.ID), nil
+		}
+		
+		// Coins go into the purse rather than the pack
+		if template.ID == items.GoldTemplateID {
+			if err := h.repoManager.Items().DeleteItemInstance(item.ID); err != nil {
+				return Reply("Error picking up the gold."), nil
+			}
+			char.Gold += item.Quantity
+			return Reply(fmt.Sprintf("You pick up %d gold.", item.Quantity)).
+				ToRoom("", fmt.Sprintf("%s picks up some gold.", ctx.ActorName()), char.ID), nil
+		}
+		
+		if err := h.repoManager.Items().TransferItem(item.ID, char.ID); err != nil {
+			return Reply(fmt.Sprintf("Error picking up %s.", template.Name)), nil
+		}
+		response := []string{fmt.Sprintf("You get %s.", describeItem(h.factory, item))}
+		return Reply(append(response, char.Quests.RecordEvent(quest.ObjectiveFetch, template.ID)...)...).
+			ToRoom("", fmt.Sprintf("%s picks up %s.", ctx.ActorName(), describeItem(h.factory, item)), char.ID), nil
+	}
+	
+	return Reply(fmt.Sprintf("You don't see %s here.", name)), nil
 }
 
 type DropHandler struct {
 	repoManager interfaces.RepositoryManager
 }
 
-func (h *DropHandler) Execute(cmd *Command) ([]string, error) {
+func (h *DropHandler) Execute(ctx *HandlerContext, cmd *Command) (*CommandResult, error) {
 	item := strings.Join(cmd.Args, " ")
-	return []string{fmt.Sprintf("You drop %s.", item)}, nil
+	return Reply(fmt.Sprintf("You drop %s.", item)), nil
 }
 
 type GiveHandler struct {
 	repoManager interfaces.RepositoryManager
 }
 
-func (h *GiveHandler) Execute(cmd *Command) ([]string, error) {
+func (h *GiveHandler) Execute(ctx *HandlerContext, cmd *Command) (*CommandResult, error) {
 	item := cmd.Args[0]
 	target := cmd.Args[1]
-	return []string{fmt.Sprintf("You give %s to %s.", item, target)}, nil
+	return Reply(fmt.Sprintf("You give %s to %s.", item, target)), nil
 }
 
 type WearHandler struct {
 	repoManager interfaces.RepositoryManager
+	factory     *items.ItemFactory
 }
 
-func (h *WearHandler) Execute(cmd *Command) ([]string, error) {
-	item := strings.Join(cmd.Args, " ")
-	return []string{fmt.Sprintf("You wear %s.", item)}, nil
+func (h *WearHandler) Execute(ctx *HandlerContext, cmd *Command) (*CommandResult, error) {
+	args := cmd.Args
+	offHand := len(args) > 1 && isOffHand(args[len(args)-1])
+	if offHand {
+		args = args[:len(args)-1]
+	}
+	name := strings.Join(args, " ")
+	char := ctx.Character
+	if char == nil {
+		return Reply("Error retrieving character information."), nil
+	}
+	
+	carried, err := h.repoManager.Items().GetCarriedItems(cmd.CharacterID)
+	if err != nil {
+		return Reply("Error retrieving inventory."), nil
+	}
+	
+	var item *items.ItemInstance
+	var template *items.ItemTemplate
+	for _, candidate := range carried {
+		if t, err := h.factory.GetTemplate(candidate.TemplateID); err == nil && matchesItemName(t, name) {
+			item, template = candidate, t
+			break
+		}
+	}
+	
+	if item == nil {
+		return Reply(fmt.Sprintf("You aren't carrying %s.", name)), nil
+	}
+	
+	if template.Slot == items.SlotNone {
+		return Reply(fmt.Sprintf("You can't wear %s.", template.Name)), nil
+	}
+	
+	slot, worn := template.Slot, fmt.Sprintf("You wear %s.", template.Name)
+	if offHand {
+		if refusal := offHandRefusal(char, template); refusal != "" {
+			return Reply(refusal), nil
+		}
+		slot, worn = items.SlotOffHand, fmt.Sprintf("You wield %s in your off hand.", template.Name)
+	}
+	if refusal := h.handsRefusal(char, slot, template); refusal != "" {
+		return Reply(refusal), nil
+	}
+	
+	response := []string{}
+	if previous := char.Equip(slot, item); previous != nil {
+		response = append(response, fmt.Sprintf("You remove %s.", itemName(h.factory, previous)))
+	}
+	char.ApplyEquipmentBonuses(h.factory)
+	return Reply(append(response, worn)...), nil
+}
+
+// handsRefusal explains why char cannot put template in slot while holding
+// what they hold, or returns "" if they can. A two-handed weapon leaves no
+// hand for the off hand, whichever of the two is taken up first.
+func (h *WearHandler) handsRefusal(char *character.Character, slot items.EquipSlot, template *items.ItemTemplate) string {
+	switch slot {
+	case items.SlotMainHand:
+		if template.WeaponClass.TwoHanded() && char.Equipment[items.SlotOffHand] != nil {
+			return fmt.Sprintf("You need both hands free to wield %s.", template.Name)
+		}
+	case items.SlotOffHand:
+		mainHand := char.Equipment[items.SlotMainHand]
+		if mainHand == nil {
+			return ""
+		}
+		if wielded, err := h.factory.GetTemplate(mainHand.TemplateID); err == nil && wielded.WeaponClass.TwoHanded() {
+			return fmt.Sprintf("%s needs both hands.", textutil.Capitalize(wielded.Name))
+		}
+	}
+	return ""
+}
+
+// isOffHand reports whether word asks for an item to go in the off hand
+func isOffHand(word string) bool {
+	switch strings.ToLower(word) {
+	case "offhand", "off-hand":
+		return true
+	}
+	return false
+}
+
+// offHandRefusal explains why char cannot wield the weapon in their off
+// hand, or returns "" if they can.
+func offHandRefusal(char *character.Character, template *items.ItemTemplate) string {
+	if template.Type != items.ItemWeapon {
+		return "Only weapons can be wielded in your off hand."
+	}
+	err := char.CanWieldOffHand(template.WeaponClass)
+	switch {
+	case err == nil:
+		return ""
+	case errors.Is(err, character.ErrOffHandTwoHanded):
+		return fmt.Sprintf("%s needs both hands.", textutil.Capitalize(template.Name))
+	case errors.Is(err, character.ErrOffHandUnskilled):
+		return fmt.Sprintf("You don't know how to fight with %s.", template.Name)
+	default:
+		return "You aren't nimble enough to fight with a weapon in each hand."
+	}
 }
 
 type RemoveHandler struct {
 	repoManager interfaces.RepositoryManager
+	factory     *items.ItemFactory
 }
 
-func (h *RemoveHandler) Execute(cmd *Command) ([]string, error) {
-	item := strings.Join(cmd.Args, " ")
-	return []string{fmt.Sprintf("You remove %s.", item)}, nil
+func (h *RemoveHandler) Execute(ctx *HandlerContext, cmd *Command) (*CommandResult, error) {
+	name := strings.Join(cmd.Args, " ")
+	char := ctx.Character
+	if char == nil {
+		return Reply("Error retrieving character information."), nil
+	}
+	
+	for slot, item := range char.Equipment {
+		template, err := h.factory.GetTemplate(item.TemplateID)
+		if err != nil || !matchesItemName(template, name) {
+			continue
+		}
+		
+		char.Unequip(slot)
+		char.ApplyEquipmentBonuses(h.factory)
+		return Reply(fmt.Sprintf("You remove %s.", template.Name)), nil
+	}
+	
+	return Reply(fmt.Sprintf("You aren't wearing %s.", name)), nil
+}
+
+// matchesItemName reports whether the name a player typed refers to an item
+// of the given template, either by its full name, its ID or a word in it.
+func matchesItemName(template *items.ItemTemplate, name string) bool {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return false
+	}
+	if strings.EqualFold(template.Name, name) || strings.EqualFold(template.ID, strings.ReplaceAll(name, " ", "_")) {
+		return true
+	}
+	for _, word := range strings.Fields(strings.ToLower(template.Name)) {
+		if word == name {
+			return true
+		}
+	}
+	return false
+}
+
+func itemName(factory *items.ItemFactory, item *items.ItemInstance) string {
+	if item.CustomName != "" {
+		return item.CustomName
+	}
+	if template, err := factory.GetTemplate(item.TemplateID); err == nil {
+		return template.Name
+	}
+	return item.GetDisplayName()
 }
 
 type SkillsHandler struct {
 	repoManager interfaces.RepositoryManager
 }
 
-func (h *SkillsHandler) Execute(cmd *Command) ([]string, error) {
-	// Get character's skills
-	_, err := h.repoManager.Characters().GetCharacter(cmd.CharacterID)
-	if err != nil {
-		return []string{"Error retrieving character skills."}, nil
+func (h *SkillsHandler) Execute(ctx *HandlerContext, cmd *Command) (*CommandResult, error) {
+	if ctx.Character == nil {
+		return Reply("Error retrieving character skills."), nil
 	}
 	
 	response := []string{"Your skills:"}
 	// This would iterate through actual skills
 	response = append(response, "  Swords: 15", "  Magic: 8", "  Stealth: 12")
 	
-	return response, nil
+	return Reply(response...), nil
 }
 
+// PracticeHandler spends a practice session on a skill, which needs a
+// trainer for that skill in the room.
 type PracticeHandler struct {
 	repoManager interfaces.RepositoryManager
+	npcs        *npc.Manager
 }
 
-func (h *PracticeHandler) Execute(cmd *Command) ([]string, error) {
-	skill := strings.Join(cmd.Args, " ")
-	return []string{fmt.Sprintf("You practice %s.", skill)}, nil
-}
-
-type HelpHandler struct{}
-
-func (h *HelpHandler) Execute(cmd *Command) ([]string, error) {
-	if len(cmd.Args) == 0 {
-		return []string{
-			"Available command categories:",
-			"  movement - Movement commands (north, south, etc.)",
-			"  communication - Chat commands (say, tell, etc.)",
-			"  inventory - Item commands (get, drop, wear, etc.)",
-			"  information - Info commands (look, examine, who, etc.)",
-			"  skills - Skill commands (skills, practice)",
-			"  social - Social commands (emote, smile, etc.)",
-			"",
-			"Type 'help <category>' for specific commands.",
-			"Type 'commands' to list all available commands.",
-		}, nil
-	}
-	
-	topic := strings.ToLower(cmd.Args[0])
-	switch topic {
-	case "movement":
-		return []string{
-			"Movement commands:",
-			"  north, south, east, west (n, s, e, w)",
-			"  up, down (u, d)",
-			"  northeast, northwest, southeast, southwest (ne, nw, se, sw)",
-		}, nil
-	case "communication":
-		return []string{
-			"Communication commands:",
-			"  say <message> (') - Say something to everyone in the room",
-			"  tell <player> <message> (t) - Send private message",
-			"  yell <message> - Yell across the area",
-			"  whisper <player> <message> (w) - Whisper to someone",
-			"  chat <message> (.) - Talk on global chat channel",
-		}, nil
-	default:
-		return []string{fmt.Sprintf("No help available for topic: %s", topic)}, nil
+func (h *PracticeHandler) Execute(ctx *HandlerContext, cmd *Command) (*CommandResult, error) {
+	char := ctx.Character
+	if char == nil {
+		return Reply("Error retrieving character information."), nil
+	}
+	
+	name := strings.Join(cmd.Args, " ")
+	skill, ok := character.SkillByName(name)
+	if !ok {
+		return Reply(fmt.Sprintf("There is no skill called '%s'.", name)), nil
 	}
+	skillName := character.GetSkillName(skill)
+	
+	trainer := h.npcs.Trainer(ctx.RoomID(), skill)
+	if trainer == nil {
+		return Reply(fmt.Sprintf("There is no one here who can teach you %s.", skillName)), nil
+	}
+	
+	improved, err := char.Practice(skill, trainer.Template.ID)
+	if errors.Is(err, character.ErrNoPractices) {
+		return Reply("You have no practice sessions left. Gain a level to earn more."), nil
+	}
+	if err != nil {
+		return Reply("Error practicing skill."), nil
+	}
+	
+	response := []string{fmt.Sprintf("You practice %s with %s.", skillName, trainer.Template.Name)}
+	if improved {
+		response = append(response, fmt.Sprintf("Your %s skill improves to %d.", skillName, char.Skills.GetSkillLevel(skill)))
+		response = append(response, spellsLearned(char.LearnSpells())...)
+	}
+	response = append(response, fmt.Sprintf("You have %d practice sessions left.", char.Practices))
+	return Reply(response...), nil
 }
 
-type CommandsHandler struct{}
-
-func (h *CommandsHandler) Execute(cmd *Command) ([]string, error) {
-	return []string{
-		"Available commands:",
-		"Movement: north, south, east, west, up, down, ne, nw, se, sw",
-		"Communication: say, tell, yell, whisper, chat",
-		"Information: look, examine, who, score, time, weather",
-		"Inventory: inventory, get, drop, give, wear, remove",
-		"Skills: skills, practice",
-		"Social: emote, smile, wave, bow",
-		"System: help, commands, quit, save",
-	}, nil
+// QuitHandler saves the character and disconnects. Nobody may quit in the
+// middle of a fight, and players who want it are asked to confirm first.
+type QuitHandler struct {
+	repoManager interfaces.RepositoryManager
 }
 
-type QuitHandler struct{}
-
-func (h *QuitHandler) Execute(cmd *Command) ([]string, error) {
-	return []string{"Saving character and disconnecting..."}, nil
+func (h *QuitHandler) Execute(ctx *HandlerContext, cmd *Command) (*CommandResult, error) {
+	char := ctx.Character
+	if char == nil {
+		return Reply("Error retrieving character information."), nil
+	}
+	
+	if char.State == character.CharacterInCombat {
+		return Reply("You can't quit in the middle of a fight! Flee or finish it first."), nil
+	}
+	
+	confirmed := len(cmd.Args) > 0 && strings.EqualFold(cmd.Args[0], "confirm")
+	if !confirmed {
+		if len(cmd.Args) > 0 {
+			return Reply("Usage: quit [confirm]"), nil
+		}
+		if p, err := h.repoManager.Players().GetPlayer(char.PlayerID); err == nil && p.Preferences.ConfirmQuit {
+			return Reply("Are you sure you want to leave? Type 'quit confirm' to quit, or 'confirmquit off' to stop being asked."), nil
+		}
+	}
+	
+	char.UpdatePlayTime()
+	if err := h.repoManager.Characters().UpdateCharacter(char); err != nil {
+		return Reply("Error saving character, so you have not quit. Please try again."), nil
+	}
+	
+	return Reply("Character saved. Farewell!").
+		ToRoom("", fmt.Sprintf("%s has left the realm.", ctx.ActorName()), char.ID).
+		WithSignal(SignalDisconnect), nil
 }
 
 type SaveHandler struct {
 	repoManager interfaces.RepositoryManager
 }
 
-func (h *SaveHandler) Execute(cmd *Command) ([]string, error) {
+func (h *SaveHandler) Execute(ctx *HandlerContext, cmd *Command) (*CommandResult, error) {
 	// Save character
-	char, err := h.repoManager.Characters().GetCharacter(cmd.CharacterID)
-	if err != nil {
-		return []string{"Error saving character."}, nil
+	char := ctx.Character
+	if char == nil {
+		return Reply("Error saving character."), nil
 	}
 	
 	char.UpdatePlayTime()
-	err = h.repoManager.Characters().UpdateCharacter(char)
-	if err != nil {
-		return []string{"Error saving character."}, nil
+	if err := h.repoManager.Characters().UpdateCharacter(char); err != nil {
+		return Reply("Error saving character."), nil
 	}
 	
-	return []string{"Character saved."}, nil
+	return Reply("Character saved."), nil
+}
+
+type SkipHandler struct {
+	repoManager interfaces.RepositoryManager
+	starts      *character.StartLocations
+}
+
+func (h *SkipHandler) Execute(ctx *HandlerContext, cmd *Command) (*CommandResult, error) {
+	char := ctx.Character
+	if char == nil {
+		return Reply("Error loading character."), nil
+	}
+	
+	if !char.InTutorial() {
+		return Reply("You are not in the tutorial."), nil
+	}
+	
+	char.EndTutorial(h.starts)
+	return Reply("You leave the tutorial behind. Type 'help' whenever you need a reminder."), nil
 }
 
 type EmoteHandler struct{}
 
-func (h *EmoteHandler) Execute(cmd *Command) ([]string, error) {
+func (h *EmoteHandler) Execute(ctx *HandlerContext, cmd *Command) (*CommandResult, error) {
 	emote := strings.Join(cmd.Args, " ")
-	return []string{fmt.Sprintf("You %s", emote)}, nil
+	return Reply(fmt.Sprintf("You %s", emote)).
+		ToRoom("", fmt.Sprintf("%s %s", ctx.ActorName(), emote), cmd.CharacterID), nil
 }
 
 type SocialHandler struct {
 	action string
 }
 
-func (h *SocialHandler) Execute(cmd *Command) ([]string, error) {
+func (h *SocialHandler) Execute(ctx *HandlerContext, cmd *Command) (*CommandResult, error) {
+	name := ctx.ActorName()
 	if len(cmd.Args) == 0 {
-		return []string{fmt.Sprintf("You %s.", h.action)}, nil
+		return Reply(fmt.Sprintf("You %s.", h.action)).
+			ToRoom("", fmt.Sprintf("%s %ss.", name, h.action), cmd.CharacterID), nil
 	}
 	
 	target := strings.Join(cmd.Args, " ")
-	return []string{fmt.Sprintf("You %s at %s.", h.action, target)}, nil
-}
-
-type KillHandler struct {
-	repoManager interfaces.RepositoryManager
-}
-
-func (h *KillHandler) Execute(cmd *Command) ([]string, error) {
-	target := strings.Join(cmd.Args, " ")
-	return []string{fmt.Sprintf("You attack %s!", target)}, nil
-}
-
-type FleeHandler struct{}
-
-func (h *FleeHandler) Execute(cmd *Command) ([]string, error) {
-	return []string{"You attempt to flee from combat!"}, nil
+	return Reply(fmt.Sprintf("You %s at %s.", h.action, target)).
+		ToRoom("", fmt.Sprintf("%s %ss at %s.", name, h.action, target), cmd.CharacterID), nil
 }
-
-type DefendHandler struct{}
-
-func (h *DefendHandler) Execute(cmd *Command) ([]string, error) {
-	return []string{"You focus on defending yourself."}, nil
-}
\ No newline at end of file
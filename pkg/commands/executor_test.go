@@ -3,7 +3,10 @@ package commands
 import (
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/elidor/dungeogo/pkg/game/character"
+	"github.com/elidor/dungeogo/pkg/game/world"
 	"github.com/elidor/dungeogo/pkg/testutil"
 )
 
@@ -50,7 +53,7 @@ func TestExecuteUnknownCommand(t *testing.T) {
 		CharacterID: "char1",
 	}
 	
-	responses, err := executor.Execute(cmd)
+	responses, err := execute(executor, cmd)
 	if err != nil {
 		t.Errorf("Unexpected error: %v", err)
 	}
@@ -70,27 +73,33 @@ func TestExecuteMovementCommand(t *testing.T) {
 		t.Skip("No database available for testing")
 	}
 	
-	executor := NewExecutor(repoManager)
-	
-	cmd := &Command{
-		Type:        CommandMovement,
-		Verb:        "north",
-		Args:        []string{},
-		PlayerID:    "player1",
-		CharacterID: "char1",
+	testPlayer := testutil.CreateTestPlayer()
+	if err := repoManager.Players().CreatePlayer(testPlayer); err != nil {
+		t.Fatalf("Failed to create test player: %v", err)
+	}
+	testChar := testutil.CreateTestCharacter(testPlayer.ID)
+	if err := repoManager.Characters().CreateCharacter(testChar); err != nil {
+		t.Fatalf("Failed to create test character: %v", err)
 	}
 	
-	responses, err := executor.Execute(cmd)
+	executor := NewExecutor(repoManager)
+	
+	cmd := NewParser().Parse("east", testPlayer.ID, testChar.ID)
+	responses, err := execute(executor, cmd)
 	if err != nil {
 		t.Errorf("Unexpected error: %v", err)
 	}
 	
-	if len(responses) != 1 {
-		t.Errorf("Expected 1 response, got %d", len(responses))
+	if len(responses) < 2 || responses[0] != "You go east." || responses[1] != "Riverbank" {
+		t.Errorf("Expected to arrive at the riverbank, got: %v", responses)
 	}
 	
-	if !strings.Contains(responses[0], "move north") {
-		t.Errorf("Expected movement message, got: %s", responses[0])
+	moved, err := repoManager.Characters().GetCharacter(testChar.ID)
+	if err != nil {
+		t.Fatalf("Failed to reload character: %v", err)
+	}
+	if moved.Location.RoomID != "riverbank" {
+		t.Errorf("Expected saved location riverbank, got %s", moved.Location.RoomID)
 	}
 }
 
@@ -110,7 +119,7 @@ func TestExecuteCommunicationCommand(t *testing.T) {
 		CharacterID: "char1",
 	}
 	
-	responses, err := executor.Execute(cmd)
+	responses, err := execute(executor, cmd)
 	if err != nil {
 		t.Errorf("Unexpected error: %v", err)
 	}
@@ -124,6 +133,44 @@ func TestExecuteCommunicationCommand(t *testing.T) {
 	}
 }
 
+func TestExecuteSayReachesRoom(t *testing.T) {
+	repoManager := testutil.SetupTestDB(t)
+	if repoManager == nil {
+		t.Skip("No database available for testing")
+	}
+	
+	testPlayer := testutil.CreateTestPlayer()
+	if err := repoManager.Players().CreatePlayer(testPlayer); err != nil {
+		t.Fatalf("Failed to create test player: %v", err)
+	}
+	testChar := testutil.CreateTestCharacter(testPlayer.ID)
+	if err := repoManager.Characters().CreateCharacter(testChar); err != nil {
+		t.Fatalf("Failed to create test character: %v", err)
+	}
+	
+	executor := NewExecutor(repoManager)
+	result, err := executor.Execute(testContext(executor, testChar.ID), &Command{
+		Type:        CommandCommunication,
+		Verb:        "say",
+		Args:        []string{"hello"},
+		PlayerID:    testPlayer.ID,
+		CharacterID: testChar.ID,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	
+	if len(result.Room) != 1 {
+		t.Fatalf("Expected one room message, got %d", len(result.Room))
+	}
+	if result.Room[0].Text != testChar.Name+" says: hello" {
+		t.Errorf("Unexpected room message: %s", result.Room[0].Text)
+	}
+	if len(result.Room[0].Exclude) != 1 || result.Room[0].Exclude[0] != testChar.ID {
+		t.Errorf("Expected the speaker to be excluded, got %v", result.Room[0].Exclude)
+	}
+}
+
 func TestExecuteTellCommand(t *testing.T) {
 	repoManager := testutil.SetupTestDB(t)
 	if repoManager == nil {
@@ -141,7 +188,7 @@ func TestExecuteTellCommand(t *testing.T) {
 		CharacterID: "char1",
 	}
 	
-	responses, err := executor.Execute(cmd)
+	responses, err := execute(executor, cmd)
 	if err != nil {
 		t.Errorf("Unexpected error: %v", err)
 	}
@@ -157,7 +204,7 @@ func TestExecuteTellCommand(t *testing.T) {
 	
 	// Test tell with insufficient args
 	cmd.Args = []string{"bob"} // Missing message
-	responses, err = executor.Execute(cmd)
+	responses, err = execute(executor, cmd)
 	if err != nil {
 		t.Errorf("Unexpected error: %v", err)
 	}
@@ -184,7 +231,7 @@ func TestExecuteLookCommand(t *testing.T) {
 		CharacterID: "char1",
 	}
 	
-	responses, err := executor.Execute(cmd)
+	responses, err := execute(executor, cmd)
 	if err != nil {
 		t.Errorf("Unexpected error: %v", err)
 	}
@@ -199,7 +246,7 @@ func TestExecuteLookCommand(t *testing.T) {
 	
 	// Test look at target
 	cmd.Args = []string{"sword"}
-	responses, err = executor.Execute(cmd)
+	responses, err = execute(executor, cmd)
 	if err != nil {
 		t.Errorf("Unexpected error: %v", err)
 	}
@@ -225,7 +272,7 @@ func TestExecuteWhoCommand(t *testing.T) {
 		CharacterID: "char1",
 	}
 	
-	responses, err := executor.Execute(cmd)
+	responses, err := execute(executor, cmd)
 	if err != nil {
 		t.Errorf("Unexpected error: %v", err)
 	}
@@ -264,7 +311,7 @@ func TestExecuteHelpCommand(t *testing.T) {
 		CharacterID: "char1",
 	}
 	
-	responses, err := executor.Execute(cmd)
+	responses, err := execute(executor, cmd)
 	if err != nil {
 		t.Errorf("Unexpected error: %v", err)
 	}
@@ -287,7 +334,7 @@ func TestExecuteHelpCommand(t *testing.T) {
 	
 	// Test help with topic
 	cmd.Args = []string{"movement"}
-	responses, err = executor.Execute(cmd)
+	responses, err = execute(executor, cmd)
 	if err != nil {
 		t.Errorf("Unexpected error: %v", err)
 	}
@@ -317,7 +364,7 @@ func TestExecuteCommandsCommand(t *testing.T) {
 		CharacterID: "char1",
 	}
 	
-	responses, err := executor.Execute(cmd)
+	responses, err := execute(executor, cmd)
 	if err != nil {
 		t.Errorf("Unexpected error: %v", err)
 	}
@@ -358,7 +405,7 @@ func TestExecuteEmoteCommand(t *testing.T) {
 		CharacterID: "char1",
 	}
 	
-	responses, err := executor.Execute(cmd)
+	responses, err := execute(executor, cmd)
 	if err != nil {
 		t.Errorf("Unexpected error: %v", err)
 	}
@@ -390,7 +437,7 @@ func TestExecuteSocialCommand(t *testing.T) {
 		CharacterID: "char1",
 	}
 	
-	responses, err := executor.Execute(cmd)
+	responses, err := execute(executor, cmd)
 	if err != nil {
 		t.Errorf("Unexpected error: %v", err)
 	}
@@ -401,7 +448,7 @@ func TestExecuteSocialCommand(t *testing.T) {
 	
 	// Test social command with target
 	cmd.Args = []string{"bob"}
-	responses, err = executor.Execute(cmd)
+	responses, err = execute(executor, cmd)
 	if err != nil {
 		t.Errorf("Unexpected error: %v", err)
 	}
@@ -419,16 +466,16 @@ func TestExecuteUnimplementedCommand(t *testing.T) {
 	
 	executor := NewExecutor(repoManager)
 	
-	// Create a command that exists in parser but not in executor
+	// Create a command that has no handler in the executor
 	cmd := &Command{
 		Type:        CommandMagic,
-		Verb:        "cast",
-		Args:        []string{"fireball"},
+		Verb:        "teleport",
+		Args:        []string{"home"},
 		PlayerID:    "player1",
 		CharacterID: "char1",
 	}
 	
-	responses, err := executor.Execute(cmd)
+	responses, err := execute(executor, cmd)
 	if err != nil {
 		t.Errorf("Unexpected error: %v", err)
 	}
@@ -474,7 +521,7 @@ func TestExecuteWithDatabaseCharacter(t *testing.T) {
 		CharacterID: testChar.ID,
 	}
 	
-	responses, err := executor.Execute(cmd)
+	responses, err := execute(executor, cmd)
 	if err != nil {
 		t.Errorf("Unexpected error: %v", err)
 	}
@@ -516,4 +563,256 @@ func TestHandlerInitialization(t *testing.T) {
 			t.Errorf("Expected handler '%s' to be initialized", handlerName)
 		}
 	}
-}
\ No newline at end of file
+}
+func TestExecuteQuestKillObjective(t *testing.T) {
+	repoManager := testutil.SetupTestDB(t)
+	if repoManager == nil {
+		t.Skip("No database available for testing")
+	}
+	
+	testPlayer := testutil.CreateTestPlayer()
+	if err := repoManager.Players().CreatePlayer(testPlayer); err != nil {
+		t.Fatalf("Failed to create test player: %v", err)
+	}
+	
+	testChar := testutil.CreateTestCharacter(testPlayer.ID)
+	if err := repoManager.Characters().CreateCharacter(testChar); err != nil {
+		t.Fatalf("Failed to create test character: %v", err)
+	}
+	
+	executor := NewExecutor(repoManager)
+	if err := executor.NPCs().Populate(); err != nil {
+		t.Fatalf("Failed to populate NPCs: %v", err)
+	}
+	// Hit as hard as possible, and let nothing drop but gold
+	executor.handlers["kill"].(*KillHandler).roll = func(n int) int { return n - 1 }
+	parser := NewParser()
+	run := func(input string) []string {
+		responses, err := execute(executor, parser.Parse(input, testPlayer.ID, testChar.ID))
+		if err != nil {
+			t.Fatalf("Unexpected error for %q: %v", input, err)
+		}
+		return responses
+	}
+	
+	run("accept goblin menace")
+	if err := repoManager.Characters().UpdateCharacterLocation(testChar.ID, &character.Location{RoomID: "riverbank"}); err != nil {
+		t.Fatalf("Failed to move character: %v", err)
+	}
+	for i := 0; i < 50 && len(executor.NPCs().InRoom("riverbank")) > 0; i++ {
+		run("kill goblin")
+	}
+	if err := repoManager.Characters().UpdateCharacterLocation(testChar.ID, &character.Location{RoomID: character.DefaultStartRoomID}); err != nil {
+		t.Fatalf("Failed to move character back: %v", err)
+	}
+	run("complete goblin menace")
+	
+	char, err := repoManager.Characters().GetCharacter(testChar.ID)
+	if err != nil {
+		t.Fatalf("Failed to reload character: %v", err)
+	}
+	
+	if !char.Quests.IsCompleted("goblin_menace") {
+		t.Errorf("Expected quest to be completed")
+	}
+	
+	if char.Experience != testChar.Experience+3*25+100 || char.Gold != testChar.Gold+25 {
+		t.Errorf("Expected quest rewards to be granted, got %d XP and %d gold", char.Experience, char.Gold)
+	}
+	
+	items, err := repoManager.Items().GetPlayerItems(testChar.ID)
+	if err != nil || len(items) != 1 || items[0].TemplateID != "health_potion" {
+		t.Errorf("Expected a health potion reward, got %v (%v)", items, err)
+	}
+}
+
+// execute runs cmd and returns only the lines meant for the actor.
+func execute(executor *Executor, cmd *Command) ([]string, error) {
+	result, err := executor.Execute(testContext(executor, cmd.CharacterID), cmd)
+	if err != nil {
+		return nil, err
+	}
+	return result.Messages, nil
+}
+
+// testContext loads the character's context, falling back to an empty one for
+// the made-up character IDs many tests use.
+func testContext(executor *Executor, characterID string) *HandlerContext {
+	ctx, err := executor.LoadContext(characterID)
+	if err != nil {
+		return &HandlerContext{Messenger: NopMessenger{}}
+	}
+	return ctx
+}
+
+func TestExecuteCraft(t *testing.T) {
+	repoManager := testutil.SetupTestDB(t)
+	if repoManager == nil {
+		t.Skip("No database available for testing")
+	}
+	
+	testPlayer := testutil.CreateTestPlayer()
+	if err := repoManager.Players().CreatePlayer(testPlayer); err != nil {
+		t.Fatalf("Failed to create test player: %v", err)
+	}
+	testChar := testutil.CreateTestCharacter(testPlayer.ID)
+	if err := repoManager.Characters().CreateCharacter(testChar); err != nil {
+		t.Fatalf("Failed to create test character: %v", err)
+	}
+	
+	ore := testutil.CreateTestItemInstance("iron_ore", testChar.ID)
+	ore.Quantity = 3
+	if err := repoManager.Items().CreateItemInstance(ore); err != nil {
+		t.Fatalf("Failed to create ore: %v", err)
+	}
+	
+	executor := NewExecutor(repoManager)
+	executor.handlers["craft"].(*CraftHandler).roll = func(n int) int { return 0 }
+	
+	responses, err := execute(executor, NewParser().Parse("craft iron ingot", testPlayer.ID, testChar.ID))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(responses) == 0 || !strings.Contains(responses[0], "You craft Iron Ingot") {
+		t.Errorf("Expected crafting success, got %v", responses)
+	}
+	
+	carried, err := repoManager.Items().GetPlayerItems(testChar.ID)
+	if err != nil {
+		t.Fatalf("Failed to load items: %v", err)
+	}
+	counts := make(map[string]int)
+	for _, item := range carried {
+		counts[item.TemplateID] += item.Quantity
+	}
+	if counts["iron_ore"] != 1 || counts["iron_ingot"] != 1 {
+		t.Errorf("Expected 1 ore and 1 ingot left, got %v", counts)
+	}
+	
+	char, err := repoManager.Characters().GetCharacter(testChar.ID)
+	if err != nil {
+		t.Fatalf("Failed to reload character: %v", err)
+	}
+	if char.Skills.GetSkill(character.SkillCrafting).Experience == 0 {
+		t.Errorf("Expected crafting experience to be awarded")
+	}
+}
+
+func TestExecuteMineDepletesNode(t *testing.T) {
+	repoManager := testutil.SetupTestDB(t)
+	if repoManager == nil {
+		t.Skip("No database available for testing")
+	}
+	
+	testPlayer := testutil.CreateTestPlayer()
+	if err := repoManager.Players().CreatePlayer(testPlayer); err != nil {
+		t.Fatalf("Failed to create test player: %v", err)
+	}
+	testChar := testutil.CreateTestCharacter(testPlayer.ID)
+	if err := repoManager.Characters().CreateCharacter(testChar); err != nil {
+		t.Fatalf("Failed to create test character: %v", err)
+	}
+	
+	executor := NewExecutor(repoManager)
+	handler := executor.handlers["mine"].(*GatherHandler)
+	handler.roll = func(n int) int { return 0 }
+	now := time.Now()
+	handler.now = func() time.Time { return now }
+	
+	node := world.FindResourceNode(testChar.Location.RoomID, character.SkillMining)
+	if node == nil {
+		t.Fatalf("Expected a mining node in the starting room")
+	}
+	
+	cmd := NewParser().Parse("mine", testPlayer.ID, testChar.ID)
+	for i := 0; i < node.Capacity; i++ {
+		responses, err := execute(executor, cmd)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !strings.HasPrefix(responses[0], "You mine") {
+			t.Errorf("Expected to mine ore, got %v", responses)
+		}
+	}
+	
+	responses, err := execute(executor, cmd)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if responses[0] != "There is nothing left to mine here." {
+		t.Errorf("Expected depleted node message, got %v", responses)
+	}
+	
+	carried, err := repoManager.Items().GetPlayerItems(testChar.ID)
+	if err != nil {
+		t.Fatalf("Failed to load items: %v", err)
+	}
+	if len(carried) != 1 || carried[0].TemplateID != node.Yield || carried[0].Quantity != node.Capacity {
+		t.Errorf("Expected one stack of %d %s, got %v", node.Capacity, node.Yield, carried)
+	}
+}
+
+func TestExecuteUnlockDoor(t *testing.T) {
+	repoManager := testutil.SetupTestDB(t)
+	if repoManager == nil {
+		t.Skip("No database available for testing")
+	}
+	
+	testPlayer := testutil.CreateTestPlayer()
+	if err := repoManager.Players().CreatePlayer(testPlayer); err != nil {
+		t.Fatalf("Failed to create test player: %v", err)
+	}
+	testChar := testutil.CreateTestCharacter(testPlayer.ID)
+	if err := repoManager.Characters().CreateCharacter(testChar); err != nil {
+		t.Fatalf("Failed to create test character: %v", err)
+	}
+	
+	executor := NewExecutor(repoManager)
+	parser := NewParser()
+	
+	responses, err := execute(executor, parser.Parse("north", testPlayer.ID, testChar.ID))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if responses[0] != "The storeroom door is locked." {
+		t.Errorf("Expected the door to be locked, got %v", responses)
+	}
+	
+	responses, err = execute(executor, parser.Parse("unlock north", testPlayer.ID, testChar.ID))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if responses[0] != "You don't have the key to the storeroom door." {
+		t.Errorf("Expected to need the key, got %v", responses)
+	}
+	
+	key := testutil.CreateTestItemInstance("storeroom_key", testChar.ID)
+	if err := repoManager.Items().CreateItemInstance(key); err != nil {
+		t.Fatalf("Failed to create key: %v", err)
+	}
+	
+	responses, err = execute(executor, parser.Parse("unlock door", testPlayer.ID, testChar.ID))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if responses[0] != "You unlock the storeroom door." {
+		t.Errorf("Expected to unlock the door, got %v", responses)
+	}
+	
+	responses, err = execute(executor, parser.Parse("north", testPlayer.ID, testChar.ID))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if responses[0] != "You go north." {
+		t.Errorf("Expected to walk through the door, got %v", responses)
+	}
+	
+	// The other side of the door sees it unlocked too
+	responses, err = execute(executor, parser.Parse("lock south", testPlayer.ID, testChar.ID))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if responses[0] != "You lock the storeroom door." {
+		t.Errorf("Expected to lock the door behind us, got %v", responses)
+	}
+}
@@ -0,0 +1,96 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+	"github.com/elidor/dungeogo/pkg/game/event"
+	"github.com/elidor/dungeogo/pkg/game/reward"
+)
+
+// experienceRules decides what earning experience does. One is shared by
+// every handler that awards experience.
+type experienceRules struct {
+	mode character.LevelingMode
+	// events is where new levels are announced
+	events *event.Bus
+	// rewards scales the experience and gold characters earn
+	rewards *reward.Multiplier
+}
+
+// rates returns the reward rates in force, which are normal if none have
+// been set
+func (r *experienceRules) rates() reward.Rates {
+	if r == nil || r.rewards == nil {
+		return reward.Normal
+	}
+	return r.rewards.Rates()
+}
+
+// gold returns amount gold earned, scaled by the reward rates
+func (r *experienceRules) gold(amount int) int {
+	return r.rates().ApplyGold(amount)
+}
+
+// train gives char amount experience in skill, scaled by the reward rates,
+// and reports whether the skill improved
+func (r *experienceRules) train(char *character.Character, skill character.SkillType, amount int) bool {
+	return char.Skills.AddExperience(skill, r.rates().ApplyExperience(amount))
+}
+
+// award gives char amount experience, scaled by the reward rates, and
+// returns the lines telling them about it. With automatic leveling that includes any new level and their
+// progress to the next; with manual leveling it tells them once they can
+// gain a level at a trainer.
+func (r *experienceRules) award(char *character.Character, amount int) []string {
+	if amount <= 0 {
+		return nil
+	}
+	amount = r.rates().ApplyExperience(amount)
+	response := []string{fmt.Sprintf("You gain %d experience.", amount)}
+
+	if r.mode == character.LevelingManual {
+		wasReady := char.CanLevelUp()
+		char.Experience += amount
+		if !wasReady && char.CanLevelUp() {
+			response = append(response, "You have enough experience to gain a level. Visit a trainer and type 'gain'.")
+		}
+		return response
+	}
+
+	previous := char.Level
+	if char.GainExperience(amount) > 0 {
+		response = append(response,
+			fmt.Sprintf("You have reached level %d!", char.Level),
+			fmt.Sprintf("Experience: %s", char.ExperienceProgress()))
+		for level := previous + 1; level <= char.Level; level++ {
+			response = append(response, r.levelUp(char, level)...)
+		}
+	}
+	return response
+}
+
+// levelUp announces that char has reached level
+func (r *experienceRules) levelUp(char *character.Character, level int) []string {
+	if r.events == nil {
+		return nil
+	}
+	return r.events.Publish(event.LevelUp{Character: char, Level: level})
+}
+
+// RewardNotice tells players the reward rates now in force
+func RewardNotice(rates reward.Rates) string {
+	if rates.IsNormal() {
+		return "Experience and gold are back to their normal rates."
+	}
+	return fmt.Sprintf("Reward rates are in effect: %s.", rates)
+}
+
+// announceRates tells a character entering the world about any reward
+// rates in force
+func (r *experienceRules) announceRates(e event.Event) []string {
+	if rates := r.rates(); !rates.IsNormal() {
+		return []string{RewardNotice(rates)}
+	}
+	return nil
+}
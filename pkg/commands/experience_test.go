@@ -0,0 +1,106 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/elidor/dungeogo/pkg/game/achievement"
+	"github.com/elidor/dungeogo/pkg/game/character"
+	"github.com/elidor/dungeogo/pkg/game/event"
+	"github.com/elidor/dungeogo/pkg/game/reward"
+)
+
+func TestAwardExperienceMessages(t *testing.T) {
+	rules := &experienceRules{}
+	char := testCharacter(character.DefaultStartRoomID)
+	if lines := rules.award(char, 0); lines != nil {
+		t.Errorf("Expected nothing for no experience, got %v", lines)
+	}
+	if lines := rules.award(char, 40); strings.Join(lines, "\n") != "You gain 40 experience." {
+		t.Errorf("Unexpected lines %v", lines)
+	}
+
+	expected := []string{
+		"You gain 80 experience.",
+		"You have reached level 2!",
+		"Experience: 20/400 (5%)",
+	}
+	if lines := rules.award(char, 80); strings.Join(lines, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Expected %v, got %v", expected, lines)
+	}
+}
+
+func TestAwardExperienceManualLeveling(t *testing.T) {
+	rules := &experienceRules{mode: character.LevelingManual}
+	char := testCharacter(character.DefaultStartRoomID)
+
+	expected := []string{
+		"You gain 120 experience.",
+		"You have enough experience to gain a level. Visit a trainer and type 'gain'.",
+	}
+	if lines := rules.award(char, 120); strings.Join(lines, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Expected %v, got %v", expected, lines)
+	}
+	if char.Level != 1 || char.Experience != 120 {
+		t.Errorf("Expected to stay level 1 with 120 experience, got level %d with %d", char.Level, char.Experience)
+	}
+
+	// Only the award that makes a level available mentions it
+	if lines := rules.award(char, 10); strings.Join(lines, "\n") != "You gain 10 experience." {
+		t.Errorf("Unexpected lines %v", lines)
+	}
+}
+
+func TestRewardRatesScaleRewards(t *testing.T) {
+	executor, repos := newFightExecutor(t)
+	executor.SetRewardRates(reward.Rates{Experience: 2, Gold: 3})
+	repos.players.player.Preferences.AutoLoot = true
+	char := testCharacter("riverbank")
+	// Earned already, so its reward doesn't add to the loot
+	char.Achievements.Record(achievement.TriggerKill, "", 1, time.Now())
+	gold := char.Gold
+
+	messages := strings.Join(killUntilDead(t, executor, &HandlerContext{Character: char}, "goblin"), "\n")
+	if !strings.Contains(messages, "You gain 50 experience.") {
+		t.Errorf("Expected double experience for the goblin, got:\n%s", messages)
+	}
+	if !strings.Contains(messages, "You take 3 gold from a goblin.") || char.Gold != gold+3 {
+		t.Errorf("Expected triple gold, got %d gold from:\n%s", char.Gold-gold, messages)
+	}
+	if got := char.Skills.GetSkill(character.SkillStealth).Experience; got != 0 {
+		t.Fatalf("Expected no Stealth experience yet, got %d", got)
+	}
+	awardStealth(char, executor.experience)
+	if got := char.Skills.GetSkill(character.SkillStealth).Experience; got != 2*stealthExperience {
+		t.Errorf("Expected double skill experience, got %d", got)
+	}
+}
+
+func TestRewardRatesShown(t *testing.T) {
+	executor := NewExecutor(newMemoryRepos())
+	char := testCharacter(character.DefaultStartRoomID)
+	score := func() string {
+		result, err := executor.handlers["score"].Execute(&HandlerContext{Character: char}, &Command{Verb: "score"})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		return strings.Join(result.Messages, "\n")
+	}
+
+	if strings.Contains(score(), "Reward rates") {
+		t.Errorf("Expected normal rates not to be shown")
+	}
+	if lines := executor.Events().Publish(event.Login{Character: char}); len(lines) != 0 {
+		t.Errorf("Expected no notice at normal rates, got %v", lines)
+	}
+
+	executor.SetRewardRates(reward.Rates{Experience: 2, Gold: 1})
+	if !strings.Contains(score(), "Reward rates: experience x2") {
+		t.Errorf("Expected score to show double experience, got:\n%s", score())
+	}
+	lines := executor.Events().Publish(event.Login{Character: char})
+	if len(lines) != 1 || lines[0] != "Reward rates are in effect: experience x2." {
+		t.Errorf("Expected a notice on entering the world, got %v", lines)
+	}
+}
@@ -0,0 +1,78 @@
+package commands
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+	"github.com/elidor/dungeogo/pkg/game/combat"
+	"github.com/elidor/dungeogo/pkg/game/npc"
+	"github.com/elidor/dungeogo/pkg/persistence/interfaces"
+)
+
+// fights knows who is fighting whom, so a fight can be ended for both
+// sides. One is shared by every handler that starts or ends fights.
+type fights struct {
+	repoManager interfaces.RepositoryManager
+	npcs        *npc.Manager
+	stances     *combat.Stances
+	duels       *combat.Duels
+	locks       *character.Locks
+}
+
+// fighting reports whether anyone, player or NPC, is fighting characterID
+func (f *fights) fighting(characterID string) bool {
+	return len(f.duels.Foes(characterID)) > 0 || f.npcs.Fighting(characterID)
+}
+
+// endDuel ends the fight between char and foe, taking each out of combat
+// unless they have another foe
+func (f *fights) endDuel(char, foe *character.Character) {
+	f.duels.End(char.ID, foe.ID)
+	f.settle(char)
+	f.settle(foe)
+}
+
+// leave ends the fights of char, whose lock is held, with every player not
+// in present, as when char has left the room or the game. Those players
+// leave combat unless they have another foe.
+func (f *fights) leave(messenger Messenger, char *character.Character, present []string) {
+	for _, foeID := range f.duels.Foes(char.ID) {
+		if slices.Contains(present, foeID) {
+			continue
+		}
+		f.duels.End(char.ID, foeID)
+		f.release(messenger, foeID, fmt.Sprintf("%s is gone, so your fight with them is over.", char.Name))
+	}
+}
+
+// settle takes char, whose lock is held, out of combat if nobody is
+// fighting them any more, as when their foe gave up the chase or was
+// calmed. It reports whether they left combat.
+func (f *fights) settle(char *character.Character) bool {
+	if char.State != character.CharacterInCombat || f.fighting(char.ID) {
+		return false
+	}
+	char.State = character.CharacterAlive
+	f.stances.Clear(char.ID)
+	return true
+}
+
+// release takes characterID out of combat if nobody is fighting them any
+// more, telling them notice. A player busy elsewhere is left for their
+// next command to settle.
+func (f *fights) release(messenger Messenger, characterID, notice string) {
+	unlock, ok := f.locks.TryLock(characterID, foeLockWait)
+	if !ok {
+		return
+	}
+	defer unlock()
+	fighter, err := f.repoManager.Characters().GetCharacter(characterID)
+	if err != nil || !f.settle(fighter) {
+		return
+	}
+	if err := f.repoManager.Characters().UpdateCharacter(fighter); err != nil {
+		return
+	}
+	messenger.SendToCharacter(characterID, notice)
+}
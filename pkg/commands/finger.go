@@ -0,0 +1,96 @@
+package commands
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+	"github.com/elidor/dungeogo/pkg/game/stealth"
+	"github.com/elidor/dungeogo/pkg/persistence/interfaces"
+	"github.com/elidor/dungeogo/pkg/textutil"
+)
+
+// FingerHandler shows a character's public profile, online or not: who
+// they are, whether they are around, how they look and what they have
+// written about themselves. Nothing about their account or whereabouts is
+// shown, and hidden characters are not given away as online.
+type FingerHandler struct {
+	repoManager interfaces.RepositoryManager
+	stealth     *stealth.Tracker
+	now         func() time.Time
+}
+
+func (h *FingerHandler) Execute(ctx *HandlerContext, cmd *Command) (*CommandResult, error) {
+	if ctx.Character == nil {
+		return Reply("Error retrieving character information."), nil
+	}
+	if len(cmd.Args) == 0 {
+		return Reply("Finger whom?"), nil
+	}
+
+	target, err := h.repoManager.Characters().GetCharacterByName(cmd.Args[0])
+	if err != nil {
+		return Reply(fmt.Sprintf("There is no character named '%s'.", cmd.Args[0])), nil
+	}
+
+	result := Reply(
+		target.DisplayName(),
+		fmt.Sprintf("Level %d %s %s", target.Level, target.Race.Name, target.Class.Name),
+	)
+	if h.isOnline(ctx, target) {
+		result.Add("Online now.")
+	} else {
+		result.Add(fmt.Sprintf("Last seen %s.", textutil.RelativeTime(target.LastPlayed, h.now())))
+	}
+	if appearance := target.Appearance.Summary(); appearance != "" {
+		result.Add(appearance)
+	}
+	if target.Description != "" {
+		result.Add(strings.Split(target.Description, "\n")...)
+	}
+	if target.Profile == "" {
+		return result.Add(fmt.Sprintf("%s has not written a profile.", target.Name)), nil
+	}
+	return result.Add("Profile: " + target.Profile), nil
+}
+
+// isOnline reports whether target is in the game and not hiding from the
+// character looking
+func (h *FingerHandler) isOnline(ctx *HandlerContext, target *character.Character) bool {
+	if ctx.Messenger == nil || !slices.Contains(ctx.Messenger.OnlineCharacterIDs(), target.ID) {
+		return false
+	}
+	return target.ID == ctx.Character.ID || !h.stealth.IsHidden(target.ID)
+}
+
+// ProfileHandler shows or sets the public profile finger shows.
+type ProfileHandler struct{}
+
+func (h *ProfileHandler) Execute(ctx *HandlerContext, cmd *Command) (*CommandResult, error) {
+	char := ctx.Character
+	if char == nil {
+		return Reply("Error retrieving character information."), nil
+	}
+
+	if len(cmd.Args) == 0 {
+		if char.Profile == "" {
+			return Reply("You have no profile. Use 'profile <text>' to write one."), nil
+		}
+		return Reply("Your profile: " + char.Profile), nil
+	}
+
+	profile := strings.Join(cmd.Args, " ")
+	if strings.EqualFold(profile, "none") || strings.EqualFold(profile, "clear") {
+		profile = ""
+	}
+	if err := char.SetProfile(profile); err != nil {
+		return Reply(appearanceError(err)), nil
+	}
+
+	if char.Profile == "" {
+		return Reply("Your profile has been cleared."), nil
+	}
+	return Reply("Your profile has been saved."), nil
+}
@@ -0,0 +1,116 @@
+package commands
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+)
+
+func newFingerExecutor(t *testing.T) (*Executor, *HandlerContext, *character.Character) {
+	repos := newMemoryRepos()
+	executor := NewExecutor(repos)
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	executor.handlers["finger"].(*FingerHandler).now = func() time.Time { return now }
+
+	bob := testCharacter("riverbank")
+	bob.ID, bob.Name, bob.Title = "char2", "Bob", "the Bold"
+	bob.Level = 4
+	bob.LastPlayed = now.Add(-3 * time.Hour)
+	bob.Appearance.Set("eyes", "grey")
+	repos.characters.stored = map[string]*character.Character{bob.ID: bob}
+
+	ctx := &HandlerContext{Character: testCharacter(character.DefaultStartRoomID), Messenger: NopMessenger{}}
+	return executor, ctx, bob
+}
+
+func finger(t *testing.T, executor *Executor, ctx *HandlerContext, name string) []string {
+	t.Helper()
+	result, err := executor.handlers["finger"].Execute(ctx, &Command{Verb: "finger", Args: []string{name}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return result.Messages
+}
+
+func TestFingerShowsProfile(t *testing.T) {
+	executor, ctx, bob := newFingerExecutor(t)
+
+	expected := []string{"Bob the Bold", "Level 4 Human Warrior", "Last seen 3 hours ago.", "Eyes: grey.", "Bob has not written a profile."}
+	if messages := finger(t, executor, ctx, "bob"); !slices.Equal(messages, expected) {
+		t.Errorf("Expected %v, got %v", expected, messages)
+	}
+
+	bob.Profile = "Fisher of the riverbank."
+	messages := finger(t, executor, ctx, "Bob")
+	if messages[len(messages)-1] != "Profile: Fisher of the riverbank." {
+		t.Errorf("Expected the profile to be shown, got %v", messages)
+	}
+	for _, line := range messages {
+		if strings.Contains(line, "Account") || strings.Contains(line, "Location") {
+			t.Errorf("Expected nothing private to be shown, got %q", line)
+		}
+	}
+
+	if messages := finger(t, executor, ctx, "carol"); messages[0] != "There is no character named 'carol'." {
+		t.Errorf("Expected an unknown name to be reported, got %v", messages)
+	}
+}
+
+func TestFingerWithoutName(t *testing.T) {
+	executor, ctx, _ := newFingerExecutor(t)
+
+	result, err := executor.Execute(ctx, NewParser().Parse("finger", "player1", "char1"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.Messages[0] != "Finger whom?" {
+		t.Errorf("Expected to be asked whom to finger, got %v", result.Messages)
+	}
+}
+
+func TestFingerOnline(t *testing.T) {
+	executor, ctx, bob := newFingerExecutor(t)
+	ctx.Messenger = onlineMessenger{ids: []string{bob.ID}}
+
+	if messages := finger(t, executor, ctx, "bob"); messages[2] != "Online now." {
+		t.Errorf("Expected Bob to be shown online, got %v", messages)
+	}
+
+	// Hiding characters are not given away
+	executor.Stealth().Hide(bob.ID)
+	if messages := finger(t, executor, ctx, "bob"); messages[2] != "Last seen 3 hours ago." {
+		t.Errorf("Expected hidden Bob not to be shown online, got %v", messages)
+	}
+}
+
+func TestProfileCommand(t *testing.T) {
+	executor := NewExecutor(newMemoryRepos())
+	char := testCharacter(character.DefaultStartRoomID)
+	ctx := &HandlerContext{Character: char}
+	profile := func(args ...string) string {
+		result, err := executor.handlers["profile"].Execute(ctx, &Command{Verb: "profile", Args: args})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		return strings.Join(result.Messages, "\n")
+	}
+
+	if got := profile(); got != "You have no profile. Use 'profile <text>' to write one." {
+		t.Errorf("Expected no profile yet, got %q", got)
+	}
+	if got := profile("Wandering", "sellsword."); got != "Your profile has been saved." || char.Profile != "Wandering sellsword." {
+		t.Errorf("Expected the profile to be saved, got %q and %q", got, char.Profile)
+	}
+	if got := profile(); got != "Your profile: Wandering sellsword." {
+		t.Errorf("Expected the profile to be shown, got %q", got)
+	}
+	if got := profile(strings.Repeat("x", character.MaxProfileLength+1)); got != "Profiles may be at most 300 characters long." {
+		t.Errorf("Expected a long profile to be refused, got %q", got)
+	}
+	if got := profile("none"); got != "Your profile has been cleared." || char.Profile != "" {
+		t.Errorf("Expected the profile to be cleared, got %q", got)
+	}
+}
@@ -0,0 +1,163 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+	"github.com/elidor/dungeogo/pkg/game/follow"
+	"github.com/elidor/dungeogo/pkg/game/stealth"
+	"github.com/elidor/dungeogo/pkg/persistence/interfaces"
+)
+
+// FollowHandler starts following someone in the room, or says who the
+// character is following.
+type FollowHandler struct {
+	repoManager interfaces.RepositoryManager
+	follow      *follow.Tracker
+	stealth     *stealth.Tracker
+}
+
+func (h *FollowHandler) Execute(ctx *HandlerContext, cmd *Command) (*CommandResult, error) {
+	char := ctx.Character
+	if char == nil {
+		return Reply("Error retrieving character information."), nil
+	}
+
+	if len(cmd.Args) == 0 {
+		leaderID, ok := h.follow.Leader(char.ID)
+		if !ok {
+			return Reply("You are not following anyone."), nil
+		}
+		return Reply(fmt.Sprintf("You are following %s.", characterName(h.repoManager, leaderID))), nil
+	}
+
+	var leader *character.Character
+	for _, other := range othersInRoom(h.repoManager, ctx) {
+		if strings.EqualFold(other.Name, cmd.Args[0]) && !h.stealth.IsHidden(other.ID) {
+			leader = other
+			break
+		}
+	}
+	if leader == nil {
+		if strings.EqualFold(char.Name, cmd.Args[0]) {
+			return stopFollowing(h.repoManager, h.follow, char), nil
+		}
+		return Reply(fmt.Sprintf("You don't see %s here.", cmd.Args[0])), nil
+	}
+	if leaderID, ok := h.follow.Leader(char.ID); ok && leaderID == leader.ID {
+		return Reply(fmt.Sprintf("You are already following %s.", leader.Name)), nil
+	}
+	if char.State == character.CharacterInCombat {
+		return Reply("You can't follow anyone while you are fighting."), nil
+	}
+	if !h.follow.Leading(leader.ID) {
+		return Reply(fmt.Sprintf("%s isn't taking followers.", leader.Name)).
+			ToCharacter(leader.ID, fmt.Sprintf("%s would like to follow you. Use 'lead on' to let them.", char.Name)), nil
+	}
+
+	switch err := h.follow.Follow(char.ID, leader.ID); {
+	case errors.Is(err, follow.ErrFollowLoop):
+		return Reply(fmt.Sprintf("%s is already following you.", leader.Name)), nil
+	case err != nil:
+		return Reply("Error following."), nil
+	}
+	return Reply(fmt.Sprintf("You start following %s.", leader.Name)).
+		ToCharacter(leader.ID, fmt.Sprintf("%s starts following you. Use 'lose %s' if you'd rather they didn't.", char.Name, char.Name)), nil
+}
+
+// LeadHandler lets the character agree to be followed, or stop taking new
+// followers. Nobody takes followers until they turn it on.
+type LeadHandler struct {
+	follow *follow.Tracker
+}
+
+func (h *LeadHandler) Execute(ctx *HandlerContext, cmd *Command) (*CommandResult, error) {
+	char := ctx.Character
+	if char == nil {
+		return Reply("Error retrieving character information."), nil
+	}
+
+	if len(cmd.Args) == 0 {
+		if h.follow.Leading(char.ID) {
+			return Reply("You are taking followers. Use 'lead off' to stop."), nil
+		}
+		return Reply("You are not taking followers. Use 'lead on' to let others follow you."), nil
+	}
+	switch strings.ToLower(cmd.Args[0]) {
+	case "on":
+		h.follow.SetLeading(char.ID, true)
+		return Reply("Others may now follow you."), nil
+	case "off":
+		h.follow.SetLeading(char.ID, false)
+		return Reply("You no longer take new followers. Use 'lose' to send away those following you."), nil
+	}
+	return Reply("Usage: lead [on|off]"), nil
+}
+
+// NoFollowHandler stops the character following anyone.
+type NoFollowHandler struct {
+	repoManager interfaces.RepositoryManager
+	follow      *follow.Tracker
+}
+
+func (h *NoFollowHandler) Execute(ctx *HandlerContext, cmd *Command) (*CommandResult, error) {
+	if ctx.Character == nil {
+		return Reply("Error retrieving character information."), nil
+	}
+	return stopFollowing(h.repoManager, h.follow, ctx.Character), nil
+}
+
+// LoseHandler stops one follower, or every follower, following the
+// character.
+type LoseHandler struct {
+	repoManager interfaces.RepositoryManager
+	follow      *follow.Tracker
+}
+
+func (h *LoseHandler) Execute(ctx *HandlerContext, cmd *Command) (*CommandResult, error) {
+	char := ctx.Character
+	if char == nil {
+		return Reply("Error retrieving character information."), nil
+	}
+
+	followers := h.follow.Followers(char.ID)
+	if len(followers) == 0 {
+		return Reply("Nobody is following you."), nil
+	}
+
+	result := Reply()
+	for _, followerID := range followers {
+		name := characterName(h.repoManager, followerID)
+		if len(cmd.Args) > 0 && !strings.EqualFold(name, cmd.Args[0]) {
+			continue
+		}
+		h.follow.Lose(char.ID, followerID)
+		result.Add(fmt.Sprintf("%s is no longer following you.", name)).
+			ToCharacter(followerID, fmt.Sprintf("%s no longer wants you following.", char.Name))
+	}
+	if len(result.Messages) == 0 {
+		return Reply(fmt.Sprintf("%s is not following you.", cmd.Args[0])), nil
+	}
+	return result, nil
+}
+
+// stopFollowing ends char's following and tells both sides.
+func stopFollowing(repoManager interfaces.RepositoryManager, tracker *follow.Tracker, char *character.Character) *CommandResult {
+	leaderID, ok := tracker.Stop(char.ID)
+	if !ok {
+		return Reply("You are not following anyone.")
+	}
+	return Reply(fmt.Sprintf("You stop following %s.", characterName(repoManager, leaderID))).
+		ToCharacter(leaderID, fmt.Sprintf("%s stops following you.", char.Name))
+}
+
+// characterName returns the name of characterID, or "someone" if they
+// cannot be loaded.
+func characterName(repoManager interfaces.RepositoryManager, characterID string) string {
+	if char, err := repoManager.Characters().GetCharacter(characterID); err == nil {
+		return char.Name
+	}
+	return "someone"
+}
@@ -0,0 +1,135 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+)
+
+// roomMessenger reports a fixed set of characters in every room
+type roomMessenger struct {
+	NopMessenger
+	ids []string
+}
+
+func (m roomMessenger) CharactersInRoom(roomID string) []string { return m.ids }
+
+func newFollowExecutor(t *testing.T) (*Executor, *HandlerContext, *character.Character) {
+	repos := newMemoryRepos()
+	executor := NewExecutor(repos)
+	alice := testCharacter(character.DefaultStartRoomID)
+	bob := testCharacter(character.DefaultStartRoomID)
+	bob.ID, bob.Name = "char2", "Bob"
+	repos.characters.stored = map[string]*character.Character{alice.ID: alice, bob.ID: bob}
+
+	ctx := &HandlerContext{Character: alice, Messenger: roomMessenger{ids: []string{alice.ID, bob.ID}}}
+	return executor, ctx, bob
+}
+
+func TestFollowCommand(t *testing.T) {
+	executor, ctx, bob := newFollowExecutor(t)
+	parser := NewParser()
+	run := func(input string) *CommandResult {
+		result, err := executor.Execute(ctx, parser.Parse(input, "player1", ctx.Character.ID))
+		if err != nil {
+			t.Fatalf("Unexpected error for %q: %v", input, err)
+		}
+		return result
+	}
+
+	if got := run("follow carol").Messages[0]; got != "You don't see carol here." {
+		t.Errorf("Unexpected reply: %q", got)
+	}
+
+	// Bob has to agree to be followed
+	result := run("follow bob")
+	if result.Messages[0] != "Bob isn't taking followers." {
+		t.Errorf("Expected Bob's consent to be needed, got %v", result.Messages)
+	}
+	if len(result.Targeted) != 1 || result.Targeted[0].Text != "Alice would like to follow you. Use 'lead on' to let them." {
+		t.Errorf("Expected Bob to be asked, got %v", result.Targeted)
+	}
+	if _, ok := executor.Follows().Leader(ctx.Character.ID); ok {
+		t.Errorf("Expected Alice not to follow Bob yet")
+	}
+
+	executor.Follows().SetLeading(bob.ID, true)
+	result = run("follow bob")
+	if result.Messages[0] != "You start following Bob." {
+		t.Errorf("Unexpected reply: %v", result.Messages)
+	}
+	if len(result.Targeted) != 1 || result.Targeted[0].CharacterID != bob.ID {
+		t.Errorf("Expected Bob to be told, got %v", result.Targeted)
+	}
+	if leaderID, ok := executor.Follows().Leader(ctx.Character.ID); !ok || leaderID != bob.ID {
+		t.Errorf("Expected Alice to follow Bob")
+	}
+	if got := run("follow").Messages[0]; got != "You are following Bob." {
+		t.Errorf("Unexpected reply: %q", got)
+	}
+
+	if got := run("nofollow").Messages[0]; got != "You stop following Bob." {
+		t.Errorf("Unexpected reply: %q", got)
+	}
+	if got := run("nofollow").Messages[0]; got != "You are not following anyone." {
+		t.Errorf("Unexpected reply: %q", got)
+	}
+}
+
+func TestLoseFollower(t *testing.T) {
+	executor, ctx, bob := newFollowExecutor(t)
+	if err := executor.Follows().Follow(bob.ID, ctx.Character.ID); err != nil {
+		t.Fatalf("Follow failed: %v", err)
+	}
+
+	result, err := executor.Execute(ctx, NewParser().Parse("lose bob", "player1", ctx.Character.ID))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.Messages[0] != "Bob is no longer following you." {
+		t.Errorf("Unexpected reply: %v", result.Messages)
+	}
+	if _, ok := executor.Follows().Leader(bob.ID); ok {
+		t.Errorf("Expected Bob to stop following")
+	}
+}
+
+func TestFightingStopsFollowing(t *testing.T) {
+	executor, _ := newFightExecutor(t)
+	char := testCharacter("riverbank")
+	executor.Follows().Follow(char.ID, "char2")
+	ctx := &HandlerContext{Character: char}
+
+	result, err := executor.handlers["kill"].Execute(ctx, &Command{Verb: "kill", Args: []string{"goblin"}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.Messages[0] != "You stop following someone." {
+		t.Errorf("Expected attacking to stop following, got %v", result.Messages)
+	}
+	if _, ok := executor.Follows().Leader(char.ID); ok {
+		t.Errorf("Expected the attacker to stop following")
+	}
+}
+
+func TestLeadCommand(t *testing.T) {
+	executor, ctx, _ := newFollowExecutor(t)
+	parser := NewParser()
+	run := func(input string) string {
+		result, err := executor.Execute(ctx, parser.Parse(input, "player1", ctx.Character.ID))
+		if err != nil {
+			t.Fatalf("Unexpected error for %q: %v", input, err)
+		}
+		return result.Messages[0]
+	}
+
+	if got := run("lead"); got != "You are not taking followers. Use 'lead on' to let others follow you." {
+		t.Errorf("Expected followers to be off by default, got %q", got)
+	}
+	if got := run("lead on"); got != "Others may now follow you." || !executor.Follows().Leading(ctx.Character.ID) {
+		t.Errorf("Expected Alice to take followers, got %q", got)
+	}
+	if got := run("lead off"); got != "You no longer take new followers. Use 'lose' to send away those following you." || executor.Follows().Leading(ctx.Character.ID) {
+		t.Errorf("Expected Alice to stop taking followers, got %q", got)
+	}
+}
@@ -0,0 +1,102 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+	"github.com/elidor/dungeogo/pkg/game/npc"
+	"github.com/elidor/dungeogo/pkg/persistence/interfaces"
+	"github.com/elidor/dungeogo/pkg/textutil"
+)
+
+// GainHandler applies a level the character has earned when leveling is
+// manual. It needs a trainer in the room, and the character chooses a stat
+// to raise or one of the trainer's skills to improve along with the level.
+type GainHandler struct {
+	repoManager interfaces.RepositoryManager
+	npcs        *npc.Manager
+	experience  *experienceRules
+}
+
+func (h *GainHandler) Execute(ctx *HandlerContext, cmd *Command) (*CommandResult, error) {
+	char := ctx.Character
+	if char == nil {
+		return Reply("Error retrieving character information."), nil
+	}
+
+	if h.experience.mode != character.LevelingManual {
+		return Reply("You gain levels automatically as you earn experience."), nil
+	}
+
+	trainer := anyTrainer(h.npcs, ctx.RoomID())
+	if trainer == nil {
+		return Reply("You need to find a trainer to gain a level."), nil
+	}
+
+	if !char.CanLevelUp() {
+		if char.Level >= character.MaxLevel {
+			return Reply("You have already reached the maximum level."), nil
+		}
+		needed := char.ExperienceToNextLevel() - char.Experience
+		return Reply(fmt.Sprintf("You need %d more experience to gain a level.", needed)), nil
+	}
+
+	if len(cmd.Args) == 0 {
+		return Reply(gainChoices(trainer)...), nil
+	}
+
+	choice := cmd.Args[0]
+	skill, isSkill := character.SkillByName(choice)
+	if isSkill && !trainer.Template.CanTeach(skill) {
+		return Reply(fmt.Sprintf("%s cannot teach you %s.", textutil.Capitalize(trainer.Template.Name), character.GetSkillName(skill))), nil
+	}
+	stat, isStat := character.StatByName(choice)
+	if !isSkill && !isStat {
+		return Reply(fmt.Sprintf("You can't improve '%s'. Type 'gain' to see your choices.", choice)), nil
+	}
+	if isStat && char.Stats.Base(stat) >= char.Class.StatCap(stat, char.Level+1) {
+		return Reply(fmt.Sprintf("Your %s can't rise any higher yet. Choose something else to improve.", character.StatName(stat))), nil
+	}
+
+	char.LevelUp()
+	response := []string{fmt.Sprintf("%s trains you hard. You are now level %d!", textutil.Capitalize(trainer.Template.Name), char.Level)}
+	if isSkill {
+		skillName := character.GetSkillName(skill)
+		if char.Train(skill, trainer.Template.ID) {
+			response = append(response, fmt.Sprintf("Your %s skill improves to %d.", skillName, char.Skills.GetSkillLevel(skill)))
+		} else {
+			response = append(response, fmt.Sprintf("Your understanding of %s deepens.", skillName))
+		}
+	} else {
+		value, _ := char.RaiseStat(choice)
+		response = append(response, fmt.Sprintf("Your %s rises to %d.", character.StatName(stat), value))
+	}
+	response = append(response, fmt.Sprintf("Experience: %s", char.ExperienceProgress()))
+	response = append(response, h.experience.levelUp(char, char.Level)...)
+	return Reply(response...), nil
+}
+
+// anyTrainer returns the first trainer in roomID, or nil
+func anyTrainer(npcs *npc.Manager, roomID string) *npc.NPC {
+	for _, n := range npcs.InRoom(roomID) {
+		if n.Template.IsTrainer() {
+			return n
+		}
+	}
+	return nil
+}
+
+// gainChoices lists what a character can improve with trainer
+func gainChoices(trainer *npc.NPC) []string {
+	skills := make([]string, len(trainer.Template.Teaches))
+	for i, skill := range trainer.Template.Teaches {
+		skills[i] = character.GetSkillName(skill)
+	}
+	return []string{
+		"You are ready to gain a level. Choose what to improve:",
+		"  Stats: " + strings.Join(character.StatNames, ", "),
+		"  Skills: " + strings.Join(skills, ", "),
+		"Usage: gain <stat|skill>",
+	}
+}
@@ -0,0 +1,79 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+)
+
+func TestGainAtTrainer(t *testing.T) {
+	executor, repos := newFightExecutor(t)
+	executor.SetLevelingMode(character.LevelingManual)
+	char := testCharacter("riverbank")
+	ctx := &HandlerContext{Character: char}
+
+	gain := func(args ...string) []string {
+		result, err := executor.handlers["gain"].Execute(ctx, &Command{Verb: "gain", Args: args})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		return result.Messages
+	}
+
+	char.Experience = 150
+	if got := gain("strength"); strings.Join(got, "\n") != "You need to find a trainer to gain a level." {
+		t.Errorf("Expected to need a trainer, got %v", got)
+	}
+
+	char.Location.RoomID = "tutorial_yard"
+	if got := gain("magic"); strings.Join(got, "\n") != "The arms trainer cannot teach you Magic." {
+		t.Errorf("Expected the trainer to refuse magic, got %v", got)
+	}
+	if got := gain("luck"); strings.Join(got, "\n") != "You can't improve 'luck'. Type 'gain' to see your choices." {
+		t.Errorf("Expected an unknown choice to be refused, got %v", got)
+	}
+	if char.Level != 1 {
+		t.Fatalf("Expected refused choices to leave the level alone, got %d", char.Level)
+	}
+
+	strength := char.Stats.Strength
+	expected := []string{
+		"The arms trainer trains you hard. You are now level 2!",
+		fmt.Sprintf("Your strength rises to %d.", strength+1),
+		"Experience: 50/400 (12%)",
+	}
+	if got := gain("strength"); strings.Join(got, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+	if char.Level != 2 || char.Stats.Strength != strength+1 {
+		t.Errorf("Expected level 2 with strength %d, got level %d with %d", strength+1, char.Level, char.Stats.Strength)
+	}
+	if repos.characters.saves != 0 {
+		t.Errorf("Expected the engine to save the character, got %d handler saves", repos.characters.saves)
+	}
+
+	if got := gain("swords"); strings.Join(got, "\n") != "You need 350 more experience to gain a level." {
+		t.Errorf("Expected to need more experience, got %v", got)
+	}
+
+	char.Experience = 400
+	gain("parry")
+	if char.Level != 3 || char.Skills.GetSkillLevel(character.SkillParry) != 1 {
+		t.Errorf("Expected level 3 with Parry 1, got level %d with Parry %d", char.Level, char.Skills.GetSkillLevel(character.SkillParry))
+	}
+}
+
+func TestGainWithAutomaticLeveling(t *testing.T) {
+	executor, _ := newFightExecutor(t)
+	ctx := &HandlerContext{Character: testCharacter("tutorial_yard")}
+
+	result, err := executor.handlers["gain"].Execute(ctx, &Command{Verb: "gain"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if strings.Join(result.Messages, "\n") != "You gain levels automatically as you earn experience." {
+		t.Errorf("Unexpected reply %v", result.Messages)
+	}
+}
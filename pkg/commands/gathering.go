@@ -0,0 +1,151 @@
+package commands
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+	"github.com/elidor/dungeogo/pkg/game/fishing"
+	"github.com/elidor/dungeogo/pkg/game/items"
+	"github.com/elidor/dungeogo/pkg/game/world"
+	"github.com/elidor/dungeogo/pkg/persistence/interfaces"
+)
+
+// GatherHandler works the room's resource node for one gathering skill, such
+// as mining an ore vein.
+type GatherHandler struct {
+	repoManager interfaces.RepositoryManager
+	factory     *items.ItemFactory
+	skill       character.SkillType
+	action      string
+	experience  *experienceRules
+	// roll returns a number from 0 to n-1
+	roll func(n int) int
+	now  func() time.Time
+}
+
+func (h *GatherHandler) Execute(ctx *HandlerContext, cmd *Command) (*CommandResult, error) {
+	char := ctx.Character
+	if char == nil {
+		return Reply("Error retrieving character information."), nil
+	}
+
+	node := world.FindResourceNode(ctx.RoomID(), h.skill)
+	if node == nil || ctx.Room == nil {
+		return Reply(fmt.Sprintf("There is nothing to %s here.", h.action)), nil
+	}
+
+	skillName := character.GetSkillName(h.skill)
+	skill := char.Skills.GetEffectiveSkillLevel(h.skill)
+	if skill < node.MinSkill {
+		return Reply(fmt.Sprintf("You need at least %d in %s to work the %s.", node.MinSkill, skillName, node.Name)), nil
+	}
+
+	if ctx.Room.Flags == nil {
+		ctx.Room.Flags = make(map[string]interface{})
+	}
+	state, ok := node.Take(node.LoadState(ctx.Room.Flags), h.now())
+	if !ok {
+		return Reply(fmt.Sprintf("There is nothing left to %s here.", h.action)), nil
+	}
+
+	if h.roll(100) >= node.SuccessChance(skill) {
+		return Reply(fmt.Sprintf("You work the %s but come away with nothing.", node.Name)), nil
+	}
+
+	node.SaveState(ctx.Room.Flags, state)
+	if err := h.repoManager.World().SaveRoomState(ctx.Room.ID, ctx.Room); err != nil {
+		return Reply("Error gathering resources."), nil
+	}
+
+	item, err := giveItem(h.repoManager, h.factory, char, node.Yield)
+	if err != nil {
+		return Reply("Error gathering resources."), nil
+	}
+	response := []string{fmt.Sprintf("You %s %s from the %s.", h.action, itemName(h.factory, item), node.Name)}
+
+	if h.experience.train(char, h.skill, node.Experience) {
+		response = append(response, fmt.Sprintf("Your %s skill improves to %d.", skillName, char.Skills.GetSkillLevel(h.skill)))
+	}
+
+	return Reply(response...), nil
+}
+
+// FishHandler casts a line in water rooms. The catch is decided and stored
+// straight away but only revealed once the delay has passed.
+type FishHandler struct {
+	repoManager interfaces.RepositoryManager
+	factory     *items.ItemFactory
+	delay       time.Duration
+	experience  *experienceRules
+	// roll returns a number from 0 to n-1
+	roll func(n int) int
+
+	mutex   sync.Mutex
+	waiting map[string]bool
+}
+
+func (h *FishHandler) Execute(ctx *HandlerContext, cmd *Command) (*CommandResult, error) {
+	char := ctx.Character
+	if char == nil {
+		return Reply("Error retrieving character information."), nil
+	}
+
+	if !roomHas(ctx, world.FlagWater) {
+		return Reply("There is no water here to fish in."), nil
+	}
+
+	if !h.startWaiting(char.ID) {
+		return Reply("You are already waiting for a bite."), nil
+	}
+
+	var outcome []string
+	skill := char.Skills.GetEffectiveSkillLevel(character.SkillFishing)
+	if catch, ok := fishing.Cast(skill, h.roll); ok {
+		item, err := giveItem(h.repoManager, h.factory, char, catch.TemplateID)
+		if err != nil {
+			h.stopWaiting(char.ID)
+			return Reply("Error landing your catch."), nil
+		}
+		outcome = append(outcome, fmt.Sprintf("You feel a tug and reel in %s!", itemName(h.factory, item)))
+		if h.experience.train(char, character.SkillFishing, catch.Experience) {
+			outcome = append(outcome, fmt.Sprintf("Your Fishing skill improves to %d.", char.Skills.GetSkillLevel(character.SkillFishing)))
+		}
+	} else {
+		outcome = append(outcome, "Nothing bites. You reel in an empty line.")
+	}
+
+	result := Reply("You cast your line into the water.").
+		ToRoom("", fmt.Sprintf("%s casts a line into the water.", ctx.ActorName()), char.ID)
+	if h.delay <= 0 || ctx.Messenger == nil {
+		h.stopWaiting(char.ID)
+		return result.Add(outcome...), nil
+	}
+
+	messenger := ctx.Messenger
+	time.AfterFunc(h.delay, func() {
+		h.stopWaiting(char.ID)
+		for _, line := range outcome {
+			messenger.SendToCharacter(char.ID, line)
+		}
+	})
+	return result, nil
+}
+
+func (h *FishHandler) startWaiting(characterID string) bool {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if h.waiting[characterID] {
+		return false
+	}
+	h.waiting[characterID] = true
+	return true
+}
+
+func (h *FishHandler) stopWaiting(characterID string) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	delete(h.waiting, characterID)
+}
@@ -0,0 +1,82 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+	"github.com/elidor/dungeogo/pkg/persistence/interfaces"
+)
+
+func testCharacter(roomID string) *character.Character {
+	race, _ := character.GetRaceByID("human")
+	class, _ := character.GetClassByID("warrior")
+	char := character.NewCharacter("player1", "Alice", race, class)
+	char.ID = "char1"
+	char.Location.RoomID = roomID
+	return char
+}
+
+func TestFishOutsideWater(t *testing.T) {
+	handler := &FishHandler{waiting: make(map[string]bool)}
+	ctx := &HandlerContext{Character: testCharacter(character.DefaultStartRoomID), Messenger: NopMessenger{}}
+
+	result, err := handler.Execute(ctx, &Command{Verb: "fish", CharacterID: "char1"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(result.Messages) != 1 || result.Messages[0] != "There is no water here to fish in." {
+		t.Errorf("Expected no-water message, got %v", result.Messages)
+	}
+}
+
+func TestFishRoomFlagOverride(t *testing.T) {
+	handler := &FishHandler{waiting: make(map[string]bool)}
+	ctx := &HandlerContext{
+		Character: testCharacter("riverbank"),
+		Room:      &interfaces.RoomState{ID: "riverbank", Flags: map[string]interface{}{"water": false}},
+		Messenger: NopMessenger{},
+	}
+
+	result, err := handler.Execute(ctx, &Command{Verb: "fish", CharacterID: "char1"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.Messages[0] != "There is no water here to fish in." {
+		t.Errorf("Expected a dried-up room to refuse fishing, got %v", result.Messages)
+	}
+}
+
+func TestFishWhileWaiting(t *testing.T) {
+	handler := &FishHandler{waiting: map[string]bool{"char1": true}}
+	ctx := &HandlerContext{Character: testCharacter("riverbank"), Messenger: NopMessenger{}}
+
+	result, err := handler.Execute(ctx, &Command{Verb: "fish", CharacterID: "char1"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.Messages[0] != "You are already waiting for a bite." {
+		t.Errorf("Expected waiting message, got %v", result.Messages)
+	}
+}
+
+func TestFishEmptyLine(t *testing.T) {
+	handler := &FishHandler{
+		waiting: make(map[string]bool),
+		roll:    func(n int) int { return n - 1 },
+	}
+	ctx := &HandlerContext{Character: testCharacter("riverbank"), Messenger: NopMessenger{}}
+
+	result, err := handler.Execute(ctx, &Command{Verb: "fish", CharacterID: "char1"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(result.Messages) != 2 || result.Messages[1] != "Nothing bites. You reel in an empty line." {
+		t.Errorf("Expected an empty catch, got %v", result.Messages)
+	}
+	if len(result.Room) != 1 {
+		t.Errorf("Expected the room to see the cast, got %v", result.Room)
+	}
+	if handler.waiting["char1"] {
+		t.Errorf("Expected the character to stop waiting once the catch is in")
+	}
+}
@@ -0,0 +1,164 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+)
+
+// helpCategory is a help topic covering every command of one type
+type helpCategory struct {
+	name    string
+	cmdType CommandType
+}
+
+// helpCategories lists the categories in the order help shows them. Admin
+// commands are left out.
+var helpCategories = []helpCategory{
+	{"movement", CommandMovement},
+	{"communication", CommandCommunication},
+	{"information", CommandInformation},
+	{"inventory", CommandInventory},
+	{"combat", CommandCombat},
+	{"magic", CommandMagic},
+	{"skills", CommandSkill},
+	{"social", CommandSocial},
+	{"system", CommandSystem},
+}
+
+// HelpHandler documents commands from what the parser knows about them, so
+// every registered command has help.
+type HelpHandler struct {
+	parser *Parser
+}
+
+func (h *HelpHandler) Execute(ctx *HandlerContext, cmd *Command) (*CommandResult, error) {
+	if len(cmd.Args) == 0 {
+		return h.overview(), nil
+	}
+
+	topic := strings.ToLower(cmd.Args[0])
+	if topic == "search" {
+		if len(cmd.Args) < 2 {
+			return Reply("Usage: help search <term>"), nil
+		}
+		return h.search(strings.ToLower(cmd.Args[1])), nil
+	}
+	for _, category := range helpCategories {
+		if category.name == topic || strings.TrimSuffix(category.name, "s") == topic {
+			return h.category(category), nil
+		}
+	}
+	if info, exists := h.parser.GetCommandInfo(topic); exists {
+		return Reply(describeCommand(info)...), nil
+	}
+	return Reply(
+		fmt.Sprintf("No help available for topic: %s", topic),
+		fmt.Sprintf("Try 'help search %s'.", topic),
+	), nil
+}
+
+// overview lists the categories with a few of their commands each
+func (h *HelpHandler) overview() *CommandResult {
+	result := Reply("Available command categories:")
+	for _, category := range helpCategories {
+		verbs := h.parser.GetCommandsByType(category.cmdType)
+		if len(verbs) == 0 {
+			continue
+		}
+		if len(verbs) > 4 {
+			verbs = append(verbs[:4], "etc.")
+		}
+		result.Add(fmt.Sprintf("  %s - %s", category.name, strings.Join(verbs, ", ")))
+	}
+	return result.Add(
+		"",
+		"Type 'help <category>' for its commands, or 'help <command>' for one command.",
+		"Type 'help search <term>' to find commands by name or description.",
+		"Type 'commands' to list all available commands.",
+	)
+}
+
+// category lists every command of one category with its usage
+func (h *HelpHandler) category(category helpCategory) *CommandResult {
+	result := Reply(fmt.Sprintf("%s commands:", (&Command{Type: category.cmdType}).GetTypeName()))
+	for _, verb := range h.parser.GetCommandsByType(category.cmdType) {
+		info, _ := h.parser.GetCommandInfo(verb)
+		result.Add(fmt.Sprintf("  %s - %s", info.Usage, info.Description))
+	}
+	return result
+}
+
+// search lists the commands whose name, aliases or description mention term
+func (h *HelpHandler) search(term string) *CommandResult {
+	result := Reply(fmt.Sprintf("Commands matching '%s':", term))
+	for _, verb := range h.parser.Verbs() {
+		info, _ := h.parser.GetCommandInfo(verb)
+		if info.Type == CommandAdmin || !matchesHelpTerm(verb, info, term) {
+			continue
+		}
+		result.Add(fmt.Sprintf("  %s - %s", verb, info.Description))
+	}
+	if len(result.Messages) == 1 {
+		return Reply(fmt.Sprintf("No help topics match '%s'.", term))
+	}
+	return result
+}
+
+func matchesHelpTerm(verb string, info CommandInfo, term string) bool {
+	if strings.Contains(verb, term) || strings.Contains(strings.ToLower(info.Description), term) {
+		return true
+	}
+	for _, alias := range info.Aliases {
+		if alias == term {
+			return true
+		}
+	}
+	return false
+}
+
+// describeCommand is the help shown for a single command
+func describeCommand(info CommandInfo) []string {
+	lines := []string{
+		fmt.Sprintf("Usage: %s", info.Usage),
+		info.Description,
+	}
+	if len(info.Aliases) > 0 {
+		lines = append(lines, fmt.Sprintf("Aliases: %s", strings.Join(info.Aliases, ", ")))
+	}
+	return lines
+}
+
+// CommandsHandler lists every registered command by category, with its
+// aliases, marking any that have no handler yet.
+type CommandsHandler struct {
+	parser *Parser
+	// implemented reports whether a verb has a handler
+	implemented func(verb string) bool
+}
+
+func (h *CommandsHandler) Execute(ctx *HandlerContext, cmd *Command) (*CommandResult, error) {
+	result := Reply("Available commands:")
+	missing := false
+	for _, category := range helpCategories {
+		var entries []string
+		for _, verb := range h.parser.GetCommandsByType(category.cmdType) {
+			info, _ := h.parser.GetCommandInfo(verb)
+			entry := verb
+			if len(info.Aliases) > 0 {
+				entry += fmt.Sprintf(" (%s)", strings.Join(info.Aliases, ", "))
+			}
+			if !h.implemented(verb) {
+				entry += "*"
+				missing = true
+			}
+			entries = append(entries, entry)
+		}
+		if len(entries) > 0 {
+			result.Add(fmt.Sprintf("%s: %s", (&Command{Type: category.cmdType}).GetTypeName(), strings.Join(entries, ", ")))
+		}
+	}
+	if missing {
+		result.Add("* not implemented yet")
+	}
+	return result, nil
+}
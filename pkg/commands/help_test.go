@@ -0,0 +1,95 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+)
+
+func runHelp(t *testing.T, args ...string) []string {
+	handler := &HelpHandler{parser: NewParser()}
+	result, err := handler.Execute(&HandlerContext{}, &Command{Verb: "help", Args: args})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return result.Messages
+}
+
+func TestHelpOverview(t *testing.T) {
+	messages := runHelp(t)
+	if messages[0] != "Available command categories:" {
+		t.Errorf("Unexpected overview: %v", messages)
+	}
+	if !strings.Contains(strings.Join(messages, "\n"), "  combat - ") {
+		t.Errorf("Expected every category with commands to be listed, got %v", messages)
+	}
+}
+
+func TestHelpCategory(t *testing.T) {
+	// Every category lists each of its registered commands
+	parser := NewParser()
+	for _, category := range helpCategories {
+		messages := strings.Join(runHelp(t, category.name), "\n")
+		for _, verb := range parser.GetCommandsByType(category.cmdType) {
+			info, _ := parser.GetCommandInfo(verb)
+			if !strings.Contains(messages, info.Usage+" - "+info.Description) {
+				t.Errorf("Expected help %s to document %s, got %s", category.name, verb, messages)
+			}
+		}
+	}
+
+	if messages := runHelp(t, "skill"); messages[0] != "Skill commands:" {
+		t.Errorf("Expected the singular to name the category too, got %v", messages)
+	}
+}
+
+func TestHelpCommand(t *testing.T) {
+	messages := runHelp(t, "k")
+	expected := []string{"Usage: kill <target>", "Attack a target", "Aliases: k, attack"}
+	if strings.Join(messages, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Expected %v, got %v", expected, messages)
+	}
+
+	if messages := runHelp(t, "xyzzy"); messages[0] != "No help available for topic: xyzzy" {
+		t.Errorf("Unexpected reply: %v", messages)
+	}
+}
+
+func TestHelpSearch(t *testing.T) {
+	messages := runHelp(t, "search", "quest")
+	joined := strings.Join(messages, "\n")
+	for _, verb := range []string{"quest", "accept", "abandon", "complete"} {
+		if !strings.Contains(joined, "  "+verb+" - ") {
+			t.Errorf("Expected search to find %s, got %v", verb, messages)
+		}
+	}
+
+	if messages := runHelp(t, "search", "xyzzy"); messages[0] != "No help topics match 'xyzzy'." {
+		t.Errorf("Unexpected reply: %v", messages)
+	}
+}
+
+func TestCommandsListsRegisteredCommands(t *testing.T) {
+	executor := NewExecutor(newMemoryRepos())
+	result, err := executor.handlers["commands"].Execute(&HandlerContext{}, &Command{Verb: "commands"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	listing := strings.Join(result.Messages, "\n")
+
+	parser := NewParser()
+	for _, verb := range parser.Verbs() {
+		if info, _ := parser.GetCommandInfo(verb); info.Type != CommandAdmin && !strings.Contains(listing, verb) {
+			t.Errorf("Expected %s to be listed", verb)
+		}
+	}
+	if !strings.Contains(listing, "kill (k, attack)") {
+		t.Errorf("Expected aliases to be listed, got %s", listing)
+	}
+
+	delete(executor.handlers, "bow")
+	result, _ = executor.handlers["commands"].Execute(&HandlerContext{}, &Command{Verb: "commands"})
+	listing = strings.Join(result.Messages, "\n")
+	if !strings.Contains(listing, "bow*") || !strings.HasSuffix(listing, "* not implemented yet") {
+		t.Errorf("Expected bow to be marked as not implemented, got %s", listing)
+	}
+}
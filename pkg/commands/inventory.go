@@ -0,0 +1,93 @@
+package commands
+
+import (
+	"github.com/elidor/dungeogo/pkg/game/character"
+	"github.com/elidor/dungeogo/pkg/game/items"
+	"github.com/elidor/dungeogo/pkg/persistence/interfaces"
+)
+
+// giveItem adds one item of templateID to the character's inventory, adding
+// to a carried stack when there is room, and returns the stack it went into.
+func giveItem(repoManager interfaces.RepositoryManager, factory *items.ItemFactory, char *character.Character, templateID string) (*items.ItemInstance, error) {
+	template, err := factory.GetTemplate(templateID)
+	if err != nil {
+		return nil, err
+	}
+
+	if template.IsStackable() {
+		carried, err := repoManager.Items().GetCarriedItems(char.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, stack := range carried {
+			if stack.TemplateID != templateID || stack.Quantity >= template.StackSize || len(stack.Enchantments) > 0 {
+				continue
+			}
+			stack.Quantity++
+			if err := repoManager.Items().UpdateItemInstance(stack); err != nil {
+				return nil, err
+			}
+			return stack, nil
+		}
+	}
+
+	item, err := factory.CreateInstance(templateID, char.ID, 1)
+	if err != nil {
+		return nil, err
+	}
+	if err := repoManager.Items().CreateItemInstance(item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// removeItem takes quantity of a carried stack, deleting the stack when it
+// is used up.
+func removeItem(repoManager interfaces.RepositoryManager, item *items.ItemInstance, quantity int) error {
+	if quantity >= item.Quantity {
+		return repoManager.Items().DeleteItemInstance(item.ID)
+	}
+	item.Quantity -= quantity
+	if err := repoManager.Items().UpdateItemInstance(item); err != nil {
+		item.Quantity += quantity
+		return err
+	}
+	return nil
+}
+
+// findCarried returns a carried, unworn item of templateID, or nil.
+func findCarried(repoManager interfaces.RepositoryManager, char *character.Character, templateID string) (*items.ItemInstance, error) {
+	carried, err := repoManager.Items().GetCarriedItems(char.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range carried {
+		if item.TemplateID == templateID {
+			return item, nil
+		}
+	}
+	return nil, nil
+}
+
+// carriedWeight totals the weight of everything the character carries,
+// worn items included.
+func carriedWeight(repoManager interfaces.RepositoryManager, factory *items.ItemFactory, char *character.Character) (float64, error) {
+	carried, err := repoManager.Items().GetPlayerItems(char.ID)
+	if err != nil {
+		return 0, err
+	}
+	total := 0.0
+	for _, item := range carried {
+		total += itemWeight(factory, item)
+	}
+	return total, nil
+}
+
+// itemWeight is the weight of a whole stack.
+func itemWeight(factory *items.ItemFactory, item *items.ItemInstance) float64 {
+	template, err := factory.GetTemplate(item.TemplateID)
+	if err != nil {
+		return 0
+	}
+	return template.Weight * float64(item.Quantity)
+}
@@ -0,0 +1,49 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/elidor/dungeogo/pkg/game/items"
+)
+
+func TestInventoryLeavesOutWornItems(t *testing.T) {
+	executor, repos := newFightExecutor(t)
+	char := testCharacter("riverbank")
+	sword, _ := executor.itemFactory.CreateInstance("rusty_sword", char.ID, 1)
+	potion, _ := executor.itemFactory.CreateInstance("health_potion", char.ID, 2)
+	repos.items.CreateItemInstance(sword)
+	repos.items.CreateItemInstance(potion)
+
+	inventory := func() []string {
+		result, err := executor.handlers["inventory"].Execute(&HandlerContext{Character: char},
+			&Command{Verb: "inventory", CharacterID: char.ID})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		return result.Messages
+	}
+	if got := inventory(); len(got) != 3 {
+		t.Errorf("Expected both items listed before wearing the sword, got %v", got)
+	}
+
+	char.Equip(items.SlotMainHand, sword)
+	repos.characters.UpdateCharacter(char)
+	if got := inventory(); len(got) != 2 {
+		t.Errorf("Expected only the potions listed once the sword is worn, got %v", got)
+	}
+
+	// Worn gear still weighs on the character, but only once
+	weight, err := carriedWeight(repos, executor.itemFactory, char)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if weight != 4.0 {
+		t.Errorf("Expected a weight of 4, got %v", weight)
+	}
+
+	char.Unequip(items.SlotMainHand)
+	repos.characters.UpdateCharacter(char)
+	if got := inventory(); len(got) != 3 {
+		t.Errorf("Expected the removed sword back in the inventory, got %v", got)
+	}
+}
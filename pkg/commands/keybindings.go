@@ -0,0 +1,143 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/elidor/dungeogo/pkg/game/player"
+	"github.com/elidor/dungeogo/pkg/persistence/interfaces"
+)
+
+// keybindingCache holds the keybindings of each character in the game, so
+// expanding them does not load the player on every command.
+type keybindingCache struct {
+	mutex    sync.Mutex
+	bindings map[string]map[string]string
+}
+
+func newKeybindingCache() *keybindingCache {
+	return &keybindingCache{bindings: make(map[string]map[string]string)}
+}
+
+// store caches a copy of bindings for characterID
+func (c *keybindingCache) store(characterID string, bindings map[string]string) {
+	copied := make(map[string]string, len(bindings))
+	for key, command := range bindings {
+		copied[key] = command
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.bindings[characterID] = copied
+}
+
+func (c *keybindingCache) expand(characterID, input string) string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return player.ExpandKeybinding(c.bindings[characterID], input)
+}
+
+func (c *keybindingCache) forget(characterID string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	delete(c.bindings, characterID)
+}
+
+// BindHandler lists the player's keybindings or binds a key to a command
+type BindHandler struct {
+	repoManager interfaces.RepositoryManager
+	cache       *keybindingCache
+}
+
+func (h *BindHandler) Execute(ctx *HandlerContext, cmd *Command) (*CommandResult, error) {
+	char := ctx.Character
+	if char == nil {
+		return Reply("Error retrieving character information."), nil
+	}
+	p, err := h.repoManager.Players().GetPlayer(char.PlayerID)
+	if err != nil {
+		return Reply("Error retrieving your preferences."), nil
+	}
+	bindings := p.Preferences.Keybindings
+
+	switch len(cmd.Args) {
+	case 0:
+		if len(bindings) == 0 {
+			return Reply("You have no keybindings. Use 'bind <key> <command>' to add one."), nil
+		}
+		keys := make([]string, 0, len(bindings))
+		for key := range bindings {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		result := Reply("Your keybindings:")
+		for _, key := range keys {
+			result.Add(fmt.Sprintf("  %-*s %s", player.MaxKeybindingKeyLength, key, bindings[key]))
+		}
+		return result, nil
+	case 1:
+		key := strings.ToLower(cmd.Args[0])
+		if command, ok := bindings[key]; ok {
+			return Reply(fmt.Sprintf("%s is bound to: %s", key, command)), nil
+		}
+		return Reply(fmt.Sprintf("%s is not bound. Use 'bind %s <command>' to bind it.", key, key)), nil
+	}
+
+	key, command := strings.ToLower(cmd.Args[0]), strings.Join(cmd.Args[1:], " ")
+	if err := p.Preferences.Bind(key, command); err != nil {
+		switch {
+		case errors.Is(err, player.ErrKeybindingTooLong):
+			return Reply(fmt.Sprintf("Keys may be at most %d characters and commands at most %d.",
+				player.MaxKeybindingKeyLength, player.MaxKeybindingCommandLength)), nil
+		case errors.Is(err, player.ErrKeybindingReserved):
+			return Reply(fmt.Sprintf("You can't bind %s.", key)), nil
+		case errors.Is(err, player.ErrTooManyKeybindings):
+			return Reply(fmt.Sprintf("You may have at most %d keybindings.", player.MaxKeybindings)), nil
+		default:
+			return Reply("Keybindings may only contain letters, numbers, spaces and punctuation."), nil
+		}
+	}
+
+	if err := h.repoManager.Players().UpdatePlayer(p); err != nil {
+		return Reply("Error saving your keybindings."), nil
+	}
+	h.cache.store(char.ID, p.Preferences.Keybindings)
+	return Reply(fmt.Sprintf("%s is now bound to: %s", key, p.Preferences.Keybindings[key])), nil
+}
+
+// UnbindHandler removes one or all of the player's keybindings
+type UnbindHandler struct {
+	repoManager interfaces.RepositoryManager
+	cache       *keybindingCache
+}
+
+func (h *UnbindHandler) Execute(ctx *HandlerContext, cmd *Command) (*CommandResult, error) {
+	char := ctx.Character
+	if char == nil {
+		return Reply("Error retrieving character information."), nil
+	}
+	if len(cmd.Args) == 0 {
+		return Reply("Usage: unbind <key|all>"), nil
+	}
+	p, err := h.repoManager.Players().GetPlayer(char.PlayerID)
+	if err != nil {
+		return Reply("Error retrieving your preferences."), nil
+	}
+
+	key := strings.ToLower(cmd.Args[0])
+	reply := fmt.Sprintf("%s is no longer bound.", key)
+	if key == "all" {
+		p.Preferences.Keybindings = make(map[string]string)
+		reply = "All your keybindings have been cleared."
+	} else if !p.Preferences.Unbind(key) {
+		return Reply(fmt.Sprintf("%s is not bound.", key)), nil
+	}
+
+	if err := h.repoManager.Players().UpdatePlayer(p); err != nil {
+		return Reply("Error saving your keybindings."), nil
+	}
+	h.cache.store(char.ID, p.Preferences.Keybindings)
+	return Reply(reply), nil
+}
@@ -0,0 +1,69 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+)
+
+func TestBindAndUnbind(t *testing.T) {
+	repos := newMemoryRepos()
+	executor := NewExecutor(repos)
+	parser := NewParser()
+	char := testCharacter(character.DefaultStartRoomID)
+	ctx := &HandlerContext{Character: char, Messenger: NopMessenger{}}
+
+	run := func(input string) []string {
+		result, err := executor.Execute(ctx, parser.Parse(input, "player1", "char1"))
+		if err != nil {
+			t.Fatalf("Unexpected error for %q: %v", input, err)
+		}
+		return result.Messages
+	}
+
+	if err := executor.LoadKeybindings(char); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := executor.ExpandKeybinding(char.ID, "x"); got != "x" {
+		t.Errorf("Expected an unbound key to be left alone, got %q", got)
+	}
+
+	if got := run("bind x kill goblin"); got[0] != "x is now bound to: kill goblin" {
+		t.Errorf("Unexpected reply: %q", got[0])
+	}
+	if repos.players.player.Preferences.Keybindings["x"] != "kill goblin" {
+		t.Errorf("Expected the binding to be saved, got %v", repos.players.player.Preferences.Keybindings)
+	}
+	if got := executor.ExpandKeybinding(char.ID, "X"); got != "kill goblin" {
+		t.Errorf("Expected x to expand to 'kill goblin', got %q", got)
+	}
+	if got := executor.ExpandKeybinding(char.ID, "x rat"); got != "x rat" {
+		t.Errorf("Expected only a lone key to expand, got %q", got)
+	}
+	if got := run("bind"); !strings.Contains(strings.Join(got, "\n"), "kill goblin") {
+		t.Errorf("Expected the binding to be listed, got %v", got)
+	}
+	if got := run("bind unbind look"); got[0] != "You can't bind unbind." {
+		t.Errorf("Expected unbind to be reserved, got %q", got[0])
+	}
+
+	if got := run("unbind x"); got[0] != "x is no longer bound." {
+		t.Errorf("Unexpected reply: %q", got[0])
+	}
+	if got := executor.ExpandKeybinding(char.ID, "x"); got != "x" {
+		t.Errorf("Expected x to be unbound, got %q", got)
+	}
+	if got := run("unbind x"); got[0] != "x is not bound." {
+		t.Errorf("Unexpected reply: %q", got[0])
+	}
+	if got := run("unbind"); got[0] != "Usage: unbind <key|all>" {
+		t.Errorf("Expected the usage for a bare unbind, got %q", got[0])
+	}
+
+	run("bind x kill goblin")
+	executor.ForgetKeybindings(char.ID)
+	if got := executor.ExpandKeybinding(char.ID, "x"); got != "x" {
+		t.Errorf("Expected forgotten keybindings not to expand, got %q", got)
+	}
+}
@@ -0,0 +1,66 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/elidor/dungeogo/pkg/game/world"
+	"github.com/elidor/dungeogo/pkg/persistence/interfaces"
+)
+
+// LocationHandler tells a character exactly where they are: the IDs of
+// their room and zone and the room's coordinates. Administrators also see
+// the room's flags as it is now and where each exit leads, which helps
+// while building the world.
+type LocationHandler struct {
+	repoManager interfaces.RepositoryManager
+}
+
+func (h *LocationHandler) Execute(ctx *HandlerContext, cmd *Command) (*CommandResult, error) {
+	char := ctx.Character
+	if char == nil {
+		return Reply("Error retrieving character information."), nil
+	}
+
+	location := char.Location
+	result := Reply(
+		fmt.Sprintf("Room: %s [%s]", world.RoomName(location.RoomID), location.RoomID),
+		fmt.Sprintf("Zone: %s [%s]", world.ZoneName(location.ZoneID), location.ZoneID),
+		fmt.Sprintf("Coordinates: %d, %d", location.X, location.Y),
+	)
+
+	account, err := h.repoManager.Players().GetPlayer(char.PlayerID)
+	if err != nil || !account.IsAdmin() {
+		return result, nil
+	}
+	room, err := world.GetRoom(location.RoomID)
+	if err != nil {
+		return result.Add("This room is not part of the world."), nil
+	}
+
+	var flags []string
+	for _, flag := range world.FlagNames() {
+		if world.HasFlag(room.ID, roomFlags(ctx), flag) {
+			flags = append(flags, flag)
+		}
+	}
+	if len(flags) == 0 {
+		result.Add("Flags: none")
+	} else {
+		result.Add("Flags: " + strings.Join(flags, ", "))
+	}
+
+	if len(room.Exits) == 0 {
+		return result.Add("Exits: none"), nil
+	}
+	result.Add("Exits:")
+	for _, direction := range room.SortedExits() {
+		exit := room.Exits[direction]
+		line := fmt.Sprintf("  %-9s -> %s", direction, exit.To)
+		if exit.DoorID != "" {
+			line += fmt.Sprintf(" (door %s, %s)", exit.DoorID, world.DoorState(roomFlags(ctx), exit.DoorID))
+		}
+		result.Add(line)
+	}
+	return result, nil
+}
@@ -0,0 +1,54 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+	"github.com/elidor/dungeogo/pkg/game/player"
+	"github.com/elidor/dungeogo/pkg/game/world"
+	"github.com/elidor/dungeogo/pkg/persistence/interfaces"
+)
+
+func runLocation(t *testing.T, executor *Executor, ctx *HandlerContext) string {
+	result, err := executor.Execute(ctx, &Command{Verb: "location"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return strings.Join(result.Messages, "\n")
+}
+
+func TestLocationShowsIDs(t *testing.T) {
+	executor, _ := newFightExecutor(t)
+	char := testCharacter(character.DefaultStartRoomID)
+	ctx := &HandlerContext{Character: char, Room: &interfaces.RoomState{ID: char.Location.RoomID}}
+
+	expected := strings.Join([]string{
+		"Room: A Simple Room [" + character.DefaultStartRoomID + "]",
+		"Zone: Riverside Village [" + character.DefaultStartZoneID + "]",
+		"Coordinates: 0, 0",
+	}, "\n")
+	if got := runLocation(t, executor, ctx); got != expected {
+		t.Errorf("Expected players to see only their location:\n%s\ngot:\n%s", expected, got)
+	}
+}
+
+func TestLocationShowsAdminsRoomDetails(t *testing.T) {
+	executor, repos := newFightExecutor(t)
+	repos.players.player.Role = player.RoleAdmin
+	char := testCharacter(character.DefaultStartRoomID)
+	room := &interfaces.RoomState{ID: char.Location.RoomID, Flags: map[string]interface{}{world.FlagIndoor: false}}
+	ctx := &HandlerContext{Character: char, Room: room}
+
+	got := runLocation(t, executor, ctx)
+	for _, want := range []string{
+		"Coordinates: 0, 0",
+		"Flags: safe\n",
+		"  north     -> storeroom (door storeroom_door, ",
+		"  east      -> riverbank",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Expected %q in:\n%s", want, got)
+		}
+	}
+}
@@ -0,0 +1,219 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+	"github.com/elidor/dungeogo/pkg/game/items"
+	"github.com/elidor/dungeogo/pkg/game/lock"
+	"github.com/elidor/dungeogo/pkg/game/world"
+	"github.com/elidor/dungeogo/pkg/persistence/interfaces"
+)
+
+// lockpickTemplateID is the tool needed to pick a lock
+const lockpickTemplateID = "lockpick"
+
+// lockTarget is a door in the room or a carried container with a lock.
+type lockTarget struct {
+	name  string
+	lock  lock.Lock
+	state lock.State
+	// set saves a new lock state
+	set func(state lock.State) error
+}
+
+// findLockTarget resolves name to a door leading out of the character's
+// room, by direction or door name, or else to a lockable item they carry.
+// It returns nil if nothing matches.
+func findLockTarget(repoManager interfaces.RepositoryManager, factory *items.ItemFactory, ctx *HandlerContext, name string) (*lockTarget, error) {
+	if target := findDoorTarget(repoManager, ctx, name); target != nil {
+		return target, nil
+	}
+
+	carried, err := repoManager.Items().GetPlayerItems(ctx.Character.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range carried {
+		template, err := factory.GetTemplate(item.TemplateID)
+		if err != nil || template.Lock == nil || !matchesItemName(template, name) {
+			continue
+		}
+		return &lockTarget{
+			name:  template.Name,
+			lock:  *template.Lock,
+			state: item.LockState(),
+			set: func(state lock.State) error {
+				item.SetLockState(state)
+				return repoManager.Items().UpdateItemInstance(item)
+			},
+		}, nil
+	}
+	return nil, nil
+}
+
+func findDoorTarget(repoManager interfaces.RepositoryManager, ctx *HandlerContext, name string) *lockTarget {
+	room, err := world.GetRoom(ctx.RoomID())
+	if err != nil || ctx.Room == nil {
+		return nil
+	}
+	direction, isDirection := world.ParseDirection(name)
+
+	for _, dir := range room.SortedExits() {
+		exit := room.Exits[dir]
+		if exit.DoorID == "" {
+			continue
+		}
+		door, err := world.GetDoor(exit.DoorID)
+		if err != nil {
+			continue
+		}
+		if !(isDirection && direction == dir) && !strings.EqualFold(door.Name, name) && name != "door" {
+			continue
+		}
+
+		return &lockTarget{
+			name:  door.Name,
+			lock:  door.Lock,
+			state: world.DoorState(ctx.Room.Flags, door.ID),
+			set: func(state lock.State) error {
+				// Both sides of the door keep its state
+				other, err := repoManager.World().LoadRoomState(exit.To)
+				if err != nil {
+					return err
+				}
+				for _, side := range []*interfaces.RoomState{ctx.Room, other} {
+					if side.Flags == nil {
+						side.Flags = make(map[string]interface{})
+					}
+					world.SetDoorState(side.Flags, door.ID, state)
+				}
+				if err := repoManager.World().SaveRoomState(ctx.Room.ID, ctx.Room); err != nil {
+					return err
+				}
+				return repoManager.World().SaveRoomState(exit.To, other)
+			},
+		}
+	}
+	return nil
+}
+
+// PickHandler picks locks with a lockpick and the Lockpicking skill.
+type PickHandler struct {
+	repoManager interfaces.RepositoryManager
+	factory     *items.ItemFactory
+	experience  *experienceRules
+	// roll returns a number from 0 to n-1
+	roll func(n int) int
+}
+
+func (h *PickHandler) Execute(ctx *HandlerContext, cmd *Command) (*CommandResult, error) {
+	char := ctx.Character
+	if char == nil {
+		return Reply("Error retrieving character information."), nil
+	}
+
+	name := strings.ToLower(strings.Join(cmd.Args, " "))
+	target, err := findLockTarget(h.repoManager, h.factory, ctx, name)
+	if err != nil {
+		return Reply("Error retrieving inventory."), nil
+	}
+	if target == nil {
+		return Reply(fmt.Sprintf("You don't see a %s to pick here.", name)), nil
+	}
+
+	switch target.state {
+	case lock.Unlocked:
+		return Reply(fmt.Sprintf("The %s is not locked.", target.name)), nil
+	case lock.Jammed:
+		return Reply(fmt.Sprintf("The lock on the %s is jammed. Only its key will turn it now.", target.name)), nil
+	}
+
+	pick, err := findCarried(h.repoManager, char, lockpickTemplateID)
+	if err != nil {
+		return Reply("Error retrieving inventory."), nil
+	}
+	if pick == nil {
+		return Reply("You need a lockpick to do that."), nil
+	}
+
+	skill := char.Skills.GetEffectiveSkillLevel(character.SkillLockpicking)
+	if target.lock.PickChance(skill) == 0 {
+		return Reply(fmt.Sprintf("The lock on the %s is beyond your skill.", target.name)), nil
+	}
+
+	switch target.lock.Pick(skill, h.roll) {
+	case lock.PickBroke:
+		if err := removeItem(h.repoManager, pick, 1); err != nil {
+			return Reply("Error picking the lock."), nil
+		}
+		return Reply("Your lockpick snaps in the lock."), nil
+	case lock.LockJammed:
+		if err := target.set(lock.Jammed); err != nil {
+			return Reply("Error picking the lock."), nil
+		}
+		return Reply(fmt.Sprintf("Something gives inside the lock. The %s is jammed.", target.name)), nil
+	case lock.Failed:
+		return Reply(fmt.Sprintf("You fail to pick the lock on the %s.", target.name)), nil
+	}
+
+	if err := target.set(lock.Unlocked); err != nil {
+		return Reply("Error picking the lock."), nil
+	}
+	response := []string{fmt.Sprintf("You pick the lock on the %s.", target.name)}
+	if h.experience.train(char, character.SkillLockpicking, target.lock.Experience()) {
+		response = append(response, fmt.Sprintf("Your Lockpicking skill improves to %d.",
+			char.Skills.GetSkillLevel(character.SkillLockpicking)))
+	}
+	return Reply(response...).
+		ToRoom("", fmt.Sprintf("%s picks the lock on the %s.", ctx.ActorName(), target.name), char.ID), nil
+}
+
+// KeyHandler locks or unlocks a door or container with its key.
+type KeyHandler struct {
+	repoManager interfaces.RepositoryManager
+	factory     *items.ItemFactory
+	lock        bool
+}
+
+func (h *KeyHandler) Execute(ctx *HandlerContext, cmd *Command) (*CommandResult, error) {
+	char := ctx.Character
+	if char == nil {
+		return Reply("Error retrieving character information."), nil
+	}
+
+	name := strings.ToLower(strings.Join(cmd.Args, " "))
+	target, err := findLockTarget(h.repoManager, h.factory, ctx, name)
+	if err != nil {
+		return Reply("Error retrieving inventory."), nil
+	}
+	if target == nil {
+		return Reply(fmt.Sprintf("You don't see a %s here.", name)), nil
+	}
+
+	if h.lock && target.state != lock.Unlocked {
+		return Reply(fmt.Sprintf("The %s is already locked.", target.name)), nil
+	}
+	if !h.lock && target.state == lock.Unlocked {
+		return Reply(fmt.Sprintf("The %s is already unlocked.", target.name)), nil
+	}
+
+	key, err := findCarried(h.repoManager, char, target.lock.KeyID)
+	if err != nil {
+		return Reply("Error retrieving inventory."), nil
+	}
+	if key == nil {
+		return Reply(fmt.Sprintf("You don't have the key to the %s.", target.name)), nil
+	}
+
+	verb, state := "unlock", lock.Unlocked
+	if h.lock {
+		verb, state = "lock", lock.Locked
+	}
+	if err := target.set(state); err != nil {
+		return Reply(fmt.Sprintf("Error trying to %s the %s.", verb, target.name)), nil
+	}
+	return Reply(fmt.Sprintf("You %s the %s.", verb, target.name)).
+		ToRoom("", fmt.Sprintf("%s %ss the %s.", ctx.ActorName(), verb, target.name), char.ID), nil
+}
@@ -0,0 +1,59 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+	"github.com/elidor/dungeogo/pkg/game/world"
+	"github.com/elidor/dungeogo/pkg/persistence/interfaces"
+)
+
+func TestMoveWithoutExit(t *testing.T) {
+	handler := &MovementHandler{direction: "south"}
+	ctx := &HandlerContext{Character: testCharacter(character.DefaultStartRoomID), Messenger: NopMessenger{}}
+
+	result, err := handler.Execute(ctx, &Command{Verb: "south", CharacterID: "char1"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.Messages[0] != "You can't go that way." {
+		t.Errorf("Expected no exit message, got %v", result.Messages)
+	}
+}
+
+func TestMoveThroughLockedDoor(t *testing.T) {
+	handler := &MovementHandler{direction: "north"}
+	ctx := &HandlerContext{
+		Character: testCharacter(character.DefaultStartRoomID),
+		Room:      &interfaces.RoomState{ID: character.DefaultStartRoomID},
+		Messenger: NopMessenger{},
+	}
+
+	result, err := handler.Execute(ctx, &Command{Verb: "north", CharacterID: "char1"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.Messages[0] != "The storeroom door is locked." {
+		t.Errorf("Expected locked door message, got %v", result.Messages)
+	}
+	if ctx.Character.Location.RoomID != character.DefaultStartRoomID {
+		t.Errorf("Expected the character to stay put")
+	}
+}
+
+func TestDescribeRoomShowsExits(t *testing.T) {
+	room, _ := world.GetRoom(character.DefaultStartRoomID)
+	state := &interfaces.RoomState{ID: character.DefaultStartRoomID}
+
+	lines := describeRoom(room, state)
+	if last := lines[len(lines)-1]; last != "Exits: north (locked), east" {
+		t.Errorf("Expected exits line, got %q", last)
+	}
+
+	state.Flags = map[string]interface{}{"door:storeroom_door": "unlocked"}
+	lines = describeRoom(room, state)
+	if strings.Contains(strings.Join(lines, " "), "locked") {
+		t.Errorf("Expected an unlocked door not to be marked, got %v", lines)
+	}
+}
@@ -0,0 +1,44 @@
+package commands
+
+import (
+	"github.com/elidor/dungeogo/pkg/game/world"
+	"github.com/elidor/dungeogo/pkg/persistence/interfaces"
+	"github.com/elidor/dungeogo/pkg/textutil"
+)
+
+// MapHandler draws the rooms around the character that they have explored
+// or can see from where they stand.
+type MapHandler struct {
+	repoManager interfaces.RepositoryManager
+}
+
+func (h *MapHandler) Execute(ctx *HandlerContext, cmd *Command) (*CommandResult, error) {
+	char := ctx.Character
+	if char == nil {
+		return Reply("Error retrieving character information."), nil
+	}
+	room, err := world.GetRoom(ctx.RoomID())
+	if err != nil {
+		return Reply("You can't make out your surroundings."), nil
+	}
+
+	char.Explore(room.ID)
+
+	// The rooms next door can be seen even before they are visited
+	visible := make(map[string]bool)
+	for _, exit := range room.Exits {
+		visible[exit.To] = true
+	}
+	shown := func(r *world.Room) bool {
+		return visible[r.ID] || char.HasExplored(r.ID)
+	}
+
+	width := textutil.DefaultWidth
+	if p, err := h.repoManager.Players().GetPlayer(char.PlayerID); err == nil && p.Preferences.ScreenWidth > 0 {
+		width = p.Preferences.ScreenWidth
+	}
+
+	response := []string{room.Name}
+	response = append(response, world.RenderMap(room, shown, width)...)
+	return Reply(append(response, "[*] You are here")...), nil
+}
@@ -0,0 +1,53 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+)
+
+func TestMapShowsExploredAndVisibleRooms(t *testing.T) {
+	repos := newMemoryRepos()
+	handler := &MapHandler{repoManager: repos}
+	char := testCharacter("riverbank")
+	ctx := &HandlerContext{Character: char}
+
+	// From the riverbank only the room to the west can be seen
+	result, err := handler.Execute(ctx, &Command{Verb: "map"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := []string{"Riverbank", "        [ ]-[*]", "[*] You are here"}
+	if len(result.Messages) != len(expected) {
+		t.Fatalf("Expected %q, got %q", expected, result.Messages)
+	}
+	for i := range expected {
+		if result.Messages[i] != expected[i] {
+			t.Errorf("Line %d: expected %q, got %q", i, expected[i], result.Messages[i])
+		}
+	}
+	if !char.HasExplored("riverbank") {
+		t.Errorf("Expected the current room to be explored")
+	}
+
+	// Once the storeroom has been visited it shows up from anywhere nearby
+	char.Explore("storeroom")
+	result, _ = handler.Execute(ctx, &Command{Verb: "map"})
+	if len(result.Messages) != 5 || result.Messages[1] != "        [ ]" {
+		t.Errorf("Expected the storeroom on the map, got %q", result.Messages)
+	}
+}
+
+func TestMapNarrowScreen(t *testing.T) {
+	repos := newMemoryRepos()
+	repos.players.player.Preferences.ScreenWidth = 5
+	handler := &MapHandler{repoManager: repos}
+	ctx := &HandlerContext{Character: testCharacter(character.DefaultStartRoomID)}
+
+	result, _ := handler.Execute(ctx, &Command{Verb: "map"})
+	for _, line := range result.Messages[1 : len(result.Messages)-1] {
+		if len(line) > 5 {
+			t.Errorf("Expected map lines to fit 5 columns, got %q", line)
+		}
+	}
+}
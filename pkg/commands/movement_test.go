@@ -0,0 +1,75 @@
+package commands
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+)
+
+func TestMoveTellsBothRooms(t *testing.T) {
+	executor, _ := newFightExecutor(t)
+	ctx := &HandlerContext{Character: testCharacter("riverbank"), Messenger: NopMessenger{}}
+
+	result, err := executor.Execute(ctx, NewParser().Parse("up", "player1", "char1"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := []RoomMessage{
+		{RoomID: "riverbank", Text: "Alice leaves upwards.", Exclude: []string{"char1"}},
+		{RoomID: character.DwarfStartRoomID, Text: "Alice arrives from below.", Exclude: []string{"char1"}},
+	}
+	if !reflect.DeepEqual(result.Room, expected) {
+		t.Errorf("Expected %v, got %v", expected, result.Room)
+	}
+
+	executor.Execute(ctx, NewParser().Parse("down", "player1", "char1"))
+	result, _ = executor.Execute(ctx, NewParser().Parse("west", "player1", "char1"))
+	expected = []RoomMessage{
+		{RoomID: "riverbank", Text: "Alice leaves to the west.", Exclude: []string{"char1"}},
+		{RoomID: character.DefaultStartRoomID, Text: "Alice arrives from the east.", Exclude: []string{"char1"}},
+	}
+	if !reflect.DeepEqual(result.Room, expected) {
+		t.Errorf("Expected %v, got %v", expected, result.Room)
+	}
+}
+
+func TestHiddenMoveIsSilent(t *testing.T) {
+	executor, _ := newFightExecutor(t)
+	ctx := &HandlerContext{Character: testCharacter("riverbank"), Messenger: NopMessenger{}}
+	executor.stealth.Hide("char1")
+
+	result, err := executor.Execute(ctx, NewParser().Parse("west", "player1", "char1"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(result.Room) != 0 {
+		t.Errorf("Expected a hidden mover to go unseen, got %v", result.Room)
+	}
+}
+
+func TestSneakIsSeenOnlyWhenNoticed(t *testing.T) {
+	executor, _ := newFightExecutor(t)
+	sneak := executor.handlers["sneak"].(*SneakHandler)
+	ctx := &HandlerContext{Character: testCharacter("riverbank"), Messenger: NopMessenger{}}
+
+	sneak.roll = func(n int) int { return 0 }
+	result, err := executor.Execute(ctx, NewParser().Parse("sneak west", "player1", "char1"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(result.Room) != 0 {
+		t.Errorf("Expected an unseen sneak to be silent, got %v", result.Room)
+	}
+
+	executor.stealth.Reveal("char1")
+	sneak.roll = func(n int) int { return n - 1 }
+	result, err = executor.Execute(ctx, NewParser().Parse("sneak east", "player1", "char1"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := []RoomMessage{{RoomID: "riverbank", Text: "Alice arrives from the west.", Exclude: []string{"char1"}}}
+	if !reflect.DeepEqual(result.Room, expected) {
+		t.Errorf("Expected only the arrival to be seen, got %v", result.Room)
+	}
+}
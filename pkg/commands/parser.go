@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"sort"
 	"strings"
 )
 
@@ -106,9 +107,20 @@ func (p *Parser) GetCommandsByType(cmdType CommandType) []string {
 			commands = append(commands, verb)
 		}
 	}
+	sort.Strings(commands)
 	return commands
 }
 
+// Verbs returns every registered command, sorted.
+func (p *Parser) Verbs() []string {
+	verbs := make([]string, 0, len(p.commands))
+	for verb := range p.commands {
+		verbs = append(verbs, verb)
+	}
+	sort.Strings(verbs)
+	return verbs
+}
+
 func (p *Parser) initializeCommands() {
 	// Movement commands
 	p.addCommand("north", CommandMovement, "Move north", "north", 0, 0, []string{"n"})
@@ -121,42 +133,73 @@ func (p *Parser) initializeCommands() {
 	p.addCommand("northwest", CommandMovement, "Move northwest", "northwest", 0, 0, []string{"nw"})
 	p.addCommand("southeast", CommandMovement, "Move southeast", "southeast", 0, 0, []string{"se"})
 	p.addCommand("southwest", CommandMovement, "Move southwest", "southwest", 0, 0, []string{"sw"})
+	p.addCommand("sneak", CommandMovement, "Move quietly, trying to stay hidden", "sneak <direction>", 1, 1, []string{})
 	
 	// Communication commands
 	p.addCommand("say", CommandCommunication, "Say something to the room", "say <message>", 1, -1, []string{"'"})
 	p.addCommand("tell", CommandCommunication, "Send a private message", "tell <player> <message>", 2, -1, []string{"t"})
+	p.addCommand("reply", CommandCommunication, "Reply to whoever last sent you a tell", "reply <message>", 1, -1, []string{"r"})
 	p.addCommand("yell", CommandCommunication, "Yell across the area", "yell <message>", 1, -1, []string{})
 	p.addCommand("whisper", CommandCommunication, "Whisper to someone", "whisper <player> <message>", 2, -1, []string{})
 	p.addCommand("chat", CommandCommunication, "Chat on global channel", "chat <message>", 1, -1, []string{"."})
+	p.addCommand("report", CommandCommunication, "Report a player to the moderators", "report <player> <reason>", 2, -1, []string{})
+	p.addCommand("follow", CommandCommunication, "Follow someone as they move", "follow [player]", 0, 1, []string{"fol"})
+	p.addCommand("lead", CommandSystem, "Let others follow you, or stop taking followers", "lead [on|off]", 0, 1, []string{})
+	p.addCommand("nofollow", CommandSystem, "Stop following whoever you follow", "nofollow", 0, 0, []string{"unfollow"})
+	p.addCommand("lose", CommandSystem, "Stop someone following you, or everyone", "lose [player]", 0, 1, []string{})
 	
 	// Inventory commands
 	p.addCommand("inventory", CommandInventory, "Show your inventory", "inventory", 0, 0, []string{"i", "inv"})
 	p.addCommand("get", CommandInventory, "Pick up an item", "get <item>", 1, 1, []string{"take"})
 	p.addCommand("drop", CommandInventory, "Drop an item", "drop <item>", 1, 1, []string{})
 	p.addCommand("give", CommandInventory, "Give an item to someone", "give <item> <player>", 2, 2, []string{})
-	p.addCommand("wear", CommandInventory, "Wear/wield an item", "wear <item>", 1, 1, []string{"wield", "equip"})
+	p.addCommand("wear", CommandInventory, "Wear/wield an item, or wield a weapon in your off hand", "wear <item> [offhand]", 1, -1, []string{"wield", "equip"})
 	p.addCommand("remove", CommandInventory, "Remove worn item", "remove <item>", 1, 1, []string{"unwield"})
+	p.addCommand("appraise", CommandInventory, "Find out what one of your items is worth", "appraise <item>", 1, -1, []string{"value"})
+	p.addCommand("lock", CommandInventory, "Lock a door or container with its key", "lock <door|direction|item>", 1, -1, []string{})
+	p.addCommand("unlock", CommandInventory, "Unlock a door or container with its key", "unlock <door|direction|item>", 1, -1, []string{})
 	
 	// Combat commands
 	p.addCommand("kill", CommandCombat, "Attack a target", "kill <target>", 1, 1, []string{"k", "attack"})
 	p.addCommand("flee", CommandCombat, "Attempt to escape combat", "flee", 0, 0, []string{})
 	p.addCommand("defend", CommandCombat, "Focus on defense", "defend", 0, 0, []string{})
+	p.addCommand("use", CommandCombat, "Use one of your class abilities", "use <ability> [target]", 1, -1, []string{"ability"})
+	p.addCommand("pvp", CommandCombat, "Choose whether to fight other players", "pvp [on|off]", 0, 1, []string{})
 	
 	// Magic commands
-	p.addCommand("cast", CommandMagic, "Cast a spell", "cast <spell> [target]", 1, 2, []string{"c"})
-	p.addCommand("prepare", CommandMagic, "Prepare a spell", "prepare <spell>", 1, 1, []string{"prep"})
+	p.addCommand("cast", CommandMagic, "Cast a prepared spell", "cast <spell> [target]", 1, -1, []string{"c"})
+	p.addCommand("prepare", CommandMagic, "Prepare a spell from your spellbook", "prepare <spell|clear>", 1, -1, []string{"prep"})
+	p.addCommand("recall", CommandMagic, "Pray to be taken back to where you started", "recall", 0, 0, []string{"home"})
 	
 	// Information commands
 	p.addCommand("look", CommandInformation, "Look at surroundings", "look [target]", 0, 1, []string{"l"})
 	p.addCommand("examine", CommandInformation, "Examine something closely", "examine <target>", 1, 1, []string{"ex", "exa"})
-	p.addCommand("who", CommandInformation, "List online players", "who", 0, 0, []string{})
+	p.addCommand("who", CommandInformation, "List online players", "who [time]", 0, 1, []string{})
+	p.addCommand("finger", CommandInformation, "Show a character's public profile, online or not", "finger <character>", 1, 1, []string{})
+	p.addCommand("whois", CommandInformation, "Show what is known about a character, online or not", "whois <character>", 1, 1, []string{})
+	p.addCommand("where", CommandInformation, "List online players by area", "where", 0, 0, []string{})
+	p.addCommand("location", CommandInformation, "Show the IDs of your room and zone, and your coordinates", "location", 0, 0, []string{"whereami", "loc"})
+	p.addCommand("combatlog", CommandInformation, "Read back the latest lines of your fights", "combatlog [count]", 0, 2, []string{"clog"})
 	p.addCommand("score", CommandInformation, "Show character stats", "score", 0, 0, []string{"sc"})
 	p.addCommand("time", CommandInformation, "Show game time", "time", 0, 0, []string{})
 	p.addCommand("weather", CommandInformation, "Show weather", "weather", 0, 0, []string{})
+	p.addCommand("map", CommandInformation, "Show a map of the rooms around you", "map", 0, 0, []string{"minimap"})
+	p.addCommand("reputation", CommandInformation, "Show how each faction regards you", "reputation", 0, 0, []string{"rep", "factions"})
+	p.addCommand("achievements", CommandInformation, "List achievements earned and still to earn", "achievements", 0, 0, []string{"ach"})
+	p.addCommand("abilities", CommandInformation, "List your class abilities", "abilities", 0, 0, []string{"abil"})
+	p.addCommand("spells", CommandInformation, "List the spells in your spellbook", "spells", 0, 0, []string{"spellbook"})
+	p.addCommand("leaderboard", CommandInformation, "Show the top characters", "leaderboard [level|kills|playtime] [count]", 0, 2, []string{"rank", "top"})
 	
 	// Skill commands
 	p.addCommand("skills", CommandSkill, "Show skill levels", "skills", 0, 0, []string{"sk"})
 	p.addCommand("practice", CommandSkill, "Practice a skill", "practice <skill>", 1, 1, []string{"prac"})
+	p.addCommand("train", CommandSkill, "Spend attribute points on your stats at a trainer", "train [stat]", 0, 1, nil)
+	p.addCommand("gain", CommandSkill, "Gain a level at a trainer", "gain [stat|skill]", 0, 1, []string{"level"})
+	p.addCommand("craft", CommandSkill, "Craft an item from materials", "craft [recipe]", 0, -1, []string{"recipes"})
+	p.addCommand("mine", CommandSkill, "Mine ore from a vein in the room", "mine", 0, 0, []string{})
+	p.addCommand("fish", CommandSkill, "Cast a line in water rooms", "fish", 0, 0, []string{})
+	p.addCommand("hide", CommandSkill, "Try to hide from others in the room", "hide", 0, 0, []string{})
+	p.addCommand("pick", CommandSkill, "Pick a lock with a lockpick", "pick <door|direction|item>", 1, -1, []string{"picklock"})
 	
 	// Social commands
 	p.addCommand("emote", CommandSocial, "Perform an emote", "emote <action>", 1, -1, []string{"em", ":"})
@@ -165,10 +208,33 @@ func (p *Parser) initializeCommands() {
 	p.addCommand("bow", CommandSocial, "Bow to someone", "bow [target]", 0, 1, []string{})
 	
 	// System commands
-	p.addCommand("quit", CommandSystem, "Quit the game", "quit", 0, 0, []string{"q"})
+	p.addCommand("afk", CommandSystem, "Mark yourself away from the keyboard, with a reply for tells", "afk [message]", 0, -1, []string{"away"})
+	p.addCommand("quit", CommandSystem, "Save and quit the game", "quit [confirm]", 0, 1, []string{"q"})
 	p.addCommand("save", CommandSystem, "Save character", "save", 0, 0, []string{})
-	p.addCommand("help", CommandSystem, "Show help", "help [topic]", 0, 1, []string{"h"})
+	p.addCommand("help", CommandSystem, "Show help on a command or category, or search it", "help [command|category|search <term>]", 0, 2, []string{"h"})
 	p.addCommand("commands", CommandSystem, "List available commands", "commands", 0, 0, []string{"cmd"})
+	p.addCommand("skip", CommandSystem, "Skip the new player tutorial", "skip", 0, 0, []string{})
+	p.addCommand("autoloot", CommandSystem, "Take loot from your kills automatically", "autoloot [on|off]", 0, 1, []string{})
+	p.addCommand("profile", CommandSystem, "Show or write the public profile finger shows", "profile [text|none]", 0, -1, []string{"bio"})
+	p.addCommand("title", CommandSystem, "Show or choose the title after your name", "title [text|none]", 0, -1, []string{})
+	p.addCommand("appearance", CommandSystem, "Show or change how you look to others", "appearance [field] [text|none]", 0, -1, []string{})
+	p.addCommand("description", CommandSystem, "Show or write the description others see", "description [text|edit|none]", 0, -1, []string{"desc"})
+	p.addCommand("bind", CommandSystem, "List your keybindings or bind a key to a command", "bind [key] [command]", 0, -1, []string{})
+	p.addCommand("unbind", CommandSystem, "Remove a keybinding, or all of them", "unbind <key|all>", 1, 1, []string{})
+	p.addCommand("prompts", CommandSystem, "Show your health and your foe's each round of a fight", "prompts [on|off]", 0, 1, []string{})
+	p.addCommand("confirmquit", CommandSystem, "Ask before quitting the game", "confirmquit [on|off]", 0, 1, []string{})
+	
+	// Quest commands
+	p.addCommand("quest", CommandInformation, "Show your quests and those offered here", "quest", 0, 0, []string{"quests", "journal"})
+	p.addCommand("accept", CommandSystem, "Accept a quest from a quest giver", "accept <quest>", 1, -1, []string{})
+	p.addCommand("abandon", CommandSystem, "Abandon an active quest", "abandon <quest>", 1, -1, []string{})
+	p.addCommand("complete", CommandSystem, "Turn in a finished quest", "complete <quest>", 1, -1, []string{"turnin"})
+	
+	// Admin commands
+	p.addCommand("peace", CommandAdmin, "Stop every fight in your room or the one named", "peace [room]", 0, 1, []string{"stopcombat"})
+	p.addCommand("reports", CommandAdmin, "List open player reports or resolve one", "reports [resolve <id> [note]]", 0, -1, []string{})
+	p.addCommand("roomflag", CommandAdmin, "Show or change the flags of the room you are in", "roomflag [<flag> on|off|reset]", 0, 2, []string{"rflag"})
+	p.addCommand("reload", CommandAdmin, "Reload content such as the message of the day from disk", "reload <what>", 0, 1, []string{})
 }
 
 func (p *Parser) addCommand(verb string, cmdType CommandType, description, usage string, minArgs, maxArgs int, aliases []string) {
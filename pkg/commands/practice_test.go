@@ -0,0 +1,59 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+)
+
+func TestPracticeNeedsTrainer(t *testing.T) {
+	executor, repos := newFightExecutor(t)
+	char := testCharacter("riverbank")
+	ctx := &HandlerContext{Character: char}
+
+	practice := func(skill string) []string {
+		result, err := executor.handlers["practice"].Execute(ctx, &Command{Verb: "practice", Args: []string{skill}})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		return result.Messages
+	}
+
+	if got := practice("swords"); len(got) != 1 || got[0] != "There is no one here who can teach you Swords." {
+		t.Errorf("Expected no trainer at the riverbank, got %v", got)
+	}
+	if got := practice("juggling"); len(got) != 1 || got[0] != "There is no skill called 'juggling'." {
+		t.Errorf("Expected an unknown skill, got %v", got)
+	}
+
+	char.Location.RoomID = "tutorial_yard"
+	if got := practice("magic"); len(got) != 1 || got[0] != "There is no one here who can teach you Magic." {
+		t.Errorf("Expected the arms trainer not to teach magic, got %v", got)
+	}
+
+	got := practice("swords")
+	expected := []string{
+		"You practice Swords with the arms trainer.",
+		"Your Swords skill improves to 1.",
+		"You have 4 practice sessions left.",
+	}
+	if len(got) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, got)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Errorf("Line %d: expected %q, got %q", i, expected[i], got[i])
+		}
+	}
+	if trainers := char.Skills.GetSkill(character.SkillSwords).Trainers; len(trainers) != 1 || trainers[0] != "arms_trainer" {
+		t.Errorf("Expected the trainer to be recorded, got %v", trainers)
+	}
+	if repos.characters.saves != 0 {
+		t.Errorf("Expected the engine to save the character, got %d handler saves", repos.characters.saves)
+	}
+
+	char.Practices = 0
+	if got := practice("swords"); len(got) != 1 || got[0] != "You have no practice sessions left. Gain a level to earn more." {
+		t.Errorf("Expected to run out of practices, got %v", got)
+	}
+}
@@ -0,0 +1,55 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/elidor/dungeogo/pkg/game/player"
+	"github.com/elidor/dungeogo/pkg/persistence/interfaces"
+)
+
+// PreferenceHandler shows or switches one of the player's on/off
+// preferences, such as auto-loot.
+type PreferenceHandler struct {
+	repoManager interfaces.RepositoryManager
+	// name is how the preference reads at the start of a sentence
+	name string
+	// setting points at the preference within the player's preferences
+	setting func(prefs *player.PlayerPrefs) *bool
+}
+
+func (h *PreferenceHandler) Execute(ctx *HandlerContext, cmd *Command) (*CommandResult, error) {
+	char := ctx.Character
+	if char == nil {
+		return Reply("Error retrieving character information."), nil
+	}
+	p, err := h.repoManager.Players().GetPlayer(char.PlayerID)
+	if err != nil {
+		return Reply("Error retrieving your preferences."), nil
+	}
+	value := h.setting(&p.Preferences)
+
+	if len(cmd.Args) == 0 {
+		return Reply(fmt.Sprintf("%s is %s.", h.name, onOff(*value))), nil
+	}
+	switch strings.ToLower(cmd.Args[0]) {
+	case "on":
+		*value = true
+	case "off":
+		*value = false
+	default:
+		return Reply(fmt.Sprintf("Use '%s on' or '%s off'.", cmd.Verb, cmd.Verb)), nil
+	}
+
+	if err := h.repoManager.Players().UpdatePlayer(p); err != nil {
+		return Reply("Error saving your preferences."), nil
+	}
+	return Reply(fmt.Sprintf("%s is now %s.", h.name, onOff(*value))), nil
+}
+
+func onOff(value bool) string {
+	if value {
+		return "on"
+	}
+	return "off"
+}
@@ -0,0 +1,36 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+)
+
+func TestAutoLootPreference(t *testing.T) {
+	executor, repos := newFightExecutor(t)
+	ctx := &HandlerContext{Character: testCharacter(character.DefaultStartRoomID)}
+	parser := NewParser()
+
+	tests := []struct {
+		input    string
+		expected string
+		autoLoot bool
+	}{
+		{"autoloot", "Auto-loot is off.", false},
+		{"autoloot on", "Auto-loot is now on.", true},
+		{"autoloot maybe", "Use 'autoloot on' or 'autoloot off'.", true},
+		{"autoloot off", "Auto-loot is now off.", false},
+	}
+	for _, test := range tests {
+		result, err := executor.Execute(ctx, parser.Parse(test.input, "player1", "char1"))
+		if err != nil {
+			t.Fatalf("Unexpected error for %q: %v", test.input, err)
+		}
+		if result.Messages[0] != test.expected {
+			t.Errorf("%q: expected %q, got %v", test.input, test.expected, result.Messages)
+		}
+		if repos.players.player.Preferences.AutoLoot != test.autoLoot {
+			t.Errorf("%q: expected auto-loot %v", test.input, test.autoLoot)
+		}
+	}
+}
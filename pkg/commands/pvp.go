@@ -0,0 +1,192 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+	"github.com/elidor/dungeogo/pkg/game/combat"
+	"github.com/elidor/dungeogo/pkg/game/damage"
+	"github.com/elidor/dungeogo/pkg/game/event"
+	"github.com/elidor/dungeogo/pkg/game/world"
+	"github.com/elidor/dungeogo/pkg/persistence/interfaces"
+)
+
+// pvpRules holds the server's PvP mode and newbie protection. One is shared
+// by every handler that lets players fight each other.
+type pvpRules struct {
+	mode       combat.PvPMode
+	protection combat.NewbieProtection
+}
+
+// PvPHandler shows or changes whether the character fights other players,
+// on servers where that is opt-in.
+type PvPHandler struct {
+	repoManager interfaces.RepositoryManager
+	rules       *pvpRules
+}
+
+func (h *PvPHandler) Execute(ctx *HandlerContext, cmd *Command) (*CommandResult, error) {
+	char := ctx.Character
+	if char == nil {
+		return Reply("Error retrieving character information."), nil
+	}
+
+	switch {
+	case h.rules.mode == combat.PvPOff:
+		return Reply("Player combat is disabled on this server."), nil
+	case h.rules.protection.Protects(char):
+		return Reply(newbieNotice(h.rules.protection)), nil
+	case h.rules.mode == combat.PvPOpen:
+		return Reply("Player combat is open on this server: anyone may attack you outside safe rooms."), nil
+	}
+
+	if len(cmd.Args) == 0 {
+		if char.PvP {
+			return Reply("You are fighting other players. Type 'pvp off' to stop."), nil
+		}
+		return Reply("You are not fighting other players. Type 'pvp on' to start."), nil
+	}
+
+	switch strings.ToLower(cmd.Args[0]) {
+	case "on":
+		if char.PvP {
+			return Reply("You are already fighting other players."), nil
+		}
+		char.PvP = true
+	case "off":
+		if !char.PvP {
+			return Reply("You are not fighting other players."), nil
+		}
+		if char.State == character.CharacterInCombat {
+			return Reply("You can't back out in the middle of a fight."), nil
+		}
+		char.PvP = false
+	default:
+		return Reply("Usage: pvp [on|off]"), nil
+	}
+
+	if char.PvP {
+		return Reply("You will now fight other players who have PvP on, outside safe rooms."), nil
+	}
+	return Reply("You will no longer fight other players."), nil
+}
+
+// newbieNotice tells a protected character what their protection means
+// and when it ends
+func newbieNotice(protection combat.NewbieProtection) string {
+	return fmt.Sprintf("You are under newbie protection until level %d: other players can't fight you, and creatures far above your level leave you alone.",
+		protection.Level)
+}
+
+// announceProtection reminds a protected character entering the world of
+// their protection
+func (r *pvpRules) announceProtection(e event.Event) []string {
+	if r.protection.Protects(e.Subject()) {
+		return []string{newbieNotice(r.protection)}
+	}
+	return nil
+}
+
+// endProtection tells a character when their protection wears off
+func (r *pvpRules) endProtection(e event.Event) []string {
+	if r.protection.Level > 1 && e.(event.LevelUp).Level == r.protection.Level {
+		return []string{"You have outgrown newbie protection. Other players may now fight you, and every creature will see you as fair game."}
+	}
+	return nil
+}
+
+// foeLockWait is how long an attack waits for the other player to finish
+// what they are doing. The attacker's own lock is held meanwhile, so this
+// must not wait forever in case the other player is attacking back.
+const foeLockWait = 500 * time.Millisecond
+
+// attackPlayer strikes another character in the room if the PvP rules
+// allow it. A player brought to no health is defeated rather than killed:
+// they are left with 1 health and lose a share of their gold to the victor.
+func (h *KillHandler) attackPlayer(ctx *HandlerContext, foe *character.Character) (*CommandResult, error) {
+	char := ctx.Character
+	unlock, ok := h.locks.TryLock(foe.ID, foeLockWait)
+	if !ok {
+		return Reply(fmt.Sprintf("You can't get at %s just now.", foe.Name)), nil
+	}
+	defer unlock()
+	// Load them again now nothing else can change them
+	foe, err := h.repoManager.Characters().GetCharacter(foe.ID)
+	if err != nil {
+		return Reply("Error attacking."), nil
+	}
+
+	safe := roomHas(ctx, world.FlagSafe)
+	if err := combat.CanAttackPlayer(h.pvp.mode, h.pvp.protection, char, foe, safe); err != nil {
+		return Reply(pvpRefusal(err, foe)), nil
+	}
+
+	strike := h.strike(char)
+	response := h.stopFollowing(char)
+	dealt := combat.Resist(foe, combat.Damage(strike, h.roll), damage.Physical, h.factory)
+	if h.stances.Defending(foe.ID) {
+		dealt = combat.DefendedDamage(foe, dealt)
+	}
+	foe.Stats.Health = max(foe.Stats.Health-dealt, 0)
+	response = append(response, fmt.Sprintf("You hit %s for %d damage.", foe.Name, dealt))
+	result := Reply(response...).
+		ToCharacter(foe.ID, fmt.Sprintf("%s hits you for %d damage!", ctx.ActorName(), dealt))
+
+	if foe.Stats.Health > 0 {
+		char.State = character.CharacterInCombat
+		foe.State = character.CharacterInCombat
+		h.fights.duels.Start(char.ID, foe.ID)
+		// The engine saves the attacker, but not whoever they hit
+		if err := h.repoManager.Characters().UpdateCharacter(foe); err != nil {
+			return Reply("Error attacking."), nil
+		}
+		result.ToRoom("", fmt.Sprintf("%s attacks %s.", ctx.ActorName(), foe.Name), char.ID, foe.ID)
+		if prefs := h.preferences(char); prefs != nil && prefs.CombatPrompts {
+			result.WithPrompt(combat.StatusLine(char, foe.Stats.Health, foe.Stats.MaxHealth, strike.Defensive))
+		}
+		return result, nil
+	}
+
+	spoils := combat.PvPSpoils(foe.Gold)
+	foe.Gold -= spoils
+	char.Gold += spoils
+	foe.Stats.Health = 1
+	defending := h.stances.Defending(char.ID)
+	h.fights.endDuel(char, foe)
+	if defending && !h.stances.Defending(char.ID) {
+		result.Add("You lower your guard.")
+	}
+	if err := h.repoManager.Characters().UpdateCharacter(foe); err != nil {
+		return Reply("Error saving character."), nil
+	}
+
+	result.Add(fmt.Sprintf("%s collapses, defeated!", foe.Name))
+	result.ToCharacter(foe.ID, fmt.Sprintf("You have been defeated by %s!", ctx.ActorName()))
+	if spoils > 0 {
+		result.Add(fmt.Sprintf("You take %d gold from %s.", spoils, foe.Name))
+		result.ToCharacter(foe.ID, fmt.Sprintf("%s takes %d gold from you.", ctx.ActorName(), spoils))
+	}
+	result.ToRoom("", fmt.Sprintf("%s defeats %s!", ctx.ActorName(), foe.Name), char.ID, foe.ID)
+	return result, nil
+}
+
+// pvpRefusal explains why the actor may not attack foe
+func pvpRefusal(err error, foe *character.Character) string {
+	switch {
+	case errors.Is(err, combat.ErrPvPSafeRoom):
+		return "This is a safe place; you can't fight other players here."
+	case errors.Is(err, combat.ErrPvPNotFlagged):
+		return "You must turn PvP on before attacking other players. Type 'pvp on'."
+	case errors.Is(err, combat.ErrPvPTargetRefused):
+		return fmt.Sprintf("%s has not chosen to fight other players.", foe.Name)
+	case errors.Is(err, combat.ErrPvPNewbie):
+		return "You are under newbie protection and can't fight other players yet."
+	case errors.Is(err, combat.ErrPvPTargetNewbie):
+		return fmt.Sprintf("%s is under newbie protection.", foe.Name)
+	default:
+		return "Player combat is disabled on this server."
+	}
+}
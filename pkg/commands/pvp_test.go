@@ -0,0 +1,203 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+	"github.com/elidor/dungeogo/pkg/game/combat"
+)
+
+func newPvPExecutor(t *testing.T, mode combat.PvPMode) (*Executor, *HandlerContext, *character.Character) {
+	executor, repos := newFightExecutor(t)
+	executor.SetPvPMode(mode)
+	alice := testCharacter("riverbank")
+	bob := testCharacter("riverbank")
+	bob.ID, bob.Name = "char2", "Bob"
+	repos.characters.stored = map[string]*character.Character{alice.ID: alice, bob.ID: bob}
+
+	ctx := &HandlerContext{Character: alice, Messenger: roomMessenger{ids: []string{alice.ID, bob.ID}}}
+	return executor, ctx, bob
+}
+
+func attack(t *testing.T, executor *Executor, ctx *HandlerContext, name string) *CommandResult {
+	result, err := executor.handlers["kill"].Execute(ctx, &Command{Verb: "kill", Args: []string{name}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return result
+}
+
+func TestKillPlayerRefused(t *testing.T) {
+	executor, ctx, bob := newPvPExecutor(t, combat.PvPOff)
+	health := bob.Stats.Health
+
+	if got := attack(t, executor, ctx, "bob").Messages; strings.Join(got, "\n") != "Player combat is disabled on this server." {
+		t.Errorf("Expected PvP to be disabled, got %v", got)
+	}
+
+	executor.SetPvPMode(combat.PvPOptIn)
+	if got := attack(t, executor, ctx, "bob").Messages; strings.Join(got, "\n") != "You must turn PvP on before attacking other players. Type 'pvp on'." {
+		t.Errorf("Expected the attacker to need PvP on, got %v", got)
+	}
+	ctx.Character.PvP = true
+	if got := attack(t, executor, ctx, "bob").Messages; strings.Join(got, "\n") != "Bob has not chosen to fight other players." {
+		t.Errorf("Expected Bob not to have opted in, got %v", got)
+	}
+
+	bob.PvP = true
+	ctx.Character.Location.RoomID = character.DefaultStartRoomID
+	bob.Location.RoomID = character.DefaultStartRoomID
+	if got := attack(t, executor, ctx, "bob").Messages; strings.Join(got, "\n") != "This is a safe place; you can't fight other players here." {
+		t.Errorf("Expected the village to be safe, got %v", got)
+	}
+
+	if bob.Stats.Health != health || bob.State != character.CharacterAlive {
+		t.Errorf("Expected refused attacks to leave Bob alone, got %d health in state %v", bob.Stats.Health, bob.State)
+	}
+}
+
+func TestKillPlayerDefeats(t *testing.T) {
+	executor, ctx, bob := newPvPExecutor(t, combat.PvPOpen)
+	alice := ctx.Character
+	bob.Gold, alice.Gold = 200, 0
+
+	result := attack(t, executor, ctx, "bob")
+	if len(result.Targeted) != 1 || result.Targeted[0].CharacterID != bob.ID || !strings.HasPrefix(result.Targeted[0].Text, "Alice hits you for ") {
+		t.Errorf("Expected Bob to be told about the hit, got %v", result.Targeted)
+	}
+	if alice.State != character.CharacterInCombat || bob.State != character.CharacterInCombat {
+		t.Errorf("Expected both players to be fighting")
+	}
+	if len(result.Room) != 1 || result.Room[0].Text != "Alice attacks Bob." {
+		t.Errorf("Expected the room to see the attack, got %v", result.Room)
+	}
+
+	bob.Stats.Health = 1
+	result = attack(t, executor, ctx, "bob")
+	if last := result.Messages[len(result.Messages)-1]; last != "You take 20 gold from Bob." {
+		t.Errorf("Expected to take spoils, got %v", result.Messages)
+	}
+	if bob.Stats.Health != 1 || bob.State != character.CharacterAlive || alice.State != character.CharacterAlive {
+		t.Errorf("Expected Bob to be defeated but alive, got %d health in state %v", bob.Stats.Health, bob.State)
+	}
+	if bob.Gold != 180 || alice.Gold != 20 {
+		t.Errorf("Expected 20 gold to change hands, got Alice %d and Bob %d", alice.Gold, bob.Gold)
+	}
+	if bob.DeathCount != 0 || alice.KillCount != 0 {
+		t.Errorf("Expected a PvP defeat not to count as a death or kill")
+	}
+	if len(result.Room) != 1 || result.Room[0].Text != "Alice defeats Bob!" {
+		t.Errorf("Expected the room to see the defeat, got %v", result.Room)
+	}
+}
+
+func TestLeavingEndsPlayerFight(t *testing.T) {
+	executor, ctx, bob := newPvPExecutor(t, combat.PvPOpen)
+	alice := ctx.Character
+
+	attack(t, executor, ctx, "bob")
+	if bob.State != character.CharacterInCombat {
+		t.Fatalf("Expected Bob to be fighting")
+	}
+
+	// Still in the room, the fight goes on
+	executor.SettleFight(ctx)
+	if bob.State != character.CharacterInCombat {
+		t.Errorf("Expected the fight to go on while Alice is there")
+	}
+
+	ctx.Messenger = roomMessenger{ids: []string{alice.ID}}
+	executor.SettleFight(ctx)
+	if bob.State != character.CharacterAlive {
+		t.Errorf("Expected Bob to leave combat once Alice has gone, got state %v", bob.State)
+	}
+
+	attack(t, executor, &HandlerContext{Character: alice, Messenger: roomMessenger{ids: []string{alice.ID, bob.ID}}}, "bob")
+	executor.LeaveFights(alice)
+	if bob.State != character.CharacterAlive {
+		t.Errorf("Expected Bob to leave combat once Alice has left the game, got state %v", bob.State)
+	}
+}
+
+func TestPvPCommand(t *testing.T) {
+	executor, ctx, _ := newPvPExecutor(t, combat.PvPOff)
+	pvp := func(args ...string) string {
+		result, err := executor.handlers["pvp"].Execute(ctx, &Command{Verb: "pvp", Args: args})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		return strings.Join(result.Messages, "\n")
+	}
+
+	if got := pvp("on"); got != "Player combat is disabled on this server." || ctx.Character.PvP {
+		t.Errorf("Expected PvP to stay off on a disabled server, got %q", got)
+	}
+
+	executor.SetPvPMode(combat.PvPOptIn)
+	if got := pvp("on"); got != "You will now fight other players who have PvP on, outside safe rooms." || !ctx.Character.PvP {
+		t.Errorf("Expected PvP to turn on, got %q", got)
+	}
+	ctx.Character.State = character.CharacterInCombat
+	if got := pvp("off"); got != "You can't back out in the middle of a fight." || !ctx.Character.PvP {
+		t.Errorf("Expected to stay in PvP mid-fight, got %q", got)
+	}
+	ctx.Character.State = character.CharacterAlive
+	if got := pvp("off"); got != "You will no longer fight other players." || ctx.Character.PvP {
+		t.Errorf("Expected PvP to turn off, got %q", got)
+	}
+}
+
+func TestKillPlayerBusyElsewhere(t *testing.T) {
+	executor, ctx, bob := newPvPExecutor(t, combat.PvPOpen)
+	health := bob.Stats.Health
+
+	// Something else, like an NPC's blow, is changing Bob
+	unlock := executor.Locks().Lock(bob.ID)
+	if got := attack(t, executor, ctx, "bob").Messages; strings.Join(got, "\n") != "You can't get at Bob just now." {
+		t.Errorf("Expected the attack to give up on a busy foe, got %v", got)
+	}
+	if bob.Stats.Health != health {
+		t.Errorf("Expected Bob untouched, got %d health", bob.Stats.Health)
+	}
+
+	unlock()
+	if got := attack(t, executor, ctx, "bob").Messages; !strings.HasPrefix(got[len(got)-1], "You hit Bob") {
+		t.Errorf("Expected the attack to land once Bob is free, got %v", got)
+	}
+}
+
+func TestNewbieProtection(t *testing.T) {
+	executor, ctx, bob := newPvPExecutor(t, combat.PvPOpen)
+	executor.SetNewbieProtection(combat.NewbieProtection{Level: 5, LevelGap: 5})
+	alice := ctx.Character
+	alice.Level = 5
+
+	if got := attack(t, executor, ctx, "bob").Messages; strings.Join(got, "\n") != "Bob is under newbie protection." {
+		t.Errorf("Expected Bob to be protected, got %v", got)
+	}
+
+	bobCtx := &HandlerContext{Character: bob, Messenger: ctx.Messenger}
+	if got := attack(t, executor, bobCtx, "alice").Messages; strings.Join(got, "\n") != "You are under newbie protection and can't fight other players yet." {
+		t.Errorf("Expected Bob not to attack while protected, got %v", got)
+	}
+	result, _ := executor.handlers["pvp"].Execute(bobCtx, &Command{Verb: "pvp", Args: []string{"on"}})
+	if !strings.HasPrefix(result.Messages[0], "You are under newbie protection until level 5") {
+		t.Errorf("Expected the pvp command to explain the protection, got %v", result.Messages)
+	}
+	result, _ = executor.handlers["score"].Execute(bobCtx, &Command{Verb: "score"})
+	if !strings.Contains(strings.Join(result.Messages, "\n"), "Newbie protection: until level 5") {
+		t.Errorf("Expected the score to show the protection, got %v", result.Messages)
+	}
+
+	// Reaching the level ends it
+	bob.Experience = character.ExperienceForLevel(2) + character.ExperienceForLevel(3) +
+		character.ExperienceForLevel(4) + character.ExperienceForLevel(5)
+	lines := executor.experience.award(bob, 1)
+	if !strings.Contains(strings.Join(lines, "\n"), "You have outgrown newbie protection.") {
+		t.Errorf("Expected to be told protection has ended, got %v", lines)
+	}
+	if got := attack(t, executor, ctx, "bob").Messages; !strings.HasPrefix(got[0], "You hit Bob") {
+		t.Errorf("Expected Bob to be fair game at level %d, got %v", bob.Level, got)
+	}
+}
@@ -0,0 +1,194 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+	"github.com/elidor/dungeogo/pkg/game/event"
+	"github.com/elidor/dungeogo/pkg/game/items"
+	"github.com/elidor/dungeogo/pkg/game/quest"
+	"github.com/elidor/dungeogo/pkg/persistence/interfaces"
+)
+
+type QuestHandler struct {
+	repoManager interfaces.RepositoryManager
+}
+
+func (h *QuestHandler) Execute(ctx *HandlerContext, cmd *Command) (*CommandResult, error) {
+	char := ctx.Character
+	if char == nil {
+		return Reply("Error retrieving character information."), nil
+	}
+
+	var response []string
+	if len(char.Quests.Active) == 0 {
+		response = append(response, "You have no active quests.")
+	} else {
+		response = append(response, "Active quests:")
+		for questID, progress := range char.Quests.Active {
+			q, err := quest.GetQuestByID(questID)
+			if err != nil {
+				continue
+			}
+			status := ""
+			if char.Quests.IsReady(q) {
+				status = " (ready to turn in)"
+			}
+			response = append(response, fmt.Sprintf("  %s%s - %s", q.Name, status, q.Description))
+			for i, objective := range q.Objectives {
+				response = append(response, fmt.Sprintf("    %s: %d/%d",
+					objective.Description, progress.Count(i), objective.Count))
+			}
+		}
+	}
+
+	for _, giver := range quest.GetGiversInRoom(ctx.RoomID()) {
+		var offered []string
+		for _, q := range quest.GetQuestsByGiver(giver.ID) {
+			if !char.Quests.IsActive(q.ID) && !char.Quests.IsCompleted(q.ID) {
+				offered = append(offered, fmt.Sprintf("  %s - %s", q.Name, q.Description))
+			}
+		}
+		if len(offered) > 0 {
+			response = append(response, fmt.Sprintf("%s offers:", giver.Name))
+			response = append(response, offered...)
+			response = append(response, "Use 'accept <quest>' to take one on.")
+		}
+	}
+
+	return Reply(response...), nil
+}
+
+type AcceptHandler struct {
+	repoManager interfaces.RepositoryManager
+}
+
+func (h *AcceptHandler) Execute(ctx *HandlerContext, cmd *Command) (*CommandResult, error) {
+	char := ctx.Character
+	if char == nil {
+		return Reply("Error retrieving character information."), nil
+	}
+
+	q, giver, err := findOfferedQuest(char, strings.Join(cmd.Args, " "))
+	if err != nil {
+		return Reply("Nobody here is offering that quest."), nil
+	}
+	if giver.Faction != "" && !char.StandingWith(giver.Faction).WillDeal() {
+		return Reply(fmt.Sprintf("%s refuses to deal with the likes of you.", giver.Name)), nil
+	}
+
+	switch err := char.Quests.Accept(q); err {
+	case nil:
+	case quest.ErrQuestAlreadyActive:
+		return Reply(fmt.Sprintf("You are already on '%s'.", q.Name)), nil
+	case quest.ErrQuestAlreadyCompleted:
+		return Reply(fmt.Sprintf("You have already completed '%s'.", q.Name)), nil
+	default:
+		return Reply("Error accepting quest."), nil
+	}
+
+	response := []string{
+		fmt.Sprintf("%s gives you a task: %s", giver.Name, q.Name),
+		q.Description,
+	}
+	for _, objective := range q.Objectives {
+		response = append(response, fmt.Sprintf("  %s: 0/%d", objective.Description, objective.Count))
+	}
+	return Reply(response...), nil
+}
+
+type AbandonHandler struct {
+	repoManager interfaces.RepositoryManager
+}
+
+func (h *AbandonHandler) Execute(ctx *HandlerContext, cmd *Command) (*CommandResult, error) {
+	char := ctx.Character
+	if char == nil {
+		return Reply("Error retrieving character information."), nil
+	}
+
+	q, err := quest.FindQuest(strings.Join(cmd.Args, " "))
+	if err != nil || char.Quests.Abandon(q.ID) != nil {
+		return Reply("You are not on that quest."), nil
+	}
+
+	return Reply(fmt.Sprintf("You abandon '%s'.", q.Name)), nil
+}
+
+type CompleteHandler struct {
+	repoManager interfaces.RepositoryManager
+	factory     *items.ItemFactory
+	experience  *experienceRules
+}
+
+func (h *CompleteHandler) Execute(ctx *HandlerContext, cmd *Command) (*CommandResult, error) {
+	char := ctx.Character
+	if char == nil {
+		return Reply("Error retrieving character information."), nil
+	}
+
+	q, giver, err := findOfferedQuest(char, strings.Join(cmd.Args, " "))
+	if err != nil || !char.Quests.IsActive(q.ID) {
+		return Reply("You have no quest like that to turn in here."), nil
+	}
+
+	if err := char.Quests.Complete(q); err != nil {
+		return Reply(fmt.Sprintf("%s shakes their head. \"You haven't finished '%s' yet.\"", giver.Name, q.Name)), nil
+	}
+
+	response := []string{fmt.Sprintf("%s thanks you for completing '%s'.", giver.Name, q.Name)}
+
+	gold := q.Reward.Gold
+	if giver.Faction != "" {
+		gold += gold * char.StandingWith(giver.Faction).RewardBonus() / 100
+	}
+	gold = h.experience.gold(gold)
+	char.Gold += gold
+	response = append(response, h.experience.award(char, q.Reward.Experience)...)
+	if gold > 0 {
+		response = append(response, fmt.Sprintf("You receive %d gold.", gold))
+	}
+	response = append(response, adjustReputation(char, q.Reward.Reputation)...)
+
+	for _, templateID := range q.Reward.Items {
+		item, err := h.factory.CreateInstance(templateID, char.ID, 1)
+		if err != nil {
+			continue
+		}
+		if err := h.repoManager.Items().CreateItemInstance(item); err != nil {
+			continue
+		}
+		response = append(response, fmt.Sprintf("You receive %s.", item.GetDisplayName()))
+	}
+
+	return Reply(response...), nil
+}
+
+// questKill counts a kill towards the killer's quest objectives
+func questKill(e event.Event) []string {
+	kill := e.(event.Kill)
+	if kill.Character.Quests == nil {
+		return nil
+	}
+	return kill.Character.Quests.RecordEvent(quest.ObjectiveKill, kill.TemplateID)
+}
+
+// findOfferedQuest finds a quest by name that is offered by a giver in the
+// character's current room.
+func findOfferedQuest(char *character.Character, name string) (*quest.Quest, *quest.Giver, error) {
+	q, err := quest.FindQuest(name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	giver, err := quest.GetGiverByID(q.GiverID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if char.Location == nil || giver.RoomID != char.Location.RoomID {
+		return nil, nil, quest.ErrGiverNotFound
+	}
+	return q, giver, nil
+}
@@ -0,0 +1,177 @@
+package commands
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+	"github.com/elidor/dungeogo/pkg/game/cooldown"
+	"github.com/elidor/dungeogo/pkg/game/world"
+	"github.com/elidor/dungeogo/pkg/persistence/interfaces"
+)
+
+const (
+	// recallDelay is how long a recall takes to cast
+	recallDelay = 3 * time.Second
+	// recallCooldown is how long a character must wait between recalls
+	recallCooldown = 5 * time.Minute
+	// recallStaminaCost is the stamina a recall takes
+	recallStaminaCost = 20
+)
+
+// RecallHandler takes the character home to where they first started, once
+// a short prayer is finished. A fight breaks the prayer, and some rooms
+// forbid it altogether.
+type RecallHandler struct {
+	repoManager interfaces.RepositoryManager
+	view        *roomViewer
+	starts      *character.StartLocations
+	locks       *character.Locks
+	// load reloads a character once the prayer is finished
+	load      func(characterID string) (*HandlerContext, error)
+	delay     time.Duration
+	cooldown  time.Duration
+	cooldowns *cooldown.Tracker
+	now       func() time.Time
+
+	mutex   sync.Mutex
+	praying map[string]bool
+}
+
+func (h *RecallHandler) Execute(ctx *HandlerContext, cmd *Command) (*CommandResult, error) {
+	char := ctx.Character
+	if char == nil {
+		return Reply("Error retrieving character information."), nil
+	}
+
+	if refusal := h.refusal(ctx); refusal != "" {
+		return Reply(refusal), nil
+	}
+	if ctx.RoomID() == h.starts.StartLocation(char).RoomID {
+		return Reply("You are already home."), nil
+	}
+	if wait := h.cooldownLeft(char.ID); wait > 0 {
+		return Reply(fmt.Sprintf("You must wait %s before you can recall again.", waitText(wait))), nil
+	}
+	if !h.startPraying(char.ID) {
+		return Reply("You are already praying for recall."), nil
+	}
+
+	if h.delay <= 0 || ctx.Messenger == nil {
+		defer h.stopPraying(char.ID)
+		return h.recall(ctx), nil
+	}
+
+	messenger := ctx.Messenger
+	time.AfterFunc(h.delay, func() {
+		h.finish(char.ID, messenger)
+	})
+	return Reply("You close your eyes and pray to be taken home.").
+		ToRoom("", fmt.Sprintf("%s closes their eyes and begins to pray.", ctx.ActorName()), char.ID), nil
+}
+
+// refusal returns why the character cannot recall from where they are now,
+// or "" if they can
+func (h *RecallHandler) refusal(ctx *HandlerContext) string {
+	char := ctx.Character
+	switch {
+	case char.State == character.CharacterInCombat:
+		return "You can't concentrate on a prayer in the middle of a fight!"
+	case roomHas(ctx, world.FlagNoRecall) || roomHas(ctx, world.FlagNoMagic):
+		return "Something about this place stifles your prayer."
+	case char.Stats != nil && char.Stats.Stamina < recallStaminaCost:
+		return "You are too tired to recall."
+	}
+	return ""
+}
+
+// cooldownLeft returns how long characterID must wait before recalling
+// again
+func (h *RecallHandler) cooldownLeft(characterID string) time.Duration {
+	return h.cooldowns.Remaining(characterID, recallCooldownKey, h.now())
+}
+
+func (h *RecallHandler) startPraying(characterID string) bool {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	if h.praying[characterID] {
+		return false
+	}
+	h.praying[characterID] = true
+	return true
+}
+
+func (h *RecallHandler) stopPraying(characterID string) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	delete(h.praying, characterID)
+}
+
+// finish answers characterID's prayer once the delay has passed, if they
+// are still online and nothing has interrupted it.
+func (h *RecallHandler) finish(characterID string, messenger Messenger) {
+	defer h.stopPraying(characterID)
+	if !slices.Contains(messenger.OnlineCharacterIDs(), characterID) {
+		return
+	}
+
+	unlock := h.locks.Lock(characterID)
+	defer unlock()
+	ctx, err := h.load(characterID)
+	if err != nil {
+		messenger.SendToCharacter(characterID, "Error recalling.")
+		return
+	}
+	fromRoom := ctx.RoomID()
+	result := h.recall(ctx)
+
+	if roomID := ctx.RoomID(); roomID != fromRoom {
+		if tracker, ok := messenger.(RoomTracker); ok {
+			tracker.SetCharacterRoom(characterID, roomID)
+		}
+	}
+	for _, line := range result.Messages {
+		messenger.SendToCharacter(characterID, line)
+	}
+	for _, message := range result.Room {
+		messenger.BroadcastToRoom(message.RoomID, message.Text, message.Exclude...)
+	}
+}
+
+// recall moves the character home, spending the stamina it costs, unless
+// something has come up since they started praying
+func (h *RecallHandler) recall(ctx *HandlerContext) *CommandResult {
+	char := ctx.Character
+	if char.State == character.CharacterInCombat {
+		return Reply("Your prayer is broken by the fighting!")
+	}
+	if refusal := h.refusal(ctx); refusal != "" {
+		return Reply(refusal)
+	}
+
+	destination, err := world.GetRoom(h.starts.StartLocation(char).RoomID)
+	if err != nil {
+		return Reply("Error recalling.")
+	}
+	fromRoom := ctx.RoomID()
+	if char.Stats != nil {
+		char.Stats.Stamina -= recallStaminaCost
+		if err := h.repoManager.Characters().UpdateCharacterStats(char.ID, char.Stats); err != nil {
+			return Reply("Error recalling.")
+		}
+	}
+	destinationState, err := enterRoom(h.repoManager, ctx, destination)
+	if err != nil {
+		return Reply("Error recalling.")
+	}
+
+	h.cooldowns.Start(char.ID, recallCooldownKey, h.cooldown, h.now())
+
+	response := append([]string{"Your prayer is answered, and you are whisked away in a flash of light."},
+		describeRoom(destination, destinationState)...)
+	return Reply(append(response, h.view.contents(ctx)...)...).
+		ToRoom(fromRoom, fmt.Sprintf("%s vanishes in a flash of light.", ctx.ActorName()), char.ID).
+		ToRoom(destination.ID, fmt.Sprintf("%s appears in a flash of light.", ctx.ActorName()), char.ID)
+}
@@ -0,0 +1,116 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+	"github.com/elidor/dungeogo/pkg/persistence/interfaces"
+)
+
+// newRecallHandler returns the executor's recall handler, answering prayers
+// at once on a clock the test controls.
+func newRecallHandler(t *testing.T) (*RecallHandler, *time.Time) {
+	executor, _ := newFightExecutor(t)
+	handler := executor.handlers["recall"].(*RecallHandler)
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	handler.delay = 0
+	handler.now = func() time.Time { return now }
+	return handler, &now
+}
+
+func recall(t *testing.T, handler *RecallHandler, char *character.Character) *CommandResult {
+	ctx := &HandlerContext{Character: char, Room: &interfaces.RoomState{ID: char.Location.RoomID}}
+	result, err := handler.Execute(ctx, &Command{Verb: "recall", CharacterID: char.ID})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return result
+}
+
+func TestRecallTakesCharacterHome(t *testing.T) {
+	handler, _ := newRecallHandler(t)
+	char := testCharacter("riverbank")
+	stamina := char.Stats.Stamina
+
+	result := recall(t, handler, char)
+	if char.Location.RoomID != character.DefaultStartRoomID {
+		t.Fatalf("Expected to be home, got %s: %v", char.Location.RoomID, result.Messages)
+	}
+	if char.Stats.Stamina != stamina-recallStaminaCost {
+		t.Errorf("Expected recall to cost %d stamina, got %d left of %d", recallStaminaCost, char.Stats.Stamina, stamina)
+	}
+	if len(result.Room) != 2 || result.Room[0].RoomID != "riverbank" || result.Room[1].RoomID != character.DefaultStartRoomID {
+		t.Errorf("Expected both rooms to see the recall, got %v", result.Room)
+	}
+}
+
+func TestRecallCooldown(t *testing.T) {
+	handler, now := newRecallHandler(t)
+	char := testCharacter("riverbank")
+	recall(t, handler, char)
+
+	char.Location.RoomID = "riverbank"
+	*now = now.Add(recallCooldown - 90*time.Second)
+	result := recall(t, handler, char)
+	if char.Location.RoomID != "riverbank" {
+		t.Fatalf("Expected the cooldown to keep the character in place")
+	}
+	if expected := "You must wait 1 minute 30 seconds before you can recall again."; result.Messages[0] != expected {
+		t.Errorf("Expected %q, got %v", expected, result.Messages)
+	}
+
+	*now = now.Add(90 * time.Second)
+	recall(t, handler, char)
+	if char.Location.RoomID != character.DefaultStartRoomID {
+		t.Errorf("Expected to recall once the cooldown has passed, got %s", char.Location.RoomID)
+	}
+}
+
+func TestRecallRefusals(t *testing.T) {
+	handler, _ := newRecallHandler(t)
+	tests := []struct {
+		name     string
+		prepare  func(char *character.Character)
+		expected string
+	}{
+		{"fighting", func(char *character.Character) { char.State = character.CharacterInCombat }, "middle of a fight"},
+		{"no recall room", func(char *character.Character) { char.Location.RoomID = character.TutorialRoomID }, "stifles your prayer"},
+		{"tired", func(char *character.Character) { char.Stats.Stamina = recallStaminaCost - 1 }, "too tired"},
+		{"home", func(char *character.Character) { char.Location.RoomID = character.DefaultStartRoomID }, "already home"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			char := testCharacter("riverbank")
+			tt.prepare(char)
+			roomID := char.Location.RoomID
+
+			result := recall(t, handler, char)
+			if !strings.Contains(result.Messages[0], tt.expected) {
+				t.Errorf("Expected %q, got %v", tt.expected, result.Messages)
+			}
+			if char.Location.RoomID != roomID {
+				t.Errorf("Expected to stay in %s, got %s", roomID, char.Location.RoomID)
+			}
+		})
+	}
+}
+
+func TestRecallInterruptedByCombat(t *testing.T) {
+	handler, _ := newRecallHandler(t)
+	char := testCharacter("riverbank")
+	// The character was attacked while praying
+	char.State = character.CharacterInCombat
+
+	result := handler.recall(&HandlerContext{Character: char})
+	if result.Messages[0] != "Your prayer is broken by the fighting!" {
+		t.Errorf("Expected the prayer to be broken, got %v", result.Messages)
+	}
+	if char.Location.RoomID != "riverbank" {
+		t.Errorf("Expected to stay put, got %s", char.Location.RoomID)
+	}
+	if wait := handler.cooldownLeft(char.ID); wait != 0 {
+		t.Errorf("Expected a broken prayer not to start the cooldown, got %v", wait)
+	}
+}
@@ -0,0 +1,87 @@
+package commands
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/elidor/dungeogo/pkg/persistence/interfaces"
+)
+
+// Reloader re-reads one kind of content from disk while the server runs. It
+// must check the new content before swapping it in, so that a mistake
+// leaves the old content in place, and it returns a summary of what changed.
+type Reloader interface {
+	Reload() (string, error)
+}
+
+// builtInContent is content still built into the server, which a reload
+// can't change until it moves to data files
+var builtInContent = []string{"rooms", "shops", "socials"}
+
+// ReloadHandler lets administrators reload content from disk, such as the
+// message of the day, without restarting the server. Only one reload runs
+// at a time.
+type ReloadHandler struct {
+	repoManager interfaces.RepositoryManager
+	reloaders   map[string]Reloader
+	mutex       sync.Mutex
+}
+
+// add makes content reloadable by name, replacing any reloader already
+// registered under it
+func (h *ReloadHandler) add(name string, reloader Reloader) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.reloaders[strings.ToLower(name)] = reloader
+}
+
+func (h *ReloadHandler) Execute(ctx *HandlerContext, cmd *Command) (*CommandResult, error) {
+	char := ctx.Character
+	if char == nil {
+		return Reply("Error retrieving character information."), nil
+	}
+	account, err := h.repoManager.Players().GetPlayer(char.PlayerID)
+	if err != nil {
+		return Reply("Error retrieving account."), nil
+	}
+	if !account.IsAdmin() {
+		return Reply("Only administrators can reload content."), nil
+	}
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if len(h.reloaders) == 0 {
+		return Reply("There is no content that can be reloaded."), nil
+	}
+	if len(cmd.Args) == 0 {
+		return Reply("Usage: reload <what>. You can reload: " + h.names() + "."), nil
+	}
+
+	name := strings.ToLower(cmd.Args[0])
+	reloader, ok := h.reloaders[name]
+	if !ok && slices.Contains(builtInContent, name) {
+		return Reply(fmt.Sprintf("The %s are built into the server, so they can't be reloaded. You can reload: %s.", name, h.names())), nil
+	}
+	if !ok {
+		return Reply(fmt.Sprintf("There is no '%s' to reload. You can reload: %s.", cmd.Args[0], h.names())), nil
+	}
+	summary, err := reloader.Reload()
+	if err != nil {
+		return Reply(fmt.Sprintf("Reloading %s failed, so nothing was changed: %v", name, err)), nil
+	}
+	return Reply(summary), nil
+}
+
+// names lists what can be reloaded, in alphabetical order
+func (h *ReloadHandler) names() string {
+	names := make([]string, 0, len(h.reloaders))
+	for name := range h.reloaders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
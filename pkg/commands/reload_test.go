@@ -0,0 +1,78 @@
+package commands
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/elidor/dungeogo/pkg/game/player"
+)
+
+// fakeReloader counts its reloads and fails with err when set
+type fakeReloader struct {
+	reloads int
+	err     error
+}
+
+func (r *fakeReloader) Reload() (string, error) {
+	if r.err != nil {
+		return "", r.err
+	}
+	r.reloads++
+	return "Reloaded.", nil
+}
+
+func runReload(t *testing.T, executor *Executor, args ...string) string {
+	ctx := &HandlerContext{Character: testCharacter("town_square")}
+	result, err := executor.handlers["reload"].Execute(ctx, &Command{Verb: "reload", Args: args})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return result.Messages[0]
+}
+
+func TestReloadNeedsAdmin(t *testing.T) {
+	executor, _ := newFightExecutor(t)
+	motd := &fakeReloader{}
+	executor.SetReloader("motd", motd)
+
+	if got := runReload(t, executor, "motd"); got != "Only administrators can reload content." {
+		t.Errorf("Expected a player to be refused, got %q", got)
+	}
+	if motd.reloads != 0 {
+		t.Errorf("Expected nothing to be reloaded")
+	}
+}
+
+func TestReloadRunsNamedReloader(t *testing.T) {
+	executor, repos := newFightExecutor(t)
+	repos.players.player.Role = player.RoleAdmin
+
+	if got := runReload(t, executor, "motd"); got != "There is no content that can be reloaded." {
+		t.Errorf("Expected nothing to be reloadable yet, got %q", got)
+	}
+
+	motd, items := &fakeReloader{}, &fakeReloader{err: errors.New("bad template")}
+	executor.SetReloader("motd", motd)
+	executor.SetReloader("items", items)
+
+	for args, expected := range map[string]string{
+		"":        "Usage: reload <what>. You can reload: items, motd.",
+		"socials": "The socials are built into the server, so they can't be reloaded. You can reload: items, motd.",
+		"npcs":    "There is no 'npcs' to reload. You can reload: items, motd.",
+		"MOTD":    "Reloaded.",
+		"items":   "Reloading items failed, so nothing was changed: bad template",
+	} {
+		var got string
+		if args == "" {
+			got = runReload(t, executor)
+		} else {
+			got = runReload(t, executor, args)
+		}
+		if got != expected {
+			t.Errorf("reload %s: expected %q, got %q", args, expected, got)
+		}
+	}
+	if motd.reloads != 1 {
+		t.Errorf("Expected the message of the day to be reloaded once, got %d", motd.reloads)
+	}
+}
@@ -0,0 +1,79 @@
+package commands
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+	"github.com/elidor/dungeogo/pkg/persistence/interfaces"
+)
+
+// replyMemory remembers who last sent each character a tell, for reply
+type replyMemory struct {
+	mutex sync.Mutex
+	from  map[string]string
+}
+
+func newReplyMemory() *replyMemory {
+	return &replyMemory{from: make(map[string]string)}
+}
+
+func (m *replyMemory) remember(recipientID, senderID string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.from[recipientID] = senderID
+}
+
+func (m *replyMemory) recall(characterID string) (string, bool) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	senderID, ok := m.from[characterID]
+	return senderID, ok
+}
+
+func (m *replyMemory) forget(characterID string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	delete(m.from, characterID)
+}
+
+// tell sends message from the acting character to recipient, who can then
+// reply to them. A recipient who is AFK answers with their auto-reply.
+func tell(replies *replyMemory, afk *afkMemory, ctx *HandlerContext, recipient *character.Character, message string) *CommandResult {
+	replies.remember(recipient.ID, ctx.Character.ID)
+	result := Reply(fmt.Sprintf("You tell %s: %s", recipient.Name, message)).
+		ToCharacter(recipient.ID, fmt.Sprintf("%s tells you: %s", ctx.ActorName(), message))
+	if autoReply := afk.autoReply(recipient); autoReply != "" {
+		result.Add(autoReply)
+	}
+	return result
+}
+
+// ReplyHandler sends a tell back to whoever last sent the character one.
+type ReplyHandler struct {
+	repoManager interfaces.RepositoryManager
+	replies     *replyMemory
+	afk         *afkMemory
+}
+
+func (h *ReplyHandler) Execute(ctx *HandlerContext, cmd *Command) (*CommandResult, error) {
+	char := ctx.Character
+	if char == nil {
+		return Reply("Error retrieving character information."), nil
+	}
+
+	senderID, ok := h.replies.recall(char.ID)
+	if !ok {
+		return Reply("You have no one to reply to."), nil
+	}
+	sender, err := h.repoManager.Characters().GetCharacter(senderID)
+	if err != nil {
+		return Reply("You have no one to reply to."), nil
+	}
+	if ctx.Messenger != nil && !slices.Contains(ctx.Messenger.OnlineCharacterIDs(), sender.ID) {
+		return Reply(fmt.Sprintf("%s is no longer online.", sender.Name)), nil
+	}
+	return tell(h.replies, h.afk, ctx, sender, strings.Join(cmd.Args, " ")), nil
+}
@@ -0,0 +1,51 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+)
+
+func TestReplyToLastTell(t *testing.T) {
+	repos := newMemoryRepos()
+	executor := NewExecutor(repos)
+	parser := NewParser()
+	alice := testCharacter(character.DefaultStartRoomID)
+	bob := testCharacter(character.DefaultStartRoomID)
+	bob.ID, bob.Name = "char2", "Bob"
+	repos.characters.stored = map[string]*character.Character{alice.ID: alice, bob.ID: bob}
+	online := onlineMessenger{ids: []string{alice.ID, bob.ID}}
+
+	run := func(char *character.Character, input string) *CommandResult {
+		ctx := &HandlerContext{Character: char, Messenger: online}
+		result, err := executor.Execute(ctx, parser.Parse(input, char.PlayerID, char.ID))
+		if err != nil {
+			t.Fatalf("Unexpected error for %q: %v", input, err)
+		}
+		return result
+	}
+
+	if got := run(bob, "reply hello?").Messages[0]; got != "You have no one to reply to." {
+		t.Errorf("Expected nobody to reply to, got %q", got)
+	}
+
+	run(alice, "tell bob meet me at the well")
+	result := run(bob, "r on my way")
+	if got := result.Messages[0]; got != "You tell Alice: on my way" {
+		t.Errorf("Unexpected reply echo: %q", got)
+	}
+	if len(result.Targeted) != 1 || result.Targeted[0].CharacterID != alice.ID ||
+		result.Targeted[0].Text != "Bob tells you: on my way" {
+		t.Errorf("Expected the reply to reach Alice, got %v", result.Targeted)
+	}
+
+	// Alice can reply straight back to Bob's reply
+	if got := run(alice, "reply good").Messages[0]; got != "You tell Bob: good" {
+		t.Errorf("Expected Alice to reply to Bob, got %q", got)
+	}
+
+	online.ids = []string{bob.ID}
+	if got := run(bob, "reply still there?").Messages[0]; got != "Alice is no longer online." {
+		t.Errorf("Expected an offline sender to be reported, got %q", got)
+	}
+}
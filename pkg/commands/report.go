@@ -0,0 +1,163 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/elidor/dungeogo/pkg/game/world"
+	"github.com/elidor/dungeogo/pkg/persistence/interfaces"
+)
+
+const (
+	// reportLimit is how many reports a character can make in reportWindow
+	reportLimit  = 3
+	reportWindow = time.Hour
+	// openReportsShown is how many open reports the reports command lists
+	openReportsShown = 20
+)
+
+// ReportHandler records a player's report about another player for the
+// moderators and tells any who are online. Characters can make only
+// reportLimit reports in any reportWindow, so reports can't be used to
+// flood the moderators.
+type ReportHandler struct {
+	repoManager interfaces.RepositoryManager
+	now         func() time.Time
+}
+
+func (h *ReportHandler) Execute(ctx *HandlerContext, cmd *Command) (*CommandResult, error) {
+	char := ctx.Character
+	if char == nil {
+		return Reply("Error retrieving character information."), nil
+	}
+	if len(cmd.Args) < 2 {
+		return Reply("Usage: report <player> <reason>"), nil
+	}
+
+	target, err := h.repoManager.Characters().GetCharacterByName(cmd.Args[0])
+	if errors.Is(err, interfaces.ErrCharacterNotFound) {
+		return Reply(fmt.Sprintf("There is no character called '%s'.", cmd.Args[0])), nil
+	}
+	if err != nil {
+		return Reply("Error finding that character."), nil
+	}
+	if target.ID == char.ID {
+		return Reply("You can't report yourself."), nil
+	}
+
+	now := h.now()
+	recent, err := h.repoManager.Reports().CountReportsSince(char.ID, now.Add(-reportWindow))
+	if err != nil {
+		return Reply("Error filing report."), nil
+	}
+	if recent >= reportLimit {
+		return Reply("You have made too many reports recently. Please try again later."), nil
+	}
+
+	report := &interfaces.Report{
+		ReporterID:   char.ID,
+		ReporterName: char.Name,
+		TargetID:     target.ID,
+		TargetName:   target.Name,
+		Reason:       strings.Join(cmd.Args[1:], " "),
+		RoomID:       ctx.RoomID(),
+		CreatedAt:    now,
+	}
+	if err := h.repoManager.Reports().CreateReport(report); err != nil {
+		return Reply("Error filing report."), nil
+	}
+
+	result := Reply(fmt.Sprintf("Thank you. Your report about %s has been passed to the moderators.", target.Name))
+	notice := fmt.Sprintf("[Report #%d] %s", report.ID, describeReport(report))
+	for _, id := range onlineModerators(h.repoManager, ctx.Messenger) {
+		if id != char.ID {
+			result.ToCharacter(id, notice)
+		}
+	}
+	return result, nil
+}
+
+// ReportsHandler lets moderators list the open reports and resolve them.
+type ReportsHandler struct {
+	repoManager interfaces.RepositoryManager
+}
+
+func (h *ReportsHandler) Execute(ctx *HandlerContext, cmd *Command) (*CommandResult, error) {
+	char := ctx.Character
+	if char == nil {
+		return Reply("Error retrieving character information."), nil
+	}
+	account, err := h.repoManager.Players().GetPlayer(char.PlayerID)
+	if err != nil {
+		return Reply("Error retrieving account."), nil
+	}
+	if !account.IsModerator() {
+		return Reply("Only moderators can review reports."), nil
+	}
+
+	if len(cmd.Args) == 0 || strings.EqualFold(cmd.Args[0], "list") {
+		return h.list()
+	}
+	if !strings.EqualFold(cmd.Args[0], "resolve") || len(cmd.Args) < 2 {
+		return Reply("Usage: reports [resolve <id> [note]]"), nil
+	}
+
+	id, err := strconv.ParseInt(strings.TrimPrefix(cmd.Args[1], "#"), 10, 64)
+	if err != nil {
+		return Reply(fmt.Sprintf("'%s' is not a report number.", cmd.Args[1])), nil
+	}
+	note := strings.Join(cmd.Args[2:], " ")
+	err = h.repoManager.Reports().ResolveReport(id, char.Name, note)
+	if errors.Is(err, interfaces.ErrReportNotFound) {
+		return Reply(fmt.Sprintf("There is no open report #%d.", id)), nil
+	}
+	if err != nil {
+		return Reply("Error resolving report."), nil
+	}
+	return Reply(fmt.Sprintf("Report #%d resolved.", id)), nil
+}
+
+func (h *ReportsHandler) list() (*CommandResult, error) {
+	reports, err := h.repoManager.Reports().GetOpenReports(openReportsShown)
+	if err != nil {
+		return Reply("Error retrieving reports."), nil
+	}
+	if len(reports) == 0 {
+		return Reply("There are no open reports."), nil
+	}
+
+	result := Reply("Open reports:")
+	for _, report := range reports {
+		result.Add(fmt.Sprintf("  #%d %s %s", report.ID, report.CreatedAt.Format("2006-01-02 15:04"), describeReport(report)))
+	}
+	return result.Add("Type 'reports resolve <id> [note]' once a report is dealt with."), nil
+}
+
+// describeReport says who reported whom, where and why
+func describeReport(report *interfaces.Report) string {
+	return fmt.Sprintf("%s reports %s in %s: %s", report.ReporterName, report.TargetName, world.RoomName(report.RoomID), report.Reason)
+}
+
+// onlineModerators returns the IDs of the online characters whose accounts
+// are moderators
+func onlineModerators(repoManager interfaces.RepositoryManager, messenger Messenger) []string {
+	if messenger == nil {
+		return nil
+	}
+	var moderators []string
+	for _, id := range messenger.OnlineCharacterIDs() {
+		char, err := repoManager.Characters().GetCharacter(id)
+		if err != nil {
+			continue
+		}
+		account, err := repoManager.Players().GetPlayer(char.PlayerID)
+		if err != nil || !account.IsModerator() {
+			continue
+		}
+		moderators = append(moderators, id)
+	}
+	return moderators
+}
@@ -0,0 +1,168 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+	"github.com/elidor/dungeogo/pkg/game/player"
+	"github.com/elidor/dungeogo/pkg/persistence/interfaces"
+)
+
+// memoryReports keeps reports in memory, numbered from 1
+type memoryReports struct {
+	interfaces.ReportRepository
+	reports []*interfaces.Report
+}
+
+func (r *memoryReports) CreateReport(report *interfaces.Report) error {
+	report.ID = int64(len(r.reports) + 1)
+	r.reports = append(r.reports, report)
+	return nil
+}
+
+func (r *memoryReports) GetOpenReports(limit int) ([]*interfaces.Report, error) {
+	var open []*interfaces.Report
+	for _, report := range r.reports {
+		if report.IsOpen() && len(open) < limit {
+			open = append(open, report)
+		}
+	}
+	return open, nil
+}
+
+func (r *memoryReports) ResolveReport(reportID int64, resolvedBy, resolution string) error {
+	if reportID < 1 || reportID > int64(len(r.reports)) || !r.reports[reportID-1].IsOpen() {
+		return interfaces.ErrReportNotFound
+	}
+	report := r.reports[reportID-1]
+	report.ResolvedAt, report.ResolvedBy, report.Resolution = time.Now(), resolvedBy, resolution
+	return nil
+}
+
+func (r *memoryReports) CountReportsSince(reporterID string, since time.Time) (int, error) {
+	count := 0
+	for _, report := range r.reports {
+		if report.ReporterID == reporterID && !report.CreatedAt.Before(since) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// newReportExecutor returns an executor where Alice and Bob are players
+// and Mira is a moderator, all online
+func newReportExecutor(t *testing.T) (*Executor, *memoryRepos, *HandlerContext, *time.Time) {
+	repos := newMemoryRepos()
+	executor := NewExecutor(repos)
+	alice := testCharacter("town_square")
+	bob := testCharacter("town_square")
+	bob.ID, bob.Name, bob.PlayerID = "char2", "Bob", "player2"
+	mira := testCharacter("town_square")
+	mira.ID, mira.Name, mira.PlayerID = "char3", "Mira", "player3"
+	repos.characters.stored = map[string]*character.Character{alice.ID: alice, bob.ID: bob, mira.ID: mira}
+	moderator := player.NewPlayer("mira", "mira@example.com", "")
+	moderator.Role = player.RoleModerator
+	repos.players.accounts = map[string]*player.Player{"player3": moderator}
+
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	executor.handlers["report"].(*ReportHandler).now = func() time.Time { return now }
+	ctx := &HandlerContext{Character: alice, Messenger: onlineMessenger{ids: []string{alice.ID, bob.ID, mira.ID}}}
+	return executor, repos, ctx, &now
+}
+
+func TestReportNotifiesModerators(t *testing.T) {
+	executor, repos, ctx, _ := newReportExecutor(t)
+
+	result, err := executor.handlers["report"].Execute(ctx, &Command{Verb: "report", Args: strings.Fields("bob keeps spamming chat")})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.Messages[0] != "Thank you. Your report about Bob has been passed to the moderators." {
+		t.Errorf("Expected the report to be thanked for, got %v", result.Messages)
+	}
+	if len(repos.reports.reports) != 1 {
+		t.Fatalf("Expected the report to be saved, got %d", len(repos.reports.reports))
+	}
+	report := repos.reports.reports[0]
+	if report.ReporterName != "Alice" || report.TargetID != "char2" || report.Reason != "keeps spamming chat" || report.RoomID != "town_square" {
+		t.Errorf("Unexpected report %+v", report)
+	}
+
+	// Only the moderator hears of it
+	if len(result.Targeted) != 1 || result.Targeted[0].CharacterID != "char3" ||
+		!strings.HasPrefix(result.Targeted[0].Text, "[Report #1] Alice reports Bob in ") {
+		t.Errorf("Expected Mira to be told of the report, got %+v", result.Targeted)
+	}
+
+	for args, expected := range map[string]string{
+		"alice being rude":  "You can't report yourself.",
+		"nobody being rude": "There is no character called 'nobody'.",
+		"bob":               "Usage: report <player> <reason>",
+	} {
+		result, _ := executor.handlers["report"].Execute(ctx, &Command{Verb: "report", Args: strings.Fields(args)})
+		if result.Messages[0] != expected {
+			t.Errorf("report %s: expected %q, got %v", args, expected, result.Messages)
+		}
+	}
+}
+
+func TestReportRateLimit(t *testing.T) {
+	executor, repos, ctx, now := newReportExecutor(t)
+	report := func() string {
+		result, err := executor.handlers["report"].Execute(ctx, &Command{Verb: "report", Args: []string{"bob", "spam"}})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		return result.Messages[0]
+	}
+
+	for i := 0; i < reportLimit; i++ {
+		report()
+		*now = now.Add(time.Minute)
+	}
+	if got := report(); got != "You have made too many reports recently. Please try again later." {
+		t.Errorf("Expected reports to be limited, got %q", got)
+	}
+	if len(repos.reports.reports) != reportLimit {
+		t.Errorf("Expected %d reports saved, got %d", reportLimit, len(repos.reports.reports))
+	}
+
+	*now = now.Add(reportWindow)
+	if got := report(); !strings.HasPrefix(got, "Thank you.") {
+		t.Errorf("Expected the limit to lift after %v, got %q", reportWindow, got)
+	}
+}
+
+func TestReportsResolve(t *testing.T) {
+	executor, _, ctx, _ := newReportExecutor(t)
+	reports := func(char *character.Character, args ...string) string {
+		result, err := executor.handlers["reports"].Execute(&HandlerContext{Character: char}, &Command{Verb: "reports", Args: args})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		return strings.Join(result.Messages, "\n")
+	}
+	executor.handlers["report"].Execute(ctx, &Command{Verb: "report", Args: []string{"bob", "spam"}})
+
+	if got := reports(ctx.Character); got != "Only moderators can review reports." {
+		t.Errorf("Expected players to be refused, got %q", got)
+	}
+
+	mira, _ := executor.repoManager.Characters().GetCharacter("char3")
+	got := reports(mira)
+	if !strings.Contains(got, "#1 2024-03-01 12:00 Alice reports Bob in ") || !strings.Contains(got, ": spam") {
+		t.Errorf("Expected the open report to be listed, got:\n%s", got)
+	}
+
+	if got := reports(mira, "resolve", "1", "warned", "them"); got != "Report #1 resolved." {
+		t.Errorf("Expected the report to be resolved, got %q", got)
+	}
+	if got := reports(mira, "resolve", "#1"); got != "There is no open report #1." {
+		t.Errorf("Expected a resolved report to stay resolved, got %q", got)
+	}
+	if got := reports(mira); got != "There are no open reports." {
+		t.Errorf("Expected no open reports, got %q", got)
+	}
+}
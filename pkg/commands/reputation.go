@@ -0,0 +1,64 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+	"github.com/elidor/dungeogo/pkg/game/faction"
+)
+
+// ReputationHandler lists how each faction regards the character.
+type ReputationHandler struct{}
+
+func (h *ReputationHandler) Execute(ctx *HandlerContext, cmd *Command) (*CommandResult, error) {
+	char := ctx.Character
+	if char == nil {
+		return Reply("Error retrieving character information."), nil
+	}
+
+	response := []string{"Reputation:"}
+	for _, f := range faction.GetAllFactions() {
+		reputation := char.ReputationWith(f.ID)
+		response = append(response, fmt.Sprintf("  %-20s %-9s (%d)", f.Name, faction.StandingFor(reputation), reputation))
+	}
+	return Reply(response...), nil
+}
+
+// adjustReputation applies changes, by faction ID, to the character's
+// reputation and returns the lines telling them about it.
+func adjustReputation(char *character.Character, changes map[string]int) []string {
+	factionIDs := make([]string, 0, len(changes))
+	for id := range changes {
+		factionIDs = append(factionIDs, id)
+	}
+	sort.Strings(factionIDs)
+
+	var messages []string
+	for _, id := range factionIDs {
+		f, err := faction.GetFaction(id)
+		if err != nil || changes[id] == 0 {
+			continue
+		}
+		direction := "improves"
+		if changes[id] < 0 {
+			direction = "worsens"
+		}
+		messages = append(messages, fmt.Sprintf("Your reputation with the %s %s.", f.Name, direction))
+		if char.AdjustReputation(id, changes[id]) {
+			messages = append(messages, fmt.Sprintf("The %s now regard you as %s.", f.Name, char.StandingWith(id)))
+		}
+	}
+	return messages
+}
+
+// standingSummary describes how every faction regards the character in one
+// line, for the score sheet.
+func standingSummary(char *character.Character) string {
+	var standings []string
+	for _, f := range faction.GetAllFactions() {
+		standings = append(standings, fmt.Sprintf("%s %s", f.Name, char.StandingWith(f.ID)))
+	}
+	return strings.Join(standings, ", ")
+}
@@ -0,0 +1,68 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+	"github.com/elidor/dungeogo/pkg/game/faction"
+	"github.com/elidor/dungeogo/pkg/game/quest"
+)
+
+func TestKillShiftsReputation(t *testing.T) {
+	executor, _ := newFightExecutor(t)
+	char := testCharacter("riverbank")
+	ctx := &HandlerContext{Character: char}
+
+	messages := strings.Join(killUntilDead(t, executor, ctx, "goblin"), "\n")
+	if !strings.Contains(messages, "Your reputation with the Riverbank Goblins worsens.") ||
+		!strings.Contains(messages, "Your reputation with the Town Militia improves.") {
+		t.Errorf("Expected reputation messages, got %v", messages)
+	}
+	if got := char.ReputationWith(faction.Militia); got != 5 {
+		t.Errorf("Expected militia reputation 5, got %d", got)
+	}
+	if got := char.ReputationWith(faction.Goblins); got != -260 {
+		t.Errorf("Expected goblin reputation -260, got %d", got)
+	}
+}
+
+func TestQuestGiverRefusesHostile(t *testing.T) {
+	executor, _ := newFightExecutor(t)
+	char := testCharacter(character.DefaultStartRoomID)
+	char.AdjustReputation(faction.Militia, -200)
+	ctx := &HandlerContext{Character: char}
+
+	result, err := executor.handlers["accept"].Execute(ctx, &Command{Verb: "accept", Args: []string{"goblin", "menace"}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.Messages[0] != "Captain Aldric refuses to deal with the likes of you." {
+		t.Errorf("Expected the captain to refuse, got %v", result.Messages)
+	}
+}
+
+func TestQuestRewardReputation(t *testing.T) {
+	executor, _ := newFightExecutor(t)
+	char := testCharacter(character.DefaultStartRoomID)
+	char.AdjustReputation(faction.Militia, 100)
+	ctx := &HandlerContext{Character: char}
+
+	q, _ := quest.GetQuestByID("arming_the_militia")
+	if err := char.Quests.Accept(q); err != nil {
+		t.Fatalf("Failed to accept quest: %v", err)
+	}
+	char.Quests.RecordEvent(quest.ObjectiveFetch, "rusty_sword")
+
+	result, err := executor.handlers["complete"].Execute(ctx, &Command{Verb: "complete", Args: []string{"arming", "the", "militia"}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// Friendly standing pays 10% more
+	if char.Gold != 11 {
+		t.Errorf("Expected 11 gold, got %d: %v", char.Gold, result.Messages)
+	}
+	if got := char.ReputationWith(faction.Militia); got != 150 {
+		t.Errorf("Expected militia reputation 150, got %d", got)
+	}
+}
@@ -0,0 +1,85 @@
+package commands
+
+// Signal asks the session layer to change the actor's connection once the
+// command's messages have been delivered.
+type Signal int
+
+const (
+	SignalNone Signal = iota
+	SignalDisconnect
+	SignalCharacterMenu
+)
+
+// RoomMessage is shown to everyone in a room except the excluded characters.
+// An empty RoomID means the actor's current room.
+type RoomMessage struct {
+	RoomID  string
+	Text    string
+	Exclude []string
+}
+
+// TargetedMessage is shown to a single character, wherever they are.
+type TargetedMessage struct {
+	CharacterID string
+	Text        string
+}
+
+// FollowerResult is what a follower saw on moving along behind the actor.
+type FollowerResult struct {
+	CharacterID string
+	Result      *CommandResult
+}
+
+// CommandResult is everything a handler produces: lines for the actor, lines
+// for other characters, and an optional control signal.
+type CommandResult struct {
+	Messages []string
+	Room     []RoomMessage
+	Targeted []TargetedMessage
+	Signal   Signal
+	// Prompt is a status line shown after the messages, such as the
+	// combat prompt. Empty means none.
+	Prompt string
+
+	// ActorRoom is filled in by the engine with the actor's room once the
+	// command has run, so the session layer can track where they are.
+	ActorRoom string
+	// Followers holds the results of followers who moved with the actor,
+	// for the session layer to deliver to each of them.
+	Followers []FollowerResult
+}
+
+// Reply returns a result that only speaks to the actor.
+func Reply(lines ...string) *CommandResult {
+	return &CommandResult{Messages: lines}
+}
+
+// Add appends lines for the actor.
+func (r *CommandResult) Add(lines ...string) *CommandResult {
+	r.Messages = append(r.Messages, lines...)
+	return r
+}
+
+// ToRoom queues text for a room, skipping the listed character IDs.
+func (r *CommandResult) ToRoom(roomID, text string, exclude ...string) *CommandResult {
+	r.Room = append(r.Room, RoomMessage{RoomID: roomID, Text: text, Exclude: exclude})
+	return r
+}
+
+// ToCharacter queues text for one character.
+func (r *CommandResult) ToCharacter(characterID, text string) *CommandResult {
+	r.Targeted = append(r.Targeted, TargetedMessage{CharacterID: characterID, Text: text})
+	return r
+}
+
+// WithPrompt sets the status line shown after the actor's messages.
+func (r *CommandResult) WithPrompt(prompt string) *CommandResult {
+	r.Prompt = prompt
+	return r
+}
+
+// WithSignal sets the control signal for the session layer.
+func (r *CommandResult) WithSignal(signal Signal) *CommandResult {
+	r.Signal = signal
+	return r
+}
@@ -0,0 +1,85 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+)
+
+func TestCommandResultBuilders(t *testing.T) {
+	result := Reply("You wave.").
+		Add("Nobody waves back.").
+		ToRoom("", "Alice waves.", "alice-id").
+		ToCharacter("bob-id", "Alice waves at you.").
+		WithSignal(SignalCharacterMenu)
+
+	if len(result.Messages) != 2 || result.Messages[1] != "Nobody waves back." {
+		t.Errorf("Unexpected actor messages: %v", result.Messages)
+	}
+	if len(result.Room) != 1 || result.Room[0].Text != "Alice waves." || result.Room[0].Exclude[0] != "alice-id" {
+		t.Errorf("Unexpected room messages: %+v", result.Room)
+	}
+	if len(result.Targeted) != 1 || result.Targeted[0].CharacterID != "bob-id" {
+		t.Errorf("Unexpected targeted messages: %+v", result.Targeted)
+	}
+	if result.Signal != SignalCharacterMenu {
+		t.Errorf("Expected character menu signal, got %v", result.Signal)
+	}
+}
+
+func TestQuitSignalsDisconnect(t *testing.T) {
+	repos := newMemoryRepos()
+	repos.players.player.Preferences.ConfirmQuit = false
+	char := testCharacter(character.DefaultStartRoomID)
+	handler := &QuitHandler{repoManager: repos}
+
+	result, err := handler.Execute(&HandlerContext{Character: char}, &Command{Verb: "quit"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.Signal != SignalDisconnect {
+		t.Errorf("Expected quit to signal a disconnect, got %v", result.Signal)
+	}
+	if repos.characters.saves != 1 {
+		t.Errorf("Expected the character to be saved before quitting, got %d saves", repos.characters.saves)
+	}
+}
+
+func TestQuitConfirmation(t *testing.T) {
+	repos := newMemoryRepos()
+	char := testCharacter(character.DefaultStartRoomID)
+	handler := &QuitHandler{repoManager: repos}
+	quit := func(args ...string) *CommandResult {
+		result, err := handler.Execute(&HandlerContext{Character: char}, &Command{Verb: "quit", Args: args})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		return result
+	}
+
+	if result := quit(); result.Signal != SignalNone || !strings.HasPrefix(result.Messages[0], "Are you sure") {
+		t.Errorf("Expected to be asked to confirm, got %v", result.Messages)
+	}
+	if result := quit("confirm"); result.Signal != SignalDisconnect {
+		t.Errorf("Expected 'quit confirm' to disconnect, got %v", result.Messages)
+	}
+}
+
+func TestQuitRefusedInCombat(t *testing.T) {
+	repos := newMemoryRepos()
+	char := testCharacter(character.DefaultStartRoomID)
+	char.State = character.CharacterInCombat
+	handler := &QuitHandler{repoManager: repos}
+
+	result, err := handler.Execute(&HandlerContext{Character: char}, &Command{Verb: "quit", Args: []string{"confirm"}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.Signal != SignalNone || result.Messages[0] != "You can't quit in the middle of a fight! Flee or finish it first." {
+		t.Errorf("Expected quitting mid-fight to be refused, got %v", result.Messages)
+	}
+	if repos.characters.saves != 0 {
+		t.Errorf("Expected nothing to be saved")
+	}
+}
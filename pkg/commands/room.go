@@ -0,0 +1,101 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/elidor/dungeogo/pkg/game/items"
+	"github.com/elidor/dungeogo/pkg/game/lock"
+	"github.com/elidor/dungeogo/pkg/game/npc"
+	"github.com/elidor/dungeogo/pkg/game/quest"
+	"github.com/elidor/dungeogo/pkg/game/stealth"
+	"github.com/elidor/dungeogo/pkg/game/world"
+	"github.com/elidor/dungeogo/pkg/persistence/interfaces"
+)
+
+// describeRoom is what a character sees on looking around room.
+func describeRoom(room *world.Room, state *interfaces.RoomState) []string {
+	var flags map[string]interface{}
+	if state != nil {
+		flags = state.Flags
+	}
+
+	response := []string{room.Name, room.Description}
+	if world.HasFlag(room.ID, flags, world.FlagDark) {
+		response = append(response, "It is dark here, and shadows pool in every corner.")
+	}
+	for _, node := range world.ResourceNodes(room.ID) {
+		response = append(response, node.Description)
+	}
+	for _, giver := range quest.GetGiversInRoom(room.ID) {
+		response = append(response, giver.Greeting)
+	}
+
+	var exits []string
+	for _, direction := range room.SortedExits() {
+		exit := room.Exits[direction]
+		if exit.DoorID != "" && world.DoorState(flags, exit.DoorID) != lock.Unlocked {
+			direction += " (locked)"
+		}
+		exits = append(exits, direction)
+	}
+	if len(exits) == 0 {
+		return append(response, "There are no obvious exits.")
+	}
+	return append(response, "Exits: "+strings.Join(exits, ", "))
+}
+
+// roomFlags returns the state flags of the acting character's room.
+func roomFlags(ctx *HandlerContext) map[string]interface{} {
+	if ctx.Room == nil {
+		return nil
+	}
+	return ctx.Room.Flags
+}
+
+// roomHas reports whether the character's room has flag, as the room is
+// now rather than as it was built
+func roomHas(ctx *HandlerContext, flag string) bool {
+	return world.HasFlag(ctx.RoomID(), roomFlags(ctx), flag)
+}
+
+// roomViewer describes what has come into a room: items on the floor, NPCs
+// and other characters.
+type roomViewer struct {
+	repoManager interfaces.RepositoryManager
+	factory     *items.ItemFactory
+	stealth     *stealth.Tracker
+	npcs        *npc.Manager
+}
+
+// contents lists what the acting character can see in their room besides
+// its fixed features. Hidden characters are left out.
+func (v *roomViewer) contents(ctx *HandlerContext) []string {
+	var lines []string
+	if floor, err := v.repoManager.Items().GetRoomItems(ctx.RoomID()); err == nil && len(floor) > 0 {
+		var names []string
+		for _, item := range floor {
+			names = append(names, describeItem(v.factory, item))
+		}
+		lines = append(lines, "On the ground: "+strings.Join(names, ", "))
+	}
+
+	for _, n := range v.npcs.InRoom(ctx.RoomID()) {
+		lines = append(lines, n.Template.Description)
+	}
+	for _, other := range othersInRoom(v.repoManager, ctx) {
+		if v.stealth.IsHidden(other.ID) {
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("%s is here.", other.DisplayName()))
+	}
+	return lines
+}
+
+// describeItem names an item along with how many there are in its stack.
+func describeItem(factory *items.ItemFactory, item *items.ItemInstance) string {
+	if item.Quantity > 1 {
+		return fmt.Sprintf("%s (x%d)", itemName(factory, item), item.Quantity)
+	}
+	return itemName(factory, item)
+}
@@ -0,0 +1,89 @@
+package commands
+
+// SavePolicy is what the engine saves of the acting character once a
+// command has run. Handlers change the character they are given and leave
+// saving it to the engine; they only save other characters, items and rooms
+// themselves. Each command takes the narrowest save that covers what it can
+// change, keeping the full rewrite of every column for commands that need
+// it.
+type SavePolicy int
+
+const (
+	// SaveNothing is for commands that cannot change the character, such
+	// as looking around or talking
+	SaveNothing SavePolicy = iota
+	// SaveLocation is for moves, which save where the character is as they
+	// step into each room
+	SaveLocation
+	// SaveSkills saves only the character's skills, for commands whose one
+	// lasting effect is skill experience
+	SaveSkills
+	// SaveCharacter saves the whole character
+	SaveCharacter
+)
+
+// typeSavePolicies is the policy for each type of command
+var typeSavePolicies = map[CommandType]SavePolicy{
+	CommandMovement:      SaveLocation,
+	CommandCommunication: SaveNothing,
+	CommandInventory:     SaveCharacter,
+	CommandCombat:        SaveCharacter,
+	CommandMagic:         SaveCharacter,
+	CommandSkill:         SaveCharacter,
+	CommandInformation:   SaveNothing,
+	CommandSystem:        SaveCharacter,
+	CommandSocial:        SaveNothing,
+	CommandAdmin:         SaveCharacter,
+	CommandUnknown:       SaveNothing,
+}
+
+// verbSavePolicies overrides the policy of a command's type for single
+// commands
+var verbSavePolicies = map[string]SavePolicy{
+	// Stealth, gathering and lockpicking only earn skill experience; the
+	// room, items and sneaking's new location are saved as they change
+	"sneak": SaveSkills,
+	"hide":  SaveSkills,
+	"mine":  SaveSkills,
+	"fish":  SaveSkills,
+	"pick":  SaveSkills,
+	// Opening the map marks the room as explored
+	"map": SaveCharacter,
+	// Reading the spellbook learns any spells newly within reach
+	"spells": SaveCharacter,
+	// These only read the character, or change items, rooms, player
+	// preferences and in-memory state that are saved elsewhere or not at all
+	"inventory":   SaveNothing,
+	"appraise":    SaveNothing,
+	"drop":        SaveNothing,
+	"give":        SaveNothing,
+	"lock":        SaveNothing,
+	"unlock":      SaveNothing,
+	"skills":      SaveNothing,
+	"defend":      SaveNothing,
+	"nofollow":    SaveNothing,
+	"lose":        SaveNothing,
+	"autoloot":    SaveNothing,
+	"prompts":     SaveNothing,
+	"confirmquit": SaveNothing,
+	"bind":        SaveNothing,
+	"unbind":      SaveNothing,
+	// Recall usually finishes after the command, so it saves the stamina
+	// and location it spends itself
+	"recall": SaveNothing,
+	// Saving and quitting save the character themselves
+	"save": SaveNothing,
+	"quit": SaveNothing,
+	// Help only reads the parser
+	"help":     SaveNothing,
+	"commands": SaveNothing,
+}
+
+// SavePolicyFor returns what the engine saves of the acting character once
+// cmd has run
+func SavePolicyFor(cmd *Command) SavePolicy {
+	if policy, ok := verbSavePolicies[cmd.Verb]; ok {
+		return policy
+	}
+	return typeSavePolicies[cmd.Type]
+}
@@ -0,0 +1,57 @@
+package commands
+
+import "testing"
+
+func TestSavePolicyFor(t *testing.T) {
+	parser := NewParser()
+	tests := []struct {
+		input    string
+		expected SavePolicy
+	}{
+		// Moves save the character's room as they enter it
+		{"north", SaveLocation},
+		{"e", SaveLocation},
+		// Talking and looking change nothing
+		{"say hello", SaveNothing},
+		{"look", SaveNothing},
+		{"score", SaveNothing},
+		{"help", SaveNothing},
+		{"smile", SaveNothing},
+		{"xyzzy", SaveNothing},
+		// Anything that can change the character saves all of them
+		{"get sword", SaveCharacter},
+		{"kill goblin", SaveCharacter},
+		{"title the Brave", SaveCharacter},
+		{"map", SaveCharacter},
+		// Commands that only earn skill experience save just the skills
+		{"sneak north", SaveSkills},
+		{"hide", SaveSkills},
+		{"mine", SaveSkills},
+		// Commands that only read the character, or save what they change
+		// themselves, save nothing
+		{"inventory", SaveNothing},
+		{"skills", SaveNothing},
+		{"autoloot on", SaveNothing},
+		{"recall", SaveNothing},
+		// Save and quit do their own saving
+		{"save", SaveNothing},
+		{"quit", SaveNothing},
+	}
+
+	for _, tt := range tests {
+		cmd := parser.Parse(tt.input, "player1", "char1")
+		if got := SavePolicyFor(cmd); got != tt.expected {
+			t.Errorf("%q: expected policy %d, got %d", tt.input, tt.expected, got)
+		}
+	}
+}
+
+func TestEveryCommandTypeHasSavePolicy(t *testing.T) {
+	parser := NewParser()
+	for _, verb := range parser.Verbs() {
+		info, _ := parser.GetCommandInfo(verb)
+		if _, ok := typeSavePolicies[info.Type]; !ok {
+			t.Errorf("Command %q has type %d with no save policy", verb, info.Type)
+		}
+	}
+}
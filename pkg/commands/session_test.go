@@ -0,0 +1,84 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+)
+
+// clockMessenger reports everyone in ids as online since their start time
+type clockMessenger struct {
+	NopMessenger
+	started map[string]time.Time
+}
+
+func (m clockMessenger) OnlineCharacterIDs() []string {
+	ids := make([]string, 0, len(m.started))
+	for id := range m.started {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func (m clockMessenger) SessionStart(characterID string) (time.Time, bool) {
+	start, ok := m.started[characterID]
+	return start, ok
+}
+
+func TestScoreShowsPlayTime(t *testing.T) {
+	repos := newMemoryRepos()
+	executor := NewExecutor(repos)
+	alice := testCharacter(character.DefaultStartRoomID)
+	alice.PlayTime = 3 * time.Hour
+	alice.LastPlayed = time.Now().Add(-25 * time.Minute)
+
+	ctx := &HandlerContext{Character: alice, Messenger: NopMessenger{}}
+	result, _ := executor.handlers["score"].Execute(ctx, &Command{Verb: "score"})
+	if last := result.Messages[len(result.Messages)-1]; last != "Played: 3 hours 25 minutes" {
+		t.Errorf("Expected unsaved time counted in the total, got %q", last)
+	}
+
+	ctx.Messenger = clockMessenger{started: map[string]time.Time{alice.ID: time.Now().Add(-25 * time.Minute)}}
+	result, _ = executor.handlers["score"].Execute(ctx, &Command{Verb: "score"})
+	if last := result.Messages[len(result.Messages)-1]; last != "Played: 3 hours 25 minutes (this session: 25 minutes)" {
+		t.Errorf("Expected the session length, got %q", last)
+	}
+}
+
+func TestWhoTime(t *testing.T) {
+	repos := newMemoryRepos()
+	executor := NewExecutor(repos)
+	alice := testCharacter(character.DefaultStartRoomID)
+	bob := testCharacter(character.DefaultStartRoomID)
+	bob.ID, bob.Name = "char2", "Bob"
+	repos.characters.stored = map[string]*character.Character{alice.ID: alice, bob.ID: bob}
+	now := time.Now()
+	ctx := &HandlerContext{Character: alice, Messenger: clockMessenger{started: map[string]time.Time{
+		alice.ID: now.Add(-90 * time.Minute),
+		bob.ID:   now.Add(-30 * time.Second),
+	}}}
+
+	who := func(args ...string) string {
+		result, err := executor.handlers["who"].Execute(ctx, &Command{Verb: "who", Args: args})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		return strings.Join(result.Messages, "\n")
+	}
+
+	if out := who(); strings.Contains(out, "online 1 hour") {
+		t.Errorf("Expected plain who to leave out session times, got %q", out)
+	}
+	out := who("time")
+	if !strings.Contains(out, "Alice (Human Warrior, Level 1) - online 1 hour 30 minutes") {
+		t.Errorf("Expected Alice's session time, got %q", out)
+	}
+	if !strings.Contains(out, "Bob (Human Warrior, Level 1) - online 30 seconds") {
+		t.Errorf("Expected Bob's session time, got %q", out)
+	}
+	if out := who("fast"); out != "Usage: who [time]" {
+		t.Errorf("Expected usage, got %q", out)
+	}
+}
@@ -0,0 +1,206 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+	"github.com/elidor/dungeogo/pkg/game/cooldown"
+	"github.com/elidor/dungeogo/pkg/game/damage"
+	"github.com/elidor/dungeogo/pkg/game/event"
+	"github.com/elidor/dungeogo/pkg/game/magic"
+)
+
+// castExperience is the skill experience a casting earns in Magic and in
+// the spell's school
+const castExperience = 10
+
+// SpellsHandler lists the spells in the character's spellbook and how many
+// castings of each they have prepared.
+type SpellsHandler struct{}
+
+func (h *SpellsHandler) Execute(ctx *HandlerContext, cmd *Command) (*CommandResult, error) {
+	char := ctx.Character
+	if char == nil {
+		return Reply("Error retrieving character information."), nil
+	}
+
+	response := spellsLearned(char.LearnSpells())
+	book := char.Spellbook
+	if len(book.Known) == 0 {
+		return Reply(append(response, "You don't know any spells.")...), nil
+	}
+
+	response = append(response, fmt.Sprintf("Your spellbook (%d/%d castings prepared):", book.PreparedCount(), char.SpellSlots()))
+	for _, id := range book.Known {
+		spell, err := magic.GetSpellByID(id)
+		if err != nil {
+			continue
+		}
+		response = append(response, fmt.Sprintf("  %-16s %3d mana  %d prepared  %s", spell.Name, spell.ManaCost, book.Prepared[id], spell.Description))
+	}
+	return Reply(response...), nil
+}
+
+// PrepareHandler readies a casting of a spell from the character's
+// spellbook, taking up one of their spell slots until it is cast. Casters
+// study between fights, not during them.
+type PrepareHandler struct{}
+
+func (h *PrepareHandler) Execute(ctx *HandlerContext, cmd *Command) (*CommandResult, error) {
+	char := ctx.Character
+	if char == nil {
+		return Reply("Error retrieving character information."), nil
+	}
+	if char.State == character.CharacterInCombat {
+		return Reply("You can't study your spellbook in the middle of a fight!"), nil
+	}
+
+	response := spellsLearned(char.LearnSpells())
+	name := strings.Join(cmd.Args, " ")
+	if strings.EqualFold(name, "clear") {
+		char.Spellbook.Clear()
+		return Reply(append(response, "You clear your mind of the spells you had prepared.")...), nil
+	}
+
+	spell, err := magic.FindSpell(name)
+	if err != nil || !char.Spellbook.Knows(spell.ID) {
+		return Reply(append(response, fmt.Sprintf("You don't know a spell called '%s'.", name))...), nil
+	}
+	slots := char.SpellSlots()
+	if err := char.Spellbook.Prepare(spell.ID, slots); errors.Is(err, magic.ErrNoSlotsFree) {
+		return Reply(append(response, fmt.Sprintf("You can't hold any more spells in mind. (%d/%d castings prepared)", slots, slots))...), nil
+	} else if err != nil {
+		return Reply("Error preparing spell."), nil
+	}
+
+	response = append(response, fmt.Sprintf("You commit %s to memory. (%d/%d castings prepared)", spell.Name, char.Spellbook.PreparedCount(), slots))
+	return Reply(response...).
+		ToRoom("", fmt.Sprintf("%s studies their spellbook.", ctx.ActorName()), char.ID), nil
+}
+
+// CastHandler casts a prepared spell, spending the casting and the spell's
+// mana. Harmful spells are aimed at an NPC in the room, or at the foe the
+// character is fighting if none is named; healing spells mend the caster.
+// Every casting trains Magic and the spell's school, and each spell has a
+// cooldown before it can be cast again.
+type CastHandler struct {
+	// killer settles fights the spells start or finish
+	killer    *KillHandler
+	cooldowns *cooldown.Tracker
+	// roll returns a number from 0 to n-1
+	roll func(n int) int
+	now  func() time.Time
+}
+
+func (h *CastHandler) Execute(ctx *HandlerContext, cmd *Command) (*CommandResult, error) {
+	char := ctx.Character
+	if char == nil {
+		return Reply("Error retrieving character information."), nil
+	}
+
+	spell, targetName := parseSpell(cmd.Args)
+	if spell == nil || !char.Spellbook.Knows(spell.ID) {
+		return Reply(fmt.Sprintf("You don't know a spell called '%s'.", strings.Join(cmd.Args, " "))), nil
+	}
+	if char.Spellbook.Prepared[spell.ID] <= 0 {
+		return Reply(fmt.Sprintf("You have no castings of %s prepared.", spell.Name)), nil
+	}
+	if wait := h.cooldowns.Remaining(char.ID, spellCooldownKey(spell), h.now()); wait > 0 {
+		return Reply(fmt.Sprintf("You must wait %s before you can cast %s again.", waitText(wait), spell.Name)), nil
+	}
+	if char.Stats == nil || char.Stats.Mana < spell.ManaCost {
+		return Reply(fmt.Sprintf("You don't have enough mana to cast %s.", spell.Name)), nil
+	}
+
+	if spell.Effect == magic.EffectHeal {
+		if targetName != "" && !strings.EqualFold(targetName, "self") && !strings.EqualFold(targetName, char.Name) {
+			return Reply(fmt.Sprintf("%s can only be cast on yourself.", spell.Name)), nil
+		}
+		h.spend(char, spell)
+		healed := min(spell.Roll(h.roll), char.Stats.MaxHealth-char.Stats.Health)
+		char.Stats.Health += healed
+		response := append([]string{fmt.Sprintf("You cast %s and recover %d health.", spell.Name, healed)}, h.train(char, spell)...)
+		return Reply(response...).
+			ToRoom("", fmt.Sprintf("%s casts %s, and their wounds close.", ctx.ActorName(), spell.Name), char.ID), nil
+	}
+
+	foe, other := h.killer.foe(ctx, targetName)
+	if other != nil {
+		return Reply("You can only turn your spells on creatures."), nil
+	}
+	if foe == nil {
+		if targetName == "" {
+			return Reply(fmt.Sprintf("Cast %s at whom?", spell.Name)), nil
+		}
+		return Reply(fmt.Sprintf("You don't see %s here.", targetName)), nil
+	}
+
+	h.spend(char, spell)
+	response := h.killer.stopFollowing(char)
+	dealt := foe.Template.Resist(spell.Roll(h.roll), spell.Element)
+	response = append(response, fmt.Sprintf("Your %s hits %s for %s.", spell.Name, foe.Template.Name, damage.Describe(dealt, spell.Element)))
+	response = append(response, h.train(char, spell)...)
+
+	killed, err := h.killer.npcs.Damage(foe.ID, dealt)
+	if err != nil {
+		return Reply("Error casting."), nil
+	}
+	if killed {
+		return h.killer.slay(ctx, foe, response)
+	}
+	h.killer.npcs.Engage(foe.ID, char.ID)
+	char.State = character.CharacterInCombat
+	return Reply(response...).
+		ToRoom("", fmt.Sprintf("%s casts %s at %s.", ctx.ActorName(), spell.Name, foe.Template.Name), char.ID), nil
+}
+
+// parseSpell splits args into the spell they start with and the name of
+// the target after it. Spell names can be several words, so the longest
+// match wins.
+func parseSpell(args []string) (*magic.Spell, string) {
+	for n := len(args); n > 0; n-- {
+		if spell, err := magic.FindSpell(strings.Join(args[:n], " ")); err == nil {
+			return spell, strings.Join(args[n:], " ")
+		}
+	}
+	return nil, ""
+}
+
+// spend uses up a prepared casting of spell and the mana it costs, and
+// starts its cooldown
+func (h *CastHandler) spend(char *character.Character, spell *magic.Spell) {
+	char.Spellbook.Expend(spell.ID)
+	char.Stats.Mana -= spell.ManaCost
+	h.cooldowns.Start(char.ID, spellCooldownKey(spell), spell.Cooldown, h.now())
+}
+
+// train gives Magic and the spell's school their experience for a casting,
+// returning the lines for any skill that improves and spell it brings
+// within reach
+func (h *CastHandler) train(char *character.Character, spell *magic.Spell) []string {
+	var response []string
+	for _, skill := range []character.SkillType{character.SkillMagic, character.SpellSkill(spell.School)} {
+		if h.killer.experience.train(char, skill, castExperience) {
+			response = append(response, fmt.Sprintf("Your %s skill improves to %d.", character.GetSkillName(skill), char.Skills.GetSkillLevel(skill)))
+		}
+	}
+	return append(response, spellsLearned(char.LearnSpells())...)
+}
+
+// learnSpells teaches a character who has gained a level the spells it
+// brings within reach
+func learnSpells(e event.Event) []string {
+	return spellsLearned(e.Subject().LearnSpells())
+}
+
+// spellsLearned returns the lines announcing newly learned spells
+func spellsLearned(spells []*magic.Spell) []string {
+	var lines []string
+	for _, spell := range spells {
+		lines = append(lines, fmt.Sprintf("You have learned %s! Type 'prepare %s' to ready it.", spell.Name, strings.ToLower(spell.Name)))
+	}
+	return lines
+}
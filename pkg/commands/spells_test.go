@@ -0,0 +1,219 @@
+package commands
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+	"github.com/elidor/dungeogo/pkg/game/cooldown"
+	"github.com/elidor/dungeogo/pkg/game/event"
+)
+
+// testMage is testCharacter as a human mage, who knows Magic Missile
+func testMage(roomID string) *character.Character {
+	race, _ := character.GetRaceByID("human")
+	class, _ := character.GetClassByID("mage")
+	char := character.NewCharacter("player1", "Alice", race, class)
+	char.ID = "char1"
+	char.Location.RoomID = roomID
+	return char
+}
+
+func runSpellCommand(t *testing.T, executor *Executor, ctx *HandlerContext, verb string, args ...string) []string {
+	t.Helper()
+	result, err := executor.handlers[verb].Execute(ctx, &Command{Verb: verb, Args: args})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return result.Messages
+}
+
+func TestPrepareFillsSpellSlots(t *testing.T) {
+	executor := NewExecutor(newMemoryRepos())
+	char := testMage(character.DefaultStartRoomID)
+	ctx := &HandlerContext{Character: char}
+
+	messages := runSpellCommand(t, executor, ctx, "prepare", "magic", "missile")
+	if messages[0] != "You commit Magic Missile to memory. (1/2 castings prepared)" {
+		t.Errorf("Expected Magic Missile prepared, got %v", messages)
+	}
+	runSpellCommand(t, executor, ctx, "prepare", "magic_missile")
+	messages = runSpellCommand(t, executor, ctx, "prepare", "magic", "missile")
+	if messages[0] != "You can't hold any more spells in mind. (2/2 castings prepared)" {
+		t.Errorf("Expected the slots to be full, got %v", messages)
+	}
+	if char.Spellbook.Prepared["magic_missile"] != 2 {
+		t.Errorf("Expected 2 castings of Magic Missile, got %v", char.Spellbook.Prepared)
+	}
+
+	messages = runSpellCommand(t, executor, ctx, "prepare", "fire", "bolt")
+	if messages[0] != "You don't know a spell called 'fire bolt'." {
+		t.Errorf("Expected Fire Bolt to be unknown, got %v", messages)
+	}
+
+	runSpellCommand(t, executor, ctx, "prepare", "clear")
+	if char.Spellbook.PreparedCount() != 0 {
+		t.Errorf("Expected the prepared castings to be cleared, got %v", char.Spellbook.Prepared)
+	}
+
+	char.State = character.CharacterInCombat
+	messages = runSpellCommand(t, executor, ctx, "prepare", "magic", "missile")
+	if messages[0] != "You can't study your spellbook in the middle of a fight!" {
+		t.Errorf("Expected preparing to be refused in a fight, got %v", messages)
+	}
+}
+
+// stopCastClock makes the cast handler's clock one the test moves on
+func stopCastClock(executor *Executor) *time.Time {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	executor.handlers["cast"].(*CastHandler).now = func() time.Time { return now }
+	return &now
+}
+
+func TestCastSpendsPreparedSpell(t *testing.T) {
+	executor, _ := newFightExecutor(t)
+	executor.handlers["cast"].(*CastHandler).roll = func(n int) int { return 0 }
+	now := stopCastClock(executor)
+	char := testMage("riverbank")
+	ctx := &HandlerContext{Character: char}
+	mana := char.Stats.Mana
+
+	messages := runSpellCommand(t, executor, ctx, "cast", "magic", "missile", "goblin")
+	if messages[0] != "You have no castings of Magic Missile prepared." {
+		t.Fatalf("Expected casting to need preparing, got %v", messages)
+	}
+
+	runSpellCommand(t, executor, ctx, "prepare", "magic", "missile")
+	runSpellCommand(t, executor, ctx, "prepare", "magic", "missile")
+	messages = runSpellCommand(t, executor, ctx, "cast", "magic", "missile", "goblin")
+	if messages[0] != "Your Magic Missile hits a goblin for 4 arcane damage." {
+		t.Errorf("Expected the missile to hit, got %v", messages)
+	}
+	if char.State != character.CharacterInCombat {
+		t.Errorf("Expected the caster to be in a fight")
+	}
+	if char.Stats.Mana != mana-5 || char.Spellbook.Prepared["magic_missile"] != 1 {
+		t.Errorf("Expected 5 mana and a casting spent, got %d mana and %v", mana-char.Stats.Mana, char.Spellbook.Prepared)
+	}
+
+	// With no target named, the spell goes at the foe being fought
+	*now = now.Add(3 * time.Second)
+	messages = runSpellCommand(t, executor, ctx, "cast", "magic", "missile")
+	if !slices.Contains(messages, "Your Magic Missile hits a goblin for 4 arcane damage.") {
+		t.Errorf("Expected the missile to hit the goblin being fought, got %v", messages)
+	}
+	if char.Skills.GetSkill(character.SkillMagic).Experience != 2*castExperience ||
+		char.Skills.GetSkill(character.SkillEvocation).Experience != 2*castExperience {
+		t.Errorf("Expected Magic and Evocation to be trained by each casting")
+	}
+
+	*now = now.Add(3 * time.Second)
+	messages = runSpellCommand(t, executor, ctx, "cast", "magic", "missile")
+	if messages[0] != "You have no castings of Magic Missile prepared." {
+		t.Errorf("Expected the prepared castings to be spent, got %v", messages)
+	}
+}
+
+func TestCastCooldown(t *testing.T) {
+	executor, _ := newFightExecutor(t)
+	executor.handlers["cast"].(*CastHandler).roll = func(n int) int { return 0 }
+	now := stopCastClock(executor)
+	char := testMage("riverbank")
+	ctx := &HandlerContext{Character: char}
+	runSpellCommand(t, executor, ctx, "prepare", "magic", "missile")
+	runSpellCommand(t, executor, ctx, "prepare", "magic", "missile")
+
+	runSpellCommand(t, executor, ctx, "cast", "magic", "missile", "goblin")
+	mana := char.Stats.Mana
+	*now = now.Add(1500 * time.Millisecond)
+	messages := runSpellCommand(t, executor, ctx, "cast", "magic", "missile", "goblin")
+	if messages[0] != "You must wait 2 seconds before you can cast Magic Missile again." {
+		t.Errorf("Expected the spell to be cooling down, got %v", messages)
+	}
+	if char.Stats.Mana != mana || char.Spellbook.Prepared["magic_missile"] != 1 {
+		t.Errorf("Expected a refused casting to cost nothing")
+	}
+
+	*now = now.Add(1500 * time.Millisecond)
+	messages = runSpellCommand(t, executor, ctx, "cast", "magic", "missile", "goblin")
+	if messages[0] != "Your Magic Missile hits a goblin for 4 arcane damage." {
+		t.Errorf("Expected the spell to be ready again, got %v", messages)
+	}
+
+	// Any action with a cooldown holds back the others for a moment
+	if wait := executor.Cooldowns().Remaining(char.ID, recallCooldownKey, *now); wait != cooldown.DefaultGlobal {
+		t.Errorf("Expected the global cooldown to hold back recall, got %v", wait)
+	}
+}
+
+func TestCastUnknownSpell(t *testing.T) {
+	executor := NewExecutor(newMemoryRepos())
+	ctx := &HandlerContext{Character: testCharacter(character.DefaultStartRoomID)}
+
+	messages := runSpellCommand(t, executor, ctx, "cast", "magic", "missile", "goblin")
+	if messages[0] != "You don't know a spell called 'magic missile goblin'." {
+		t.Errorf("Expected a warrior not to know Magic Missile, got %v", messages)
+	}
+}
+
+func TestCastHealsCaster(t *testing.T) {
+	executor := NewExecutor(newMemoryRepos())
+	executor.handlers["cast"].(*CastHandler).roll = func(n int) int { return 0 }
+	char := testMage(character.DefaultStartRoomID)
+	char.Level = 2
+	char.Skills.GetSkill(character.SkillMagic).Level = 1
+	char.Skills.GetSkill(character.SkillHealing).Level = 1
+	char.Stats.Health = char.Stats.MaxHealth - 20
+	ctx := &HandlerContext{Character: char}
+
+	messages := runSpellCommand(t, executor, ctx, "prepare", "mend")
+	if messages[0] != "You have learned Mend! Type 'prepare mend' to ready it." {
+		t.Errorf("Expected Mend to be learned, got %v", messages)
+	}
+	messages = runSpellCommand(t, executor, ctx, "cast", "mend")
+	if messages[0] != "You cast Mend and recover 8 health." {
+		t.Errorf("Expected to be healed, got %v", messages)
+	}
+	if char.Stats.Health != char.Stats.MaxHealth-12 {
+		t.Errorf("Expected 8 health back, got %d of %d", char.Stats.Health, char.Stats.MaxHealth)
+	}
+}
+
+func TestLevelUpLearnsSpells(t *testing.T) {
+	executor := NewExecutor(newMemoryRepos())
+	char := testMage(character.DefaultStartRoomID)
+	char.Skills.GetSkill(character.SkillMagic).Level = 1
+	char.Skills.GetSkill(character.SkillHealing).Level = 1
+	char.Level = 2
+
+	lines := executor.Events().Publish(event.LevelUp{Character: char, Level: 2})
+	if !slices.Contains(lines, "You have learned Mend! Type 'prepare mend' to ready it.") {
+		t.Errorf("Expected Mend to be learned on levelling, got %v", lines)
+	}
+
+	messages := runSpellCommand(t, executor, &HandlerContext{Character: char}, "spells")
+	output := strings.Join(messages, "\n")
+	for _, want := range []string{"Your spellbook (0/2 castings prepared):", "Magic Missile", "Mend"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected %q in:\n%s", want, output)
+		}
+	}
+}
+
+func TestCastFireAtStraw(t *testing.T) {
+	executor, _ := newFightExecutor(t)
+	executor.handlers["cast"].(*CastHandler).roll = func(n int) int { return 0 }
+	char := testMage(character.TutorialRoomID)
+	char.Level = 3
+	char.Skills.GetSkill(character.SkillMagic).Level = 2
+	char.Skills.GetSkill(character.SkillEvocation).Level = 2
+	ctx := &HandlerContext{Character: char}
+
+	runSpellCommand(t, executor, ctx, "prepare", "fire", "bolt")
+	messages := runSpellCommand(t, executor, ctx, "cast", "fire", "bolt", "dummy")
+	if messages[0] != "Your Fire Bolt hits a training dummy for 12 fire damage." {
+		t.Errorf("Expected straw to burn for half as much again, got %v", messages)
+	}
+}
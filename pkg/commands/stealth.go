@@ -0,0 +1,129 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+	"github.com/elidor/dungeogo/pkg/game/stealth"
+	"github.com/elidor/dungeogo/pkg/game/world"
+	"github.com/elidor/dungeogo/pkg/persistence/interfaces"
+)
+
+// stealthExperience is the Stealth experience for going unnoticed
+const stealthExperience = 10
+
+// breaksStealth reports whether cmd draws attention to a hidden character.
+// Sneaking is the one way to move without being seen, and casting a spell
+// gives the caster away.
+func breaksStealth(cmd *Command) bool {
+	switch cmd.Type {
+	case CommandCommunication, CommandCombat, CommandSocial:
+		return true
+	case CommandMovement:
+		return cmd.Verb != "sneak"
+	case CommandMagic:
+		return cmd.Verb == "cast"
+	}
+	return false
+}
+
+// othersInRoom loads the other characters in the acting character's room.
+func othersInRoom(repoManager interfaces.RepositoryManager, ctx *HandlerContext) []*character.Character {
+	if ctx.Messenger == nil || ctx.Character == nil {
+		return nil
+	}
+
+	var others []*character.Character
+	for _, id := range ctx.Messenger.CharactersInRoom(ctx.RoomID()) {
+		if id == ctx.Character.ID {
+			continue
+		}
+		if other, err := repoManager.Characters().GetCharacter(id); err == nil {
+			others = append(others, other)
+		}
+	}
+	return others
+}
+
+// tryHide rolls for the acting character to go unnoticed by everyone in
+// their room, hiding them on success and revealing them otherwise.
+func tryHide(repoManager interfaces.RepositoryManager, tracker *stealth.Tracker, roll func(n int) int, ctx *HandlerContext) bool {
+	dark := roomHas(ctx, world.FlagDark)
+	if roll(100) < stealth.HideChance(ctx.Character, othersInRoom(repoManager, ctx), dark) {
+		tracker.Hide(ctx.Character.ID)
+		return true
+	}
+	tracker.Reveal(ctx.Character.ID)
+	return false
+}
+
+// awardStealth adds Stealth experience, returning any improvement message.
+func awardStealth(char *character.Character, experience *experienceRules) []string {
+	if experience.train(char, character.SkillStealth, stealthExperience) {
+		return []string{fmt.Sprintf("Your Stealth skill improves to %d.",
+			char.Skills.GetSkillLevel(character.SkillStealth))}
+	}
+	return nil
+}
+
+type HideHandler struct {
+	repoManager interfaces.RepositoryManager
+	stealth     *stealth.Tracker
+	experience  *experienceRules
+	// roll returns a number from 0 to n-1
+	roll func(n int) int
+}
+
+func (h *HideHandler) Execute(ctx *HandlerContext, cmd *Command) (*CommandResult, error) {
+	char := ctx.Character
+	if char == nil {
+		return Reply("Error retrieving character information."), nil
+	}
+	if h.stealth.IsHidden(char.ID) {
+		return Reply("You are already hidden."), nil
+	}
+
+	if !tryHide(h.repoManager, h.stealth, h.roll, ctx) {
+		return Reply("You try to hide, but you are noticed.").
+			ToRoom("", fmt.Sprintf("%s tries to hide in the shadows.", ctx.ActorName()), char.ID), nil
+	}
+
+	return Reply(append([]string{"You slip into the shadows."}, awardStealth(char, h.experience)...)...), nil
+}
+
+// SneakHandler moves like a normal step but tries to stay hidden on arrival.
+type SneakHandler struct {
+	repoManager interfaces.RepositoryManager
+	stealth     *stealth.Tracker
+	view        *roomViewer
+	experience  *experienceRules
+	// roll returns a number from 0 to n-1
+	roll func(n int) int
+}
+
+func (h *SneakHandler) Execute(ctx *HandlerContext, cmd *Command) (*CommandResult, error) {
+	char := ctx.Character
+	if char == nil {
+		return Reply("Error retrieving character information."), nil
+	}
+	if len(cmd.Args) == 0 {
+		return Reply("Sneak which way?"), nil
+	}
+	direction, ok := world.ParseDirection(cmd.Args[0])
+	if !ok {
+		return Reply("Sneak which way?"), nil
+	}
+
+	from := ctx.RoomID()
+	move := &MovementHandler{repoManager: h.repoManager, view: h.view, stealth: h.stealth, direction: direction, quiet: true}
+	result, err := move.Execute(ctx, cmd)
+	if err != nil || ctx.RoomID() == from {
+		return result, err
+	}
+
+	if !tryHide(h.repoManager, h.stealth, h.roll, ctx) {
+		return result.Add("You are noticed as you arrive.").
+			ToRoom(ctx.RoomID(), arriveMessage(ctx.ActorName(), direction), char.ID), nil
+	}
+	return result.Add("You arrive unseen.").Add(awardStealth(char, h.experience)...), nil
+}
@@ -0,0 +1,117 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+	"github.com/elidor/dungeogo/pkg/game/stealth"
+)
+
+func TestBreaksStealth(t *testing.T) {
+	parser := NewParser()
+	tests := map[string]bool{
+		"say hello":   true,
+		"kill rat":    true,
+		"smile":       true,
+		"north":       true,
+		"sneak north": false,
+		"look":        false,
+		"inventory":   false,
+	}
+	for input, expected := range tests {
+		if got := breaksStealth(parser.Parse(input, "player1", "char1")); got != expected {
+			t.Errorf("breaksStealth(%q) = %v, want %v", input, got, expected)
+		}
+	}
+}
+
+func TestSpeakingRevealsHiddenCharacter(t *testing.T) {
+	executor := NewExecutor(nil)
+	executor.stealth.Hide("char1")
+	ctx := &HandlerContext{Character: testCharacter(character.DefaultStartRoomID), Messenger: NopMessenger{}}
+
+	result, err := executor.Execute(ctx, NewParser().Parse("say hello", "player1", "char1"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.Messages[0] != "You step out of the shadows." {
+		t.Errorf("Expected to be revealed, got %v", result.Messages)
+	}
+	if executor.stealth.IsHidden("char1") {
+		t.Errorf("Expected char1 to no longer be hidden")
+	}
+}
+
+func TestHideNoticed(t *testing.T) {
+	handler := &HideHandler{stealth: stealth.NewTracker(), roll: func(n int) int { return n - 1 }}
+	ctx := &HandlerContext{Character: testCharacter(character.DefaultStartRoomID), Messenger: NopMessenger{}}
+
+	result, err := handler.Execute(ctx, &Command{Verb: "hide", CharacterID: "char1"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.Messages[0] != "You try to hide, but you are noticed." {
+		t.Errorf("Expected to be noticed, got %v", result.Messages)
+	}
+	if handler.stealth.IsHidden("char1") {
+		t.Errorf("Expected char1 not to be hidden")
+	}
+}
+
+func TestHideWhileHidden(t *testing.T) {
+	handler := &HideHandler{stealth: stealth.NewTracker()}
+	handler.stealth.Hide("char1")
+	ctx := &HandlerContext{Character: testCharacter(character.DefaultStartRoomID), Messenger: NopMessenger{}}
+
+	result, err := handler.Execute(ctx, &Command{Verb: "hide", CharacterID: "char1"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.Messages[0] != "You are already hidden." {
+		t.Errorf("Expected already hidden message, got %v", result.Messages)
+	}
+}
+
+func TestSneakWithoutDirection(t *testing.T) {
+	executor := NewExecutor(nil)
+	ctx := &HandlerContext{Character: testCharacter(character.DefaultStartRoomID), Messenger: NopMessenger{}}
+
+	for _, input := range []string{"sneak", "sneak sideways"} {
+		result, err := executor.Execute(ctx, NewParser().Parse(input, "player1", "char1"))
+		if err != nil {
+			t.Fatalf("Unexpected error for %q: %v", input, err)
+		}
+		if result.Messages[0] != "Sneak which way?" {
+			t.Errorf("Expected to be asked which way for %q, got %v", input, result.Messages)
+		}
+	}
+}
+
+func TestKillFromStealth(t *testing.T) {
+	executor, _ := newFightExecutor(t)
+	handler := executor.handlers["kill"]
+
+	race, _ := character.GetRaceByID("human")
+	class, _ := character.GetClassByID("rogue")
+	rogue := character.NewCharacter("player1", "Shade", race, class)
+	rogue.ID = "char1"
+	rogue.Location.RoomID = character.TutorialRoomID
+	rogue.State = character.CharacterInCombat
+
+	result, err := handler.Execute(&HandlerContext{Character: rogue}, &Command{Verb: "kill", Args: []string{"dummy"}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(result.Messages) != 1 {
+		t.Errorf("Expected a plain attack mid-fight, got %v", result.Messages)
+	}
+
+	executor.stealth.Hide("char1")
+	result, err = handler.Execute(&HandlerContext{Character: rogue}, &Command{Verb: "kill", Args: []string{"dummy"}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.Messages[0] != "You catch a training dummy off guard!" {
+		t.Errorf("Expected a sneak attack from stealth, got %v", result.Messages)
+	}
+}
@@ -0,0 +1,169 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+	"github.com/elidor/dungeogo/pkg/game/npc"
+	"github.com/elidor/dungeogo/pkg/game/stealth"
+	"github.com/elidor/dungeogo/pkg/persistence/interfaces"
+	"github.com/elidor/dungeogo/pkg/textutil"
+)
+
+// targetPronouns refer back to the last thing a character singled out
+var targetPronouns = map[string]bool{"it": true, "him": true, "her": true, "them": true}
+
+// isTargetPronoun reports whether name refers back to the last target
+func isTargetPronoun(name string) bool {
+	return targetPronouns[strings.ToLower(strings.TrimSpace(name))]
+}
+
+// target is an NPC or character singled out by a command. Exactly one of
+// the IDs is set.
+type target struct {
+	npcID       string
+	characterID string
+}
+
+// targetMemory remembers each character's last target, so pronouns such as
+// "it" can refer back to it. Targets do not outlast the server.
+type targetMemory struct {
+	mutex sync.Mutex
+	last  map[string]target
+}
+
+func newTargetMemory() *targetMemory {
+	return &targetMemory{last: make(map[string]target)}
+}
+
+func (m *targetMemory) remember(characterID string, t target) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.last[characterID] = t
+}
+
+func (m *targetMemory) recall(characterID string) (target, bool) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	t, ok := m.last[characterID]
+	return t, ok
+}
+
+func (m *targetMemory) forget(characterID string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	delete(m.last, characterID)
+}
+
+// forgetNPC clears npcID from everyone's memory, as when it dies.
+func (m *targetMemory) forgetNPC(npcID string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	for characterID, t := range m.last {
+		if t.npcID == npcID {
+			delete(m.last, characterID)
+		}
+	}
+}
+
+// targetResolver finds what a name given to a command refers to in the
+// acting character's room, remembering it for later pronouns.
+type targetResolver struct {
+	repoManager interfaces.RepositoryManager
+	npcs        *npc.Manager
+	stealth     *stealth.Tracker
+	memory      *targetMemory
+}
+
+// resolve returns the NPC or visible character in the actor's room that
+// name refers to, or neither. A pronoun refers to the remembered target,
+// which is forgotten once it has died or left the room.
+func (r *targetResolver) resolve(ctx *HandlerContext, name string) (*npc.NPC, *character.Character) {
+	char := ctx.Character
+	if isTargetPronoun(name) {
+		t, ok := r.memory.recall(char.ID)
+		if !ok {
+			return nil, nil
+		}
+		if t.npcID != "" {
+			if n := r.npcs.Get(t.npcID); n != nil && n.IsAlive() && n.RoomID == ctx.RoomID() {
+				return n, nil
+			}
+		} else if other := r.findCharacter(ctx, func(c *character.Character) bool { return c.ID == t.characterID }); other != nil {
+			return nil, other
+		}
+		r.memory.forget(char.ID)
+		return nil, nil
+	}
+
+	if n := r.npcs.Find(ctx.RoomID(), name); n != nil {
+		r.memory.remember(char.ID, target{npcID: n.ID})
+		return n, nil
+	}
+	other := r.findCharacter(ctx, func(c *character.Character) bool { return strings.EqualFold(c.Name, name) })
+	if other != nil {
+		r.memory.remember(char.ID, target{characterID: other.ID})
+	}
+	return nil, other
+}
+
+// findCharacter returns the first visible character in the actor's room
+// that matches.
+func (r *targetResolver) findCharacter(ctx *HandlerContext, match func(*character.Character) bool) *character.Character {
+	for _, other := range othersInRoom(r.repoManager, ctx) {
+		if match(other) && !r.stealth.IsHidden(other.ID) {
+			return other
+		}
+	}
+	return nil
+}
+
+// lookAt describes the NPC or character name refers to, adding how hurt an
+// NPC is when examining closely. It returns nil if name is not an NPC or
+// character in the room, unless name was a pronoun with nothing to refer to.
+func lookAt(r *targetResolver, ctx *HandlerContext, name string, closely bool) []string {
+	if ctx.Character == nil {
+		return []string{"Error retrieving character information."}
+	}
+
+	n, other := r.resolve(ctx, name)
+	switch {
+	case n != nil:
+		lines := []string{fmt.Sprintf("You look at %s.", n.Template.Name), n.Template.Description}
+		if closely {
+			lines = append(lines, fmt.Sprintf("%s %s.", textutil.Capitalize(n.Template.Name), npcCondition(n)))
+		}
+		return lines
+	case other != nil:
+		lines := []string{fmt.Sprintf("You look at %s.", other.DisplayName())}
+		if other.Race != nil && other.Class != nil {
+			lines = append(lines, fmt.Sprintf("%s is a level %d %s %s.", other.Name, other.Level, other.Race.Name, other.Class.Name))
+		}
+		if summary := other.Appearance.Summary(); summary != "" {
+			lines = append(lines, summary)
+		}
+		if other.Description != "" {
+			lines = append(lines, strings.Split(other.Description, "\n")...)
+		}
+		return lines
+	case isTargetPronoun(name):
+		return []string{fmt.Sprintf("You don't see %s here.", name)}
+	}
+	return nil
+}
+
+// npcCondition describes how hurt n is
+func npcCondition(n *npc.NPC) string {
+	switch percent := n.Health * 100 / max(n.Template.MaxHealth, 1); {
+	case percent >= 100:
+		return "is unhurt"
+	case percent >= 50:
+		return "is wounded"
+	case percent >= 20:
+		return "is badly wounded"
+	default:
+		return "is close to death"
+	}
+}
@@ -0,0 +1,61 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+)
+
+func TestKillIt(t *testing.T) {
+	executor, _ := newFightExecutor(t)
+	ctx := &HandlerContext{Character: testCharacter("riverbank")}
+	kill := func(target string) []string {
+		result, err := executor.handlers["kill"].Execute(ctx, &Command{Verb: "kill", Args: []string{target}})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		return result.Messages
+	}
+
+	if got := kill("it"); got[0] != "You don't see it here." {
+		t.Errorf("Expected no target before one is chosen, got %v", got)
+	}
+
+	kill("goblin")
+	first, _ := executor.targets.recall(ctx.Character.ID)
+	kill("it")
+	if again, _ := executor.targets.recall(ctx.Character.ID); again != first {
+		t.Errorf("Expected 'it' to stay on the same goblin")
+	}
+	if executor.NPCs().Get(first.npcID).Health == executor.NPCs().Get(first.npcID).Template.MaxHealth {
+		t.Errorf("Expected 'it' to hit the remembered goblin")
+	}
+
+	killUntilDead(t, executor, ctx, "it")
+	if _, ok := executor.targets.recall(ctx.Character.ID); ok {
+		t.Errorf("Expected the target to be forgotten once it died")
+	}
+	if got := kill("it"); got[0] != "You don't see it here." {
+		t.Errorf("Expected a dead target not to be found, got %v", got)
+	}
+}
+
+func TestTargetForgottenWhenLeavingRoom(t *testing.T) {
+	executor, _ := newFightExecutor(t)
+	char := testCharacter("riverbank")
+	ctx := &HandlerContext{Character: char}
+
+	result, _ := executor.handlers["examine"].Execute(ctx, &Command{Verb: "examine", Args: []string{"goblin"}})
+	if len(result.Messages) != 3 || result.Messages[2] != "A goblin is unhurt." {
+		t.Errorf("Unexpected examine output: %v", result.Messages)
+	}
+
+	char.Location.RoomID = character.DefaultStartRoomID
+	result, _ = executor.handlers["look"].Execute(ctx, &Command{Verb: "look", Args: []string{"it"}})
+	if result.Messages[0] != "You don't see it here." {
+		t.Errorf("Expected the goblin to be out of sight, got %v", result.Messages)
+	}
+	if _, ok := executor.targets.recall(char.ID); ok {
+		t.Errorf("Expected the target to be forgotten")
+	}
+}
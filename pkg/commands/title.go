@@ -0,0 +1,50 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+)
+
+// TitleHandler shows, sets or clears the title shown after a character's
+// name.
+type TitleHandler struct{}
+
+func (h *TitleHandler) Execute(ctx *HandlerContext, cmd *Command) (*CommandResult, error) {
+	char := ctx.Character
+	if char == nil {
+		return Reply("Error retrieving character information."), nil
+	}
+
+	if len(cmd.Args) == 0 {
+		if char.Title == "" {
+			return Reply("You have no title. Use 'title <text>' to choose one."), nil
+		}
+		return Reply(fmt.Sprintf("You are known as %s.", char.DisplayName())), nil
+	}
+
+	title := strings.Join(cmd.Args, " ")
+	if strings.EqualFold(title, "none") || strings.EqualFold(title, "clear") {
+		title = ""
+	} else if char.Level < character.TitleMinLevel {
+		return Reply(fmt.Sprintf("You must reach level %d before you can choose a title.", character.TitleMinLevel)), nil
+	}
+
+	if err := char.SetTitle(title); err != nil {
+		switch {
+		case errors.Is(err, character.ErrTitleTooLong):
+			return Reply(fmt.Sprintf("Titles may be at most %d characters long.", character.MaxTitleLength)), nil
+		case errors.Is(err, character.ErrTitleProfane):
+			return Reply("That title is not allowed here."), nil
+		default:
+			return Reply("Titles may only contain letters, numbers, spaces and punctuation."), nil
+		}
+	}
+
+	if char.Title == "" {
+		return Reply("Your title has been cleared."), nil
+	}
+	return Reply(fmt.Sprintf("You are now known as %s.", char.DisplayName())), nil
+}
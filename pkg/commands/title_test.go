@@ -0,0 +1,84 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+)
+
+func TestTitleCommand(t *testing.T) {
+	repos := newMemoryRepos()
+	executor := NewExecutor(repos)
+	parser := NewParser()
+	char := testCharacter(character.DefaultStartRoomID)
+	ctx := &HandlerContext{Character: char, Messenger: NopMessenger{}}
+
+	run := func(input string) string {
+		result, err := executor.Execute(ctx, parser.Parse(input, "player1", "char1"))
+		if err != nil {
+			t.Fatalf("Unexpected error for %q: %v", input, err)
+		}
+		return result.Messages[0]
+	}
+
+	if got := run("title the Brave"); got != "You must reach level 5 before you can choose a title." {
+		t.Errorf("Expected low level characters to be refused, got %q", got)
+	}
+
+	char.Level = character.TitleMinLevel
+	if got := run("title the Brave"); got != "You are now known as Alice the Brave." {
+		t.Errorf("Unexpected reply: %q", got)
+	}
+	if repos.characters.saves != 0 {
+		t.Errorf("Expected the engine to save the title, got %d handler saves", repos.characters.saves)
+	}
+	if got := run("title the Shit"); got != "That title is not allowed here." {
+		t.Errorf("Expected a profane title to be refused, got %q", got)
+	}
+	if got := run("title"); got != "You are known as Alice the Brave." {
+		t.Errorf("Unexpected reply: %q", got)
+	}
+	if got := run("title none"); got != "Your title has been cleared." || char.Title != "" {
+		t.Errorf("Expected the title to be cleared, got %q", got)
+	}
+}
+
+// onlineMessenger reports a fixed set of characters as online
+type onlineMessenger struct {
+	NopMessenger
+	ids []string
+}
+
+func (m onlineMessenger) OnlineCharacterIDs() []string { return m.ids }
+
+func TestWhoListsTitles(t *testing.T) {
+	repos := newMemoryRepos()
+	executor := NewExecutor(repos)
+	alice := testCharacter(character.DefaultStartRoomID)
+	alice.Title = "the Brave"
+	bob := testCharacter(character.DefaultStartRoomID)
+	bob.ID, bob.Name = "char2", "Bob"
+	repos.characters.stored = map[string]*character.Character{alice.ID: alice, bob.ID: bob}
+
+	ctx := &HandlerContext{Character: alice, Messenger: onlineMessenger{ids: []string{bob.ID, alice.ID}}}
+	result, err := executor.Execute(ctx, &Command{Verb: "who"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []string{
+		"Players currently online:",
+		"  Alice the Brave (Human Warrior, Level 1)",
+		"  Bob (Human Warrior, Level 1)",
+		"",
+		"2 players online.",
+	}
+	if len(result.Messages) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, result.Messages)
+	}
+	for i, line := range expected {
+		if result.Messages[i] != line {
+			t.Errorf("Line %d: expected %q, got %q", i, line, result.Messages[i])
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+	"github.com/elidor/dungeogo/pkg/game/npc"
+	"github.com/elidor/dungeogo/pkg/textutil"
+)
+
+// TrainHandler spends the attribute points characters earn with each level
+// raising their stats, which needs a trainer in the room. With no stat
+// named it shows the points left and how far each stat can be trained.
+type TrainHandler struct {
+	npcs *npc.Manager
+}
+
+func (h *TrainHandler) Execute(ctx *HandlerContext, cmd *Command) (*CommandResult, error) {
+	char := ctx.Character
+	if char == nil || char.Stats == nil {
+		return Reply("Error retrieving character information."), nil
+	}
+
+	if len(cmd.Args) == 0 {
+		return Reply(trainChoices(char)...), nil
+	}
+
+	stat, ok := character.StatByName(cmd.Args[0])
+	if !ok {
+		return Reply(fmt.Sprintf("There is no stat called '%s'. Type 'train' to see your stats.", cmd.Args[0])), nil
+	}
+	name := character.StatName(stat)
+
+	trainer := anyTrainer(h.npcs, ctx.RoomID())
+	if trainer == nil {
+		return Reply("You need to find a trainer to train your stats."), nil
+	}
+
+	value, err := char.TrainStat(stat)
+	if errors.Is(err, character.ErrNoStatPoints) {
+		return Reply("You have no attribute points left. Gain a level to earn more."), nil
+	}
+	if errors.Is(err, character.ErrStatCapped) {
+		return Reply(fmt.Sprintf("Your %s is as high as you can train it at your level (%d).", name, char.StatCap(stat))), nil
+	}
+	if err != nil {
+		return Reply("Error training stat."), nil
+	}
+
+	return Reply(
+		fmt.Sprintf("%s puts you through your paces. Your %s rises to %d.", textutil.Capitalize(trainer.Template.Name), name, value),
+		fmt.Sprintf("You have %d attribute points left.", char.StatPoints),
+	), nil
+}
+
+// trainChoices lists the character's stats against their caps, marking
+// those their class favours
+func trainChoices(char *character.Character) []string {
+	response := []string{fmt.Sprintf("You have %d attribute points to spend.", char.StatPoints)}
+	for i := range character.StatNames {
+		stat := character.StatType(i)
+		line := fmt.Sprintf("  %-14s %2d / %d", character.StatName(stat), char.Stats.Base(stat), char.StatCap(stat))
+		if char.Class.IsPrimaryStat(stat) {
+			line += "  (primary)"
+		}
+		response = append(response, line)
+	}
+	return append(response, "Usage: train <stat>")
+}
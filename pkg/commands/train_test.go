@@ -0,0 +1,56 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestTrainAtTrainer(t *testing.T) {
+	executor, _ := newFightExecutor(t)
+	char := testCharacter("riverbank")
+	ctx := &HandlerContext{Character: char}
+
+	train := func(args ...string) string {
+		result, err := executor.handlers["train"].Execute(ctx, &Command{Verb: "train", Args: args})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		return strings.Join(result.Messages, "\n")
+	}
+
+	char.StatPoints = 1
+	if got := train("strength"); got != "You need to find a trainer to train your stats." {
+		t.Errorf("Expected to need a trainer, got %q", got)
+	}
+
+	char.Location.RoomID = "tutorial_yard"
+	if got := train("luck"); got != "There is no stat called 'luck'. Type 'train' to see your stats." {
+		t.Errorf("Expected an unknown stat to be refused, got %q", got)
+	}
+
+	strength := char.Stats.Strength
+	expected := fmt.Sprintf("The arms trainer puts you through your paces. Your strength rises to %d.\nYou have 0 attribute points left.", strength+1)
+	if got := train("strength"); got != expected {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+	if got := train("strength"); got != "You have no attribute points left. Gain a level to earn more." {
+		t.Errorf("Expected to run out of points, got %q", got)
+	}
+
+	char.StatPoints = 1
+	char.Stats.Dexterity = 18
+	if got := train("dexterity"); got != "Your dexterity is as high as you can train it at your level (18)." {
+		t.Errorf("Expected dexterity to be capped, got %q", got)
+	}
+	if char.StatPoints != 1 {
+		t.Errorf("Expected a capped stat to cost nothing")
+	}
+
+	got := train()
+	for _, want := range []string{"You have 1 attribute points to spend.", "strength       11 / 20  (primary)", "dexterity      18 / 18", "Usage: train <stat>"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Expected %q in:\n%s", want, got)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+	"github.com/elidor/dungeogo/pkg/game/stealth"
+	"github.com/elidor/dungeogo/pkg/game/world"
+	"github.com/elidor/dungeogo/pkg/persistence/interfaces"
+)
+
+// WhereHandler lists online players grouped by the zone they are in. Hidden
+// players are left out, except that players always see themselves.
+type WhereHandler struct {
+	repoManager interfaces.RepositoryManager
+	stealth     *stealth.Tracker
+}
+
+func (h *WhereHandler) Execute(ctx *HandlerContext, cmd *Command) (*CommandResult, error) {
+	zones := make(map[string][]*character.Character)
+	total := 0
+	if ctx.Messenger != nil {
+		for _, id := range ctx.Messenger.OnlineCharacterIDs() {
+			if h.stealth.IsHidden(id) && (ctx.Character == nil || ctx.Character.ID != id) {
+				continue
+			}
+			char, err := h.repoManager.Characters().GetCharacter(id)
+			if err != nil {
+				continue
+			}
+			zones[char.Location.ZoneID] = append(zones[char.Location.ZoneID], char)
+			total++
+		}
+	}
+
+	zoneIDs := make([]string, 0, len(zones))
+	for zoneID := range zones {
+		zoneIDs = append(zoneIDs, zoneID)
+	}
+	sort.Slice(zoneIDs, func(i, j int) bool { return world.ZoneName(zoneIDs[i]) < world.ZoneName(zoneIDs[j]) })
+
+	result := Reply("Players by area:")
+	for _, zoneID := range zoneIDs {
+		players := zones[zoneID]
+		sort.Slice(players, func(i, j int) bool { return players[i].Name < players[j].Name })
+		result.Add(fmt.Sprintf("%s (%d):", world.ZoneName(zoneID), len(players)))
+		for _, char := range players {
+			place := "somewhere unknown"
+			if room, err := world.GetRoom(char.Location.RoomID); err == nil {
+				place = room.Name
+			}
+			result.Add(fmt.Sprintf("  %s - %s", char.DisplayName(), place))
+		}
+	}
+	result.Add("")
+	if total == 1 {
+		result.Add("1 player online.")
+	} else {
+		result.Add(fmt.Sprintf("%d players online.", total))
+	}
+	return result, nil
+}
@@ -0,0 +1,55 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+)
+
+func TestWhereGroupsPlayersByZone(t *testing.T) {
+	repos := newMemoryRepos()
+	executor := NewExecutor(repos)
+	alice := testCharacter(character.DefaultStartRoomID)
+	bob := testCharacter("riverbank")
+	bob.ID, bob.Name = "char2", "Bob"
+	carol := testCharacter(character.TutorialRoomID)
+	carol.ID, carol.Name = "char3", "Carol"
+	carol.Location.ZoneID = character.TutorialZoneID
+	dave := testCharacter("riverbank")
+	dave.ID, dave.Name = "char4", "Dave"
+	repos.characters.stored = map[string]*character.Character{
+		alice.ID: alice, bob.ID: bob, carol.ID: carol, dave.ID: dave,
+	}
+	executor.Stealth().Hide(dave.ID)
+
+	ctx := &HandlerContext{Character: alice, Messenger: onlineMessenger{ids: []string{dave.ID, carol.ID, bob.ID, alice.ID}}}
+	result, err := executor.Execute(ctx, &Command{Verb: "where"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []string{
+		"Players by area:",
+		"Riverside Village (2):",
+		"  Alice - A Simple Room",
+		"  Bob - Riverbank",
+		"The Training Grounds (1):",
+		"  Carol - A Quiet Training Ground",
+		"",
+		"3 players online.",
+	}
+	if len(result.Messages) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, result.Messages)
+	}
+	for i, line := range expected {
+		if result.Messages[i] != line {
+			t.Errorf("Line %d: expected %q, got %q", i, line, result.Messages[i])
+		}
+	}
+
+	ctx.Character = dave
+	result, _ = executor.Execute(ctx, &Command{Verb: "where"})
+	if last := result.Messages[len(result.Messages)-1]; last != "4 players online." {
+		t.Errorf("Expected hidden players to see themselves, got %q", last)
+	}
+}
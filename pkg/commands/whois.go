@@ -0,0 +1,66 @@
+package commands
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+	"github.com/elidor/dungeogo/pkg/game/world"
+	"github.com/elidor/dungeogo/pkg/persistence/interfaces"
+	"github.com/elidor/dungeogo/pkg/textutil"
+)
+
+// WhoisHandler shows what anyone may know about a character, online or
+// not. Staff also see the account behind them and where they are.
+type WhoisHandler struct {
+	repoManager interfaces.RepositoryManager
+	now         func() time.Time
+}
+
+func (h *WhoisHandler) Execute(ctx *HandlerContext, cmd *Command) (*CommandResult, error) {
+	char := ctx.Character
+	if char == nil {
+		return Reply("Error retrieving character information."), nil
+	}
+	if len(cmd.Args) == 0 {
+		return Reply("Usage: whois <character>"), nil
+	}
+
+	target, err := h.repoManager.Characters().GetCharacterByName(cmd.Args[0])
+	if err != nil {
+		return Reply(fmt.Sprintf("There is no character named '%s'.", cmd.Args[0])), nil
+	}
+
+	response := []string{
+		target.DisplayName(),
+		fmt.Sprintf("Level %d %s %s", target.Level, target.Race.Name, target.Class.Name),
+	}
+	if ctx.Messenger != nil && slices.Contains(ctx.Messenger.OnlineCharacterIDs(), target.ID) {
+		response = append(response, "Online now.")
+	} else {
+		response = append(response, fmt.Sprintf("Last seen %s.", textutil.RelativeTime(target.LastPlayed, h.now())))
+	}
+	if target.Description != "" {
+		response = append(response, target.Description)
+	}
+
+	requester, err := h.repoManager.Players().GetPlayer(char.PlayerID)
+	if err != nil || !requester.IsModerator() {
+		return Reply(response...), nil
+	}
+	return Reply(append(response, h.staffDetails(target)...)...), nil
+}
+
+// staffDetails are what only staff may see about target
+func (h *WhoisHandler) staffDetails(target *character.Character) []string {
+	details := []string{fmt.Sprintf("Created: %s", target.CreatedAt.Format("2006-01-02"))}
+	if target.Location != nil {
+		details = append(details, fmt.Sprintf("Location: %s (%s)", world.RoomName(target.Location.RoomID), target.Location.RoomID))
+	}
+	owner, err := h.repoManager.Players().GetPlayer(target.PlayerID)
+	if err != nil {
+		return append(details, "Account: unknown")
+	}
+	return append(details, fmt.Sprintf("Account: %s <%s>, %s, %s", owner.Username, owner.Email, owner.Role, owner.AccountStatus))
+}
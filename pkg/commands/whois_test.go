@@ -0,0 +1,87 @@
+package commands
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+	"github.com/elidor/dungeogo/pkg/game/player"
+)
+
+func newWhoisExecutor(t *testing.T) (*Executor, *memoryRepos, *HandlerContext) {
+	repos := newMemoryRepos()
+	executor := NewExecutor(repos)
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	executor.handlers["whois"].(*WhoisHandler).now = func() time.Time { return now }
+
+	bob := testCharacter("riverbank")
+	bob.ID, bob.Name, bob.Title = "char2", "Bob", "the Bold"
+	bob.Level = 4
+	bob.LastPlayed = now.Add(-3 * time.Hour)
+	repos.characters.stored = map[string]*character.Character{bob.ID: bob}
+
+	ctx := &HandlerContext{Character: testCharacter(character.DefaultStartRoomID), Messenger: NopMessenger{}}
+	return executor, repos, ctx
+}
+
+func whois(t *testing.T, executor *Executor, ctx *HandlerContext, name string) []string {
+	result, err := executor.handlers["whois"].Execute(ctx, &Command{Verb: "whois", Args: []string{name}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return result.Messages
+}
+
+func TestWhoisOfflineCharacter(t *testing.T) {
+	executor, _, ctx := newWhoisExecutor(t)
+
+	messages := whois(t, executor, ctx, "bob")
+	expected := []string{"Bob the Bold", "Level 4 Human Warrior", "Last seen 3 hours ago."}
+	if !slices.Equal(messages, expected) {
+		t.Errorf("Expected %v, got %v", expected, messages)
+	}
+
+	if messages := whois(t, executor, ctx, "carol"); messages[0] != "There is no character named 'carol'." {
+		t.Errorf("Expected an unknown name to be reported, got %v", messages)
+	}
+}
+
+func TestWhoisWithoutName(t *testing.T) {
+	executor, _, ctx := newWhoisExecutor(t)
+
+	result, err := executor.Execute(ctx, NewParser().Parse("whois", "player1", "char1"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.Messages[0] != "Usage: whois <character>" {
+		t.Errorf("Expected the usage to be shown, got %v", result.Messages)
+	}
+}
+
+func TestWhoisOnlineCharacter(t *testing.T) {
+	executor, _, ctx := newWhoisExecutor(t)
+	ctx.Messenger = onlineMessenger{ids: []string{"char2"}}
+
+	if messages := whois(t, executor, ctx, "Bob"); messages[2] != "Online now." {
+		t.Errorf("Expected Bob to be shown online, got %v", messages)
+	}
+}
+
+func TestWhoisStaffDetails(t *testing.T) {
+	executor, repos, ctx := newWhoisExecutor(t)
+
+	output := strings.Join(whois(t, executor, ctx, "bob"), "\n")
+	if strings.Contains(output, "Account") || strings.Contains(output, "Location") {
+		t.Errorf("Expected players not to see private details, got:\n%s", output)
+	}
+
+	repos.players.player.Role = player.RoleModerator
+	output = strings.Join(whois(t, executor, ctx, "bob"), "\n")
+	for _, want := range []string{"Location: Riverbank (riverbank)", "Account: alice <alice@example.com>, Moderator, Active"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected %q for staff in:\n%s", want, output)
+		}
+	}
+}
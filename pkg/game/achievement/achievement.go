@@ -0,0 +1,195 @@
+// Package achievement defines the milestones characters can earn, such as
+// a first kill or reaching a level, and tracks each character's progress
+// towards them.
+package achievement
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+var ErrAchievementNotFound = errors.New("achievement not found")
+
+// Trigger is the kind of game event that counts towards an achievement
+type Trigger int
+
+const (
+	// TriggerKill counts NPCs killed. Target, if set, is the NPC template.
+	TriggerKill Trigger = iota
+	// TriggerLevel is met by reaching level Count
+	TriggerLevel
+	// TriggerCraft counts items crafted. Target, if set, is the item
+	// template.
+	TriggerCraft
+	// TriggerDeath counts deaths
+	TriggerDeath
+)
+
+// Reward is what a character receives on earning an achievement
+type Reward struct {
+	// Title is given to a character without one; the rest are told they
+	// may take it
+	Title string
+	Gold  int
+	// Items are item template IDs, one of each
+	Items []string
+}
+
+// Achievement is a milestone earned when Trigger has happened Count times,
+// or for TriggerLevel, once the character reaches level Count.
+type Achievement struct {
+	ID          string
+	Name        string
+	Description string
+	Trigger     Trigger
+	Target      string
+	Count       int
+	Reward      Reward
+}
+
+// Matches reports whether an event of trigger involving target counts
+// towards the achievement
+func (a *Achievement) Matches(trigger Trigger, target string) bool {
+	return a.Trigger == trigger && (a.Target == "" || strings.EqualFold(a.Target, target))
+}
+
+// achievements holds every achievement, the built-in ones together with
+// any loaded from data files, by ID
+var achievements = struct {
+	sync.RWMutex
+	byID map[string]*Achievement
+}{byID: indexAchievements(getStandardAchievements())}
+
+func indexAchievements(list []*Achievement) map[string]*Achievement {
+	index := make(map[string]*Achievement, len(list))
+	for _, a := range list {
+		index[a.ID] = a
+	}
+	return index
+}
+
+func GetAchievementByID(id string) (*Achievement, error) {
+	achievements.RLock()
+	defer achievements.RUnlock()
+	if a, ok := achievements.byID[id]; ok {
+		return a, nil
+	}
+	return nil, ErrAchievementNotFound
+}
+
+// GetAllAchievements returns every achievement, ordered by name
+func GetAllAchievements() []*Achievement {
+	achievements.RLock()
+	list := make([]*Achievement, 0, len(achievements.byID))
+	for _, a := range achievements.byID {
+		list = append(list, a)
+	}
+	achievements.RUnlock()
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
+
+// Replace makes the achievements the built-in ones together with list,
+// whose entries take the place of any built-in achievement with the same
+// ID. Characters keep achievements they earned that are no longer defined,
+// but they are not shown.
+func Replace(list []*Achievement) *Changes {
+	replacement := indexAchievements(getStandardAchievements())
+	for _, a := range list {
+		replacement[a.ID] = a
+	}
+
+	achievements.Lock()
+	defer achievements.Unlock()
+	changes := &Changes{}
+	for id, a := range replacement {
+		previous, exists := achievements.byID[id]
+		if !exists {
+			changes.Added = append(changes.Added, id)
+		} else if !reflect.DeepEqual(previous, a) {
+			changes.Changed = append(changes.Changed, id)
+		}
+	}
+	for id := range achievements.byID {
+		if _, kept := replacement[id]; !kept {
+			changes.Removed = append(changes.Removed, id)
+		}
+	}
+	sort.Strings(changes.Added)
+	sort.Strings(changes.Changed)
+	sort.Strings(changes.Removed)
+	achievements.byID = replacement
+	return changes
+}
+
+// Changes lists the IDs of the achievements a Replace added, changed and
+// removed
+type Changes struct {
+	Added   []string
+	Changed []string
+	Removed []string
+}
+
+func (c *Changes) String() string {
+	var parts []string
+	for _, part := range []struct {
+		label string
+		ids   []string
+	}{{"Added", c.Added}, {"Changed", c.Changed}, {"Removed", c.Removed}} {
+		if len(part.ids) > 0 {
+			parts = append(parts, part.label+": "+strings.Join(part.ids, ", ")+".")
+		}
+	}
+	if len(parts) == 0 {
+		return "Nothing changed."
+	}
+	return strings.Join(parts, " ")
+}
+
+func getStandardAchievements() []*Achievement {
+	return []*Achievement{
+		{
+			ID:          "first_blood",
+			Name:        "First Blood",
+			Description: "Kill your first foe",
+			Trigger:     TriggerKill,
+			Count:       1,
+			Reward:      Reward{Gold: 10},
+		},
+		{
+			ID:          "goblin_bane",
+			Name:        "Goblin Bane",
+			Description: "Kill 25 goblins",
+			Trigger:     TriggerKill,
+			Target:      "goblin",
+			Count:       25,
+			Reward:      Reward{Title: "the Goblin Bane", Gold: 50},
+		},
+		{
+			ID:          "seasoned",
+			Name:        "Seasoned Adventurer",
+			Description: "Reach level 10",
+			Trigger:     TriggerLevel,
+			Count:       10,
+			Reward:      Reward{Gold: 100, Items: []string{"health_potion"}},
+		},
+		{
+			ID:          "artisan",
+			Name:        "Artisan",
+			Description: "Craft 100 items",
+			Trigger:     TriggerCraft,
+			Count:       100,
+			Reward:      Reward{Title: "the Artisan"},
+		},
+		{
+			ID:          "hard_lessons",
+			Name:        "Hard Lessons",
+			Description: "Die 10 times",
+			Trigger:     TriggerDeath,
+			Count:       10,
+		},
+	}
+}
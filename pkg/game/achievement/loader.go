@@ -0,0 +1,165 @@
+package achievement
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// DefaultPath is where the server looks for achievement files unless told
+// otherwise
+const DefaultPath = "data/achievements"
+
+var ErrInvalidAchievement = errors.New("invalid achievement")
+
+var triggerNames = map[string]Trigger{
+	"kill":  TriggerKill,
+	"level": TriggerLevel,
+	"craft": TriggerCraft,
+	"death": TriggerDeath,
+}
+
+// achievementData is an achievement as written in a data file, with its
+// trigger given by name
+type achievementData struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Trigger     string `json:"trigger"`
+	Target      string `json:"target"`
+	Count       int    `json:"count"`
+	Reward      struct {
+		Title string   `json:"title"`
+		Gold  int      `json:"gold"`
+		Items []string `json:"items"`
+	} `json:"reward"`
+}
+
+// Load reads achievements from path, which is either a JSON file holding a
+// list of achievements or a directory of such files. A missing path yields
+// none. itemExists reports whether an item template exists, to check
+// rewards; if nil, they are not checked. Every malformed entry is
+// reported, by file and position.
+func Load(path string, itemExists func(templateID string) bool) ([]*Achievement, error) {
+	if path == "" {
+		return nil, nil
+	}
+	info, err := os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read achievements: %w", err)
+	}
+
+	files := []string{path}
+	if info.IsDir() {
+		if files, err = filepath.Glob(filepath.Join(path, "*.json")); err != nil {
+			return nil, fmt.Errorf("failed to list achievement files: %w", err)
+		}
+		sort.Strings(files)
+	}
+
+	var list []*Achievement
+	var problems []error
+	seen := make(map[string]string)
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read achievements: %w", err)
+		}
+		var entries []achievementData
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&entries); err != nil {
+			problems = append(problems, fmt.Errorf("%s: %w", file, err))
+			continue
+		}
+		for i, entry := range entries {
+			a, err := entry.achievement(itemExists)
+			if err == nil && seen[entry.ID] != "" {
+				err = fmt.Errorf("%w: already defined in %s", ErrInvalidAchievement, seen[entry.ID])
+			}
+			if err != nil {
+				problems = append(problems, fmt.Errorf("%s: entry %d (%q): %w", file, i+1, entry.ID, err))
+				continue
+			}
+			seen[entry.ID] = file
+			list = append(list, a)
+		}
+	}
+	if len(problems) > 0 {
+		return nil, errors.Join(problems...)
+	}
+	return list, nil
+}
+
+// achievement checks an entry and turns it into an Achievement. Entries
+// need an ID, a name, a trigger and a count above zero.
+func (d *achievementData) achievement(itemExists func(string) bool) (*Achievement, error) {
+	if d.ID == "" || d.Name == "" {
+		return nil, fmt.Errorf("%w: an id and a name are required", ErrInvalidAchievement)
+	}
+	trigger, ok := triggerNames[strings.ToLower(d.Trigger)]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown trigger %q", ErrInvalidAchievement, d.Trigger)
+	}
+	if d.Count <= 0 {
+		return nil, fmt.Errorf("%w: count must be above zero", ErrInvalidAchievement)
+	}
+	if d.Reward.Gold < 0 {
+		return nil, fmt.Errorf("%w: gold can't be negative", ErrInvalidAchievement)
+	}
+	for _, templateID := range d.Reward.Items {
+		if templateID == "" || (itemExists != nil && !itemExists(templateID)) {
+			return nil, fmt.Errorf("%w: unknown reward item %q", ErrInvalidAchievement, templateID)
+		}
+	}
+	return &Achievement{
+		ID:          d.ID,
+		Name:        d.Name,
+		Description: d.Description,
+		Trigger:     trigger,
+		Target:      d.Target,
+		Count:       d.Count,
+		Reward: Reward{
+			Title: strings.Join(strings.Fields(d.Reward.Title), " "),
+			Gold:  d.Reward.Gold,
+			Items: d.Reward.Items,
+		},
+	}, nil
+}
+
+// Loader keeps the achievements in step with the data files at a path
+type Loader struct {
+	path       string
+	itemExists func(templateID string) bool
+}
+
+func NewLoader(path string, itemExists func(templateID string) bool) *Loader {
+	return &Loader{path: path, itemExists: itemExists}
+}
+
+// Load reads the files and, if every achievement in them is valid, swaps
+// them in
+func (l *Loader) Load() (*Changes, error) {
+	list, err := Load(l.path, l.itemExists)
+	if err != nil {
+		return nil, err
+	}
+	return Replace(list), nil
+}
+
+// Reload loads the files again while the server runs and says what changed
+func (l *Loader) Reload() (string, error) {
+	changes, err := l.Load()
+	if err != nil {
+		return "", err
+	}
+	return "Achievements reloaded. " + changes.String(), nil
+}
@@ -0,0 +1,76 @@
+package achievement
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeData writes content to name in a new directory and returns the
+// directory, restoring the built-in achievements after the test
+func writeData(t *testing.T, name, content string) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write %s: %v", name, err)
+	}
+	t.Cleanup(func() { Replace(nil) })
+	return dir
+}
+
+func TestLoader(t *testing.T) {
+	dir := writeData(t, "achievements.json", `[
+		{"id": "rat_catcher", "name": "Rat Catcher", "description": "Kill 10 giant rats",
+			"trigger": "kill", "target": "giant_rat", "count": 10,
+			"reward": {"title": "the  Rat Catcher", "gold": 20, "items": ["health_potion"]}},
+		{"id": "first_blood", "name": "First Blood", "description": "Kill your first foe",
+			"trigger": "kill", "count": 1, "reward": {"gold": 25}}
+	]`)
+	itemExists := func(templateID string) bool { return templateID == "health_potion" }
+
+	changes, err := NewLoader(dir, itemExists).Load()
+	if err != nil {
+		t.Fatalf("Failed to load achievements: %v", err)
+	}
+	if changes.String() != "Added: rat_catcher. Changed: first_blood." {
+		t.Errorf("Unexpected changes %q", changes)
+	}
+	catcher, err := GetAchievementByID("rat_catcher")
+	if err != nil {
+		t.Fatalf("Expected Rat Catcher to be defined, got %v", err)
+	}
+	if catcher.Trigger != TriggerKill || catcher.Count != 10 || catcher.Reward.Title != "the Rat Catcher" {
+		t.Errorf("Unexpected achievement %+v", catcher)
+	}
+	if earned := earnedIDs(NewLog().Record(TriggerKill, "giant_rat", 10, time.Now())); !slices.Contains(earned, "rat_catcher") {
+		t.Errorf("Expected ten rats to earn Rat Catcher, got %v", earned)
+	}
+	if blood, _ := GetAchievementByID("first_blood"); blood.Reward.Gold != 25 {
+		t.Errorf("Expected the file to override First Blood, got %+v", blood.Reward)
+	}
+}
+
+func TestLoaderRejectsBadEntries(t *testing.T) {
+	dir := writeData(t, "achievements.json", `[
+		{"id": "a", "name": "A", "trigger": "dance", "count": 1},
+		{"id": "b", "name": "B", "trigger": "kill", "count": 0},
+		{"id": "c", "name": "C", "trigger": "kill", "count": 1, "reward": {"items": ["crown"]}}
+	]`)
+
+	_, err := NewLoader(dir, func(string) bool { return false }).Load()
+	if !errors.Is(err, ErrInvalidAchievement) {
+		t.Fatalf("Expected ErrInvalidAchievement, got %v", err)
+	}
+	for _, want := range []string{`unknown trigger "dance"`, "count must be above zero", `unknown reward item "crown"`} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected %q in %v", want, err)
+		}
+	}
+	if _, err := GetAchievementByID("a"); err == nil {
+		t.Errorf("Expected a bad file to change nothing")
+	}
+}
@@ -0,0 +1,67 @@
+package achievement
+
+import "time"
+
+// Log records a character's progress towards achievements and the ones
+// they have earned.
+type Log struct {
+	// Counts is progress towards each unearned achievement, by ID
+	Counts map[string]int
+	// Earned is when each earned achievement was earned, by ID
+	Earned map[string]time.Time
+}
+
+func NewLog() *Log {
+	return &Log{
+		Counts: make(map[string]int),
+		Earned: make(map[string]time.Time),
+	}
+}
+
+func (l *Log) ensure() {
+	if l.Counts == nil {
+		l.Counts = make(map[string]int)
+	}
+	if l.Earned == nil {
+		l.Earned = make(map[string]time.Time)
+	}
+}
+
+// IsEarned reports whether the achievement with id has been earned
+func (l *Log) IsEarned(id string) bool {
+	_, ok := l.Earned[id]
+	return ok
+}
+
+// Progress returns how far the character is towards a, capped at its Count
+func (l *Log) Progress(a *Achievement) int {
+	if l.IsEarned(a.ID) {
+		return a.Count
+	}
+	return min(l.Counts[a.ID], a.Count)
+}
+
+// Record counts an event of trigger involving target towards every
+// matching achievement not yet earned. For TriggerLevel, value is the level
+// reached; for other triggers it is how many times the event happened. It
+// returns the achievements earned by it.
+func (l *Log) Record(trigger Trigger, target string, value int, now time.Time) []*Achievement {
+	l.ensure()
+	var earned []*Achievement
+	for _, a := range GetAllAchievements() {
+		if l.IsEarned(a.ID) || !a.Matches(trigger, target) {
+			continue
+		}
+		if trigger == TriggerLevel {
+			l.Counts[a.ID] = max(l.Counts[a.ID], value)
+		} else {
+			l.Counts[a.ID] += value
+		}
+		if l.Counts[a.ID] >= a.Count {
+			delete(l.Counts, a.ID)
+			l.Earned[a.ID] = now
+			earned = append(earned, a)
+		}
+	}
+	return earned
+}
@@ -0,0 +1,76 @@
+package achievement
+
+import (
+	"testing"
+	"time"
+)
+
+func earnedIDs(list []*Achievement) []string {
+	ids := make([]string, len(list))
+	for i, a := range list {
+		ids[i] = a.ID
+	}
+	return ids
+}
+
+func TestRecordKills(t *testing.T) {
+	log := NewLog()
+	now := time.Now()
+
+	earned := log.Record(TriggerKill, "rat", 1, now)
+	if len(earned) != 1 || earned[0].ID != "first_blood" {
+		t.Fatalf("Expected the first kill to earn First Blood, got %v", earnedIDs(earned))
+	}
+	if !log.Earned["first_blood"].Equal(now) {
+		t.Errorf("Expected First Blood to be dated, got %v", log.Earned["first_blood"])
+	}
+
+	bane, _ := GetAchievementByID("goblin_bane")
+	if got := log.Progress(bane); got != 0 {
+		t.Errorf("Expected a rat not to count towards Goblin Bane, got %d", got)
+	}
+	for i := 1; i < bane.Count; i++ {
+		if earned := log.Record(TriggerKill, "goblin", 1, now); len(earned) != 0 {
+			t.Fatalf("Expected nothing earned after %d goblins, got %v", i, earnedIDs(earned))
+		}
+	}
+	if got := log.Progress(bane); got != bane.Count-1 {
+		t.Errorf("Expected %d goblins counted, got %d", bane.Count-1, got)
+	}
+	if earned := log.Record(TriggerKill, "Goblin", 1, now); len(earned) != 1 || earned[0].ID != "goblin_bane" {
+		t.Errorf("Expected the last goblin to earn Goblin Bane, got %v", earnedIDs(earned))
+	}
+
+	// Earned achievements are not earned again
+	if earned := log.Record(TriggerKill, "goblin", 1, now); len(earned) != 0 {
+		t.Errorf("Expected nothing more to earn, got %v", earnedIDs(earned))
+	}
+	if got := log.Progress(bane); got != bane.Count {
+		t.Errorf("Expected an earned achievement to show full progress, got %d", got)
+	}
+}
+
+func TestRecordLevel(t *testing.T) {
+	log := NewLog()
+	seasoned, _ := GetAchievementByID("seasoned")
+
+	log.Record(TriggerLevel, "", 4, time.Now())
+	log.Record(TriggerLevel, "", 3, time.Now())
+	if got := log.Progress(seasoned); got != 4 {
+		t.Errorf("Expected the highest level reached as progress, got %d", got)
+	}
+	if earned := log.Record(TriggerLevel, "", 12, time.Now()); len(earned) != 1 || earned[0].ID != "seasoned" {
+		t.Errorf("Expected level 12 to earn Seasoned Adventurer, got %v", earnedIDs(earned))
+	}
+}
+
+func TestZeroLogRecords(t *testing.T) {
+	// Logs loaded from characters saved before achievements have no maps
+	var log Log
+	if earned := log.Record(TriggerDeath, "", 1, time.Now()); len(earned) != 0 {
+		t.Errorf("Expected one death to earn nothing, got %v", earnedIDs(earned))
+	}
+	if log.Counts["hard_lessons"] != 1 {
+		t.Errorf("Expected the death counted, got %v", log.Counts)
+	}
+}
@@ -0,0 +1,147 @@
+package character
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+
+	"github.com/elidor/dungeogo/pkg/textutil"
+)
+
+const (
+	// MaxAppearanceLength is the longest an appearance field may be, in runes
+	MaxAppearanceLength = 30
+	// MaxDescriptionLength is the longest a description may be, in runes
+	MaxDescriptionLength = 800
+	// MaxDescriptionLines is the most lines a description may have
+	MaxDescriptionLines = 10
+	// MaxProfileLength is the longest a public profile may be, in runes
+	MaxProfileLength = 300
+)
+
+var (
+	ErrUnknownAppearanceField = errors.New("unknown appearance field")
+	ErrAppearanceTooLong      = errors.New("appearance is too long")
+	ErrDescriptionTooLong     = errors.New("description is too long")
+	ErrProfileTooLong         = errors.New("profile is too long")
+	ErrAppearanceInvalid      = errors.New("appearance may only contain printable characters")
+	ErrAppearanceProfane      = errors.New("appearance contains language that is not allowed")
+)
+
+// AppearanceFields are the names players use for each appearance field, in
+// the order they are shown.
+var AppearanceFields = []string{"height", "weight", "build", "eyes", "hair", "skin"}
+
+// field returns the appearance field called name
+func (a *CharacterAppearance) field(name string) (*string, error) {
+	switch name {
+	case "height":
+		return &a.Height, nil
+	case "weight":
+		return &a.Weight, nil
+	case "build":
+		return &a.Build, nil
+	case "eyes":
+		return &a.EyeColor, nil
+	case "hair":
+		return &a.HairColor, nil
+	case "skin":
+		return &a.SkinColor, nil
+	}
+	return nil, ErrUnknownAppearanceField
+}
+
+// Get returns the value of the appearance field called name.
+func (a *CharacterAppearance) Get(name string) (string, error) {
+	field, err := a.field(name)
+	if err != nil {
+		return "", err
+	}
+	return *field, nil
+}
+
+// Set validates value and stores it in the appearance field called name. An
+// empty value clears the field.
+func (a *CharacterAppearance) Set(name, value string) error {
+	field, err := a.field(name)
+	if err != nil {
+		return err
+	}
+	value = strings.Join(strings.Fields(value), " ")
+	if len([]rune(value)) > MaxAppearanceLength {
+		return ErrAppearanceTooLong
+	}
+	if err := checkText(value); err != nil {
+		return err
+	}
+	*field = value
+	return nil
+}
+
+// Summary describes the fields that have been set, as in
+// "Height: tall. Eyes: green.", or returns "" if none have.
+func (a *CharacterAppearance) Summary() string {
+	var parts []string
+	for _, name := range AppearanceFields {
+		if value, _ := a.Get(name); value != "" {
+			parts = append(parts, textutil.Capitalize(name)+": "+value+".")
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// SetDescription validates the lines of a description and gives it to the
+// character. Surrounding spaces are trimmed from each line and blank lines
+// at either end are dropped. No lines clears the description.
+func (c *Character) SetDescription(lines []string) error {
+	var kept []string
+	for _, line := range lines {
+		kept = append(kept, strings.TrimSpace(line))
+	}
+	for len(kept) > 0 && kept[0] == "" {
+		kept = kept[1:]
+	}
+	for len(kept) > 0 && kept[len(kept)-1] == "" {
+		kept = kept[:len(kept)-1]
+	}
+
+	description := strings.Join(kept, "\n")
+	if len(kept) > MaxDescriptionLines || len([]rune(description)) > MaxDescriptionLength {
+		return ErrDescriptionTooLong
+	}
+	for _, line := range kept {
+		if err := checkText(line); err != nil {
+			return err
+		}
+	}
+	c.Description = description
+	return nil
+}
+
+// SetProfile validates the public profile others see when they finger the
+// character and gives it to them. Runs of spaces are collapsed, and an
+// empty profile clears it.
+func (c *Character) SetProfile(profile string) error {
+	profile = strings.Join(strings.Fields(profile), " ")
+	if len([]rune(profile)) > MaxProfileLength {
+		return ErrProfileTooLong
+	}
+	if err := checkText(profile); err != nil {
+		return err
+	}
+	c.Profile = profile
+	return nil
+}
+
+// checkText rejects text that is unprintable or profane
+func checkText(text string) error {
+	for _, r := range text {
+		if !unicode.IsPrint(r) {
+			return ErrAppearanceInvalid
+		}
+	}
+	if textutil.ContainsProfanity(text) {
+		return ErrAppearanceProfane
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package character
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestAppearanceSet(t *testing.T) {
+	var a CharacterAppearance
+	if err := a.Set("eyes", "  deep   green "); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if a.EyeColor != "deep green" {
+		t.Errorf("Expected spaces to be collapsed, got %q", a.EyeColor)
+	}
+	if err := a.Set("height", "tall"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := a.Summary(); got != "Height: tall. Eyes: deep green." {
+		t.Errorf("Unexpected summary: %q", got)
+	}
+
+	if err := a.Set("tail", "long"); !errors.Is(err, ErrUnknownAppearanceField) {
+		t.Errorf("Expected ErrUnknownAppearanceField, got %v", err)
+	}
+	if err := a.Set("hair", strings.Repeat("x", MaxAppearanceLength+1)); !errors.Is(err, ErrAppearanceTooLong) {
+		t.Errorf("Expected ErrAppearanceTooLong, got %v", err)
+	}
+	if err := a.Set("hair", "red\x1b[31m"); !errors.Is(err, ErrAppearanceInvalid) {
+		t.Errorf("Expected ErrAppearanceInvalid, got %v", err)
+	}
+	if err := a.Set("eyes", ""); err != nil || a.EyeColor != "" {
+		t.Errorf("Expected an empty value to clear the field, got %q (%v)", a.EyeColor, err)
+	}
+}
+
+func TestSetDescription(t *testing.T) {
+	c := &Character{}
+	if err := c.SetDescription([]string{"", "  A wiry figure. ", "", "Scarred hands.", ""}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if c.Description != "A wiry figure.\n\nScarred hands." {
+		t.Errorf("Unexpected description: %q", c.Description)
+	}
+
+	lines := make([]string, MaxDescriptionLines+1)
+	for i := range lines {
+		lines[i] = "line"
+	}
+	if err := c.SetDescription(lines); !errors.Is(err, ErrDescriptionTooLong) {
+		t.Errorf("Expected ErrDescriptionTooLong, got %v", err)
+	}
+	if err := c.SetDescription([]string{"What the shit"}); !errors.Is(err, ErrAppearanceProfane) {
+		t.Errorf("Expected ErrAppearanceProfane, got %v", err)
+	}
+	if c.Description != "A wiry figure.\n\nScarred hands." {
+		t.Errorf("Expected a rejected description to leave the old one, got %q", c.Description)
+	}
+	if err := c.SetDescription(nil); err != nil || c.Description != "" {
+		t.Errorf("Expected no lines to clear the description, got %q (%v)", c.Description, err)
+	}
+}
+
+func TestSetProfile(t *testing.T) {
+	var c Character
+	if err := c.SetProfile("  Smith by trade,   adventurer   by accident. "); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if c.Profile != "Smith by trade, adventurer by accident." {
+		t.Errorf("Expected spaces to be collapsed, got %q", c.Profile)
+	}
+
+	if err := c.SetProfile(strings.Repeat("x", MaxProfileLength+1)); !errors.Is(err, ErrProfileTooLong) {
+		t.Errorf("Expected ErrProfileTooLong, got %v", err)
+	}
+	if err := c.SetProfile("bell\a"); !errors.Is(err, ErrAppearanceInvalid) {
+		t.Errorf("Expected ErrAppearanceInvalid, got %v", err)
+	}
+	if c.Profile != "Smith by trade, adventurer by accident." {
+		t.Errorf("Expected a refused profile to leave the old one, got %q", c.Profile)
+	}
+
+	if err := c.SetProfile(""); err != nil || c.Profile != "" {
+		t.Errorf("Expected the profile to be cleared, got %q (%v)", c.Profile, err)
+	}
+}
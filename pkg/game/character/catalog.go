@@ -0,0 +1,147 @@
+package character
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// catalog holds the races and classes characters can be, the built-in ones
+// together with any loaded from data files. Lookups hand out copies, so
+// nothing outside can change what is in it.
+var catalog = struct {
+	sync.RWMutex
+	races   map[string]*Race
+	classes map[string]*Class
+}{races: getStandardRaces(), classes: getStandardClasses()}
+
+// RaceIDs returns the ID of every race characters can be, sorted
+func RaceIDs() []string {
+	catalog.RLock()
+	defer catalog.RUnlock()
+	return slices.Sorted(maps.Keys(catalog.races))
+}
+
+// ClassIDs returns the ID of every class characters can be, sorted
+func ClassIDs() []string {
+	catalog.RLock()
+	defer catalog.RUnlock()
+	return slices.Sorted(maps.Keys(catalog.classes))
+}
+
+// KitTemplateIDs returns the ID of every item template a class gives as
+// starting kit, sorted
+func KitTemplateIDs() []string {
+	catalog.RLock()
+	defer catalog.RUnlock()
+	var ids []string
+	for _, class := range catalog.classes {
+		for _, kit := range class.StartingKit {
+			ids = append(ids, kit.TemplateID)
+		}
+	}
+	slices.Sort(ids)
+	return slices.Compact(ids)
+}
+
+// CatalogChanges lists the IDs of the races or classes a replace added,
+// changed and removed
+type CatalogChanges struct {
+	Added   []string
+	Changed []string
+	Removed []string
+}
+
+func (c *CatalogChanges) String() string {
+	var parts []string
+	for _, part := range []struct {
+		label string
+		ids   []string
+	}{{"Added", c.Added}, {"Changed", c.Changed}, {"Removed", c.Removed}} {
+		if len(part.ids) > 0 {
+			parts = append(parts, part.label+": "+strings.Join(part.ids, ", ")+".")
+		}
+	}
+	if len(parts) == 0 {
+		return "Nothing changed."
+	}
+	return strings.Join(parts, " ")
+}
+
+// ReplaceRaces makes the races characters can be the built-in ones together
+// with races, which take the place of any built-in race with the same ID
+func ReplaceRaces(races []*Race) *CatalogChanges {
+	replacement := getStandardRaces()
+	for _, race := range races {
+		replacement[race.ID] = race.clone()
+	}
+
+	catalog.Lock()
+	defer catalog.Unlock()
+	changes := compareCatalog(catalog.races, replacement)
+	catalog.races = replacement
+	return changes
+}
+
+// ReplaceClasses makes the classes characters can be the built-in ones
+// together with classes, which take the place of any built-in class with the
+// same ID
+func ReplaceClasses(classes []*Class) *CatalogChanges {
+	replacement := getStandardClasses()
+	for _, class := range classes {
+		replacement[class.ID] = class.clone()
+	}
+
+	catalog.Lock()
+	defer catalog.Unlock()
+	changes := compareCatalog(catalog.classes, replacement)
+	catalog.classes = replacement
+	return changes
+}
+
+// compareCatalog lists what differs between the old and new entries
+func compareCatalog[T any](old, replacement map[string]T) *CatalogChanges {
+	changes := &CatalogChanges{}
+	for id, entry := range replacement {
+		previous, exists := old[id]
+		if !exists {
+			changes.Added = append(changes.Added, id)
+		} else if !reflect.DeepEqual(previous, entry) {
+			changes.Changed = append(changes.Changed, id)
+		}
+	}
+	for id := range old {
+		if _, kept := replacement[id]; !kept {
+			changes.Removed = append(changes.Removed, id)
+		}
+	}
+	sort.Strings(changes.Added)
+	sort.Strings(changes.Changed)
+	sort.Strings(changes.Removed)
+	return changes
+}
+
+func (r *Race) clone() *Race {
+	race := *r
+	race.SkillBonuses = maps.Clone(r.SkillBonuses)
+	race.Abilities = slices.Clone(r.Abilities)
+	race.Resistances = maps.Clone(r.Resistances)
+	return &race
+}
+
+func (c *Class) clone() *Class {
+	class := *c
+	class.PrimaryStats = slices.Clone(c.PrimaryStats)
+	class.WeaponProficiencies = slices.Clone(c.WeaponProficiencies)
+	class.ArmorProficiencies = slices.Clone(c.ArmorProficiencies)
+	class.StartingKit = slices.Clone(c.StartingKit)
+	class.Abilities = make([]ClassAbility, len(c.Abilities))
+	for i, ability := range c.Abilities {
+		ability.Requirements = slices.Clone(ability.Requirements)
+		class.Abilities[i] = ability
+	}
+	return &class
+}
@@ -2,6 +2,13 @@ package character
 
 import (
 	"time"
+	
+	"github.com/google/uuid"
+	
+	"github.com/elidor/dungeogo/pkg/game/achievement"
+	"github.com/elidor/dungeogo/pkg/game/items"
+	"github.com/elidor/dungeogo/pkg/game/magic"
+	"github.com/elidor/dungeogo/pkg/game/quest"
 )
 
 type Character struct {
@@ -22,9 +29,48 @@ type Character struct {
 	DeathCount  int
 	KillCount   int
 	Description string
+	// Profile is the short public biography shown to anyone who fingers
+	// the character
+	Profile     string
+	// Title is shown after the character's name, as in "Bob the Brave"
+	Title       string
 	Appearance  CharacterAppearance
+	Gold        int
+	Quests      *quest.Log
+	// Achievements tracks the milestones the character has earned or is
+	// working towards
+	Achievements *achievement.Log
+	// Spellbook holds the spells the character has learned and prepared
+	Spellbook   *magic.Spellbook
+	Equipment   map[items.EquipSlot]*items.ItemInstance
+	// TutorialStep is the current onboarding step, or 0 once the tutorial
+	// is finished or skipped.
+	TutorialStep int
+	// Explored holds the IDs of the rooms the character has been to
+	Explored map[string]bool
+	// Reputation maps faction IDs to the character's reputation with them.
+	// Factions missing from it start at their initial reputation.
+	Reputation map[string]int
+	// Practices is how many practice sessions the character has left to
+	// spend with trainers
+	Practices int
+	// StatPoints is how many attribute points the character has left to
+	// spend raising their stats with trainers
+	StatPoints int
+	// PvP is whether the character has chosen to fight other players, on
+	// servers where that is opt-in
+	PvP bool
 }
 
+const (
+	DefaultStartRoomID = "starting_room"
+	DefaultStartZoneID = "newbie_zone"
+)
+
+// carryPerStrength is how much weight each point of Strength lets a
+// character carry
+const carryPerStrength = 5.0
+
 type CharacterState int
 
 const (
@@ -55,6 +101,10 @@ type CharacterStats struct {
 	MaxMana      int
 	Stamina      int
 	MaxStamina   int
+	// Modifiers adjust the stats above for as long as their source lasts.
+	// Those from equipment are worked out again whenever it changes, so
+	// they are not saved.
+	Modifiers    []StatModifier `json:"-"`
 }
 
 type CharacterAppearance struct {
@@ -70,7 +120,12 @@ type CharacterAppearance struct {
 func NewCharacter(playerID, name string, race *Race, class *Class) *Character {
 	stats := calculateStartingStats(race, class)
 	
-	return &Character{
+	c := &Character{
+		ID:          uuid.New().String(),
+		Quests:      quest.NewLog(),
+		Achievements: achievement.NewLog(),
+		Spellbook:   magic.NewSpellbook(),
+		Equipment:   make(map[items.EquipSlot]*items.ItemInstance),
 		PlayerID:    playerID,
 		Name:        name,
 		Race:        race,
@@ -83,11 +138,14 @@ func NewCharacter(playerID, name string, race *Race, class *Class) *Character {
 		Experience:  0,
 		DeathCount:  0,
 		KillCount:   0,
+		Practices:   PracticesPerLevel,
 		Location: &Location{
-			RoomID: "starting_room",
-			ZoneID: "newbie_zone",
+			RoomID: DefaultStartRoomID,
+			ZoneID: DefaultStartZoneID,
 		},
 	}
+	c.LearnSpells()
+	return c
 }
 
 func (c *Character) IsAlive() bool {
@@ -98,11 +156,26 @@ func (c *Character) IsDead() bool {
 	return c.State == CharacterDead || c.Stats.Health <= 0
 }
 
+// CarryCapacity is the most weight the character can carry.
+func (c *Character) CarryCapacity() float64 {
+	return float64(c.Stats.Effective(StatStrength)) * carryPerStrength
+}
+
+// UpdatePlayTime adds the time played since the last save to PlayTime,
+// ready for the character to be saved
 func (c *Character) UpdatePlayTime() {
-	if !c.LastPlayed.IsZero() {
-		c.PlayTime += time.Since(c.LastPlayed)
+	now := time.Now()
+	c.PlayTime = c.CurrentPlayTime(now)
+	c.LastPlayed = now
+}
+
+// CurrentPlayTime is the character's total play time at now, including
+// time not yet saved. It only makes sense for a character in the game.
+func (c *Character) CurrentPlayTime(now time.Time) time.Duration {
+	if c.LastPlayed.IsZero() {
+		return c.PlayTime
 	}
-	c.LastPlayed = time.Now()
+	return c.PlayTime + now.Sub(c.LastPlayed)
 }
 
 func calculateStartingStats(race *Race, class *Class) *CharacterStats {
@@ -124,11 +197,11 @@ func calculateStartingStats(race *Race, class *Class) *CharacterStats {
 		stats.Charisma += race.StatModifiers.Charisma
 	}
 	
-	stats.MaxHealth = stats.Constitution * 10
+	stats.MaxHealth = stats.Constitution * healthPerConstitution
 	stats.Health = stats.MaxHealth
-	stats.MaxMana = stats.Intelligence * 5
+	stats.MaxMana = stats.Intelligence * manaPerIntelligence
 	stats.Mana = stats.MaxMana
-	stats.MaxStamina = stats.Constitution * 5
+	stats.MaxStamina = stats.Constitution * staminaPerConstitution
 	stats.Stamina = stats.MaxStamina
 	
 	return stats
@@ -127,6 +127,21 @@ func TestCharacterUpdatePlayTime(t *testing.T) {
 	}
 }
 
+func TestCharacterCurrentPlayTime(t *testing.T) {
+	char := createTestCharacter()
+	now := time.Now()
+	char.PlayTime = 2 * time.Hour
+	
+	if got := char.CurrentPlayTime(now); got != 2*time.Hour {
+		t.Errorf("Expected saved play time before the clock starts, got %v", got)
+	}
+	
+	char.LastPlayed = now.Add(-10 * time.Minute)
+	if got := char.CurrentPlayTime(now); got != 2*time.Hour+10*time.Minute {
+		t.Errorf("Expected unsaved time to be counted, got %v", got)
+	}
+}
+
 func TestCalculateStartingStats(t *testing.T) {
 	race, _ := GetRaceByID("dwarf")
 	class, _ := GetClassByID("warrior")
@@ -185,4 +200,58 @@ func createTestCharacter() *Character {
 	race, _ := GetRaceByID("human")
 	class, _ := GetClassByID("warrior")
 	return NewCharacter("test-player", "TestChar", race, class)
-}
\ No newline at end of file
+}
+func TestCharacterTutorial(t *testing.T) {
+	race, _ := GetRaceByID("human")
+	class, _ := GetClassByID("warrior")
+	char := NewCharacter("player", "Newbie", race, class)
+	
+	if char.InTutorial() {
+		t.Errorf("Expected new character to not be in the tutorial until started")
+	}
+	
+	char.StartTutorial()
+	if !char.InTutorial() || char.TutorialStep != 1 {
+		t.Errorf("Expected tutorial to start at step 1, got %d", char.TutorialStep)
+	}
+	
+	if char.Location.RoomID != TutorialRoomID || char.Location.ZoneID != TutorialZoneID {
+		t.Errorf("Expected character to be placed in the tutorial zone, got %+v", char.Location)
+	}
+	
+	char.EndTutorial(DefaultStartLocations())
+	if char.InTutorial() {
+		t.Errorf("Expected tutorial to be finished")
+	}
+	
+	if char.Location.RoomID != DefaultStartRoomID || char.Location.ZoneID != DefaultStartZoneID {
+		t.Errorf("Expected character to be moved to the starting room, got %+v", char.Location)
+	}
+}
+
+func TestCarryCapacity(t *testing.T) {
+	race, _ := GetRaceByID("human")
+	class, _ := GetClassByID("warrior")
+	char := NewCharacter("player1", "Alice", race, class)
+	
+	char.Stats.Strength = 12
+	if capacity := char.CarryCapacity(); capacity != 60 {
+		t.Errorf("Expected a capacity of 60, got %v", capacity)
+	}
+}
+
+func TestExplore(t *testing.T) {
+	char := &Character{}
+	if char.HasExplored("riverbank") {
+		t.Errorf("Expected a new character to have explored nothing")
+	}
+	if !char.Explore("riverbank") {
+		t.Errorf("Expected the first visit to be new")
+	}
+	if char.Explore("riverbank") {
+		t.Errorf("Expected a second visit not to be new")
+	}
+	if !char.HasExplored("riverbank") {
+		t.Errorf("Expected riverbank to be explored")
+	}
+}
@@ -1,5 +1,12 @@
 package character
 
+import (
+	"strings"
+	"time"
+
+	"github.com/elidor/dungeogo/pkg/game/damage"
+)
+
 type Class struct {
 	ID                  string
 	Name                string
@@ -10,6 +17,13 @@ type Class struct {
 	Abilities           []ClassAbility
 	WeaponProficiencies []WeaponType
 	ArmorProficiencies  []ArmorType
+	StartingKit         []StartingItem
+}
+
+// StartingItem is an item template handed to new characters of a class
+type StartingItem struct {
+	TemplateID string
+	Quantity   int
 }
 
 type StatType int
@@ -24,14 +38,57 @@ const (
 )
 
 type ClassAbility struct {
-	ID           string
-	Name         string
-	Description  string
-	Level        int
-	Type         AbilityType
+	ID          string
+	Name        string
+	Description string
+	Level       int
+	Type        AbilityType
+	// Cooldown is how many seconds must pass between uses
 	Cooldown     int
 	ManaCost     int
 	Requirements []string
+	// Passive abilities work by themselves, like Sneak Attack, rather than
+	// being used
+	Passive bool
+	// Power is the damage the ability adds to a weapon blow, for a combat
+	// ability, or does by itself, for a magic one, and Spread how much more
+	// it can roll on top
+	Power  int
+	Spread int
+	// Accuracy is the percent chance the ability lands, or 0 if it always
+	// does
+	Accuracy int
+	// Element is the type of damage a magic ability does
+	Element damage.Type
+}
+
+// CooldownDuration returns the ability's cooldown as a duration
+func (a ClassAbility) CooldownDuration() time.Duration {
+	return time.Duration(a.Cooldown) * time.Second
+}
+
+// Roll returns the damage the ability adds or does. roll returns a number
+// from 0 to n-1.
+func (a ClassAbility) Roll(roll func(n int) int) int {
+	return a.Power + roll(a.Spread+1)
+}
+
+// Lands rolls whether the ability hits
+func (a ClassAbility) Lands(roll func(n int) int) bool {
+	return a.Accuracy <= 0 || roll(100) < a.Accuracy
+}
+
+// FindAbility returns the class's ability called name, by its name or ID
+// and without regard to case
+func (c *Class) FindAbility(name string) (*ClassAbility, bool) {
+	id := strings.ReplaceAll(strings.TrimSpace(name), " ", "_")
+	for i := range c.Abilities {
+		ability := &c.Abilities[i]
+		if strings.EqualFold(ability.Name, name) || strings.EqualFold(ability.ID, id) {
+			return ability, true
+		}
+	}
+	return nil, false
 }
 
 type WeaponType int
@@ -56,16 +113,24 @@ const (
 	ArmorShields
 )
 
+// GetClassByID returns a copy of the class with id
 func GetClassByID(id string) (*Class, error) {
-	classes := getStandardClasses()
-	if class, exists := classes[id]; exists {
-		return class, nil
+	catalog.RLock()
+	defer catalog.RUnlock()
+	if class, exists := catalog.classes[id]; exists {
+		return class.clone(), nil
 	}
 	return nil, ErrClassNotFound
 }
 
 func GetAllClasses() map[string]*Class {
-	return getStandardClasses()
+	catalog.RLock()
+	defer catalog.RUnlock()
+	classes := make(map[string]*Class, len(catalog.classes))
+	for id, class := range catalog.classes {
+		classes[id] = class.clone()
+	}
+	return classes
 }
 
 func getStandardClasses() map[string]*Class {
@@ -91,6 +156,11 @@ func getStandardClasses() map[string]*Class {
 				ArmorPlate,
 				ArmorShields,
 			},
+			StartingKit: []StartingItem{
+				{TemplateID: "rusty_sword", Quantity: 1},
+				{TemplateID: "leather_armor", Quantity: 1},
+				{TemplateID: "health_potion", Quantity: 2},
+			},
 			Abilities: []ClassAbility{
 				{
 					ID:          "power_attack",
@@ -98,8 +168,10 @@ func getStandardClasses() map[string]*Class {
 					Description: "Deal extra damage at the cost of accuracy",
 					Level:       1,
 					Type:        AbilityCombat,
-					Cooldown:    0,
+					Cooldown:    6,
 					ManaCost:    0,
+					Power:       4,
+					Accuracy:    75,
 				},
 			},
 		},
@@ -120,6 +192,10 @@ func getStandardClasses() map[string]*Class {
 			ArmorProficiencies: []ArmorType{
 				ArmorCloth,
 			},
+			StartingKit: []StartingItem{
+				{TemplateID: "worn_dagger", Quantity: 1},
+				{TemplateID: "health_potion", Quantity: 3},
+			},
 			Abilities: []ClassAbility{
 				{
 					ID:          "magic_missile",
@@ -129,6 +205,9 @@ func getStandardClasses() map[string]*Class {
 					Type:        AbilityMagic,
 					Cooldown:    3,
 					ManaCost:    5,
+					Power:       4,
+					Spread:      4,
+					Element:     damage.Arcane,
 				},
 			},
 		},
@@ -151,6 +230,11 @@ func getStandardClasses() map[string]*Class {
 				ArmorCloth,
 				ArmorLeather,
 			},
+			StartingKit: []StartingItem{
+				{TemplateID: "worn_dagger", Quantity: 1},
+				{TemplateID: "leather_armor", Quantity: 1},
+				{TemplateID: "health_potion", Quantity: 2},
+			},
 			Abilities: []ClassAbility{
 				{
 					ID:          "sneak_attack",
@@ -160,8 +244,9 @@ func getStandardClasses() map[string]*Class {
 					Type:        AbilityCombat,
 					Cooldown:    0,
 					ManaCost:    0,
+					Passive:     true,
 				},
 			},
 		},
 	}
-}
\ No newline at end of file
+}
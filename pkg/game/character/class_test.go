@@ -0,0 +1,44 @@
+package character
+
+import (
+	"testing"
+	"time"
+
+	"github.com/elidor/dungeogo/pkg/game/items"
+)
+
+func TestStartingKitsUseKnownTemplates(t *testing.T) {
+	factory := items.NewItemFactory()
+
+	for id, class := range GetAllClasses() {
+		if len(class.StartingKit) == 0 {
+			t.Errorf("Expected class %s to have a starting kit", id)
+		}
+
+		for _, entry := range class.StartingKit {
+			if _, err := factory.CreateInstance(entry.TemplateID, "owner", entry.Quantity); err != nil {
+				t.Errorf("Class %s starting item %s is invalid: %v", id, entry.TemplateID, err)
+			}
+		}
+	}
+}
+
+func TestFindAbility(t *testing.T) {
+	warrior, _ := GetClassByID("warrior")
+	for _, name := range []string{"Power Attack", "power attack", "power_attack"} {
+		if ability, ok := warrior.FindAbility(name); !ok || ability.ID != "power_attack" {
+			t.Errorf("Expected %q to find Power Attack, got %v", name, ability)
+		}
+	}
+	if _, ok := warrior.FindAbility("sneak attack"); ok {
+		t.Errorf("Expected a warrior not to have Sneak Attack")
+	}
+
+	ability, _ := warrior.FindAbility("power attack")
+	if got := ability.Roll(func(n int) int { return n - 1 }); got != ability.Power+ability.Spread {
+		t.Errorf("Expected the highest roll to be %d, got %d", ability.Power+ability.Spread, got)
+	}
+	if ability.CooldownDuration() != time.Duration(ability.Cooldown)*time.Second {
+		t.Errorf("Expected the cooldown in seconds, got %v", ability.CooldownDuration())
+	}
+}
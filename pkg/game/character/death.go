@@ -0,0 +1,51 @@
+package character
+
+import "time"
+
+const (
+	DefaultDeathExperiencePercent = 10
+	DefaultDeathGoldPercent       = 10
+	DefaultCorpseDecay            = 30 * time.Minute
+)
+
+// DeathPenalty is what dying to an NPC costs. Defeat by another player is
+// settled by the PvP rules instead. A zero percentage or a false Corpse
+// turns that part of the penalty off.
+type DeathPenalty struct {
+	// ExperiencePercent of the experience earned towards the next level is lost
+	ExperiencePercent int
+	// GoldPercent of carried gold is lost. Gold kept in a bank is never at
+	// risk.
+	GoldPercent int
+	// Corpse leaves what the character carried in a corpse where they died
+	Corpse bool
+	// CorpseDecay is how long a corpse lasts before its contents scatter
+	CorpseDecay time.Duration
+}
+
+func DefaultDeathPenalty() DeathPenalty {
+	return DeathPenalty{
+		ExperiencePercent: DefaultDeathExperiencePercent,
+		GoldPercent:       DefaultDeathGoldPercent,
+		Corpse:            true,
+		CorpseDecay:       DefaultCorpseDecay,
+	}
+}
+
+// Die counts a death and takes the penalty's share of experience and gold,
+// returning how much of each was lost. The character comes back with half
+// their health; moving them somewhere safe is up to the caller. Levels are
+// never lost.
+func (c *Character) Die(penalty DeathPenalty) (experience, gold int) {
+	experience = c.Experience * penalty.ExperiencePercent / 100
+	gold = c.Gold * penalty.GoldPercent / 100
+	c.Experience -= experience
+	c.Gold -= gold
+
+	c.DeathCount++
+	c.State = CharacterAlive
+	if c.Stats != nil {
+		c.Stats.Health = max(c.Stats.MaxHealth/2, 1)
+	}
+	return experience, gold
+}
@@ -0,0 +1,29 @@
+package character
+
+import "testing"
+
+func TestDie(t *testing.T) {
+	race, _ := GetRaceByID("human")
+	class, _ := GetClassByID("warrior")
+	char := NewCharacter("player", "Unlucky", race, class)
+	char.Experience, char.Gold = 250, 95
+	char.Stats.Health = 0
+	char.State = CharacterInCombat
+
+	experience, gold := char.Die(DefaultDeathPenalty())
+	if experience != 25 || gold != 9 {
+		t.Errorf("Expected to lose 25 experience and 9 gold, got %d and %d", experience, gold)
+	}
+	if char.Experience != 225 || char.Gold != 86 {
+		t.Errorf("Expected 225 experience and 86 gold left, got %d and %d", char.Experience, char.Gold)
+	}
+	if char.DeathCount != 1 || char.State != CharacterAlive || char.Stats.Health != char.Stats.MaxHealth/2 {
+		t.Errorf("Expected one death and half health, got %d deaths and %d/%d health", char.DeathCount, char.Stats.Health, char.Stats.MaxHealth)
+	}
+
+	// With the penalties off only the death is counted
+	experience, gold = char.Die(DeathPenalty{})
+	if experience != 0 || gold != 0 || char.Experience != 225 || char.Gold != 86 || char.DeathCount != 2 {
+		t.Errorf("Expected nothing lost without penalties, got %d experience and %d gold", experience, gold)
+	}
+}
@@ -0,0 +1,136 @@
+package character
+
+import (
+	"errors"
+
+	"github.com/elidor/dungeogo/pkg/game/items"
+)
+
+// Equip places item in slot and returns whatever was previously there.
+func (c *Character) Equip(slot items.EquipSlot, item *items.ItemInstance) *items.ItemInstance {
+	if c.Equipment == nil {
+		c.Equipment = make(map[items.EquipSlot]*items.ItemInstance)
+	}
+	previous := c.Equipment[slot]
+	c.Equipment[slot] = item
+	return previous
+}
+
+// Unequip empties slot and returns the item that was in it, if any.
+func (c *Character) Unequip(slot items.EquipSlot) *items.ItemInstance {
+	item := c.Equipment[slot]
+	delete(c.Equipment, slot)
+	return item
+}
+
+// equipmentSource is the source of the stat modifiers worn items give
+const equipmentSource = "equipment"
+
+// itemStats maps the stats items name to the character's stats
+var itemStats = map[items.StatType]StatType{
+	items.StatStrength:     StatStrength,
+	items.StatDexterity:    StatDexterity,
+	items.StatIntelligence: StatIntelligence,
+	items.StatConstitution: StatConstitution,
+	items.StatWisdom:       StatWisdom,
+	items.StatCharisma:     StatCharisma,
+}
+
+// ApplyEquipmentBonuses brings the character's stat modifiers in line with
+// what they are wearing: the stat bonuses of each item's template and its
+// stat enchantments. Call it whenever equipment changes, so that taking an
+// item off takes its bonuses with it.
+func (c *Character) ApplyEquipmentBonuses(factory *items.ItemFactory) {
+	if c.Stats == nil {
+		return
+	}
+	var modifiers []StatModifier
+	for _, item := range c.Equipment {
+		if item == nil {
+			continue
+		}
+		var bonuses map[items.StatType]int
+		if template, err := factory.GetTemplate(item.TemplateID); err == nil {
+			bonuses = template.BaseStats.StatBonuses
+		}
+		for itemStat, stat := range itemStats {
+			if value := bonuses[itemStat] + item.StatBonus(itemStat); value != 0 {
+				modifiers = append(modifiers, StatModifier{Stat: stat, Value: value})
+			}
+		}
+	}
+	c.Stats.SetModifiers(equipmentSource, modifiers)
+}
+
+// EquippedSlot returns the slot holding the item with itemID.
+func (c *Character) EquippedSlot(itemID string) (items.EquipSlot, bool) {
+	for slot, item := range c.Equipment {
+		if item != nil && item.ID == itemID {
+			return slot, true
+		}
+	}
+	return items.SlotNone, false
+}
+
+// EquipmentIDs maps each occupied slot to the ID of the item in it, which
+// is the form equipment is persisted in.
+func (c *Character) EquipmentIDs() map[items.EquipSlot]string {
+	ids := make(map[items.EquipSlot]string, len(c.Equipment))
+	for slot, item := range c.Equipment {
+		if item != nil {
+			ids[slot] = item.ID
+		}
+	}
+	return ids
+}
+
+// OffHandMinDexterity is the Dexterity a character needs to wield a second
+// weapon in their off hand
+const OffHandMinDexterity = 12
+
+var (
+	ErrOffHandTwoHanded = errors.New("two-handed weapons cannot be wielded in the off hand")
+	ErrOffHandUnskilled = errors.New("class is not proficient with the weapon")
+	ErrOffHandDexterity = errors.New("not dexterous enough to wield a weapon in the off hand")
+)
+
+// weaponTypes maps each kind of weapon to the proficiency needed to use it
+var weaponTypes = map[items.WeaponClass]WeaponType{
+	items.WeaponSword:    WeaponSwords,
+	items.WeaponAxe:      WeaponAxes,
+	items.WeaponMace:     WeaponMaces,
+	items.WeaponDagger:   WeaponDaggers,
+	items.WeaponBow:      WeaponBows,
+	items.WeaponCrossbow: WeaponCrossbows,
+	items.WeaponStaff:    WeaponStaves,
+}
+
+// IsProficientWith reports whether the character's class is trained in
+// weapons of weaponClass.
+func (c *Character) IsProficientWith(weaponClass items.WeaponClass) bool {
+	weaponType, ok := weaponTypes[weaponClass]
+	if !ok || c.Class == nil {
+		return false
+	}
+	for _, proficiency := range c.Class.WeaponProficiencies {
+		if proficiency == weaponType {
+			return true
+		}
+	}
+	return false
+}
+
+// CanWieldOffHand reports why the character cannot wield a weapon of
+// weaponClass in their off hand, or nil if they can.
+func (c *Character) CanWieldOffHand(weaponClass items.WeaponClass) error {
+	if weaponClass.TwoHanded() {
+		return ErrOffHandTwoHanded
+	}
+	if !c.IsProficientWith(weaponClass) {
+		return ErrOffHandUnskilled
+	}
+	if c.Stats == nil || c.Stats.Effective(StatDexterity) < OffHandMinDexterity {
+		return ErrOffHandDexterity
+	}
+	return nil
+}
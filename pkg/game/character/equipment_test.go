@@ -0,0 +1,137 @@
+package character
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/elidor/dungeogo/pkg/game/items"
+)
+
+func TestCharacterEquipment(t *testing.T) {
+	race, _ := GetRaceByID("human")
+	class, _ := GetClassByID("warrior")
+	char := NewCharacter("player", "Armsman", race, class)
+
+	sword := &items.ItemInstance{ID: "sword", TemplateID: "rusty_sword"}
+	staff := &items.ItemInstance{ID: "staff", TemplateID: "magic_staff"}
+
+	if previous := char.Equip(items.SlotMainHand, sword); previous != nil {
+		t.Errorf("Expected empty main hand, got %v", previous)
+	}
+
+	if slot, ok := char.EquippedSlot("sword"); !ok || slot != items.SlotMainHand {
+		t.Errorf("Expected sword in main hand, got %q", slot)
+	}
+
+	if previous := char.Equip(items.SlotMainHand, staff); previous != sword {
+		t.Errorf("Expected equipping the staff to displace the sword")
+	}
+
+	ids := char.EquipmentIDs()
+	if len(ids) != 1 || ids[items.SlotMainHand] != "staff" {
+		t.Errorf("Expected equipment IDs to hold the staff, got %v", ids)
+	}
+
+	if removed := char.Unequip(items.SlotMainHand); removed != staff {
+		t.Errorf("Expected to remove the staff, got %v", removed)
+	}
+
+	if _, ok := char.EquippedSlot("staff"); ok {
+		t.Errorf("Expected staff to no longer be equipped")
+	}
+}
+
+func TestCanWieldOffHand(t *testing.T) {
+	race, _ := GetRaceByID("human")
+	class, _ := GetClassByID("rogue")
+	char := NewCharacter("player", "Quickhand", race, class)
+
+	char.Stats.Dexterity = OffHandMinDexterity - 1
+	if err := char.CanWieldOffHand(items.WeaponDagger); !errors.Is(err, ErrOffHandDexterity) {
+		t.Errorf("Expected ErrOffHandDexterity, got %v", err)
+	}
+
+	char.Stats.Dexterity = OffHandMinDexterity
+	if err := char.CanWieldOffHand(items.WeaponDagger); err != nil {
+		t.Errorf("Expected a nimble rogue to wield an off-hand dagger, got %v", err)
+	}
+	if err := char.CanWieldOffHand(items.WeaponSword); !errors.Is(err, ErrOffHandUnskilled) {
+		t.Errorf("Expected ErrOffHandUnskilled for a rogue with a sword, got %v", err)
+	}
+	if err := char.CanWieldOffHand(items.WeaponBow); !errors.Is(err, ErrOffHandTwoHanded) {
+		t.Errorf("Expected ErrOffHandTwoHanded, got %v", err)
+	}
+}
+
+func TestEquipmentStatBonuses(t *testing.T) {
+	race, _ := GetRaceByID("human")
+	class, _ := GetClassByID("warrior")
+	char := NewCharacter("player", "Armsman", race, class)
+	factory := items.NewItemFactory()
+	strength, capacity := char.Stats.Strength, char.CarryCapacity()
+
+	sword := &items.ItemInstance{ID: "sword", TemplateID: "rusty_sword"}
+	sword.AddEnchantment(items.Enchantment{ID: "might", Type: items.EnchantmentStat, Stat: items.StatStrength, Power: 3})
+	staff := &items.ItemInstance{ID: "staff", TemplateID: "magic_staff"}
+
+	char.Equip(items.SlotMainHand, sword)
+	char.Equip(items.SlotOffHand, staff)
+	char.ApplyEquipmentBonuses(factory)
+	if got := char.Stats.Effective(StatStrength); got != strength+3 {
+		t.Errorf("Expected the sword to raise Strength to %d, got %d", strength+3, got)
+	}
+	if got := char.Stats.Effective(StatIntelligence); got != char.Stats.Intelligence+2 {
+		t.Errorf("Expected the staff's template to raise Intelligence by 2, got %d", got)
+	}
+	if char.Stats.Strength != strength {
+		t.Errorf("Expected base Strength to stay %d, got %d", strength, char.Stats.Strength)
+	}
+	if got := char.CarryCapacity(); got != capacity+3*carryPerStrength {
+		t.Errorf("Expected Strength from the sword to add to carry capacity, got %v", got)
+	}
+
+	// Working the bonuses out again must not count them twice
+	char.ApplyEquipmentBonuses(factory)
+	if got := char.Stats.Effective(StatStrength); got != strength+3 {
+		t.Errorf("Expected Strength %d after reapplying, got %d", strength+3, got)
+	}
+
+	char.Unequip(items.SlotMainHand)
+	char.ApplyEquipmentBonuses(factory)
+	if got := char.Stats.Effective(StatStrength); got != strength {
+		t.Errorf("Expected removing the sword to restore Strength %d, got %d", strength, got)
+	}
+	if got := char.CarryCapacity(); got != capacity {
+		t.Errorf("Expected carry capacity %v, got %v", capacity, got)
+	}
+	if got := char.Stats.Effective(StatIntelligence); got != char.Stats.Intelligence+2 {
+		t.Errorf("Expected the staff's bonus to remain, got %d", got)
+	}
+}
+
+func TestSetModifiersKeepsOtherSources(t *testing.T) {
+	stats := &CharacterStats{Wisdom: 10}
+	stats.SetModifiers("blessing", []StatModifier{{Stat: StatWisdom, Value: 2}})
+	stats.SetModifiers(equipmentSource, []StatModifier{{Stat: StatWisdom, Value: 1}})
+	stats.SetModifiers(equipmentSource, nil)
+	if got := stats.Effective(StatWisdom); got != 12 {
+		t.Errorf("Expected only the blessing to remain, got %d", got)
+	}
+}
+
+func TestStatModifiersAreNotSaved(t *testing.T) {
+	stats := &CharacterStats{Wisdom: 10}
+	stats.SetModifiers(equipmentSource, []StatModifier{{Stat: StatWisdom, Value: 1}})
+	data, err := json.Marshal(stats)
+	if err != nil {
+		t.Fatalf("Failed to marshal stats: %v", err)
+	}
+	var saved CharacterStats
+	if err := json.Unmarshal(data, &saved); err != nil {
+		t.Fatalf("Failed to unmarshal stats: %v", err)
+	}
+	if saved.Wisdom != 10 || len(saved.Modifiers) != 0 {
+		t.Errorf("Expected the base stats without modifiers, got %+v", saved)
+	}
+}
@@ -0,0 +1,19 @@
+package character
+
+// Explore marks roomID as visited, reporting whether this is the first
+// visit.
+func (c *Character) Explore(roomID string) bool {
+	if c.Explored[roomID] {
+		return false
+	}
+	if c.Explored == nil {
+		c.Explored = make(map[string]bool)
+	}
+	c.Explored[roomID] = true
+	return true
+}
+
+// HasExplored reports whether the character has been to roomID.
+func (c *Character) HasExplored(roomID string) bool {
+	return c.Explored[roomID]
+}
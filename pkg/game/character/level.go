@@ -0,0 +1,114 @@
+package character
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// MaxLevel is the highest level a character can reach
+const MaxLevel = 50
+
+// LevelingMode decides how earned experience turns into levels
+type LevelingMode int
+
+const (
+	// LevelingAutomatic levels characters up as soon as they have the experience
+	LevelingAutomatic LevelingMode = iota
+	// LevelingManual makes characters visit a trainer to gain each level
+	LevelingManual
+)
+
+var ErrUnknownStat = errors.New("unknown stat")
+
+// StatNames lists the stats a character can raise when gaining a level
+var StatNames = []string{"strength", "dexterity", "intelligence", "constitution", "wisdom", "charisma"}
+
+func ParseLevelingMode(name string) (LevelingMode, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "automatic", "auto":
+		return LevelingAutomatic, nil
+	case "manual", "trainer":
+		return LevelingManual, nil
+	default:
+		return LevelingAutomatic, fmt.Errorf("unknown leveling mode: %s", name)
+	}
+}
+
+// ExperienceForLevel returns the experience needed to reach level from the
+// level below it, growing with the square of the level as skills do. Level
+// 1 needs none.
+func ExperienceForLevel(level int) int {
+	if level <= 1 {
+		return 0
+	}
+	return (level - 1) * (level - 1) * 100
+}
+
+// ExperienceToNextLevel returns the experience the character needs in all
+// to reach their next level, or 0 at MaxLevel.
+func (c *Character) ExperienceToNextLevel() int {
+	if c.Level >= MaxLevel {
+		return 0
+	}
+	return ExperienceForLevel(c.Level + 1)
+}
+
+// GainExperience adds amount to the character's experience, levelling them
+// up as many times as it pays for. It returns how many levels were gained.
+func (c *Character) GainExperience(amount int) int {
+	c.Experience += amount
+	gained := 0
+	for c.LevelUp() {
+		gained++
+	}
+	return gained
+}
+
+// CanLevelUp reports whether the character has the experience for their
+// next level.
+func (c *Character) CanLevelUp() bool {
+	return c.Level < MaxLevel && c.Experience >= c.ExperienceToNextLevel()
+}
+
+// LevelUp raises the character one level if they have the experience for
+// it. The experience the level costs is spent and any excess carries over
+// towards the next. The new level adds the class's hit die to maximum
+// health, PracticesPerLevel practice sessions and StatPointsPerLevel
+// attribute points, and heals the character fully. It reports whether the character levelled up.
+func (c *Character) LevelUp() bool {
+	if !c.CanLevelUp() {
+		return false
+	}
+	c.Experience -= c.ExperienceToNextLevel()
+	c.Level++
+	c.Practices += PracticesPerLevel
+	c.StatPoints += StatPointsPerLevel
+	if c.Stats != nil {
+		if c.Class != nil {
+			c.Stats.MaxHealth += c.Class.HitDie
+		}
+		c.Stats.Health = c.Stats.MaxHealth
+	}
+	return true
+}
+
+// RaiseStat adds one point to the stat called name, one of StatNames, and
+// returns its new value. Stats already at their cap can't be raised.
+func (c *Character) RaiseStat(name string) (int, error) {
+	stat, ok := StatByName(name)
+	if !ok {
+		return 0, ErrUnknownStat
+	}
+	return c.raiseStat(stat)
+}
+
+// ExperienceProgress describes how far the character is towards their next
+// level, as in "250/900 (27%)".
+func (c *Character) ExperienceProgress() string {
+	needed := c.ExperienceToNextLevel()
+	if needed == 0 {
+		return fmt.Sprintf("%d (maximum level)", c.Experience)
+	}
+	return fmt.Sprintf("%d/%d (%d%%)", c.Experience, needed, c.Experience*100/needed)
+}
@@ -0,0 +1,51 @@
+package character
+
+import "testing"
+
+func TestExperienceForLevel(t *testing.T) {
+	tests := map[int]int{0: 0, 1: 0, 2: 100, 3: 400, 4: 900, 10: 8100}
+	for level, expected := range tests {
+		if got := ExperienceForLevel(level); got != expected {
+			t.Errorf("ExperienceForLevel(%d): expected %d, got %d", level, expected, got)
+		}
+	}
+}
+
+func TestGainExperience(t *testing.T) {
+	race, _ := GetRaceByID("human")
+	class, _ := GetClassByID("warrior")
+	char := NewCharacter("player", "Climber", race, class)
+	maxHealth := char.Stats.MaxHealth
+
+	if gained := char.GainExperience(60); gained != 0 || char.Level != 1 || char.Experience != 60 {
+		t.Errorf("Expected no level yet, got level %d with %d experience", char.Level, char.Experience)
+	}
+	if got := char.ExperienceProgress(); got != "60/100 (60%)" {
+		t.Errorf("Unexpected progress %q", got)
+	}
+
+	// 60 + 50 pays the 100 for level 2 and carries 10 over
+	char.Stats.Health = 1
+	if gained := char.GainExperience(50); gained != 1 || char.Level != 2 || char.Experience != 10 {
+		t.Errorf("Expected level 2 with 10 experience, got level %d with %d", char.Level, char.Experience)
+	}
+	if char.Stats.MaxHealth != maxHealth+class.HitDie || char.Stats.Health != char.Stats.MaxHealth {
+		t.Errorf("Expected a new level to raise and restore health, got %d/%d", char.Stats.Health, char.Stats.MaxHealth)
+	}
+
+	// 10 + 1300 pays 400 for level 3 and 900 for level 4, leaving 10
+	if gained := char.GainExperience(1300); gained != 2 || char.Level != 4 || char.Experience != 10 {
+		t.Errorf("Expected level 4 with 10 experience, got level %d with %d", char.Level, char.Experience)
+	}
+	if char.Practices != 4*PracticesPerLevel {
+		t.Errorf("Expected %d practices after three levels, got %d", 4*PracticesPerLevel, char.Practices)
+	}
+
+	char.Level, char.Experience = MaxLevel, 0
+	if gained := char.GainExperience(1000000); gained != 0 || char.Level != MaxLevel {
+		t.Errorf("Expected no levels past %d, got %d", MaxLevel, char.Level)
+	}
+	if got := char.ExperienceProgress(); got != "1000000 (maximum level)" {
+		t.Errorf("Unexpected progress at the maximum level %q", got)
+	}
+}
@@ -0,0 +1,491 @@
+package character
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/elidor/dungeogo/pkg/game/damage"
+)
+
+// DefaultRacePath and DefaultClassPath are where the server looks for race
+// and class files unless told otherwise
+const (
+	DefaultRacePath  = "data/races"
+	DefaultClassPath = "data/classes"
+)
+
+var (
+	ErrInvalidRace  = errors.New("invalid race")
+	ErrInvalidClass = errors.New("invalid class")
+	// ErrInUse is returned by a load that would remove a race or class saved
+	// characters still are
+	ErrInUse = errors.New("removed but still used by saved characters")
+)
+
+var sizeNames = map[string]SizeType{
+	"tiny":   SizeTiny,
+	"small":  SizeSmall,
+	"medium": SizeMedium,
+	"large":  SizeLarge,
+	"huge":   SizeHuge,
+}
+
+var abilityTypeNames = map[string]AbilityType{
+	"vision":     AbilityVision,
+	"resistance": AbilityResistance,
+	"movement":   AbilityMovement,
+	"combat":     AbilityCombat,
+	"magic":      AbilityMagic,
+}
+
+var weaponTypeNames = map[string]WeaponType{
+	"swords":    WeaponSwords,
+	"axes":      WeaponAxes,
+	"maces":     WeaponMaces,
+	"daggers":   WeaponDaggers,
+	"bows":      WeaponBows,
+	"crossbows": WeaponCrossbows,
+	"staves":    WeaponStaves,
+}
+
+var armorTypeNames = map[string]ArmorType{
+	"cloth":   ArmorCloth,
+	"leather": ArmorLeather,
+	"chain":   ArmorChain,
+	"plate":   ArmorPlate,
+	"shields": ArmorShields,
+}
+
+// raceData is a race as written in a data file, with sizes, skills,
+// ability types and damage types given by name
+type raceData struct {
+	ID            string `json:"id"`
+	Name          string `json:"name"`
+	Description   string `json:"description"`
+	Size          string `json:"size"`
+	Lifespan      int    `json:"lifespan"`
+	StatModifiers struct {
+		Strength     int `json:"strength"`
+		Dexterity    int `json:"dexterity"`
+		Intelligence int `json:"intelligence"`
+		Constitution int `json:"constitution"`
+		Wisdom       int `json:"wisdom"`
+		Charisma     int `json:"charisma"`
+	} `json:"stat_modifiers"`
+	SkillBonuses map[string]int `json:"skill_bonuses"`
+	Abilities    []struct {
+		ID          string `json:"id"`
+		Name        string `json:"name"`
+		Description string `json:"description"`
+		Type        string `json:"type"`
+		Passive     bool   `json:"passive"`
+	} `json:"abilities"`
+	Resistances map[string]int `json:"resistances"`
+}
+
+// classData is a class as written in a data file, with stats, weapons,
+// armor, ability types and damage types given by name
+type classData struct {
+	ID                  string   `json:"id"`
+	Name                string   `json:"name"`
+	Description         string   `json:"description"`
+	PrimaryStats        []string `json:"primary_stats"`
+	HitDie              int      `json:"hit_die"`
+	BaseAttackBonus     int      `json:"base_attack_bonus"`
+	WeaponProficiencies []string `json:"weapon_proficiencies"`
+	ArmorProficiencies  []string `json:"armor_proficiencies"`
+	StartingKit         []struct {
+		TemplateID string `json:"template_id"`
+		Quantity   int    `json:"quantity"`
+	} `json:"starting_kit"`
+	Abilities []struct {
+		ID           string   `json:"id"`
+		Name         string   `json:"name"`
+		Description  string   `json:"description"`
+		Level        int      `json:"level"`
+		Type         string   `json:"type"`
+		Cooldown     int      `json:"cooldown"`
+		ManaCost     int      `json:"mana_cost"`
+		Requirements []string `json:"requirements"`
+		Passive      bool     `json:"passive"`
+		Power        int      `json:"power"`
+		Spread       int      `json:"spread"`
+		Accuracy     int      `json:"accuracy"`
+		Element      string   `json:"element"`
+	} `json:"abilities"`
+}
+
+// LoadRaces reads races from path, which is either a JSON file holding a
+// list of races or a directory of such files. A missing path yields no
+// races. Every malformed entry is reported, by file and position.
+func LoadRaces(path string) ([]*Race, error) {
+	var races []*Race
+	err := readEntries(path, func(entries []raceData, file string) []error {
+		var problems []error
+		for i, entry := range entries {
+			race, err := entry.race()
+			if err != nil {
+				problems = append(problems, fmt.Errorf("%s: entry %d (%q): %w", file, i+1, entry.ID, err))
+				continue
+			}
+			races = append(races, race)
+		}
+		return problems
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := checkUnique(races, func(r *Race) string { return r.ID }, ErrInvalidRace); err != nil {
+		return nil, err
+	}
+	return races, nil
+}
+
+// LoadClasses reads classes from path as LoadRaces reads races. itemExists
+// reports whether an item template exists, to check starting kits; if nil,
+// kits are not checked.
+func LoadClasses(path string, itemExists func(templateID string) bool) ([]*Class, error) {
+	var classes []*Class
+	err := readEntries(path, func(entries []classData, file string) []error {
+		var problems []error
+		for i, entry := range entries {
+			class, err := entry.class(itemExists)
+			if err != nil {
+				problems = append(problems, fmt.Errorf("%s: entry %d (%q): %w", file, i+1, entry.ID, err))
+				continue
+			}
+			classes = append(classes, class)
+		}
+		return problems
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := checkUnique(classes, func(c *Class) string { return c.ID }, ErrInvalidClass); err != nil {
+		return nil, err
+	}
+	return classes, nil
+}
+
+// readEntries decodes the list in each JSON file at path and hands it to
+// check, joining the problems found in every file into one error
+func readEntries[T any](path string, check func(entries []T, file string) []error) error {
+	if path == "" {
+		return nil
+	}
+	info, err := os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	files := []string{path}
+	if info.IsDir() {
+		if files, err = filepath.Glob(filepath.Join(path, "*.json")); err != nil {
+			return fmt.Errorf("failed to list files in %s: %w", path, err)
+		}
+		sort.Strings(files)
+	}
+
+	var problems []error
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", file, err)
+		}
+		var entries []T
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&entries); err != nil {
+			problems = append(problems, fmt.Errorf("%s: %w", file, err))
+			continue
+		}
+		problems = append(problems, check(entries, file)...)
+	}
+	return errors.Join(problems...)
+}
+
+// checkUnique reports any ID given to more than one entry
+func checkUnique[T any](entries []T, id func(T) string, invalid error) error {
+	seen := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		if seen[id(entry)] {
+			return fmt.Errorf("%w: %q is defined more than once", invalid, id(entry))
+		}
+		seen[id(entry)] = true
+	}
+	return nil
+}
+
+// isCatalogID reports whether id can name a race or class: players type
+// it when creating a character, and it is matched in lower case
+func isCatalogID(id string) bool {
+	return strings.Trim(id, "abcdefghijklmnopqrstuvwxyz_") == ""
+}
+
+// race checks an entry and turns it into a Race. Races need an ID and a
+// name; the size defaults to medium.
+func (d *raceData) race() (*Race, error) {
+	if d.ID == "" || d.Name == "" {
+		return nil, fmt.Errorf("%w: an id and a name are required", ErrInvalidRace)
+	}
+	if !isCatalogID(d.ID) {
+		return nil, fmt.Errorf("%w: ids must be lower case letters and underscores", ErrInvalidRace)
+	}
+	race := &Race{
+		ID:            d.ID,
+		Name:          d.Name,
+		Description:   d.Description,
+		SizeCategory:  SizeMedium,
+		Lifespan:      d.Lifespan,
+		StatModifiers: StatModifiers(d.StatModifiers),
+		SkillBonuses:  make(map[SkillType]int),
+		Abilities:     []RacialAbility{},
+	}
+	if d.Size != "" {
+		size, ok := sizeNames[strings.ToLower(d.Size)]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown size %q", ErrInvalidRace, d.Size)
+		}
+		race.SizeCategory = size
+	}
+
+	for name, bonus := range d.SkillBonuses {
+		skill, ok := SkillByName(name)
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown skill %q", ErrInvalidRace, name)
+		}
+		race.SkillBonuses[skill] = bonus
+	}
+	for _, ability := range d.Abilities {
+		if ability.ID == "" || ability.Name == "" {
+			return nil, fmt.Errorf("%w: abilities need an id and a name", ErrInvalidRace)
+		}
+		abilityType, ok := abilityTypeNames[strings.ToLower(ability.Type)]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown ability type %q", ErrInvalidRace, ability.Type)
+		}
+		race.Abilities = append(race.Abilities, RacialAbility{
+			ID:          ability.ID,
+			Name:        ability.Name,
+			Description: ability.Description,
+			Type:        abilityType,
+			Passive:     ability.Passive,
+		})
+	}
+	if len(d.Resistances) > 0 {
+		race.Resistances = make(damage.Resistances)
+		for name, amount := range d.Resistances {
+			kind, ok := damage.TypeByName(name)
+			if !ok {
+				return nil, fmt.Errorf("%w: unknown damage type %q", ErrInvalidRace, name)
+			}
+			race.Resistances[kind] = amount
+		}
+	}
+	return race, nil
+}
+
+// class checks an entry and turns it into a Class. Classes need an ID, a
+// name and a hit die; abilities default to level one and kit items to a
+// quantity of one.
+func (d *classData) class(itemExists func(string) bool) (*Class, error) {
+	if d.ID == "" || d.Name == "" {
+		return nil, fmt.Errorf("%w: an id and a name are required", ErrInvalidClass)
+	}
+	if !isCatalogID(d.ID) {
+		return nil, fmt.Errorf("%w: ids must be lower case letters and underscores", ErrInvalidClass)
+	}
+	if d.HitDie <= 0 {
+		return nil, fmt.Errorf("%w: hit_die must be above zero", ErrInvalidClass)
+	}
+	class := &Class{
+		ID:              d.ID,
+		Name:            d.Name,
+		Description:     d.Description,
+		HitDie:          d.HitDie,
+		BaseAttackBonus: d.BaseAttackBonus,
+	}
+
+	for _, name := range d.PrimaryStats {
+		stat, ok := StatByName(name)
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown stat %q", ErrInvalidClass, name)
+		}
+		class.PrimaryStats = append(class.PrimaryStats, stat)
+	}
+	for _, name := range d.WeaponProficiencies {
+		weapon, ok := weaponTypeNames[strings.ToLower(name)]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown weapon type %q", ErrInvalidClass, name)
+		}
+		class.WeaponProficiencies = append(class.WeaponProficiencies, weapon)
+	}
+	for _, name := range d.ArmorProficiencies {
+		armor, ok := armorTypeNames[strings.ToLower(name)]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown armor type %q", ErrInvalidClass, name)
+		}
+		class.ArmorProficiencies = append(class.ArmorProficiencies, armor)
+	}
+	for _, item := range d.StartingKit {
+		if item.TemplateID == "" || (itemExists != nil && !itemExists(item.TemplateID)) {
+			return nil, fmt.Errorf("%w: unknown starting item %q", ErrInvalidClass, item.TemplateID)
+		}
+		class.StartingKit = append(class.StartingKit, StartingItem{TemplateID: item.TemplateID, Quantity: max(item.Quantity, 1)})
+	}
+
+	for _, ability := range d.Abilities {
+		if ability.ID == "" || ability.Name == "" {
+			return nil, fmt.Errorf("%w: abilities need an id and a name", ErrInvalidClass)
+		}
+		abilityType, ok := abilityTypeNames[strings.ToLower(ability.Type)]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown ability type %q", ErrInvalidClass, ability.Type)
+		}
+		element := damage.Physical
+		if ability.Element != "" {
+			if element, ok = damage.TypeByName(ability.Element); !ok {
+				return nil, fmt.Errorf("%w: unknown damage type %q", ErrInvalidClass, ability.Element)
+			}
+		}
+		class.Abilities = append(class.Abilities, ClassAbility{
+			ID:           ability.ID,
+			Name:         ability.Name,
+			Description:  ability.Description,
+			Level:        max(ability.Level, 1),
+			Type:         abilityType,
+			Cooldown:     ability.Cooldown,
+			ManaCost:     ability.ManaCost,
+			Requirements: ability.Requirements,
+			Passive:      ability.Passive,
+			Power:        ability.Power,
+			Spread:       ability.Spread,
+			Accuracy:     ability.Accuracy,
+			Element:      element,
+		})
+	}
+	return class, nil
+}
+
+// checkKept refuses a load that leaves out an ID inUse reports, as the
+// characters of that race or class could no longer be loaded
+func checkKept(inUse func() ([]string, error), standard []string, loaded []string) error {
+	if inUse == nil {
+		return nil
+	}
+	used, err := inUse()
+	if err != nil {
+		return fmt.Errorf("failed to find which are in use: %w", err)
+	}
+	var missing []string
+	for _, id := range used {
+		if !slices.Contains(standard, id) && !slices.Contains(loaded, id) {
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return fmt.Errorf("%w: %s", ErrInUse, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// RaceLoader keeps the races characters can be in step with the data files
+// at a path
+type RaceLoader struct {
+	path  string
+	inUse func() ([]string, error)
+}
+
+func NewRaceLoader(path string) *RaceLoader {
+	return &RaceLoader{path: path}
+}
+
+// SetInUse sets how to list the races saved characters are, so a load
+// that would remove one of them is refused
+func (l *RaceLoader) SetInUse(inUse func() ([]string, error)) {
+	l.inUse = inUse
+}
+
+// Load reads the files and, if every race in them is valid and none still
+// in use is left out, swaps them in
+func (l *RaceLoader) Load() (*CatalogChanges, error) {
+	races, err := LoadRaces(l.path)
+	if err != nil {
+		return nil, err
+	}
+	loaded := make([]string, len(races))
+	for i, race := range races {
+		loaded[i] = race.ID
+	}
+	standard := slices.Collect(maps.Keys(getStandardRaces()))
+	if err := checkKept(l.inUse, standard, loaded); err != nil {
+		return nil, err
+	}
+	return ReplaceRaces(races), nil
+}
+
+// Reload loads the files again while the server runs and says what changed
+func (l *RaceLoader) Reload() (string, error) {
+	changes, err := l.Load()
+	if err != nil {
+		return "", err
+	}
+	return "Races reloaded. " + changes.String(), nil
+}
+
+// ClassLoader keeps the classes characters can be in step with the data
+// files at a path
+type ClassLoader struct {
+	path       string
+	itemExists func(templateID string) bool
+	inUse      func() ([]string, error)
+}
+
+func NewClassLoader(path string, itemExists func(templateID string) bool) *ClassLoader {
+	return &ClassLoader{path: path, itemExists: itemExists}
+}
+
+// SetInUse sets how to list the classes saved characters are, so a load
+// that would remove one of them is refused
+func (l *ClassLoader) SetInUse(inUse func() ([]string, error)) {
+	l.inUse = inUse
+}
+
+// Load reads the files and, if every class in them is valid and none still
+// in use is left out, swaps them in
+func (l *ClassLoader) Load() (*CatalogChanges, error) {
+	classes, err := LoadClasses(l.path, l.itemExists)
+	if err != nil {
+		return nil, err
+	}
+	loaded := make([]string, len(classes))
+	for i, class := range classes {
+		loaded[i] = class.ID
+	}
+	standard := slices.Collect(maps.Keys(getStandardClasses()))
+	if err := checkKept(l.inUse, standard, loaded); err != nil {
+		return nil, err
+	}
+	return ReplaceClasses(classes), nil
+}
+
+// Reload loads the files again while the server runs and says what changed
+func (l *ClassLoader) Reload() (string, error) {
+	changes, err := l.Load()
+	if err != nil {
+		return "", err
+	}
+	return "Classes reloaded. " + changes.String(), nil
+}
@@ -0,0 +1,155 @@
+package character
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/elidor/dungeogo/pkg/game/damage"
+)
+
+// writeData writes content to name in a new directory and returns the
+// directory, restoring the built-in races and classes after the test
+func writeData(t *testing.T, name, content string) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write %s: %v", name, err)
+	}
+	t.Cleanup(func() {
+		ReplaceRaces(nil)
+		ReplaceClasses(nil)
+	})
+	return dir
+}
+
+const halflingFile = `[{
+	"id": "halfling",
+	"name": "Halfling",
+	"description": "Small, quick and hard to pin down.",
+	"size": "small",
+	"lifespan": 150,
+	"stat_modifiers": {"strength": -1, "dexterity": 2},
+	"skill_bonuses": {"stealth": 10},
+	"abilities": [{"id": "lucky", "name": "Lucky", "type": "combat", "passive": true}],
+	"resistances": {"poison": 25}
+}]`
+
+func TestRaceLoader(t *testing.T) {
+	dir := writeData(t, "races.json", halflingFile)
+
+	changes, err := NewRaceLoader(dir).Load()
+	if err != nil {
+		t.Fatalf("Failed to load races: %v", err)
+	}
+	if changes.String() != "Added: halfling." {
+		t.Errorf("Unexpected changes %q", changes)
+	}
+
+	race, err := GetRaceByID("halfling")
+	if err != nil {
+		t.Fatalf("Expected the halfling to be playable, got %v", err)
+	}
+	if race.SizeCategory != SizeSmall || race.StatModifiers.Dexterity != 2 || race.StatModifiers.Strength != -1 {
+		t.Errorf("Expected a small, quick race, got %+v", race)
+	}
+	if race.SkillBonuses[SkillStealth] != 10 || race.Resistances[damage.Poison] != 25 || race.Abilities[0].Type != AbilityCombat {
+		t.Errorf("Expected the halfling's bonuses, got %+v", race)
+	}
+	if strings.Join(RaceIDs(), ",") != "dwarf,elf,halfling,human" {
+		t.Errorf("Expected the built-in races to stay, got %v", RaceIDs())
+	}
+
+	// Lookups are copies, so changing one changes nothing else
+	race.SkillBonuses[SkillStealth] = 99
+	if again, _ := GetRaceByID("halfling"); again.SkillBonuses[SkillStealth] != 10 {
+		t.Errorf("Expected the catalog to be left alone, got %d", again.SkillBonuses[SkillStealth])
+	}
+}
+
+func TestClassLoader(t *testing.T) {
+	dir := writeData(t, "classes.json", `[{
+		"id": "warrior",
+		"name": "Warrior",
+		"primary_stats": ["strength", "constitution"],
+		"hit_die": 12,
+		"weapon_proficiencies": ["swords", "axes"],
+		"armor_proficiencies": ["plate", "shields"],
+		"starting_kit": [{"template_id": "rusty_sword"}],
+		"abilities": [{"id": "cleave", "name": "Cleave", "type": "combat", "cooldown": 8, "power": 5, "element": "physical"}]
+	}]`)
+	itemExists := func(id string) bool { return id == "rusty_sword" }
+
+	reloaded, err := NewClassLoader(dir, itemExists).Reload()
+	if err != nil || reloaded != "Classes reloaded. Changed: warrior." {
+		t.Fatalf("Expected the warrior to change, got %q (%v)", reloaded, err)
+	}
+
+	warrior, _ := GetClassByID("warrior")
+	if warrior.HitDie != 12 || warrior.StartingKit[0].Quantity != 1 || warrior.ArmorProficiencies[0] != ArmorPlate {
+		t.Errorf("Expected the warrior from the file, got %+v", warrior)
+	}
+	if cleave, ok := warrior.FindAbility("cleave"); !ok || cleave.Level != 1 || cleave.Power != 5 {
+		t.Errorf("Expected Cleave at level 1, got %+v", cleave)
+	}
+	if warrior.StatCap(StatStrength, 1) != statCapBase+primaryStatCapBonus {
+		t.Errorf("Expected strength to be the warrior's primary stat")
+	}
+
+	// A broken file leaves the classes as they were
+	os.WriteFile(filepath.Join(dir, "classes.json"), []byte(`[{"id": "warrior", "name": "Warrior", "hit_die": 12,
+		"starting_kit": [{"template_id": "golden_sword"}]}]`), 0644)
+	if _, err := NewClassLoader(dir, itemExists).Load(); !errors.Is(err, ErrInvalidClass) {
+		t.Errorf("Expected an unknown starting item to be refused, got %v", err)
+	}
+	if warrior, _ := GetClassByID("warrior"); warrior.HitDie != 12 {
+		t.Errorf("Expected the loaded warrior to be kept, got hit die %d", warrior.HitDie)
+	}
+}
+
+func TestRaceLoaderKeepsRacesInUse(t *testing.T) {
+	dir := writeData(t, "races.json", halflingFile)
+	loader := NewRaceLoader(dir)
+	loader.SetInUse(func() ([]string, error) { return []string{"human", "halfling"}, nil })
+	if _, err := loader.Load(); err != nil {
+		t.Fatalf("Failed to load races: %v", err)
+	}
+
+	// Dropping the halfling would leave its characters without a race
+	os.Remove(filepath.Join(dir, "races.json"))
+	if _, err := loader.Reload(); !errors.Is(err, ErrInUse) || !strings.Contains(err.Error(), "halfling") {
+		t.Errorf("Expected the halfling's removal to be refused, got %v", err)
+	}
+	if _, err := GetRaceByID("halfling"); err != nil {
+		t.Errorf("Expected the halfling to be kept, got %v", err)
+	}
+}
+
+func TestLoadRacesReportsEveryProblem(t *testing.T) {
+	dir := writeData(t, "races.json", `[
+		{"id": "Gnome", "name": "Gnome"},
+		{"id": "orc", "name": "Orc", "size": "enormous"},
+		{"id": "ogre", "name": "Ogre", "skill_bonuses": {"smashing": 5}}
+	]`)
+	os.WriteFile(filepath.Join(dir, "fey.json"), []byte(`[{"id": "pixie", "name": "Pixie", "stat_modifiers": {"luck": 2}}]`), 0644)
+
+	_, err := LoadRaces(dir)
+	if err == nil {
+		t.Fatalf("Expected the races to be refused")
+	}
+	for _, want := range []string{
+		`entry 1 ("Gnome"): invalid race: ids must be lower case letters and underscores`,
+		`entry 2 ("orc"): invalid race: unknown size "enormous"`,
+		`entry 3 ("ogre"): invalid race: unknown skill "smashing"`,
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected %q in:\n%v", want, err)
+		}
+	}
+	// An unknown field stops its file being read at all
+	if !strings.Contains(err.Error(), `unknown field "luck"`) {
+		t.Errorf("Expected the unknown stat to be reported, got:\n%v", err)
+	}
+}
@@ -0,0 +1,89 @@
+package character
+
+import (
+	"sync"
+	"time"
+)
+
+// lockPollInterval is how often TryLock checks whether a lock has come free
+const lockPollInterval = 5 * time.Millisecond
+
+// Locks serialises changes to characters. Commands, the world tick and
+// saves each load a character, change it and write it back; whoever does
+// so holds the character's lock throughout, so two of them never clobber
+// each other's changes.
+type Locks struct {
+	mutex sync.Mutex
+	locks map[string]*characterLock
+}
+
+// characterLock is one character's lock, kept only while someone holds or
+// waits for it
+type characterLock struct {
+	sync.Mutex
+	users int
+}
+
+func NewLocks() *Locks {
+	return &Locks{locks: make(map[string]*characterLock)}
+}
+
+// Lock waits for the character's lock and takes it. The returned function
+// releases it.
+func (l *Locks) Lock(characterID string) func() {
+	lock := l.acquire(characterID)
+	lock.Lock()
+	return l.unlocker(characterID, lock)
+}
+
+// TryLock takes the character's lock if it comes free within wait, and
+// reports whether it did. Use it to take a second character's lock while
+// holding one, where waiting forever could deadlock with someone doing the
+// same the other way round.
+func (l *Locks) TryLock(characterID string, wait time.Duration) (func(), bool) {
+	lock := l.acquire(characterID)
+	deadline := time.Now().Add(wait)
+	for !lock.TryLock() {
+		if !time.Now().Before(deadline) {
+			l.release(characterID, lock)
+			return nil, false
+		}
+		time.Sleep(lockPollInterval)
+	}
+	return l.unlocker(characterID, lock), true
+}
+
+// acquire returns the character's lock, counting the caller as one of its
+// users
+func (l *Locks) acquire(characterID string) *characterLock {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	lock, ok := l.locks[characterID]
+	if !ok {
+		lock = &characterLock{}
+		l.locks[characterID] = lock
+	}
+	lock.users++
+	return lock
+}
+
+// release stops counting the caller as a user of the character's lock,
+// forgetting the lock once nobody uses it
+func (l *Locks) release(characterID string, lock *characterLock) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	lock.users--
+	if lock.users == 0 {
+		delete(l.locks, characterID)
+	}
+}
+
+func (l *Locks) unlocker(characterID string, lock *characterLock) func() {
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			lock.Unlock()
+			l.release(characterID, lock)
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package character
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLocksSerialiseChanges(t *testing.T) {
+	locks := NewLocks()
+	char := &Character{ID: "char1"}
+
+	// Each writer loads the character's gold, yields and writes it back
+	// one higher, the way a command or tick would. Without the lock, writes
+	// would be lost.
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			unlock := locks.Lock(char.ID)
+			defer unlock()
+			gold := char.Gold
+			time.Sleep(time.Microsecond)
+			char.Gold = gold + 1
+		}()
+	}
+	wg.Wait()
+
+	if char.Gold != 50 {
+		t.Errorf("Expected 50 gold after 50 writes, got %d", char.Gold)
+	}
+	if len(locks.locks) != 0 {
+		t.Errorf("Expected unused locks to be forgotten, got %d", len(locks.locks))
+	}
+}
+
+func TestTryLock(t *testing.T) {
+	locks := NewLocks()
+	unlock := locks.Lock("char1")
+
+	if _, ok := locks.TryLock("char1", 10*time.Millisecond); ok {
+		t.Fatalf("Expected a held lock not to be taken")
+	}
+	other, ok := locks.TryLock("char2", 0)
+	if !ok {
+		t.Fatalf("Expected another character's lock to be free")
+	}
+	other()
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		unlock()
+	}()
+	again, ok := locks.TryLock("char1", time.Second)
+	if !ok {
+		t.Fatalf("Expected the lock to be taken once released")
+	}
+	again()
+	// Releasing twice is harmless
+	again()
+
+	if len(locks.locks) != 0 {
+		t.Errorf("Expected unused locks to be forgotten, got %d", len(locks.locks))
+	}
+}
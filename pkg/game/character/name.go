@@ -0,0 +1,127 @@
+package character
+
+import (
+	"errors"
+	"slices"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+
+	"github.com/elidor/dungeogo/pkg/textutil"
+)
+
+// Character names are letters from any script, so "Zoë", "Ærwyn" and "李娜"
+// are all fine. A single apostrophe or hyphen may join two runs of letters,
+// as in "D'Arcy" or "Anne-Marie". Digits, spaces, symbols and control
+// characters are not allowed. Lengths count runes, not bytes. Names are
+// stored in Unicode NFC, so "Zoë" is the same name however the ë was typed,
+// and must be written in a single script, so "Аlice" with a Cyrillic А
+// can't pass for "Alice".
+const (
+	MinNameLength = 2
+	MaxNameLength = 20
+)
+
+var (
+	ErrNameLength  = errors.New("name must be between 2 and 20 letters long")
+	ErrNameInvalid = errors.New("name may only contain letters, with an apostrophe or hyphen between them")
+	ErrNameProfane = errors.New("name contains language that is not allowed")
+	ErrNameScripts = errors.New("name must be written in a single alphabet")
+)
+
+// ValidateName checks that name follows the character name policy,
+// returning it normalized to NFC. That is the form to store and compare.
+func ValidateName(name string) (string, error) {
+	if !utf8.ValidString(name) {
+		return "", ErrNameInvalid
+	}
+	name = norm.NFC.String(name)
+	if err := validateName(name); err != nil {
+		return "", err
+	}
+	return name, nil
+}
+
+// validateName checks a name already in NFC
+func validateName(name string) error {
+	length := utf8.RuneCountInString(name)
+	if length < MinNameLength || length > MaxNameLength {
+		return ErrNameLength
+	}
+
+	var previous rune
+	for i, r := range name {
+		switch {
+		case unicode.IsLetter(r):
+		case unicode.Is(unicode.Mn, r):
+			// Combining accents must follow a letter
+			if i == 0 || !unicode.IsLetter(previous) && !unicode.Is(unicode.Mn, previous) {
+				return ErrNameInvalid
+			}
+		case r == '\'' || r == '-':
+			if i == 0 || !unicode.IsLetter(previous) && !unicode.Is(unicode.Mn, previous) {
+				return ErrNameInvalid
+			}
+		default:
+			return ErrNameInvalid
+		}
+		previous = r
+	}
+	if previous == '\'' || previous == '-' {
+		return ErrNameInvalid
+	}
+	if !singleScript(name) {
+		return ErrNameScripts
+	}
+
+	if textutil.ContainsProfanity(name) {
+		return ErrNameProfane
+	}
+	return nil
+}
+
+// scriptSets are the scripts that may be mixed in one name, as they are
+// written together: Japanese uses kanji with both kana, and Korean may use
+// hanja with hangul
+var scriptSets = [][]string{
+	{"Han", "Hiragana", "Katakana"},
+	{"Han", "Hangul"},
+}
+
+// singleScript reports whether the letters of name all come from one
+// script, or from scripts written together. Combining marks belong to the
+// letter they follow.
+func singleScript(name string) bool {
+	scripts := make(map[string]bool)
+	for _, r := range name {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		for script, table := range unicode.Scripts {
+			if unicode.Is(table, r) {
+				scripts[script] = true
+				break
+			}
+		}
+	}
+	if len(scripts) <= 1 {
+		return true
+	}
+	for _, set := range scriptSets {
+		if allIn(scripts, set) {
+			return true
+		}
+	}
+	return false
+}
+
+// allIn reports whether every script in scripts is in set
+func allIn(scripts map[string]bool, set []string) bool {
+	for script := range scripts {
+		if !slices.Contains(set, script) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,44 @@
+package character
+
+import "testing"
+
+func TestValidateName(t *testing.T) {
+	for _, name := range []string{"Bob", "Zoë", "Ærwyn", "Łucja", "Søren", "李娜", "Алиса", "D'Arcy", "Anne-Marie", "Zoe\u0308", "山田さくら", "Δημήτρης"} {
+		if _, err := ValidateName(name); err != nil {
+			t.Errorf("ValidateName(%q) = %v, expected it to be allowed", name, err)
+		}
+	}
+}
+
+func TestValidateNameRejectsInvalid(t *testing.T) {
+	tests := map[string]error{
+		"B":                      ErrNameLength,
+		"Ω":                      ErrNameLength,
+		"Bartholomew-Montgomery": ErrNameLength,
+		"ЖЖЖЖЖЖЖЖЖЖЖЖЖЖЖЖЖЖЖЖЖ": ErrNameLength,
+		"Bob2":        ErrNameInvalid,
+		"Bob Smith":   ErrNameInvalid,
+		"Bob\x1b[31m": ErrNameInvalid,
+		"'Bob":        ErrNameInvalid,
+		"Bob-":        ErrNameInvalid,
+		"Bo--b":       ErrNameInvalid,
+		"\u0308Bob":   ErrNameInvalid,
+		"Bob\xff":     ErrNameInvalid,
+		"Zo\u200be":   ErrNameInvalid,
+		"Shit":        ErrNameProfane,
+		"\u0410lice":  ErrNameScripts,
+		"Bobα":        ErrNameScripts,
+	}
+	for name, expected := range tests {
+		if _, err := ValidateName(name); err != expected {
+			t.Errorf("ValidateName(%q) = %v, want %v", name, err, expected)
+		}
+	}
+}
+
+func TestValidateNameNormalizes(t *testing.T) {
+	name, err := ValidateName("Zoe\u0308")
+	if err != nil || name != "Zo\u00eb" {
+		t.Errorf("Expected the composed form of Zoë, got %q (%v)", name, err)
+	}
+}
@@ -0,0 +1,45 @@
+package character
+
+import (
+	"errors"
+	"slices"
+)
+
+// PracticesPerLevel is how many practice sessions a character starts with
+// and earns with each level they gain
+const PracticesPerLevel = 5
+
+// PracticeExperience is the skill experience one practice session is worth
+const PracticeExperience = 100
+
+var ErrNoPractices = errors.New("no practice sessions left")
+
+// Practice spends one practice session improving a skill under trainer,
+// remembering the trainer on the skill. It reports whether the skill went
+// up a level.
+func (c *Character) Practice(skillType SkillType, trainer string) (bool, error) {
+	skill := c.Skills.GetSkill(skillType)
+	if skill == nil {
+		return false, ErrSkillNotFound
+	}
+	if c.Practices <= 0 {
+		return false, ErrNoPractices
+	}
+
+	c.Practices--
+	return c.Train(skillType, trainer), nil
+}
+
+// Train gives a skill PracticeExperience under trainer without spending a
+// practice session, remembering the trainer on the skill. It reports
+// whether the skill went up a level.
+func (c *Character) Train(skillType SkillType, trainer string) bool {
+	skill := c.Skills.GetSkill(skillType)
+	if skill == nil {
+		return false
+	}
+	if !slices.Contains(skill.Trainers, trainer) {
+		skill.Trainers = append(skill.Trainers, trainer)
+	}
+	return c.Skills.AddExperience(skillType, PracticeExperience)
+}
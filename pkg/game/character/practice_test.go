@@ -0,0 +1,51 @@
+package character
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPractice(t *testing.T) {
+	race, _ := GetRaceByID("human")
+	class, _ := GetClassByID("warrior")
+	char := NewCharacter("player", "Student", race, class)
+	if char.Practices != PracticesPerLevel {
+		t.Fatalf("Expected %d practices to start with, got %d", PracticesPerLevel, char.Practices)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := char.Practice(SkillSwords, "arms_trainer"); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	skill := char.Skills.GetSkill(SkillSwords)
+	if skill.Experience != 2*PracticeExperience || skill.Level != 1 {
+		t.Errorf("Expected level 1 with %d experience, got level %d with %d", 2*PracticeExperience, skill.Level, skill.Experience)
+	}
+	if len(skill.Trainers) != 1 || skill.Trainers[0] != "arms_trainer" {
+		t.Errorf("Expected the trainer to be recorded once, got %v", skill.Trainers)
+	}
+	if char.Practices != PracticesPerLevel-2 {
+		t.Errorf("Expected %d practices left, got %d", PracticesPerLevel-2, char.Practices)
+	}
+
+	char.Practices = 0
+	if _, err := char.Practice(SkillSwords, "arms_trainer"); !errors.Is(err, ErrNoPractices) {
+		t.Errorf("Expected ErrNoPractices, got %v", err)
+	}
+	if skill.Experience != 2*PracticeExperience {
+		t.Errorf("Expected no progress without practices, got %d experience", skill.Experience)
+	}
+}
+
+func TestSkillByName(t *testing.T) {
+	if skill, ok := SkillByName("swords"); !ok || skill != SkillSwords {
+		t.Errorf("Expected swords to be found, got %v %v", skill, ok)
+	}
+	if skill, ok := SkillByName("Lockpicking"); !ok || skill != SkillLockpicking {
+		t.Errorf("Expected Lockpicking to be found, got %v %v", skill, ok)
+	}
+	if _, ok := SkillByName("juggling"); ok {
+		t.Errorf("Expected an unknown skill not to be found")
+	}
+}
@@ -1,5 +1,7 @@
 package character
 
+import "github.com/elidor/dungeogo/pkg/game/damage"
+
 type Race struct {
 	ID            string
 	Name          string
@@ -9,6 +11,9 @@ type Race struct {
 	Lifespan      int
 	Description   string
 	Abilities     []RacialAbility
+	// Resistances turn aside part of the damage of each type the race
+	// resists
+	Resistances   damage.Resistances
 }
 
 type StatModifiers struct {
@@ -48,16 +53,24 @@ const (
 	AbilityMagic
 )
 
+// GetRaceByID returns a copy of the race with id
 func GetRaceByID(id string) (*Race, error) {
-	races := getStandardRaces()
-	if race, exists := races[id]; exists {
-		return race, nil
+	catalog.RLock()
+	defer catalog.RUnlock()
+	if race, exists := catalog.races[id]; exists {
+		return race.clone(), nil
 	}
 	return nil, ErrRaceNotFound
 }
 
 func GetAllRaces() map[string]*Race {
-	return getStandardRaces()
+	catalog.RLock()
+	defer catalog.RUnlock()
+	races := make(map[string]*Race, len(catalog.races))
+	for id, race := range catalog.races {
+		races[id] = race.clone()
+	}
+	return races
 }
 
 func getStandardRaces() map[string]*Race {
@@ -134,6 +147,9 @@ func getStandardRaces() map[string]*Race {
 					Passive:     true,
 				},
 			},
+			Resistances: damage.Resistances{
+				damage.Poison: 50,
+			},
 		},
 	}
 }
\ No newline at end of file
@@ -2,6 +2,8 @@ package character
 
 import (
 	"testing"
+	
+	"github.com/elidor/dungeogo/pkg/game/damage"
 )
 
 func TestGetRaceByID(t *testing.T) {
@@ -186,6 +188,9 @@ func TestDwarfRaceProperties(t *testing.T) {
 	if !hasPoisonResistance {
 		t.Errorf("Expected dwarves to have poison resistance")
 	}
+	if resistance := race.Resistances[damage.Poison]; resistance != 50 {
+		t.Errorf("Expected dwarves to resist 50%% of poison, got %d", resistance)
+	}
 }
 
 func TestSizeCategories(t *testing.T) {
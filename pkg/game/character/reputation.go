@@ -0,0 +1,31 @@
+package character
+
+import "github.com/elidor/dungeogo/pkg/game/faction"
+
+// ReputationWith returns the character's reputation with factionID, or the
+// faction's starting reputation if they have not dealt with it yet.
+func (c *Character) ReputationWith(factionID string) int {
+	if reputation, ok := c.Reputation[factionID]; ok {
+		return reputation
+	}
+	if f, err := faction.GetFaction(factionID); err == nil {
+		return f.Initial
+	}
+	return 0
+}
+
+// StandingWith returns how factionID regards the character.
+func (c *Character) StandingWith(factionID string) faction.Standing {
+	return faction.StandingFor(c.ReputationWith(factionID))
+}
+
+// AdjustReputation changes the character's reputation with factionID by
+// delta, reporting whether their standing changed as a result.
+func (c *Character) AdjustReputation(factionID string, delta int) bool {
+	before := c.StandingWith(factionID)
+	if c.Reputation == nil {
+		c.Reputation = make(map[string]int)
+	}
+	c.Reputation[factionID] = faction.Clamp(c.ReputationWith(factionID) + delta)
+	return c.StandingWith(factionID) != before
+}
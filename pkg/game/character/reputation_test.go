@@ -0,0 +1,29 @@
+package character
+
+import (
+	"testing"
+
+	"github.com/elidor/dungeogo/pkg/game/faction"
+)
+
+func TestAdjustReputation(t *testing.T) {
+	c := &Character{Name: "Bob"}
+	if got := c.ReputationWith(faction.Goblins); got != -250 {
+		t.Errorf("Expected the goblins' starting reputation, got %d", got)
+	}
+
+	if changed := c.AdjustReputation(faction.Militia, 50); changed {
+		t.Errorf("Expected 50 reputation to leave the militia neutral")
+	}
+	if changed := c.AdjustReputation(faction.Militia, 50); !changed {
+		t.Errorf("Expected 100 reputation to make the militia friendly")
+	}
+	if got := c.StandingWith(faction.Militia); got != faction.StandingFriendly {
+		t.Errorf("Expected Friendly, got %v", got)
+	}
+
+	c.AdjustReputation(faction.Goblins, -5000)
+	if got := c.ReputationWith(faction.Goblins); got != faction.MinReputation {
+		t.Errorf("Expected reputation to stop at %d, got %d", faction.MinReputation, got)
+	}
+}
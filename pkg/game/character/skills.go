@@ -1,6 +1,7 @@
 package character
 
 import (
+	"strings"
 	"time"
 )
 
@@ -183,4 +184,15 @@ func GetSkillName(skillType SkillType) string {
 		return name
 	}
 	return "Unknown"
-}
\ No newline at end of file
+}
+
+// SkillByName returns the skill called name, ignoring case.
+func SkillByName(name string) (SkillType, bool) {
+	name = strings.TrimSpace(name)
+	for skillType := SkillSwords; skillType <= SkillMining; skillType++ {
+		if strings.EqualFold(GetSkillName(skillType), name) {
+			return skillType, true
+		}
+	}
+	return 0, false
+}
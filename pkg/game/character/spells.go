@@ -0,0 +1,54 @@
+package character
+
+import "github.com/elidor/dungeogo/pkg/game/magic"
+
+// SpellSkill returns the skill that spells of school draw on
+func SpellSkill(school magic.School) SkillType {
+	switch school {
+	case magic.SchoolHealing:
+		return SkillHealing
+	case magic.SchoolDivination:
+		return SkillDivination
+	default:
+		return SkillEvocation
+	}
+}
+
+// CanLearn reports whether the character's class, level and skills are
+// enough to learn spell. It needs both Magic and the skill of the spell's
+// school at the spell's MinSkill.
+func (c *Character) CanLearn(spell *magic.Spell) bool {
+	if c.Class == nil || !spell.TeachesClass(c.Class.ID) || c.Level < spell.Level {
+		return false
+	}
+	if c.Skills == nil {
+		return spell.MinSkill <= 0
+	}
+	return c.Skills.GetEffectiveSkillLevel(SkillMagic) >= spell.MinSkill &&
+		c.Skills.GetEffectiveSkillLevel(SpellSkill(spell.School)) >= spell.MinSkill
+}
+
+// LearnSpells adds every spell the character can now learn to their
+// spellbook, returning the ones that are new
+func (c *Character) LearnSpells() []*magic.Spell {
+	if c.Spellbook == nil {
+		c.Spellbook = magic.NewSpellbook()
+	}
+	var learned []*magic.Spell
+	for _, spell := range magic.GetAllSpells() {
+		if c.CanLearn(spell) && c.Spellbook.Learn(spell.ID) {
+			learned = append(learned, spell)
+		}
+	}
+	return learned
+}
+
+// SpellSlots returns how many castings the character can hold prepared at
+// once
+func (c *Character) SpellSlots() int {
+	intelligence := 0
+	if c.Stats != nil {
+		intelligence = c.Stats.Effective(StatIntelligence)
+	}
+	return magic.Slots(c.Level, intelligence)
+}
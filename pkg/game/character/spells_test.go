@@ -0,0 +1,55 @@
+package character
+
+import "testing"
+
+func TestNewMageKnowsFirstSpell(t *testing.T) {
+	race, _ := GetRaceByID("human")
+	mage, _ := GetClassByID("mage")
+	char := NewCharacter("player", "Merlin", race, mage)
+	if !char.Spellbook.Knows("magic_missile") {
+		t.Errorf("Expected a new mage to know Magic Missile, got %v", char.Spellbook.Known)
+	}
+	if char.Spellbook.Knows("mend") {
+		t.Errorf("Expected Mend to wait for level 2")
+	}
+
+	warrior, _ := GetClassByID("warrior")
+	if known := NewCharacter("player", "Conan", race, warrior).Spellbook.Known; len(known) != 0 {
+		t.Errorf("Expected a warrior to know no spells, got %v", known)
+	}
+}
+
+func TestLearnSpellsNeedsLevelAndSkills(t *testing.T) {
+	race, _ := GetRaceByID("human")
+	mage, _ := GetClassByID("mage")
+	char := NewCharacter("player", "Merlin", race, mage)
+	char.Level = 3
+
+	if learned := char.LearnSpells(); len(learned) != 0 {
+		t.Fatalf("Expected nothing learned without Magic and Healing, got %d spells", len(learned))
+	}
+
+	char.Skills.GetSkill(SkillMagic).Level = 1
+	char.Skills.GetSkill(SkillHealing).Level = 1
+	learned := char.LearnSpells()
+	if len(learned) != 1 || learned[0].ID != "mend" {
+		t.Fatalf("Expected to learn Mend, got %v", learned)
+	}
+
+	// Fire Bolt needs Evocation as well as Magic
+	char.Skills.GetSkill(SkillMagic).Level = 2
+	if char.LearnSpells() != nil || char.Spellbook.Knows("fire_bolt") {
+		t.Errorf("Expected Fire Bolt to need Evocation 2")
+	}
+	char.Skills.GetSkill(SkillEvocation).Level = 2
+	if learned := char.LearnSpells(); len(learned) != 1 || learned[0].ID != "fire_bolt" {
+		t.Errorf("Expected to learn Fire Bolt, got %v", learned)
+	}
+}
+
+func TestSpellSlots(t *testing.T) {
+	char := &Character{Level: 3, Stats: &CharacterStats{Intelligence: 14}}
+	if got := char.SpellSlots(); got != 5 {
+		t.Errorf("Expected 5 slots, got %d", got)
+	}
+}
@@ -0,0 +1,50 @@
+package character
+
+// DwarfStartRoomID and DwarfStartZoneID are where dwarves begin, in their
+// mountain hold
+const (
+	DwarfStartRoomID = "mountain_hold"
+	DwarfStartZoneID = "ironpeak_hold"
+)
+
+// StartLocations decides where characters begin, keyed by race or class
+// ID. A race's starting place wins over a class's, and everyone else starts
+// at Default.
+type StartLocations struct {
+	Default Location
+	Races   map[string]Location
+	Classes map[string]Location
+}
+
+// DefaultStartLocations starts everyone in the village except dwarves, who
+// start in their hold.
+func DefaultStartLocations() *StartLocations {
+	return &StartLocations{
+		Default: Location{RoomID: DefaultStartRoomID, ZoneID: DefaultStartZoneID},
+		Races: map[string]Location{
+			"dwarf": {RoomID: DwarfStartRoomID, ZoneID: DwarfStartZoneID},
+		},
+		Classes: map[string]Location{},
+	}
+}
+
+// For returns a new copy of where a character of race and class starts.
+func (s *StartLocations) For(race *Race, class *Class) *Location {
+	if race != nil {
+		if start, ok := s.Races[race.ID]; ok {
+			return &start
+		}
+	}
+	if class != nil {
+		if start, ok := s.Classes[class.ID]; ok {
+			return &start
+		}
+	}
+	start := s.Default
+	return &start
+}
+
+// StartLocation is where c begins, and returns to after the tutorial.
+func (s *StartLocations) StartLocation(c *Character) *Location {
+	return s.For(c.Race, c.Class)
+}
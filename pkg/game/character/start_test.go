@@ -0,0 +1,36 @@
+package character
+
+import "testing"
+
+func TestStartLocationsFor(t *testing.T) {
+	human, _ := GetRaceByID("human")
+	dwarf, _ := GetRaceByID("dwarf")
+	warrior, _ := GetClassByID("warrior")
+	mage, _ := GetClassByID("mage")
+
+	starts := DefaultStartLocations()
+	starts.Classes["mage"] = Location{RoomID: "mage_tower", ZoneID: "tower_zone"}
+
+	tests := []struct {
+		race     *Race
+		class    *Class
+		expected string
+	}{
+		{human, warrior, DefaultStartRoomID},
+		{dwarf, warrior, DwarfStartRoomID},
+		{human, mage, "mage_tower"},
+		{dwarf, mage, DwarfStartRoomID},
+	}
+	for _, test := range tests {
+		if got := starts.For(test.race, test.class); got.RoomID != test.expected {
+			t.Errorf("Expected a %s %s to start in %s, got %s", test.race.ID, test.class.ID, test.expected, got.RoomID)
+		}
+	}
+
+	// Each character gets their own location to move around
+	first := starts.For(human, warrior)
+	first.RoomID = "riverbank"
+	if starts.Default.RoomID != DefaultStartRoomID {
+		t.Errorf("Expected the default start to be unaffected, got %s", starts.Default.RoomID)
+	}
+}
@@ -0,0 +1,84 @@
+package character
+
+// StatModifier raises or lowers one of a character's stats for as long as
+// its source lasts, such as an item being worn
+type StatModifier struct {
+	Source string
+	Stat   StatType
+	Value  int
+}
+
+// Base returns stat as it is without any modifiers
+func (s *CharacterStats) Base(stat StatType) int {
+	if value := s.field(stat); value != nil {
+		return *value
+	}
+	return 0
+}
+
+// field returns the field holding stat, or nil for an unknown stat
+func (s *CharacterStats) field(stat StatType) *int {
+	switch stat {
+	case StatStrength:
+		return &s.Strength
+	case StatDexterity:
+		return &s.Dexterity
+	case StatIntelligence:
+		return &s.Intelligence
+	case StatConstitution:
+		return &s.Constitution
+	case StatWisdom:
+		return &s.Wisdom
+	case StatCharisma:
+		return &s.Charisma
+	}
+	return nil
+}
+
+// raise adds one point to stat. Constitution also raises maximum health
+// and stamina, and Intelligence maximum mana, as they would have been had
+// the character started with the point; the current values rise with them.
+func (s *CharacterStats) raise(stat StatType) {
+	value := s.field(stat)
+	if value == nil {
+		return
+	}
+	*value++
+	switch stat {
+	case StatConstitution:
+		s.MaxHealth += healthPerConstitution
+		s.Health += healthPerConstitution
+		s.MaxStamina += staminaPerConstitution
+		s.Stamina += staminaPerConstitution
+	case StatIntelligence:
+		s.MaxMana += manaPerIntelligence
+		s.Mana += manaPerIntelligence
+	}
+}
+
+// Effective returns stat with every modifier applied. This is the value
+// the game plays by.
+func (s *CharacterStats) Effective(stat StatType) int {
+	value := s.Base(stat)
+	for _, modifier := range s.Modifiers {
+		if modifier.Stat == stat {
+			value += modifier.Value
+		}
+	}
+	return value
+}
+
+// SetModifiers replaces the modifiers from source with modifiers
+func (s *CharacterStats) SetModifiers(source string, modifiers []StatModifier) {
+	kept := s.Modifiers[:0]
+	for _, modifier := range s.Modifiers {
+		if modifier.Source != source {
+			kept = append(kept, modifier)
+		}
+	}
+	for _, modifier := range modifiers {
+		modifier.Source = source
+		kept = append(kept, modifier)
+	}
+	s.Modifiers = kept
+}
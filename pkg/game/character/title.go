@@ -0,0 +1,58 @@
+package character
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+
+	"github.com/elidor/dungeogo/pkg/textutil"
+)
+
+const (
+	// MaxTitleLength is the longest title a character may take, in runes
+	MaxTitleLength = 40
+	// TitleMinLevel is the level a character must reach to choose a title
+	TitleMinLevel = 5
+)
+
+var (
+	ErrTitleTooLong = errors.New("title is too long")
+	ErrTitleInvalid = errors.New("title may only contain printable characters")
+	ErrTitleProfane = errors.New("title contains language that is not allowed")
+)
+
+// ValidateTitle checks that title is fit to show beside a name.
+func ValidateTitle(title string) error {
+	if len([]rune(title)) > MaxTitleLength {
+		return ErrTitleTooLong
+	}
+	for _, r := range title {
+		if !unicode.IsPrint(r) {
+			return ErrTitleInvalid
+		}
+	}
+	if textutil.ContainsProfanity(title) {
+		return ErrTitleProfane
+	}
+	return nil
+}
+
+// SetTitle validates title and gives it to the character. An empty title
+// clears it.
+func (c *Character) SetTitle(title string) error {
+	title = strings.Join(strings.Fields(title), " ")
+	if err := ValidateTitle(title); err != nil {
+		return err
+	}
+	c.Title = title
+	return nil
+}
+
+// DisplayName is the character's name followed by their title, if any, as in
+// "Bob the Brave".
+func (c *Character) DisplayName() string {
+	if c.Title == "" {
+		return c.Name
+	}
+	return c.Name + " " + c.Title
+}
@@ -0,0 +1,44 @@
+package character
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSetTitle(t *testing.T) {
+	c := &Character{Name: "Bob"}
+	if got := c.DisplayName(); got != "Bob" {
+		t.Errorf("Expected an untitled character to show just their name, got %q", got)
+	}
+
+	if err := c.SetTitle("  the   Brave "); err != nil {
+		t.Fatalf("SetTitle failed: %v", err)
+	}
+	if got := c.DisplayName(); got != "Bob the Brave" {
+		t.Errorf("Expected 'Bob the Brave', got %q", got)
+	}
+
+	if err := c.SetTitle(""); err != nil {
+		t.Fatalf("Failed to clear title: %v", err)
+	}
+	if c.Title != "" {
+		t.Errorf("Expected title to be cleared, got %q", c.Title)
+	}
+}
+
+func TestSetTitleRejectsInvalid(t *testing.T) {
+	tests := map[string]error{
+		strings.Repeat("a", MaxTitleLength+1): ErrTitleTooLong,
+		"the \x1b[31mRed":                     ErrTitleInvalid,
+		"the Shit":                            ErrTitleProfane,
+	}
+	for title, expected := range tests {
+		c := &Character{Name: "Bob", Title: "the Brave"}
+		if err := c.SetTitle(title); err != expected {
+			t.Errorf("SetTitle(%q) = %v, want %v", title, err, expected)
+		}
+		if c.Title != "the Brave" {
+			t.Errorf("Expected a rejected title to leave the old one, got %q", c.Title)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package character
+
+import (
+	"errors"
+	"slices"
+	"strings"
+)
+
+// StatPointsPerLevel is how many attribute points a character earns with
+// each level they gain, to spend raising their stats with a trainer
+const StatPointsPerLevel = 1
+
+const (
+	// statCapBase is the highest a stat can be trained at level 1
+	statCapBase = 18
+	// statCapLevels is how many levels it takes to raise stat caps by one
+	statCapLevels = 5
+	// primaryStatCapBonus is how much further a class can train the stats
+	// it relies on
+	primaryStatCapBonus = 2
+)
+
+// Each point of a stat is worth this much of the maxima that grow from it
+const (
+	healthPerConstitution  = 10
+	staminaPerConstitution = 5
+	manaPerIntelligence    = 5
+)
+
+var (
+	ErrNoStatPoints = errors.New("no attribute points left")
+	ErrStatCapped   = errors.New("stat cannot be raised any further at this level")
+)
+
+// StatByName returns the stat called name, one of StatNames
+func StatByName(name string) (StatType, bool) {
+	i := slices.Index(StatNames, strings.ToLower(strings.TrimSpace(name)))
+	if i < 0 {
+		return 0, false
+	}
+	return StatType(i), true
+}
+
+// StatName returns the name of stat, as in StatNames
+func StatName(stat StatType) string {
+	if stat < 0 || int(stat) >= len(StatNames) {
+		return "unknown"
+	}
+	return StatNames[stat]
+}
+
+// IsPrimaryStat reports whether the class relies on stat
+func (c *Class) IsPrimaryStat(stat StatType) bool {
+	return c != nil && slices.Contains(c.PrimaryStats, stat)
+}
+
+// StatCap returns the highest a member of the class can train stat by
+// level. Caps rise every statCapLevels levels, and the class's primary
+// stats can go further than the rest.
+func (c *Class) StatCap(stat StatType, level int) int {
+	limit := statCapBase + max(level, 1)/statCapLevels
+	if c.IsPrimaryStat(stat) {
+		limit += primaryStatCapBonus
+	}
+	return limit
+}
+
+// StatCap returns the highest the character can train stat at their level
+func (c *Character) StatCap(stat StatType) int {
+	return c.Class.StatCap(stat, c.Level)
+}
+
+// TrainStat spends one attribute point raising stat and returns its new
+// value. Stats already at their cap can't be trained.
+func (c *Character) TrainStat(stat StatType) (int, error) {
+	if c.StatPoints <= 0 {
+		return 0, ErrNoStatPoints
+	}
+	value, err := c.raiseStat(stat)
+	if err != nil {
+		return 0, err
+	}
+	c.StatPoints--
+	return value, nil
+}
+
+// raiseStat adds one point to stat, up to its cap, along with the maxima
+// that grow from it
+func (c *Character) raiseStat(stat StatType) (int, error) {
+	if c.Stats.Base(stat) >= c.StatCap(stat) {
+		return 0, ErrStatCapped
+	}
+	c.Stats.raise(stat)
+	return c.Stats.Base(stat), nil
+}
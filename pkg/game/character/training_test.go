@@ -0,0 +1,111 @@
+package character
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestStatByName(t *testing.T) {
+	if stat, ok := StatByName(" Constitution "); !ok || stat != StatConstitution {
+		t.Errorf("Expected constitution, got %v %v", stat, ok)
+	}
+	if _, ok := StatByName("luck"); ok {
+		t.Errorf("Expected luck not to be a stat")
+	}
+	for _, name := range StatNames {
+		stat, _ := StatByName(name)
+		if StatName(stat) != name {
+			t.Errorf("Expected %s to name itself, got %s", name, StatName(stat))
+		}
+	}
+}
+
+func TestStatCap(t *testing.T) {
+	class, _ := GetClassByID("warrior")
+	tests := []struct {
+		stat     StatType
+		level    int
+		expected int
+	}{
+		{StatDexterity, 1, 18},
+		{StatDexterity, 4, 18},
+		{StatDexterity, 5, 19},
+		{StatDexterity, 50, 28},
+		{StatStrength, 1, 20},
+		{StatConstitution, 10, 22},
+	}
+	for _, tt := range tests {
+		if got := class.StatCap(tt.stat, tt.level); got != tt.expected {
+			t.Errorf("StatCap(%s, %d): expected %d, got %d", StatName(tt.stat), tt.level, tt.expected, got)
+		}
+	}
+
+	var classless *Class
+	if got := classless.StatCap(StatStrength, 1); got != 18 {
+		t.Errorf("Expected no primary stats without a class, got cap %d", got)
+	}
+}
+
+func TestTrainStat(t *testing.T) {
+	race, _ := GetRaceByID("human")
+	class, _ := GetClassByID("warrior")
+	char := NewCharacter("player", "Lifter", race, class)
+
+	if _, err := char.TrainStat(StatConstitution); !errors.Is(err, ErrNoStatPoints) {
+		t.Errorf("Expected a new character to have no attribute points, got %v", err)
+	}
+
+	char.Experience = ExperienceForLevel(2)
+	char.LevelUp()
+	if char.StatPoints != StatPointsPerLevel {
+		t.Fatalf("Expected a level to earn %d attribute points, got %d", StatPointsPerLevel, char.StatPoints)
+	}
+
+	stats := *char.Stats
+	value, err := char.TrainStat(StatConstitution)
+	if err != nil || value != stats.Constitution+1 {
+		t.Fatalf("Expected constitution %d, got %d (%v)", stats.Constitution+1, value, err)
+	}
+	if char.StatPoints != 0 {
+		t.Errorf("Expected the attribute point to be spent, got %d left", char.StatPoints)
+	}
+	if char.Stats.MaxHealth != stats.MaxHealth+healthPerConstitution || char.Stats.Health != stats.Health+healthPerConstitution ||
+		char.Stats.MaxStamina != stats.MaxStamina+staminaPerConstitution {
+		t.Errorf("Expected constitution to raise health and stamina, got %d/%d health and %d stamina",
+			char.Stats.Health, char.Stats.MaxHealth, char.Stats.MaxStamina)
+	}
+	if char.Stats.MaxMana != stats.MaxMana {
+		t.Errorf("Expected mana to stay at %d, got %d", stats.MaxMana, char.Stats.MaxMana)
+	}
+}
+
+func TestTrainStatCap(t *testing.T) {
+	race, _ := GetRaceByID("human")
+	class, _ := GetClassByID("warrior")
+	char := NewCharacter("player", "Lifter", race, class)
+	char.StatPoints = 2
+	char.Stats.Dexterity = 18
+	char.Stats.Strength = 18
+
+	if _, err := char.TrainStat(StatDexterity); !errors.Is(err, ErrStatCapped) {
+		t.Errorf("Expected dexterity to be capped at level 1, got %v", err)
+	}
+	if char.StatPoints != 2 || char.Stats.Dexterity != 18 {
+		t.Errorf("Expected a capped stat to cost nothing")
+	}
+
+	// Strength is a warrior's primary stat, so it trains further
+	if value, err := char.TrainStat(StatStrength); err != nil || value != 19 {
+		t.Errorf("Expected strength 19, got %d (%v)", value, err)
+	}
+
+	char.Level = 5
+	if value, err := char.TrainStat(StatDexterity); err != nil || value != 19 {
+		t.Errorf("Expected the cap to rise at level 5, got %d (%v)", value, err)
+	}
+
+	char.Stats.Wisdom = 19
+	if _, err := char.RaiseStat("wisdom"); !errors.Is(err, ErrStatCapped) {
+		t.Errorf("Expected gaining a level to respect the cap too, got %v", err)
+	}
+}
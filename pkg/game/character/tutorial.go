@@ -0,0 +1,27 @@
+package character
+
+const (
+	TutorialRoomID = "tutorial_room"
+	TutorialZoneID = "tutorial_zone"
+)
+
+// StartTutorial places the character at the first onboarding step in the
+// tutorial zone.
+func (c *Character) StartTutorial() {
+	c.TutorialStep = 1
+	c.Location = &Location{
+		RoomID: TutorialRoomID,
+		ZoneID: TutorialZoneID,
+	}
+}
+
+func (c *Character) InTutorial() bool {
+	return c.TutorialStep > 0
+}
+
+// EndTutorial clears tutorial progress and moves the character to where
+// they would have started without it.
+func (c *Character) EndTutorial(starts *StartLocations) {
+	c.TutorialStep = 0
+	c.Location = starts.StartLocation(c)
+}
@@ -0,0 +1,124 @@
+// Package combat works out what happens when characters trade blows.
+package combat
+
+import (
+	"github.com/elidor/dungeogo/pkg/game/character"
+	"github.com/elidor/dungeogo/pkg/game/items"
+)
+
+const (
+	// unarmedDamage is the most a bare-handed blow can do
+	unarmedDamage = 2
+	// Every strengthPerDamage points of Strength above baseStrength add
+	// one damage to each hit.
+	baseStrength      = 10
+	strengthPerDamage = 2
+
+	sneakAttackAbilityID = "sneak_attack"
+	// sneakAttackBase is the least a sneak attack adds; the attacker's level
+	// and Stealth skill add to it.
+	sneakAttackBase = 2
+	// daggerMultiplier scales the sneak attack bonus for dagger strikes,
+	// after the Daggers skill is added.
+	daggerMultiplier = 2
+
+	// Off-hand blows do offHandDamagePercent of a normal hit, without the
+	// Strength bonus, and land offHandHitChance percent of the time plus
+	// offHandHitPerDexterity for each point of Dexterity above
+	// baseDexterity, up to offHandMaxHitChance.
+	offHandDamagePercent   = 50
+	offHandHitChance       = 40
+	offHandHitPerDexterity = 5
+	offHandMaxHitChance    = 90
+	baseDexterity          = 10
+)
+
+// Strike describes one attack.
+type Strike struct {
+	Attacker *character.Character
+	// Weapon is the template of the wielded weapon, or nil when unarmed
+	Weapon *items.ItemTemplate
+	// FromStealth is set when the attacker was hidden
+	FromStealth bool
+	// Opening is set for the first blow of a fight
+	Opening bool
+	// OffHand is set for a blow with a weapon held in the off hand
+	OffHand bool
+	// Defensive is set when the attacker is holding back to defend
+	Defensive bool
+}
+
+// BaseDamage rolls the damage of an ordinary hit. roll returns a number from
+// 0 to n-1.
+func BaseDamage(strike Strike, roll func(n int) int) int {
+	maxDamage := unarmedDamage
+	if strike.Weapon != nil && strike.Weapon.BaseStats.Damage > 0 {
+		maxDamage = strike.Weapon.BaseStats.Damage
+	}
+
+	damage := roll(maxDamage) + 1
+	if strike.OffHand {
+		return max(damage*offHandDamagePercent/100, 1)
+	}
+	if stats := strike.Attacker.Stats; stats != nil {
+		if strength := stats.Effective(character.StatStrength); strength > baseStrength {
+			damage += (strength - baseStrength) / strengthPerDamage
+		}
+	}
+	return damage
+}
+
+// OffHandHitChance returns the percent chance that the attacker's off-hand
+// blow lands.
+func OffHandHitChance(attacker *character.Character) int {
+	chance := offHandHitChance
+	if stats := attacker.Stats; stats != nil {
+		if dexterity := stats.Effective(character.StatDexterity); dexterity > baseDexterity {
+			chance += (dexterity - baseDexterity) * offHandHitPerDexterity
+		}
+	}
+	return min(chance, offHandMaxHitChance)
+}
+
+// OffHandHits rolls whether the attacker's off-hand blow lands.
+func OffHandHits(attacker *character.Character, roll func(n int) int) bool {
+	return roll(100) < OffHandHitChance(attacker)
+}
+
+// SneakAttackBonus returns the extra damage of a sneak attack. Only
+// characters with the ability get it, only when striking from stealth or
+// opening a fight, and never with the off hand.
+func SneakAttackBonus(strike Strike) int {
+	if !(strike.FromStealth || strike.Opening) || strike.OffHand || !hasSneakAttack(strike.Attacker) {
+		return 0
+	}
+
+	skills := strike.Attacker.Skills
+	bonus := sneakAttackBase + strike.Attacker.Level + skills.GetEffectiveSkillLevel(character.SkillStealth)
+	if strike.Weapon != nil && strike.Weapon.WeaponClass == items.WeaponDagger {
+		bonus = (bonus + skills.GetEffectiveSkillLevel(character.SkillDaggers)) * daggerMultiplier
+	}
+	return bonus
+}
+
+// Damage rolls the full damage of strike. Blows struck from a defensive
+// stance do less.
+func Damage(strike Strike, roll func(n int) int) int {
+	damage := BaseDamage(strike, roll) + SneakAttackBonus(strike)
+	if strike.Defensive {
+		damage = max(damage*defendDamagePercent/100, 1)
+	}
+	return damage
+}
+
+func hasSneakAttack(attacker *character.Character) bool {
+	if attacker.Class == nil {
+		return false
+	}
+	for _, ability := range attacker.Class.Abilities {
+		if ability.ID == sneakAttackAbilityID && attacker.Level >= ability.Level {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,110 @@
+package combat
+
+import (
+	"testing"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+	"github.com/elidor/dungeogo/pkg/game/items"
+)
+
+func newCharacter(t *testing.T, classID string) *character.Character {
+	race, _ := character.GetRaceByID("human")
+	class, err := character.GetClassByID(classID)
+	if err != nil {
+		t.Fatalf("Failed to get class %s: %v", classID, err)
+	}
+	char := character.NewCharacter("player1", "Shade", race, class)
+	char.Stats.Strength = baseStrength
+	return char
+}
+
+func maxRoll(n int) int { return n - 1 }
+
+func TestBaseDamage(t *testing.T) {
+	attacker := newCharacter(t, "warrior")
+	sword := &items.ItemTemplate{BaseStats: items.ItemStats{Damage: 5}}
+
+	if damage := BaseDamage(Strike{Attacker: attacker}, maxRoll); damage != unarmedDamage {
+		t.Errorf("Expected unarmed damage %d, got %d", unarmedDamage, damage)
+	}
+	if damage := BaseDamage(Strike{Attacker: attacker, Weapon: sword}, maxRoll); damage != 5 {
+		t.Errorf("Expected sword damage 5, got %d", damage)
+	}
+
+	attacker.Stats.Strength = baseStrength + 4
+	if damage := BaseDamage(Strike{Attacker: attacker, Weapon: sword}, maxRoll); damage != 7 {
+		t.Errorf("Expected Strength to add 2 damage, got %d", damage)
+	}
+}
+
+func TestSneakAttackBonus(t *testing.T) {
+	rogue := newCharacter(t, "rogue")
+	rogue.Level = 3
+	rogue.Skills.GetSkill(character.SkillStealth).Level = 2
+	rogue.Skills.GetSkill(character.SkillDaggers).Level = 1
+	sword := &items.ItemTemplate{WeaponClass: items.WeaponSword}
+	dagger := &items.ItemTemplate{WeaponClass: items.WeaponDagger}
+
+	if bonus := SneakAttackBonus(Strike{Attacker: rogue, Weapon: sword}); bonus != 0 {
+		t.Errorf("Expected no bonus mid-fight in the open, got %d", bonus)
+	}
+
+	expected := sneakAttackBase + 3 + 2
+	if bonus := SneakAttackBonus(Strike{Attacker: rogue, Weapon: sword, FromStealth: true}); bonus != expected {
+		t.Errorf("Expected bonus %d from stealth, got %d", expected, bonus)
+	}
+	if bonus := SneakAttackBonus(Strike{Attacker: rogue, Weapon: sword, Opening: true}); bonus != expected {
+		t.Errorf("Expected bonus %d on the opening strike, got %d", expected, bonus)
+	}
+
+	expected = (expected + 1) * daggerMultiplier
+	if bonus := SneakAttackBonus(Strike{Attacker: rogue, Weapon: dagger, FromStealth: true}); bonus != expected {
+		t.Errorf("Expected dagger bonus %d, got %d", expected, bonus)
+	}
+}
+
+func TestSneakAttackRogueOnly(t *testing.T) {
+	warrior := newCharacter(t, "warrior")
+	dagger := &items.ItemTemplate{WeaponClass: items.WeaponDagger}
+
+	if bonus := SneakAttackBonus(Strike{Attacker: warrior, Weapon: dagger, FromStealth: true}); bonus != 0 {
+		t.Errorf("Expected non-rogues to get no bonus, got %d", bonus)
+	}
+}
+
+func TestOffHandStrike(t *testing.T) {
+	rogue := newCharacter(t, "rogue")
+	rogue.Stats.Strength = baseStrength + 4
+	dagger := &items.ItemTemplate{BaseStats: items.ItemStats{Damage: 6}, WeaponClass: items.WeaponDagger}
+
+	if damage := BaseDamage(Strike{Attacker: rogue, Weapon: dagger, OffHand: true}, maxRoll); damage != 3 {
+		t.Errorf("Expected an off-hand blow to do half damage without Strength, got %d", damage)
+	}
+	if damage := BaseDamage(Strike{Attacker: rogue, OffHand: true}, func(int) int { return 0 }); damage != 1 {
+		t.Errorf("Expected an off-hand blow to do at least 1 damage, got %d", damage)
+	}
+	if bonus := SneakAttackBonus(Strike{Attacker: rogue, Weapon: dagger, Opening: true, OffHand: true}); bonus != 0 {
+		t.Errorf("Expected no sneak attack with the off hand, got %d", bonus)
+	}
+
+	rogue.Stats.Dexterity = baseDexterity
+	if chance := OffHandHitChance(rogue); chance != offHandHitChance {
+		t.Errorf("Expected a %d%% chance, got %d%%", offHandHitChance, chance)
+	}
+	rogue.Stats.Dexterity = baseDexterity + 4
+	if chance := OffHandHitChance(rogue); chance != 60 {
+		t.Errorf("Expected Dexterity to raise the chance to 60%%, got %d%%", chance)
+	}
+	rogue.Stats.Dexterity = 40
+	if chance := OffHandHitChance(rogue); chance != offHandMaxHitChance {
+		t.Errorf("Expected the chance to be capped at %d%%, got %d%%", offHandMaxHitChance, chance)
+	}
+
+	rogue.Stats.Dexterity = baseDexterity
+	if !OffHandHits(rogue, func(int) int { return offHandHitChance - 1 }) {
+		t.Errorf("Expected a roll under the chance to hit")
+	}
+	if OffHandHits(rogue, func(int) int { return offHandHitChance }) {
+		t.Errorf("Expected a roll at the chance to miss")
+	}
+}
@@ -0,0 +1,67 @@
+package combat
+
+import (
+	"slices"
+	"sync"
+)
+
+// Duels tracks which players are fighting each other. Like stances, duels
+// are not saved: they last until one side wins, leaves or the fight is
+// stopped.
+type Duels struct {
+	mutex sync.Mutex
+	foes  map[string][]string
+}
+
+func NewDuels() *Duels {
+	return &Duels{foes: make(map[string][]string)}
+}
+
+// Start records that a and b are fighting each other.
+func (d *Duels) Start(a, b string) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if !slices.Contains(d.foes[a], b) {
+		d.foes[a] = append(d.foes[a], b)
+		d.foes[b] = append(d.foes[b], a)
+	}
+}
+
+// Foes returns the players characterID is fighting.
+func (d *Duels) Foes(characterID string) []string {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return slices.Clone(d.foes[characterID])
+}
+
+// End ends the fight between a and b.
+func (d *Duels) End(a, b string) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.remove(a, b)
+	d.remove(b, a)
+}
+
+// Forget ends every fight characterID is in, returning their foes.
+func (d *Duels) Forget(characterID string) []string {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	foes := d.foes[characterID]
+	for _, foe := range foes {
+		d.remove(foe, characterID)
+	}
+	delete(d.foes, characterID)
+	return foes
+}
+
+// remove drops foe from characterID's foes. The caller must hold the mutex.
+func (d *Duels) remove(characterID, foe string) {
+	foes := slices.DeleteFunc(d.foes[characterID], func(id string) bool { return id == foe })
+	if len(foes) == 0 {
+		delete(d.foes, characterID)
+		return
+	}
+	d.foes[characterID] = foes
+}
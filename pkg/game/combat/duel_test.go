@@ -0,0 +1,35 @@
+package combat
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDuels(t *testing.T) {
+	duels := NewDuels()
+	duels.Start("alice", "bob")
+	duels.Start("bob", "alice")
+	duels.Start("alice", "carol")
+
+	if foes := duels.Foes("alice"); !slices.Equal(foes, []string{"bob", "carol"}) {
+		t.Errorf("Expected alice to fight bob and carol once each, got %v", foes)
+	}
+	if foes := duels.Foes("bob"); !slices.Equal(foes, []string{"alice"}) {
+		t.Errorf("Expected bob to fight alice, got %v", foes)
+	}
+
+	duels.End("bob", "alice")
+	if foes := duels.Foes("alice"); !slices.Equal(foes, []string{"carol"}) {
+		t.Errorf("Expected alice to fight only carol, got %v", foes)
+	}
+	if foes := duels.Foes("bob"); len(foes) != 0 {
+		t.Errorf("Expected bob to fight no one, got %v", foes)
+	}
+
+	if foes := duels.Forget("carol"); !slices.Equal(foes, []string{"alice"}) {
+		t.Errorf("Expected carol's foes to be returned, got %v", foes)
+	}
+	if foes := duels.Foes("alice"); len(foes) != 0 {
+		t.Errorf("Expected alice to fight no one, got %v", foes)
+	}
+}
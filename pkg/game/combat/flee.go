@@ -0,0 +1,45 @@
+package combat
+
+import (
+	"github.com/elidor/dungeogo/pkg/game/character"
+)
+
+const (
+	// A character escapes a fight fleeBaseChance percent of the time, plus
+	// fleePerDodge for each level of Dodge, plus up to fleeStaminaChance
+	// scaled by how much stamina they have left, between fleeMinChance and
+	// fleeMaxChance.
+	fleeBaseChance    = 30
+	fleePerDodge      = 5
+	fleeStaminaChance = 40
+	fleeMinChance     = 5
+	fleeMaxChance     = 95
+
+	// FleeStaminaCost is the stamina spent by escaping
+	FleeStaminaCost = 10
+)
+
+// FleeChance returns the percent chance that char escapes a fight.
+func FleeChance(char *character.Character) int {
+	chance := fleeBaseChance
+	if char.Skills != nil {
+		chance += char.Skills.GetEffectiveSkillLevel(character.SkillDodge) * fleePerDodge
+	}
+	if stats := char.Stats; stats != nil && stats.MaxStamina > 0 {
+		chance += fleeStaminaChance * stats.Stamina / stats.MaxStamina
+	}
+	return min(max(chance, fleeMinChance), fleeMaxChance)
+}
+
+// Flees rolls whether char escapes a fight.
+func Flees(char *character.Character, roll func(n int) int) bool {
+	return roll(100) < FleeChance(char)
+}
+
+// SpendFleeStamina takes the stamina an escape costs from char, stopping at
+// none.
+func SpendFleeStamina(char *character.Character) {
+	if char.Stats != nil {
+		char.Stats.Stamina = max(char.Stats.Stamina-FleeStaminaCost, 0)
+	}
+}
@@ -0,0 +1,46 @@
+package combat
+
+import (
+	"testing"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+)
+
+func TestFleeChance(t *testing.T) {
+	char := newCharacter(t, "warrior")
+	if chance := FleeChance(char); chance != fleeBaseChance+fleeStaminaChance {
+		t.Errorf("Expected %d%% at full stamina, got %d%%", fleeBaseChance+fleeStaminaChance, chance)
+	}
+
+	char.Stats.Stamina = char.Stats.MaxStamina / 2
+	if chance := FleeChance(char); chance != fleeBaseChance+fleeStaminaChance/2 {
+		t.Errorf("Expected half stamina to halve its share, got %d%%", chance)
+	}
+
+	char.Skills.GetSkill(character.SkillDodge).Level = 4
+	if chance := FleeChance(char); chance != fleeBaseChance+fleeStaminaChance/2+4*fleePerDodge {
+		t.Errorf("Expected Dodge to raise the chance, got %d%%", chance)
+	}
+
+	char.Skills.GetSkill(character.SkillDodge).Level = 50
+	if chance := FleeChance(char); chance != fleeMaxChance {
+		t.Errorf("Expected the chance to be capped at %d%%, got %d%%", fleeMaxChance, chance)
+	}
+
+	if !Flees(char, func(int) int { return fleeMaxChance - 1 }) || Flees(char, func(int) int { return fleeMaxChance }) {
+		t.Errorf("Expected rolls under the chance to escape and others not to")
+	}
+}
+
+func TestSpendFleeStamina(t *testing.T) {
+	char := newCharacter(t, "warrior")
+	char.Stats.Stamina = FleeStaminaCost + 3
+	SpendFleeStamina(char)
+	if char.Stats.Stamina != 3 {
+		t.Errorf("Expected 3 stamina left, got %d", char.Stats.Stamina)
+	}
+	SpendFleeStamina(char)
+	if char.Stats.Stamina != 0 {
+		t.Errorf("Expected stamina to stop at 0, got %d", char.Stats.Stamina)
+	}
+}
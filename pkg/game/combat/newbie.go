@@ -0,0 +1,33 @@
+package combat
+
+import "github.com/elidor/dungeogo/pkg/game/character"
+
+const (
+	// DefaultNewbieLevel is the level at which characters lose newbie
+	// protection
+	DefaultNewbieLevel = 5
+	// DefaultNewbieLevelGap is how many levels above a protected character
+	// an aggressive NPC must be to leave them alone
+	DefaultNewbieLevelGap = 5
+)
+
+// NewbieProtection shields new characters while they find their feet.
+// Below Level they can neither attack other players nor be attacked by
+// them, and aggressive NPCs more than LevelGap levels above them leave them
+// alone. It wears off by itself once they reach Level; a Level of 0 or 1
+// protects no one.
+type NewbieProtection struct {
+	Level    int
+	LevelGap int
+}
+
+// Protects reports whether char is under newbie protection
+func (p NewbieProtection) Protects(char *character.Character) bool {
+	return char.Level < p.Level
+}
+
+// Shields reports whether an aggressive NPC of npcLevel should leave char
+// alone
+func (p NewbieProtection) Shields(char *character.Character, npcLevel int) bool {
+	return p.Protects(char) && npcLevel-char.Level > p.LevelGap
+}
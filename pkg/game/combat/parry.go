@@ -0,0 +1,51 @@
+package combat
+
+import (
+	"github.com/elidor/dungeogo/pkg/game/character"
+	"github.com/elidor/dungeogo/pkg/game/items"
+)
+
+const (
+	// A defender with a melee weapon parries parryBaseChance percent of
+	// blows, plus parryPerSkill for each level of Parry and
+	// parryDefendBonus while defending, up to parryMaxChance.
+	parryBaseChance  = 5
+	parryPerSkill    = 4
+	parryDefendBonus = 10
+	parryMaxChance   = 60
+
+	// ParryExperience is the Parry experience earned by turning a blow
+	ParryExperience = 5
+)
+
+// CanParryWith reports whether weapon is fit to turn aside a blow. Bare
+// hands and bows are not.
+func CanParryWith(weapon *items.ItemTemplate) bool {
+	if weapon == nil || weapon.Type != items.ItemWeapon {
+		return false
+	}
+	return weapon.WeaponClass != items.WeaponBow && weapon.WeaponClass != items.WeaponCrossbow
+}
+
+// ParryChance returns the percent chance that defender, wielding weapon,
+// parries a melee blow. It is 0 without a weapon fit to parry with.
+func ParryChance(defender *character.Character, weapon *items.ItemTemplate, defending bool) int {
+	if !CanParryWith(weapon) {
+		return 0
+	}
+	chance := parryBaseChance
+	if defender.Skills != nil {
+		chance += defender.Skills.GetEffectiveSkillLevel(character.SkillParry) * parryPerSkill
+	}
+	if defending {
+		chance += parryDefendBonus
+	}
+	return min(chance, parryMaxChance)
+}
+
+// Parries rolls whether defender parries a melee blow, turning it aside
+// completely.
+func Parries(defender *character.Character, weapon *items.ItemTemplate, defending bool, roll func(n int) int) bool {
+	chance := ParryChance(defender, weapon, defending)
+	return chance > 0 && roll(100) < chance
+}
@@ -0,0 +1,52 @@
+package combat
+
+import (
+	"testing"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+	"github.com/elidor/dungeogo/pkg/game/items"
+)
+
+func TestParryChance(t *testing.T) {
+	defender := newCharacter(t, "warrior")
+	sword := &items.ItemTemplate{Type: items.ItemWeapon, WeaponClass: items.WeaponSword}
+	bow := &items.ItemTemplate{Type: items.ItemWeapon, WeaponClass: items.WeaponBow}
+
+	if chance := ParryChance(defender, nil, true); chance != 0 {
+		t.Errorf("Expected no parry bare-handed, got %d%%", chance)
+	}
+	if chance := ParryChance(defender, bow, false); chance != 0 {
+		t.Errorf("Expected no parry with a bow, got %d%%", chance)
+	}
+	if chance := ParryChance(defender, sword, false); chance != parryBaseChance {
+		t.Errorf("Expected the base %d%% with a sword, got %d%%", parryBaseChance, chance)
+	}
+
+	defender.Skills.GetSkill(character.SkillParry).Level = 5
+	if chance := ParryChance(defender, sword, false); chance != 25 {
+		t.Errorf("Expected Parry 5 to give 25%%, got %d%%", chance)
+	}
+	if chance := ParryChance(defender, sword, true); chance != 35 {
+		t.Errorf("Expected defending to add %d%%, got %d%%", parryDefendBonus, chance)
+	}
+
+	defender.Skills.GetSkill(character.SkillParry).Level = 50
+	if chance := ParryChance(defender, sword, true); chance != parryMaxChance {
+		t.Errorf("Expected the chance to be capped at %d%%, got %d%%", parryMaxChance, chance)
+	}
+}
+
+func TestParries(t *testing.T) {
+	defender := newCharacter(t, "warrior")
+	sword := &items.ItemTemplate{Type: items.ItemWeapon, WeaponClass: items.WeaponSword}
+
+	if !Parries(defender, sword, false, func(int) int { return parryBaseChance - 1 }) {
+		t.Errorf("Expected a roll under the chance to parry")
+	}
+	if Parries(defender, sword, false, func(int) int { return parryBaseChance }) {
+		t.Errorf("Expected a roll at the chance not to parry")
+	}
+	if Parries(defender, nil, false, func(int) int { return 0 }) {
+		t.Errorf("Expected no parry bare-handed, whatever the roll")
+	}
+}
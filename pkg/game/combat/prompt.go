@@ -0,0 +1,18 @@
+package combat
+
+import (
+	"fmt"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+)
+
+// StatusLine is the prompt shown each round of a fight to players who want
+// it, giving their health and their opponent's, and whether they are
+// defending.
+func StatusLine(char *character.Character, foeHealth, foeMaxHealth int, defending bool) string {
+	line := fmt.Sprintf("[HP: %d/%d] [Enemy: %d/%d]", char.Stats.Health, char.Stats.MaxHealth, foeHealth, foeMaxHealth)
+	if defending {
+		line += " [Defending]"
+	}
+	return line
+}
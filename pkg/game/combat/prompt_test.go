@@ -0,0 +1,17 @@
+package combat
+
+import (
+	"testing"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+)
+
+func TestStatusLine(t *testing.T) {
+	char := &character.Character{Stats: &character.CharacterStats{Health: 45, MaxHealth: 80}}
+	if line := StatusLine(char, 30, 60, false); line != "[HP: 45/80] [Enemy: 30/60]" {
+		t.Errorf("Unexpected status line %q", line)
+	}
+	if line := StatusLine(char, 30, 60, true); line != "[HP: 45/80] [Enemy: 30/60] [Defending]" {
+		t.Errorf("Unexpected status line %q", line)
+	}
+}
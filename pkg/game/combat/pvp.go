@@ -0,0 +1,74 @@
+package combat
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+)
+
+// PvPMode decides whether players may attack each other
+type PvPMode int
+
+const (
+	// PvPOff never lets players attack each other
+	PvPOff PvPMode = iota
+	// PvPOptIn lets players fight only when both have turned PvP on
+	PvPOptIn
+	// PvPOpen lets any player attack another outside safe rooms
+	PvPOpen
+)
+
+// PvPGoldPercent is the share of a defeated player's gold the victor takes.
+// Unlike a death to an NPC, losing to a player costs no experience and drops
+// no items.
+const PvPGoldPercent = 10
+
+var (
+	ErrPvPDisabled      = errors.New("player combat is disabled")
+	ErrPvPSafeRoom      = errors.New("player combat is not allowed in safe rooms")
+	ErrPvPNotFlagged    = errors.New("attacker has not turned pvp on")
+	ErrPvPTargetRefused = errors.New("target has not turned pvp on")
+	ErrPvPNewbie        = errors.New("attacker is under newbie protection")
+	ErrPvPTargetNewbie  = errors.New("target is under newbie protection")
+)
+
+func ParsePvPMode(name string) (PvPMode, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "off", "disabled":
+		return PvPOff, nil
+	case "optin", "opt-in":
+		return PvPOptIn, nil
+	case "open", "on":
+		return PvPOpen, nil
+	default:
+		return PvPOff, fmt.Errorf("unknown pvp mode: %s", name)
+	}
+}
+
+// CanAttackPlayer reports why attacker may not attack target under mode
+// and newbie protection, or nil if they may. safeRoom is whether they stand
+// in a safe room.
+func CanAttackPlayer(mode PvPMode, protection NewbieProtection, attacker, target *character.Character, safeRoom bool) error {
+	switch {
+	case mode == PvPOff:
+		return ErrPvPDisabled
+	case safeRoom:
+		return ErrPvPSafeRoom
+	case protection.Protects(attacker):
+		return ErrPvPNewbie
+	case protection.Protects(target):
+		return ErrPvPTargetNewbie
+	case mode == PvPOptIn && !attacker.PvP:
+		return ErrPvPNotFlagged
+	case mode == PvPOptIn && !target.PvP:
+		return ErrPvPTargetRefused
+	}
+	return nil
+}
+
+// PvPSpoils returns how much of a defeated player's gold goes to the victor
+func PvPSpoils(gold int) int {
+	return gold * PvPGoldPercent / 100
+}
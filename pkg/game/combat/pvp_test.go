@@ -0,0 +1,98 @@
+package combat
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+)
+
+func TestCanAttackPlayer(t *testing.T) {
+	attacker := &character.Character{Name: "Alice"}
+	target := &character.Character{Name: "Bob"}
+
+	tests := []struct {
+		name     string
+		mode     PvPMode
+		attacker bool
+		target   bool
+		safe     bool
+		expected error
+	}{
+		{"disabled server", PvPOff, true, true, false, ErrPvPDisabled},
+		{"safe room", PvPOpen, true, true, true, ErrPvPSafeRoom},
+		{"open server", PvPOpen, false, false, false, nil},
+		{"attacker not flagged", PvPOptIn, false, true, false, ErrPvPNotFlagged},
+		{"target not flagged", PvPOptIn, true, false, false, ErrPvPTargetRefused},
+		{"both flagged", PvPOptIn, true, true, false, nil},
+		{"both flagged in a safe room", PvPOptIn, true, true, true, ErrPvPSafeRoom},
+	}
+	for _, tt := range tests {
+		attacker.PvP, target.PvP = tt.attacker, tt.target
+		if err := CanAttackPlayer(tt.mode, NewbieProtection{}, attacker, target, tt.safe); !errors.Is(err, tt.expected) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.expected, err)
+		}
+	}
+}
+
+func TestCanAttackPlayerNewbies(t *testing.T) {
+	protection := NewbieProtection{Level: 5, LevelGap: 5}
+	veteran := &character.Character{Name: "Alice", Level: 5}
+	newbie := &character.Character{Name: "Bob", Level: 4}
+
+	if err := CanAttackPlayer(PvPOpen, protection, veteran, newbie, false); !errors.Is(err, ErrPvPTargetNewbie) {
+		t.Errorf("Expected a newbie to be protected, got %v", err)
+	}
+	if err := CanAttackPlayer(PvPOpen, protection, newbie, veteran, false); !errors.Is(err, ErrPvPNewbie) {
+		t.Errorf("Expected a newbie not to attack players, got %v", err)
+	}
+	if err := CanAttackPlayer(PvPOff, protection, veteran, newbie, false); !errors.Is(err, ErrPvPDisabled) {
+		t.Errorf("Expected a disabled server to say so first, got %v", err)
+	}
+
+	newbie.Level = 5
+	if err := CanAttackPlayer(PvPOpen, protection, veteran, newbie, false); err != nil {
+		t.Errorf("Expected protection to end at level 5, got %v", err)
+	}
+}
+
+func TestNewbieProtectionShields(t *testing.T) {
+	protection := NewbieProtection{Level: 5, LevelGap: 5}
+	newbie := &character.Character{Level: 2}
+
+	if protection.Shields(newbie, 7) {
+		t.Errorf("Expected a level 7 NPC to be within the gap")
+	}
+	if !protection.Shields(newbie, 8) {
+		t.Errorf("Expected a level 8 NPC to leave a level 2 newbie alone")
+	}
+	newbie.Level = 5
+	if protection.Shields(newbie, 50) {
+		t.Errorf("Expected no shield once protection ends")
+	}
+	if (NewbieProtection{}).Protects(&character.Character{Level: 1}) {
+		t.Errorf("Expected the zero value to protect no one")
+	}
+}
+
+func TestParsePvPMode(t *testing.T) {
+	tests := map[string]PvPMode{"": PvPOff, "off": PvPOff, "OptIn": PvPOptIn, "open": PvPOpen}
+	for input, expected := range tests {
+		mode, err := ParsePvPMode(input)
+		if err != nil || mode != expected {
+			t.Errorf("ParsePvPMode(%q): expected %v, got %v (%v)", input, expected, mode, err)
+		}
+	}
+	if _, err := ParsePvPMode("sometimes"); err == nil {
+		t.Errorf("Expected an error for an unknown mode")
+	}
+}
+
+func TestPvPSpoils(t *testing.T) {
+	if got := PvPSpoils(250); got != 25 {
+		t.Errorf("Expected 25 gold, got %d", got)
+	}
+	if got := PvPSpoils(5); got != 0 {
+		t.Errorf("Expected no gold from a nearly empty purse, got %d", got)
+	}
+}
@@ -0,0 +1,33 @@
+package combat
+
+import (
+	"github.com/elidor/dungeogo/pkg/game/character"
+	"github.com/elidor/dungeogo/pkg/game/damage"
+	"github.com/elidor/dungeogo/pkg/game/items"
+)
+
+// Resistance returns defender's percent resistance to damage of type t:
+// their race's, plus that of the gear they wear and its enchantments.
+// factory looks up the templates of the gear.
+func Resistance(defender *character.Character, t damage.Type, factory *items.ItemFactory) int {
+	resistance := 0
+	if defender.Race != nil {
+		resistance += defender.Race.Resistances[t]
+	}
+	for _, item := range defender.Equipment {
+		if item == nil {
+			continue
+		}
+		resistance += item.ResistanceTo(t)
+		if template, err := factory.GetTemplate(item.TemplateID); err == nil {
+			resistance += template.BaseStats.ResistanceTo(t)
+		}
+	}
+	return resistance
+}
+
+// Resist returns what is left of amount damage of type t once defender's
+// resistance to it has turned some aside
+func Resist(defender *character.Character, amount int, t damage.Type, factory *items.ItemFactory) int {
+	return damage.Mitigate(amount, Resistance(defender, t, factory))
+}
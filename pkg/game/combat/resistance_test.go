@@ -0,0 +1,55 @@
+package combat
+
+import (
+	"testing"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+	"github.com/elidor/dungeogo/pkg/game/damage"
+	"github.com/elidor/dungeogo/pkg/game/items"
+)
+
+func TestRacialResistance(t *testing.T) {
+	factory := items.NewItemFactory()
+	dwarf, _ := character.GetRaceByID("dwarf")
+	warrior, _ := character.GetClassByID("warrior")
+	char := character.NewCharacter("player1", "Gimli", dwarf, warrior)
+
+	if got := Resist(char, 10, damage.Poison, factory); got != 5 {
+		t.Errorf("Expected a dwarf to take half of 10 poison damage, got %d", got)
+	}
+	if got := Resist(char, 10, damage.Physical, factory); got != 10 {
+		t.Errorf("Expected a dwarf to take all of 10 physical damage, got %d", got)
+	}
+	if got := Resist(newCharacter(t, "warrior"), 10, damage.Poison, factory); got != 10 {
+		t.Errorf("Expected a human to take all of 10 poison damage, got %d", got)
+	}
+}
+
+func TestGearResistance(t *testing.T) {
+	factory := items.NewItemFactory()
+	char := newCharacter(t, "mage")
+
+	armor := items.NewItemInstance("leather_armor", char.ID, 1)
+	armor.AddEnchantment(items.Enchantment{ID: "warding", Type: items.EnchantmentResistance, Element: damage.Fire, Power: 30})
+	char.Equip(items.SlotBody, armor)
+	char.Equip(items.SlotMainHand, items.NewItemInstance("magic_staff", char.ID, 1))
+
+	// 30 from the enchantment and 5 from the staff's magic defense
+	if got := Resistance(char, damage.Fire, factory); got != 35 {
+		t.Errorf("Expected 35 fire resistance, got %d", got)
+	}
+	if got := Resist(char, 20, damage.Fire, factory); got != 13 {
+		t.Errorf("Expected 13 of 20 fire damage to get through, got %d", got)
+	}
+	if got := Resist(char, 20, damage.Cold, factory); got != 19 {
+		t.Errorf("Expected only magic defense against cold, got %d", got)
+	}
+	if got := Resist(char, 20, damage.Physical, factory); got != 20 {
+		t.Errorf("Expected no resistance to physical damage, got %d", got)
+	}
+
+	armor.AddEnchantment(items.Enchantment{ID: "greater_warding", Type: items.EnchantmentResistance, Element: damage.Fire, Power: 80})
+	if got := Resist(char, 20, damage.Fire, factory); got != 5 {
+		t.Errorf("Expected resistance to be capped at %d%%, got %d damage", damage.MaxResistance, got)
+	}
+}
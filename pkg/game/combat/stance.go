@@ -0,0 +1,77 @@
+package combat
+
+import (
+	"sync"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+)
+
+const (
+	// A defending character turns aside defendBlockPercent of each blow,
+	// plus defendPerParry for each level of Parry, up to defendMaxBlock.
+	defendBlockPercent = 30
+	defendPerParry     = 5
+	defendMaxBlock     = 75
+	// defendDamagePercent is how hard a defending character still hits
+	defendDamagePercent = 50
+)
+
+// Stances tracks which characters are fighting defensively. Stances are not
+// saved: they last until toggled off or the fight ends.
+type Stances struct {
+	mutex     sync.Mutex
+	defending map[string]bool
+}
+
+func NewStances() *Stances {
+	return &Stances{defending: make(map[string]bool)}
+}
+
+// Toggle switches characterID's defensive stance, reporting whether they
+// are now defending.
+func (s *Stances) Toggle(characterID string) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.defending[characterID] {
+		delete(s.defending, characterID)
+		return false
+	}
+	s.defending[characterID] = true
+	return true
+}
+
+// Defending reports whether characterID is in a defensive stance.
+func (s *Stances) Defending(characterID string) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.defending[characterID]
+}
+
+// Clear drops characterID's stance, as when their fight ends. It reports
+// whether they were defending.
+func (s *Stances) Clear(characterID string) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	defending := s.defending[characterID]
+	delete(s.defending, characterID)
+	return defending
+}
+
+// BlockPercent returns how much of each blow char turns aside while
+// defending.
+func BlockPercent(char *character.Character) int {
+	block := defendBlockPercent
+	if char.Skills != nil {
+		block += char.Skills.GetEffectiveSkillLevel(character.SkillParry) * defendPerParry
+	}
+	return min(block, defendMaxBlock)
+}
+
+// DefendedDamage returns what is left of a blow of damage against char
+// once their guard has turned some of it aside. A blow always does at least
+// 1 damage.
+func DefendedDamage(char *character.Character, damage int) int {
+	return max(damage*(100-BlockPercent(char))/100, 1)
+}
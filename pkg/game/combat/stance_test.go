@@ -0,0 +1,64 @@
+package combat
+
+import (
+	"testing"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+	"github.com/elidor/dungeogo/pkg/game/items"
+)
+
+func TestStances(t *testing.T) {
+	stances := NewStances()
+	if stances.Defending("char1") {
+		t.Errorf("Expected no one to start out defending")
+	}
+	if !stances.Toggle("char1") || !stances.Defending("char1") {
+		t.Errorf("Expected the first toggle to start defending")
+	}
+	if stances.Toggle("char1") || stances.Defending("char1") {
+		t.Errorf("Expected the second toggle to stop defending")
+	}
+
+	stances.Toggle("char1")
+	if !stances.Clear("char1") || stances.Defending("char1") {
+		t.Errorf("Expected Clear to end the stance")
+	}
+	if stances.Clear("char1") {
+		t.Errorf("Expected clearing twice to report no stance")
+	}
+}
+
+func TestDefendedDamage(t *testing.T) {
+	char := newCharacter(t, "warrior")
+	if damage := DefendedDamage(char, 10); damage != 7 {
+		t.Errorf("Expected the guard to turn aside 30%%, leaving 7, got %d", damage)
+	}
+
+	char.Skills.GetSkill(character.SkillParry).Level = 4
+	if block := BlockPercent(char); block != 50 {
+		t.Errorf("Expected Parry to raise the block to 50%%, got %d%%", block)
+	}
+	if damage := DefendedDamage(char, 10); damage != 5 {
+		t.Errorf("Expected 5 damage through a 50%% block, got %d", damage)
+	}
+
+	char.Skills.GetSkill(character.SkillParry).Level = 50
+	if block := BlockPercent(char); block != defendMaxBlock {
+		t.Errorf("Expected the block to be capped at %d%%, got %d%%", defendMaxBlock, block)
+	}
+	if damage := DefendedDamage(char, 1); damage != 1 {
+		t.Errorf("Expected a blow to do at least 1 damage, got %d", damage)
+	}
+}
+
+func TestDefensiveStrike(t *testing.T) {
+	attacker := newCharacter(t, "warrior")
+	sword := &items.ItemTemplate{BaseStats: items.ItemStats{Damage: 8}}
+
+	if damage := Damage(Strike{Attacker: attacker, Weapon: sword, Defensive: true}, maxRoll); damage != 4 {
+		t.Errorf("Expected a defensive blow to do half damage, got %d", damage)
+	}
+	if damage := Damage(Strike{Attacker: attacker, Defensive: true}, func(int) int { return 0 }); damage != 1 {
+		t.Errorf("Expected a defensive blow to do at least 1 damage, got %d", damage)
+	}
+}
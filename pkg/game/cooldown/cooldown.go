@@ -0,0 +1,78 @@
+// Package cooldown keeps track of when characters can next use actions that
+// need time to recover between uses, such as spells, special attacks and
+// recall.
+package cooldown
+
+import (
+	"sync"
+	"time"
+)
+
+// DefaultGlobal is the global cooldown: the short pause after any action
+// with a cooldown before another can be used, so they can't all be
+// unleashed at once
+const DefaultGlobal = 1500 * time.Millisecond
+
+// global is the key the global cooldown is kept under
+const global = ""
+
+// Tracker remembers when each character's actions will be ready again.
+// Actions are named by keys of the caller's choosing, such as
+// "spell:fire_bolt". Cooldowns do not outlast the server.
+type Tracker struct {
+	mutex  sync.Mutex
+	global time.Duration
+	// ready is when each action of each character is ready, by character
+	// ID and then key
+	ready map[string]map[string]time.Time
+}
+
+// NewTracker returns a tracker with a global cooldown of globalCooldown,
+// which may be 0 for none
+func NewTracker(globalCooldown time.Duration) *Tracker {
+	return &Tracker{global: globalCooldown, ready: make(map[string]map[string]time.Time)}
+}
+
+// Remaining returns how long characterID must wait at now before using the
+// action key, taking the global cooldown into account. It is 0 once the
+// action is ready.
+func (t *Tracker) Remaining(characterID, key string, now time.Time) time.Duration {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	ready := t.ready[characterID]
+	until := ready[key]
+	if ready[global].After(until) {
+		until = ready[global]
+	}
+	return max(until.Sub(now), 0)
+}
+
+// Start records that characterID used the action key at now, so it is not
+// ready again for d, and starts the global cooldown
+func (t *Tracker) Start(characterID, key string, d time.Duration, now time.Time) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	ready, ok := t.ready[characterID]
+	if !ok {
+		ready = make(map[string]time.Time)
+		t.ready[characterID] = ready
+	}
+	for k, until := range ready {
+		if !until.After(now) {
+			delete(ready, k)
+		}
+	}
+	if d > 0 {
+		ready[key] = now.Add(d)
+	}
+	if t.global > 0 {
+		ready[global] = now.Add(t.global)
+	}
+}
+
+// Reset makes all of characterID's actions ready at once
+func (t *Tracker) Reset(characterID string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	delete(t.ready, characterID)
+}
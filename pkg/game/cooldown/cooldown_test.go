@@ -0,0 +1,46 @@
+package cooldown
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCooldown(t *testing.T) {
+	tracker := NewTracker(0)
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	if wait := tracker.Remaining("char1", "recall", now); wait != 0 {
+		t.Errorf("Expected an unused action to be ready, got %v", wait)
+	}
+	tracker.Start("char1", "recall", time.Minute, now)
+	if wait := tracker.Remaining("char1", "recall", now.Add(20*time.Second)); wait != 40*time.Second {
+		t.Errorf("Expected 40 seconds left, got %v", wait)
+	}
+	if wait := tracker.Remaining("char2", "recall", now); wait != 0 {
+		t.Errorf("Expected cooldowns to be kept per character, got %v", wait)
+	}
+	if wait := tracker.Remaining("char1", "spell:mend", now); wait != 0 {
+		t.Errorf("Expected cooldowns to be kept per action, got %v", wait)
+	}
+	if wait := tracker.Remaining("char1", "recall", now.Add(time.Minute)); wait != 0 {
+		t.Errorf("Expected the action to be ready once the cooldown passes, got %v", wait)
+	}
+
+	tracker.Reset("char1")
+	if wait := tracker.Remaining("char1", "recall", now); wait != 0 {
+		t.Errorf("Expected a reset to make the action ready, got %v", wait)
+	}
+}
+
+func TestGlobalCooldown(t *testing.T) {
+	tracker := NewTracker(2 * time.Second)
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tracker.Start("char1", "spell:magic_missile", 0, now)
+	if wait := tracker.Remaining("char1", "spell:mend", now.Add(time.Second)); wait != time.Second {
+		t.Errorf("Expected the global cooldown to hold back other actions, got %v", wait)
+	}
+	if wait := tracker.Remaining("char1", "spell:magic_missile", now.Add(2*time.Second)); wait != 0 {
+		t.Errorf("Expected an action with no cooldown of its own to wait only for the global cooldown, got %v", wait)
+	}
+}
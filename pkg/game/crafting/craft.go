@@ -0,0 +1,100 @@
+package crafting
+
+import (
+	"errors"
+
+	"github.com/elidor/dungeogo/pkg/game/items"
+)
+
+var (
+	ErrSkillTooLow      = errors.New("crafting skill too low")
+	ErrMissingMaterials = errors.New("missing materials")
+)
+
+const (
+	// baseSuccessChance is the percent chance of success at exactly the
+	// recipe's minimum skill; each level above it adds skillBonusPerLevel.
+	baseSuccessChance  = 60
+	skillBonusPerLevel = 10
+	maxSuccessChance   = 95
+
+	// failureExperienceDivisor is how much less a failed attempt teaches.
+	failureExperienceDivisor = 4
+)
+
+// Result is the outcome of one crafting attempt.
+type Result struct {
+	Success bool
+	// Consumed maps the ID of each carried item used up to how many of it
+	// were used. A failed attempt spoils about half the materials.
+	Consumed   map[string]int
+	Experience int
+}
+
+// SuccessChance returns the percent chance that a character with skill in
+// Crafting makes recipe.
+func SuccessChance(recipe *Recipe, skill int) int {
+	chance := baseSuccessChance + (skill-recipe.MinSkill)*skillBonusPerLevel
+	if chance > maxSuccessChance {
+		return maxSuccessChance
+	}
+	if chance < 0 {
+		return 0
+	}
+	return chance
+}
+
+// Plan picks which carried items cover the recipe's inputs, returning how
+// many to take from each.
+func Plan(recipe *Recipe, carried []*items.ItemInstance) (map[string]int, error) {
+	plan := make(map[string]int)
+	for _, input := range recipe.Inputs {
+		needed := input.Quantity
+		for _, item := range carried {
+			if needed == 0 {
+				break
+			}
+			if item.TemplateID != input.TemplateID {
+				continue
+			}
+			take := item.Quantity - plan[item.ID]
+			if take > needed {
+				take = needed
+			}
+			if take > 0 {
+				plan[item.ID] += take
+				needed -= take
+			}
+		}
+		if needed > 0 {
+			return nil, ErrMissingMaterials
+		}
+	}
+	return plan, nil
+}
+
+// Attempt works out what happens when a character with skill in Crafting
+// tries recipe with the carried items. roll is a number from 0 to 99; the
+// attempt succeeds when it falls under the success chance.
+func Attempt(recipe *Recipe, skill int, carried []*items.ItemInstance, roll int) (*Result, error) {
+	if skill < recipe.MinSkill {
+		return nil, ErrSkillTooLow
+	}
+
+	plan, err := Plan(recipe, carried)
+	if err != nil {
+		return nil, err
+	}
+
+	if roll < SuccessChance(recipe, skill) {
+		return &Result{Success: true, Consumed: plan, Experience: recipe.Experience}, nil
+	}
+
+	spoiled := make(map[string]int)
+	for itemID, quantity := range plan {
+		if lost := quantity / 2; lost > 0 {
+			spoiled[itemID] = lost
+		}
+	}
+	return &Result{Consumed: spoiled, Experience: recipe.Experience / failureExperienceDivisor}, nil
+}
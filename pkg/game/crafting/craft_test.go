@@ -0,0 +1,145 @@
+package crafting
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/elidor/dungeogo/pkg/game/items"
+)
+
+func material(id, templateID string, quantity int) *items.ItemInstance {
+	item := items.NewItemInstance(templateID, "char1", quantity)
+	item.ID = id
+	return item
+}
+
+func TestDefaultRecipesUseKnownTemplates(t *testing.T) {
+	factory := items.NewItemFactory()
+	for _, recipe := range NewRecipeRegistry().GetAllRecipes() {
+		if _, err := factory.GetTemplate(recipe.Output); err != nil {
+			t.Errorf("Recipe %s makes unknown template %s", recipe.ID, recipe.Output)
+		}
+		for _, input := range recipe.Inputs {
+			if _, err := factory.GetTemplate(input.TemplateID); err != nil {
+				t.Errorf("Recipe %s uses unknown template %s", recipe.ID, input.TemplateID)
+			}
+		}
+	}
+}
+
+func TestRegisterRecipeValidates(t *testing.T) {
+	registry := NewRecipeRegistry()
+
+	invalid := []*Recipe{
+		nil,
+		{ID: "", Output: "iron_ingot", Inputs: []Ingredient{{TemplateID: "iron_ore", Quantity: 1}}},
+		{ID: "x", Output: "", Inputs: []Ingredient{{TemplateID: "iron_ore", Quantity: 1}}},
+		{ID: "x", Output: "iron_ingot"},
+		{ID: "x", Output: "iron_ingot", Inputs: []Ingredient{{TemplateID: "iron_ore", Quantity: 0}}},
+	}
+	for i, recipe := range invalid {
+		if err := registry.RegisterRecipe(recipe); !errors.Is(err, ErrInvalidRecipe) {
+			t.Errorf("Case %d: expected ErrInvalidRecipe, got %v", i, err)
+		}
+	}
+
+	recipe := &Recipe{ID: "nails", Name: "Iron Nails", Output: "iron_ingot",
+		Inputs: []Ingredient{{TemplateID: "iron_ore", Quantity: 1}}}
+	if err := registry.RegisterRecipe(recipe); err != nil {
+		t.Fatalf("Failed to register recipe: %v", err)
+	}
+	if recipe.OutputQuantity != 1 {
+		t.Errorf("Expected output quantity to default to 1, got %d", recipe.OutputQuantity)
+	}
+}
+
+func TestFindRecipe(t *testing.T) {
+	registry := NewRecipeRegistry()
+
+	for _, name := range []string{"iron ingot", "Iron Ingot", "iron_ingot"} {
+		recipe, err := registry.FindRecipe(name)
+		if err != nil || recipe.ID != "iron_ingot" {
+			t.Errorf("FindRecipe(%q) = %v, %v", name, recipe, err)
+		}
+	}
+	if _, err := registry.FindRecipe("mithril crown"); !errors.Is(err, ErrRecipeNotFound) {
+		t.Errorf("Expected ErrRecipeNotFound, got %v", err)
+	}
+}
+
+func TestGetAllRecipesEasiestFirst(t *testing.T) {
+	recipes := NewRecipeRegistry().GetAllRecipes()
+	for i := 1; i < len(recipes); i++ {
+		if recipes[i].MinSkill < recipes[i-1].MinSkill {
+			t.Errorf("Recipes out of order: %s before %s", recipes[i-1].ID, recipes[i].ID)
+		}
+	}
+}
+
+func TestSuccessChance(t *testing.T) {
+	recipe := &Recipe{MinSkill: 2}
+	tests := []struct {
+		skill    int
+		expected int
+	}{
+		{2, 60},
+		{3, 70},
+		{10, 95},
+	}
+	for _, test := range tests {
+		if got := SuccessChance(recipe, test.skill); got != test.expected {
+			t.Errorf("SuccessChance at skill %d = %d, want %d", test.skill, got, test.expected)
+		}
+	}
+}
+
+func TestPlanSpansStacks(t *testing.T) {
+	recipe := &Recipe{Inputs: []Ingredient{{TemplateID: "leather_hide", Quantity: 4}}}
+	carried := []*items.ItemInstance{
+		material("a", "leather_hide", 1),
+		material("b", "iron_ore", 5),
+		material("c", "leather_hide", 5),
+	}
+
+	plan, err := Plan(recipe, carried)
+	if err != nil {
+		t.Fatalf("Plan failed: %v", err)
+	}
+	if plan["a"] != 1 || plan["c"] != 3 || len(plan) != 2 {
+		t.Errorf("Unexpected plan %v", plan)
+	}
+
+	if _, err := Plan(recipe, carried[:2]); !errors.Is(err, ErrMissingMaterials) {
+		t.Errorf("Expected ErrMissingMaterials, got %v", err)
+	}
+}
+
+func TestAttempt(t *testing.T) {
+	recipe := &Recipe{
+		Inputs:     []Ingredient{{TemplateID: "iron_ore", Quantity: 2}},
+		Output:     "iron_ingot",
+		MinSkill:   1,
+		Experience: 20,
+	}
+	carried := []*items.ItemInstance{material("ore", "iron_ore", 3)}
+
+	if _, err := Attempt(recipe, 0, carried, 0); !errors.Is(err, ErrSkillTooLow) {
+		t.Errorf("Expected ErrSkillTooLow, got %v", err)
+	}
+
+	result, err := Attempt(recipe, 1, carried, 59)
+	if err != nil {
+		t.Fatalf("Attempt failed: %v", err)
+	}
+	if !result.Success || result.Consumed["ore"] != 2 || result.Experience != 20 {
+		t.Errorf("Unexpected success result %+v", result)
+	}
+
+	result, err = Attempt(recipe, 1, carried, 60)
+	if err != nil {
+		t.Fatalf("Attempt failed: %v", err)
+	}
+	if result.Success || result.Consumed["ore"] != 1 || result.Experience != 5 {
+		t.Errorf("Unexpected failure result %+v", result)
+	}
+}
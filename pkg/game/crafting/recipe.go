@@ -0,0 +1,150 @@
+// Package crafting turns carried materials into new items. Recipes are data,
+// registered like item templates, and the Crafting skill decides how likely
+// an attempt is to succeed.
+package crafting
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+)
+
+var (
+	ErrRecipeNotFound = errors.New("recipe not found")
+	ErrInvalidRecipe  = errors.New("invalid recipe")
+)
+
+// Ingredient is a quantity of one item template consumed by a recipe.
+type Ingredient struct {
+	TemplateID string
+	Quantity   int
+}
+
+// Recipe makes OutputQuantity of the Output template from its Inputs. A
+// character needs at least MinSkill in Crafting to attempt it, and gains
+// Experience in Crafting when it succeeds.
+type Recipe struct {
+	ID             string
+	Name           string
+	Inputs         []Ingredient
+	Output         string
+	OutputQuantity int
+	MinSkill       int
+	Experience     int
+}
+
+// Matches reports whether name refers to this recipe by ID or name.
+func (r *Recipe) Matches(name string) bool {
+	name = strings.ReplaceAll(strings.TrimSpace(name), "_", " ")
+	return strings.EqualFold(strings.ReplaceAll(r.ID, "_", " "), name) || strings.EqualFold(r.Name, name)
+}
+
+type RecipeRegistry struct {
+	recipes map[string]*Recipe
+	mutex   sync.RWMutex
+}
+
+func NewRecipeRegistry() *RecipeRegistry {
+	registry := &RecipeRegistry{
+		recipes: make(map[string]*Recipe),
+	}
+
+	registry.loadDefaultRecipes()
+	return registry
+}
+
+func (rr *RecipeRegistry) RegisterRecipe(recipe *Recipe) error {
+	if recipe == nil || recipe.ID == "" || recipe.Output == "" || len(recipe.Inputs) == 0 {
+		return ErrInvalidRecipe
+	}
+	for _, input := range recipe.Inputs {
+		if input.TemplateID == "" || input.Quantity <= 0 {
+			return ErrInvalidRecipe
+		}
+	}
+	if recipe.OutputQuantity <= 0 {
+		recipe.OutputQuantity = 1
+	}
+
+	rr.mutex.Lock()
+	defer rr.mutex.Unlock()
+
+	rr.recipes[recipe.ID] = recipe
+	return nil
+}
+
+func (rr *RecipeRegistry) GetRecipe(recipeID string) (*Recipe, error) {
+	rr.mutex.RLock()
+	defer rr.mutex.RUnlock()
+
+	recipe, exists := rr.recipes[recipeID]
+	if !exists {
+		return nil, ErrRecipeNotFound
+	}
+	return recipe, nil
+}
+
+// FindRecipe looks a recipe up by the name a player typed.
+func (rr *RecipeRegistry) FindRecipe(name string) (*Recipe, error) {
+	rr.mutex.RLock()
+	defer rr.mutex.RUnlock()
+
+	for _, recipe := range rr.recipes {
+		if recipe.Matches(name) {
+			return recipe, nil
+		}
+	}
+	return nil, ErrRecipeNotFound
+}
+
+// GetAllRecipes returns every recipe, easiest first.
+func (rr *RecipeRegistry) GetAllRecipes() []*Recipe {
+	rr.mutex.RLock()
+	defer rr.mutex.RUnlock()
+
+	result := make([]*Recipe, 0, len(rr.recipes))
+	for _, recipe := range rr.recipes {
+		result = append(result, recipe)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].MinSkill != result[j].MinSkill {
+			return result[i].MinSkill < result[j].MinSkill
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+func (rr *RecipeRegistry) loadDefaultRecipes() {
+	recipes := []*Recipe{
+		{
+			ID:         "iron_ingot",
+			Name:       "Iron Ingot",
+			Inputs:     []Ingredient{{TemplateID: "iron_ore", Quantity: 2}},
+			Output:     "iron_ingot",
+			MinSkill:   0,
+			Experience: 20,
+		},
+		{
+			ID:         "worn_dagger",
+			Name:       "Worn Dagger",
+			Inputs:     []Ingredient{{TemplateID: "iron_ingot", Quantity: 2}},
+			Output:     "worn_dagger",
+			MinSkill:   1,
+			Experience: 40,
+		},
+		{
+			ID:         "leather_armor",
+			Name:       "Leather Armor",
+			Inputs:     []Ingredient{{TemplateID: "leather_hide", Quantity: 4}},
+			Output:     "leather_armor",
+			MinSkill:   2,
+			Experience: 60,
+		},
+	}
+
+	for _, recipe := range recipes {
+		rr.RegisterRecipe(recipe)
+	}
+}
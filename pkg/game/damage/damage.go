@@ -0,0 +1,75 @@
+// Package damage names the kinds of harm a blow or spell can do, and works
+// out how much of it gets past a defender's resistances.
+package damage
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Type is the kind of damage a blow or spell does
+type Type int
+
+const (
+	Physical Type = iota
+	Fire
+	Cold
+	Lightning
+	Poison
+	Arcane
+)
+
+// MaxResistance is the most of any type of damage resistance can turn
+// aside, in percent
+const MaxResistance = 75
+
+var typeNames = map[Type]string{
+	Physical:  "physical",
+	Fire:      "fire",
+	Cold:      "cold",
+	Lightning: "lightning",
+	Poison:    "poison",
+	Arcane:    "arcane",
+}
+
+func (t Type) String() string {
+	if name, ok := typeNames[t]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// TypeByName returns the type of damage called name, without regard to case
+func TypeByName(name string) (Type, bool) {
+	for t, typeName := range typeNames {
+		if strings.EqualFold(typeName, name) {
+			return t, true
+		}
+	}
+	return Physical, false
+}
+
+// Describe names amount damage of type t for messages, such as "4 damage"
+// for a physical blow or "4 fire damage"
+func Describe(amount int, t Type) string {
+	if t == Physical {
+		return fmt.Sprintf("%d damage", amount)
+	}
+	return fmt.Sprintf("%d %s damage", amount, t)
+}
+
+// Resistances is percent resistance to each type of damage. Types missing
+// from it are not resisted; a negative resistance is a weakness that makes
+// the damage worse.
+type Resistances map[Type]int
+
+// Mitigate returns what is left of amount once resistance percent of it
+// has been turned aside. Resistance is capped at MaxResistance, so a blow
+// that does any damage always does at least 1.
+func Mitigate(amount, resistance int) int {
+	if amount <= 0 {
+		return amount
+	}
+	resistance = min(resistance, MaxResistance)
+	return max(amount*(100-resistance)/100, 1)
+}
@@ -0,0 +1,43 @@
+package damage
+
+import "testing"
+
+func TestMitigate(t *testing.T) {
+	tests := []struct {
+		name                         string
+		amount, resistance, expected int
+	}{
+		{"no resistance", 10, 0, 10},
+		{"half", 10, 50, 5},
+		{"capped", 100, 90, 100 - MaxResistance},
+		{"at least one", 1, 50, 1},
+		{"weakness", 10, -50, 15},
+		{"nothing to resist", 0, 50, 0},
+	}
+	for _, tt := range tests {
+		if got := Mitigate(tt.amount, tt.resistance); got != tt.expected {
+			t.Errorf("%s: expected %d, got %d", tt.name, tt.expected, got)
+		}
+	}
+}
+
+func TestTypeByName(t *testing.T) {
+	if got, ok := TypeByName("Fire"); !ok || got != Fire {
+		t.Errorf("Expected Fire, got %v %v", got, ok)
+	}
+	if _, ok := TypeByName("sonic"); ok {
+		t.Errorf("Expected an unknown type not to be found")
+	}
+	if Poison.String() != "poison" {
+		t.Errorf("Expected poison, got %s", Poison)
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	if got := Describe(4, Physical); got != "4 damage" {
+		t.Errorf("Expected plain damage, got %q", got)
+	}
+	if got := Describe(4, Fire); got != "4 fire damage" {
+		t.Errorf("Expected fire damage, got %q", got)
+	}
+}
@@ -0,0 +1,44 @@
+// Package dice supplies the random numbers behind combat, loot, skill
+// checks and the rest of the game. Every system rolls from one Source, so
+// seeding it makes a run repeatable.
+package dice
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// Source is a random number generator that is safe for concurrent use
+type Source struct {
+	mutex sync.Mutex
+	rng   *rand.Rand
+}
+
+// New returns a source seeded with seed, which always rolls the same
+// numbers in the same order
+func New(seed int64) *Source {
+	return &Source{rng: rand.New(rand.NewSource(seed))}
+}
+
+// NewTimeSeeded returns a source seeded from the current time, for
+// production
+func NewTimeSeeded() *Source {
+	return New(time.Now().UnixNano())
+}
+
+// Intn returns a number from 0 to n-1. It has the signature of the roll
+// functions the game's systems take, so src.Intn can be passed to them.
+func (s *Source) Intn(n int) int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.rng.Intn(n)
+}
+
+// Seed restarts the source from seed. Systems already rolling from it see
+// the new sequence.
+func (s *Source) Seed(seed int64) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.rng = rand.New(rand.NewSource(seed))
+}
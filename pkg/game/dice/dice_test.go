@@ -0,0 +1,41 @@
+package dice
+
+import (
+	"slices"
+	"testing"
+)
+
+func rolls(src *Source, count int) []int {
+	out := make([]int, count)
+	for i := range out {
+		out[i] = src.Intn(100)
+	}
+	return out
+}
+
+func TestSameSeedSameRolls(t *testing.T) {
+	first, second := rolls(New(42), 20), rolls(New(42), 20)
+	if !slices.Equal(first, second) {
+		t.Errorf("Expected the same rolls from the same seed, got %v and %v", first, second)
+	}
+	if other := rolls(New(7), 20); slices.Equal(first, other) {
+		t.Errorf("Expected different seeds to roll differently, got %v twice", first)
+	}
+	for _, roll := range first {
+		if roll < 0 || roll >= 100 {
+			t.Errorf("Expected rolls from 0 to 99, got %d", roll)
+		}
+	}
+}
+
+func TestSeedRestartsSequence(t *testing.T) {
+	src := NewTimeSeeded()
+	roll := src.Intn
+	src.Seed(42)
+	first := []int{roll(100), roll(100), roll(100)}
+	src.Seed(42)
+	second := []int{roll(100), roll(100), roll(100)}
+	if !slices.Equal(first, second) {
+		t.Errorf("Expected reseeding to restart the rolls, got %v and %v", first, second)
+	}
+}
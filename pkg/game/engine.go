@@ -2,45 +2,692 @@ package game
 
 import (
 	"fmt"
+	"log"
+	"slices"
+	"sync"
+	"time"
 	
 	"github.com/elidor/dungeogo/pkg/commands"
+	"github.com/elidor/dungeogo/pkg/game/character"
+	"github.com/elidor/dungeogo/pkg/game/combat"
+	"github.com/elidor/dungeogo/pkg/game/damage"
+	"github.com/elidor/dungeogo/pkg/game/event"
+	"github.com/elidor/dungeogo/pkg/game/faction"
+	"github.com/elidor/dungeogo/pkg/game/items"
+	"github.com/elidor/dungeogo/pkg/game/npc"
+	"github.com/elidor/dungeogo/pkg/game/reward"
+	"github.com/elidor/dungeogo/pkg/game/tutorial"
+	"github.com/elidor/dungeogo/pkg/metrics"
 	"github.com/elidor/dungeogo/pkg/persistence/interfaces"
+	"github.com/elidor/dungeogo/pkg/textutil"
 )
 
 type Engine struct {
 	repoManager interfaces.RepositoryManager
 	parser      *commands.Parser
 	executor    *commands.Executor
+	
+	messenger   commands.Messenger
+	// roll returns a number from 0 to n-1
+	roll func(n int) int
+	
+	commandsTotal  *metrics.Counter
+	commandLatency *metrics.Histogram
+	
+	ticks int
+	stop  chan struct{}
+	done  chan struct{}
+	once  sync.Once
 }
 
+const (
+	// WorldTick is how often the world moves on by itself, such as NPCs
+	// acting and respawning
+	WorldTick = 5 * time.Second
+	// npcSaveTicks is how many ticks pass between saves of NPCs that have
+	// moved or changed state
+	npcSaveTicks = 6
+	// corpseDecayTicks is how many ticks pass between checks for corpses
+	// that have decayed
+	corpseDecayTicks = 12
+	// rewardRefreshTicks is how many ticks pass between checks for reward
+	// events that have started or ended
+	rewardRefreshTicks = 12
+)
+
 func NewEngine(repoManager interfaces.RepositoryManager) *Engine {
 	parser := commands.NewParser()
 	executor := commands.NewExecutor(repoManager)
 	
-	return &Engine{
+	e := &Engine{
 		repoManager: repoManager,
 		parser:      parser,
 		executor:    executor,
+		messenger:   commands.NopMessenger{},
+		roll:        executor.Dice().Intn,
+		stop:        make(chan struct{}),
+		done:        make(chan struct{}),
 	}
+	executor.NPCs().SetStanding(e.standingWith)
+	executor.NPCs().SetShielded(e.shielded)
+	return e
 }
 
-func (e *Engine) ProcessCommand(characterID string, input string) ([]string, error) {
-	// Get character to validate it exists and get player ID
-	character, err := e.repoManager.Characters().GetCharacter(characterID)
+// standingWith returns how factionID regards characterID. Characters that
+// cannot be loaded are treated as strangers.
+func (e *Engine) standingWith(characterID, factionID string) faction.Standing {
+	char, err := e.repoManager.Characters().GetCharacter(characterID)
 	if err != nil {
-		return nil, fmt.Errorf("character not found: %w", err)
+		if f, err := faction.GetFaction(factionID); err == nil {
+			return faction.StandingFor(f.Initial)
+		}
+		return faction.StandingNeutral
+	}
+	return char.StandingWith(factionID)
+}
+
+// shielded reports whether newbie protection keeps aggressive NPCs of
+// level away from characterID. Characters that cannot be loaded are not
+// shielded.
+func (e *Engine) shielded(characterID string, level int) bool {
+	char, err := e.repoManager.Characters().GetCharacter(characterID)
+	if err != nil {
+		return false
+	}
+	return e.executor.NewbieProtection().Shields(char, level)
+}
+
+// Start populates the world with NPCs and runs the world tick in the
+// background until Stop is called.
+func (e *Engine) Start() error {
+	if err := e.executor.NPCs().Populate(); err != nil {
+		return fmt.Errorf("failed to populate npcs: %w", err)
+	}
+	if err := e.executor.RefreshRewards(); err != nil {
+		return fmt.Errorf("failed to load reward events: %w", err)
+	}
+	go e.run()
+	return nil
+}
+
+// Stop ends the world tick, waits for it to finish and saves any NPCs
+// changed since the last save
+func (e *Engine) Stop() {
+	e.once.Do(func() {
+		close(e.stop)
+	})
+	<-e.done
+	if err := e.executor.NPCs().SaveDirty(); err != nil {
+		log.Printf("Failed to save npcs: %v", err)
+	}
+}
+
+func (e *Engine) run() {
+	defer close(e.done)
+	
+	ticker := time.NewTicker(WorldTick)
+	defer ticker.Stop()
+	
+	for {
+		select {
+		case <-e.stop:
+			return
+		case <-ticker.C:
+			e.Tick()
+		}
+	}
+}
+
+// Tick advances the world once: dead NPCs whose time has come return to
+// their rooms and every NPC gets to act.
+func (e *Engine) Tick() {
+	npcs := e.executor.NPCs()
+	respawned, err := npcs.Respawn()
+	if err != nil {
+		log.Printf("Failed to respawn npcs: %v", err)
+	}
+	for _, n := range respawned {
+		e.messenger.BroadcastToRoom(n.RoomID, fmt.Sprintf("%s appears.", textutil.Capitalize(n.Template.Name)))
+	}
+	
+	for _, event := range npcs.Think(e.visibleInRoom) {
+		e.handleNPCEvent(event)
+	}
+	
+	e.ticks++
+	if e.ticks%npcSaveTicks == 0 {
+		if err := npcs.SaveDirty(); err != nil {
+			log.Printf("Failed to save npcs: %v", err)
+		}
+	}
+	if e.ticks%corpseDecayTicks == 0 {
+		e.decayCorpses()
+	}
+	if e.ticks%rewardRefreshTicks == 0 {
+		e.refreshRewards()
+	}
+}
+
+// refreshRewards picks up reward events that have started or ended,
+// telling everyone online when the rates change.
+func (e *Engine) refreshRewards() {
+	before := e.executor.RewardRates()
+	if err := e.executor.RefreshRewards(); err != nil {
+		log.Printf("Failed to refresh reward rates: %v", err)
+		return
+	}
+	rates := e.executor.RewardRates()
+	if rates == before {
+		return
+	}
+	notice := commands.RewardNotice(rates)
+	for _, characterID := range e.messenger.OnlineCharacterIDs() {
+		e.messenger.SendToCharacter(characterID, notice)
+	}
+}
+
+// decayCorpses scatters the contents of corpses whose time has run out,
+// telling their rooms.
+func (e *Engine) decayCorpses() {
+	decayed, err := e.executor.DecayCorpses(time.Now())
+	if err != nil {
+		log.Printf("Failed to decay corpses: %v", err)
+	}
+	for roomID, lines := range decayed {
+		for _, line := range lines {
+			e.messenger.BroadcastToRoom(roomID, line)
+		}
+	}
+}
+
+// visibleInRoom returns the characters in roomID that NPCs can see, leaving
+// out anyone hidden.
+func (e *Engine) visibleInRoom(roomID string) []string {
+	var visible []string
+	for _, id := range e.messenger.CharactersInRoom(roomID) {
+		if !e.executor.Stealth().IsHidden(id) {
+			visible = append(visible, id)
+		}
+	}
+	return visible
+}
+
+func (e *Engine) handleNPCEvent(event npc.Event) {
+	name := textutil.Capitalize(event.NPC.Template.Name)
+	switch event.Type {
+	case npc.EventLeave:
+		e.messenger.BroadcastToRoom(event.RoomID, fmt.Sprintf("%s leaves %s.", name, event.Direction))
+	case npc.EventArrive:
+		e.messenger.BroadcastToRoom(event.RoomID, fmt.Sprintf("%s arrives.", name))
+	case npc.EventAttack:
+		defer e.LockCharacter(event.Target)()
+		target, err := e.repoManager.Characters().GetCharacter(event.Target)
+		if err != nil {
+			log.Printf("Failed to load npc target %s: %v", event.Target, err)
+			return
+		}
+		target.State = character.CharacterInCombat
+		if err := e.repoManager.Characters().UpdateCharacter(target); err != nil {
+			log.Printf("Failed to save npc target %s: %v", target.ID, err)
+		}
+		e.tellFighter(target.ID, fmt.Sprintf("%s attacks you!", name))
+		if _, ok := e.executor.Follows().Stop(target.ID); ok {
+			e.tellFighter(target.ID, "You stop following to defend yourself.")
+		}
+		e.messenger.BroadcastToRoom(event.RoomID, fmt.Sprintf("%s attacks %s!", name, target.Name), target.ID)
+	case npc.EventHit:
+		defer e.LockCharacter(event.Target)()
+		target, err := e.repoManager.Characters().GetCharacter(event.Target)
+		if err != nil {
+			log.Printf("Failed to load npc target %s: %v", event.Target, err)
+			return
+		}
+		defending := e.executor.Stances().Defending(target.ID)
+		if combat.Parries(target, e.wieldedWeapon(target), defending, e.roll) {
+			e.parry(target, event.NPC)
+			return
+		}
+		kind := event.NPC.Template.DamageType
+		dealt := combat.Resist(target, event.Damage, kind, e.executor.Items())
+		if defending {
+			dealt = combat.DefendedDamage(target, dealt)
+		}
+		target.Stats.Health = max(target.Stats.Health-dealt, 0)
+		if err := e.repoManager.Characters().UpdateCharacterStats(target.ID, target.Stats); err != nil {
+			log.Printf("Failed to save npc target %s: %v", target.ID, err)
+		}
+		e.tellFighter(target.ID, fmt.Sprintf("%s hits you for %s.", name, damage.Describe(dealt, kind)))
+		if target.Stats.Health == 0 {
+			e.die(target, event.NPC)
+			return
+		}
+		if e.wantsCombatPrompts(target) {
+			e.messenger.SendToCharacter(target.ID, combat.StatusLine(target, event.NPC.Health, event.NPC.Template.MaxHealth, defending))
+		}
+	}
+}
+
+// tellFighter sends text to a character in a fight and keeps it in their
+// combat log.
+func (e *Engine) tellFighter(characterID, text string) {
+	e.messenger.SendToCharacter(characterID, text)
+	e.executor.LogCombat(characterID, text)
+}
+
+// die handles char being killed by killer, telling everyone concerned.
+func (e *Engine) die(char *character.Character, killer *npc.NPC) {
+	deathRoom := char.Location.RoomID
+	lines, err := e.executor.Die(char, killer.Template.Name, time.Now())
+	if err != nil {
+		log.Printf("Failed to handle death of %s: %v", char.ID, err)
+		return
+	}
+	if tracker, ok := e.messenger.(commands.RoomTracker); ok {
+		tracker.SetCharacterRoom(char.ID, char.Location.RoomID)
+	}
+	for _, line := range lines {
+		e.tellFighter(char.ID, line)
+	}
+	e.messenger.BroadcastToRoom(deathRoom, fmt.Sprintf("%s has been slain by %s!", char.Name, killer.Template.Name), char.ID)
+	e.messenger.BroadcastToRoom(char.Location.RoomID, fmt.Sprintf("%s stumbles in, pale and shaken.", char.Name), char.ID)
+}
+
+// wieldedWeapon returns the template of the weapon in char's main hand, or
+// nil if they hold none.
+func (e *Engine) wieldedWeapon(char *character.Character) *items.ItemTemplate {
+	weapon := char.Equipment[items.SlotMainHand]
+	if weapon == nil {
+		return nil
+	}
+	template, err := e.executor.Items().GetTemplate(weapon.TemplateID)
+	if err != nil {
+		return nil
+	}
+	return template
+}
+
+// parry turns aside attacker's blow at char, who learns from it.
+func (e *Engine) parry(char *character.Character, attacker *npc.NPC) {
+	e.tellFighter(char.ID, fmt.Sprintf("You parry %s's attack.", attacker.Template.Name))
+	e.messenger.BroadcastToRoom(attacker.RoomID, fmt.Sprintf("%s parries %s's attack.", char.Name, attacker.Template.Name), char.ID)
+	improved := char.Skills.AddExperience(character.SkillParry, e.executor.RewardRates().ApplyExperience(combat.ParryExperience))
+	if err := e.repoManager.Characters().SaveCharacterSkills(char.ID, char.Skills); err != nil {
+		log.Printf("Failed to save parry experience for %s: %v", char.ID, err)
+	}
+	if improved {
+		e.tellFighter(char.ID, fmt.Sprintf("Your Parry skill improves to %d.", char.Skills.GetSkillLevel(character.SkillParry)))
+	}
+}
+
+// wantsCombatPrompts reports whether the character's player has asked for a
+// status line each round of a fight.
+func (e *Engine) wantsCombatPrompts(char *character.Character) bool {
+	p, err := e.repoManager.Players().GetPlayer(char.PlayerID)
+	return err == nil && p.Preferences.CombatPrompts
+}
+
+// saveCharacter saves as much of char as policy covers, using the
+// narrowest update the repository has for it
+func (e *Engine) saveCharacter(char *character.Character, policy commands.SavePolicy) error {
+	switch policy {
+	case commands.SaveSkills:
+		if err := e.repoManager.Characters().SaveCharacterSkills(char.ID, char.Skills); err != nil {
+			return fmt.Errorf("failed to save skills: %w", err)
+		}
+	case commands.SaveCharacter:
+		if err := e.repoManager.Characters().UpdateCharacter(char); err != nil {
+			return fmt.Errorf("failed to save character: %w", err)
+		}
+	}
+	return nil
+}
+
+// ProcessCommand runs one line of input for a character and saves what it
+// changed of them, as commands.SavePolicyFor says. Room messages without a
+// room are addressed to the character's room, and ActorRoom is set to where
+// the character ended up.
+func (e *Engine) ProcessCommand(characterID string, input string) (*commands.CommandResult, error) {
+	return e.runLocked(characterID, func() (*commands.CommandResult, *leaderMove, error) {
+		return e.runCommand(characterID, input)
+	})
+}
+
+// runLocked calls run with the character's lock held, then moves their
+// followers if run made a move they should copy.
+func (e *Engine) runLocked(characterID string, run func() (*commands.CommandResult, *leaderMove, error)) (*commands.CommandResult, error) {
+	unlock := e.LockCharacter(characterID)
+	result, move, err := run()
+	unlock()
+	if err != nil {
+		return nil, err
+	}
+	
+	// Followers are moved once the leader is unlocked, so no command holds
+	// two characters' locks while waiting for another
+	if move != nil {
+		result.Followers = e.moveFollowers(move.leader, move.fromRoom, move.cmd)
 	}
 	
-	// Parse the command
+	return result, nil
+}
+
+// leaderMove is a move that the character's followers should copy
+type leaderMove struct {
+	leader   *character.Character
+	fromRoom string
+	cmd      *commands.Command
+}
+
+// runCommand runs one line of input for a character whose lock is held. It
+// returns the move their followers should make, if any.
+func (e *Engine) runCommand(characterID string, input string) (*commands.CommandResult, *leaderMove, error) {
+	// Load the character and their room once for the whole command
+	ctx, err := e.executor.LoadContext(characterID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("character not found: %w", err)
+	}
+	character := ctx.Character
+	fromRoom := ctx.RoomID()
+	
+	// A player writing their description is not giving commands
+	if result, editing, err := e.executor.Edit(ctx, input); editing {
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to edit description: %w", err)
+		}
+		result.ActorRoom = fromRoom
+		return result, nil, nil
+	}
+	
+	// Parse the command, standing in for a bound key
+	input = e.executor.ExpandKeybinding(characterID, input)
 	cmd := e.parser.Parse(input, character.PlayerID, characterID)
 	
+	// Any input but going AFK again brings the character back
+	back := cmd.Verb != "afk" && e.executor.ReturnFromAfk(character)
+	
+	return e.execute(ctx, cmd, back)
+}
+
+// execute runs cmd for the character in ctx, whose lock is held, and saves
+// what it changed of them. back reports whether the command brought them
+// back from being AFK.
+func (e *Engine) execute(ctx *commands.HandlerContext, cmd *commands.Command, back bool) (*commands.CommandResult, *leaderMove, error) {
+	character := ctx.Character
+	fromRoom := ctx.RoomID()
+	
+	// A fight that ended without them, as when their foe gave up or left,
+	// no longer holds them
+	settled := e.executor.SettleFight(ctx)
+	
 	// Execute the command
-	responses, err := e.executor.Execute(cmd)
+	start := time.Now()
+	result, err := e.executor.Execute(ctx, cmd)
+	e.recordCommand(cmd, time.Since(start))
 	if err != nil {
-		return nil, fmt.Errorf("command execution failed: %w", err)
+		return nil, nil, fmt.Errorf("command execution failed: %w", err)
+	}
+	
+	policy := commands.SavePolicyFor(cmd)
+	// Leaving a room leaves the players fought there behind
+	if ctx.RoomID() != fromRoom && e.executor.SettleFight(ctx) {
+		settled = true
+	}
+	if settled {
+		policy = commands.SaveCharacter
+	}
+	if back {
+		result.Messages = append([]string{"You are no longer AFK."}, result.Messages...)
+		policy = commands.SaveCharacter
+	}
+	
+	// Walk new characters through the tutorial
+	if character.InTutorial() {
+		if hints, advanced := tutorial.Advance(character, cmd, e.executor.StartLocations()); advanced {
+			result.Add(hints...)
+			policy = commands.SaveCharacter
+		}
+	}
+	
+	// Handlers leave saving the actor to the engine
+	if err := e.saveCharacter(character, policy); err != nil {
+		return nil, nil, err
+	}
+	
+	result.ActorRoom = ctx.RoomID()
+	for i := range result.Room {
+		if result.Room[i].RoomID == "" {
+			result.Room[i].RoomID = result.ActorRoom
+		}
+	}
+	
+	if cmd.Type == commands.CommandMovement && result.ActorRoom != fromRoom {
+		return result, &leaderMove{leader: character, fromRoom: fromRoom, cmd: cmd}, nil
+	}
+	return result, nil, nil
+}
+
+// moveFollowers sends the leader's followers still in fromRoom the same way
+// the leader went. Followers who are fighting or cannot make the move stop
+// following.
+func (e *Engine) moveFollowers(leader *character.Character, fromRoom string, cmd *commands.Command) []commands.FollowerResult {
+	direction := cmd.Verb
+	if direction == "sneak" && len(cmd.Args) > 0 {
+		direction = cmd.Args[0]
 	}
 	
-	return responses, nil
+	follows := e.executor.Follows()
+	var results []commands.FollowerResult
+	for _, followerID := range follows.Followers(leader.ID) {
+		if !slices.Contains(e.messenger.CharactersInRoom(fromRoom), followerID) {
+			continue
+		}
+		
+		follower, err := e.repoManager.Characters().GetCharacter(followerID)
+		if err != nil {
+			log.Printf("Failed to load follower %s: %v", followerID, err)
+			continue
+		}
+		if follower.State == character.CharacterInCombat {
+			follows.Stop(followerID)
+			e.messenger.SendToCharacter(followerID,
+				fmt.Sprintf("You are too busy fighting to follow %s.", leader.Name))
+			continue
+		}
+		
+		result, err := e.runLocked(followerID, func() (*commands.CommandResult, *leaderMove, error) {
+			return e.follow(followerID, direction)
+		})
+		if err != nil {
+			log.Printf("Failed to move follower %s: %v", followerID, err)
+			continue
+		}
+		if result.ActorRoom == fromRoom {
+			follows.Stop(followerID)
+			result.Add(fmt.Sprintf("You can't follow %s that way.", leader.Name))
+		} else {
+			result.Messages = append([]string{fmt.Sprintf("You follow %s %s.", leader.Name, direction)}, result.Messages...)
+		}
+		results = append(results, commands.FollowerResult{CharacterID: followerID, Result: result})
+	}
+	return results
+}
+
+// follow moves a follower, whose lock is held, in direction. Unlike their
+// own input it is never taken as description text or a bound key, and it
+// doesn't bring them back from being AFK.
+func (e *Engine) follow(followerID, direction string) (*commands.CommandResult, *leaderMove, error) {
+	ctx, err := e.executor.LoadContext(followerID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("character not found: %w", err)
+	}
+	cmd := e.parser.Parse(direction, ctx.Character.PlayerID, followerID)
+	if cmd.Type != commands.CommandMovement {
+		return nil, nil, fmt.Errorf("%q is not a movement command", direction)
+	}
+	return e.execute(ctx, cmd, false)
+}
+
+// LeaveGame forgets a character leaving the world, so nobody follows them
+// and they follow nobody, and "it" no longer refers to their last target.
+func (e *Engine) LeaveGame(characterID string) {
+	e.leaveFights(characterID)
+	e.executor.ForgetTarget(characterID)
+	e.executor.Stances().Clear(characterID)
+	e.executor.CloseEditor(characterID)
+	e.executor.ForgetKeybindings(characterID)
+	e.executor.ForgetReplies(characterID)
+	e.executor.ForgetAfk(characterID)
+	e.executor.ForgetCombatLog(characterID)
+	followers := e.executor.Follows().Forget(characterID)
+	if len(followers) == 0 {
+		return
+	}
+	name := "Your leader"
+	if leader, err := e.repoManager.Characters().GetCharacter(characterID); err == nil {
+		name = leader.Name
+	}
+	for _, followerID := range followers {
+		e.messenger.SendToCharacter(followerID, fmt.Sprintf("%s has left the realm, so you stop following.", name))
+	}
+}
+
+// leaveFights ends the fights of a character leaving the world. One still
+// fighting a creature forfeits the fight and is slain.
+func (e *Engine) leaveFights(characterID string) {
+	defer e.LockCharacter(characterID)()
+	char, err := e.repoManager.Characters().GetCharacter(characterID)
+	if err != nil {
+		log.Printf("Failed to load character %s leaving their fights: %v", characterID, err)
+		return
+	}
+	foe, left := e.executor.LeaveFights(char)
+	switch {
+	case foe != nil:
+		// Dropping out of a fight forfeits it, so leaving is no escape
+		e.die(char, foe)
+	case left:
+		if err := e.repoManager.Characters().UpdateCharacter(char); err != nil {
+			log.Printf("Failed to save character %s leaving combat: %v", characterID, err)
+		}
+	}
+}
+
+// SetMetrics registers the engine's command metrics on registry
+func (e *Engine) SetMetrics(registry *metrics.Registry) {
+	e.commandsTotal = registry.NewCounter("dungeogo_commands_total",
+		"Commands processed, by command.", "command")
+	e.commandLatency = registry.NewHistogram("dungeogo_command_duration_seconds",
+		"Time taken to execute a command, by command.", metrics.DefaultBuckets, "command")
+}
+
+func (e *Engine) recordCommand(cmd *commands.Command, duration time.Duration) {
+	if e.commandsTotal == nil {
+		return
+	}
+	// Label by the resolved verb; anything unrecognised shares one label so
+	// typos cannot create new series
+	label := cmd.Verb
+	if cmd.Type == commands.CommandUnknown {
+		label = "unknown"
+	}
+	e.commandsTotal.Inc(label)
+	e.commandLatency.Observe(duration.Seconds(), label)
+}
+
+// SetLevelingMode sets whether characters level up automatically or must
+// gain each level at a trainer
+func (e *Engine) SetLevelingMode(mode character.LevelingMode) {
+	e.executor.SetLevelingMode(mode)
+}
+
+// SetDeathPenalty sets what dying to an NPC costs
+func (e *Engine) SetDeathPenalty(penalty character.DeathPenalty) {
+	e.executor.SetDeathPenalty(penalty)
+}
+
+// SetRewardRates sets the server's base experience and gold multipliers
+func (e *Engine) SetRewardRates(rates reward.Rates) {
+	e.executor.SetRewardRates(rates)
+}
+
+// SetStartLocations sets where characters begin
+func (e *Engine) SetStartLocations(starts *character.StartLocations) {
+	e.executor.SetStartLocations(starts)
+}
+
+// SetSeed makes the game's random numbers repeat from seed
+func (e *Engine) SetSeed(seed int64) {
+	e.executor.SetSeed(seed)
+}
+
+// SetPvPMode sets whether and when players may attack each other
+func (e *Engine) SetPvPMode(mode combat.PvPMode) {
+	e.executor.SetPvPMode(mode)
+}
+
+// SetNewbieProtection sets which new characters are shielded from other
+// players and from NPCs far above their level
+func (e *Engine) SetNewbieProtection(protection combat.NewbieProtection) {
+	e.executor.SetNewbieProtection(protection)
+}
+
+// Items returns the factory, and so the item templates, the game makes
+// items from
+func (e *Engine) Items() *items.ItemFactory {
+	return e.executor.Items()
+}
+
+// SetReloader lets administrators reload content by name while the server
+// runs
+func (e *Engine) SetReloader(name string, reloader commands.Reloader) {
+	e.executor.SetReloader(name, reloader)
+}
+
+// SetMessenger lets command handlers reach connected players
+func (e *Engine) SetMessenger(messenger commands.Messenger) {
+	e.messenger = messenger
+	e.executor.SetMessenger(messenger)
+}
+
+// LockCharacter waits until nothing else is changing the character and
+// stops anything else doing so. The returned function unlocks them.
+func (e *Engine) LockCharacter(characterID string) func() {
+	return e.executor.Locks().Lock(characterID)
+}
+
+// EnterGame returns the messages shown when a character enters the world.
+func (e *Engine) EnterGame(characterID string) ([]string, error) {
+	defer e.LockCharacter(characterID)()
+	character, err := e.repoManager.Characters().GetCharacter(characterID)
+	if err != nil {
+		return nil, fmt.Errorf("character not found: %w", err)
+	}
+	if err := e.executor.LoadKeybindings(character); err != nil {
+		return nil, fmt.Errorf("failed to load keybindings: %w", err)
+	}
+	
+	// Start the play time clock now, so time away is not counted as played,
+	// and nobody who logged out while AFK comes back still marked away
+	character.LastPlayed = time.Now()
+	e.executor.ReturnFromAfk(character)
+	announced := e.executor.Events().Publish(event.Login{Character: character})
+	if err := e.repoManager.Characters().UpdateCharacter(character); err != nil {
+		return nil, fmt.Errorf("failed to save character: %w", err)
+	}
+	
+	if character.InTutorial() {
+		messages := []string{"You find yourself in a quiet training ground, set apart from the world."}
+		messages = append(messages, tutorial.CurrentHint(character)...)
+		return append(messages, announced...), nil
+	}
+	
+	return announced, nil
+}
+
+// Events returns the bus game events are published on, for systems that
+// react to kills, deaths, new levels and logins
+func (e *Engine) Events() *event.Bus {
+	return e.executor.Events()
 }
 
 func (e *Engine) GetCharacterState(characterID string) (interface{}, error) {
@@ -0,0 +1,117 @@
+// Package event carries news of what happens in the game, such as kills and
+// level-ups, from the code where it happens to the systems that react to
+// it, like quests, so neither has to know about the other.
+package event
+
+import (
+	"sync"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+)
+
+// Type is the kind of an event, which subscribers choose by
+type Type int
+
+const (
+	TypeKill Type = iota
+	TypeDeath
+	TypeLevelUp
+	TypeLogin
+	TypeCraft
+)
+
+// Event is something that happened to a character
+type Event interface {
+	Type() Type
+	// Subject is the character the event happened to. Subscribers may change
+	// them; whoever published the event saves them afterwards.
+	Subject() *character.Character
+}
+
+// Kill is published when a character kills an NPC
+type Kill struct {
+	Character *character.Character
+	// TemplateID is the kind of NPC killed
+	TemplateID string
+	RoomID     string
+}
+
+func (e Kill) Type() Type                    { return TypeKill }
+func (e Kill) Subject() *character.Character { return e.Character }
+
+// Death is published when a character is killed by an NPC, after they have
+// paid the death penalty and woken up again
+type Death struct {
+	Character *character.Character
+	// Killer is the name of what killed them
+	Killer string
+	// RoomID is where they died
+	RoomID string
+}
+
+func (e Death) Type() Type                    { return TypeDeath }
+func (e Death) Subject() *character.Character { return e.Character }
+
+// LevelUp is published when a character reaches a new level
+type LevelUp struct {
+	Character *character.Character
+	Level     int
+}
+
+func (e LevelUp) Type() Type                    { return TypeLevelUp }
+func (e LevelUp) Subject() *character.Character { return e.Character }
+
+// Login is published when a character enters the world
+type Login struct {
+	Character *character.Character
+}
+
+func (e Login) Type() Type                    { return TypeLogin }
+func (e Login) Subject() *character.Character { return e.Character }
+
+// Craft is published when a character crafts an item
+type Craft struct {
+	Character *character.Character
+	// TemplateID is the kind of item made
+	TemplateID string
+}
+
+func (e Craft) Type() Type                    { return TypeCraft }
+func (e Craft) Subject() *character.Character { return e.Character }
+
+// Handler reacts to an event. It returns any lines to tell the character
+// the event happened to.
+type Handler func(e Event) []string
+
+// Bus passes each published event to the handlers subscribed to its type.
+// Handlers run on the publisher's goroutine, in the order they subscribed,
+// while the publisher holds the subject's lock.
+type Bus struct {
+	mutex    sync.RWMutex
+	handlers map[Type][]Handler
+}
+
+func NewBus() *Bus {
+	return &Bus{handlers: make(map[Type][]Handler)}
+}
+
+// Subscribe makes handler react to every event of type t
+func (b *Bus) Subscribe(t Type, handler Handler) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.handlers[t] = append(b.handlers[t], handler)
+}
+
+// Publish passes e to its subscribers and returns the lines they have for
+// the event's subject
+func (b *Bus) Publish(e Event) []string {
+	b.mutex.RLock()
+	handlers := b.handlers[e.Type()]
+	b.mutex.RUnlock()
+
+	var lines []string
+	for _, handler := range handlers {
+		lines = append(lines, handler(e)...)
+	}
+	return lines
+}
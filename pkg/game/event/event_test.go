@@ -0,0 +1,40 @@
+package event
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+)
+
+func TestPublishReachesSubscribersInOrder(t *testing.T) {
+	bus := NewBus()
+	alice := &character.Character{ID: "char1", Name: "Alice"}
+
+	bus.Subscribe(TypeKill, func(e Event) []string {
+		return []string{"first saw " + e.(Kill).TemplateID}
+	})
+	bus.Subscribe(TypeKill, func(e Event) []string {
+		e.Subject().KillCount++
+		return []string{"second saw " + e.Subject().Name}
+	})
+	bus.Subscribe(TypeLevelUp, func(e Event) []string {
+		t.Errorf("Expected level-up subscribers to miss a kill")
+		return nil
+	})
+
+	lines := bus.Publish(Kill{Character: alice, TemplateID: "goblin", RoomID: "riverbank"})
+	expected := []string{"first saw goblin", "second saw Alice"}
+	if strings.Join(lines, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Expected %v, got %v", expected, lines)
+	}
+	if alice.KillCount != 1 {
+		t.Errorf("Expected a subscriber to be able to change the subject, got %d kills", alice.KillCount)
+	}
+}
+
+func TestPublishWithoutSubscribers(t *testing.T) {
+	if lines := NewBus().Publish(Login{Character: &character.Character{ID: "char1"}}); lines != nil {
+		t.Errorf("Expected nothing to say, got %v", lines)
+	}
+}
@@ -0,0 +1,142 @@
+// Package faction defines the groups characters earn standing with and how
+// that standing changes the way their members behave.
+package faction
+
+import (
+	"errors"
+	"sort"
+)
+
+var ErrFactionNotFound = errors.New("faction not found")
+
+const (
+	// MinReputation and MaxReputation bound a character's reputation with
+	// any faction
+	MinReputation = -1000
+	MaxReputation = 1000
+)
+
+// Faction IDs referenced by NPCs and quests
+const (
+	Militia = "militia"
+	Goblins = "goblins"
+)
+
+// Faction is a group whose members share an opinion of each character.
+// Initial is the reputation a character starts with.
+type Faction struct {
+	ID      string
+	Name    string
+	Initial int
+}
+
+// Standing is how a faction regards a character, from Hated to Honored
+type Standing int
+
+const (
+	StandingHated Standing = iota
+	StandingHostile
+	StandingNeutral
+	StandingFriendly
+	StandingHonored
+)
+
+// standingThresholds is the least reputation needed for each standing above
+// Hated
+var standingThresholds = []struct {
+	standing Standing
+	min      int
+}{
+	{StandingHonored, 500},
+	{StandingFriendly, 100},
+	{StandingNeutral, -100},
+	{StandingHostile, -500},
+}
+
+// StandingFor returns the standing a reputation earns
+func StandingFor(reputation int) Standing {
+	for _, threshold := range standingThresholds {
+		if reputation >= threshold.min {
+			return threshold.standing
+		}
+	}
+	return StandingHated
+}
+
+func (s Standing) String() string {
+	switch s {
+	case StandingHated:
+		return "Hated"
+	case StandingHostile:
+		return "Hostile"
+	case StandingNeutral:
+		return "Neutral"
+	case StandingFriendly:
+		return "Friendly"
+	case StandingHonored:
+		return "Honored"
+	default:
+		return "Unknown"
+	}
+}
+
+// AttackOnSight reports whether the faction's aggressive members attack
+// characters of this standing.
+func (s Standing) AttackOnSight() bool {
+	return s <= StandingHostile
+}
+
+// WillDeal reports whether the faction's members will hand out work to
+// characters of this standing.
+func (s Standing) WillDeal() bool {
+	return s >= StandingNeutral
+}
+
+// RewardBonus is the extra percentage of gold the faction's members pay
+// characters of this standing.
+func (s Standing) RewardBonus() int {
+	switch s {
+	case StandingFriendly:
+		return 10
+	case StandingHonored:
+		return 25
+	default:
+		return 0
+	}
+}
+
+// Clamp keeps reputation within MinReputation and MaxReputation
+func Clamp(reputation int) int {
+	return max(MinReputation, min(MaxReputation, reputation))
+}
+
+func GetFaction(id string) (*Faction, error) {
+	if f, exists := getStandardFactions()[id]; exists {
+		return f, nil
+	}
+	return nil, ErrFactionNotFound
+}
+
+// GetAllFactions returns every faction, sorted by name.
+func GetAllFactions() []*Faction {
+	var factions []*Faction
+	for _, f := range getStandardFactions() {
+		factions = append(factions, f)
+	}
+	sort.Slice(factions, func(i, j int) bool { return factions[i].Name < factions[j].Name })
+	return factions
+}
+
+func getStandardFactions() map[string]*Faction {
+	return map[string]*Faction{
+		Militia: {
+			ID:   Militia,
+			Name: "Town Militia",
+		},
+		Goblins: {
+			ID:      Goblins,
+			Name:    "Riverbank Goblins",
+			Initial: -250,
+		},
+	}
+}
@@ -0,0 +1,47 @@
+package faction
+
+import "testing"
+
+func TestStandingFor(t *testing.T) {
+	tests := map[int]Standing{
+		MinReputation: StandingHated,
+		-501:          StandingHated,
+		-500:          StandingHostile,
+		-101:          StandingHostile,
+		0:             StandingNeutral,
+		99:            StandingNeutral,
+		100:           StandingFriendly,
+		500:           StandingHonored,
+		MaxReputation: StandingHonored,
+	}
+	for reputation, expected := range tests {
+		if got := StandingFor(reputation); got != expected {
+			t.Errorf("StandingFor(%d) = %v, want %v", reputation, got, expected)
+		}
+	}
+}
+
+func TestStandingTreatment(t *testing.T) {
+	if !StandingHostile.AttackOnSight() || StandingNeutral.AttackOnSight() {
+		t.Errorf("Expected only hostile or worse standings to be attacked on sight")
+	}
+	if StandingHostile.WillDeal() || !StandingNeutral.WillDeal() {
+		t.Errorf("Expected factions to deal only with neutral or better standings")
+	}
+	if StandingNeutral.RewardBonus() != 0 || StandingHonored.RewardBonus() <= StandingFriendly.RewardBonus() {
+		t.Errorf("Expected reward bonuses to grow with standing")
+	}
+}
+
+func TestStartingGoblinsHostile(t *testing.T) {
+	goblins, err := GetFaction(Goblins)
+	if err != nil {
+		t.Fatalf("Failed to get goblins: %v", err)
+	}
+	if !StandingFor(goblins.Initial).AttackOnSight() {
+		t.Errorf("Expected goblins to attack new characters on sight")
+	}
+	if _, err := GetFaction("nobody"); err != ErrFactionNotFound {
+		t.Errorf("Expected ErrFactionNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,71 @@
+// Package fishing decides what a cast of the line brings in. Better anglers
+// land fish more often and can hook rarer ones.
+package fishing
+
+const (
+	// baseSuccessChance is the percent chance that an unskilled angler
+	// catches anything; each Fishing level adds skillBonusPerLevel.
+	baseSuccessChance  = 40
+	skillBonusPerLevel = 8
+	maxSuccessChance   = 90
+)
+
+// Catch is a fish that can be landed once a character's Fishing skill
+// reaches MinSkill. Weight sets how common it is among the catches
+// available at that skill.
+type Catch struct {
+	TemplateID string
+	MinSkill   int
+	Weight     int
+	Experience int
+}
+
+// SuccessChance returns the percent chance that a cast catches anything.
+func SuccessChance(skill int) int {
+	return min(baseSuccessChance+skill*skillBonusPerLevel, maxSuccessChance)
+}
+
+// Available returns the catches a character with skill can land.
+func Available(skill int) []Catch {
+	var catches []Catch
+	for _, c := range getStandardCatches() {
+		if skill >= c.MinSkill {
+			catches = append(catches, c)
+		}
+	}
+	return catches
+}
+
+// Cast decides the outcome of one cast for a character with skill. roll
+// returns a number from 0 to n-1. It returns false for an empty line.
+func Cast(skill int, roll func(n int) int) (Catch, bool) {
+	if roll(100) >= SuccessChance(skill) {
+		return Catch{}, false
+	}
+
+	catches := Available(skill)
+	total := 0
+	for _, c := range catches {
+		total += c.Weight
+	}
+	if total == 0 {
+		return Catch{}, false
+	}
+
+	pick := roll(total)
+	for _, c := range catches {
+		if pick < c.Weight {
+			return c, true
+		}
+		pick -= c.Weight
+	}
+	return Catch{}, false
+}
+
+func getStandardCatches() []Catch {
+	return []Catch{
+		{TemplateID: "river_perch", MinSkill: 0, Weight: 70, Experience: 10},
+		{TemplateID: "silver_trout", MinSkill: 2, Weight: 25, Experience: 25},
+		{TemplateID: "golden_carp", MinSkill: 5, Weight: 5, Experience: 60},
+	}
+}
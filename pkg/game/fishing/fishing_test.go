@@ -0,0 +1,60 @@
+package fishing
+
+import (
+	"testing"
+
+	"github.com/elidor/dungeogo/pkg/game/items"
+)
+
+// fixedRolls returns each value in turn, whatever n is.
+func fixedRolls(values ...int) func(n int) int {
+	return func(n int) int {
+		value := values[0]
+		values = values[1:]
+		return value
+	}
+}
+
+func TestStandardCatchesAreKnownItems(t *testing.T) {
+	factory := items.NewItemFactory()
+	for _, c := range getStandardCatches() {
+		if _, err := factory.GetTemplate(c.TemplateID); err != nil {
+			t.Errorf("Catch uses unknown template %s", c.TemplateID)
+		}
+	}
+}
+
+func TestSkillUnlocksRarerCatches(t *testing.T) {
+	if got := len(Available(0)); got != 1 {
+		t.Errorf("Expected 1 catch for a novice, got %d", got)
+	}
+	if got := len(Available(10)); got != len(getStandardCatches()) {
+		t.Errorf("Expected every catch for an expert, got %d", got)
+	}
+}
+
+func TestSuccessChance(t *testing.T) {
+	if got := SuccessChance(0); got != 40 {
+		t.Errorf("Expected 40%% for a novice, got %d", got)
+	}
+	if got := SuccessChance(50); got != 90 {
+		t.Errorf("Expected chance to cap at 90%%, got %d", got)
+	}
+}
+
+func TestCast(t *testing.T) {
+	if _, ok := Cast(0, fixedRolls(40)); ok {
+		t.Errorf("Expected a roll at the success chance to miss")
+	}
+
+	catch, ok := Cast(0, fixedRolls(0, 0))
+	if !ok || catch.TemplateID != "river_perch" {
+		t.Errorf("Expected a perch, got %+v (%v)", catch, ok)
+	}
+
+	// The last weight slot belongs to the rarest fish
+	catch, ok = Cast(10, fixedRolls(0, 99))
+	if !ok || catch.TemplateID != "golden_carp" {
+		t.Errorf("Expected a golden carp, got %+v (%v)", catch, ok)
+	}
+}
@@ -0,0 +1,132 @@
+// Package follow remembers which characters are following whom, so a group
+// can move together behind its leader.
+package follow
+
+import (
+	"errors"
+	"sort"
+	"sync"
+)
+
+var (
+	ErrFollowSelf = errors.New("cannot follow yourself")
+	// ErrFollowLoop is returned when the leader is already following the
+	// would-be follower, directly or through others
+	ErrFollowLoop = errors.New("leader is following you")
+)
+
+// Tracker maps each follower to the character they follow, and remembers
+// who has agreed to be followed. Neither outlasts the server.
+type Tracker struct {
+	mutex   sync.RWMutex
+	leaders map[string]string
+	leading map[string]bool
+}
+
+func NewTracker() *Tracker {
+	return &Tracker{leaders: make(map[string]string), leading: make(map[string]bool)}
+}
+
+// SetLeading sets whether characterID lets others start following them.
+// Nobody can be followed until they agree to it.
+func (t *Tracker) SetLeading(characterID string, leading bool) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if leading {
+		t.leading[characterID] = true
+		return
+	}
+	delete(t.leading, characterID)
+}
+
+// Leading reports whether characterID lets others start following them.
+func (t *Tracker) Leading(characterID string) bool {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+	return t.leading[characterID]
+}
+
+// Follow makes followerID follow leaderID, replacing anyone they followed
+// before.
+func (t *Tracker) Follow(followerID, leaderID string) error {
+	if followerID == leaderID {
+		return ErrFollowSelf
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	for id, ok := leaderID, true; ok; id, ok = t.leaders[id] {
+		if id == followerID {
+			return ErrFollowLoop
+		}
+	}
+	t.leaders[followerID] = leaderID
+	return nil
+}
+
+// Stop ends followerID's following, returning who they were following.
+func (t *Tracker) Stop(followerID string) (string, bool) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	leaderID, ok := t.leaders[followerID]
+	delete(t.leaders, followerID)
+	return leaderID, ok
+}
+
+// Leader returns who followerID is following.
+func (t *Tracker) Leader(followerID string) (string, bool) {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	leaderID, ok := t.leaders[followerID]
+	return leaderID, ok
+}
+
+// Followers returns the IDs of everyone following leaderID, sorted.
+func (t *Tracker) Followers(leaderID string) []string {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	var followers []string
+	for followerID, id := range t.leaders {
+		if id == leaderID {
+			followers = append(followers, followerID)
+		}
+	}
+	sort.Strings(followers)
+	return followers
+}
+
+// Lose stops followerID following leaderID, reporting whether they were.
+func (t *Tracker) Lose(leaderID, followerID string) bool {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.leaders[followerID] != leaderID {
+		return false
+	}
+	delete(t.leaders, followerID)
+	return true
+}
+
+// Forget drops characterID from every group, as when they leave the game.
+// It returns the followers they left behind.
+func (t *Tracker) Forget(characterID string) []string {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	delete(t.leaders, characterID)
+	delete(t.leading, characterID)
+	var followers []string
+	for followerID, leaderID := range t.leaders {
+		if leaderID == characterID {
+			followers = append(followers, followerID)
+			delete(t.leaders, followerID)
+		}
+	}
+	sort.Strings(followers)
+	return followers
+}
@@ -0,0 +1,80 @@
+package follow
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestFollow(t *testing.T) {
+	tracker := NewTracker()
+	if err := tracker.Follow("bob", "alice"); err != nil {
+		t.Fatalf("Follow failed: %v", err)
+	}
+	if err := tracker.Follow("carol", "alice"); err != nil {
+		t.Fatalf("Follow failed: %v", err)
+	}
+
+	if leader, ok := tracker.Leader("bob"); !ok || leader != "alice" {
+		t.Errorf("Expected bob to follow alice, got %q", leader)
+	}
+	if followers := tracker.Followers("alice"); !slices.Equal(followers, []string{"bob", "carol"}) {
+		t.Errorf("Expected bob and carol to follow alice, got %v", followers)
+	}
+
+	if leader, ok := tracker.Stop("bob"); !ok || leader != "alice" {
+		t.Errorf("Expected bob to stop following alice, got %q", leader)
+	}
+	if tracker.Lose("dave", "carol") {
+		t.Errorf("Expected dave not to be able to lose someone following alice")
+	}
+	if !tracker.Lose("alice", "carol") {
+		t.Errorf("Expected alice to lose carol")
+	}
+	if followers := tracker.Followers("alice"); len(followers) != 0 {
+		t.Errorf("Expected no followers left, got %v", followers)
+	}
+}
+
+func TestFollowRejectsLoops(t *testing.T) {
+	tracker := NewTracker()
+	if err := tracker.Follow("alice", "alice"); err != ErrFollowSelf {
+		t.Errorf("Expected ErrFollowSelf, got %v", err)
+	}
+
+	tracker.Follow("bob", "alice")
+	tracker.Follow("carol", "bob")
+	if err := tracker.Follow("alice", "carol"); err != ErrFollowLoop {
+		t.Errorf("Expected ErrFollowLoop, got %v", err)
+	}
+}
+
+func TestForget(t *testing.T) {
+	tracker := NewTracker()
+	tracker.Follow("bob", "alice")
+	tracker.Follow("carol", "bob")
+
+	if left := tracker.Forget("bob"); !slices.Equal(left, []string{"carol"}) {
+		t.Errorf("Expected carol to be left behind, got %v", left)
+	}
+	if _, ok := tracker.Leader("bob"); ok {
+		t.Errorf("Expected bob to stop following once forgotten")
+	}
+	if _, ok := tracker.Leader("carol"); ok {
+		t.Errorf("Expected carol to stop following once bob left")
+	}
+}
+
+func TestLeading(t *testing.T) {
+	tracker := NewTracker()
+	if tracker.Leading("alice") {
+		t.Errorf("Expected nobody to take followers until they agree")
+	}
+	tracker.SetLeading("alice", true)
+	if !tracker.Leading("alice") {
+		t.Errorf("Expected alice to take followers")
+	}
+	tracker.Forget("alice")
+	if tracker.Leading("alice") {
+		t.Errorf("Expected leaving the game to stop alice taking followers")
+	}
+}
@@ -0,0 +1,41 @@
+package items
+
+import "time"
+
+// Keys in a corpse's Modifications
+const (
+	corpseOwnerKey = "corpse_owner"
+	corpseDecayKey = "corpse_decays_at"
+)
+
+// NewCorpse returns the corpse of the character ownerID, called name, lying
+// in roomID until decaysAt. Items it holds are owned by the corpse itself.
+func NewCorpse(ownerID, name, roomID string, decaysAt time.Time) *ItemInstance {
+	corpse := NewItemInstance(CorpseTemplateID, roomID, 1)
+	corpse.ID = generateItemID()
+	corpse.CustomName = "the corpse of " + name
+	corpse.Modifications[corpseOwnerKey] = ownerID
+	corpse.Modifications[corpseDecayKey] = decaysAt.UTC().Format(time.RFC3339)
+	return corpse
+}
+
+func (ii *ItemInstance) IsCorpse() bool {
+	return ii.TemplateID == CorpseTemplateID
+}
+
+// CorpseOwner returns the ID of the character whose corpse this is
+func (ii *ItemInstance) CorpseOwner() string {
+	owner, _ := ii.Modifications[corpseOwnerKey].(string)
+	return owner
+}
+
+// CorpseDecayed reports whether the corpse's time has run out by now. A
+// corpse with no decay time recorded never decays.
+func (ii *ItemInstance) CorpseDecayed(now time.Time) bool {
+	value, _ := ii.Modifications[corpseDecayKey].(string)
+	decaysAt, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return false
+	}
+	return !now.Before(decaysAt)
+}
@@ -0,0 +1,36 @@
+package items
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestCorpse(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	corpse := NewCorpse("char1", "Alice", "riverbank", now.Add(time.Minute))
+
+	if !corpse.IsCorpse() || corpse.OwnerID != "riverbank" || corpse.CustomName != "the corpse of Alice" {
+		t.Errorf("Unexpected corpse %+v", corpse)
+	}
+
+	// Corpses are saved as JSON, so their details must survive it
+	data, err := json.Marshal(corpse)
+	if err != nil {
+		t.Fatalf("Failed to marshal corpse: %v", err)
+	}
+	var loaded ItemInstance
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		t.Fatalf("Failed to unmarshal corpse: %v", err)
+	}
+
+	if loaded.CorpseOwner() != "char1" {
+		t.Errorf("Expected owner char1, got %q", loaded.CorpseOwner())
+	}
+	if loaded.CorpseDecayed(now) {
+		t.Errorf("Expected the corpse to last a minute")
+	}
+	if !loaded.CorpseDecayed(now.Add(time.Minute)) {
+		t.Errorf("Expected the corpse to decay after a minute")
+	}
+}
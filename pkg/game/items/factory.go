@@ -2,6 +2,8 @@ package items
 
 import (
 	"fmt"
+	"time"
+	
 	"github.com/google/uuid"
 )
 
@@ -42,11 +44,17 @@ func (f *ItemFactory) CreateInstance(templateID, ownerID string, quantity int) (
 		Durability:   template.Durability,
 		Enchantments: []Enchantment{},
 		Modifications: make(map[string]interface{}),
+		CreatedAt:    time.Now(),
 	}
 	
 	return instance, nil
 }
 
+// Registry returns the templates the factory makes items from
+func (f *ItemFactory) Registry() *ItemRegistry {
+	return f.registry
+}
+
 func (f *ItemFactory) GetTemplate(templateID string) (*ItemTemplate, error) {
 	return f.registry.GetTemplate(templateID)
 }
@@ -218,10 +218,22 @@ func TestGetTemplatesByType(t *testing.T) {
 		t.Errorf("Expected at least one consumable template")
 	}
 	
-	// Test getting non-existent type
+	// Test getting crafting materials
 	materials := factory.GetTemplatesByType(ItemMaterial)
-	if len(materials) != 0 {
-		t.Errorf("Expected no material templates, got %d", len(materials))
+	if len(materials) == 0 {
+		t.Errorf("Expected at least one material template")
+	}
+	
+	// Test getting treasure
+	treasures := factory.GetTemplatesByType(ItemTreasure)
+	if len(treasures) != 1 || treasures[0].ID != GoldTemplateID {
+		t.Errorf("Expected gold coins to be the only treasure, got %d templates", len(treasures))
+	}
+	
+	// Test getting non-existent type
+	unknown := factory.GetTemplatesByType(ItemType(99))
+	if len(unknown) != 0 {
+		t.Errorf("Expected no templates of an unknown type, got %d", len(unknown))
 	}
 }
 
@@ -2,6 +2,9 @@ package items
 
 import (
 	"time"
+	
+	"github.com/elidor/dungeogo/pkg/game/damage"
+	"github.com/elidor/dungeogo/pkg/game/lock"
 )
 
 type ItemInstance struct {
@@ -22,6 +25,10 @@ type Enchantment struct {
 	Name        string
 	Description string
 	Type        EnchantmentType
+	// Element is the type of damage a resistance enchantment resists
+	Element     damage.Type
+	// Stat is the stat a stat enchantment raises
+	Stat        StatType
 	Power       int
 	Duration    time.Duration
 	AppliedAt   time.Time
@@ -107,6 +114,29 @@ func (ii *ItemInstance) GetEnchantmentBonus(enchantmentType EnchantmentType) int
 	return bonus
 }
 
+// ResistanceTo returns the resistance the item's resistance enchantments
+// give to damage of type t
+func (ii *ItemInstance) ResistanceTo(t damage.Type) int {
+	resistance := 0
+	for _, enchantment := range ii.Enchantments {
+		if enchantment.Type == EnchantmentResistance && enchantment.Element == t {
+			resistance += enchantment.Power
+		}
+	}
+	return resistance
+}
+
+// StatBonus returns how much the item's stat enchantments raise stat
+func (ii *ItemInstance) StatBonus(stat StatType) int {
+	bonus := 0
+	for _, enchantment := range ii.Enchantments {
+		if enchantment.Type == EnchantmentStat && enchantment.Stat == stat {
+			bonus += enchantment.Power
+		}
+	}
+	return bonus
+}
+
 func (ii *ItemInstance) UpdateLastUsed() {
 	ii.LastUsed = time.Now()
 }
@@ -117,4 +147,23 @@ func (ii *ItemInstance) CanStack(other *ItemInstance) bool {
 		   len(other.Enchantments) == 0 &&
 		   ii.Durability == other.Durability &&
 		   ii.CustomName == other.CustomName
+}
+
+// lockModification is where an instance's lock state is kept
+const lockModification = "lock"
+
+// LockState returns whether a lockable item is locked. New lockable items
+// start locked.
+func (ii *ItemInstance) LockState() lock.State {
+	if state, ok := ii.Modifications[lockModification].(string); ok {
+		return lock.State(state)
+	}
+	return lock.Locked
+}
+
+func (ii *ItemInstance) SetLockState(state lock.State) {
+	if ii.Modifications == nil {
+		ii.Modifications = make(map[string]interface{})
+	}
+	ii.Modifications[lockModification] = string(state)
 }
\ No newline at end of file
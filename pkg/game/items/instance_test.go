@@ -3,6 +3,8 @@ package items
 import (
 	"testing"
 	"time"
+	
+	"github.com/elidor/dungeogo/pkg/game/damage"
 )
 
 func TestNewItemInstance(t *testing.T) {
@@ -294,4 +296,42 @@ func TestEnchantmentDuration(t *testing.T) {
 	if instance.Enchantments[0].Duration != time.Millisecond*100 {
 		t.Errorf("Expected duration to be preserved")
 	}
-}
\ No newline at end of file
+}
+func TestResistanceTo(t *testing.T) {
+	instance := NewItemInstance("leather_armor", "player1", 1)
+	instance.AddEnchantment(Enchantment{ID: "warding", Type: EnchantmentResistance, Element: damage.Fire, Power: 20})
+	instance.AddEnchantment(Enchantment{ID: "frostward", Type: EnchantmentResistance, Element: damage.Cold, Power: 10})
+	instance.AddEnchantment(Enchantment{ID: "flaming", Type: EnchantmentDamage, Element: damage.Fire, Power: 5})
+	
+	if got := instance.ResistanceTo(damage.Fire); got != 20 {
+		t.Errorf("Expected 20 fire resistance, got %d", got)
+	}
+	if got := instance.ResistanceTo(damage.Poison); got != 0 {
+		t.Errorf("Expected no poison resistance, got %d", got)
+	}
+	if got := instance.GetEnchantmentBonus(EnchantmentResistance); got != 30 {
+		t.Errorf("Expected 30 resistance across every element, got %d", got)
+	}
+	
+	stats := ItemStats{MagicDefense: 5, Resistances: damage.Resistances{damage.Fire: 10}}
+	if got := stats.ResistanceTo(damage.Fire); got != 15 {
+		t.Errorf("Expected magic defense to add to fire resistance, got %d", got)
+	}
+	if got := stats.ResistanceTo(damage.Physical); got != 0 {
+		t.Errorf("Expected magic defense not to resist physical damage, got %d", got)
+	}
+}
+
+func TestStatBonus(t *testing.T) {
+	instance := NewItemInstance("rusty_sword", "player1", 1)
+	instance.AddEnchantment(Enchantment{ID: "might", Type: EnchantmentStat, Stat: StatStrength, Power: 3})
+	instance.AddEnchantment(Enchantment{ID: "grace", Type: EnchantmentStat, Stat: StatDexterity, Power: 1})
+	instance.AddEnchantment(Enchantment{ID: "sharp", Type: EnchantmentDamage, Power: 2})
+	
+	if got := instance.StatBonus(StatStrength); got != 3 {
+		t.Errorf("Expected +3 Strength, got %d", got)
+	}
+	if got := instance.StatBonus(StatWisdom); got != 0 {
+		t.Errorf("Expected no Wisdom bonus, got %d", got)
+	}
+}
@@ -0,0 +1,332 @@
+package items
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/elidor/dungeogo/pkg/game/damage"
+	"github.com/elidor/dungeogo/pkg/game/lock"
+)
+
+// DefaultTemplatePath is where the server looks for item template files
+// unless told otherwise
+const DefaultTemplatePath = "data/items"
+
+var weaponClassNames = map[string]WeaponClass{
+	"none":     WeaponNone,
+	"sword":    WeaponSword,
+	"axe":      WeaponAxe,
+	"mace":     WeaponMace,
+	"dagger":   WeaponDagger,
+	"bow":      WeaponBow,
+	"crossbow": WeaponCrossbow,
+	"staff":    WeaponStaff,
+}
+
+var statNames = map[string]StatType{
+	"strength":     StatStrength,
+	"dexterity":    StatDexterity,
+	"intelligence": StatIntelligence,
+	"constitution": StatConstitution,
+	"wisdom":       StatWisdom,
+	"charisma":     StatCharisma,
+}
+
+var slots = []EquipSlot{SlotNone, SlotMainHand, SlotOffHand, SlotHead, SlotBody, SlotHands, SlotLegs, SlotFeet}
+
+// templateData is an item template as written in a data file, with types,
+// rarities, slots, weapon classes, stats and damage types given by name
+type templateData struct {
+	ID           string           `json:"id"`
+	Name         string           `json:"name"`
+	Type         string           `json:"type"`
+	Description  string           `json:"description"`
+	Rarity       string           `json:"rarity"`
+	Weight       float64          `json:"weight"`
+	Value        int              `json:"value"`
+	Durability   int              `json:"durability"`
+	Enchantable  bool             `json:"enchantable"`
+	StackSize    int              `json:"stack_size"`
+	Slot         string           `json:"slot"`
+	WeaponClass  string           `json:"weapon_class"`
+	Stats        statsData        `json:"stats"`
+	Requirements requirementsData `json:"requirements"`
+	Lock         *lockData        `json:"lock"`
+}
+
+type statsData struct {
+	Damage       int            `json:"damage"`
+	Defense      int            `json:"defense"`
+	MagicDefense int            `json:"magic_defense"`
+	HitBonus     int            `json:"hit_bonus"`
+	DodgeBonus   int            `json:"dodge_bonus"`
+	StatBonuses  map[string]int `json:"stat_bonuses"`
+	Resistances  map[string]int `json:"resistances"`
+}
+
+type lockData struct {
+	KeyID      string `json:"key_id"`
+	Difficulty int    `json:"difficulty"`
+}
+
+type requirementsData struct {
+	MinLevel  int            `json:"min_level"`
+	MinStats  map[string]int `json:"min_stats"`
+	Races     []string       `json:"races"`
+	Classes   []string       `json:"classes"`
+	Forbidden []string       `json:"forbidden"`
+}
+
+// LoadTemplates reads item templates from path, which is either a JSON file
+// holding a list of templates or a directory of such files. A missing path
+// yields no templates. Every malformed entry is reported, by file and
+// position, rather than only the first.
+func LoadTemplates(path string) ([]*ItemTemplate, error) {
+	if path == "" {
+		return nil, nil
+	}
+	info, err := os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read item templates: %w", err)
+	}
+
+	files := []string{path}
+	if info.IsDir() {
+		if files, err = filepath.Glob(filepath.Join(path, "*.json")); err != nil {
+			return nil, fmt.Errorf("failed to list item template files: %w", err)
+		}
+		sort.Strings(files)
+	}
+
+	var templates []*ItemTemplate
+	var problems []error
+	seen := make(map[string]string)
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read item templates: %w", err)
+		}
+		var entries []templateData
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&entries); err != nil {
+			problems = append(problems, fmt.Errorf("%s: %w", file, err))
+			continue
+		}
+		for i, entry := range entries {
+			template, err := entry.template()
+			if err == nil && seen[entry.ID] != "" {
+				err = fmt.Errorf("%w: already defined in %s", ErrInvalidTemplate, seen[entry.ID])
+			}
+			if err != nil {
+				problems = append(problems, fmt.Errorf("%s: entry %d (%q): %w", file, i+1, entry.ID, err))
+				continue
+			}
+			seen[entry.ID] = file
+			templates = append(templates, template)
+		}
+	}
+	if len(problems) > 0 {
+		return nil, errors.Join(problems...)
+	}
+	return templates, nil
+}
+
+// template checks an entry and turns it into an ItemTemplate. Entries need
+// an ID, a name and a type; the stack size and durability default to one
+// and the slot to the one for the type.
+func (d *templateData) template() (*ItemTemplate, error) {
+	if d.ID == "" {
+		return nil, fmt.Errorf("%w: missing id", ErrInvalidTemplate)
+	}
+	if d.Name == "" {
+		return nil, fmt.Errorf("%w: missing name", ErrInvalidTemplate)
+	}
+	if d.Type == "" {
+		return nil, fmt.Errorf("%w: missing type", ErrInvalidTemplate)
+	}
+	itemType, ok := itemTypeByName(d.Type)
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown type %q", ErrInvalidTemplate, d.Type)
+	}
+
+	template := NewItemTemplate(d.ID, d.Name, itemType)
+	template.Description = d.Description
+	template.Weight = d.Weight
+	template.Value = d.Value
+	template.Durability = max(d.Durability, 1)
+	template.Enchantable = d.Enchantable
+	template.StackSize = max(d.StackSize, 1)
+	if d.Lock != nil {
+		template.Lock = &lock.Lock{KeyID: d.Lock.KeyID, Difficulty: d.Lock.Difficulty}
+	}
+
+	if d.Rarity != "" {
+		if template.Rarity, ok = rarityByName(d.Rarity); !ok {
+			return nil, fmt.Errorf("%w: unknown rarity %q", ErrInvalidTemplate, d.Rarity)
+		}
+	}
+	if d.Slot != "" {
+		template.Slot = EquipSlot(strings.ToLower(d.Slot))
+		if !slices.Contains(slots, template.Slot) {
+			return nil, fmt.Errorf("%w: unknown slot %q", ErrInvalidTemplate, d.Slot)
+		}
+	}
+	if d.WeaponClass != "" {
+		if template.WeaponClass, ok = weaponClassNames[strings.ToLower(d.WeaponClass)]; !ok {
+			return nil, fmt.Errorf("%w: unknown weapon class %q", ErrInvalidTemplate, d.WeaponClass)
+		}
+	}
+
+	stats := ItemStats{
+		Damage:       d.Stats.Damage,
+		Defense:      d.Stats.Defense,
+		MagicDefense: d.Stats.MagicDefense,
+		HitBonus:     d.Stats.HitBonus,
+		DodgeBonus:   d.Stats.DodgeBonus,
+	}
+	var err error
+	if stats.StatBonuses, err = statMap(d.Stats.StatBonuses); err != nil {
+		return nil, err
+	}
+	if len(d.Stats.Resistances) > 0 {
+		stats.Resistances = make(damage.Resistances)
+		for name, amount := range d.Stats.Resistances {
+			kind, ok := damage.TypeByName(name)
+			if !ok {
+				return nil, fmt.Errorf("%w: unknown damage type %q", ErrInvalidTemplate, name)
+			}
+			stats.Resistances[kind] = amount
+		}
+	}
+	template.BaseStats = stats
+
+	template.Requirements = Requirements{
+		MinLevel:      d.Requirements.MinLevel,
+		RequiredRace:  d.Requirements.Races,
+		RequiredClass: d.Requirements.Classes,
+		Forbidden:     d.Requirements.Forbidden,
+	}
+	if template.Requirements.MinStats, err = statMap(d.Requirements.MinStats); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+// statMap turns stats given by name into a map by StatType
+func statMap(byName map[string]int) (map[StatType]int, error) {
+	stats := make(map[StatType]int, len(byName))
+	for name, amount := range byName {
+		stat, ok := statNames[strings.ToLower(name)]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown stat %q", ErrInvalidTemplate, name)
+		}
+		stats[stat] = amount
+	}
+	return stats, nil
+}
+
+func itemTypeByName(name string) (ItemType, bool) {
+	for itemType := ItemWeapon; itemType <= ItemMaterial; itemType++ {
+		if strings.EqualFold(GetItemTypeName(itemType), name) {
+			return itemType, true
+		}
+	}
+	return ItemWeapon, false
+}
+
+func rarityByName(name string) (RarityType, bool) {
+	for rarity := RarityCommon; rarity <= RarityLegendary; rarity++ {
+		if strings.EqualFold(GetRarityName(rarity), name) {
+			return rarity, true
+		}
+	}
+	return RarityCommon, false
+}
+
+// TemplateLoader keeps a registry's item templates in step with the data
+// files at a path: the built-in templates, overridden or added to by those
+// in the files.
+type TemplateLoader struct {
+	path     string
+	registry *ItemRegistry
+	inUse    func() []string
+}
+
+func NewTemplateLoader(path string, registry *ItemRegistry) *TemplateLoader {
+	return &TemplateLoader{path: path, registry: registry}
+}
+
+// SetInUse sets how to list the templates that must not be removed, such as
+// those classes give as starting kit
+func (l *TemplateLoader) SetInUse(inUse func() []string) {
+	l.inUse = inUse
+}
+
+// Load reads the files and, if every template in them is valid and none
+// still in use is left out, swaps them into the registry
+func (l *TemplateLoader) Load() (*TemplateChanges, error) {
+	templates, err := LoadTemplates(l.path)
+	if err != nil {
+		return nil, err
+	}
+	if l.inUse != nil {
+		available := make(map[string]bool)
+		for _, template := range slices.Concat(defaultTemplates(), templates) {
+			available[template.ID] = true
+		}
+		var missing []string
+		for _, id := range l.inUse() {
+			if !available[id] {
+				missing = append(missing, id)
+			}
+		}
+		if len(missing) > 0 {
+			return nil, fmt.Errorf("%w: %s", ErrTemplateInUse, strings.Join(missing, ", "))
+		}
+	}
+	return l.registry.Replace(templates), nil
+}
+
+// Reload loads the files again while the server runs and says what changed
+func (l *TemplateLoader) Reload() (string, error) {
+	changes, err := l.Load()
+	if err != nil {
+		return "", err
+	}
+	return "Item templates reloaded. " + changes.String(), nil
+}
+
+// TemplateChanges lists the IDs of the templates a Replace added, changed
+// and removed
+type TemplateChanges struct {
+	Added   []string
+	Changed []string
+	Removed []string
+}
+
+func (c *TemplateChanges) String() string {
+	if len(c.Added)+len(c.Changed)+len(c.Removed) == 0 {
+		return "Nothing changed."
+	}
+	var parts []string
+	for _, part := range []struct {
+		label string
+		ids   []string
+	}{{"Added", c.Added}, {"Changed", c.Changed}, {"Removed", c.Removed}} {
+		if len(part.ids) > 0 {
+			parts = append(parts, fmt.Sprintf("%s: %s.", part.label, strings.Join(part.ids, ", ")))
+		}
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,156 @@
+package items
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/elidor/dungeogo/pkg/game/damage"
+)
+
+func writeTemplates(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write %s: %v", name, err)
+	}
+}
+
+const weaponsFile = `[
+	{
+		"id": "oak_shield",
+		"name": "Oak Shield",
+		"type": "shield",
+		"rarity": "uncommon",
+		"weight": 6,
+		"value": 40,
+		"durability": 90,
+		"stats": {"defense": 4, "resistances": {"fire": 10}},
+		"requirements": {"min_level": 2, "min_stats": {"strength": 10}}
+	},
+	{
+		"id": "rusty_sword",
+		"name": "Notched Sword",
+		"type": "Weapon",
+		"weapon_class": "sword",
+		"stats": {"damage": 6, "stat_bonuses": {"Strength": 1}}
+	}
+]`
+
+func TestLoadTemplates(t *testing.T) {
+	dir := t.TempDir()
+	writeTemplates(t, dir, "weapons.json", weaponsFile)
+	writeTemplates(t, dir, "keys.json", `[{"id": "vault_key", "name": "Vault Key", "type": "key", "stack_size": 0}]`)
+	writeTemplates(t, dir, "notes.txt", "not templates")
+
+	templates, err := LoadTemplates(dir)
+	if err != nil {
+		t.Fatalf("Failed to load templates: %v", err)
+	}
+	if len(templates) != 3 || templates[0].ID != "vault_key" {
+		t.Fatalf("Expected the three templates in file order, got %d", len(templates))
+	}
+
+	shield := templates[1]
+	if shield.Type != ItemShield || shield.Rarity != RarityUncommon || shield.Slot != SlotOffHand {
+		t.Errorf("Expected an uncommon shield for the off hand, got %+v", shield)
+	}
+	if shield.BaseStats.Defense != 4 || shield.BaseStats.Resistances[damage.Fire] != 10 {
+		t.Errorf("Expected the shield's stats, got %+v", shield.BaseStats)
+	}
+	if shield.Requirements.MinLevel != 2 || shield.Requirements.MinStats[StatStrength] != 10 {
+		t.Errorf("Expected the shield's requirements, got %+v", shield.Requirements)
+	}
+	if sword := templates[2]; sword.WeaponClass != WeaponSword || sword.BaseStats.StatBonuses[StatStrength] != 1 {
+		t.Errorf("Expected a sword giving strength, got %+v", sword)
+	}
+	if key := templates[0]; key.StackSize != 1 || key.Durability != 1 || key.Slot != SlotNone {
+		t.Errorf("Expected the key's defaults, got %+v", key)
+	}
+
+	if templates, err := LoadTemplates(filepath.Join(dir, "missing")); err != nil || templates != nil {
+		t.Errorf("Expected a missing path to give no templates, got %d (%v)", len(templates), err)
+	}
+}
+
+func TestLoadTemplatesReportsEveryProblem(t *testing.T) {
+	dir := t.TempDir()
+	writeTemplates(t, dir, "a.json", `[
+		{"name": "Nameless", "type": "tool"},
+		{"id": "bad_rarity", "name": "Bad", "type": "tool", "rarity": "mythic"},
+		{"id": "good", "name": "Good", "type": "tool"}
+	]`)
+	writeTemplates(t, dir, "b.json", `[{"id": "good", "name": "Again", "type": "tool"}]`)
+	writeTemplates(t, dir, "c.json", `[{"id": "typo", "name": "Typo", "type": "tool", "stacksize": 5}]`)
+
+	_, err := LoadTemplates(dir)
+	if !errors.Is(err, ErrInvalidTemplate) {
+		t.Fatalf("Expected ErrInvalidTemplate, got %v", err)
+	}
+	for _, want := range []string{
+		`a.json: entry 1 (""): invalid item template: missing id`,
+		`a.json: entry 2 ("bad_rarity"): invalid item template: unknown rarity "mythic"`,
+		`b.json: entry 1 ("good"): invalid item template: already defined in`,
+		`c.json: json: unknown field "stacksize"`,
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected %q in:\n%v", want, err)
+		}
+	}
+}
+
+func TestTemplateLoaderKeepsTemplatesInUse(t *testing.T) {
+	dir := t.TempDir()
+	writeTemplates(t, dir, "weapons.json", weaponsFile)
+	loader := NewTemplateLoader(dir, NewItemFactory().Registry())
+	loader.SetInUse(func() []string { return []string{"rusty_sword", "oak_shield"} })
+	if _, err := loader.Load(); err != nil {
+		t.Fatalf("Failed to load templates: %v", err)
+	}
+
+	os.Remove(filepath.Join(dir, "weapons.json"))
+	if _, err := loader.Reload(); !errors.Is(err, ErrTemplateInUse) || !strings.Contains(err.Error(), "oak_shield") {
+		t.Errorf("Expected the shield's removal to be refused, got %v", err)
+	}
+}
+
+func TestTemplateLoaderReload(t *testing.T) {
+	dir := t.TempDir()
+	writeTemplates(t, dir, "weapons.json", weaponsFile)
+	factory := NewItemFactory()
+	loader := NewTemplateLoader(dir, factory.Registry())
+
+	changes, err := loader.Load()
+	if err != nil {
+		t.Fatalf("Failed to load templates: %v", err)
+	}
+	if changes.String() != "Added: oak_shield. Changed: rusty_sword." {
+		t.Errorf("Unexpected changes %q", changes)
+	}
+	if template, _ := factory.GetTemplate("rusty_sword"); template.Name != "Notched Sword" {
+		t.Errorf("Expected the file to override the built-in sword, got %s", template.Name)
+	}
+
+	if summary, err := loader.Reload(); err != nil || summary != "Item templates reloaded. Nothing changed." {
+		t.Errorf("Expected nothing to change, got %q (%v)", summary, err)
+	}
+
+	// A broken file leaves the templates as they were
+	writeTemplates(t, dir, "weapons.json", `[{"id": "oak_shield", "type": "shield"}]`)
+	if _, err := loader.Reload(); err == nil {
+		t.Errorf("Expected a broken file to fail to reload")
+	}
+	if _, err := factory.GetTemplate("oak_shield"); err != nil {
+		t.Errorf("Expected the shield to be kept, got %v", err)
+	}
+
+	os.Remove(filepath.Join(dir, "weapons.json"))
+	summary, err := loader.Reload()
+	if err != nil || summary != "Item templates reloaded. Changed: rusty_sword. Removed: oak_shield." {
+		t.Errorf("Expected the built-in sword back and the shield gone, got %q (%v)", summary, err)
+	}
+	if template, _ := factory.GetTemplate("rusty_sword"); template.Name != "Rusty Sword" {
+		t.Errorf("Expected the built-in sword back, got %s", template.Name)
+	}
+}
@@ -2,14 +2,27 @@ package items
 
 import (
 	"errors"
+	"reflect"
+	"sort"
 	"sync"
+	
+	"github.com/elidor/dungeogo/pkg/game/lock"
 )
 
 var (
 	ErrTemplateNotFound = errors.New("item template not found")
 	ErrInvalidTemplate  = errors.New("invalid item template")
+	ErrTemplateInUse    = errors.New("item templates still in use would be removed")
 )
 
+// GoldTemplateID is the template for coins lying around the world. Picking
+// them up adds to a character's gold rather than their inventory.
+const GoldTemplateID = "gold_coins"
+
+// CorpseTemplateID is the template for the corpse a character leaves where
+// they died, holding what they carried
+const CorpseTemplateID = "corpse"
+
 type ItemRegistry struct {
 	templates map[string]*ItemTemplate
 	mutex     sync.RWMutex
@@ -74,8 +87,54 @@ func (ir *ItemRegistry) GetTemplatesByType(itemType ItemType) []*ItemTemplate {
 	return result
 }
 
+// Replace swaps the registry's templates for the built-in ones together with
+// templates, which take the place of any built-in template with the same ID.
+// Templates are never changed once registered, so anyone still holding one
+// sees it as it was.
+func (ir *ItemRegistry) Replace(templates []*ItemTemplate) *TemplateChanges {
+	replacement := make(map[string]*ItemTemplate)
+	for _, template := range defaultTemplates() {
+		replacement[template.ID] = template
+	}
+	for _, template := range templates {
+		replacement[template.ID] = template
+	}
+	
+	ir.mutex.Lock()
+	defer ir.mutex.Unlock()
+	
+	changes := &TemplateChanges{}
+	for id, template := range replacement {
+		old, exists := ir.templates[id]
+		if !exists {
+			changes.Added = append(changes.Added, id)
+		} else if !reflect.DeepEqual(old, template) {
+			changes.Changed = append(changes.Changed, id)
+		}
+	}
+	for id := range ir.templates {
+		if _, kept := replacement[id]; !kept {
+			changes.Removed = append(changes.Removed, id)
+		}
+	}
+	sort.Strings(changes.Added)
+	sort.Strings(changes.Changed)
+	sort.Strings(changes.Removed)
+	
+	ir.templates = replacement
+	return changes
+}
+
 func (ir *ItemRegistry) loadDefaultTemplates() {
-	templates := []*ItemTemplate{
+	for _, template := range defaultTemplates() {
+		ir.RegisterTemplate(template)
+	}
+}
+
+// defaultTemplates returns new copies of the templates built into the game,
+// which data files can override
+func defaultTemplates() []*ItemTemplate {
+	return []*ItemTemplate{
 		{
 			ID:          "rusty_sword",
 			Name:        "Rusty Sword",
@@ -88,11 +147,32 @@ func (ir *ItemRegistry) loadDefaultTemplates() {
 			Durability:  50,
 			Enchantable: true,
 			StackSize:   1,
+			Slot:        SlotMainHand,
+			WeaponClass: WeaponSword,
 			Requirements: Requirements{
 				MinLevel: 1,
 				MinStats: map[StatType]int{StatStrength: 8},
 			},
 		},
+		{
+			ID:          "worn_dagger",
+			Name:        "Worn Dagger",
+			Type:        ItemWeapon,
+			Description: "A short, nicked blade that is easy to conceal.",
+			BaseStats:   ItemStats{Damage: 3, HitBonus: 1, StatBonuses: make(map[StatType]int)},
+			Rarity:      RarityCommon,
+			Weight:      1.0,
+			Value:       8,
+			Durability:  40,
+			Enchantable: true,
+			StackSize:   1,
+			Slot:        SlotMainHand,
+			WeaponClass: WeaponDagger,
+			Requirements: Requirements{
+				MinLevel: 1,
+				MinStats: make(map[StatType]int),
+			},
+		},
 		{
 			ID:          "leather_armor",
 			Name:        "Leather Armor",
@@ -105,6 +185,7 @@ func (ir *ItemRegistry) loadDefaultTemplates() {
 			Durability:  75,
 			Enchantable: true,
 			StackSize:   1,
+			Slot:        SlotBody,
 			Requirements: Requirements{
 				MinLevel: 1,
 				MinStats: make(map[StatType]int),
@@ -145,15 +226,206 @@ func (ir *ItemRegistry) loadDefaultTemplates() {
 			Durability:  80,
 			Enchantable: true,
 			StackSize:   1,
+			Slot:        SlotMainHand,
+			WeaponClass: WeaponStaff,
 			Requirements: Requirements{
 				MinLevel: 3,
 				MinStats: map[StatType]int{StatIntelligence: 12},
 				RequiredClass: []string{"mage"},
 			},
 		},
-	}
-	
-	for _, template := range templates {
-		ir.RegisterTemplate(template)
+		{
+			ID:          "iron_ore",
+			Name:        "Iron Ore",
+			Type:        ItemMaterial,
+			Description: "A lump of rust-streaked rock, heavy with iron.",
+			BaseStats:   ItemStats{StatBonuses: make(map[StatType]int)},
+			Rarity:      RarityCommon,
+			Weight:      2.0,
+			Value:       3,
+			Durability:  1,
+			Enchantable: false,
+			StackSize:   20,
+			Requirements: Requirements{
+				MinStats: make(map[StatType]int),
+			},
+		},
+		{
+			ID:          "iron_ingot",
+			Name:        "Iron Ingot",
+			Type:        ItemMaterial,
+			Description: "A bar of smelted iron, ready for the forge.",
+			BaseStats:   ItemStats{StatBonuses: make(map[StatType]int)},
+			Rarity:      RarityCommon,
+			Weight:      1.5,
+			Value:       10,
+			Durability:  1,
+			Enchantable: false,
+			StackSize:   20,
+			Requirements: Requirements{
+				MinStats: make(map[StatType]int),
+			},
+		},
+		{
+			ID:          "leather_hide",
+			Name:        "Leather Hide",
+			Type:        ItemMaterial,
+			Description: "A tanned hide, supple enough to cut and stitch.",
+			BaseStats:   ItemStats{StatBonuses: make(map[StatType]int)},
+			Rarity:      RarityCommon,
+			Weight:      1.0,
+			Value:       5,
+			Durability:  1,
+			Enchantable: false,
+			StackSize:   10,
+			Requirements: Requirements{
+				MinStats: make(map[StatType]int),
+			},
+		},
+		{
+			ID:          "river_perch",
+			Name:        "River Perch",
+			Type:        ItemConsumable,
+			Description: "A small striped fish, good for a simple meal.",
+			BaseStats:   ItemStats{StatBonuses: make(map[StatType]int)},
+			Rarity:      RarityCommon,
+			Weight:      0.5,
+			Value:       4,
+			Durability:  1,
+			Enchantable: false,
+			StackSize:   10,
+			Requirements: Requirements{
+				MinStats: make(map[StatType]int),
+			},
+		},
+		{
+			ID:          "silver_trout",
+			Name:        "Silver Trout",
+			Type:        ItemConsumable,
+			Description: "A sleek trout whose scales flash like coins.",
+			BaseStats:   ItemStats{StatBonuses: make(map[StatType]int)},
+			Rarity:      RarityUncommon,
+			Weight:      1.0,
+			Value:       15,
+			Durability:  1,
+			Enchantable: false,
+			StackSize:   10,
+			Requirements: Requirements{
+				MinStats: make(map[StatType]int),
+			},
+		},
+		{
+			ID:          "golden_carp",
+			Name:        "Golden Carp",
+			Type:        ItemConsumable,
+			Description: "A fat carp gleaming gold, prized by cooks and collectors.",
+			BaseStats:   ItemStats{StatBonuses: make(map[StatType]int)},
+			Rarity:      RarityRare,
+			Weight:      2.0,
+			Value:       60,
+			Durability:  1,
+			Enchantable: false,
+			StackSize:   10,
+			Requirements: Requirements{
+				MinStats: make(map[StatType]int),
+			},
+		},
+		{
+			ID:          GoldTemplateID,
+			Name:        "Gold Coins",
+			Type:        ItemTreasure,
+			Description: "A handful of gold coins, worn smooth by many hands.",
+			BaseStats:   ItemStats{StatBonuses: make(map[StatType]int)},
+			Rarity:      RarityCommon,
+			Weight:      0.01,
+			Value:       1,
+			Durability:  1,
+			Enchantable: false,
+			StackSize:   1000000,
+			Requirements: Requirements{
+				MinStats: make(map[StatType]int),
+			},
+		},
+		{
+			ID:          "storeroom_key",
+			Name:        "Storeroom Key",
+			Type:        ItemKey,
+			Description: "A heavy iron key stamped with a barrel.",
+			BaseStats:   ItemStats{StatBonuses: make(map[StatType]int)},
+			Rarity:      RarityCommon,
+			Weight:      0.2,
+			Value:       1,
+			Durability:  1,
+			Enchantable: false,
+			StackSize:   1,
+			Requirements: Requirements{
+				MinStats: make(map[StatType]int),
+			},
+		},
+		{
+			ID:          "lockbox_key",
+			Name:        "Lockbox Key",
+			Type:        ItemKey,
+			Description: "A small brass key with fine teeth.",
+			BaseStats:   ItemStats{StatBonuses: make(map[StatType]int)},
+			Rarity:      RarityCommon,
+			Weight:      0.1,
+			Value:       1,
+			Durability:  1,
+			Enchantable: false,
+			StackSize:   1,
+			Requirements: Requirements{
+				MinStats: make(map[StatType]int),
+			},
+		},
+		{
+			ID:          "lockpick",
+			Name:        "Lockpick",
+			Type:        ItemTool,
+			Description: "A slender bent pin of spring steel.",
+			BaseStats:   ItemStats{StatBonuses: make(map[StatType]int)},
+			Rarity:      RarityCommon,
+			Weight:      0.1,
+			Value:       5,
+			Durability:  1,
+			Enchantable: false,
+			StackSize:   10,
+			Requirements: Requirements{
+				MinStats: make(map[StatType]int),
+			},
+		},
+		{
+			ID:          "iron_lockbox",
+			Name:        "Iron Lockbox",
+			Type:        ItemContainer,
+			Description: "A squat iron box with a stout lock.",
+			BaseStats:   ItemStats{StatBonuses: make(map[StatType]int)},
+			Rarity:      RarityCommon,
+			Weight:      4.0,
+			Value:       30,
+			Durability:  100,
+			Enchantable: false,
+			StackSize:   1,
+			Lock:        &lock.Lock{KeyID: "lockbox_key", Difficulty: 3},
+			Requirements: Requirements{
+				MinStats: make(map[StatType]int),
+			},
+		},
+		{
+			ID:          CorpseTemplateID,
+			Name:        "Corpse",
+			Type:        ItemContainer,
+			Description: "A lifeless body, its belongings still about it.",
+			BaseStats:   ItemStats{StatBonuses: make(map[StatType]int)},
+			Rarity:      RarityCommon,
+			Weight:      150.0,
+			Value:       0,
+			Durability:  1,
+			Enchantable: false,
+			StackSize:   1,
+			Requirements: Requirements{
+				MinStats: make(map[StatType]int),
+			},
+		},
 	}
 }
\ No newline at end of file
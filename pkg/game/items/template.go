@@ -1,5 +1,10 @@
 package items
 
+import (
+	"github.com/elidor/dungeogo/pkg/game/damage"
+	"github.com/elidor/dungeogo/pkg/game/lock"
+)
+
 type ItemTemplate struct {
 	ID          string
 	Name        string
@@ -12,7 +17,11 @@ type ItemTemplate struct {
 	Durability  int
 	Enchantable bool
 	StackSize   int
+	Slot        EquipSlot
+	WeaponClass WeaponClass
 	Requirements Requirements
+	// Lock is set for containers that can be locked
+	Lock         *lock.Lock
 }
 
 type ItemType int
@@ -29,13 +38,76 @@ const (
 	ItemMaterial
 )
 
+// WeaponClass is the kind of weapon an item is, which decides the skill
+// used to fight with it
+type WeaponClass int
+
+const (
+	WeaponNone WeaponClass = iota
+	WeaponSword
+	WeaponAxe
+	WeaponMace
+	WeaponDagger
+	WeaponBow
+	WeaponCrossbow
+	WeaponStaff
+)
+
+// TwoHanded reports whether weapons of the class take both hands, so they
+// cannot be wielded in the off hand or while it holds anything
+func (c WeaponClass) TwoHanded() bool {
+	return c == WeaponBow || c == WeaponCrossbow || c == WeaponStaff
+}
+
+// EquipSlot is where an item is worn or wielded. Items with no slot cannot
+// be equipped.
+type EquipSlot string
+
+const (
+	SlotNone     EquipSlot = ""
+	SlotMainHand EquipSlot = "main_hand"
+	SlotOffHand  EquipSlot = "off_hand"
+	SlotHead     EquipSlot = "head"
+	SlotBody     EquipSlot = "body"
+	SlotHands    EquipSlot = "hands"
+	SlotLegs     EquipSlot = "legs"
+	SlotFeet     EquipSlot = "feet"
+)
+
+// DefaultSlotForType returns the slot an item of the given type occupies
+// when its template does not name one.
+func DefaultSlotForType(itemType ItemType) EquipSlot {
+	switch itemType {
+	case ItemWeapon:
+		return SlotMainHand
+	case ItemShield:
+		return SlotOffHand
+	case ItemArmor:
+		return SlotBody
+	default:
+		return SlotNone
+	}
+}
+
 type ItemStats struct {
 	Damage       int
 	Defense      int
+	// MagicDefense resists every type of damage but physical
 	MagicDefense int
 	HitBonus     int
 	DodgeBonus   int
 	StatBonuses  map[StatType]int
+	// Resistances resist the damage of particular types
+	Resistances  damage.Resistances
+}
+
+// ResistanceTo returns the resistance the item gives to damage of type t
+func (s ItemStats) ResistanceTo(t damage.Type) int {
+	resistance := s.Resistances[t]
+	if t != damage.Physical {
+		resistance += s.MagicDefense
+	}
+	return resistance
 }
 
 type StatType int
@@ -79,6 +151,7 @@ func NewItemTemplate(id, name string, itemType ItemType) *ItemTemplate {
 		Durability:  100,
 		Enchantable: true,
 		StackSize:   1,
+		Slot:        DefaultSlotForType(itemType),
 		Requirements: Requirements{
 			MinStats: make(map[StatType]int),
 		},
@@ -0,0 +1,40 @@
+package items
+
+// EnchantmentValue is the gold each point of enchantment power adds to an
+// item's worth
+const EnchantmentValue = 5
+
+// BrokenValuePercent is the share of its value a broken item keeps, as
+// scrap
+const BrokenValuePercent = 10
+
+// rarityValuePercent scales an item's base value by how rare it is
+var rarityValuePercent = map[RarityType]int{
+	RarityCommon:    100,
+	RarityUncommon:  150,
+	RarityRare:      250,
+	RarityEpic:      400,
+	RarityLegendary: 700,
+}
+
+// Appraise returns what a single item of the stack is worth: the
+// template's base value scaled by rarity and the item's condition, plus
+// its enchantments. A damaged item loses value in proportion to its lost
+// durability, down to BrokenValuePercent.
+func Appraise(template *ItemTemplate, item *ItemInstance) int {
+	percent, ok := rarityValuePercent[template.Rarity]
+	if !ok {
+		percent = 100
+	}
+	value := template.Value * percent / 100
+
+	if template.Durability > 0 {
+		condition := min(max(item.Durability*100/template.Durability, BrokenValuePercent), 100)
+		value = value * condition / 100
+	}
+
+	for _, enchantment := range item.Enchantments {
+		value += enchantment.Power * EnchantmentValue
+	}
+	return value
+}
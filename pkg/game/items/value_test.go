@@ -0,0 +1,40 @@
+package items
+
+import "testing"
+
+func TestAppraise(t *testing.T) {
+	template := &ItemTemplate{ID: "blade", Name: "Blade", Value: 40, Durability: 50}
+	tests := []struct {
+		name         string
+		rarity       RarityType
+		durability   int
+		enchantments []Enchantment
+		expected     int
+	}{
+		{"pristine", RarityCommon, 50, nil, 40},
+		{"half worn", RarityCommon, 25, nil, 20},
+		{"broken keeps scrap value", RarityCommon, 0, nil, 4},
+		{"rare", RarityRare, 50, nil, 100},
+		{"worn rare", RarityRare, 25, nil, 50},
+		{"enchanted", RarityCommon, 50, []Enchantment{{Power: 2}, {Power: 1}}, 40 + 3*EnchantmentValue},
+		{"broken but enchanted", RarityCommon, 0, []Enchantment{{Power: 4}}, 4 + 4*EnchantmentValue},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			template.Rarity = tt.rarity
+			item := &ItemInstance{Durability: tt.durability, Enchantments: tt.enchantments}
+			if got := Appraise(template, item); got != tt.expected {
+				t.Errorf("Expected %d gold, got %d", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestAppraiseWithoutDurability(t *testing.T) {
+	// Items that never wear out, like potions, are always in full condition
+	template := &ItemTemplate{ID: "tonic", Name: "Tonic", Value: 12, Rarity: RarityUncommon}
+	if got := Appraise(template, &ItemInstance{Durability: 0}); got != 18 {
+		t.Errorf("Expected 18 gold, got %d", got)
+	}
+}
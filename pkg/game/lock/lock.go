@@ -0,0 +1,75 @@
+// Package lock models locks on doors and containers: opening them with the
+// matching key, or picking them with the Lockpicking skill.
+package lock
+
+// State is whether a lock is open. A jammed lock is shut and can no longer
+// be picked, though its key still turns it.
+type State string
+
+const (
+	Locked   State = "locked"
+	Unlocked State = "unlocked"
+	Jammed   State = "jammed"
+)
+
+const (
+	// basePickChance is the percent chance to pick a lock whose difficulty
+	// matches the character's skill; each level of difference moves it by
+	// pickChancePerLevel.
+	basePickChance     = 50
+	pickChancePerLevel = 15
+	maxPickChance      = 95
+
+	// Of failed attempts, this percent break the pick, and of those that
+	// do not, jamChance percent jam the lock.
+	breakChance = 30
+	jamChance   = 10
+
+	// experiencePerDifficulty is awarded per level of difficulty, plus one,
+	// for each lock picked.
+	experiencePerDifficulty = 10
+)
+
+// Lock is opened by the item template KeyID, or picked by a character whose
+// Lockpicking skill is a match for Difficulty.
+type Lock struct {
+	KeyID      string
+	Difficulty int
+}
+
+// Outcome is what happened when a character tried to pick a lock.
+type Outcome int
+
+const (
+	Picked Outcome = iota
+	Failed
+	PickBroke
+	LockJammed
+)
+
+// PickChance returns the percent chance that a character with skill picks
+// the lock. It is 0 when the lock is beyond them.
+func (l Lock) PickChance(skill int) int {
+	chance := basePickChance + (skill-l.Difficulty)*pickChancePerLevel
+	return max(0, min(chance, maxPickChance))
+}
+
+// Pick works out one attempt on the lock by a character with skill. roll
+// returns a number from 0 to n-1.
+func (l Lock) Pick(skill int, roll func(n int) int) Outcome {
+	if roll(100) < l.PickChance(skill) {
+		return Picked
+	}
+	if roll(100) < breakChance {
+		return PickBroke
+	}
+	if roll(100) < jamChance {
+		return LockJammed
+	}
+	return Failed
+}
+
+// Experience is the Lockpicking experience for picking the lock.
+func (l Lock) Experience() int {
+	return (l.Difficulty + 1) * experiencePerDifficulty
+}
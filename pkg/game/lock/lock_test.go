@@ -0,0 +1,54 @@
+package lock
+
+import "testing"
+
+// fixedRolls returns each value in turn, whatever n is.
+func fixedRolls(values ...int) func(n int) int {
+	return func(n int) int {
+		value := values[0]
+		values = values[1:]
+		return value
+	}
+}
+
+func TestPickChance(t *testing.T) {
+	l := Lock{Difficulty: 3}
+	tests := []struct {
+		skill    int
+		expected int
+	}{
+		{3, 50},
+		{4, 65},
+		{0, 5},
+		{-1, 0},
+		{10, 95},
+	}
+	for _, test := range tests {
+		if got := l.PickChance(test.skill); got != test.expected {
+			t.Errorf("PickChance(%d) = %d, want %d", test.skill, got, test.expected)
+		}
+	}
+}
+
+func TestPick(t *testing.T) {
+	l := Lock{Difficulty: 2}
+
+	if got := l.Pick(2, fixedRolls(49)); got != Picked {
+		t.Errorf("Expected the lock to be picked, got %v", got)
+	}
+	if got := l.Pick(2, fixedRolls(50, 29)); got != PickBroke {
+		t.Errorf("Expected the pick to break, got %v", got)
+	}
+	if got := l.Pick(2, fixedRolls(50, 30, 9)); got != LockJammed {
+		t.Errorf("Expected the lock to jam, got %v", got)
+	}
+	if got := l.Pick(2, fixedRolls(50, 30, 10)); got != Failed {
+		t.Errorf("Expected a plain failure, got %v", got)
+	}
+}
+
+func TestExperienceGrowsWithDifficulty(t *testing.T) {
+	if (Lock{Difficulty: 3}).Experience() <= (Lock{Difficulty: 1}).Experience() {
+		t.Errorf("Expected harder locks to teach more")
+	}
+}
@@ -0,0 +1,183 @@
+// Package magic defines the spells characters can learn and the spellbooks
+// recording which spells each character knows and has prepared to cast.
+package magic
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/elidor/dungeogo/pkg/game/damage"
+)
+
+var ErrSpellNotFound = errors.New("spell not found")
+
+// School is the branch of magic a spell belongs to. Each school has its own
+// skill, which casters need along with Magic to learn the school's spells.
+type School int
+
+const (
+	SchoolEvocation School = iota
+	SchoolHealing
+	SchoolDivination
+)
+
+// Effect is what a spell does once cast
+type Effect int
+
+const (
+	// EffectDamage hurts a foe
+	EffectDamage Effect = iota
+	// EffectHeal restores the caster's health
+	EffectHeal
+)
+
+// Spell is something a character can learn, prepare and cast
+type Spell struct {
+	ID          string
+	Name        string
+	Description string
+	// Classes are the IDs of the classes that can learn the spell
+	Classes []string
+	// Level is the character level needed to learn the spell
+	Level  int
+	School School
+	// MinSkill is the level of Magic and of the school's skill needed to
+	// learn the spell
+	MinSkill int
+	ManaCost int
+	Effect   Effect
+	// Element is the type of damage a harmful spell does
+	Element damage.Type
+	// Power is the least damage or healing the spell does, and Spread how
+	// much more it can roll on top
+	Power  int
+	Spread int
+	// Cooldown is how long the caster must wait before casting the spell
+	// again
+	Cooldown time.Duration
+}
+
+// TeachesClass reports whether characters of classID can learn the spell
+func (s *Spell) TeachesClass(classID string) bool {
+	for _, id := range s.Classes {
+		if id == classID {
+			return true
+		}
+	}
+	return false
+}
+
+// Roll returns how much damage or healing one casting does. roll returns
+// a number from 0 to n-1.
+func (s *Spell) Roll(roll func(n int) int) int {
+	return s.Power + roll(s.Spread+1)
+}
+
+var spells = indexSpells(getStandardSpells())
+
+func indexSpells(list []*Spell) map[string]*Spell {
+	index := make(map[string]*Spell, len(list))
+	for _, s := range list {
+		index[s.ID] = s
+	}
+	return index
+}
+
+func GetSpellByID(id string) (*Spell, error) {
+	if s, ok := spells[id]; ok {
+		return s, nil
+	}
+	return nil, ErrSpellNotFound
+}
+
+// FindSpell returns the spell called name, matching its name or ID without
+// regard to case
+func FindSpell(name string) (*Spell, error) {
+	for _, s := range spells {
+		if strings.EqualFold(s.Name, name) || strings.EqualFold(s.ID, name) {
+			return s, nil
+		}
+	}
+	return nil, ErrSpellNotFound
+}
+
+// GetAllSpells returns every spell, ordered by the level needed to learn it
+// and then by name
+func GetAllSpells() []*Spell {
+	list := make([]*Spell, 0, len(spells))
+	for _, s := range spells {
+		list = append(list, s)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Level != list[j].Level {
+			return list[i].Level < list[j].Level
+		}
+		return list[i].Name < list[j].Name
+	})
+	return list
+}
+
+func getStandardSpells() []*Spell {
+	return []*Spell{
+		{
+			ID:          "magic_missile",
+			Name:        "Magic Missile",
+			Description: "Launches a magical projectile that always hits",
+			Classes:     []string{"mage"},
+			Level:       1,
+			School:      SchoolEvocation,
+			ManaCost:    5,
+			Effect:      EffectDamage,
+			Element:     damage.Arcane,
+			Power:       4,
+			Spread:      4,
+			Cooldown:    3 * time.Second,
+		},
+		{
+			ID:          "mend",
+			Name:        "Mend",
+			Description: "Knits the caster's wounds closed",
+			Classes:     []string{"mage"},
+			Level:       2,
+			School:      SchoolHealing,
+			MinSkill:    1,
+			ManaCost:    8,
+			Effect:      EffectHeal,
+			Power:       8,
+			Spread:      6,
+			Cooldown:    10 * time.Second,
+		},
+		{
+			ID:          "fire_bolt",
+			Name:        "Fire Bolt",
+			Description: "Hurls a bolt of flame at a foe",
+			Classes:     []string{"mage"},
+			Level:       3,
+			School:      SchoolEvocation,
+			MinSkill:    2,
+			ManaCost:    10,
+			Effect:      EffectDamage,
+			Element:     damage.Fire,
+			Power:       8,
+			Spread:      8,
+			Cooldown:    6 * time.Second,
+		},
+		{
+			ID:          "lightning_bolt",
+			Name:        "Lightning Bolt",
+			Description: "Calls down a crackling bolt of lightning",
+			Classes:     []string{"mage"},
+			Level:       6,
+			School:      SchoolEvocation,
+			MinSkill:    4,
+			ManaCost:    18,
+			Effect:      EffectDamage,
+			Element:     damage.Lightning,
+			Power:       15,
+			Spread:      12,
+			Cooldown:    12 * time.Second,
+		},
+	}
+}
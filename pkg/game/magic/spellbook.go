@@ -0,0 +1,93 @@
+package magic
+
+import (
+	"errors"
+	"slices"
+)
+
+var (
+	ErrSpellNotKnown = errors.New("spell not known")
+	ErrNoSlotsFree   = errors.New("no spell slots free")
+)
+
+// Slots returns how many castings a caster of level and intelligence can
+// hold prepared at once: two to start with, one more every second level,
+// and one for every two points of Intelligence above 10.
+func Slots(level, intelligence int) int {
+	return 2 + max(level-1, 0)/2 + max(intelligence-10, 0)/2
+}
+
+// Spellbook records the spells a character has learned and how many
+// castings of each they have prepared. Casting a spell spends one of its
+// prepared castings.
+type Spellbook struct {
+	// Known are the IDs of the spells learned, in the order learned
+	Known []string
+	// Prepared is how many castings of each spell are ready, by ID
+	Prepared map[string]int
+}
+
+func NewSpellbook() *Spellbook {
+	return &Spellbook{
+		Known:    []string{},
+		Prepared: make(map[string]int),
+	}
+}
+
+// Knows reports whether the spell with id has been learned
+func (b *Spellbook) Knows(id string) bool {
+	return slices.Contains(b.Known, id)
+}
+
+// Learn adds the spell with id to the book. It reports whether the spell
+// was new.
+func (b *Spellbook) Learn(id string) bool {
+	if b.Knows(id) {
+		return false
+	}
+	b.Known = append(b.Known, id)
+	return true
+}
+
+// PreparedCount returns how many castings are prepared in all
+func (b *Spellbook) PreparedCount() int {
+	count := 0
+	for _, n := range b.Prepared {
+		count += n
+	}
+	return count
+}
+
+// Prepare readies one more casting of the known spell with id, if fewer
+// than slots castings are already prepared
+func (b *Spellbook) Prepare(id string, slots int) error {
+	if !b.Knows(id) {
+		return ErrSpellNotKnown
+	}
+	if b.PreparedCount() >= slots {
+		return ErrNoSlotsFree
+	}
+	if b.Prepared == nil {
+		b.Prepared = make(map[string]int)
+	}
+	b.Prepared[id]++
+	return nil
+}
+
+// Expend spends one prepared casting of the spell with id. It reports
+// whether there was one to spend.
+func (b *Spellbook) Expend(id string) bool {
+	if b.Prepared[id] <= 0 {
+		return false
+	}
+	b.Prepared[id]--
+	if b.Prepared[id] == 0 {
+		delete(b.Prepared, id)
+	}
+	return true
+}
+
+// Clear forgets every prepared casting, freeing all the slots
+func (b *Spellbook) Clear() {
+	b.Prepared = make(map[string]int)
+}
@@ -0,0 +1,93 @@
+package magic
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSlots(t *testing.T) {
+	tests := []struct {
+		level, intelligence, expected int
+	}{
+		{1, 10, 2},
+		{1, 8, 2},
+		{1, 12, 3},
+		{3, 10, 3},
+		{6, 14, 6},
+	}
+	for _, tt := range tests {
+		if got := Slots(tt.level, tt.intelligence); got != tt.expected {
+			t.Errorf("Expected %d slots at level %d with %d Intelligence, got %d", tt.expected, tt.level, tt.intelligence, got)
+		}
+	}
+}
+
+func TestPrepareAndExpend(t *testing.T) {
+	book := NewSpellbook()
+	if err := book.Prepare("magic_missile", 2); !errors.Is(err, ErrSpellNotKnown) {
+		t.Errorf("Expected an unknown spell to be refused, got %v", err)
+	}
+
+	if !book.Learn("magic_missile") {
+		t.Errorf("Expected Magic Missile to be new")
+	}
+	if book.Learn("magic_missile") {
+		t.Errorf("Expected Magic Missile to be learned only once")
+	}
+	for i := 0; i < 2; i++ {
+		if err := book.Prepare("magic_missile", 2); err != nil {
+			t.Fatalf("Unexpected error preparing: %v", err)
+		}
+	}
+	if err := book.Prepare("magic_missile", 2); !errors.Is(err, ErrNoSlotsFree) {
+		t.Errorf("Expected the slots to be full, got %v", err)
+	}
+	if book.PreparedCount() != 2 {
+		t.Errorf("Expected 2 castings prepared, got %d", book.PreparedCount())
+	}
+
+	if !book.Expend("magic_missile") || !book.Expend("magic_missile") {
+		t.Fatalf("Expected both prepared castings to be spent")
+	}
+	if book.Expend("magic_missile") {
+		t.Errorf("Expected nothing left to spend")
+	}
+	if _, ok := book.Prepared["magic_missile"]; ok {
+		t.Errorf("Expected a spent spell to be dropped from the prepared castings")
+	}
+}
+
+func TestClearFreesSlots(t *testing.T) {
+	book := NewSpellbook()
+	book.Learn("magic_missile")
+	book.Prepare("magic_missile", 1)
+
+	book.Clear()
+	if book.PreparedCount() != 0 {
+		t.Errorf("Expected no castings prepared, got %d", book.PreparedCount())
+	}
+	if err := book.Prepare("magic_missile", 1); err != nil {
+		t.Errorf("Expected the slot to be free again, got %v", err)
+	}
+}
+
+func TestFindSpell(t *testing.T) {
+	for _, name := range []string{"Magic Missile", "magic missile", "magic_missile"} {
+		if s, err := FindSpell(name); err != nil || s.ID != "magic_missile" {
+			t.Errorf("Expected %q to find Magic Missile, got %v, %v", name, s, err)
+		}
+	}
+	if _, err := FindSpell("fireball"); !errors.Is(err, ErrSpellNotFound) {
+		t.Errorf("Expected an unknown spell not to be found, got %v", err)
+	}
+}
+
+func TestRoll(t *testing.T) {
+	spell, _ := GetSpellByID("magic_missile")
+	if got := spell.Roll(func(n int) int { return 0 }); got != spell.Power {
+		t.Errorf("Expected the lowest roll to do %d, got %d", spell.Power, got)
+	}
+	if got := spell.Roll(func(n int) int { return n - 1 }); got != spell.Power+spell.Spread {
+		t.Errorf("Expected the highest roll to do %d, got %d", spell.Power+spell.Spread, got)
+	}
+}
@@ -0,0 +1,172 @@
+package npc
+
+import (
+	"slices"
+
+	"github.com/elidor/dungeogo/pkg/game/faction"
+	"github.com/elidor/dungeogo/pkg/game/world"
+)
+
+const (
+	// wanderChance is the percent chance each tick that a wandering NPC
+	// moves on
+	wanderChance = 25
+	// pursueChance is the percent chance that an aggressive NPC chases a
+	// fleeing foe into the next room
+	pursueChance = 50
+)
+
+// Mind decides what NPCs of one behavior do each tick. New kinds of NPC
+// plug in their own with Manager.RegisterMind.
+type Mind interface {
+	// Think lets n act, changing its state, room or target directly, and
+	// returns what others should hear about.
+	Think(n *NPC, senses Senses) []Event
+}
+
+// Senses is what an NPC perceives on its turn.
+type Senses struct {
+	// Characters lists the IDs of characters in the NPC's room
+	Characters []string
+	// Roll returns a number from 0 to n-1
+	Roll func(n int) int
+	// Standing returns how a faction regards a character. It is nil when
+	// reputation is not tracked, and every character counts as a foe.
+	Standing func(characterID, factionID string) faction.Standing
+	// Shielded reports whether a character is too new to be attacked on
+	// sight by an NPC of a level. It is nil when no one is protected.
+	Shielded func(characterID string, level int) bool
+}
+
+// foes returns the characters n would attack on sight: everyone, unless n
+// belongs to a faction, in which case only those it is hostile to. Either
+// way, newbies shielded from n are left alone.
+func (s Senses) foes(n *NPC) []string {
+	var foes []string
+	for _, id := range s.Characters {
+		if n.Template.Faction != "" && s.Standing != nil && !s.Standing(id, n.Template.Faction).AttackOnSight() {
+			continue
+		}
+		if s.Shielded != nil && s.Shielded(id, n.Template.Level) {
+			continue
+		}
+		foes = append(foes, id)
+	}
+	return foes
+}
+
+// EventType is the kind of thing an NPC did
+type EventType int
+
+const (
+	// EventLeave is sent to RoomID when the NPC walks out Direction
+	EventLeave EventType = iota
+	// EventArrive is sent to RoomID when the NPC walks in
+	EventArrive
+	// EventAttack is when the NPC starts a fight with Target
+	EventAttack
+	// EventHit is when the NPC strikes Target for Damage
+	EventHit
+)
+
+// Event is something an NPC did that players may need to hear about.
+type Event struct {
+	Type      EventType
+	NPC       *NPC
+	RoomID    string
+	Direction string
+	Target    string
+	Damage    int
+}
+
+func defaultMinds() map[Behavior]Mind {
+	return map[Behavior]Mind{
+		BehaviorPassive:    PassiveMind{},
+		BehaviorWander:     WanderMind{},
+		BehaviorAggressive: AggressiveMind{},
+	}
+}
+
+// PassiveMind stands still. It fights back while its target stays in the
+// room and calms down once they leave.
+type PassiveMind struct{}
+
+func (PassiveMind) Think(n *NPC, senses Senses) []Event {
+	return fightOn(n, senses)
+}
+
+// WanderMind drifts between rooms through open exits, fighting back like a
+// passive NPC when attacked.
+type WanderMind struct{}
+
+func (WanderMind) Think(n *NPC, senses Senses) []Event {
+	if n.State == StateFighting {
+		return fightOn(n, senses)
+	}
+	if senses.Roll(100) >= wanderChance {
+		n.State = StateIdle
+		return nil
+	}
+
+	room, err := world.GetRoom(n.RoomID)
+	if err != nil {
+		return nil
+	}
+	// Doors stay shut to wanderers
+	var open []string
+	for _, direction := range room.SortedExits() {
+		if room.Exits[direction].DoorID == "" {
+			open = append(open, direction)
+		}
+	}
+	if len(open) == 0 {
+		n.State = StateIdle
+		return nil
+	}
+
+	direction := open[senses.Roll(len(open))]
+	from := n.RoomID
+	n.RoomID = room.Exits[direction].To
+	n.State = StateWandering
+	return []Event{
+		{Type: EventLeave, NPC: n, RoomID: from, Direction: direction},
+		{Type: EventArrive, NPC: n, RoomID: n.RoomID},
+	}
+}
+
+// AggressiveMind attacks anyone in its room its faction is hostile to.
+type AggressiveMind struct{}
+
+func (AggressiveMind) Think(n *NPC, senses Senses) []Event {
+	if n.State == StateFighting {
+		if events := fightOn(n, senses); n.State == StateFighting {
+			return events
+		}
+	}
+	foes := senses.foes(n)
+	if len(foes) == 0 {
+		return nil
+	}
+
+	n.State = StateFighting
+	n.Target = foes[senses.Roll(len(foes))]
+	return []Event{{Type: EventAttack, NPC: n, RoomID: n.RoomID, Target: n.Target}}
+}
+
+// fightOn keeps n fighting its target while they are still in the room,
+// and otherwise lets it calm down.
+func fightOn(n *NPC, senses Senses) []Event {
+	if n.State != StateFighting {
+		return nil
+	}
+	if !slices.Contains(senses.Characters, n.Target) {
+		n.State = StateIdle
+		n.Target = ""
+		return nil
+	}
+	// Harmless NPCs, such as training dummies, just soak up blows
+	if n.Template.Damage <= 0 {
+		return nil
+	}
+	return []Event{{Type: EventHit, NPC: n, RoomID: n.RoomID, Target: n.Target, Damage: senses.Roll(n.Template.Damage) + 1}}
+}
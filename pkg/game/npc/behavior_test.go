@@ -0,0 +1,177 @@
+package npc
+
+import (
+	"testing"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+	"github.com/elidor/dungeogo/pkg/game/faction"
+)
+
+func testNPC(t *testing.T, templateID, roomID string) *NPC {
+	template, err := GetTemplate(templateID)
+	if err != nil {
+		t.Fatalf("Failed to get template %s: %v", templateID, err)
+	}
+	return &NPC{ID: "npc1", Template: template, RoomID: roomID, Health: template.MaxHealth, State: StateIdle}
+}
+
+func firstRoll(n int) int { return 0 }
+
+func TestAggressiveMindAttacks(t *testing.T) {
+	goblin := testNPC(t, "goblin", "riverbank")
+	mind := AggressiveMind{}
+
+	if events := mind.Think(goblin, Senses{Roll: firstRoll}); len(events) != 0 {
+		t.Errorf("Expected nothing to attack in an empty room, got %v", events)
+	}
+
+	events := mind.Think(goblin, Senses{Characters: []string{"char1"}, Roll: firstRoll})
+	if len(events) != 1 || events[0].Type != EventAttack || events[0].Target != "char1" {
+		t.Fatalf("Expected an attack on char1, got %v", events)
+	}
+	if goblin.State != StateFighting || goblin.Target != "char1" {
+		t.Errorf("Expected the goblin to be fighting char1, got %s %s", goblin.State, goblin.Target)
+	}
+
+	events = mind.Think(goblin, Senses{Characters: []string{"char1"}, Roll: firstRoll})
+	if len(events) != 1 || events[0].Type != EventHit || events[0].Damage != 1 {
+		t.Errorf("Expected the goblin to hit char1, got %v", events)
+	}
+
+	// Once the target leaves, the goblin looks for someone else
+	events = mind.Think(goblin, Senses{Characters: []string{"char2"}, Roll: firstRoll})
+	if len(events) != 1 || events[0].Type != EventAttack || goblin.Target != "char2" {
+		t.Errorf("Expected the goblin to turn on char2, got %v", events)
+	}
+	mind.Think(goblin, Senses{Roll: firstRoll})
+	if goblin.State != StateIdle || goblin.Target != "" {
+		t.Errorf("Expected the goblin to calm down in an empty room")
+	}
+}
+
+func TestAggressiveMindSparesTolerated(t *testing.T) {
+	goblin := testNPC(t, "goblin", "riverbank")
+	standing := func(characterID, factionID string) faction.Standing {
+		if characterID == "friend" {
+			return faction.StandingNeutral
+		}
+		return faction.StandingHostile
+	}
+
+	senses := Senses{Characters: []string{"friend"}, Roll: firstRoll, Standing: standing}
+	if events := (AggressiveMind{}).Think(goblin, senses); len(events) != 0 {
+		t.Errorf("Expected the goblin to leave a tolerated character alone, got %v", events)
+	}
+
+	senses.Characters = []string{"friend", "foe"}
+	events := AggressiveMind{}.Think(goblin, senses)
+	if len(events) != 1 || events[0].Target != "foe" {
+		t.Errorf("Expected the goblin to attack foe, got %v", events)
+	}
+}
+
+func TestAggressiveMindSparesShielded(t *testing.T) {
+	goblin := testNPC(t, "goblin", "riverbank")
+	var asked int
+	shielded := func(characterID string, level int) bool {
+		asked = level
+		return characterID == "newbie"
+	}
+
+	senses := Senses{Characters: []string{"newbie"}, Roll: firstRoll, Shielded: shielded}
+	if events := (AggressiveMind{}).Think(goblin, senses); len(events) != 0 {
+		t.Errorf("Expected the goblin to leave a shielded newbie alone, got %v", events)
+	}
+	if asked != goblin.Template.Level {
+		t.Errorf("Expected the goblin's level %d to be weighed, got %d", goblin.Template.Level, asked)
+	}
+
+	senses.Characters = []string{"newbie", "veteran"}
+	events := AggressiveMind{}.Think(goblin, senses)
+	if len(events) != 1 || events[0].Target != "veteran" {
+		t.Errorf("Expected the goblin to attack the veteran, got %v", events)
+	}
+}
+
+func TestWanderMindMoves(t *testing.T) {
+	rat := testNPC(t, "giant_rat", "riverbank")
+	mind := WanderMind{}
+
+	if events := mind.Think(rat, Senses{Roll: func(n int) int { return n - 1 }}); len(events) != 0 {
+		t.Errorf("Expected the rat to stay put on a high roll, got %v", events)
+	}
+
+	events := mind.Think(rat, Senses{Roll: firstRoll})
+	if len(events) != 2 || events[0].Type != EventLeave || events[1].Type != EventArrive {
+		t.Fatalf("Expected the rat to leave and arrive, got %v", events)
+	}
+	if rat.RoomID != character.DefaultStartRoomID || rat.State != StateWandering {
+		t.Errorf("Expected the rat to wander west, got %s %s", rat.RoomID, rat.State)
+	}
+
+	// The storeroom's only way out is a door
+	rat.RoomID = "storeroom"
+	if events := mind.Think(rat, Senses{Roll: firstRoll}); len(events) != 0 {
+		t.Errorf("Expected doors to stop the rat, got %v", events)
+	}
+}
+
+func TestPassiveMindStaysHarmless(t *testing.T) {
+	dummy := testNPC(t, "training_dummy", character.TutorialRoomID)
+	dummy.State, dummy.Target = StateFighting, "char1"
+
+	if events := (PassiveMind{}).Think(dummy, Senses{Characters: []string{"char1"}, Roll: firstRoll}); len(events) != 0 {
+		t.Errorf("Expected the dummy never to hit back, got %v", events)
+	}
+}
+
+type stillMind struct{ thought int }
+
+func (m *stillMind) Think(n *NPC, senses Senses) []Event {
+	m.thought++
+	n.State = StateIdle
+	return nil
+}
+
+func TestManagerThinkAndSave(t *testing.T) {
+	manager, world := newTestManager()
+	manager.roll = firstRoll
+	if err := manager.Populate(); err != nil {
+		t.Fatalf("Failed to populate: %v", err)
+	}
+
+	custom := &stillMind{}
+	manager.RegisterMind(BehaviorWander, custom)
+
+	present := func(roomID string) []string {
+		if roomID == "riverbank" {
+			return []string{"char1"}
+		}
+		return nil
+	}
+	events := manager.Think(present)
+	if custom.thought == 0 {
+		t.Errorf("Expected registered minds to replace the defaults")
+	}
+
+	attacks := 0
+	for _, event := range events {
+		if event.Type == EventAttack {
+			attacks++
+		}
+	}
+	if attacks != 3 {
+		t.Errorf("Expected every goblin to attack, got %d attacks", attacks)
+	}
+
+	goblin := manager.Find("riverbank", "goblin")
+	if world.states[goblin.ID].State == StateFighting {
+		t.Errorf("Expected state changes to wait for SaveDirty")
+	}
+	if err := manager.SaveDirty(); err != nil {
+		t.Fatalf("Failed to save: %v", err)
+	}
+	if world.states[goblin.ID].State != StateFighting {
+		t.Errorf("Expected the fighting goblin to be saved")
+	}
+}
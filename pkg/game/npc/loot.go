@@ -0,0 +1,30 @@
+package npc
+
+// Drop is an item left behind by a dead NPC.
+type Drop struct {
+	TemplateID string
+	Quantity   int
+}
+
+// RollLoot decides what a dead NPC leaves behind: each entry of its loot
+// table drops on its own chance, and it carries from 1 up to Gold coins.
+// roll returns a number from 0 to n-1.
+func (t *Template) RollLoot(roll func(n int) int) ([]Drop, int) {
+	var drops []Drop
+	for _, loot := range t.Loot {
+		if roll(100) >= loot.Chance {
+			continue
+		}
+		quantity := loot.Quantity
+		if quantity < 1 {
+			quantity = 1
+		}
+		drops = append(drops, Drop{TemplateID: loot.TemplateID, Quantity: quantity})
+	}
+
+	gold := 0
+	if t.Gold > 0 {
+		gold = roll(t.Gold) + 1
+	}
+	return drops, gold
+}
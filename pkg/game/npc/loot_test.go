@@ -0,0 +1,64 @@
+package npc
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestRollLootIsRepeatable(t *testing.T) {
+	goblin, _ := GetTemplate("goblin")
+
+	drops, gold := goblin.RollLoot(rand.New(rand.NewSource(1)).Intn)
+	again, goldAgain := goblin.RollLoot(rand.New(rand.NewSource(1)).Intn)
+	if len(drops) != len(again) || gold != goldAgain {
+		t.Errorf("Expected the same seed to give the same loot, got %v/%d and %v/%d", drops, gold, again, goldAgain)
+	}
+	if gold < 1 || gold > goblin.Gold {
+		t.Errorf("Expected 1 to %d gold, got %d", goblin.Gold, gold)
+	}
+}
+
+func TestRollLootChances(t *testing.T) {
+	goblin, _ := GetTemplate("goblin")
+
+	// Every roll at its lowest drops everything
+	drops, gold := goblin.RollLoot(func(n int) int { return 0 })
+	if len(drops) != len(goblin.Loot) {
+		t.Errorf("Expected every drop, got %v", drops)
+	}
+	if gold != 1 {
+		t.Errorf("Expected 1 gold on the lowest roll, got %d", gold)
+	}
+
+	// Every roll at its highest drops nothing but the most gold
+	drops, gold = goblin.RollLoot(func(n int) int { return n - 1 })
+	if len(drops) != 0 {
+		t.Errorf("Expected no drops, got %v", drops)
+	}
+	if gold != goblin.Gold {
+		t.Errorf("Expected %d gold on the highest roll, got %d", goblin.Gold, gold)
+	}
+
+	// Over many seeded kills the drop rate follows the chance
+	roll := rand.New(rand.NewSource(42)).Intn
+	potions := 0
+	for i := 0; i < 1000; i++ {
+		drops, _ := goblin.RollLoot(roll)
+		for _, drop := range drops {
+			if drop.TemplateID == "health_potion" {
+				potions++
+			}
+		}
+	}
+	if potions < 200 || potions > 300 {
+		t.Errorf("Expected about 250 potions from 1000 goblins, got %d", potions)
+	}
+}
+
+func TestRollLootWithoutGold(t *testing.T) {
+	dummy, _ := GetTemplate("training_dummy")
+	drops, gold := dummy.RollLoot(rand.New(rand.NewSource(1)).Intn)
+	if len(drops) != 0 || gold != 0 {
+		t.Errorf("Expected the dummy to drop nothing, got %v and %d gold", drops, gold)
+	}
+}
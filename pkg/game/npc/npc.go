@@ -0,0 +1,433 @@
+package npc
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+	"github.com/elidor/dungeogo/pkg/game/faction"
+	"github.com/elidor/dungeogo/pkg/game/world"
+	"github.com/elidor/dungeogo/pkg/persistence/interfaces"
+)
+
+// States an NPC can be in, as saved in its NPCState
+const (
+	StateIdle      = "idle"
+	StateWandering = "wandering"
+	StateFighting  = "fighting"
+	StateDead      = "dead"
+)
+
+// NPC is one living (or recently dead) instance of a template.
+type NPC struct {
+	ID       string
+	Template *Template
+	SpawnID  string
+	RoomID   string
+	Health   int
+	State    string
+	// Target is the character the NPC is fighting
+	Target string
+	// DiedAt is when the NPC was killed, counting towards its respawn
+	DiedAt time.Time
+}
+
+func (n *NPC) IsAlive() bool {
+	return n.State != StateDead
+}
+
+// Manager holds every NPC in the world, saving their state through the
+// world repository so it survives restarts.
+type Manager struct {
+	repoManager interfaces.RepositoryManager
+	spawns      map[string]*Spawn
+	minds       map[Behavior]Mind
+	// now returns the current time, replaced in tests
+	now func() time.Time
+	// roll returns a number from 0 to n-1
+	roll func(n int) int
+	// standing tells NPCs how their faction regards a character
+	standing func(characterID, factionID string) faction.Standing
+	// shielded tells aggressive NPCs which characters to leave alone
+	shielded func(characterID string, level int) bool
+
+	mutex sync.RWMutex
+	npcs  map[string]*NPC
+	// dirty holds the IDs of NPCs changed since they were last saved
+	dirty map[string]bool
+}
+
+func NewManager(repoManager interfaces.RepositoryManager) *Manager {
+	spawns := make(map[string]*Spawn)
+	for _, spawn := range getStandardSpawns() {
+		spawns[spawn.ID] = spawn
+	}
+
+	return &Manager{
+		repoManager: repoManager,
+		spawns:      spawns,
+		minds:       defaultMinds(),
+		now:         time.Now,
+		roll:        rand.Intn,
+		npcs:        make(map[string]*NPC),
+		dirty:       make(map[string]bool),
+	}
+}
+
+// RegisterMind makes NPCs with behavior act through mind, replacing any
+// mind already registered for it.
+func (m *Manager) RegisterMind(behavior Behavior, mind Mind) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.minds[behavior] = mind
+}
+
+// SetStanding lets faction NPCs judge characters by their reputation, so
+// aggressive ones only attack those their faction is hostile to.
+func (m *Manager) SetStanding(standing func(characterID, factionID string) faction.Standing) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.standing = standing
+}
+
+// SetShielded lets aggressive NPCs leave alone the new characters shielded
+// from NPCs of their level
+func (m *Manager) SetShielded(shielded func(characterID string, level int) bool) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.shielded = shielded
+}
+
+// SetRoll sets where NPCs' rolls come from, such as whether an aggressive
+// NPC gives chase
+func (m *Manager) SetRoll(roll func(n int) int) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.roll = roll
+}
+
+// npcID derives a stable ID for the index'th NPC of a spawn, so the same NPC
+// picks up its saved state after a restart.
+func npcID(spawnID string, index int) string {
+	return uuid.NewSHA1(uuid.NameSpaceOID, []byte(fmt.Sprintf("npc:%s:%d", spawnID, index))).String()
+}
+
+// Populate creates every spawn's NPCs, restoring their saved state where
+// there is one.
+func (m *Manager) Populate() error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for _, spawn := range m.spawns {
+		template, err := GetTemplate(spawn.TemplateID)
+		if err != nil {
+			return fmt.Errorf("failed to populate spawn %s: %w", spawn.ID, err)
+		}
+
+		for i := 0; i < spawn.Count; i++ {
+			n := &NPC{
+				ID:       npcID(spawn.ID, i),
+				Template: template,
+				SpawnID:  spawn.ID,
+				RoomID:   spawn.RoomID,
+				Health:   template.MaxHealth,
+				State:    StateIdle,
+			}
+
+			saved, err := m.repoManager.World().LoadNPCState(n.ID)
+			switch {
+			case err == nil:
+				n.Health = saved.Health
+				n.State = saved.State
+				// Fights do not survive a restart
+				if n.State == StateFighting {
+					n.State = StateIdle
+				}
+				if saved.Location != nil && saved.Location.RoomID != "" {
+					n.RoomID = saved.Location.RoomID
+				}
+				if !n.IsAlive() {
+					n.DiedAt = saved.LastUpdate
+				}
+			case errors.Is(err, interfaces.ErrNPCNotFound):
+				if err := m.save(n); err != nil {
+					return err
+				}
+			default:
+				return fmt.Errorf("failed to load npc %s: %w", n.ID, err)
+			}
+			m.npcs[n.ID] = n
+		}
+	}
+	return nil
+}
+
+// InRoom returns the living NPCs in roomID, in a stable order.
+func (m *Manager) InRoom(roomID string) []*NPC {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	var present []*NPC
+	for _, n := range m.npcs {
+		if n.IsAlive() && n.RoomID == roomID {
+			present = append(present, n)
+		}
+	}
+	sort.Slice(present, func(i, j int) bool { return present[i].ID < present[j].ID })
+	return present
+}
+
+// Find returns the first living NPC in roomID that name refers to, or nil.
+func (m *Manager) Find(roomID, name string) *NPC {
+	for _, n := range m.InRoom(roomID) {
+		if n.Template.Matches(name) {
+			return n
+		}
+	}
+	return nil
+}
+
+// Trainer returns the first living NPC in roomID that can teach skill, or nil.
+func (m *Manager) Trainer(roomID string, skill character.SkillType) *NPC {
+	for _, n := range m.InRoom(roomID) {
+		if n.Template.CanTeach(skill) {
+			return n
+		}
+	}
+	return nil
+}
+
+// Get returns the NPC with id, or nil.
+func (m *Manager) Get(id string) *NPC {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	return m.npcs[id]
+}
+
+// Damage takes amount of health from a living NPC, killing it at zero.
+// It reports whether the NPC died.
+func (m *Manager) Damage(id string, amount int) (bool, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	n, exists := m.npcs[id]
+	if !exists || !n.IsAlive() {
+		return false, nil
+	}
+
+	n.Health -= amount
+	killed := n.Health <= 0
+	if killed {
+		n.Health = 0
+		n.State = StateDead
+		n.Target = ""
+		n.DiedAt = m.now()
+	}
+	return killed, m.save(n)
+}
+
+// Engage sets a living NPC fighting characterID, such as when attacked.
+func (m *Manager) Engage(id, characterID string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if n, exists := m.npcs[id]; exists && n.IsAlive() {
+		n.State = StateFighting
+		n.Target = characterID
+		m.dirty[n.ID] = true
+	}
+}
+
+// Flee ends the fights of NPCs in fromRoom with characterID, who has
+// escaped to toRoom. Aggressive NPCs may give chase, following them to toRoom
+// and fighting on; those that do are returned.
+func (m *Manager) Flee(characterID, fromRoom, toRoom string) []*NPC {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	ids := make([]string, 0, len(m.npcs))
+	for id := range m.npcs {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	var pursuers []*NPC
+	for _, id := range ids {
+		n := m.npcs[id]
+		if !n.IsAlive() || n.RoomID != fromRoom || n.State != StateFighting || n.Target != characterID {
+			continue
+		}
+		m.dirty[n.ID] = true
+		if n.Template.Behavior == BehaviorAggressive && m.roll(100) < pursueChance {
+			n.RoomID = toRoom
+			pursuers = append(pursuers, n)
+			continue
+		}
+		n.State = StateIdle
+		n.Target = ""
+	}
+	return pursuers
+}
+
+// Fighting reports whether any living NPC is fighting characterID.
+func (m *Manager) Fighting(characterID string) bool {
+	return m.Foe(characterID) != nil
+}
+
+// Foe returns a living NPC fighting characterID, or nil. Where several are,
+// the one returned is stable.
+func (m *Manager) Foe(characterID string) *NPC {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	var foe *NPC
+	for _, n := range m.npcs {
+		if n.IsAlive() && n.State == StateFighting && n.Target == characterID && (foe == nil || n.ID < foe.ID) {
+			foe = n
+		}
+	}
+	return foe
+}
+
+// Disengage ends every NPC's fight with characterID, as when they die.
+func (m *Manager) Disengage(characterID string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for _, n := range m.npcs {
+		if n.State == StateFighting && n.Target == characterID {
+			n.State = StateIdle
+			n.Target = ""
+			m.dirty[n.ID] = true
+		}
+	}
+}
+
+// Pacify ends the fight of every NPC in roomID and returns those that were
+// fighting, in a stable order.
+func (m *Manager) Pacify(roomID string) []*NPC {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	var pacified []*NPC
+	for _, n := range m.npcs {
+		if !n.IsAlive() || n.RoomID != roomID || n.State != StateFighting {
+			continue
+		}
+		n.State = StateIdle
+		n.Target = ""
+		m.dirty[n.ID] = true
+		pacified = append(pacified, n)
+	}
+	sort.Slice(pacified, func(i, j int) bool { return pacified[i].ID < pacified[j].ID })
+	return pacified
+}
+
+// Respawn brings back every dead NPC whose respawn time has passed, full of
+// health and in its spawn room, and returns them.
+func (m *Manager) Respawn() ([]*NPC, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	now := m.now()
+	var respawned []*NPC
+	for _, n := range m.npcs {
+		spawn := m.spawns[n.SpawnID]
+		if n.IsAlive() || spawn == nil || now.Before(n.DiedAt.Add(spawn.Respawn)) {
+			continue
+		}
+
+		n.State = StateIdle
+		n.Target = ""
+		n.Health = n.Template.MaxHealth
+		n.RoomID = spawn.RoomID
+		n.DiedAt = time.Time{}
+		if err := m.save(n); err != nil {
+			return respawned, err
+		}
+		respawned = append(respawned, n)
+	}
+	sort.Slice(respawned, func(i, j int) bool { return respawned[i].ID < respawned[j].ID })
+	return respawned, nil
+}
+
+// Think lets every living NPC act once, returning what they did. present
+// lists the characters in a room.
+func (m *Manager) Think(present func(roomID string) []string) []Event {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	ids := make([]string, 0, len(m.npcs))
+	for id := range m.npcs {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	var events []Event
+	for _, id := range ids {
+		n := m.npcs[id]
+		mind, exists := m.minds[n.Template.Behavior]
+		if !n.IsAlive() || !exists {
+			continue
+		}
+
+		roomID, state, target := n.RoomID, n.State, n.Target
+		events = append(events, mind.Think(n, Senses{
+			Characters: present(n.RoomID),
+			Roll:       m.roll,
+			Standing:   m.standing,
+			Shielded:   m.shielded,
+		})...)
+		if n.RoomID != roomID || n.State != state || n.Target != target {
+			m.dirty[n.ID] = true
+		}
+	}
+	return events
+}
+
+// SaveDirty writes the state of every NPC changed since it was last saved.
+func (m *Manager) SaveDirty() error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for id := range m.dirty {
+		if n, exists := m.npcs[id]; exists {
+			if err := m.save(n); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// save writes n's state. The caller holds the mutex.
+func (m *Manager) save(n *NPC) error {
+	location := &character.Location{RoomID: n.RoomID}
+	if room, err := world.GetRoom(n.RoomID); err == nil {
+		location.ZoneID, location.X, location.Y = room.ZoneID, room.X, room.Y
+	}
+
+	state := &interfaces.NPCState{
+		ID:         n.ID,
+		TemplateID: n.Template.ID,
+		Health:     n.Health,
+		Location:   location,
+		Inventory:  []string{},
+		State:      n.State,
+		LastUpdate: m.now(),
+	}
+	if !n.IsAlive() {
+		state.LastUpdate = n.DiedAt
+	}
+	if err := m.repoManager.World().SaveNPCState(n.ID, state); err != nil {
+		return fmt.Errorf("failed to save npc %s: %w", n.ID, err)
+	}
+	delete(m.dirty, n.ID)
+	return nil
+}
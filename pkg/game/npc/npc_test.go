@@ -0,0 +1,262 @@
+package npc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+	"github.com/elidor/dungeogo/pkg/game/damage"
+	"github.com/elidor/dungeogo/pkg/persistence/interfaces"
+)
+
+// memoryWorld keeps NPC states in memory. Only the NPC methods are used.
+type memoryWorld struct {
+	interfaces.WorldRepository
+	states map[string]*interfaces.NPCState
+}
+
+func (w *memoryWorld) SaveNPCState(npcID string, state *interfaces.NPCState) error {
+	saved := *state
+	w.states[npcID] = &saved
+	return nil
+}
+
+func (w *memoryWorld) LoadNPCState(npcID string) (*interfaces.NPCState, error) {
+	if state, ok := w.states[npcID]; ok {
+		saved := *state
+		return &saved, nil
+	}
+	return nil, interfaces.ErrNPCNotFound
+}
+
+type memoryRepos struct {
+	interfaces.RepositoryManager
+	world *memoryWorld
+}
+
+func (r *memoryRepos) World() interfaces.WorldRepository {
+	return r.world
+}
+
+func newTestManager() (*Manager, *memoryWorld) {
+	world := &memoryWorld{states: make(map[string]*interfaces.NPCState)}
+	return NewManager(&memoryRepos{world: world}), world
+}
+
+func TestTemplateMatches(t *testing.T) {
+	rat, err := GetTemplate("giant_rat")
+	if err != nil {
+		t.Fatalf("Expected giant_rat template: %v", err)
+	}
+	for _, name := range []string{"rat", "Giant Rat", "a giant rat"} {
+		if !rat.Matches(name) {
+			t.Errorf("Expected %q to match the giant rat", name)
+		}
+	}
+	if rat.Matches("goblin") {
+		t.Errorf("Expected goblin not to match the giant rat")
+	}
+}
+
+func TestSpawnsUseKnownTemplatesAndRooms(t *testing.T) {
+	for _, spawn := range getStandardSpawns() {
+		if _, err := GetTemplate(spawn.TemplateID); err != nil {
+			t.Errorf("Spawn %s uses unknown template %s", spawn.ID, spawn.TemplateID)
+		}
+		if spawn.Count <= 0 || spawn.Respawn <= 0 {
+			t.Errorf("Spawn %s needs a count and respawn time", spawn.ID)
+		}
+	}
+}
+
+func TestPopulate(t *testing.T) {
+	manager, world := newTestManager()
+	if err := manager.Populate(); err != nil {
+		t.Fatalf("Failed to populate: %v", err)
+	}
+
+	goblins := manager.InRoom("riverbank")
+	if len(goblins) != 3 {
+		t.Fatalf("Expected 3 goblins at the riverbank, got %d", len(goblins))
+	}
+	if len(world.states) == 0 {
+		t.Errorf("Expected new NPCs to be saved")
+	}
+	if manager.Find("riverbank", "goblin") == nil {
+		t.Errorf("Expected to find a goblin by keyword")
+	}
+
+	// A restart restores saved state rather than starting fresh
+	killed, err := manager.Damage(goblins[0].ID, 100)
+	if err != nil || !killed {
+		t.Fatalf("Expected the goblin to die, got %v, %v", killed, err)
+	}
+	restarted := NewManager(&memoryRepos{world: world})
+	if err := restarted.Populate(); err != nil {
+		t.Fatalf("Failed to populate after restart: %v", err)
+	}
+	if len(restarted.InRoom("riverbank")) != 2 {
+		t.Errorf("Expected the dead goblin to stay dead after a restart")
+	}
+}
+
+func TestDamageAndRespawn(t *testing.T) {
+	manager, _ := newTestManager()
+	now := time.Now()
+	manager.now = func() time.Time { return now }
+	if err := manager.Populate(); err != nil {
+		t.Fatalf("Failed to populate: %v", err)
+	}
+
+	rat := manager.Find("storeroom", "rat")
+	killed, err := manager.Damage(rat.ID, 1)
+	if err != nil || killed {
+		t.Fatalf("Expected the rat to survive a scratch, got %v, %v", killed, err)
+	}
+	if killed, _ := manager.Damage(rat.ID, rat.Template.MaxHealth); !killed {
+		t.Fatalf("Expected the rat to die")
+	}
+	if len(manager.InRoom("storeroom")) != 1 {
+		t.Errorf("Expected dead NPCs to leave the room")
+	}
+
+	if respawned, _ := manager.Respawn(); len(respawned) != 0 {
+		t.Errorf("Expected nothing to respawn yet, got %d", len(respawned))
+	}
+
+	now = now.Add(manager.spawns[rat.SpawnID].Respawn)
+	respawned, err := manager.Respawn()
+	if err != nil {
+		t.Fatalf("Failed to respawn: %v", err)
+	}
+	if len(respawned) != 1 || respawned[0].ID != rat.ID {
+		t.Fatalf("Expected the rat to respawn, got %v", respawned)
+	}
+	if rat.Health != rat.Template.MaxHealth || !rat.IsAlive() {
+		t.Errorf("Expected the rat back at full health")
+	}
+}
+
+func TestFlee(t *testing.T) {
+	manager, _ := newTestManager()
+	if err := manager.Populate(); err != nil {
+		t.Fatalf("Failed to populate: %v", err)
+	}
+
+	goblins := manager.InRoom("riverbank")
+	rat := manager.Find("storeroom", "rat")
+	manager.Engage(goblins[0].ID, "char1")
+	manager.Engage(goblins[1].ID, "char1")
+	manager.Engage(goblins[2].ID, "char2")
+	manager.Engage(rat.ID, "char1")
+
+	rolls := []int{0, pursueChance}
+	manager.roll = func(n int) int {
+		roll := rolls[0]
+		rolls = rolls[1:]
+		return roll
+	}
+	pursuers := manager.Flee("char1", "riverbank", "start_room")
+
+	if len(pursuers) != 1 || pursuers[0] != goblins[0] {
+		t.Fatalf("Expected only the first goblin to give chase, got %v", pursuers)
+	}
+	if goblins[0].RoomID != "start_room" || goblins[0].State != StateFighting || goblins[0].Target != "char1" {
+		t.Errorf("Expected the pursuer to follow and fight on, got %s %s %s", goblins[0].RoomID, goblins[0].State, goblins[0].Target)
+	}
+	if goblins[1].RoomID != "riverbank" || goblins[1].State != StateIdle || goblins[1].Target != "" {
+		t.Errorf("Expected the other goblin to give up, got %s %s %s", goblins[1].RoomID, goblins[1].State, goblins[1].Target)
+	}
+	if goblins[2].Target != "char2" {
+		t.Errorf("Expected fights with others to go on")
+	}
+	if rat.Target != "char1" {
+		t.Errorf("Expected NPCs in other rooms to be left alone")
+	}
+}
+
+func TestTrainer(t *testing.T) {
+	manager, _ := newTestManager()
+	if err := manager.Populate(); err != nil {
+		t.Fatalf("Failed to populate: %v", err)
+	}
+
+	trainer := manager.Trainer("tutorial_yard", character.SkillSwords)
+	if trainer == nil || trainer.Template.ID != "arms_trainer" {
+		t.Fatalf("Expected the arms trainer to teach swords, got %v", trainer)
+	}
+	if manager.Trainer("tutorial_yard", character.SkillMagic) != nil {
+		t.Errorf("Expected no one in the yard to teach magic")
+	}
+	if manager.Trainer("riverbank", character.SkillSwords) != nil {
+		t.Errorf("Expected no trainer at the riverbank")
+	}
+
+	// The tutorial is left for good, so the village must teach every skill
+	for skill := character.SkillSwords; skill <= character.SkillMining; skill++ {
+		if manager.Trainer(character.DefaultStartRoomID, skill) == nil {
+			t.Errorf("Expected someone in the village to teach %s", character.GetSkillName(skill))
+		}
+	}
+}
+
+func TestPacify(t *testing.T) {
+	manager, _ := newTestManager()
+	if err := manager.Populate(); err != nil {
+		t.Fatalf("Failed to populate: %v", err)
+	}
+
+	goblins := manager.InRoom("riverbank")
+	rat := manager.Find("storeroom", "rat")
+	manager.Engage(goblins[0].ID, "char1")
+	manager.Engage(goblins[2].ID, "char2")
+	manager.Engage(rat.ID, "char1")
+
+	pacified := manager.Pacify("riverbank")
+	if len(pacified) != 2 || pacified[0] != goblins[0] || pacified[1] != goblins[2] {
+		t.Fatalf("Expected the two fighting goblins to be pacified, got %v", pacified)
+	}
+	for _, goblin := range goblins {
+		if goblin.State == StateFighting || goblin.Target != "" {
+			t.Errorf("Expected no goblin left fighting, got %s %s", goblin.State, goblin.Target)
+		}
+	}
+	if rat.State != StateFighting {
+		t.Errorf("Expected fights in other rooms to go on, got %s", rat.State)
+	}
+}
+
+func TestDisengage(t *testing.T) {
+	manager, _ := newTestManager()
+	if err := manager.Populate(); err != nil {
+		t.Fatalf("Failed to populate: %v", err)
+	}
+
+	goblins := manager.InRoom("riverbank")
+	manager.Engage(goblins[0].ID, "char1")
+	manager.Engage(goblins[1].ID, "char2")
+
+	if !manager.Fighting("char1") || manager.Fighting("char3") {
+		t.Errorf("Expected only char1 and char2 to be fought")
+	}
+	manager.Disengage("char1")
+	if manager.Fighting("char1") {
+		t.Errorf("Expected char1 to be fought no more")
+	}
+	if goblins[0].State != StateIdle || goblins[0].Target != "" {
+		t.Errorf("Expected the goblin to stop fighting, got %s %s", goblins[0].State, goblins[0].Target)
+	}
+	if goblins[1].State != StateFighting || goblins[1].Target != "char2" {
+		t.Errorf("Expected other fights to go on, got %s %s", goblins[1].State, goblins[1].Target)
+	}
+}
+
+func TestTemplateResist(t *testing.T) {
+	dummy, _ := GetTemplate("training_dummy")
+	if got := dummy.Resist(10, damage.Fire); got != 15 {
+		t.Errorf("Expected straw to burn for 15 of 10 fire damage, got %d", got)
+	}
+	if got := dummy.Resist(10, damage.Physical); got != 10 {
+		t.Errorf("Expected all of 10 physical damage, got %d", got)
+	}
+}
@@ -0,0 +1,239 @@
+// Package npc defines the creatures and characters that populate the world
+// and keeps track of the ones currently walking it.
+package npc
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+	"github.com/elidor/dungeogo/pkg/game/damage"
+	"github.com/elidor/dungeogo/pkg/game/faction"
+)
+
+var ErrTemplateNotFound = errors.New("npc template not found")
+
+// Behavior is how an NPC acts when left to itself
+type Behavior string
+
+const (
+	BehaviorPassive    Behavior = "passive"
+	BehaviorAggressive Behavior = "aggressive"
+	BehaviorWander     Behavior = "wander"
+)
+
+// LootDrop is an item an NPC may leave behind. Chance is a percentage.
+type LootDrop struct {
+	TemplateID string
+	Chance     int
+	Quantity   int
+}
+
+// Template describes a kind of NPC. Every spawned NPC is an instance of one.
+type Template struct {
+	ID   string
+	Name string
+	// Description is the line shown when the NPC is in the room
+	Description string
+	Keywords    []string
+	Level       int
+	MaxHealth   int
+	Damage      int
+	// DamageType is the kind of damage the NPC's blows do
+	DamageType damage.Type
+	// Resistances turn aside part of the damage of each type the NPC
+	// resists
+	Resistances damage.Resistances
+	Defense     int
+	Experience  int
+	// Gold is the most coins the NPC carries
+	Gold     int
+	Loot     []LootDrop
+	Behavior Behavior
+	// Faction is the faction the NPC belongs to, if any. Aggressive members
+	// leave alone characters the faction does not hate.
+	Faction string
+	// KillReputation is how killing the NPC changes the killer's reputation,
+	// by faction ID
+	KillReputation map[string]int
+	// Teaches lists the skills a trainer can practice characters in
+	Teaches []character.SkillType
+}
+
+// Spawn keeps Count NPCs of a template in a room, bringing each back
+// Respawn after it dies.
+type Spawn struct {
+	ID         string
+	TemplateID string
+	RoomID     string
+	Count      int
+	Respawn    time.Duration
+}
+
+func GetTemplate(templateID string) (*Template, error) {
+	if template, exists := getStandardTemplates()[templateID]; exists {
+		return template, nil
+	}
+	return nil, ErrTemplateNotFound
+}
+
+// Matches reports whether name refers to the template, by its full name or
+// one of its keywords.
+func (t *Template) Matches(name string) bool {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return false
+	}
+	if strings.EqualFold(t.Name, name) {
+		return true
+	}
+	for _, keyword := range t.Keywords {
+		if keyword == name {
+			return true
+		}
+	}
+	return false
+}
+
+// IsTrainer reports whether the NPC teaches anything
+func (t *Template) IsTrainer() bool {
+	return len(t.Teaches) > 0
+}
+
+// CanTeach reports whether the NPC trains characters in skill
+func (t *Template) CanTeach(skill character.SkillType) bool {
+	return slices.Contains(t.Teaches, skill)
+}
+
+// Resist returns what is left of amount damage of type kind once the NPC's
+// resistance to it has turned some aside
+func (t *Template) Resist(amount int, kind damage.Type) int {
+	return damage.Mitigate(amount, t.Resistances[kind])
+}
+
+func getStandardTemplates() map[string]*Template {
+	return map[string]*Template{
+		"goblin": {
+			ID:          "goblin",
+			Name:        "a goblin",
+			Description: "A scrawny goblin crouches here, clutching a rusty knife.",
+			Keywords:    []string{"goblin"},
+			Level:       1,
+			MaxHealth:   12,
+			Damage:      3,
+			Defense:     1,
+			Experience:  25,
+			Gold:        3,
+			Loot: []LootDrop{
+				{TemplateID: "rusty_sword", Chance: 10, Quantity: 1},
+				{TemplateID: "health_potion", Chance: 25, Quantity: 1},
+			},
+			Behavior: BehaviorAggressive,
+			Faction:  faction.Goblins,
+			KillReputation: map[string]int{
+				faction.Goblins: -10,
+				faction.Militia: 5,
+			},
+		},
+		"giant_rat": {
+			ID:          "giant_rat",
+			Name:        "a giant rat",
+			Description: "A giant rat noses through the dust, whiskers twitching.",
+			Keywords:    []string{"rat", "giant rat"},
+			Level:       1,
+			MaxHealth:   6,
+			Damage:      2,
+			DamageType:  damage.Poison,
+			Experience:  10,
+			Loot: []LootDrop{
+				{TemplateID: "leather_hide", Chance: 50, Quantity: 1},
+			},
+			Behavior: BehaviorWander,
+		},
+		"training_dummy": {
+			ID:          "training_dummy",
+			Name:        "a training dummy",
+			Description: "A straw training dummy stands here, patched from many beatings.",
+			Keywords:    []string{"dummy", "training dummy"},
+			Level:       1,
+			MaxHealth:   20,
+			// Straw burns
+			Resistances: damage.Resistances{damage.Fire: -50},
+			Behavior:    BehaviorPassive,
+		},
+		"arms_trainer": {
+			ID:          "arms_trainer",
+			Name:        "the arms trainer",
+			Description: "A scarred arms trainer stands here, arms folded.",
+			Keywords:    []string{"trainer", "arms trainer"},
+			Level:       10,
+			MaxHealth:   150,
+			Damage:      8,
+			Defense:     5,
+			Behavior:    BehaviorPassive,
+			Teaches: []character.SkillType{
+				character.SkillSwords, character.SkillAxes, character.SkillMaces,
+				character.SkillDaggers, character.SkillArchery, character.SkillCrossbows,
+				character.SkillShields, character.SkillDodge, character.SkillParry,
+			},
+		},
+		"village_thief": {
+			ID:          "village_thief",
+			Name:        "a sly-eyed thief",
+			Description: "A sly-eyed thief leans in the shadows, turning a lockpick between their fingers.",
+			Keywords:    []string{"thief", "sly thief", "trainer"},
+			Level:       10,
+			MaxHealth:   80,
+			Damage:      6,
+			Defense:     3,
+			Behavior:    BehaviorPassive,
+			Teaches: []character.SkillType{
+				character.SkillStealth, character.SkillLockpicking,
+			},
+		},
+		"village_artisan": {
+			ID:          "village_artisan",
+			Name:        "the village artisan",
+			Description: "The village artisan sorts a bench of tools, a pickaxe and a fishing rod among them.",
+			Keywords:    []string{"artisan", "village artisan", "trainer"},
+			Level:       10,
+			MaxHealth:   80,
+			Damage:      4,
+			Defense:     3,
+			Behavior:    BehaviorPassive,
+			Teaches: []character.SkillType{
+				character.SkillCrafting, character.SkillMining, character.SkillFishing,
+			},
+		},
+		"village_sage": {
+			ID:          "village_sage",
+			Name:        "the village sage",
+			Description: "The village sage sits by the window, leafing through a worn book.",
+			Keywords:    []string{"sage", "village sage", "trainer"},
+			Level:       10,
+			MaxHealth:   60,
+			Damage:      4,
+			Defense:     2,
+			Behavior:    BehaviorPassive,
+			Teaches: []character.SkillType{
+				character.SkillMagic, character.SkillHealing, character.SkillEvocation,
+				character.SkillDivination,
+			},
+		},
+	}
+}
+
+func getStandardSpawns() []*Spawn {
+	return []*Spawn{
+		{ID: "riverbank_goblins", TemplateID: "goblin", RoomID: "riverbank", Count: 3, Respawn: 5 * time.Minute},
+		{ID: "storeroom_rats", TemplateID: "giant_rat", RoomID: "storeroom", Count: 2, Respawn: 3 * time.Minute},
+		{ID: "yard_trainer", TemplateID: "arms_trainer", RoomID: "tutorial_yard", Count: 1, Respawn: 5 * time.Minute},
+		{ID: "village_trainer", TemplateID: "arms_trainer", RoomID: character.DefaultStartRoomID, Count: 1, Respawn: 5 * time.Minute},
+		{ID: "village_sage", TemplateID: "village_sage", RoomID: character.DefaultStartRoomID, Count: 1, Respawn: 5 * time.Minute},
+		{ID: "village_thief", TemplateID: "village_thief", RoomID: character.DefaultStartRoomID, Count: 1, Respawn: 5 * time.Minute},
+		{ID: "village_artisan", TemplateID: "village_artisan", RoomID: character.DefaultStartRoomID, Count: 1, Respawn: 5 * time.Minute},
+		{ID: "tutorial_dummy", TemplateID: "training_dummy", RoomID: character.TutorialRoomID, Count: 1, Respawn: 10 * time.Second},
+	}
+}
@@ -0,0 +1,48 @@
+package player
+
+// Default premium benefits
+const (
+	DefaultExtraCharacters    = 3
+	DefaultCommandRate        = 10
+	DefaultPremiumCommandRate = 20
+	DefaultReservedSlots      = 10
+)
+
+// Benefits sets what a premium subscription is worth. Server operators can
+// tune each value through config.
+type Benefits struct {
+	// ExtraCharacters are character slots added to a premium account
+	ExtraCharacters int
+	// CommandRate and PremiumCommandRate are how many commands a player may
+	// send each second. Zero means no limit.
+	CommandRate        int
+	PremiumCommandRate int
+	// ReservedSlots are connections kept for premium players once the
+	// server is otherwise full
+	ReservedSlots int
+}
+
+func DefaultBenefits() *Benefits {
+	return &Benefits{
+		ExtraCharacters:    DefaultExtraCharacters,
+		CommandRate:        DefaultCommandRate,
+		PremiumCommandRate: DefaultPremiumCommandRate,
+		ReservedSlots:      DefaultReservedSlots,
+	}
+}
+
+// MaxCharacters is how many characters p may have.
+func (b *Benefits) MaxCharacters(p *Player) int {
+	if p.HasPremium() {
+		return p.MaxCharacters + b.ExtraCharacters
+	}
+	return p.MaxCharacters
+}
+
+// CommandRateFor is how many commands p may send each second.
+func (b *Benefits) CommandRateFor(p *Player) int {
+	if p.HasPremium() {
+		return b.PremiumCommandRate
+	}
+	return b.CommandRate
+}
@@ -0,0 +1,33 @@
+package player
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBenefits(t *testing.T) {
+	benefits := DefaultBenefits()
+	player := NewPlayer("test", "test@test.com", "hash")
+
+	if max := benefits.MaxCharacters(player); max != DefaultMaxCharacters {
+		t.Errorf("Expected %d characters without premium, got %d", DefaultMaxCharacters, max)
+	}
+	if rate := benefits.CommandRateFor(player); rate != DefaultCommandRate {
+		t.Errorf("Expected a command rate of %d, got %d", DefaultCommandRate, rate)
+	}
+
+	player.RenewSubscription(1, time.Now())
+	if max := benefits.MaxCharacters(player); max != DefaultMaxCharacters+DefaultExtraCharacters {
+		t.Errorf("Expected extra characters with premium, got %d", max)
+	}
+	if rate := benefits.CommandRateFor(player); rate != DefaultPremiumCommandRate {
+		t.Errorf("Expected a command rate of %d, got %d", DefaultPremiumCommandRate, rate)
+	}
+
+	// Benefits end with the subscription
+	player.Subscription.ExpiresAt = time.Now().Add(-time.Hour)
+	player.ExpireSubscription(time.Now())
+	if max := benefits.MaxCharacters(player); max != DefaultMaxCharacters {
+		t.Errorf("Expected extra characters to be revoked, got %d", max)
+	}
+}
@@ -0,0 +1,76 @@
+package player
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+)
+
+const (
+	// MaxKeybindings is how many keybindings a player may have
+	MaxKeybindings = 20
+	// MaxKeybindingKeyLength is the longest a bound key may be, in runes
+	MaxKeybindingKeyLength = 10
+	// MaxKeybindingCommandLength is the longest a bound command may be, in runes
+	MaxKeybindingCommandLength = 100
+)
+
+var (
+	ErrKeybindingInvalid  = errors.New("keybinding must be a single printable word bound to a printable command")
+	ErrKeybindingTooLong  = errors.New("keybinding is too long")
+	ErrKeybindingReserved = errors.New("keybinding would hide the commands that manage keybindings")
+	ErrTooManyKeybindings = errors.New("too many keybindings")
+)
+
+// reservedKeys are the commands that manage keybindings, which may not be
+// bound so a player can always undo a binding
+var reservedKeys = map[string]bool{"bind": true, "unbind": true}
+
+// Bind makes key, a single word, stand for command. Keys are not case
+// sensitive and binding a key again replaces its command.
+func (p *PlayerPrefs) Bind(key, command string) error {
+	key = strings.ToLower(key)
+	command = strings.Join(strings.Fields(command), " ")
+	if key == "" || command == "" || strings.ContainsFunc(key, unicode.IsSpace) ||
+		strings.ContainsFunc(key+command, func(r rune) bool { return !unicode.IsPrint(r) }) {
+		return ErrKeybindingInvalid
+	}
+	if len([]rune(key)) > MaxKeybindingKeyLength || len([]rune(command)) > MaxKeybindingCommandLength {
+		return ErrKeybindingTooLong
+	}
+	if reservedKeys[key] {
+		return ErrKeybindingReserved
+	}
+	if _, exists := p.Keybindings[key]; !exists && len(p.Keybindings) >= MaxKeybindings {
+		return ErrTooManyKeybindings
+	}
+
+	if p.Keybindings == nil {
+		p.Keybindings = make(map[string]string)
+	}
+	p.Keybindings[key] = command
+	return nil
+}
+
+// Unbind removes the binding for key, reporting whether there was one.
+func (p *PlayerPrefs) Unbind(key string) bool {
+	key = strings.ToLower(key)
+	if _, exists := p.Keybindings[key]; !exists {
+		return false
+	}
+	delete(p.Keybindings, key)
+	return true
+}
+
+// ExpandKeybinding returns the command bound to input if input is a single
+// bound key, or input unchanged otherwise.
+func ExpandKeybinding(bindings map[string]string, input string) string {
+	fields := strings.Fields(input)
+	if len(fields) != 1 {
+		return input
+	}
+	if command, ok := bindings[strings.ToLower(fields[0])]; ok {
+		return command
+	}
+	return input
+}
@@ -0,0 +1,60 @@
+package player
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestBind(t *testing.T) {
+	var prefs PlayerPrefs
+	if err := prefs.Bind("X", "kill   goblin"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if prefs.Keybindings["x"] != "kill goblin" {
+		t.Errorf("Expected x to be bound to 'kill goblin', got %v", prefs.Keybindings)
+	}
+
+	if err := prefs.Bind("bind", "look"); !errors.Is(err, ErrKeybindingReserved) {
+		t.Errorf("Expected ErrKeybindingReserved, got %v", err)
+	}
+	if err := prefs.Bind("z", ""); !errors.Is(err, ErrKeybindingInvalid) {
+		t.Errorf("Expected ErrKeybindingInvalid, got %v", err)
+	}
+	if err := prefs.Bind("z", strings.Repeat("a", MaxKeybindingCommandLength+1)); !errors.Is(err, ErrKeybindingTooLong) {
+		t.Errorf("Expected ErrKeybindingTooLong, got %v", err)
+	}
+
+	for i := len(prefs.Keybindings); i < MaxKeybindings; i++ {
+		if err := prefs.Bind(fmt.Sprintf("k%d", i), "look"); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	if err := prefs.Bind("extra", "look"); !errors.Is(err, ErrTooManyKeybindings) {
+		t.Errorf("Expected ErrTooManyKeybindings, got %v", err)
+	}
+	if err := prefs.Bind("x", "flee"); err != nil {
+		t.Errorf("Expected rebinding an existing key to be allowed, got %v", err)
+	}
+
+	if !prefs.Unbind("X") || prefs.Unbind("x") {
+		t.Errorf("Expected x to be unbound exactly once")
+	}
+}
+
+func TestExpandKeybinding(t *testing.T) {
+	bindings := map[string]string{"x": "kill goblin"}
+	tests := map[string]string{
+		"x":     "kill goblin",
+		" X ":   "kill goblin",
+		"x rat": "x rat",
+		"look":  "look",
+		"":      "",
+	}
+	for input, expected := range tests {
+		if got := ExpandKeybinding(bindings, input); got != expected {
+			t.Errorf("ExpandKeybinding(%q): expected %q, got %q", input, expected, got)
+		}
+	}
+}
@@ -1,6 +1,8 @@
 package player
 
 import (
+	"crypto/subtle"
+	"strings"
 	"time"
 	
 	"github.com/google/uuid"
@@ -14,10 +16,13 @@ type Player struct {
 	CreatedAt          time.Time
 	LastLogin          time.Time
 	AccountStatus      AccountStatus
+	Role               Role
 	Subscription       *Subscription
 	Preferences        PlayerPrefs
 	MaxCharacters      int
 	CurrentCharacterID string
+	EmailVerified      bool
+	VerificationCode   string
 }
 
 type AccountStatus int
@@ -28,6 +33,40 @@ const (
 	AccountBanned
 )
 
+type Role int
+
+const (
+	RolePlayer Role = iota
+	RoleModerator
+	RoleAdmin
+)
+
+func (s AccountStatus) String() string {
+	switch s {
+	case AccountActive:
+		return "Active"
+	case AccountSuspended:
+		return "Suspended"
+	case AccountBanned:
+		return "Banned"
+	default:
+		return "Unknown"
+	}
+}
+
+func (r Role) String() string {
+	switch r {
+	case RolePlayer:
+		return "Player"
+	case RoleModerator:
+		return "Moderator"
+	case RoleAdmin:
+		return "Admin"
+	default:
+		return "Unknown"
+	}
+}
+
 type Subscription struct {
 	Type      SubscriptionType
 	ExpiresAt time.Time
@@ -46,24 +85,32 @@ type PlayerPrefs struct {
 	ScreenWidth     int
 	AutoLoot        bool
 	CombatPrompts   bool
+	ConfirmQuit     bool // Whether quit asks before leaving the game
 	Keybindings     map[string]string
 }
 
+// NormalizeEmail returns email as it is stored and looked up: trimmed and
+// lowercased, so addresses differing only in case belong to one account
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
 func NewPlayer(username, email, passwordHash string) *Player {
 	return &Player{
 		ID:            uuid.New().String(),
 		Username:      username,
-		Email:         email,
+		Email:         NormalizeEmail(email),
 		PasswordHash:  passwordHash,
 		CreatedAt:     time.Now(),
 		LastLogin:     time.Now(),
 		AccountStatus: AccountActive,
-		MaxCharacters: 5,
+		MaxCharacters: DefaultMaxCharacters,
 		Preferences: PlayerPrefs{
 			ColorEnabled:  true,
 			ScreenWidth:   80,
 			AutoLoot:      false,
 			CombatPrompts: true,
+			ConfirmQuit:   true,
 			Keybindings:   make(map[string]string),
 		},
 	}
@@ -73,6 +120,14 @@ func (p *Player) IsActive() bool {
 	return p.AccountStatus == AccountActive
 }
 
+func (p *Player) IsModerator() bool {
+	return p.Role >= RoleModerator
+}
+
+func (p *Player) IsAdmin() bool {
+	return p.Role >= RoleAdmin
+}
+
 func (p *Player) HasPremium() bool {
 	return p.Subscription != nil && 
 		   p.Subscription.Active && 
@@ -82,4 +137,28 @@ func (p *Player) HasPremium() bool {
 
 func (p *Player) UpdateLastLogin() {
 	p.LastLogin = time.Now()
+}
+
+// StartEmailVerification marks the email unverified and records the code the
+// player must echo back to prove ownership.
+func (p *Player) StartEmailVerification(code string) {
+	p.EmailVerified = false
+	p.VerificationCode = code
+}
+
+// VerifyEmail checks the supplied code and marks the email verified on a match
+func (p *Player) VerifyEmail(code string) bool {
+	if p.EmailVerified {
+		return true
+	}
+	if p.VerificationCode == "" {
+		return false
+	}
+	if subtle.ConstantTimeCompare([]byte(p.VerificationCode), []byte(code)) != 1 {
+		return false
+	}
+	
+	p.EmailVerified = true
+	p.VerificationCode = ""
+	return true
 }
\ No newline at end of file
@@ -216,4 +216,89 @@ func TestSubscriptionEdgeCases(t *testing.T) {
 	if player.HasPremium() {
 		t.Errorf("Expected subscription expiring now to not have premium")
 	}
-}
\ No newline at end of file
+}
+func TestEmailVerification(t *testing.T) {
+	player := NewPlayer("test", "test@test.com", "hash")
+	player.StartEmailVerification("123456")
+	
+	if player.EmailVerified {
+		t.Errorf("Expected email to be unverified after starting verification")
+	}
+	
+	if player.VerifyEmail("654321") {
+		t.Errorf("Expected wrong code to be rejected")
+	}
+	
+	if player.VerifyEmail("") {
+		t.Errorf("Expected empty code to be rejected")
+	}
+	
+	if !player.VerifyEmail("123456") {
+		t.Errorf("Expected matching code to verify email")
+	}
+	
+	if !player.EmailVerified {
+		t.Errorf("Expected email to be marked verified")
+	}
+	
+	if player.VerificationCode != "" {
+		t.Errorf("Expected verification code to be cleared after use")
+	}
+}
+
+func TestNormalizeEmail(t *testing.T) {
+	tests := map[string]string{
+		"test@example.com":      "test@example.com",
+		"Test@Example.COM":      "test@example.com",
+		"  padded@example.com ": "padded@example.com",
+		"":                      "",
+	}
+	for email, expected := range tests {
+		if got := NormalizeEmail(email); got != expected {
+			t.Errorf("NormalizeEmail(%q) = %q, want %q", email, got, expected)
+		}
+	}
+	
+	if p := NewPlayer("test", "Test@Test.com", "hash"); p.Email != "test@test.com" {
+		t.Errorf("Expected new players to get a normalized email, got %q", p.Email)
+	}
+}
+
+func TestVerifyEmailWithoutPendingCode(t *testing.T) {
+	player := NewPlayer("test", "test@test.com", "hash")
+	
+	if player.VerifyEmail("") {
+		t.Errorf("Expected verification to fail when no code was issued")
+	}
+}
+
+func TestRoles(t *testing.T) {
+	player := NewPlayer("test", "test@test.com", "hash")
+	
+	if player.Role != RolePlayer {
+		t.Errorf("Expected new players to have the player role")
+	}
+	
+	if player.IsModerator() || player.IsAdmin() {
+		t.Errorf("Expected regular player to have no staff permissions")
+	}
+	
+	player.Role = RoleModerator
+	if !player.IsModerator() {
+		t.Errorf("Expected moderator to have moderator permissions")
+	}
+	if player.IsAdmin() {
+		t.Errorf("Expected moderator to not have admin permissions")
+	}
+	
+	player.Role = RoleAdmin
+	if !player.IsModerator() || !player.IsAdmin() {
+		t.Errorf("Expected admin to have moderator and admin permissions")
+	}
+	if player.Role.String() != "Admin" || RoleModerator.String() != "Moderator" {
+		t.Errorf("Expected role names, got %s and %s", player.Role, RoleModerator)
+	}
+	if AccountSuspended.String() != "Suspended" {
+		t.Errorf("Expected Suspended, got %s", AccountSuspended)
+	}
+}
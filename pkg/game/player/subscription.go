@@ -0,0 +1,37 @@
+package player
+
+import "time"
+
+// DefaultMaxCharacters is how many characters a new account may have
+const DefaultMaxCharacters = 5
+
+// SubscriptionPeriod is how long one paid month of premium lasts
+const SubscriptionPeriod = 30 * 24 * time.Hour
+
+// ExpireSubscription deactivates a premium subscription that has run out by
+// now, which takes away its benefits. It reports whether anything changed,
+// so the caller knows to save and tell the player.
+func (p *Player) ExpireSubscription(now time.Time) bool {
+	s := p.Subscription
+	if s == nil || !s.Active || s.Type != SubscriptionPremium || s.ExpiresAt.After(now) {
+		return false
+	}
+
+	s.Active = false
+	return true
+}
+
+// RenewSubscription adds months of premium. Time left on an active
+// subscription is kept; otherwise the new period starts now.
+func (p *Player) RenewSubscription(months int, now time.Time) {
+	start := now
+	if p.HasPremium() && p.Subscription.ExpiresAt.After(now) {
+		start = p.Subscription.ExpiresAt
+	}
+
+	p.Subscription = &Subscription{
+		Type:      SubscriptionPremium,
+		ExpiresAt: start.Add(time.Duration(months) * SubscriptionPeriod),
+		Active:    true,
+	}
+}
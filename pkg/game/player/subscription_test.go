@@ -0,0 +1,67 @@
+package player
+
+import (
+	"testing"
+	"time"
+)
+
+func TestExpireSubscription(t *testing.T) {
+	now := time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC)
+	player := NewPlayer("test", "test@test.com", "hash")
+
+	// Nothing to expire without a subscription
+	if player.ExpireSubscription(now) {
+		t.Errorf("Expected no change without a subscription")
+	}
+
+	player.RenewSubscription(1, now.Add(-SubscriptionPeriod-time.Hour))
+
+	if !player.ExpireSubscription(now) {
+		t.Fatalf("Expected the lapsed subscription to expire")
+	}
+	if player.Subscription.Active || player.HasPremium() {
+		t.Errorf("Expected the subscription to be inactive")
+	}
+
+	// Expiring twice changes nothing
+	if player.ExpireSubscription(now) {
+		t.Errorf("Expected an expired subscription to stay put")
+	}
+}
+
+func TestExpireSubscriptionStillRunning(t *testing.T) {
+	now := time.Now()
+	player := NewPlayer("test", "test@test.com", "hash")
+	player.RenewSubscription(1, now)
+
+	if player.ExpireSubscription(now) {
+		t.Errorf("Expected a running subscription not to expire")
+	}
+	if !player.HasPremium() {
+		t.Errorf("Expected premium to still apply")
+	}
+}
+
+func TestRenewSubscription(t *testing.T) {
+	now := time.Now()
+	player := NewPlayer("test", "test@test.com", "hash")
+
+	player.RenewSubscription(1, now)
+	if !player.Subscription.ExpiresAt.Equal(now.Add(SubscriptionPeriod)) {
+		t.Errorf("Expected a month from now, got %v", player.Subscription.ExpiresAt)
+	}
+
+	// Renewing early keeps the time already paid for
+	player.RenewSubscription(2, now)
+	if !player.Subscription.ExpiresAt.Equal(now.Add(3 * SubscriptionPeriod)) {
+		t.Errorf("Expected three months from now, got %v", player.Subscription.ExpiresAt)
+	}
+
+	// Renewing after a lapse starts again from now
+	player.Subscription.ExpiresAt = now.Add(-time.Hour)
+	player.ExpireSubscription(now)
+	player.RenewSubscription(1, now)
+	if !player.HasPremium() || !player.Subscription.ExpiresAt.Equal(now.Add(SubscriptionPeriod)) {
+		t.Errorf("Expected a fresh month of premium, got %+v", player.Subscription)
+	}
+}
@@ -0,0 +1,141 @@
+package quest
+
+import (
+	"fmt"
+	"time"
+)
+
+// Progress tracks a character's counts towards each objective of an
+// accepted quest.
+type Progress struct {
+	QuestID    string
+	Counts     []int
+	AcceptedAt time.Time
+}
+
+// Count returns the progress towards the objective at index i.
+func (p *Progress) Count(i int) int {
+	if i < 0 || i >= len(p.Counts) {
+		return 0
+	}
+	return p.Counts[i]
+}
+
+// Log is a character's quest journal.
+type Log struct {
+	Active    map[string]*Progress
+	Completed map[string]time.Time
+}
+
+func NewLog() *Log {
+	return &Log{
+		Active:    make(map[string]*Progress),
+		Completed: make(map[string]time.Time),
+	}
+}
+
+func (l *Log) ensure() {
+	if l.Active == nil {
+		l.Active = make(map[string]*Progress)
+	}
+	if l.Completed == nil {
+		l.Completed = make(map[string]time.Time)
+	}
+}
+
+func (l *Log) IsActive(questID string) bool {
+	_, ok := l.Active[questID]
+	return ok
+}
+
+func (l *Log) IsCompleted(questID string) bool {
+	_, ok := l.Completed[questID]
+	return ok
+}
+
+func (l *Log) Accept(q *Quest) error {
+	l.ensure()
+	if l.IsActive(q.ID) {
+		return ErrQuestAlreadyActive
+	}
+	if l.IsCompleted(q.ID) {
+		return ErrQuestAlreadyCompleted
+	}
+
+	l.Active[q.ID] = &Progress{
+		QuestID:    q.ID,
+		Counts:     make([]int, len(q.Objectives)),
+		AcceptedAt: time.Now(),
+	}
+	return nil
+}
+
+func (l *Log) Abandon(questID string) error {
+	if !l.IsActive(questID) {
+		return ErrQuestNotActive
+	}
+	delete(l.Active, questID)
+	return nil
+}
+
+// RecordEvent advances every active objective matching the event and
+// returns progress messages for the character.
+func (l *Log) RecordEvent(eventType ObjectiveType, target string) []string {
+	var messages []string
+	for questID, progress := range l.Active {
+		q, err := GetQuestByID(questID)
+		if err != nil {
+			continue
+		}
+
+		advanced := false
+		for i, objective := range q.Objectives {
+			if i >= len(progress.Counts) || progress.Counts[i] >= objective.Count {
+				continue
+			}
+			if !objective.Matches(eventType, target) {
+				continue
+			}
+
+			progress.Counts[i]++
+			advanced = true
+			messages = append(messages, fmt.Sprintf("[%s] %s: %d/%d",
+				q.Name, objective.Description, progress.Counts[i], objective.Count))
+		}
+
+		if advanced && l.IsReady(q) {
+			messages = append(messages, fmt.Sprintf("[%s] All objectives complete. Return to your quest giver.", q.Name))
+		}
+	}
+	return messages
+}
+
+// IsReady reports whether every objective of an active quest is met.
+func (l *Log) IsReady(q *Quest) bool {
+	progress, ok := l.Active[q.ID]
+	if !ok {
+		return false
+	}
+	for i, objective := range q.Objectives {
+		if i >= len(progress.Counts) || progress.Counts[i] < objective.Count {
+			return false
+		}
+	}
+	return true
+}
+
+// Complete turns in a finished quest. The caller is responsible for
+// granting the quest's reward.
+func (l *Log) Complete(q *Quest) error {
+	if !l.IsActive(q.ID) {
+		return ErrQuestNotActive
+	}
+	if !l.IsReady(q) {
+		return ErrObjectivesIncomplete
+	}
+
+	l.ensure()
+	delete(l.Active, q.ID)
+	l.Completed[q.ID] = time.Now()
+	return nil
+}
@@ -0,0 +1,113 @@
+package quest
+
+import "testing"
+
+func TestQuestKillObjective(t *testing.T) {
+	q, err := GetQuestByID("goblin_menace")
+	if err != nil {
+		t.Fatalf("Failed to get quest: %v", err)
+	}
+
+	log := NewLog()
+	if err := log.Accept(q); err != nil {
+		t.Fatalf("Failed to accept quest: %v", err)
+	}
+
+	if err := log.Accept(q); err != ErrQuestAlreadyActive {
+		t.Errorf("Expected ErrQuestAlreadyActive, got %v", err)
+	}
+
+	if messages := log.RecordEvent(ObjectiveKill, "rat"); len(messages) != 0 {
+		t.Errorf("Expected unrelated kill to be ignored, got %v", messages)
+	}
+
+	if err := log.Complete(q); err != ErrObjectivesIncomplete {
+		t.Errorf("Expected ErrObjectivesIncomplete, got %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		if messages := log.RecordEvent(ObjectiveKill, "Goblin"); len(messages) == 0 {
+			t.Errorf("Expected progress message for kill %d", i+1)
+		}
+	}
+
+	if !log.IsReady(q) {
+		t.Fatalf("Expected quest to be ready after three kills")
+	}
+
+	if messages := log.RecordEvent(ObjectiveKill, "goblin"); len(messages) != 0 {
+		t.Errorf("Expected no progress past the objective count, got %v", messages)
+	}
+
+	if err := log.Complete(q); err != nil {
+		t.Fatalf("Failed to complete quest: %v", err)
+	}
+
+	if log.IsActive(q.ID) || !log.IsCompleted(q.ID) {
+		t.Errorf("Expected quest to move to completed")
+	}
+
+	if err := log.Accept(q); err != ErrQuestAlreadyCompleted {
+		t.Errorf("Expected ErrQuestAlreadyCompleted, got %v", err)
+	}
+}
+
+func TestQuestFetchObjectiveMatching(t *testing.T) {
+	q, _ := GetQuestByID("arming_the_militia")
+	log := NewLog()
+	log.Accept(q)
+
+	log.RecordEvent(ObjectiveKill, "rusty sword")
+	if log.IsReady(q) {
+		t.Errorf("Expected kill event to not satisfy a fetch objective")
+	}
+
+	log.RecordEvent(ObjectiveFetch, "rusty sword")
+	if !log.IsReady(q) {
+		t.Errorf("Expected 'rusty sword' to match the rusty_sword objective")
+	}
+}
+
+func TestQuestAbandon(t *testing.T) {
+	q, _ := GetQuestByID("goblin_menace")
+	log := NewLog()
+
+	if err := log.Abandon(q.ID); err != ErrQuestNotActive {
+		t.Errorf("Expected ErrQuestNotActive, got %v", err)
+	}
+
+	log.Accept(q)
+	log.RecordEvent(ObjectiveKill, "goblin")
+	if err := log.Abandon(q.ID); err != nil {
+		t.Fatalf("Failed to abandon quest: %v", err)
+	}
+
+	if log.IsActive(q.ID) {
+		t.Errorf("Expected quest to be removed from the log")
+	}
+
+	// Progress resets when the quest is accepted again
+	log.Accept(q)
+	if log.Active[q.ID].Counts[0] != 0 {
+		t.Errorf("Expected progress to reset, got %d", log.Active[q.ID].Counts[0])
+	}
+}
+
+func TestFindQuestAndGivers(t *testing.T) {
+	if q, err := FindQuest("goblin menace"); err != nil || q.ID != "goblin_menace" {
+		t.Errorf("Expected to find quest by name, got %v, %v", q, err)
+	}
+
+	if _, err := FindQuest("nonexistent"); err != ErrQuestNotFound {
+		t.Errorf("Expected ErrQuestNotFound, got %v", err)
+	}
+
+	givers := GetGiversInRoom("starting_room")
+	if len(givers) != 1 || givers[0].ID != "captain_aldric" {
+		t.Fatalf("Expected Captain Aldric in the starting room, got %v", givers)
+	}
+
+	if len(GetQuestsByGiver(givers[0].ID)) != 2 {
+		t.Errorf("Expected Captain Aldric to offer two quests")
+	}
+}
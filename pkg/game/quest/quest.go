@@ -0,0 +1,178 @@
+package quest
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/elidor/dungeogo/pkg/game/faction"
+)
+
+var (
+	ErrQuestNotFound         = errors.New("quest not found")
+	ErrGiverNotFound         = errors.New("quest giver not found")
+	ErrQuestAlreadyActive    = errors.New("quest already active")
+	ErrQuestAlreadyCompleted = errors.New("quest already completed")
+	ErrQuestNotActive        = errors.New("quest not active")
+	ErrObjectivesIncomplete  = errors.New("quest objectives incomplete")
+)
+
+type ObjectiveType int
+
+const (
+	ObjectiveKill ObjectiveType = iota
+	ObjectiveFetch
+)
+
+// Objective is a single requirement of a quest, such as killing a number
+// of a creature or picking up an item. Target is matched against the name
+// given to the relevant command.
+type Objective struct {
+	Type        ObjectiveType
+	Target      string
+	Count       int
+	Description string
+}
+
+type Reward struct {
+	Experience int
+	Gold       int
+	Items      []string
+	// Reputation changes the character's reputation, by faction ID
+	Reputation map[string]int
+}
+
+type Quest struct {
+	ID          string
+	Name        string
+	Description string
+	GiverID     string
+	Objectives  []Objective
+	Reward      Reward
+}
+
+// Giver is an NPC who hands out and accepts quests in a room.
+type Giver struct {
+	ID       string
+	Name     string
+	RoomID   string
+	Greeting string
+	// Faction is who the giver answers to. They only deal with characters
+	// the faction will deal with, and pay more to those it favours.
+	Faction string
+}
+
+// Matches reports whether the objective is satisfied by an event of the
+// given type against target.
+func (o Objective) Matches(eventType ObjectiveType, target string) bool {
+	return o.Type == eventType && matchesName(o.Target, target)
+}
+
+// Matches reports whether name refers to this quest by ID or name.
+func (q *Quest) Matches(name string) bool {
+	return matchesName(q.ID, name) || matchesName(q.Name, name)
+}
+
+func matchesName(want, got string) bool {
+	normalize := func(s string) string {
+		return strings.ReplaceAll(strings.TrimSpace(s), "_", " ")
+	}
+	return strings.EqualFold(normalize(want), normalize(got))
+}
+
+func GetQuestByID(id string) (*Quest, error) {
+	quests := getStandardQuests()
+	if q, exists := quests[id]; exists {
+		return q, nil
+	}
+	return nil, ErrQuestNotFound
+}
+
+// FindQuest looks up a quest by ID or name.
+func FindQuest(name string) (*Quest, error) {
+	for _, q := range getStandardQuests() {
+		if q.Matches(name) {
+			return q, nil
+		}
+	}
+	return nil, ErrQuestNotFound
+}
+
+func GetAllQuests() map[string]*Quest {
+	return getStandardQuests()
+}
+
+func GetGiverByID(id string) (*Giver, error) {
+	givers := getStandardGivers()
+	if g, exists := givers[id]; exists {
+		return g, nil
+	}
+	return nil, ErrGiverNotFound
+}
+
+// GetGiversInRoom returns the quest givers standing in roomID.
+func GetGiversInRoom(roomID string) []*Giver {
+	var givers []*Giver
+	for _, g := range getStandardGivers() {
+		if g.RoomID == roomID {
+			givers = append(givers, g)
+		}
+	}
+	return givers
+}
+
+// GetQuestsByGiver returns the quests offered by giverID.
+func GetQuestsByGiver(giverID string) []*Quest {
+	var quests []*Quest
+	for _, q := range getStandardQuests() {
+		if q.GiverID == giverID {
+			quests = append(quests, q)
+		}
+	}
+	return quests
+}
+
+func getStandardGivers() map[string]*Giver {
+	return map[string]*Giver{
+		"captain_aldric": {
+			ID:       "captain_aldric",
+			Name:     "Captain Aldric",
+			RoomID:   "starting_room",
+			Greeting: "Captain Aldric looks you over. \"Looking for work? Type 'quest' to see what I need.\"",
+			Faction:  faction.Militia,
+		},
+	}
+}
+
+func getStandardQuests() map[string]*Quest {
+	return map[string]*Quest{
+		"goblin_menace": {
+			ID:          "goblin_menace",
+			Name:        "Goblin Menace",
+			Description: "Goblins have been raiding the roads outside town. Thin their numbers.",
+			GiverID:     "captain_aldric",
+			Objectives: []Objective{
+				{Type: ObjectiveKill, Target: "goblin", Count: 3, Description: "Kill goblins"},
+			},
+			Reward: Reward{
+				Experience: 100,
+				Gold:       25,
+				Items:      []string{"health_potion"},
+				Reputation: map[string]int{faction.Militia: 100},
+			},
+		},
+		"arming_the_militia": {
+			ID:          "arming_the_militia",
+			Name:        "Arming the Militia",
+			Description: "The town militia is short of weapons. Bring back any blade you can find.",
+			GiverID:     "captain_aldric",
+			Objectives: []Objective{
+				{Type: ObjectiveFetch, Target: "rusty_sword", Count: 1, Description: "Find a rusty sword"},
+			},
+			Reward: Reward{
+				Experience: 50,
+				Gold:       10,
+				Reputation: map[string]int{faction.Militia: 50},
+			},
+		},
+	}
+}
@@ -0,0 +1,105 @@
+// Package resource describes gathering spots in rooms, such as ore veins,
+// which yield materials until they run dry and then refill over time.
+package resource
+
+import (
+	"time"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+)
+
+const (
+	// baseSuccessChance is the percent chance to gather at exactly a node's
+	// minimum skill; each level above it adds skillBonusPerLevel.
+	baseSuccessChance  = 50
+	skillBonusPerLevel = 10
+	maxSuccessChance   = 95
+)
+
+// Node is a spot in a room that yields one material to a gathering skill.
+// It holds up to Capacity units and regains one every Regen. Nodes are
+// placed in the world with the rooms they are in.
+type Node struct {
+	ID          string
+	Name        string
+	Description string
+	Skill       character.SkillType
+	MinSkill    int
+	Yield       string
+	Capacity    int
+	Regen       time.Duration
+	Experience  int
+}
+
+// State is how much a node has left. A node with no saved state is full.
+type State struct {
+	Remaining int
+	UpdatedAt time.Time
+}
+
+// Available returns how many units the node holds at now, counting what it
+// has regained since state was saved.
+func (n *Node) Available(state State, now time.Time) int {
+	if n.Regen <= 0 || state.Remaining >= n.Capacity {
+		return min(state.Remaining, n.Capacity)
+	}
+	regained := int(now.Sub(state.UpdatedAt) / n.Regen)
+	return min(state.Remaining+regained, n.Capacity)
+}
+
+// Take removes one unit at now and returns the updated state, or false if
+// the node is empty. Partial progress towards the next unit is kept.
+func (n *Node) Take(state State, now time.Time) (State, bool) {
+	available := n.Available(state, now)
+	if available == 0 {
+		return state, false
+	}
+
+	updatedAt := now
+	if state.Remaining < n.Capacity && available < n.Capacity && n.Regen > 0 {
+		regained := available - state.Remaining
+		updatedAt = state.UpdatedAt.Add(time.Duration(regained) * n.Regen)
+	}
+	return State{Remaining: available - 1, UpdatedAt: updatedAt}, true
+}
+
+// SuccessChance returns the percent chance that a character with skill
+// gathers anything from the node on one attempt.
+func (n *Node) SuccessChance(skill int) int {
+	return min(baseSuccessChance+(skill-n.MinSkill)*skillBonusPerLevel, maxSuccessChance)
+}
+
+// LoadState reads the node's state from a room's flags.
+func (n *Node) LoadState(flags map[string]interface{}) State {
+	state := State{Remaining: n.Capacity}
+	saved, ok := flags[n.flagKey()].(map[string]interface{})
+	if !ok {
+		return state
+	}
+
+	// Flags come back from the database as JSON numbers
+	switch remaining := saved["remaining"].(type) {
+	case int:
+		state.Remaining = remaining
+	case float64:
+		state.Remaining = int(remaining)
+	}
+	if updatedAt, ok := saved["updated_at"].(string); ok {
+		if parsed, err := time.Parse(time.RFC3339Nano, updatedAt); err == nil {
+			state.UpdatedAt = parsed
+		}
+	}
+	return state
+}
+
+// SaveState records the node's state in a room's flags.
+func (n *Node) SaveState(flags map[string]interface{}, state State) {
+	flags[n.flagKey()] = map[string]interface{}{
+		"remaining":  state.Remaining,
+		"updated_at": state.UpdatedAt.Format(time.RFC3339Nano),
+	}
+}
+
+func (n *Node) flagKey() string {
+	return "resource:" + n.ID
+}
@@ -0,0 +1,82 @@
+package resource
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+)
+
+func testNode() *Node {
+	return &Node{ID: "vein", Capacity: 3, Regen: 10 * time.Minute, Skill: character.SkillMining, MinSkill: 1}
+}
+
+func TestTakeDepletesAndRegenerates(t *testing.T) {
+	node := testNode()
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	state := State{Remaining: node.Capacity}
+
+	var ok bool
+	for i := 0; i < node.Capacity; i++ {
+		if state, ok = node.Take(state, start); !ok {
+			t.Fatalf("Expected take %d to succeed", i+1)
+		}
+	}
+	if _, ok := node.Take(state, start); ok {
+		t.Errorf("Expected an empty node to refuse")
+	}
+
+	// One unit back after one regen period, keeping progress towards the next
+	later := start.Add(15 * time.Minute)
+	if available := node.Available(state, later); available != 1 {
+		t.Errorf("Expected 1 unit after 15 minutes, got %d", available)
+	}
+	state, ok = node.Take(state, later)
+	if !ok {
+		t.Fatalf("Expected take after regen to succeed")
+	}
+	if available := node.Available(state, start.Add(20*time.Minute)); available != 1 {
+		t.Errorf("Expected partial regen progress to carry over, got %d", available)
+	}
+
+	if available := node.Available(state, start.Add(24*time.Hour)); available != node.Capacity {
+		t.Errorf("Expected regen to stop at capacity, got %d", available)
+	}
+}
+
+func TestStateRoundTripsThroughFlags(t *testing.T) {
+	node := testNode()
+	flags := make(map[string]interface{})
+	if state := node.LoadState(flags); state.Remaining != node.Capacity {
+		t.Errorf("Expected an unsaved node to be full, got %d", state.Remaining)
+	}
+
+	saved := State{Remaining: 1, UpdatedAt: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)}
+	node.SaveState(flags, saved)
+
+	// Room flags are stored as JSON
+	data, err := json.Marshal(flags)
+	if err != nil {
+		t.Fatalf("Failed to marshal flags: %v", err)
+	}
+	var loaded map[string]interface{}
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		t.Fatalf("Failed to unmarshal flags: %v", err)
+	}
+
+	state := node.LoadState(loaded)
+	if state.Remaining != 1 || !state.UpdatedAt.Equal(saved.UpdatedAt) {
+		t.Errorf("Expected %+v after round trip, got %+v", saved, state)
+	}
+}
+
+func TestSuccessChance(t *testing.T) {
+	node := testNode()
+	if got := node.SuccessChance(1); got != 50 {
+		t.Errorf("Expected 50%% at minimum skill, got %d", got)
+	}
+	if got := node.SuccessChance(20); got != 95 {
+		t.Errorf("Expected chance to cap at 95%%, got %d", got)
+	}
+}
@@ -0,0 +1,155 @@
+// Package reward scales the experience and gold characters earn, for
+// double-experience weekends and tuning how fast a server plays.
+package reward
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/elidor/dungeogo/pkg/persistence/interfaces"
+)
+
+// EventType is the type of world event that overrides the server's rates
+// while it runs. Its data may set "experience" and "gold" multipliers.
+const EventType = "reward_bonus"
+
+// Rates multiply the experience and gold characters earn
+type Rates struct {
+	Experience float64
+	Gold       float64
+}
+
+// Normal is the rates of a server with no bonus
+var Normal = Rates{Experience: 1, Gold: 1}
+
+// ApplyExperience returns amount experience scaled by the rates. A reward
+// of any experience stays at least 1.
+func (r Rates) ApplyExperience(amount int) int {
+	return scale(amount, r.Experience)
+}
+
+// ApplyGold returns amount gold scaled by the rates
+func (r Rates) ApplyGold(amount int) int {
+	return scale(amount, r.Gold)
+}
+
+func scale(amount int, multiplier float64) int {
+	if amount <= 0 {
+		return amount
+	}
+	return max(int(math.Round(float64(amount)*multiplier)), 1)
+}
+
+// IsNormal reports whether the rates leave rewards as they are
+func (r Rates) IsNormal() bool {
+	return r == Normal
+}
+
+// String describes the rates that differ from normal, as in
+// "experience x2, gold x1.5"
+func (r Rates) String() string {
+	var parts []string
+	if r.Experience != 1 {
+		parts = append(parts, "experience x"+formatMultiplier(r.Experience))
+	}
+	if r.Gold != 1 {
+		parts = append(parts, "gold x"+formatMultiplier(r.Gold))
+	}
+	if len(parts) == 0 {
+		return "normal"
+	}
+	return strings.Join(parts, ", ")
+}
+
+func formatMultiplier(multiplier float64) string {
+	return strconv.FormatFloat(multiplier, 'f', -1, 64)
+}
+
+// ParseMultiplier parses a positive multiplier such as "2" or "0.5". An
+// empty value is 1.
+func ParseMultiplier(value string) (float64, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 1, nil
+	}
+	multiplier, err := strconv.ParseFloat(value, 64)
+	if err != nil || multiplier <= 0 || math.IsInf(multiplier, 0) {
+		return 1, fmt.Errorf("invalid multiplier: %s", value)
+	}
+	return multiplier, nil
+}
+
+// Multiplier holds the rates in force: the server's base rates, unless a
+// running reward event overrides them. It is safe for concurrent use.
+type Multiplier struct {
+	mu      sync.RWMutex
+	base    Rates
+	current Rates
+}
+
+// NewMultiplier returns a multiplier at the base rates
+func NewMultiplier(base Rates) *Multiplier {
+	return &Multiplier{base: base, current: base}
+}
+
+// Rates returns the rates in force
+func (m *Multiplier) Rates() Rates {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.current
+}
+
+// SetBase sets the server's base rates. Any event overriding them still
+// applies until the next Refresh.
+func (m *Multiplier) SetBase(base Rates) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.base, m.current = base, base
+}
+
+// Refresh works out the rates in force from the world events running now.
+// A reward event replaces each base rate it sets; when several run at
+// once, the most generous wins.
+func (m *Multiplier) Refresh(events []*interfaces.WorldEvent) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	rates := m.base
+	var overridden Rates
+	for _, e := range events {
+		if e == nil || e.Type != EventType {
+			continue
+		}
+		if experience, ok := eventMultiplier(e, "experience"); ok {
+			overridden.Experience = max(overridden.Experience, experience)
+		}
+		if gold, ok := eventMultiplier(e, "gold"); ok {
+			overridden.Gold = max(overridden.Gold, gold)
+		}
+	}
+	if overridden.Experience > 0 {
+		rates.Experience = overridden.Experience
+	}
+	if overridden.Gold > 0 {
+		rates.Gold = overridden.Gold
+	}
+	m.current = rates
+}
+
+// eventMultiplier reads the multiplier called key from the event's data,
+// which may have been stored as a number or a string
+func eventMultiplier(e *interfaces.WorldEvent, key string) (float64, bool) {
+	switch value := e.Data[key].(type) {
+	case float64:
+		return value, value > 0
+	case int:
+		return float64(value), value > 0
+	case string:
+		multiplier, err := ParseMultiplier(value)
+		return multiplier, err == nil && multiplier > 0
+	}
+	return 0, false
+}
@@ -0,0 +1,67 @@
+package reward
+
+import (
+	"testing"
+
+	"github.com/elidor/dungeogo/pkg/persistence/interfaces"
+)
+
+func TestRatesApply(t *testing.T) {
+	rates := Rates{Experience: 2, Gold: 0.5}
+	if got := rates.ApplyExperience(25); got != 50 {
+		t.Errorf("Expected double experience, got %d", got)
+	}
+	if got := rates.ApplyGold(5); got != 3 {
+		t.Errorf("Expected half the gold rounded, got %d", got)
+	}
+	if got := rates.ApplyGold(1); got != 1 {
+		t.Errorf("Expected a reward to stay at least 1, got %d", got)
+	}
+	if got := rates.ApplyExperience(0); got != 0 {
+		t.Errorf("Expected nothing to stay nothing, got %d", got)
+	}
+	if rates.String() != "experience x2, gold x0.5" {
+		t.Errorf("Unexpected description %q", rates.String())
+	}
+	if !Normal.IsNormal() || rates.IsNormal() {
+		t.Errorf("Expected only the normal rates to be normal")
+	}
+}
+
+func TestParseMultiplier(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected float64
+		valid    bool
+	}{
+		{"", 1, true},
+		{"2", 2, true},
+		{" 1.5 ", 1.5, true},
+		{"0", 1, false},
+		{"-1", 1, false},
+		{"double", 1, false},
+	}
+	for _, tt := range tests {
+		got, err := ParseMultiplier(tt.value)
+		if (err == nil) != tt.valid || got != tt.expected {
+			t.Errorf("ParseMultiplier(%q): expected %v (valid %v), got %v, %v", tt.value, tt.expected, tt.valid, got, err)
+		}
+	}
+}
+
+func TestMultiplierRefresh(t *testing.T) {
+	m := NewMultiplier(Rates{Experience: 1.5, Gold: 1})
+	m.Refresh([]*interfaces.WorldEvent{
+		{Type: EventType, Data: map[string]interface{}{"experience": 2.0}},
+		{Type: EventType, Data: map[string]interface{}{"experience": "3"}},
+		{Type: "festival", Data: map[string]interface{}{"gold": 10.0}},
+	})
+	if got := m.Rates(); got != (Rates{Experience: 3, Gold: 1}) {
+		t.Errorf("Expected the most generous event to override experience only, got %+v", got)
+	}
+
+	m.Refresh(nil)
+	if got := m.Rates(); got != (Rates{Experience: 1.5, Gold: 1}) {
+		t.Errorf("Expected the base rates back once the events end, got %+v", got)
+	}
+}
@@ -0,0 +1,107 @@
+// Package stealth decides whether characters trying to stay out of sight
+// are noticed, and remembers who is currently hidden.
+package stealth
+
+import (
+	"sync"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+)
+
+const (
+	// baseHideChance is the percent chance to hide with no Stealth skill
+	// from an observer of basePerception; each skill level adds
+	// skillBonusPerLevel and each point of perception above the base takes
+	// away perceptionPenalty.
+	baseHideChance     = 40
+	skillBonusPerLevel = 10
+	basePerception     = 10
+	perceptionPenalty  = 3
+	minHideChance      = 5
+	maxHideChance      = 95
+
+	// darkPerceptionDivisor is how much worse observers without darkvision
+	// see in a dark room.
+	darkPerceptionDivisor = 2
+
+	darkvisionAbilityID = "darkvision"
+)
+
+// Perception is how good observer is at spotting hidden characters.
+func Perception(observer *character.Character, dark bool) int {
+	perception := observer.Level
+	if observer.Stats != nil {
+		perception += observer.Stats.Effective(character.StatWisdom)
+	}
+	if dark && !HasDarkvision(observer) {
+		perception /= darkPerceptionDivisor
+	}
+	return perception
+}
+
+// HasDarkvision reports whether c's race can see in the dark.
+func HasDarkvision(c *character.Character) bool {
+	if c.Race == nil {
+		return false
+	}
+	for _, ability := range c.Race.Abilities {
+		if ability.ID == darkvisionAbilityID {
+			return true
+		}
+	}
+	return false
+}
+
+// HideChance returns the percent chance that hider goes unnoticed by all of
+// observers. Only the sharpest observer counts.
+func HideChance(hider *character.Character, observers []*character.Character, dark bool) int {
+	chance := baseHideChance + hider.Skills.GetEffectiveSkillLevel(character.SkillStealth)*skillBonusPerLevel
+
+	sharpest := 0
+	for _, observer := range observers {
+		sharpest = max(sharpest, Perception(observer, dark))
+	}
+	if sharpest > basePerception {
+		chance -= (sharpest - basePerception) * perceptionPenalty
+	}
+	if len(observers) == 0 {
+		chance = maxHideChance
+	}
+	return max(minHideChance, min(chance, maxHideChance))
+}
+
+// Tracker remembers which characters are hidden. Being hidden does not
+// outlast the server.
+type Tracker struct {
+	mutex  sync.RWMutex
+	hidden map[string]bool
+}
+
+func NewTracker() *Tracker {
+	return &Tracker{hidden: make(map[string]bool)}
+}
+
+func (t *Tracker) Hide(characterID string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.hidden[characterID] = true
+}
+
+// Reveal brings characterID out of hiding, reporting whether they were
+// hidden.
+func (t *Tracker) Reveal(characterID string) bool {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if !t.hidden[characterID] {
+		return false
+	}
+	delete(t.hidden, characterID)
+	return true
+}
+
+func (t *Tracker) IsHidden(characterID string) bool {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+	return t.hidden[characterID]
+}
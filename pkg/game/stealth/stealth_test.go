@@ -0,0 +1,74 @@
+package stealth
+
+import (
+	"testing"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+)
+
+func newCharacter(t *testing.T, raceID string) *character.Character {
+	race, err := character.GetRaceByID(raceID)
+	if err != nil {
+		t.Fatalf("Failed to get race %s: %v", raceID, err)
+	}
+	class, _ := character.GetClassByID("rogue")
+	return character.NewCharacter("player1", "Shade", race, class)
+}
+
+func TestHideChanceAlone(t *testing.T) {
+	hider := newCharacter(t, "human")
+	if chance := HideChance(hider, nil, false); chance != maxHideChance {
+		t.Errorf("Expected %d with nobody watching, got %d", maxHideChance, chance)
+	}
+}
+
+func TestHideChanceSkillAndPerception(t *testing.T) {
+	hider := newCharacter(t, "human")
+	observer := newCharacter(t, "human")
+	observer.Stats.Wisdom = basePerception - observer.Level
+
+	base := HideChance(hider, []*character.Character{observer}, false)
+	if base != baseHideChance {
+		t.Errorf("Expected %d against an average observer, got %d", baseHideChance, base)
+	}
+
+	hider.Skills.GetSkill(character.SkillStealth).Level = 2
+	if chance := HideChance(hider, []*character.Character{observer}, false); chance != base+2*skillBonusPerLevel {
+		t.Errorf("Expected Stealth to help, got %d", chance)
+	}
+
+	observer.Stats.Wisdom += 5
+	if chance := HideChance(hider, []*character.Character{observer}, false); chance != base+2*skillBonusPerLevel-5*perceptionPenalty {
+		t.Errorf("Expected a sharper observer to hurt, got %d", chance)
+	}
+}
+
+func TestHideChanceDarkness(t *testing.T) {
+	hider := newCharacter(t, "human")
+	human := newCharacter(t, "human")
+	human.Stats.Wisdom = 20
+	elf := newCharacter(t, "elf")
+	elf.Stats.Wisdom = 20
+
+	lit := HideChance(hider, []*character.Character{human}, false)
+	if dark := HideChance(hider, []*character.Character{human}, true); dark <= lit {
+		t.Errorf("Expected darkness to help against a human, got %d lit and %d dark", lit, dark)
+	}
+	if Perception(elf, true) != Perception(elf, false) {
+		t.Errorf("Expected darkvision to see as well in the dark")
+	}
+}
+
+func TestTracker(t *testing.T) {
+	tracker := NewTracker()
+	if tracker.Reveal("char1") {
+		t.Errorf("Expected revealing a visible character to report false")
+	}
+	tracker.Hide("char1")
+	if !tracker.IsHidden("char1") {
+		t.Errorf("Expected char1 to be hidden")
+	}
+	if !tracker.Reveal("char1") || tracker.IsHidden("char1") {
+		t.Errorf("Expected char1 to be revealed")
+	}
+}
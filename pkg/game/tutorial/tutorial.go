@@ -0,0 +1,92 @@
+package tutorial
+
+import (
+	"github.com/elidor/dungeogo/pkg/commands"
+	"github.com/elidor/dungeogo/pkg/game/character"
+)
+
+// Step is a single onboarding lesson. A step is completed by entering a
+// command that matches it.
+type Step struct {
+	Name    string
+	Hint    string
+	Done    string
+	Matches func(cmd *commands.Command) bool
+}
+
+func verb(v string) func(cmd *commands.Command) bool {
+	return func(cmd *commands.Command) bool {
+		return cmd.Verb == v
+	}
+}
+
+// Steps are walked in order; Character.TutorialStep is 1-based.
+var Steps = []Step{
+	{
+		Name:    "look",
+		Hint:    "[Tutorial] Type 'look' (or 'l') to see your surroundings. Type 'skip' at any time to leave the tutorial.",
+		Done:    "[Tutorial] Well done. 'look' describes the room, its exits and anyone nearby.",
+		Matches: verb("look"),
+	},
+	{
+		Name: "movement",
+		Hint: "[Tutorial] Move around by typing a direction such as 'north' or just 'n'.",
+		Done: "[Tutorial] You can move in any direction listed in a room's exits.",
+		Matches: func(cmd *commands.Command) bool {
+			return cmd.Type == commands.CommandMovement
+		},
+	},
+	{
+		Name:    "inventory",
+		Hint:    "[Tutorial] Type 'inventory' (or 'i') to check what you are carrying.",
+		Done:    "[Tutorial] Use 'get', 'drop' and 'wear' to manage your belongings.",
+		Matches: verb("inventory"),
+	},
+	{
+		Name:    "combat",
+		Hint:    "[Tutorial] Practice fighting by typing 'kill dummy'.",
+		Done:    "[Tutorial] Combat continues until you or your foe falls. Use 'flee' if things go badly.",
+		Matches: verb("kill"),
+	},
+}
+
+// CurrentStep returns the step the character is working on, or nil when
+// they are not in the tutorial.
+func CurrentStep(c *character.Character) *Step {
+	if !c.InTutorial() || c.TutorialStep > len(Steps) {
+		return nil
+	}
+	return &Steps[c.TutorialStep-1]
+}
+
+// CurrentHint returns the hint for the character's current step.
+func CurrentHint(c *character.Character) []string {
+	step := CurrentStep(c)
+	if step == nil {
+		return nil
+	}
+	return []string{step.Hint}
+}
+
+// Advance checks cmd against the current step and moves the character on
+// when it matches, sending them to their start location once the last step
+// is done. It returns the messages to show and whether the character's
+// tutorial progress changed.
+func Advance(c *character.Character, cmd *commands.Command, starts *character.StartLocations) ([]string, bool) {
+	step := CurrentStep(c)
+	if step == nil || !step.Matches(cmd) {
+		return nil, false
+	}
+
+	messages := []string{step.Done}
+	c.TutorialStep++
+
+	if c.TutorialStep > len(Steps) {
+		c.EndTutorial(starts)
+		messages = append(messages, "[Tutorial] You have completed the tutorial and are ready to explore the world. Good luck!")
+		return messages, true
+	}
+
+	messages = append(messages, Steps[c.TutorialStep-1].Hint)
+	return messages, true
+}
@@ -0,0 +1,66 @@
+package tutorial
+
+import (
+	"testing"
+
+	"github.com/elidor/dungeogo/pkg/commands"
+	"github.com/elidor/dungeogo/pkg/game/character"
+)
+
+func newTutorialCharacter() *character.Character {
+	c := &character.Character{}
+	c.StartTutorial()
+	return c
+}
+
+func TestAdvanceThroughTutorial(t *testing.T) {
+	parser := commands.NewParser()
+	c := newTutorialCharacter()
+
+	inputs := []string{"look", "n", "i", "kill dummy"}
+	for i, input := range inputs {
+		cmd := parser.Parse(input, "player", "char")
+		messages, advanced := Advance(c, cmd, character.DefaultStartLocations())
+		if !advanced {
+			t.Fatalf("Expected %q to complete step %d", input, i+1)
+		}
+		if len(messages) == 0 {
+			t.Errorf("Expected feedback after %q", input)
+		}
+	}
+
+	if c.InTutorial() {
+		t.Errorf("Expected tutorial to be finished, still at step %d", c.TutorialStep)
+	}
+
+	if c.Location.RoomID != character.DefaultStartRoomID {
+		t.Errorf("Expected character to leave the tutorial zone, got %s", c.Location.RoomID)
+	}
+}
+
+func TestAdvanceIgnoresOtherCommands(t *testing.T) {
+	parser := commands.NewParser()
+	c := newTutorialCharacter()
+
+	_, advanced := Advance(c, parser.Parse("inventory", "player", "char"), character.DefaultStartLocations())
+	if advanced {
+		t.Errorf("Expected out-of-order command to not advance the tutorial")
+	}
+
+	if c.TutorialStep != 1 {
+		t.Errorf("Expected to stay on step 1, got %d", c.TutorialStep)
+	}
+}
+
+func TestCurrentHint(t *testing.T) {
+	c := &character.Character{}
+	if hint := CurrentHint(c); hint != nil {
+		t.Errorf("Expected no hint outside the tutorial, got %v", hint)
+	}
+
+	c.StartTutorial()
+	hint := CurrentHint(c)
+	if len(hint) != 1 || hint[0] != Steps[0].Hint {
+		t.Errorf("Expected first step hint, got %v", hint)
+	}
+}
@@ -0,0 +1,129 @@
+package world
+
+import (
+	"errors"
+
+	"github.com/elidor/dungeogo/pkg/game/lock"
+)
+
+var ErrDoorNotFound = errors.New("door not found")
+
+const (
+	North     = "north"
+	South     = "south"
+	East      = "east"
+	West      = "west"
+	Up        = "up"
+	Down      = "down"
+	Northeast = "northeast"
+	Northwest = "northwest"
+	Southeast = "southeast"
+	Southwest = "southwest"
+)
+
+// Directions lists every direction in the order exits are shown.
+var Directions = []string{North, South, East, West, Northeast, Northwest, Southeast, Southwest, Up, Down}
+
+var opposites = map[string]string{
+	North:     South,
+	South:     North,
+	East:      West,
+	West:      East,
+	Up:        Down,
+	Down:      Up,
+	Northeast: Southwest,
+	Southwest: Northeast,
+	Northwest: Southeast,
+	Southeast: Northwest,
+}
+
+var directionAliases = map[string]string{
+	"n":  North,
+	"s":  South,
+	"e":  East,
+	"w":  West,
+	"u":  Up,
+	"d":  Down,
+	"ne": Northeast,
+	"nw": Northwest,
+	"se": Southeast,
+	"sw": Southwest,
+}
+
+// ParseDirection turns a direction or its abbreviation, such as "n", into
+// the direction's full name.
+func ParseDirection(name string) (string, bool) {
+	if direction, ok := directionAliases[name]; ok {
+		return direction, true
+	}
+	if _, ok := opposites[name]; ok {
+		return name, true
+	}
+	return "", false
+}
+
+// Opposite returns the direction leading back, or "" for an unknown one.
+func Opposite(direction string) string {
+	return opposites[direction]
+}
+
+// Exit leads to another room, optionally through a door. Both sides of a
+// door name the same DoorID.
+type Exit struct {
+	To     string
+	DoorID string
+}
+
+// Door is a lockable door between two rooms.
+type Door struct {
+	ID   string
+	Name string
+	Lock lock.Lock
+}
+
+func GetDoor(doorID string) (*Door, error) {
+	if door, exists := getStandardDoors()[doorID]; exists {
+		return door, nil
+	}
+	return nil, ErrDoorNotFound
+}
+
+// SortedExits returns the room's exit directions in display order.
+func (r *Room) SortedExits() []string {
+	var directions []string
+	for _, direction := range Directions {
+		if _, ok := r.Exits[direction]; ok {
+			directions = append(directions, direction)
+		}
+	}
+	return directions
+}
+
+// DoorState reads a door's lock state from a room's state flags. Doors with
+// no saved state start locked.
+func DoorState(flags map[string]interface{}, doorID string) lock.State {
+	if value, ok := flags[doorFlag(doorID)].(string); ok {
+		return lock.State(value)
+	}
+	return lock.Locked
+}
+
+// SetDoorState records a door's lock state in a room's state flags. Both
+// rooms the door joins keep a copy.
+func SetDoorState(flags map[string]interface{}, doorID string, state lock.State) {
+	flags[doorFlag(doorID)] = string(state)
+}
+
+func doorFlag(doorID string) string {
+	return "door:" + doorID
+}
+
+func getStandardDoors() map[string]*Door {
+	return map[string]*Door{
+		"storeroom_door": {
+			ID:   "storeroom_door",
+			Name: "storeroom door",
+			Lock: lock.Lock{KeyID: "storeroom_key", Difficulty: 2},
+		},
+	}
+}
@@ -0,0 +1,62 @@
+package world
+
+import (
+	"testing"
+
+	"github.com/elidor/dungeogo/pkg/game/lock"
+)
+
+func TestExitsLeadBack(t *testing.T) {
+	for id, room := range getStandardRooms() {
+		for direction, exit := range room.Exits {
+			to, err := GetRoom(exit.To)
+			if err != nil {
+				t.Errorf("Room %s exit %s leads to unknown room %s", id, direction, exit.To)
+				continue
+			}
+			back, ok := to.Exits[Opposite(direction)]
+			if !ok || back.To != id {
+				t.Errorf("Room %s exit %s has no way back from %s", id, direction, exit.To)
+			}
+			if back.DoorID != exit.DoorID {
+				t.Errorf("Room %s exit %s and its way back name different doors", id, direction)
+			}
+			if exit.DoorID != "" {
+				if _, err := GetDoor(exit.DoorID); err != nil {
+					t.Errorf("Room %s exit %s uses unknown door %s", id, direction, exit.DoorID)
+				}
+			}
+		}
+	}
+}
+
+func TestParseDirection(t *testing.T) {
+	tests := map[string]string{"n": North, "north": North, "sw": Southwest, "up": Up}
+	for input, expected := range tests {
+		if direction, ok := ParseDirection(input); !ok || direction != expected {
+			t.Errorf("ParseDirection(%q) = %q, %v; want %q", input, direction, ok, expected)
+		}
+	}
+	if _, ok := ParseDirection("door"); ok {
+		t.Errorf("Expected door not to be a direction")
+	}
+}
+
+func TestSortedExits(t *testing.T) {
+	room, _ := GetRoom("starting_room")
+	exits := room.SortedExits()
+	if len(exits) != 2 || exits[0] != North || exits[1] != East {
+		t.Errorf("Expected [north east], got %v", exits)
+	}
+}
+
+func TestDoorState(t *testing.T) {
+	flags := make(map[string]interface{})
+	if DoorState(flags, "storeroom_door") != lock.Locked {
+		t.Errorf("Expected doors to start locked")
+	}
+	SetDoorState(flags, "storeroom_door", lock.Unlocked)
+	if DoorState(flags, "storeroom_door") != lock.Unlocked {
+		t.Errorf("Expected the door to be unlocked")
+	}
+}
@@ -0,0 +1,86 @@
+package world
+
+import "strings"
+
+// Map drawing sizes
+const (
+	// mapRadiusX and mapRadiusY are how many rooms are drawn either side of
+	// the centre
+	mapRadiusX = 3
+	mapRadiusY = 2
+	// mapCellWidth is one room and the link to its east neighbour, "[ ]-"
+	mapCellWidth = 4
+)
+
+// RenderMap draws the rooms of center's zone around it as an ASCII grid no
+// wider than width, with north at the top. Rooms appear only if shown
+// returns true for them, and the centre is marked "[*]".
+func RenderMap(center *Room, shown func(room *Room) bool, width int) []string {
+	radiusX := mapRadiusX
+	if fit := ((width+1)/mapCellWidth - 1) / 2; fit < radiusX {
+		radiusX = max(fit, 0)
+	}
+
+	type point struct{ x, y int }
+	grid := make(map[point]*Room)
+	for _, room := range getStandardRooms() {
+		if room.ZoneID != center.ZoneID || (room.ID != center.ID && !shown(room)) {
+			continue
+		}
+		if abs(room.X-center.X) > radiusX || abs(room.Y-center.Y) > mapRadiusY {
+			continue
+		}
+		grid[point{room.X, room.Y}] = room
+	}
+	linked := func(from *Room, direction string, to *Room) bool {
+		if from == nil || to == nil {
+			return false
+		}
+		exit, ok := from.Exits[direction]
+		return ok && exit.To == to.ID
+	}
+
+	var lines []string
+	for y := center.Y + mapRadiusY; y >= center.Y-mapRadiusY; y-- {
+		var row, below strings.Builder
+		for x := center.X - radiusX; x <= center.X+radiusX; x++ {
+			room := grid[point{x, y}]
+			switch {
+			case room == nil:
+				row.WriteString("   ")
+			case room.ID == center.ID:
+				row.WriteString("[*]")
+			default:
+				row.WriteString("[ ]")
+			}
+
+			if linked(room, East, grid[point{x + 1, y}]) {
+				row.WriteString("-")
+			} else {
+				row.WriteString(" ")
+			}
+			if linked(room, South, grid[point{x, y - 1}]) {
+				below.WriteString(" |  ")
+			} else {
+				below.WriteString("    ")
+			}
+		}
+		lines = append(lines, strings.TrimRight(row.String(), " "), strings.TrimRight(below.String(), " "))
+	}
+
+	// Drop the empty rows above and below the drawn rooms
+	for len(lines) > 0 && lines[0] == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
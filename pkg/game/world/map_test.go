@@ -0,0 +1,48 @@
+package world
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+)
+
+func TestRenderMap(t *testing.T) {
+	center, _ := GetRoom(character.DefaultStartRoomID)
+	all := func(room *Room) bool { return true }
+
+	expected := []string{
+		"            [ ]",
+		"             |",
+		"            [*]-[ ]",
+	}
+	if lines := RenderMap(center, all, 80); !reflect.DeepEqual(lines, expected) {
+		t.Errorf("Expected %q, got %q", expected, lines)
+	}
+
+	// Rooms not shown are left out along with their links
+	riverbankOnly := func(room *Room) bool { return room.ID == "riverbank" }
+	expected = []string{"            [*]-[ ]"}
+	if lines := RenderMap(center, riverbankOnly, 80); !reflect.DeepEqual(lines, expected) {
+		t.Errorf("Expected %q, got %q", expected, lines)
+	}
+}
+
+func TestRenderMapFitsWidth(t *testing.T) {
+	center, _ := GetRoom(character.DefaultStartRoomID)
+	all := func(room *Room) bool { return true }
+
+	for _, width := range []int{80, 20, 10, 3} {
+		for _, line := range RenderMap(center, all, width) {
+			if len(line) > width {
+				t.Errorf("Width %d: line %q is too wide", width, line)
+			}
+		}
+	}
+
+	// A narrow screen only has room for the centre column
+	expected := []string{"[ ]", " |", "[*]"}
+	if lines := RenderMap(center, all, 5); !reflect.DeepEqual(lines, expected) {
+		t.Errorf("Expected %q, got %q", expected, lines)
+	}
+}
@@ -0,0 +1,210 @@
+// Package world holds the static definition of the game world: its rooms,
+// where they sit and what kind of place each one is. What changes while the
+// server runs, such as items on the floor, lives in the room's saved state.
+package world
+
+import (
+	"errors"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+	"github.com/elidor/dungeogo/pkg/game/resource"
+)
+
+var ErrRoomNotFound = errors.New("room not found")
+
+// Room flags describe what kind of place a room is
+const (
+	// FlagWater rooms can be fished in
+	FlagWater = "water"
+	// FlagDark rooms are unlit, making it easier to hide in them
+	FlagDark = "dark"
+	// FlagSafe rooms are sanctuaries where players cannot attack each other
+	FlagSafe = "safe"
+	// FlagNoRecall rooms cannot be recalled out of
+	FlagNoRecall = "norecall"
+	// FlagNoMagic rooms smother every kind of magic
+	FlagNoMagic = "nomagic"
+	// FlagIndoor rooms are under a roof, out of the weather
+	FlagIndoor = "indoor"
+)
+
+// flagNames lists every room flag, in the order they are shown
+var flagNames = []string{FlagSafe, FlagNoRecall, FlagNoMagic, FlagWater, FlagDark, FlagIndoor}
+
+// FlagNames returns the name of every room flag
+func FlagNames() []string {
+	return slices.Clone(flagNames)
+}
+
+// IsFlag reports whether name is a room flag
+func IsFlag(name string) bool {
+	return slices.Contains(flagNames, name)
+}
+
+type Room struct {
+	ID          string
+	Name        string
+	Description string
+	ZoneID      string
+	X           int
+	Y           int
+	Flags       map[string]bool
+	Exits       map[string]Exit
+	// Resources are the spots in the room that can be gathered from
+	Resources []*resource.Node
+}
+
+// zoneNames are the names players see for each zone
+var zoneNames = map[string]string{
+	character.TutorialZoneID:     "The Training Grounds",
+	character.DefaultStartZoneID: "Riverside Village",
+	character.DwarfStartZoneID:   "Ironpeak Hold",
+}
+
+// ZoneName returns the name players see for zoneID, or the ID itself when
+// the zone has no name.
+func ZoneName(zoneID string) string {
+	if name, ok := zoneNames[zoneID]; ok {
+		return name
+	}
+	return zoneID
+}
+
+func GetRoom(roomID string) (*Room, error) {
+	if room, exists := getStandardRooms()[roomID]; exists {
+		return room, nil
+	}
+	return nil, ErrRoomNotFound
+}
+
+// RoomName returns the name of the room with roomID, or the ID if there is
+// no such room.
+func RoomName(roomID string) string {
+	if room, err := GetRoom(roomID); err == nil {
+		return room.Name
+	}
+	return roomID
+}
+
+// RoomIDs returns the ID of every room in the world, sorted
+func RoomIDs() []string {
+	rooms := getStandardRooms()
+	ids := make([]string, 0, len(rooms))
+	for id := range rooms {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// ResourceNodes returns the resource nodes in the room with roomID
+func ResourceNodes(roomID string) []*resource.Node {
+	room, err := GetRoom(roomID)
+	if err != nil {
+		return nil
+	}
+	return room.Resources
+}
+
+// FindResourceNode returns the first node in the room with roomID worked
+// with skill, or nil.
+func FindResourceNode(roomID string, skill character.SkillType) *resource.Node {
+	for _, node := range ResourceNodes(roomID) {
+		if node.Skill == skill {
+			return node
+		}
+	}
+	return nil
+}
+
+// HasFlag reports whether the room with roomID has flag. A value saved in
+// the room's state flags overrides the room's definition, so flags can be
+// changed while the server runs.
+func HasFlag(roomID string, stateFlags map[string]interface{}, flag string) bool {
+	if value, ok := stateFlags[flag].(bool); ok {
+		return value
+	}
+	room, err := GetRoom(roomID)
+	if err != nil {
+		return false
+	}
+	return room.Flags[flag]
+}
+
+func getStandardRooms() map[string]*Room {
+	return map[string]*Room{
+		character.TutorialRoomID: {
+			ID:          character.TutorialRoomID,
+			Name:        "A Quiet Training Ground",
+			Description: "A fenced yard set apart from the world, where newcomers learn the ropes.",
+			ZoneID:      character.TutorialZoneID,
+			Flags:       map[string]bool{FlagSafe: true, FlagNoRecall: true},
+			Exits:       map[string]Exit{North: {To: "tutorial_yard"}},
+		},
+		"tutorial_yard": {
+			ID:          "tutorial_yard",
+			Name:        "The Practice Yard",
+			Description: "Straw dummies slump against a weathered fence.",
+			ZoneID:      character.TutorialZoneID,
+			Y:           1,
+			Flags:       map[string]bool{FlagSafe: true, FlagNoRecall: true},
+			Exits:       map[string]Exit{South: {To: character.TutorialRoomID}},
+		},
+		character.DefaultStartRoomID: {
+			ID:          character.DefaultStartRoomID,
+			Name:        "A Simple Room",
+			Description: "You are in a basic room with stone walls and a dirt floor.",
+			ZoneID:      character.DefaultStartZoneID,
+			Flags:       map[string]bool{FlagSafe: true, FlagIndoor: true},
+			Exits: map[string]Exit{
+				North: {To: "storeroom", DoorID: "storeroom_door"},
+				East:  {To: "riverbank"},
+			},
+			Resources: []*resource.Node{
+				{
+					ID:          "starting_iron_vein",
+					Name:        "iron vein",
+					Description: "A rust-streaked vein of iron ore runs through one wall.",
+					Skill:       character.SkillMining,
+					MinSkill:    0,
+					Yield:       "iron_ore",
+					Capacity:    5,
+					Regen:       10 * time.Minute,
+					Experience:  15,
+				},
+			},
+		},
+		"storeroom": {
+			ID:          "storeroom",
+			Name:        "A Dusty Storeroom",
+			Description: "Crates and barrels are stacked to the rafters, thick with dust.",
+			ZoneID:      character.DefaultStartZoneID,
+			Y:           1,
+			Flags:       map[string]bool{FlagDark: true, FlagIndoor: true},
+			Exits:       map[string]Exit{South: {To: character.DefaultStartRoomID, DoorID: "storeroom_door"}},
+		},
+		"riverbank": {
+			ID:          "riverbank",
+			Name:        "Riverbank",
+			Description: "A slow river winds past a muddy bank dotted with reeds.",
+			ZoneID:      character.DefaultStartZoneID,
+			X:           1,
+			Flags:       map[string]bool{FlagWater: true},
+			Exits: map[string]Exit{
+				West: {To: character.DefaultStartRoomID},
+				Up:   {To: character.DwarfStartRoomID},
+			},
+		},
+		character.DwarfStartRoomID: {
+			ID:          character.DwarfStartRoomID,
+			Name:        "The Great Hall of Ironpeak",
+			Description: "Braziers light a vaulted hall carved deep into the mountain. A road winds down the mountain to the river.",
+			ZoneID:      character.DwarfStartZoneID,
+			Flags:       map[string]bool{FlagSafe: true, FlagIndoor: true},
+			Exits:       map[string]Exit{Down: {To: "riverbank"}},
+		},
+	}
+}
@@ -0,0 +1,86 @@
+package world
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+	"github.com/elidor/dungeogo/pkg/game/items"
+)
+
+func TestGetRoom(t *testing.T) {
+	room, err := GetRoom(character.DefaultStartRoomID)
+	if err != nil {
+		t.Fatalf("Expected the starting room to exist: %v", err)
+	}
+	if room.ZoneID != character.DefaultStartZoneID {
+		t.Errorf("Expected starting room in %s, got %s", character.DefaultStartZoneID, room.ZoneID)
+	}
+
+	if _, err := GetRoom("nowhere"); !errors.Is(err, ErrRoomNotFound) {
+		t.Errorf("Expected ErrRoomNotFound, got %v", err)
+	}
+}
+
+func TestZoneName(t *testing.T) {
+	if got := ZoneName(character.DefaultStartZoneID); got != "Riverside Village" {
+		t.Errorf("Expected Riverside Village, got %s", got)
+	}
+	if got := ZoneName("unknown_zone"); got != "unknown_zone" {
+		t.Errorf("Expected an unnamed zone to fall back to its ID, got %s", got)
+	}
+}
+
+func TestHasFlag(t *testing.T) {
+	if !HasFlag("riverbank", nil, FlagWater) {
+		t.Errorf("Expected the riverbank to be water")
+	}
+	if HasFlag(character.DefaultStartRoomID, nil, FlagWater) {
+		t.Errorf("Expected the starting room to be dry")
+	}
+	if HasFlag("nowhere", nil, FlagWater) {
+		t.Errorf("Expected unknown rooms to have no flags")
+	}
+
+	// Saved room state overrides the definition either way
+	if HasFlag("riverbank", map[string]interface{}{FlagWater: false}, FlagWater) {
+		t.Errorf("Expected state to clear the flag")
+	}
+	if !HasFlag(character.DefaultStartRoomID, map[string]interface{}{FlagWater: true}, FlagWater) {
+		t.Errorf("Expected state to set the flag")
+	}
+}
+
+func TestIsFlag(t *testing.T) {
+	for _, name := range FlagNames() {
+		if !IsFlag(name) {
+			t.Errorf("Expected %s to be a flag", name)
+		}
+	}
+	if IsFlag("door:storeroom_door") {
+		t.Errorf("Expected door state not to count as a flag")
+	}
+}
+
+func TestResourceNodesYieldKnownItems(t *testing.T) {
+	factory := items.NewItemFactory()
+	for _, roomID := range RoomIDs() {
+		for _, node := range ResourceNodes(roomID) {
+			if _, err := factory.GetTemplate(node.Yield); err != nil {
+				t.Errorf("Node %s in %s yields unknown template %s", node.ID, roomID, node.Yield)
+			}
+		}
+	}
+}
+
+func TestFindResourceNode(t *testing.T) {
+	if node := FindResourceNode(character.DefaultStartRoomID, character.SkillMining); node == nil {
+		t.Errorf("Expected a mining node in the starting room")
+	}
+	if node := FindResourceNode(character.DefaultStartRoomID, character.SkillLockpicking); node != nil {
+		t.Errorf("Expected no lockpicking node, got %s", node.ID)
+	}
+	if nodes := ResourceNodes("nowhere"); len(nodes) != 0 {
+		t.Errorf("Expected no nodes in an unknown room, got %d", len(nodes))
+	}
+}
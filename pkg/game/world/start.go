@@ -0,0 +1,70 @@
+package world
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+)
+
+// StartLocations builds where characters begin from server settings.
+// defaultRoomID, if set, replaces the usual starting room, and overrides is
+// a comma-separated list of race or class IDs and the room each starts in,
+// such as "dwarf=mountain_hold,mage=riverbank". Every room must exist; its
+// zone is taken from the room.
+func StartLocations(defaultRoomID, overrides string) (*character.StartLocations, error) {
+	starts := character.DefaultStartLocations()
+
+	if defaultRoomID != "" {
+		start, err := startLocation(defaultRoomID)
+		if err != nil {
+			return nil, err
+		}
+		starts.Default = start
+	}
+
+	for _, entry := range strings.Split(overrides, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		id, roomID, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("start location %q must be written as id=room", entry)
+		}
+		id = strings.ToLower(strings.TrimSpace(id))
+		start, err := startLocation(strings.TrimSpace(roomID))
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case isRace(id):
+			starts.Races[id] = start
+		case isClass(id):
+			starts.Classes[id] = start
+		default:
+			return nil, fmt.Errorf("start location for %q: no race or class has that ID", id)
+		}
+	}
+
+	return starts, nil
+}
+
+func startLocation(roomID string) (character.Location, error) {
+	room, err := GetRoom(roomID)
+	if err != nil {
+		return character.Location{}, fmt.Errorf("start room %q: %w", roomID, err)
+	}
+	return character.Location{RoomID: room.ID, ZoneID: room.ZoneID}, nil
+}
+
+func isRace(id string) bool {
+	_, err := character.GetRaceByID(id)
+	return err == nil
+}
+
+func isClass(id string) bool {
+	_, err := character.GetClassByID(id)
+	return err == nil
+}
@@ -0,0 +1,46 @@
+package world
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+)
+
+func TestStartLocations(t *testing.T) {
+	starts, err := StartLocations("", "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if starts.Default.RoomID != character.DefaultStartRoomID || starts.Races["dwarf"].RoomID != character.DwarfStartRoomID {
+		t.Errorf("Expected the usual start locations, got %+v", starts)
+	}
+
+	starts, err = StartLocations("riverbank", "elf = storeroom, Mage=mountain_hold")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if starts.Default != (character.Location{RoomID: "riverbank", ZoneID: character.DefaultStartZoneID}) {
+		t.Errorf("Expected the riverbank as the default, got %+v", starts.Default)
+	}
+	if starts.Races["elf"].RoomID != "storeroom" {
+		t.Errorf("Expected elves to start in the storeroom, got %+v", starts.Races["elf"])
+	}
+	if mage := starts.Classes["mage"]; mage.RoomID != character.DwarfStartRoomID || mage.ZoneID != character.DwarfStartZoneID {
+		t.Errorf("Expected mages to start in the hold, got %+v", mage)
+	}
+}
+
+func TestStartLocationsRejectsInvalid(t *testing.T) {
+	if _, err := StartLocations("nowhere", ""); !errors.Is(err, ErrRoomNotFound) {
+		t.Errorf("Expected ErrRoomNotFound for an unknown default room, got %v", err)
+	}
+	if _, err := StartLocations("", "dwarf=nowhere"); !errors.Is(err, ErrRoomNotFound) {
+		t.Errorf("Expected ErrRoomNotFound for an unknown override room, got %v", err)
+	}
+	for _, overrides := range []string{"dwarf", "gnome=riverbank"} {
+		if _, err := StartLocations("", overrides); err == nil {
+			t.Errorf("Expected %q to be rejected", overrides)
+		}
+	}
+}
@@ -166,11 +166,12 @@ func TestServerIntegration_GameEngineCommands(t *testing.T) {
 	}
 
 	for _, test := range testCommands {
-		responses, err := gameEngine.ProcessCommand(testChar.ID, test.input)
+		result, err := gameEngine.ProcessCommand(testChar.ID, test.input)
 		if err != nil {
 			t.Errorf("Command '%s' failed: %v", test.input, err)
 			continue
 		}
+		responses := result.Messages
 
 		if len(responses) == 0 {
 			t.Errorf("Command '%s' returned no responses", test.input)
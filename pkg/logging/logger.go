@@ -0,0 +1,111 @@
+package logging
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"sync"
+)
+
+type Level int
+
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+func (l Level) String() string {
+	switch l {
+	case LevelDebug:
+		return "DEBUG"
+	case LevelInfo:
+		return "INFO"
+	case LevelWarn:
+		return "WARN"
+	case LevelError:
+		return "ERROR"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// ParseLevel converts a level name such as "debug" or "warn" into a Level
+func ParseLevel(name string) (Level, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "debug", "verbose":
+		return LevelDebug, nil
+	case "info", "":
+		return LevelInfo, nil
+	case "warn", "warning":
+		return LevelWarn, nil
+	case "error":
+		return LevelError, nil
+	default:
+		return LevelInfo, fmt.Errorf("unknown log level: %s", name)
+	}
+}
+
+// Logger is a minimal leveled logger. Callers are responsible for never
+// passing credentials or values derived from them (lengths, hashes) as
+// arguments, at any level.
+type Logger struct {
+	out   *log.Logger
+	level Level
+	mutex sync.RWMutex
+}
+
+func New(w io.Writer, level Level) *Logger {
+	return &Logger{
+		out:   log.New(w, "", log.LstdFlags),
+		level: level,
+	}
+}
+
+// Default returns an info-level logger writing to stdout
+func Default() *Logger {
+	return New(os.Stdout, LevelInfo)
+}
+
+// Discard returns a logger that drops everything, for tests
+func Discard() *Logger {
+	return New(io.Discard, LevelError+1)
+}
+
+func (l *Logger) SetLevel(level Level) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.level = level
+}
+
+func (l *Logger) Enabled(level Level) bool {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+	return level >= l.level
+}
+
+func (l *Logger) Debugf(format string, args ...interface{}) {
+	l.logf(LevelDebug, format, args...)
+}
+
+func (l *Logger) Infof(format string, args ...interface{}) {
+	l.logf(LevelInfo, format, args...)
+}
+
+func (l *Logger) Warnf(format string, args ...interface{}) {
+	l.logf(LevelWarn, format, args...)
+}
+
+func (l *Logger) Errorf(format string, args ...interface{}) {
+	l.logf(LevelError, format, args...)
+}
+
+func (l *Logger) logf(level Level, format string, args ...interface{}) {
+	if !l.Enabled(level) {
+		return
+	}
+	l.out.Printf("[%s] %s", level, fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,68 @@
+package logging
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestLogger_LevelFiltering(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, LevelInfo)
+
+	logger.Debugf("debug %d", 1)
+	logger.Infof("info %d", 2)
+	logger.Errorf("error %d", 3)
+
+	output := buf.String()
+	if strings.Contains(output, "debug 1") {
+		t.Errorf("Expected debug message to be suppressed at info level, got: %s", output)
+	}
+
+	if !strings.Contains(output, "[INFO] info 2") {
+		t.Errorf("Expected info message in output, got: %s", output)
+	}
+
+	if !strings.Contains(output, "[ERROR] error 3") {
+		t.Errorf("Expected error message in output, got: %s", output)
+	}
+
+	buf.Reset()
+	logger.SetLevel(LevelDebug)
+	logger.Debugf("debug %d", 4)
+	if !strings.Contains(buf.String(), "[DEBUG] debug 4") {
+		t.Errorf("Expected debug message once verbose, got: %s", buf.String())
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	tests := map[string]Level{
+		"debug":   LevelDebug,
+		"VERBOSE": LevelDebug,
+		"info":    LevelInfo,
+		"":        LevelInfo,
+		"warning": LevelWarn,
+		"error":   LevelError,
+	}
+
+	for input, expected := range tests {
+		level, err := ParseLevel(input)
+		if err != nil {
+			t.Errorf("Unexpected error parsing %q: %v", input, err)
+		}
+		if level != expected {
+			t.Errorf("Expected %q to parse as %v, got %v", input, expected, level)
+		}
+	}
+
+	if _, err := ParseLevel("loud"); err == nil {
+		t.Errorf("Expected error for unknown level")
+	}
+}
+
+func TestDiscard(t *testing.T) {
+	logger := Discard()
+	if logger.Enabled(LevelError) {
+		t.Errorf("Expected discard logger to drop every level")
+	}
+}
@@ -0,0 +1,53 @@
+package mail
+
+import (
+	"fmt"
+	"net/smtp"
+	"strings"
+)
+
+// Mailer delivers account emails such as verification codes
+type Mailer interface {
+	Send(to, subject, body string) error
+}
+
+// NoopMailer discards every message. It is the default when no mail server
+// is configured and is what tests should use.
+type NoopMailer struct{}
+
+func (m *NoopMailer) Send(to, subject, body string) error {
+	return nil
+}
+
+// SMTPMailer sends plain-text mail through an SMTP relay
+type SMTPMailer struct {
+	Addr string
+	From string
+	Auth smtp.Auth
+}
+
+func NewSMTPMailer(addr, from, username, password string) *SMTPMailer {
+	mailer := &SMTPMailer{
+		Addr: addr,
+		From: from,
+	}
+
+	if username != "" {
+		host := addr
+		if idx := strings.LastIndex(addr, ":"); idx >= 0 {
+			host = addr[:idx]
+		}
+		mailer.Auth = smtp.PlainAuth("", username, password, host)
+	}
+
+	return mailer
+}
+
+func (m *SMTPMailer) Send(to, subject, body string) error {
+	message := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\n\r\n%s\r\n", m.From, to, subject, body)
+
+	if err := smtp.SendMail(m.Addr, m.Auth, m.From, []string{to}, []byte(message)); err != nil {
+		return fmt.Errorf("failed to send mail: %w", err)
+	}
+	return nil
+}
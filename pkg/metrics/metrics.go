@@ -0,0 +1,315 @@
+// Package metrics keeps counters, gauges and histograms and serves them in
+// the Prometheus text exposition format. Each part of the server registers
+// its metrics on a Registry it is given, so tests can use a fresh one.
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultBuckets suit latencies measured in seconds, from 1ms to 10s.
+var DefaultBuckets = []float64{0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Registry holds every metric exposed on one endpoint.
+type Registry struct {
+	mutex   sync.RWMutex
+	metrics []metric
+	names   map[string]bool
+}
+
+type metric interface {
+	name() string
+	write(w io.Writer) error
+}
+
+func NewRegistry() *Registry {
+	return &Registry{names: make(map[string]bool)}
+}
+
+func (r *Registry) register(m metric) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.names[m.name()] {
+		panic(fmt.Sprintf("metric %s registered twice", m.name()))
+	}
+	r.names[m.name()] = true
+	r.metrics = append(r.metrics, m)
+}
+
+// NewCounter registers a counter partitioned by labelNames.
+func (r *Registry) NewCounter(name, help string, labelNames ...string) *Counter {
+	c := &Counter{family: newFamily(name, help, "counter", labelNames)}
+	r.register(c)
+	return c
+}
+
+// NewGaugeFunc registers a gauge whose value is read from fn at scrape time.
+func (r *Registry) NewGaugeFunc(name, help string, fn func() float64) {
+	r.register(&gaugeFunc{family: newFamily(name, help, "gauge", nil), fn: fn})
+}
+
+// NewHistogram registers a histogram with the given upper bounds, which
+// must be sorted, partitioned by labelNames.
+func (r *Registry) NewHistogram(name, help string, buckets []float64, labelNames ...string) *Histogram {
+	h := &Histogram{family: newFamily(name, help, "histogram", labelNames), buckets: buckets}
+	r.register(h)
+	return h
+}
+
+// Write writes every metric in registration order.
+func (r *Registry) Write(w io.Writer) error {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	for _, m := range r.metrics {
+		if err := m.write(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Handler serves the registry to Prometheus scrapers.
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet && req.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		r.Write(w)
+	})
+}
+
+// family is the name, help text and labels shared by a metric's series.
+type family struct {
+	metricName string
+	help       string
+	kind       string
+	labelNames []string
+}
+
+func newFamily(name, help, kind string, labelNames []string) family {
+	return family{metricName: name, help: help, kind: kind, labelNames: labelNames}
+}
+
+func (f *family) name() string {
+	return f.metricName
+}
+
+func (f *family) writeHeader(w io.Writer) error {
+	help := strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(f.help)
+	_, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", f.metricName, help, f.metricName, f.kind)
+	return err
+}
+
+func (f *family) key(labelValues []string) string {
+	if len(labelValues) != len(f.labelNames) {
+		panic(fmt.Sprintf("metric %s takes %d label values, got %d", f.metricName, len(f.labelNames), len(labelValues)))
+	}
+	return strings.Join(labelValues, "\xff")
+}
+
+// labels formats label pairs, with extra appended after the family's own.
+func (f *family) labels(labelValues []string, extra ...string) string {
+	var pairs []string
+	for i, name := range f.labelNames {
+		pairs = append(pairs, name+`="`+escapeLabel(labelValues[i])+`"`)
+	}
+	for i := 0; i+1 < len(extra); i += 2 {
+		pairs = append(pairs, extra[i]+`="`+escapeLabel(extra[i+1])+`"`)
+	}
+	if len(pairs) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func escapeLabel(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
+
+func formatValue(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+// Counter is a value that only goes up, such as commands processed.
+type Counter struct {
+	family
+	mutex  sync.Mutex
+	series map[string]*counterSeries
+}
+
+type counterSeries struct {
+	labelValues []string
+	value       float64
+}
+
+// Inc adds one to the series with labelValues.
+func (c *Counter) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+// Add adds delta, which must not be negative, to the series with labelValues.
+func (c *Counter) Add(delta float64, labelValues ...string) {
+	if delta < 0 {
+		panic(fmt.Sprintf("counter %s cannot decrease", c.metricName))
+	}
+	key := c.key(labelValues)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.series == nil {
+		c.series = make(map[string]*counterSeries)
+	}
+	s, ok := c.series[key]
+	if !ok {
+		s = &counterSeries{labelValues: append([]string(nil), labelValues...)}
+		c.series[key] = s
+	}
+	s.value += delta
+}
+
+// Value returns the current value of the series with labelValues.
+func (c *Counter) Value(labelValues ...string) float64 {
+	key := c.key(labelValues)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if s, ok := c.series[key]; ok {
+		return s.value
+	}
+	return 0
+}
+
+func (c *Counter) write(w io.Writer) error {
+	if err := c.writeHeader(w); err != nil {
+		return err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for _, key := range sortedKeys(c.series) {
+		s := c.series[key]
+		if _, err := fmt.Fprintf(w, "%s%s %s\n", c.metricName, c.labels(s.labelValues), formatValue(s.value)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type gaugeFunc struct {
+	family
+	fn func() float64
+}
+
+func (g *gaugeFunc) write(w io.Writer) error {
+	if err := g.writeHeader(w); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(w, "%s %s\n", g.metricName, formatValue(g.fn()))
+	return err
+}
+
+// Histogram counts observations, such as latencies, into buckets.
+type Histogram struct {
+	family
+	buckets []float64
+	mutex   sync.Mutex
+	series  map[string]*histogramSeries
+}
+
+type histogramSeries struct {
+	labelValues []string
+	counts      []uint64
+	count       uint64
+	sum         float64
+}
+
+// Observe records value in the series with labelValues.
+func (h *Histogram) Observe(value float64, labelValues ...string) {
+	key := h.key(labelValues)
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	if h.series == nil {
+		h.series = make(map[string]*histogramSeries)
+	}
+	s, ok := h.series[key]
+	if !ok {
+		s = &histogramSeries{
+			labelValues: append([]string(nil), labelValues...),
+			counts:      make([]uint64, len(h.buckets)),
+		}
+		h.series[key] = s
+	}
+
+	for i, bound := range h.buckets {
+		if value <= bound {
+			s.counts[i]++
+		}
+	}
+	s.count++
+	s.sum += value
+}
+
+// Count returns how many values the series with labelValues has observed.
+func (h *Histogram) Count(labelValues ...string) uint64 {
+	key := h.key(labelValues)
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	if s, ok := h.series[key]; ok {
+		return s.count
+	}
+	return 0
+}
+
+func (h *Histogram) write(w io.Writer) error {
+	if err := h.writeHeader(w); err != nil {
+		return err
+	}
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	for _, key := range sortedKeys(h.series) {
+		s := h.series[key]
+		for i, bound := range h.buckets {
+			if _, err := fmt.Fprintf(w, "%s_bucket%s %d\n", h.metricName,
+				h.labels(s.labelValues, "le", formatValue(bound)), s.counts[i]); err != nil {
+				return err
+			}
+		}
+		if _, err := fmt.Fprintf(w, "%s_bucket%s %d\n%s_sum%s %s\n%s_count%s %d\n",
+			h.metricName, h.labels(s.labelValues, "le", "+Inf"), s.count,
+			h.metricName, h.labels(s.labelValues), formatValue(s.sum),
+			h.metricName, h.labels(s.labelValues), s.count); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func sortedKeys[V any](series map[string]V) []string {
+	keys := make([]string, 0, len(series))
+	for key := range series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,128 @@
+package metrics
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func render(t *testing.T, registry *Registry) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := registry.Write(&buf); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	return buf.String()
+}
+
+func TestCounterExposition(t *testing.T) {
+	registry := NewRegistry()
+	counter := registry.NewCounter("test_commands_total", "Commands run.", "command")
+	counter.Inc("look")
+	counter.Inc("look")
+	counter.Add(3, "say")
+
+	expected := "# HELP test_commands_total Commands run.\n" +
+		"# TYPE test_commands_total counter\n" +
+		"test_commands_total{command=\"look\"} 2\n" +
+		"test_commands_total{command=\"say\"} 3\n"
+	if got := render(t, registry); got != expected {
+		t.Errorf("Unexpected output:\n%s\nwant:\n%s", got, expected)
+	}
+	if counter.Value("look") != 2 {
+		t.Errorf("Expected look count 2, got %v", counter.Value("look"))
+	}
+	if counter.Value("north") != 0 {
+		t.Errorf("Expected unseen series to be 0, got %v", counter.Value("north"))
+	}
+}
+
+func TestGaugeFuncReadsAtScrape(t *testing.T) {
+	registry := NewRegistry()
+	value := 1.0
+	registry.NewGaugeFunc("test_clients", "Connected clients.", func() float64 { return value })
+
+	value = 7
+	if got := render(t, registry); !strings.Contains(got, "test_clients 7\n") {
+		t.Errorf("Expected current gauge value, got:\n%s", got)
+	}
+}
+
+func TestHistogramExposition(t *testing.T) {
+	registry := NewRegistry()
+	histogram := registry.NewHistogram("test_latency_seconds", "Latency.", []float64{0.1, 1}, "op")
+	histogram.Observe(0.05, "select")
+	histogram.Observe(0.5, "select")
+	histogram.Observe(2, "select")
+
+	expected := "# HELP test_latency_seconds Latency.\n" +
+		"# TYPE test_latency_seconds histogram\n" +
+		"test_latency_seconds_bucket{op=\"select\",le=\"0.1\"} 1\n" +
+		"test_latency_seconds_bucket{op=\"select\",le=\"1\"} 2\n" +
+		"test_latency_seconds_bucket{op=\"select\",le=\"+Inf\"} 3\n" +
+		"test_latency_seconds_sum{op=\"select\"} 2.55\n" +
+		"test_latency_seconds_count{op=\"select\"} 3\n"
+	if got := render(t, registry); got != expected {
+		t.Errorf("Unexpected output:\n%s\nwant:\n%s", got, expected)
+	}
+	if histogram.Count("select") != 3 {
+		t.Errorf("Expected 3 observations, got %d", histogram.Count("select"))
+	}
+}
+
+func TestLabelValuesAreEscaped(t *testing.T) {
+	registry := NewRegistry()
+	registry.NewCounter("test_total", "Escaping.", "value").Inc("a\"b\\c\nd")
+
+	if got := render(t, registry); !strings.Contains(got, `test_total{value="a\"b\\c\nd"} 1`) {
+		t.Errorf("Expected escaped label, got:\n%s", got)
+	}
+}
+
+func TestDuplicateRegistrationPanics(t *testing.T) {
+	registry := NewRegistry()
+	registry.NewCounter("test_total", "First.")
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected registering a metric twice to panic")
+		}
+	}()
+	registry.NewCounter("test_total", "Second.")
+}
+
+func TestWrongLabelCountPanics(t *testing.T) {
+	counter := NewRegistry().NewCounter("test_total", "Labels.", "command")
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a missing label value to panic")
+		}
+	}()
+	counter.Inc()
+}
+
+func TestHandler(t *testing.T) {
+	registry := NewRegistry()
+	registry.NewCounter("test_total", "Served.").Inc()
+
+	recorder := httptest.NewRecorder()
+	registry.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", recorder.Code)
+	}
+	if !strings.HasPrefix(recorder.Header().Get("Content-Type"), "text/plain") {
+		t.Errorf("Unexpected content type %q", recorder.Header().Get("Content-Type"))
+	}
+	if !strings.Contains(recorder.Body.String(), "test_total 1\n") {
+		t.Errorf("Expected counter in body, got:\n%s", recorder.Body.String())
+	}
+
+	recorder = httptest.NewRecorder()
+	registry.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/metrics", nil))
+	if recorder.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected 405 for POST, got %d", recorder.Code)
+	}
+}
@@ -0,0 +1,46 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+// shutdownTimeout bounds how long Stop waits for an in-flight scrape
+const shutdownTimeout = 5 * time.Second
+
+// Server exposes a registry at /metrics.
+type Server struct {
+	registry *Registry
+	server   *http.Server
+}
+
+func NewServer(registry *Registry) *Server {
+	return &Server{registry: registry}
+}
+
+// Start listens on address and serves in the background.
+func (s *Server) Start(address string) error {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return fmt.Errorf("failed to start metrics listener: %w", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", s.registry.Handler())
+	s.server = &http.Server{Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+	go s.server.Serve(listener)
+	return nil
+}
+
+// Stop shuts the server down, waiting briefly for open requests.
+func (s *Server) Stop() error {
+	if s.server == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	return s.server.Shutdown(ctx)
+}
@@ -0,0 +1,10 @@
+package interfaces
+
+import "errors"
+
+var (
+	ErrCharacterNotFound = errors.New("character not found")
+	ErrNPCNotFound       = errors.New("npc state not found")
+	ErrPlayerNotFound    = errors.New("player not found")
+	ErrReportNotFound    = errors.New("report not found")
+)
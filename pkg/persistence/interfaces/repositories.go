@@ -1,6 +1,8 @@
 package interfaces
 
 import (
+	"time"
+	
 	"github.com/elidor/dungeogo/pkg/game/character"
 	"github.com/elidor/dungeogo/pkg/game/items"
 	"github.com/elidor/dungeogo/pkg/game/player"
@@ -14,25 +16,39 @@ type PlayerRepository interface {
 	UpdatePlayer(player *player.Player) error
 	UpdatePlayerLogin(playerID string) error
 	DeletePlayer(playerID string) error
+	RecordLogin(record *LoginRecord) error
+	GetRecentLogins(playerID string, limit int) ([]*LoginRecord, error)
 }
 
 type CharacterRepository interface {
 	CreateCharacter(character *character.Character) error
 	GetCharacter(characterID string) (*character.Character, error)
+	GetCharacterByName(name string) (*character.Character, error)
 	GetCharactersByPlayer(playerID string) ([]*CharacterSummary, error)
 	UpdateCharacter(character *character.Character) error
 	DeleteCharacter(characterID string) error
+	// ImportCharacter creates character and its carried items in one
+	// transaction, first deleting the character replaceID and its items if
+	// set
+	ImportCharacter(character *character.Character, carried []*items.ItemInstance, replaceID string) error
 	UpdateCharacterStats(characterID string, stats *character.CharacterStats) error
 	UpdateCharacterLocation(characterID string, location *character.Location) error
 	SaveCharacterSkills(characterID string, skills *character.SkillSet) error
+	GetLeaderboard(category LeaderboardCategory, limit int) ([]*LeaderboardEntry, error)
+	// RaceIDsInUse and ClassIDsInUse list the races and classes saved
+	// characters are
+	RaceIDsInUse() ([]string, error)
+	ClassIDsInUse() ([]string, error)
 }
 
 type ItemRepository interface {
 	CreateItemInstance(item *items.ItemInstance) error
+	CreateItemInstances(instances []*items.ItemInstance) error
 	GetItemInstance(itemID string) (*items.ItemInstance, error)
 	UpdateItemInstance(item *items.ItemInstance) error
 	DeleteItemInstance(itemID string) error
 	GetPlayerItems(characterID string) ([]*items.ItemInstance, error)
+	GetCarriedItems(characterID string) ([]*items.ItemInstance, error)
 	GetRoomItems(roomID string) ([]*items.ItemInstance, error)
 	TransferItem(itemID, newOwnerID string) error
 }
@@ -46,6 +62,16 @@ type WorldRepository interface {
 	GetActiveWorldEvents() ([]*WorldEvent, error)
 }
 
+// ReportRepository keeps players' reports about each other for moderators
+// to review
+type ReportRepository interface {
+	CreateReport(report *Report) error
+	GetReport(reportID int64) (*Report, error)
+	GetOpenReports(limit int) ([]*Report, error)
+	ResolveReport(reportID int64, resolvedBy, resolution string) error
+	CountReportsSince(reporterID string, since time.Time) (int, error)
+}
+
 type CharacterSummary struct {
 	ID         string
 	Name       string
@@ -53,17 +79,55 @@ type CharacterSummary struct {
 	Class      string
 	Level      int
 	Location   string
-	LastPlayed string
+	LastPlayed time.Time
 	IsAlive    bool
 }
 
+// LoginRecord is a single entry in an account's login history
+type LoginRecord struct {
+	PlayerID  string
+	IPAddress string
+	Success   bool
+	Reason    string
+	Timestamp time.Time
+}
+
+type LeaderboardCategory string
+
+const (
+	LeaderboardLevel    LeaderboardCategory = "level"
+	LeaderboardKills    LeaderboardCategory = "kills"
+	LeaderboardPlayTime LeaderboardCategory = "playtime"
+)
+
+// ParseLeaderboardCategory returns the category named by s, or false if
+// there is no such category.
+func ParseLeaderboardCategory(s string) (LeaderboardCategory, bool) {
+	switch LeaderboardCategory(s) {
+	case LeaderboardLevel, LeaderboardKills, LeaderboardPlayTime:
+		return LeaderboardCategory(s), true
+	}
+	return "", false
+}
+
+type LeaderboardEntry struct {
+	Rank       int
+	Name       string
+	Race       string
+	Class      string
+	Level      int
+	Experience int
+	KillCount  int
+	PlayTime   time.Duration
+}
+
 type RoomState struct {
 	ID          string
 	Items       []string
 	NPCs        []string
 	Players     []string
 	Flags       map[string]interface{}
-	LastUpdate  string
+	LastUpdate  time.Time
 }
 
 type NPCState struct {
@@ -73,22 +137,47 @@ type NPCState struct {
 	Location   *character.Location
 	Inventory  []string
 	State      string
-	LastUpdate string
+	LastUpdate time.Time
 }
 
 type WorldEvent struct {
 	ID          string
 	Type        string
 	Description string
-	StartTime   string
-	EndTime     string
+	StartTime   time.Time
+	EndTime     time.Time // zero for events with no end
 	Data        map[string]interface{}
 }
 
+// Report is a player's complaint about another player. Names are kept as
+// they were when the report was made, so it still reads the same if either
+// character is renamed or deleted.
+type Report struct {
+	ID           int64
+	ReporterID   string
+	ReporterName string
+	TargetID     string
+	TargetName   string
+	Reason       string
+	// RoomID is where the reporter was when they made the report
+	RoomID    string
+	CreatedAt time.Time
+	// ResolvedAt is zero while the report is open
+	ResolvedAt time.Time
+	ResolvedBy string
+	Resolution string
+}
+
+// IsOpen reports whether no moderator has resolved the report yet
+func (r *Report) IsOpen() bool {
+	return r.ResolvedAt.IsZero()
+}
+
 type RepositoryManager interface {
 	Players() PlayerRepository
 	Characters() CharacterRepository
 	Items() ItemRepository
 	World() WorldRepository
+	Reports() ReportRepository
 	Close() error
 }
\ No newline at end of file
@@ -7,18 +7,76 @@ import (
 	"time"
 	
 	"github.com/elidor/dungeogo/pkg/game/character"
+	"github.com/elidor/dungeogo/pkg/game/items"
 	"github.com/elidor/dungeogo/pkg/persistence/interfaces"
+	"github.com/lib/pq"
 )
 
 type CharacterRepository struct {
 	db *sql.DB
+	// stmts holds the prepared queries of the hot paths
+	stmts *statements
 }
 
+// characterColumns lists the columns read by scanCharacter, in scan order
+const characterColumns = `id, player_id, name, race_id, class_id, stats, skills, location,
+			state, created_at, last_played, EXTRACT(EPOCH FROM play_time)::BIGINT, level, experience,
+			death_count, kill_count, description, appearance, tutorial_step, gold, quests,
+			equipment, explored, title, reputation, practices, pvp, achievements, spellbook, stat_points, profile`
+
 func NewCharacterRepository(db *sql.DB) *CharacterRepository {
-	return &CharacterRepository{db: db}
+	return &CharacterRepository{db: db, stmts: newStatements(db)}
+}
+
+// execer is a *sql.DB or *sql.Tx
+type execer interface {
+	Exec(query string, args ...any) (sql.Result, error)
 }
 
 func (r *CharacterRepository) CreateCharacter(c *character.Character) error {
+	return insertCharacter(r.db, c)
+}
+
+// ImportCharacter creates c and its carried items in one transaction. If
+// replaceID is set, that character and the items it owns are deleted first,
+// so a failed import leaves it untouched.
+func (r *CharacterRepository) ImportCharacter(c *character.Character, carried []*items.ItemInstance, replaceID string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin character import: %w", err)
+	}
+	defer tx.Rollback()
+	
+	if replaceID != "" {
+		if _, err := tx.Exec(`DELETE FROM item_instances WHERE owner_id = $1`, replaceID); err != nil {
+			return fmt.Errorf("failed to remove items of character being replaced: %w", err)
+		}
+		if _, err := tx.Exec(`DELETE FROM characters WHERE id = $1`, replaceID); err != nil {
+			return fmt.Errorf("failed to remove character being replaced: %w", err)
+		}
+	}
+	
+	if err := insertCharacter(tx, c); err != nil {
+		return err
+	}
+	for start := 0; start < len(carried); start += itemInsertBatchSize {
+		query, args, err := buildItemInsert(carried[start:min(start+itemInsertBatchSize, len(carried))])
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(query, args...); err != nil {
+			return fmt.Errorf("failed to create item instances: %w", err)
+		}
+	}
+	
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit character import: %w", err)
+	}
+	return nil
+}
+
+// insertCharacter inserts c through exec
+func insertCharacter(exec execer, c *character.Character) error {
 	statsJSON, err := json.Marshal(c.Stats)
 	if err != nil {
 		return fmt.Errorf("failed to marshal stats: %w", err)
@@ -39,6 +97,36 @@ func (r *CharacterRepository) CreateCharacter(c *character.Character) error {
 		return fmt.Errorf("failed to marshal appearance: %w", err)
 	}
 	
+	questsJSON, err := json.Marshal(c.Quests)
+	if err != nil {
+		return fmt.Errorf("failed to marshal quests: %w", err)
+	}
+	
+	equipmentJSON, err := json.Marshal(c.EquipmentIDs())
+	if err != nil {
+		return fmt.Errorf("failed to marshal equipment: %w", err)
+	}
+	
+	exploredJSON, err := json.Marshal(c.Explored)
+	if err != nil {
+		return fmt.Errorf("failed to marshal explored rooms: %w", err)
+	}
+	
+	reputationJSON, err := json.Marshal(c.Reputation)
+	if err != nil {
+		return fmt.Errorf("failed to marshal reputation: %w", err)
+	}
+	
+	achievementsJSON, err := json.Marshal(c.Achievements)
+	if err != nil {
+		return fmt.Errorf("failed to marshal achievements: %w", err)
+	}
+	
+	spellbookJSON, err := json.Marshal(c.Spellbook)
+	if err != nil {
+		return fmt.Errorf("failed to marshal spellbook: %w", err)
+	}
+	
 	var raceID, classID string
 	if c.Race != nil {
 		raceID = c.Race.ID
@@ -50,13 +138,16 @@ func (r *CharacterRepository) CreateCharacter(c *character.Character) error {
 	query := `
 		INSERT INTO characters (id, player_id, name, race_id, class_id, stats, 
 			skills, location, state, created_at, last_played, play_time, level, 
-			experience, death_count, kill_count, description, appearance)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)`
+			experience, death_count, kill_count, description, appearance, tutorial_step,
+			gold, quests, equipment, explored, title, reputation, practices, pvp, achievements, spellbook, stat_points, profile)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, make_interval(secs => $12), $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28, $29, $30, $31)`
 	
-	_, err = r.db.Exec(query, c.ID, c.PlayerID, c.Name, raceID, classID,
+	_, err = exec.Exec(query, c.ID, c.PlayerID, c.Name, raceID, classID,
 		statsJSON, skillsJSON, locationJSON, int(c.State), c.CreatedAt,
-		c.LastPlayed, c.PlayTime, c.Level, c.Experience, c.DeathCount,
-		c.KillCount, c.Description, appearanceJSON)
+		c.LastPlayed, c.PlayTime.Seconds(), c.Level, c.Experience, c.DeathCount,
+		c.KillCount, c.Description, appearanceJSON, c.TutorialStep, c.Gold, questsJSON,
+		equipmentJSON, exploredJSON, c.Title, reputationJSON, c.Practices, c.PvP, achievementsJSON,
+		spellbookJSON, c.StatPoints, c.Profile)
 	
 	if err != nil {
 		return fmt.Errorf("failed to create character: %w", err)
@@ -66,38 +157,71 @@ func (r *CharacterRepository) CreateCharacter(c *character.Character) error {
 }
 
 func (r *CharacterRepository) GetCharacter(characterID string) (*character.Character, error) {
-	query := `
-		SELECT id, player_id, name, race_id, class_id, stats, skills, location,
-			state, created_at, last_played, play_time, level, experience,
-			death_count, kill_count, description, appearance
-		FROM characters WHERE id = $1`
+	stmt, err := r.stmts.prepare(`SELECT ` + characterColumns + ` FROM characters WHERE id = $1`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get character: %w", err)
+	}
+	
+	c, err := r.scanCharacter(stmt.QueryRow(characterID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%w: %s", interfaces.ErrCharacterNotFound, characterID)
+		}
+		return nil, fmt.Errorf("failed to get character: %w", err)
+	}
+	
+	return c, nil
+}
+
+// GetCharacterByName finds a character by name, ignoring case. A missing
+// character is reported with interfaces.ErrCharacterNotFound.
+func (r *CharacterRepository) GetCharacterByName(name string) (*character.Character, error) {
+	query := `SELECT ` + characterColumns + ` FROM characters WHERE LOWER(name) = LOWER($1)`
+	
+	c, err := r.scanCharacter(r.db.QueryRow(query, name))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%w: %s", interfaces.ErrCharacterNotFound, name)
+		}
+		return nil, fmt.Errorf("failed to get character by name: %w", err)
+	}
 	
+	return c, nil
+}
+
+// scanCharacter reads a row selected with characterColumns into a Character
+func (r *CharacterRepository) scanCharacter(row *sql.Row) (*character.Character, error) {
 	c := &character.Character{}
 	var raceID, classID string
-	var statsJSON, skillsJSON, locationJSON, appearanceJSON []byte
+	var statsJSON, skillsJSON, locationJSON, appearanceJSON, questsJSON, equipmentJSON, exploredJSON, reputationJSON, achievementsJSON, spellbookJSON []byte
 	var state int
+	var playSeconds int64
 	
-	err := r.db.QueryRow(query, characterID).Scan(
+	err := row.Scan(
 		&c.ID, &c.PlayerID, &c.Name, &raceID, &classID, &statsJSON,
 		&skillsJSON, &locationJSON, &state, &c.CreatedAt, &c.LastPlayed,
-		&c.PlayTime, &c.Level, &c.Experience, &c.DeathCount, &c.KillCount,
-		&c.Description, &appearanceJSON)
-	
+		&playSeconds, &c.Level, &c.Experience, &c.DeathCount, &c.KillCount,
+		&c.Description, &appearanceJSON, &c.TutorialStep, &c.Gold, &questsJSON,
+		&equipmentJSON, &exploredJSON, &c.Title, &reputationJSON, &c.Practices, &c.PvP, &achievementsJSON,
+		&spellbookJSON, &c.StatPoints, &c.Profile)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("character not found: %s", characterID)
-		}
-		return nil, fmt.Errorf("failed to get character: %w", err)
+		return nil, err
 	}
 	
 	c.State = character.CharacterState(state)
+	c.PlayTime = time.Duration(playSeconds) * time.Second
 	
-	// Load race and class
+	// Load race and class; a character whose race or class is gone can't
+	// be played, so report it rather than hand back half a character
 	if raceID != "" {
-		c.Race, _ = character.GetRaceByID(raceID)
+		if c.Race, err = character.GetRaceByID(raceID); err != nil {
+			return nil, fmt.Errorf("failed to load race of %s: %w", c.Name, err)
+		}
 	}
 	if classID != "" {
-		c.Class, _ = character.GetClassByID(classID)
+		if c.Class, err = character.GetClassByID(classID); err != nil {
+			return nil, fmt.Errorf("failed to load class of %s: %w", c.Name, err)
+		}
 	}
 	
 	// Unmarshal JSON fields
@@ -117,6 +241,30 @@ func (r *CharacterRepository) GetCharacter(characterID string) (*character.Chara
 		return nil, fmt.Errorf("failed to unmarshal appearance: %w", err)
 	}
 	
+	if err := json.Unmarshal(questsJSON, &c.Quests); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal quests: %w", err)
+	}
+	
+	if err := json.Unmarshal(achievementsJSON, &c.Achievements); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal achievements: %w", err)
+	}
+	
+	if err := json.Unmarshal(spellbookJSON, &c.Spellbook); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal spellbook: %w", err)
+	}
+	
+	if err := json.Unmarshal(exploredJSON, &c.Explored); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal explored rooms: %w", err)
+	}
+	
+	if err := json.Unmarshal(reputationJSON, &c.Reputation); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal reputation: %w", err)
+	}
+	
+	if err := r.loadEquipment(c, equipmentJSON); err != nil {
+		return nil, err
+	}
+	
 	return c, nil
 }
 
@@ -187,15 +335,50 @@ func (r *CharacterRepository) UpdateCharacter(c *character.Character) error {
 		return fmt.Errorf("failed to marshal appearance: %w", err)
 	}
 	
+	questsJSON, err := json.Marshal(c.Quests)
+	if err != nil {
+		return fmt.Errorf("failed to marshal quests: %w", err)
+	}
+	
+	equipmentJSON, err := json.Marshal(c.EquipmentIDs())
+	if err != nil {
+		return fmt.Errorf("failed to marshal equipment: %w", err)
+	}
+	
+	exploredJSON, err := json.Marshal(c.Explored)
+	if err != nil {
+		return fmt.Errorf("failed to marshal explored rooms: %w", err)
+	}
+	
+	reputationJSON, err := json.Marshal(c.Reputation)
+	if err != nil {
+		return fmt.Errorf("failed to marshal reputation: %w", err)
+	}
+	
+	achievementsJSON, err := json.Marshal(c.Achievements)
+	if err != nil {
+		return fmt.Errorf("failed to marshal achievements: %w", err)
+	}
+	
+	spellbookJSON, err := json.Marshal(c.Spellbook)
+	if err != nil {
+		return fmt.Errorf("failed to marshal spellbook: %w", err)
+	}
+	
 	query := `
 		UPDATE characters SET stats = $2, skills = $3, location = $4, state = $5,
-			last_played = $6, play_time = $7, level = $8, experience = $9,
-			death_count = $10, kill_count = $11, description = $12, appearance = $13
+			last_played = $6, play_time = make_interval(secs => $7), level = $8, experience = $9,
+			death_count = $10, kill_count = $11, description = $12, appearance = $13,
+			tutorial_step = $14, gold = $15, quests = $16,
+			equipment = $17, explored = $18, title = $19, reputation = $20, practices = $21,
+			pvp = $22, achievements = $23, spellbook = $24, stat_points = $25, profile = $26
 		WHERE id = $1`
 	
 	_, err = r.db.Exec(query, c.ID, statsJSON, skillsJSON, locationJSON,
-		int(c.State), c.LastPlayed, c.PlayTime, c.Level, c.Experience,
-		c.DeathCount, c.KillCount, c.Description, appearanceJSON)
+		int(c.State), c.LastPlayed, c.PlayTime.Seconds(), c.Level, c.Experience,
+		c.DeathCount, c.KillCount, c.Description, appearanceJSON, c.TutorialStep,
+		c.Gold, questsJSON, equipmentJSON, exploredJSON, c.Title, reputationJSON, c.Practices, c.PvP,
+		achievementsJSON, spellbookJSON, c.StatPoints, c.Profile)
 	
 	if err != nil {
 		return fmt.Errorf("failed to update character: %w", err)
@@ -204,6 +387,133 @@ func (r *CharacterRepository) UpdateCharacter(c *character.Character) error {
 	return nil
 }
 
+// leaderboardOrder maps each leaderboard category to its ORDER BY clause.
+// Ties are broken by name so rankings are stable.
+var leaderboardOrder = map[interfaces.LeaderboardCategory]string{
+	interfaces.LeaderboardLevel:    "level DESC, experience DESC, name",
+	interfaces.LeaderboardKills:    "kill_count DESC, level DESC, name",
+	interfaces.LeaderboardPlayTime: "play_time DESC, name",
+}
+
+func (r *CharacterRepository) GetLeaderboard(category interfaces.LeaderboardCategory, limit int) ([]*interfaces.LeaderboardEntry, error) {
+	order, ok := leaderboardOrder[category]
+	if !ok {
+		return nil, fmt.Errorf("unknown leaderboard category: %s", category)
+	}
+	
+	query := `
+		SELECT name, race_id, class_id, level, experience, kill_count,
+			EXTRACT(EPOCH FROM play_time)::BIGINT
+		FROM characters ORDER BY ` + order + ` LIMIT $1`
+	
+	rows, err := r.db.Query(query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get leaderboard: %w", err)
+	}
+	defer rows.Close()
+	
+	var entries []*interfaces.LeaderboardEntry
+	for rows.Next() {
+		entry := &interfaces.LeaderboardEntry{Rank: len(entries) + 1}
+		var raceID, classID string
+		var playSeconds int64
+		
+		err := rows.Scan(&entry.Name, &raceID, &classID, &entry.Level,
+			&entry.Experience, &entry.KillCount, &playSeconds)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan leaderboard entry: %w", err)
+		}
+		
+		if race, err := character.GetRaceByID(raceID); err == nil {
+			entry.Race = race.Name
+		}
+		if class, err := character.GetClassByID(classID); err == nil {
+			entry.Class = class.Name
+		}
+		entry.PlayTime = time.Duration(playSeconds) * time.Second
+		
+		entries = append(entries, entry)
+	}
+	
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read leaderboard: %w", err)
+	}
+	
+	return entries, nil
+}
+
+// RaceIDsInUse returns the ID of every race a saved character is
+func (r *CharacterRepository) RaceIDsInUse() ([]string, error) {
+	return r.idsInUse("race_id")
+}
+
+// ClassIDsInUse returns the ID of every class a saved character is
+func (r *CharacterRepository) ClassIDsInUse() ([]string, error) {
+	return r.idsInUse("class_id")
+}
+
+// idsInUse returns the distinct values of column, one of the characters
+// table's own columns
+func (r *CharacterRepository) idsInUse(column string) ([]string, error) {
+	rows, err := r.db.Query(`SELECT DISTINCT ` + column + ` FROM characters WHERE ` + column + ` <> ''`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s in use: %w", column, err)
+	}
+	defer rows.Close()
+	
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan %s: %w", column, err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list %s in use: %w", column, err)
+	}
+	return ids, nil
+}
+
+// loadEquipment rehydrates equipped items from their stored IDs in one
+// query. Items that no longer exist or have changed hands are dropped from
+// the slot.
+func (r *CharacterRepository) loadEquipment(c *character.Character, equipmentJSON []byte) error {
+	var ids map[items.EquipSlot]string
+	if err := json.Unmarshal(equipmentJSON, &ids); err != nil {
+		return fmt.Errorf("failed to unmarshal equipment: %w", err)
+	}
+	
+	c.Equipment = make(map[items.EquipSlot]*items.ItemInstance, len(ids))
+	if len(ids) == 0 {
+		return nil
+	}
+	itemIDs := make([]string, 0, len(ids))
+	for _, itemID := range ids {
+		itemIDs = append(itemIDs, itemID)
+	}
+	
+	query := `
+		SELECT id, template_id, owner_id, quantity, durability, enchantments,
+			custom_name, modifications, created_at, last_used
+		FROM item_instances WHERE owner_id = $1 AND id::text = ANY($2)`
+	worn, err := NewItemRepository(r.db).queryItems(query, c.ID, pq.Array(itemIDs))
+	if err != nil {
+		return fmt.Errorf("failed to load equipment: %w", err)
+	}
+	byID := make(map[string]*items.ItemInstance, len(worn))
+	for _, item := range worn {
+		byID[item.ID] = item
+	}
+	for slot, itemID := range ids {
+		if item, ok := byID[itemID]; ok {
+			c.Equipment[slot] = item
+		}
+	}
+	
+	return nil
+}
+
 func (r *CharacterRepository) DeleteCharacter(characterID string) error {
 	query := `DELETE FROM characters WHERE id = $1`
 	_, err := r.db.Exec(query, characterID)
@@ -1,9 +1,16 @@
 package postgres
 
 import (
+	"errors"
 	"testing"
+	"time"
+
+	"github.com/google/uuid"
 
 	"github.com/elidor/dungeogo/pkg/game/character"
+	"github.com/elidor/dungeogo/pkg/game/faction"
+	"github.com/elidor/dungeogo/pkg/game/items"
+	"github.com/elidor/dungeogo/pkg/persistence/interfaces"
 )
 
 func TestCharacterRepository_CreateCharacter(t *testing.T) {
@@ -407,5 +414,361 @@ func TestCharacterRepository_UniqueNameConstraint(t *testing.T) {
 	if err == nil {
 		t.Errorf("Expected error when creating character with duplicate name")
 	}
+
+	// Nor may a name differ only in case
+	char3 := createTestCharacter(testPlayer.ID)
+	char3.Name = "uniquecharacter"
+	if err := repo.CreateCharacter(char3); err == nil {
+		t.Errorf("Expected error when creating character whose name differs only in case")
+	}
+}
+
+
+func TestCharacterRepository_TutorialProgress(t *testing.T) {
+	repoManager := setupTestDB(t)
+	if repoManager == nil {
+		t.Skip("Database not available for testing")
+	}
+
+	testPlayer := createTestPlayer()
+	if err := repoManager.Players().CreatePlayer(testPlayer); err != nil {
+		t.Fatalf("Failed to create test player: %v", err)
+	}
+
+	repo := repoManager.Characters()
+	testChar := createTestCharacter(testPlayer.ID)
+	testChar.StartTutorial()
+
+	if err := repo.CreateCharacter(testChar); err != nil {
+		t.Fatalf("Failed to create character: %v", err)
+	}
+
+	testChar.TutorialStep = 3
+	if err := repo.UpdateCharacter(testChar); err != nil {
+		t.Fatalf("Failed to update character: %v", err)
+	}
+
+	retrieved, err := repo.GetCharacter(testChar.ID)
+	if err != nil {
+		t.Fatalf("Failed to retrieve character: %v", err)
+	}
+
+	if retrieved.TutorialStep != 3 {
+		t.Errorf("Expected tutorial step 3, got %d", retrieved.TutorialStep)
+	}
+
+	if retrieved.Location.RoomID != character.TutorialRoomID {
+		t.Errorf("Expected tutorial room, got %s", retrieved.Location.RoomID)
+	}
+}
+
+func TestCharacterRepository_EquipmentPersistence(t *testing.T) {
+	repoManager := setupTestDB(t)
+	if repoManager == nil {
+		t.Skip("Database not available for testing")
+	}
+
+	testPlayer := createTestPlayer()
+	if err := repoManager.Players().CreatePlayer(testPlayer); err != nil {
+		t.Fatalf("Failed to create test player: %v", err)
+	}
+
+	repo := repoManager.Characters()
+	testChar := createTestCharacter(testPlayer.ID)
+	if err := repo.CreateCharacter(testChar); err != nil {
+		t.Fatalf("Failed to create character: %v", err)
+	}
+
+	sword := createTestItemInstance()
+	sword.TemplateID = "rusty_sword"
+	sword.OwnerID = testChar.ID
+	if err := repoManager.Items().CreateItemInstance(sword); err != nil {
+		t.Fatalf("Failed to create sword: %v", err)
+	}
+
+	testChar.Equip(items.SlotMainHand, sword)
+	if err := repo.UpdateCharacter(testChar); err != nil {
+		t.Fatalf("Failed to update character: %v", err)
+	}
+
+	retrieved, err := repo.GetCharacter(testChar.ID)
+	if err != nil {
+		t.Fatalf("Failed to retrieve character: %v", err)
+	}
+
+	worn := retrieved.Equipment[items.SlotMainHand]
+	if worn == nil {
+		t.Fatalf("Expected sword to still be wielded after reload")
+	}
+
+	if worn.ID != sword.ID || worn.TemplateID != "rusty_sword" {
+		t.Errorf("Expected rehydrated sword %s, got %s (%s)", sword.ID, worn.ID, worn.TemplateID)
+	}
+
+	// Items that changed hands are no longer worn
+	if err := repoManager.Items().TransferItem(sword.ID, uuid.New().String()); err != nil {
+		t.Fatalf("Failed to transfer sword: %v", err)
+	}
+
+	retrieved, err = repo.GetCharacter(testChar.ID)
+	if err != nil {
+		t.Fatalf("Failed to retrieve character: %v", err)
+	}
+
+	if retrieved.Equipment[items.SlotMainHand] != nil {
+		t.Errorf("Expected transferred sword to be dropped from equipment")
+	}
+}
+
+func TestCharacterRepository_GetLeaderboard(t *testing.T) {
+	repoManager := setupTestDB(t)
+	if repoManager == nil {
+		t.Skip("Database not available for testing")
+	}
+
+	testPlayer := createTestPlayer()
+	if err := repoManager.Players().CreatePlayer(testPlayer); err != nil {
+		t.Fatalf("Failed to create test player: %v", err)
+	}
+
+	repo := repoManager.Characters()
+	for i, name := range []string{"Novice", "Veteran", "Slayer"} {
+		char := createTestCharacter(testPlayer.ID)
+		char.Name = name
+		char.Level = i + 1
+		if name == "Slayer" {
+			char.Level = 1
+			char.KillCount = 50
+		}
+		if err := repo.CreateCharacter(char); err != nil {
+			t.Fatalf("Failed to create character %s: %v", name, err)
+		}
+	}
+
+	entries, err := repo.GetLeaderboard(interfaces.LeaderboardLevel, 2)
+	if err != nil {
+		t.Fatalf("Failed to get leaderboard: %v", err)
+	}
+
+	if len(entries) != 2 {
+		t.Fatalf("Expected limit of 2 entries, got %d", len(entries))
+	}
+
+	if entries[0].Name != "Veteran" || entries[0].Rank != 1 {
+		t.Errorf("Expected Veteran ranked first by level, got %s (rank %d)", entries[0].Name, entries[0].Rank)
+	}
+
+	entries, err = repo.GetLeaderboard(interfaces.LeaderboardKills, 10)
+	if err != nil {
+		t.Fatalf("Failed to get kills leaderboard: %v", err)
+	}
+
+	if len(entries) != 3 || entries[0].Name != "Slayer" {
+		t.Errorf("Expected Slayer ranked first by kills, got %v", entries)
+	}
+
+	if _, err := repo.GetLeaderboard("gold", 10); err == nil {
+		t.Errorf("Expected error for unknown category")
+	}
 }
 
+func TestCharacterRepository_GetCharacterByName(t *testing.T) {
+	repoManager := setupTestDB(t)
+	if repoManager == nil {
+		t.Skip("Database not available for testing")
+	}
+
+	testPlayer := createTestPlayer()
+	if err := repoManager.Players().CreatePlayer(testPlayer); err != nil {
+		t.Fatalf("Failed to create test player: %v", err)
+	}
+
+	repo := repoManager.Characters()
+	testChar := createTestCharacter(testPlayer.ID)
+	testChar.Name = "Gandalf"
+	if err := repo.CreateCharacter(testChar); err != nil {
+		t.Fatalf("Failed to create character: %v", err)
+	}
+
+	for _, name := range []string{"Gandalf", "gandalf", "GANDALF"} {
+		retrieved, err := repo.GetCharacterByName(name)
+		if err != nil {
+			t.Errorf("Failed to find character by name %q: %v", name, err)
+			continue
+		}
+		if retrieved.ID != testChar.ID {
+			t.Errorf("Expected character %s for %q, got %s", testChar.ID, name, retrieved.ID)
+		}
+	}
+
+	_, err := repo.GetCharacterByName("Saruman")
+	if !errors.Is(err, interfaces.ErrCharacterNotFound) {
+		t.Errorf("Expected ErrCharacterNotFound, got %v", err)
+	}
+}
+
+func TestCharacterRepository_TimeFields(t *testing.T) {
+	repoManager := setupTestDB(t)
+	if repoManager == nil {
+		t.Skip("Database not available for testing")
+	}
+
+	testPlayer := createTestPlayer()
+	if err := repoManager.Players().CreatePlayer(testPlayer); err != nil {
+		t.Fatalf("Failed to create test player: %v", err)
+	}
+
+	repo := repoManager.Characters()
+	testChar := createTestCharacter(testPlayer.ID)
+	testChar.PlayTime = 90 * time.Minute
+	testChar.LastPlayed = time.Now().Add(-2 * time.Hour).Truncate(time.Second)
+	if err := repo.CreateCharacter(testChar); err != nil {
+		t.Fatalf("Failed to create character: %v", err)
+	}
+
+	retrieved, err := repo.GetCharacter(testChar.ID)
+	if err != nil {
+		t.Fatalf("Failed to retrieve character: %v", err)
+	}
+
+	if retrieved.PlayTime != testChar.PlayTime {
+		t.Errorf("Expected play time %v, got %v", testChar.PlayTime, retrieved.PlayTime)
+	}
+
+	summaries, err := repo.GetCharactersByPlayer(testPlayer.ID)
+	if err != nil || len(summaries) != 1 {
+		t.Fatalf("Failed to list characters: %v", err)
+	}
+
+	if !summaries[0].LastPlayed.Equal(testChar.LastPlayed) {
+		t.Errorf("Expected last played %v, got %v", testChar.LastPlayed, summaries[0].LastPlayed)
+	}
+}
+
+func TestCharacterRepository_ExploredPersistence(t *testing.T) {
+	repoManager := setupTestDB(t)
+	if repoManager == nil {
+		t.Skip("Database not available for testing")
+	}
+	
+	testPlayer := createTestPlayer()
+	if err := repoManager.Players().CreatePlayer(testPlayer); err != nil {
+		t.Fatalf("Failed to create test player: %v", err)
+	}
+	
+	repo := repoManager.Characters()
+	testChar := createTestCharacter(testPlayer.ID)
+	if err := repo.CreateCharacter(testChar); err != nil {
+		t.Fatalf("Failed to create character: %v", err)
+	}
+	
+	testChar.Explore("riverbank")
+	if err := repo.UpdateCharacter(testChar); err != nil {
+		t.Fatalf("Failed to update character: %v", err)
+	}
+	
+	retrieved, err := repo.GetCharacter(testChar.ID)
+	if err != nil {
+		t.Fatalf("Failed to retrieve character: %v", err)
+	}
+	if !retrieved.HasExplored("riverbank") || retrieved.HasExplored("storeroom") {
+		t.Errorf("Expected only riverbank to be explored, got %v", retrieved.Explored)
+	}
+}
+
+func TestCharacterRepository_TitlePersistence(t *testing.T) {
+	repoManager := setupTestDB(t)
+	if repoManager == nil {
+		t.Skip("Database not available for testing")
+	}
+	
+	testPlayer := createTestPlayer()
+	if err := repoManager.Players().CreatePlayer(testPlayer); err != nil {
+		t.Fatalf("Failed to create test player: %v", err)
+	}
+	
+	repo := repoManager.Characters()
+	testChar := createTestCharacter(testPlayer.ID)
+	if err := repo.CreateCharacter(testChar); err != nil {
+		t.Fatalf("Failed to create character: %v", err)
+	}
+	
+	testChar.Title = "the Brave"
+	if err := repo.UpdateCharacter(testChar); err != nil {
+		t.Fatalf("Failed to update character: %v", err)
+	}
+	
+	retrieved, err := repo.GetCharacter(testChar.ID)
+	if err != nil {
+		t.Fatalf("Failed to retrieve character: %v", err)
+	}
+	if retrieved.Title != "the Brave" {
+		t.Errorf("Expected title 'the Brave', got %q", retrieved.Title)
+	}
+}
+
+func TestCharacterRepository_ReputationPersistence(t *testing.T) {
+	repoManager := setupTestDB(t)
+	if repoManager == nil {
+		t.Skip("Database not available for testing")
+	}
+	
+	testPlayer := createTestPlayer()
+	if err := repoManager.Players().CreatePlayer(testPlayer); err != nil {
+		t.Fatalf("Failed to create test player: %v", err)
+	}
+	
+	repo := repoManager.Characters()
+	testChar := createTestCharacter(testPlayer.ID)
+	if err := repo.CreateCharacter(testChar); err != nil {
+		t.Fatalf("Failed to create character: %v", err)
+	}
+	
+	testChar.AdjustReputation(faction.Militia, 150)
+	if err := repo.UpdateCharacter(testChar); err != nil {
+		t.Fatalf("Failed to update character: %v", err)
+	}
+	
+	retrieved, err := repo.GetCharacter(testChar.ID)
+	if err != nil {
+		t.Fatalf("Failed to retrieve character: %v", err)
+	}
+	if got := retrieved.ReputationWith(faction.Militia); got != 150 {
+		t.Errorf("Expected militia reputation 150, got %d", got)
+	}
+}
+
+// BenchmarkGetCharacter compares loading a character with a query parsed
+// on every call against the prepared statement GetCharacter reuses
+func BenchmarkGetCharacter(b *testing.B) {
+	repoManager := setupTestDB(b)
+	if repoManager == nil {
+		b.Skip("Database not available for testing")
+	}
+	
+	testPlayer := createTestPlayer()
+	if err := repoManager.Players().CreatePlayer(testPlayer); err != nil {
+		b.Fatalf("Failed to create test player: %v", err)
+	}
+	repo := repoManager.characterRepo
+	testChar := createTestCharacter(testPlayer.ID)
+	if err := repo.CreateCharacter(testChar); err != nil {
+		b.Fatalf("Failed to create character: %v", err)
+	}
+	
+	b.Run("unprepared", func(b *testing.B) {
+		query := `SELECT ` + characterColumns + ` FROM characters WHERE id = $1`
+		for i := 0; i < b.N; i++ {
+			if _, err := repo.scanCharacter(repo.db.QueryRow(query, testChar.ID)); err != nil {
+				b.Fatalf("Failed to get character: %v", err)
+			}
+		}
+	})
+	b.Run("prepared", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := repo.GetCharacter(testChar.ID); err != nil {
+				b.Fatalf("Failed to get character: %v", err)
+			}
+		}
+	})
+}
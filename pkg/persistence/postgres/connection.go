@@ -1,11 +1,14 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"sync/atomic"
 	
 	"github.com/elidor/dungeogo/pkg/persistence/interfaces"
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 )
 
 type PostgreSQLRepositoryManager struct {
@@ -14,26 +17,41 @@ type PostgreSQLRepositoryManager struct {
 	characterRepo    *CharacterRepository
 	itemRepo         *ItemRepository
 	worldRepo        *WorldRepository
+	reportRepo       *ReportRepository
+	observer         atomic.Pointer[QueryObserver]
 }
 
 func NewPostgreSQLRepositoryManager(databaseURL string) (*PostgreSQLRepositoryManager, error) {
-	db, err := sql.Open("postgres", databaseURL)
+	return NewPostgreSQLRepositoryManagerWithPool(databaseURL, DefaultPoolConfig())
+}
+
+// NewPostgreSQLRepositoryManagerWithPool connects to the database and sizes
+// its connection pool from pool, which must be valid.
+func NewPostgreSQLRepositoryManagerWithPool(databaseURL string, pool PoolConfig) (*PostgreSQLRepositoryManager, error) {
+	if err := pool.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid connection pool config: %w", err)
+	}
+	
+	connector, err := pq.NewConnector(databaseURL)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 	
+	manager := &PostgreSQLRepositoryManager{}
+	db := sql.OpenDB(&observedConnector{base: connector, observer: &manager.observer})
+	pool.apply(db)
+	
 	if err := db.Ping(); err != nil {
+		db.Close()
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
-	
-	manager := &PostgreSQLRepositoryManager{
-		db: db,
-	}
+	manager.db = db
 	
 	manager.playerRepo = NewPlayerRepository(db)
 	manager.characterRepo = NewCharacterRepository(db)
 	manager.itemRepo = NewItemRepository(db)
 	manager.worldRepo = NewWorldRepository(db)
+	manager.reportRepo = NewReportRepository(db)
 	
 	return manager, nil
 }
@@ -54,8 +72,39 @@ func (m *PostgreSQLRepositoryManager) World() interfaces.WorldRepository {
 	return m.worldRepo
 }
 
+func (m *PostgreSQLRepositoryManager) Reports() interfaces.ReportRepository {
+	return m.reportRepo
+}
+
+// SetQueryObserver times every statement the repositories run. Pass nil to
+// stop observing.
+func (m *PostgreSQLRepositoryManager) SetQueryObserver(observer QueryObserver) {
+	if observer == nil {
+		m.observer.Store(nil)
+		return
+	}
+	m.observer.Store(&observer)
+}
+
+// HealthCheck pings the database, opening a fresh connection if the pooled
+// ones have gone stale.
+func (m *PostgreSQLRepositoryManager) HealthCheck(ctx context.Context) error {
+	if err := m.db.PingContext(ctx); err != nil {
+		return fmt.Errorf("database health check failed: %w", err)
+	}
+	return nil
+}
+
+// Close closes the repositories' prepared statements and then the database.
 func (m *PostgreSQLRepositoryManager) Close() error {
-	return m.db.Close()
+	err := errors.Join(m.characterRepo.stmts.Close(), m.itemRepo.stmts.Close())
+	if closeErr := m.db.Close(); closeErr != nil {
+		return closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to close prepared statements: %w", err)
+	}
+	return nil
 }
 
 // GetDB returns the underlying database connection for testing
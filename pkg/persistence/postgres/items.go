@@ -4,16 +4,19 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"strings"
 	
 	"github.com/elidor/dungeogo/pkg/game/items"
 )
 
 type ItemRepository struct {
 	db *sql.DB
+	// stmts holds the prepared queries of the hot paths
+	stmts *statements
 }
 
 func NewItemRepository(db *sql.DB) *ItemRepository {
-	return &ItemRepository{db: db}
+	return &ItemRepository{db: db, stmts: newStatements(db)}
 }
 
 func (r *ItemRepository) CreateItemInstance(item *items.ItemInstance) error {
@@ -43,6 +46,86 @@ func (r *ItemRepository) CreateItemInstance(item *items.ItemInstance) error {
 	return nil
 }
 
+// itemInsertBatchSize caps rows per INSERT to stay well under the
+// PostgreSQL limit on bind parameters.
+const itemInsertBatchSize = 500
+
+// CreateItemInstances inserts many items using multi-row INSERTs inside a
+// single transaction, so either every item is created or none are.
+func (r *ItemRepository) CreateItemInstances(instances []*items.ItemInstance) error {
+	if len(instances) == 0 {
+		return nil
+	}
+	
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin item batch: %w", err)
+	}
+	defer tx.Rollback()
+	
+	for start := 0; start < len(instances); start += itemInsertBatchSize {
+		end := start + itemInsertBatchSize
+		if end > len(instances) {
+			end = len(instances)
+		}
+		
+		query, args, err := buildItemInsert(instances[start:end])
+		if err != nil {
+			return err
+		}
+		
+		if _, err := tx.Exec(query, args...); err != nil {
+			return fmt.Errorf("failed to create item instances: %w", err)
+		}
+	}
+	
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit item batch: %w", err)
+	}
+	
+	return nil
+}
+
+// buildItemInsert builds a single multi-row INSERT for the given items
+func buildItemInsert(instances []*items.ItemInstance) (string, []interface{}, error) {
+	const columns = 10
+	
+	var query strings.Builder
+	query.WriteString(`INSERT INTO item_instances (id, template_id, owner_id, quantity, durability,
+			enchantments, custom_name, modifications, created_at, last_used) VALUES `)
+	
+	args := make([]interface{}, 0, len(instances)*columns)
+	for i, item := range instances {
+		enchantmentsJSON, err := json.Marshal(item.Enchantments)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to marshal enchantments: %w", err)
+		}
+		
+		modificationsJSON, err := json.Marshal(item.Modifications)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to marshal modifications: %w", err)
+		}
+		
+		if i > 0 {
+			query.WriteString(", ")
+		}
+		query.WriteString("(")
+		for c := 1; c <= columns; c++ {
+			if c > 1 {
+				query.WriteString(", ")
+			}
+			fmt.Fprintf(&query, "$%d", i*columns+c)
+		}
+		query.WriteString(")")
+		
+		args = append(args, item.ID, item.TemplateID, item.OwnerID, item.Quantity,
+			item.Durability, enchantmentsJSON, item.CustomName, modificationsJSON,
+			item.CreatedAt, item.LastUsed)
+	}
+	
+	return query.String(), args, nil
+}
+
 func (r *ItemRepository) GetItemInstance(itemID string) (*items.ItemInstance, error) {
 	query := `
 		SELECT id, template_id, owner_id, quantity, durability, enchantments,
@@ -113,15 +196,54 @@ func (r *ItemRepository) DeleteItemInstance(itemID string) error {
 }
 
 func (r *ItemRepository) GetPlayerItems(characterID string) ([]*items.ItemInstance, error) {
-	query := `
+	stmt, err := r.stmts.prepare(`
 		SELECT id, template_id, owner_id, quantity, durability, enchantments,
 			custom_name, modifications, created_at, last_used
-		FROM item_instances WHERE owner_id = $1`
+		FROM item_instances WHERE owner_id = $1`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get player items: %w", err)
+	}
 	
-	rows, err := r.db.Query(query, characterID)
+	rows, err := stmt.Query(characterID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get player items: %w", err)
+	}
+	itemInstances, err := scanItems(rows)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get player items: %w", err)
 	}
+	return itemInstances, nil
+}
+
+// GetCarriedItems returns the items the character owns but is not wearing,
+// leaving out anything named in their stored equipment.
+func (r *ItemRepository) GetCarriedItems(characterID string) ([]*items.ItemInstance, error) {
+	query := `
+		SELECT id, template_id, owner_id, quantity, durability, enchantments,
+			custom_name, modifications, created_at, last_used
+		FROM item_instances i
+		WHERE i.owner_id = $1 AND NOT EXISTS (
+			SELECT 1 FROM characters c, jsonb_each_text(c.equipment) worn
+			WHERE c.id::text = $1 AND worn.value = i.id::text)`
+	
+	itemInstances, err := r.queryItems(query, characterID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get carried items: %w", err)
+	}
+	return itemInstances, nil
+}
+
+// queryItems runs a query selecting whole item instance rows
+func (r *ItemRepository) queryItems(query string, args ...interface{}) ([]*items.ItemInstance, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return scanItems(rows)
+}
+
+// scanItems reads and closes rows of whole item instances
+func scanItems(rows *sql.Rows) ([]*items.ItemInstance, error) {
 	defer rows.Close()
 	
 	var itemInstances []*items.ItemInstance
@@ -147,7 +269,7 @@ func (r *ItemRepository) GetPlayerItems(characterID string) ([]*items.ItemInstan
 		itemInstances = append(itemInstances, item)
 	}
 	
-	return itemInstances, nil
+	return itemInstances, rows.Err()
 }
 
 func (r *ItemRepository) GetRoomItems(roomID string) ([]*items.ItemInstance, error) {
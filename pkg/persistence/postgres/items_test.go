@@ -1,6 +1,7 @@
 package postgres
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -366,3 +367,116 @@ func TestItemRepository_GetRoomItems(t *testing.T) {
 	}
 }
 
+
+
+func TestBuildItemInsert(t *testing.T) {
+	batch := []*items.ItemInstance{createTestItemInstance(), createTestItemInstance()}
+
+	query, args, err := buildItemInsert(batch)
+	if err != nil {
+		t.Fatalf("Failed to build insert: %v", err)
+	}
+
+	if len(args) != 20 {
+		t.Errorf("Expected 20 args for 2 items, got %d", len(args))
+	}
+
+	if !strings.Contains(query, "($11, $12, $13, $14, $15, $16, $17, $18, $19, $20)") {
+		t.Errorf("Expected second row placeholders in query, got %s", query)
+	}
+
+	if args[10] != batch[1].ID {
+		t.Errorf("Expected second row to start with the second item's ID")
+	}
+}
+
+func TestItemRepository_CreateItemInstances(t *testing.T) {
+	repoManager := setupTestDB(t)
+	if repoManager == nil {
+		t.Skip("Database not available for testing")
+	}
+
+	repo := repoManager.Items()
+	ownerID := createTestItemInstance().ID
+
+	var batch []*items.ItemInstance
+	for i := 0; i < itemInsertBatchSize+5; i++ {
+		item := createTestItemInstance()
+		item.OwnerID = ownerID
+		batch = append(batch, item)
+	}
+
+	if err := repo.CreateItemInstances(batch); err != nil {
+		t.Fatalf("Failed to create item batch: %v", err)
+	}
+
+	owned, err := repo.GetPlayerItems(ownerID)
+	if err != nil {
+		t.Fatalf("Failed to get items: %v", err)
+	}
+
+	if len(owned) != len(batch) {
+		t.Errorf("Expected %d items, got %d", len(batch), len(owned))
+	}
+
+	// A failing row rolls back the whole batch
+	duplicate := []*items.ItemInstance{createTestItemInstance(), batch[0]}
+	duplicate[0].OwnerID = ownerID
+	if err := repo.CreateItemInstances(duplicate); err == nil {
+		t.Errorf("Expected duplicate ID to fail the batch")
+	}
+
+	owned, _ = repo.GetPlayerItems(ownerID)
+	if len(owned) != len(batch) {
+		t.Errorf("Expected failed batch to be rolled back, got %d items", len(owned))
+	}
+}
+
+func TestItemRepository_GetCarriedItems(t *testing.T) {
+	repoManager := setupTestDB(t)
+	if repoManager == nil {
+		t.Skip("Database not available for testing")
+	}
+
+	testPlayer := createTestPlayer()
+	if err := repoManager.Players().CreatePlayer(testPlayer); err != nil {
+		t.Fatalf("Failed to create test player: %v", err)
+	}
+	testChar := createTestCharacter(testPlayer.ID)
+	if err := repoManager.Characters().CreateCharacter(testChar); err != nil {
+		t.Fatalf("Failed to create character: %v", err)
+	}
+
+	repo := repoManager.Items()
+	sword := createTestItemInstance()
+	sword.OwnerID = testChar.ID
+	potion := createTestItemInstance()
+	potion.OwnerID = testChar.ID
+	potion.TemplateID = "health_potion"
+	for _, item := range []*items.ItemInstance{sword, potion} {
+		if err := repo.CreateItemInstance(item); err != nil {
+			t.Fatalf("Failed to create item: %v", err)
+		}
+	}
+
+	testChar.Equip(items.SlotMainHand, sword)
+	if err := repoManager.Characters().UpdateCharacter(testChar); err != nil {
+		t.Fatalf("Failed to update character: %v", err)
+	}
+
+	carried, err := repo.GetCarriedItems(testChar.ID)
+	if err != nil {
+		t.Fatalf("Failed to get carried items: %v", err)
+	}
+	if len(carried) != 1 || carried[0].ID != potion.ID {
+		t.Errorf("Expected only the potion to be carried, got %v", carried)
+	}
+
+	owned, err := repo.GetPlayerItems(testChar.ID)
+	if err != nil {
+		t.Fatalf("Failed to get player items: %v", err)
+	}
+	if len(owned) != 2 {
+		t.Errorf("Expected the worn sword among the owned items, got %d items", len(owned))
+	}
+}
@@ -0,0 +1,185 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/elidor/dungeogo/migrations"
+)
+
+// migrationLockID is the advisory lock key held while migrating, so two
+// servers starting together do not apply the same migration twice.
+const migrationLockID = 720_531_001
+
+// Migration is one numbered SQL file.
+type Migration struct {
+	Version int
+	Name    string
+	SQL     string
+}
+
+// LoadMigrations reads every NNN_name.sql file at the root of fsys and returns
+// them ordered by version.
+func LoadMigrations(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	var result []Migration
+	seen := make(map[int]string)
+	for _, entry := range entries {
+		if entry.IsDir() || path.Ext(entry.Name()) != ".sql" {
+			continue
+		}
+
+		version, name, err := parseMigrationName(entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		if other, ok := seen[version]; ok {
+			return nil, fmt.Errorf("migrations %s and %s share version %d", other, entry.Name(), version)
+		}
+		seen[version] = entry.Name()
+
+		data, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %s: %w", entry.Name(), err)
+		}
+		result = append(result, Migration{Version: version, Name: name, SQL: string(data)})
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Version < result[j].Version })
+	return result, nil
+}
+
+func parseMigrationName(filename string) (int, string, error) {
+	base := strings.TrimSuffix(filename, ".sql")
+	prefix, name, ok := strings.Cut(base, "_")
+	if !ok || name == "" {
+		return 0, "", fmt.Errorf("migration %s is not named NNN_name.sql", filename)
+	}
+
+	version, err := strconv.Atoi(prefix)
+	if err != nil || version < 1 {
+		return 0, "", fmt.Errorf("migration %s has an invalid version", filename)
+	}
+	return version, name, nil
+}
+
+// Migrate applies every migration in fsys that db has not recorded yet, each
+// in its own transaction, and returns how many were applied.
+func Migrate(db *sql.DB, fsys fs.FS) (int, error) {
+	all, err := LoadMigrations(fsys)
+	if err != nil {
+		return 0, err
+	}
+
+	ctx := context.Background()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to reserve migration connection: %w", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, migrationLockID); err != nil {
+		return 0, fmt.Errorf("failed to acquire migration lock: %w", err)
+	}
+	defer conn.ExecContext(ctx, `SELECT pg_advisory_unlock($1)`, migrationLockID)
+
+	_, err = conn.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version INTEGER PRIMARY KEY,
+			name VARCHAR(255) NOT NULL,
+			applied_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+		)`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create schema_migrations table: %w", err)
+	}
+
+	applied, err := appliedVersions(ctx, conn)
+	if err != nil {
+		return 0, err
+	}
+	if len(applied) == 0 {
+		if err := checkUntrackedSchema(ctx, conn); err != nil {
+			return 0, err
+		}
+	}
+
+	count := 0
+	for _, m := range all {
+		if applied[m.Version] {
+			continue
+		}
+		if err := applyMigration(ctx, conn, m); err != nil {
+			return count, err
+		}
+		count++
+	}
+	return count, nil
+}
+
+func appliedVersions(ctx context.Context, conn *sql.Conn) (map[int]bool, error) {
+	rows, err := conn.QueryContext(ctx, `SELECT version FROM schema_migrations`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read applied migrations: %w", err)
+	}
+	defer rows.Close()
+
+	applied := make(map[int]bool)
+	for rows.Next() {
+		var version int
+		if err := rows.Scan(&version); err != nil {
+			return nil, fmt.Errorf("failed to scan migration version: %w", err)
+		}
+		applied[version] = true
+	}
+	return applied, rows.Err()
+}
+
+// checkUntrackedSchema refuses to run the initial migration over a database
+// whose tables were created by hand, which would otherwise fail halfway.
+func checkUntrackedSchema(ctx context.Context, conn *sql.Conn) error {
+	var exists bool
+	err := conn.QueryRowContext(ctx, `SELECT to_regclass('players') IS NOT NULL`).Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("failed to inspect existing schema: %w", err)
+	}
+	if exists {
+		return fmt.Errorf("database has tables but no migration history; record the versions already applied in schema_migrations")
+	}
+	return nil
+}
+
+func applyMigration(ctx context.Context, conn *sql.Conn, m Migration) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin migration %03d: %w", m.Version, err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, m.SQL); err != nil {
+		return fmt.Errorf("failed to apply migration %03d_%s: %w", m.Version, m.Name, err)
+	}
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO schema_migrations (version, name) VALUES ($1, $2)`, m.Version, m.Name); err != nil {
+		return fmt.Errorf("failed to record migration %03d: %w", m.Version, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit migration %03d: %w", m.Version, err)
+	}
+	return nil
+}
+
+// Migrate brings the connected database up to the schema embedded in the
+// migrations package.
+func (m *PostgreSQLRepositoryManager) Migrate() (int, error) {
+	return Migrate(m.db, migrations.Files)
+}
@@ -0,0 +1,75 @@
+package postgres
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/elidor/dungeogo/migrations"
+)
+
+func TestLoadMigrations_OrdersByVersion(t *testing.T) {
+	fsys := fstest.MapFS{
+		"010_later.sql":   {Data: []byte("SELECT 10;")},
+		"002_second.sql":  {Data: []byte("SELECT 2;")},
+		"001_initial.sql": {Data: []byte("SELECT 1;")},
+		"README.md":       {Data: []byte("ignored")},
+	}
+
+	loaded, err := LoadMigrations(fsys)
+	if err != nil {
+		t.Fatalf("Failed to load migrations: %v", err)
+	}
+
+	if len(loaded) != 3 {
+		t.Fatalf("Expected 3 migrations, got %d", len(loaded))
+	}
+	want := []int{1, 2, 10}
+	for i, m := range loaded {
+		if m.Version != want[i] {
+			t.Errorf("Migration %d: expected version %d, got %d", i, want[i], m.Version)
+		}
+	}
+	if loaded[0].Name != "initial" || loaded[0].SQL != "SELECT 1;" {
+		t.Errorf("Unexpected first migration: %+v", loaded[0])
+	}
+}
+
+func TestLoadMigrations_RejectsBadNames(t *testing.T) {
+	tests := []fstest.MapFS{
+		{"initial.sql": {Data: []byte("")}},
+		{"abc_initial.sql": {Data: []byte("")}},
+		{"001_.sql": {Data: []byte("")}},
+		{"001_a.sql": {Data: []byte("")}, "1_b.sql": {Data: []byte("")}},
+	}
+
+	for _, fsys := range tests {
+		if _, err := LoadMigrations(fsys); err == nil {
+			t.Errorf("Expected error loading %v", fsys)
+		}
+	}
+}
+
+func TestEmbeddedMigrations(t *testing.T) {
+	loaded, err := LoadMigrations(migrations.Files)
+	if err != nil {
+		t.Fatalf("Failed to load embedded migrations: %v", err)
+	}
+
+	for i, m := range loaded {
+		if m.Version != i+1 {
+			t.Errorf("Expected migration %d to have version %d, got %d", i, i+1, m.Version)
+		}
+	}
+}
+
+func TestMigrate_IsIdempotent(t *testing.T) {
+	repoManager := setupTestDB(t)
+
+	applied, err := repoManager.Migrate()
+	if err != nil {
+		t.Fatalf("Failed to re-run migrations: %v", err)
+	}
+	if applied != 0 {
+		t.Errorf("Expected no migrations on an up-to-date database, got %d", applied)
+	}
+}
@@ -0,0 +1,146 @@
+package postgres
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/elidor/dungeogo/pkg/logging"
+)
+
+const (
+	DefaultHealthInterval = 30 * time.Second
+	healthCheckTimeout    = 5 * time.Second
+	maxReconnectBackoff   = time.Minute
+)
+
+// HealthChecker is implemented by anything that can verify its database
+// connection, such as PostgreSQLRepositoryManager.
+type HealthChecker interface {
+	HealthCheck(ctx context.Context) error
+}
+
+// HealthStatus is a snapshot of the monitor's view of the database
+type HealthStatus struct {
+	Healthy             bool
+	LastCheck           time.Time
+	LastError           error
+	ConsecutiveFailures int
+}
+
+// Monitor periodically checks database health in the background. While the
+// database is unreachable it retries with backoff; database/sql discards
+// broken connections, so a successful check means the pool has reconnected.
+type Monitor struct {
+	checker  HealthChecker
+	interval time.Duration
+	logger   *logging.Logger
+
+	mutex  sync.RWMutex
+	status HealthStatus
+
+	stop chan struct{}
+	done chan struct{}
+	once sync.Once
+}
+
+func NewMonitor(checker HealthChecker, interval time.Duration, logger *logging.Logger) *Monitor {
+	if interval <= 0 {
+		interval = DefaultHealthInterval
+	}
+
+	return &Monitor{
+		checker:  checker,
+		interval: interval,
+		logger:   logger,
+		status:   HealthStatus{Healthy: true},
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+func (m *Monitor) Start() {
+	go m.run()
+}
+
+// Stop ends monitoring and waits for the background goroutine to exit
+func (m *Monitor) Stop() {
+	m.once.Do(func() {
+		close(m.stop)
+	})
+	<-m.done
+}
+
+func (m *Monitor) Status() HealthStatus {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	return m.status
+}
+
+func (m *Monitor) run() {
+	defer close(m.done)
+
+	timer := time.NewTimer(m.interval)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-m.stop:
+			return
+		case <-timer.C:
+			timer.Reset(m.Check())
+		}
+	}
+}
+
+// Check runs a single health check, records the result and returns how long
+// to wait before the next one.
+func (m *Monitor) Check() time.Duration {
+	ctx, cancel := context.WithTimeout(context.Background(), healthCheckTimeout)
+	defer cancel()
+	err := m.checker.HealthCheck(ctx)
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	wasHealthy := m.status.Healthy
+	m.status.LastCheck = time.Now()
+	m.status.LastError = err
+
+	if err == nil {
+		if !wasHealthy {
+			m.logger.Infof("Database connection restored after %d failed checks", m.status.ConsecutiveFailures)
+		}
+		m.status.Healthy = true
+		m.status.ConsecutiveFailures = 0
+		return m.interval
+	}
+
+	m.status.Healthy = false
+	m.status.ConsecutiveFailures++
+	if wasHealthy {
+		m.logger.Errorf("Database connection unhealthy: %v", err)
+	} else {
+		m.logger.Warnf("Database still unreachable (attempt %d): %v", m.status.ConsecutiveFailures, err)
+	}
+
+	return reconnectBackoff(m.status.ConsecutiveFailures, m.interval)
+}
+
+// reconnectBackoff doubles the retry delay from one second per failure,
+// capped at the lesser of the normal interval and maxReconnectBackoff.
+func reconnectBackoff(failures int, interval time.Duration) time.Duration {
+	limit := interval
+	if limit > maxReconnectBackoff {
+		limit = maxReconnectBackoff
+	}
+
+	delay := time.Second
+	for i := 1; i < failures && delay < limit; i++ {
+		delay *= 2
+	}
+	if delay > limit {
+		delay = limit
+	}
+	return delay
+}
@@ -0,0 +1,81 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/elidor/dungeogo/pkg/logging"
+)
+
+type fakeChecker struct {
+	err error
+}
+
+func (f *fakeChecker) HealthCheck(ctx context.Context) error {
+	return f.err
+}
+
+func TestMonitorTracksHealth(t *testing.T) {
+	checker := &fakeChecker{}
+	monitor := NewMonitor(checker, time.Minute, logging.Discard())
+
+	if next := monitor.Check(); next != time.Minute {
+		t.Errorf("Expected healthy check to wait the full interval, got %v", next)
+	}
+	if !monitor.Status().Healthy {
+		t.Errorf("Expected monitor to report healthy")
+	}
+
+	checker.err = errors.New("connection refused")
+	monitor.Check()
+	next := monitor.Check()
+
+	status := monitor.Status()
+	if status.Healthy || status.ConsecutiveFailures != 2 {
+		t.Errorf("Expected 2 consecutive failures, got %+v", status)
+	}
+	if next != 2*time.Second {
+		t.Errorf("Expected backoff of 2s after two failures, got %v", next)
+	}
+
+	checker.err = nil
+	monitor.Check()
+	status = monitor.Status()
+	if !status.Healthy || status.ConsecutiveFailures != 0 || status.LastError != nil {
+		t.Errorf("Expected monitor to recover, got %+v", status)
+	}
+}
+
+func TestReconnectBackoff(t *testing.T) {
+	tests := []struct {
+		failures int
+		interval time.Duration
+		expected time.Duration
+	}{
+		{1, time.Minute, time.Second},
+		{3, time.Minute, 4 * time.Second},
+		{10, time.Minute, time.Minute},
+		{10, 10 * time.Second, 10 * time.Second},
+		{20, time.Hour, maxReconnectBackoff},
+	}
+
+	for _, test := range tests {
+		if actual := reconnectBackoff(test.failures, test.interval); actual != test.expected {
+			t.Errorf("reconnectBackoff(%d, %v) = %v, expected %v", test.failures, test.interval, actual, test.expected)
+		}
+	}
+}
+
+func TestMonitorStartStop(t *testing.T) {
+	monitor := NewMonitor(&fakeChecker{}, 10*time.Millisecond, logging.Discard())
+	monitor.Start()
+	time.Sleep(30 * time.Millisecond)
+	monitor.Stop()
+	monitor.Stop()
+
+	if monitor.Status().LastCheck.IsZero() {
+		t.Errorf("Expected the background monitor to have run a check")
+	}
+}
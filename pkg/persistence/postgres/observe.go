@@ -0,0 +1,127 @@
+package postgres
+
+import (
+	"context"
+	"database/sql/driver"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// QueryObserver is told how long each database call took. Operation is the
+// statement's leading keyword, such as "select" or "update".
+type QueryObserver func(operation string, duration time.Duration)
+
+// observedConnector wraps the driver so every statement can be timed without
+// the repositories knowing about it.
+type observedConnector struct {
+	base     driver.Connector
+	observer *atomic.Pointer[QueryObserver]
+}
+
+func (c *observedConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.base.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &observedConn{Conn: conn, observer: c.observer}, nil
+}
+
+func (c *observedConnector) Driver() driver.Driver {
+	return c.base.Driver()
+}
+
+func observe(observer *atomic.Pointer[QueryObserver], query string, start time.Time) {
+	if fn := observer.Load(); fn != nil {
+		(*fn)(queryOperation(query), time.Since(start))
+	}
+}
+
+// queryOperation labels a statement by its first keyword, keeping the set of
+// labels small whatever the query.
+func queryOperation(query string) string {
+	fields := strings.Fields(query)
+	if len(fields) == 0 {
+		return "other"
+	}
+	switch op := strings.ToLower(fields[0]); op {
+	case "select", "insert", "update", "delete", "with":
+		return op
+	}
+	return "other"
+}
+
+type observedConn struct {
+	driver.Conn
+	observer *atomic.Pointer[QueryObserver]
+}
+
+func (c *observedConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	execer, ok := c.Conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	defer observe(c.observer, query, time.Now())
+	return execer.ExecContext(ctx, query, args)
+}
+
+func (c *observedConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	queryer, ok := c.Conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	defer observe(c.observer, query, time.Now())
+	return queryer.QueryContext(ctx, query, args)
+}
+
+func (c *observedConn) Prepare(query string) (driver.Stmt, error) {
+	stmt, err := c.Conn.Prepare(query)
+	if err != nil {
+		return nil, err
+	}
+	return &observedStmt{Stmt: stmt, query: query, observer: c.observer}, nil
+}
+
+func (c *observedConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if beginner, ok := c.Conn.(driver.ConnBeginTx); ok {
+		return beginner.BeginTx(ctx, opts)
+	}
+	return c.Conn.Begin()
+}
+
+func (c *observedConn) Ping(ctx context.Context) error {
+	if pinger, ok := c.Conn.(driver.Pinger); ok {
+		return pinger.Ping(ctx)
+	}
+	return nil
+}
+
+func (c *observedConn) ResetSession(ctx context.Context) error {
+	if resetter, ok := c.Conn.(driver.SessionResetter); ok {
+		return resetter.ResetSession(ctx)
+	}
+	return nil
+}
+
+func (c *observedConn) IsValid() bool {
+	if validator, ok := c.Conn.(driver.Validator); ok {
+		return validator.IsValid()
+	}
+	return true
+}
+
+type observedStmt struct {
+	driver.Stmt
+	query    string
+	observer *atomic.Pointer[QueryObserver]
+}
+
+func (s *observedStmt) Exec(args []driver.Value) (driver.Result, error) {
+	defer observe(s.observer, s.query, time.Now())
+	return s.Stmt.Exec(args)
+}
+
+func (s *observedStmt) Query(args []driver.Value) (driver.Rows, error) {
+	defer observe(s.observer, s.query, time.Now())
+	return s.Stmt.Query(args)
+}
@@ -0,0 +1,72 @@
+package postgres
+
+import (
+	"context"
+	"database/sql/driver"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type fakeConn struct {
+	driver.Conn
+	executed string
+}
+
+func (c *fakeConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	c.executed = query
+	return driver.RowsAffected(1), nil
+}
+
+func TestQueryOperation(t *testing.T) {
+	tests := map[string]string{
+		"SELECT id FROM players":            "select",
+		"\n\t\tINSERT INTO characters (id)": "insert",
+		"update items SET owner_id = $1":    "update",
+		"DELETE FROM items":                 "delete",
+		"WITH x AS (SELECT 1) SELECT *":     "with",
+		"SELECT pg_advisory_lock($1)":       "select",
+		"CREATE TABLE foo ()":               "other",
+		"":                                  "other",
+	}
+	for query, expected := range tests {
+		if got := queryOperation(query); got != expected {
+			t.Errorf("queryOperation(%q) = %q, want %q", query, got, expected)
+		}
+	}
+}
+
+func TestObservedConnTimesStatements(t *testing.T) {
+	var observer atomic.Pointer[QueryObserver]
+	var operations []string
+	fn := QueryObserver(func(operation string, duration time.Duration) {
+		operations = append(operations, operation)
+	})
+
+	base := &fakeConn{}
+	conn := &observedConn{Conn: base, observer: &observer}
+
+	// Nothing is observed until an observer is set
+	if _, err := conn.ExecContext(context.Background(), "UPDATE players SET x = 1", nil); err != nil {
+		t.Fatalf("ExecContext failed: %v", err)
+	}
+	if len(operations) != 0 {
+		t.Errorf("Expected no observations without an observer, got %v", operations)
+	}
+
+	observer.Store(&fn)
+	if _, err := conn.ExecContext(context.Background(), "DELETE FROM items", nil); err != nil {
+		t.Fatalf("ExecContext failed: %v", err)
+	}
+	if base.executed != "DELETE FROM items" {
+		t.Errorf("Expected statement to reach the driver, got %q", base.executed)
+	}
+	if len(operations) != 1 || operations[0] != "delete" {
+		t.Errorf("Expected one delete observation, got %v", operations)
+	}
+
+	// Drivers without context queries fall back to database/sql's own path
+	if _, err := conn.QueryContext(context.Background(), "SELECT 1", nil); err != driver.ErrSkip {
+		t.Errorf("Expected ErrSkip for unsupported queries, got %v", err)
+	}
+}
@@ -7,12 +7,18 @@ import (
 	"time"
 	
 	"github.com/elidor/dungeogo/pkg/game/player"
+	"github.com/elidor/dungeogo/pkg/persistence/interfaces"
 )
 
 type PlayerRepository struct {
 	db *sql.DB
 }
 
+// playerColumns lists the columns read by scanPlayer, in scan order
+const playerColumns = `id, username, email, password_hash, created_at, last_login,
+			account_status, subscription, preferences, max_characters, current_character_id,
+			email_verified, verification_code, role`
+
 func NewPlayerRepository(db *sql.DB) *PlayerRepository {
 	return &PlayerRepository{db: db}
 }
@@ -36,8 +42,9 @@ func (r *PlayerRepository) CreatePlayer(p *player.Player) error {
 	
 	query := `
 		INSERT INTO players (id, username, email, password_hash, created_at, last_login, 
-			account_status, subscription, preferences, max_characters, current_character_id)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
+			account_status, subscription, preferences, max_characters, current_character_id,
+			email_verified, verification_code, role)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)`
 	
 	var currentCharacterID interface{}
 	if p.CurrentCharacterID == "" {
@@ -46,9 +53,10 @@ func (r *PlayerRepository) CreatePlayer(p *player.Player) error {
 		currentCharacterID = p.CurrentCharacterID
 	}
 	
-	_, err = r.db.Exec(query, p.ID, p.Username, p.Email, p.PasswordHash, 
+	_, err = r.db.Exec(query, p.ID, p.Username, player.NormalizeEmail(p.Email), p.PasswordHash, 
 		p.CreatedAt, p.LastLogin, int(p.AccountStatus), subscriptionJSON, 
-		prefsJSON, p.MaxCharacters, currentCharacterID)
+		prefsJSON, p.MaxCharacters, currentCharacterID, p.EmailVerified,
+		p.VerificationCode, int(p.Role))
 	
 	if err != nil {
 		return fmt.Errorf("failed to create player: %w", err)
@@ -58,125 +66,69 @@ func (r *PlayerRepository) CreatePlayer(p *player.Player) error {
 }
 
 func (r *PlayerRepository) GetPlayer(playerID string) (*player.Player, error) {
-	query := `
-		SELECT id, username, email, password_hash, created_at, last_login,
-			account_status, subscription, preferences, max_characters, current_character_id
-		FROM players WHERE id = $1`
-	
-	p := &player.Player{}
-	var subscriptionJSON, prefsJSON []byte
-	var currentCharacterID sql.NullString
-	var accountStatus int
-	
-	err := r.db.QueryRow(query, playerID).Scan(
-		&p.ID, &p.Username, &p.Email, &p.PasswordHash, &p.CreatedAt,
-		&p.LastLogin, &accountStatus, &subscriptionJSON, &prefsJSON,
-		&p.MaxCharacters, &currentCharacterID)
+	query := `SELECT ` + playerColumns + ` FROM players WHERE id = $1`
 	
+	p, err := scanPlayer(r.db.QueryRow(query, playerID))
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("player not found: %s", playerID)
+			return nil, fmt.Errorf("%w: %s", interfaces.ErrPlayerNotFound, playerID)
 		}
 		return nil, fmt.Errorf("failed to get player: %w", err)
 	}
 	
-	p.AccountStatus = player.AccountStatus(accountStatus)
-	
-	if currentCharacterID.Valid {
-		p.CurrentCharacterID = currentCharacterID.String
-	} else {
-		p.CurrentCharacterID = ""
-	}
-	
-	if subscriptionJSON != nil {
-		p.Subscription = &player.Subscription{}
-		if err := json.Unmarshal(subscriptionJSON, p.Subscription); err != nil {
-			return nil, fmt.Errorf("failed to unmarshal subscription: %w", err)
-		}
-	}
-	
-	if err := json.Unmarshal(prefsJSON, &p.Preferences); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal preferences: %w", err)
-	}
-	
 	return p, nil
 }
 
 func (r *PlayerRepository) GetPlayerByUsername(username string) (*player.Player, error) {
-	query := `
-		SELECT id, username, email, password_hash, created_at, last_login,
-			account_status, subscription, preferences, max_characters, current_character_id
-		FROM players WHERE username = $1`
-	
-	p := &player.Player{}
-	var subscriptionJSON, prefsJSON []byte
-	var currentCharacterID sql.NullString
-	var accountStatus int
-	
-	err := r.db.QueryRow(query, username).Scan(
-		&p.ID, &p.Username, &p.Email, &p.PasswordHash, &p.CreatedAt,
-		&p.LastLogin, &accountStatus, &subscriptionJSON, &prefsJSON,
-		&p.MaxCharacters, &currentCharacterID)
+	query := `SELECT ` + playerColumns + ` FROM players WHERE username = $1`
 	
+	p, err := scanPlayer(r.db.QueryRow(query, username))
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("player not found: %s", username)
+			return nil, fmt.Errorf("%w: %s", interfaces.ErrPlayerNotFound, username)
 		}
 		return nil, fmt.Errorf("failed to get player by username: %w", err)
 	}
 	
-	p.AccountStatus = player.AccountStatus(accountStatus)
-	
-	if currentCharacterID.Valid {
-		p.CurrentCharacterID = currentCharacterID.String
-	} else {
-		p.CurrentCharacterID = ""
-	}
+	return p, nil
+}
+
+// GetPlayerByEmail finds a player by email, ignoring case and surrounding
+// spaces
+func (r *PlayerRepository) GetPlayerByEmail(email string) (*player.Player, error) {
+	query := `SELECT ` + playerColumns + ` FROM players WHERE LOWER(email) = $1`
 	
-	if subscriptionJSON != nil {
-		p.Subscription = &player.Subscription{}
-		if err := json.Unmarshal(subscriptionJSON, p.Subscription); err != nil {
-			return nil, fmt.Errorf("failed to unmarshal subscription: %w", err)
+	p, err := scanPlayer(r.db.QueryRow(query, player.NormalizeEmail(email)))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%w: %s", interfaces.ErrPlayerNotFound, email)
 		}
-	}
-	
-	if err := json.Unmarshal(prefsJSON, &p.Preferences); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal preferences: %w", err)
+		return nil, fmt.Errorf("failed to get player by email: %w", err)
 	}
 	
 	return p, nil
 }
 
-func (r *PlayerRepository) GetPlayerByEmail(email string) (*player.Player, error) {
-	query := `
-		SELECT id, username, email, password_hash, created_at, last_login,
-			account_status, subscription, preferences, max_characters, current_character_id
-		FROM players WHERE email = $1`
-	
+// scanPlayer reads a row selected with playerColumns into a Player
+func scanPlayer(row *sql.Row) (*player.Player, error) {
 	p := &player.Player{}
 	var subscriptionJSON, prefsJSON []byte
-	var currentCharacterID sql.NullString
-	var accountStatus int
+	var currentCharacterID, verificationCode sql.NullString
+	var accountStatus, role int
 	
-	err := r.db.QueryRow(query, email).Scan(
+	err := row.Scan(
 		&p.ID, &p.Username, &p.Email, &p.PasswordHash, &p.CreatedAt,
 		&p.LastLogin, &accountStatus, &subscriptionJSON, &prefsJSON,
-		&p.MaxCharacters, &currentCharacterID)
-	
+		&p.MaxCharacters, &currentCharacterID, &p.EmailVerified,
+		&verificationCode, &role)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("player not found: %s", email)
-		}
-		return nil, fmt.Errorf("failed to get player by email: %w", err)
+		return nil, err
 	}
 	
 	p.AccountStatus = player.AccountStatus(accountStatus)
-	
-	if currentCharacterID.Valid {
-		p.CurrentCharacterID = currentCharacterID.String
-	} else {
-		p.CurrentCharacterID = ""
-	}
+	p.Role = player.Role(role)
+	p.CurrentCharacterID = currentCharacterID.String
+	p.VerificationCode = verificationCode.String
 	
 	if subscriptionJSON != nil {
 		p.Subscription = &player.Subscription{}
@@ -209,12 +161,19 @@ func (r *PlayerRepository) UpdatePlayer(p *player.Player) error {
 	query := `
 		UPDATE players SET username = $2, email = $3, password_hash = $4, 
 			last_login = $5, account_status = $6, subscription = $7, 
-			preferences = $8, max_characters = $9, current_character_id = $10
+			preferences = $8, max_characters = $9, current_character_id = $10,
+			email_verified = $11, verification_code = $12, role = $13
 		WHERE id = $1`
 	
-	_, err = r.db.Exec(query, p.ID, p.Username, p.Email, p.PasswordHash,
+	var currentCharacterID interface{}
+	if p.CurrentCharacterID != "" {
+		currentCharacterID = p.CurrentCharacterID
+	}
+	
+	_, err = r.db.Exec(query, p.ID, p.Username, player.NormalizeEmail(p.Email), p.PasswordHash,
 		p.LastLogin, int(p.AccountStatus), subscriptionJSON, prefsJSON,
-		p.MaxCharacters, p.CurrentCharacterID)
+		p.MaxCharacters, currentCharacterID, p.EmailVerified, p.VerificationCode,
+		int(p.Role))
 	
 	if err != nil {
 		return fmt.Errorf("failed to update player: %w", err)
@@ -239,4 +198,49 @@ func (r *PlayerRepository) DeletePlayer(playerID string) error {
 		return fmt.Errorf("failed to delete player: %w", err)
 	}
 	return nil
-}
\ No newline at end of file
+}
+
+
+func (r *PlayerRepository) RecordLogin(record *interfaces.LoginRecord) error {
+	query := `
+		INSERT INTO login_history (player_id, ip_address, success, reason, created_at)
+		VALUES ($1, $2, $3, $4, $5)`
+	
+	_, err := r.db.Exec(query, record.PlayerID, record.IPAddress, record.Success,
+		record.Reason, record.Timestamp)
+	if err != nil {
+		return fmt.Errorf("failed to record login: %w", err)
+	}
+	return nil
+}
+
+// GetRecentLogins returns the account's latest login attempts, newest first
+func (r *PlayerRepository) GetRecentLogins(playerID string, limit int) ([]*interfaces.LoginRecord, error) {
+	query := `
+		SELECT player_id, ip_address, success, reason, created_at
+		FROM login_history WHERE player_id = $1
+		ORDER BY created_at DESC LIMIT $2`
+	
+	rows, err := r.db.Query(query, playerID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recent logins: %w", err)
+	}
+	defer rows.Close()
+	
+	var records []*interfaces.LoginRecord
+	for rows.Next() {
+		record := &interfaces.LoginRecord{}
+		err := rows.Scan(&record.PlayerID, &record.IPAddress, &record.Success,
+			&record.Reason, &record.Timestamp)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan login record: %w", err)
+		}
+		records = append(records, record)
+	}
+	
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read login history: %w", err)
+	}
+	
+	return records, nil
+}
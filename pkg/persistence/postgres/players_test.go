@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/elidor/dungeogo/pkg/game/player"
+	"github.com/elidor/dungeogo/pkg/persistence/interfaces"
 )
 
 func TestPlayerRepository_CreatePlayer(t *testing.T) {
@@ -240,6 +241,62 @@ func TestPlayerRepository_UniqueConstraints(t *testing.T) {
 	if err == nil {
 		t.Errorf("Expected error when creating player with duplicate email")
 	}
+
+	// Emails differing only in case are the same email
+	player4 := createTestPlayer()
+	player4.Username = "shoutyuser"
+	player4.Email = " Test@Example.COM"
+
+	err = repo.CreatePlayer(player4)
+	if err == nil {
+		t.Errorf("Expected error when creating player with a mixed-case duplicate email")
+	}
+}
+
+func TestPlayerRepository_GetPlayerByEmailIgnoresCase(t *testing.T) {
+	repoManager := setupTestDB(t)
+	if repoManager == nil {
+		t.Skip("Database not available for testing")
+	}
+
+	repo := repoManager.Players()
+	testPlayer := createTestPlayer()
+	testPlayer.Email = "Mixed.Case@Example.com"
+	if err := repo.CreatePlayer(testPlayer); err != nil {
+		t.Fatalf("Failed to create player: %v", err)
+	}
+
+	retrieved, err := repo.GetPlayerByEmail("  MIXED.case@example.COM ")
+	if err != nil {
+		t.Fatalf("Failed to retrieve player by email: %v", err)
+	}
+	if retrieved.ID != testPlayer.ID {
+		t.Errorf("Expected ID %s, got %s", testPlayer.ID, retrieved.ID)
+	}
+	if retrieved.Email != "mixed.case@example.com" {
+		t.Errorf("Expected the email to be stored lowercased, got %q", retrieved.Email)
+	}
+}
+
+func TestPlayerRepository_EmailUniqueIgnoringCase(t *testing.T) {
+	repoManager := setupTestDB(t)
+	if repoManager == nil {
+		t.Skip("Database not available for testing")
+	}
+
+	repo := repoManager.Players()
+	first := createTestPlayer()
+	first.Email = "Shared@Example.com"
+	if err := repo.CreatePlayer(first); err != nil {
+		t.Fatalf("Failed to create player: %v", err)
+	}
+
+	second := createTestPlayer()
+	second.Username = "otheruser"
+	second.Email = "shared@example.COM"
+	if err := repo.CreatePlayer(second); err == nil {
+		t.Errorf("Expected an email differing only in case to be refused")
+	}
 }
 
 func TestPlayerRepository_Subscription(t *testing.T) {
@@ -302,3 +359,96 @@ func TestPlayerRepository_Subscription(t *testing.T) {
 	}
 }
 
+
+func TestPlayerRepository_EmailVerification(t *testing.T) {
+	repoManager := setupTestDB(t)
+	if repoManager == nil {
+		t.Skip("Database not available for testing")
+	}
+
+	repo := repoManager.Players()
+	testPlayer := createTestPlayer()
+	testPlayer.StartEmailVerification("123456")
+
+	err := repo.CreatePlayer(testPlayer)
+	if err != nil {
+		t.Fatalf("Failed to create player: %v", err)
+	}
+
+	retrieved, err := repo.GetPlayer(testPlayer.ID)
+	if err != nil {
+		t.Fatalf("Failed to retrieve player: %v", err)
+	}
+
+	if retrieved.EmailVerified {
+		t.Errorf("Expected new player to be unverified")
+	}
+
+	if retrieved.VerificationCode != "123456" {
+		t.Errorf("Expected verification code to round-trip, got %q", retrieved.VerificationCode)
+	}
+
+	if !retrieved.VerifyEmail("123456") {
+		t.Fatalf("Expected stored code to verify")
+	}
+
+	err = repo.UpdatePlayer(retrieved)
+	if err != nil {
+		t.Fatalf("Failed to update player: %v", err)
+	}
+
+	verified, err := repo.GetPlayer(testPlayer.ID)
+	if err != nil {
+		t.Fatalf("Failed to retrieve verified player: %v", err)
+	}
+
+	if !verified.EmailVerified {
+		t.Errorf("Expected email to be verified after update")
+	}
+
+	if verified.VerificationCode != "" {
+		t.Errorf("Expected verification code to be cleared, got %q", verified.VerificationCode)
+	}
+}
+
+func TestPlayerRepository_LoginHistory(t *testing.T) {
+	repoManager := setupTestDB(t)
+	if repoManager == nil {
+		t.Skip("Database not available for testing")
+	}
+
+	repo := repoManager.Players()
+	testPlayer := createTestPlayer()
+	if err := repo.CreatePlayer(testPlayer); err != nil {
+		t.Fatalf("Failed to create player: %v", err)
+	}
+
+	base := time.Now().Add(-time.Hour)
+	attempts := []*interfaces.LoginRecord{
+		{PlayerID: testPlayer.ID, IPAddress: "203.0.113.5", Success: false, Reason: "bad_password", Timestamp: base},
+		{PlayerID: testPlayer.ID, IPAddress: "203.0.113.5", Success: true, Timestamp: base.Add(time.Minute)},
+		{PlayerID: testPlayer.ID, IPAddress: "198.51.100.7", Success: true, Timestamp: base.Add(2 * time.Minute)},
+	}
+	for _, record := range attempts {
+		if err := repo.RecordLogin(record); err != nil {
+			t.Fatalf("Failed to record login: %v", err)
+		}
+	}
+
+	records, err := repo.GetRecentLogins(testPlayer.ID, 2)
+	if err != nil {
+		t.Fatalf("Failed to get recent logins: %v", err)
+	}
+
+	if len(records) != 2 {
+		t.Fatalf("Expected 2 records, got %d", len(records))
+	}
+
+	if records[0].IPAddress != "198.51.100.7" || !records[0].Success {
+		t.Errorf("Expected newest login first, got %+v", records[0])
+	}
+
+	if records[1].IPAddress != "203.0.113.5" || !records[1].Success {
+		t.Errorf("Expected second record to be the successful login from 203.0.113.5, got %+v", records[1])
+	}
+}
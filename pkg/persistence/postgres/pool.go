@@ -0,0 +1,57 @@
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// Pool defaults keep well under PostgreSQL's stock max_connections of 100 so
+// several server processes (or a psql session) can share one database.
+const (
+	DefaultMaxOpenConns    = 25
+	DefaultMaxIdleConns    = 5
+	DefaultConnMaxLifetime = 30 * time.Minute
+)
+
+// PoolConfig controls how many connections the server holds open and for how
+// long each one is reused.
+type PoolConfig struct {
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+}
+
+// DefaultPoolConfig returns the pool settings used when none are configured.
+func DefaultPoolConfig() PoolConfig {
+	return PoolConfig{
+		MaxOpenConns:    DefaultMaxOpenConns,
+		MaxIdleConns:    DefaultMaxIdleConns,
+		ConnMaxLifetime: DefaultConnMaxLifetime,
+	}
+}
+
+// Validate rejects settings that would leave the server without connections
+// or keep more idle connections than it is allowed to open.
+func (p PoolConfig) Validate() error {
+	if p.MaxOpenConns < 1 {
+		return fmt.Errorf("max open connections must be at least 1, got %d", p.MaxOpenConns)
+	}
+	if p.MaxIdleConns < 0 {
+		return fmt.Errorf("max idle connections cannot be negative, got %d", p.MaxIdleConns)
+	}
+	if p.MaxIdleConns > p.MaxOpenConns {
+		return fmt.Errorf("max idle connections (%d) cannot exceed max open connections (%d)",
+			p.MaxIdleConns, p.MaxOpenConns)
+	}
+	if p.ConnMaxLifetime < 0 {
+		return fmt.Errorf("connection max lifetime cannot be negative, got %s", p.ConnMaxLifetime)
+	}
+	return nil
+}
+
+func (p PoolConfig) apply(db *sql.DB) {
+	db.SetMaxOpenConns(p.MaxOpenConns)
+	db.SetMaxIdleConns(p.MaxIdleConns)
+	db.SetConnMaxLifetime(p.ConnMaxLifetime)
+}
@@ -0,0 +1,34 @@
+package postgres
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDefaultPoolConfig_IsValid(t *testing.T) {
+	if err := DefaultPoolConfig().Validate(); err != nil {
+		t.Errorf("Default pool config should be valid: %v", err)
+	}
+}
+
+func TestPoolConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		pool    PoolConfig
+		wantErr bool
+	}{
+		{"valid", PoolConfig{MaxOpenConns: 10, MaxIdleConns: 2, ConnMaxLifetime: time.Minute}, false},
+		{"no idle, no lifetime", PoolConfig{MaxOpenConns: 1}, false},
+		{"zero open", PoolConfig{MaxOpenConns: 0}, true},
+		{"negative idle", PoolConfig{MaxOpenConns: 5, MaxIdleConns: -1}, true},
+		{"idle above open", PoolConfig{MaxOpenConns: 5, MaxIdleConns: 6}, true},
+		{"negative lifetime", PoolConfig{MaxOpenConns: 5, ConnMaxLifetime: -time.Second}, true},
+	}
+
+	for _, tt := range tests {
+		err := tt.pool.Validate()
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: Validate() error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
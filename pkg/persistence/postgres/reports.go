@@ -0,0 +1,136 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/elidor/dungeogo/pkg/persistence/interfaces"
+)
+
+type ReportRepository struct {
+	db *sql.DB
+}
+
+func NewReportRepository(db *sql.DB) *ReportRepository {
+	return &ReportRepository{db: db}
+}
+
+// reportColumns lists the columns read by scanReport, in scan order
+const reportColumns = `id, reporter_id, reporter_name, target_id, target_name, reason, room_id,
+			created_at, resolved_at, resolved_by, resolution`
+
+// CreateReport saves a new report and sets its ID
+func (r *ReportRepository) CreateReport(report *interfaces.Report) error {
+	if report.CreatedAt.IsZero() {
+		report.CreatedAt = time.Now()
+	}
+
+	query := `
+		INSERT INTO reports (reporter_id, reporter_name, target_id, target_name, reason, room_id, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id`
+
+	err := r.db.QueryRow(query, report.ReporterID, report.ReporterName, report.TargetID,
+		report.TargetName, report.Reason, report.RoomID, report.CreatedAt).Scan(&report.ID)
+	if err != nil {
+		return fmt.Errorf("failed to create report: %w", err)
+	}
+	return nil
+}
+
+func (r *ReportRepository) GetReport(reportID int64) (*interfaces.Report, error) {
+	query := `SELECT ` + reportColumns + ` FROM reports WHERE id = $1`
+
+	report, err := scanReport(r.db.QueryRow(query, reportID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, interfaces.ErrReportNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get report: %w", err)
+	}
+	return report, nil
+}
+
+// GetOpenReports returns up to limit unresolved reports, oldest first
+func (r *ReportRepository) GetOpenReports(limit int) ([]*interfaces.Report, error) {
+	query := `SELECT ` + reportColumns + ` FROM reports
+		WHERE resolved_at IS NULL
+		ORDER BY created_at, id LIMIT $1`
+
+	rows, err := r.db.Query(query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get open reports: %w", err)
+	}
+	defer rows.Close()
+
+	var reports []*interfaces.Report
+	for rows.Next() {
+		report, err := scanReport(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan report: %w", err)
+		}
+		reports = append(reports, report)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read reports: %w", err)
+	}
+
+	return reports, nil
+}
+
+// ResolveReport closes an open report, recording who resolved it and how
+func (r *ReportRepository) ResolveReport(reportID int64, resolvedBy, resolution string) error {
+	query := `
+		UPDATE reports SET resolved_at = $2, resolved_by = $3, resolution = $4
+		WHERE id = $1 AND resolved_at IS NULL`
+
+	result, err := r.db.Exec(query, reportID, time.Now(), resolvedBy, resolution)
+	if err != nil {
+		return fmt.Errorf("failed to resolve report: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return interfaces.ErrReportNotFound
+	}
+
+	return nil
+}
+
+// CountReportsSince returns how many reports the character has made since
+// the given time
+func (r *ReportRepository) CountReportsSince(reporterID string, since time.Time) (int, error) {
+	query := `SELECT COUNT(*) FROM reports WHERE reporter_id = $1 AND created_at >= $2`
+
+	var count int
+	if err := r.db.QueryRow(query, reporterID, since).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count reports: %w", err)
+	}
+	return count, nil
+}
+
+// rowScanner is a *sql.Row or *sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanReport reads a row selected with reportColumns into a Report
+func scanReport(row rowScanner) (*interfaces.Report, error) {
+	report := &interfaces.Report{}
+	var resolvedAt sql.NullTime
+
+	err := row.Scan(&report.ID, &report.ReporterID, &report.ReporterName, &report.TargetID,
+		&report.TargetName, &report.Reason, &report.RoomID, &report.CreatedAt, &resolvedAt,
+		&report.ResolvedBy, &report.Resolution)
+	if err != nil {
+		return nil, err
+	}
+	report.ResolvedAt = resolvedAt.Time
+	return report, nil
+}
@@ -0,0 +1,71 @@
+package postgres
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/elidor/dungeogo/pkg/persistence/interfaces"
+	"github.com/google/uuid"
+)
+
+func TestReportRepository_CreateAndResolve(t *testing.T) {
+	repoManager := setupTestDB(t)
+	if repoManager == nil {
+		t.Skip("Database not available for testing")
+	}
+
+	repo := repoManager.Reports()
+	reporterID, targetID := uuid.New().String(), uuid.New().String()
+	report := &interfaces.Report{
+		ReporterID:   reporterID,
+		ReporterName: "Alice",
+		TargetID:     targetID,
+		TargetName:   "Bob",
+		Reason:       "spamming the market square",
+		RoomID:       "town_square",
+	}
+	if err := repo.CreateReport(report); err != nil {
+		t.Fatalf("Failed to create report: %v", err)
+	}
+	if report.ID == 0 {
+		t.Fatalf("Expected the report to be given an ID")
+	}
+
+	open, err := repo.GetOpenReports(10)
+	if err != nil {
+		t.Fatalf("Failed to get open reports: %v", err)
+	}
+	if len(open) != 1 || open[0].TargetName != "Bob" || open[0].Reason != report.Reason || !open[0].IsOpen() {
+		t.Fatalf("Expected the open report about Bob, got %+v", open)
+	}
+
+	count, err := repo.CountReportsSince(reporterID, time.Now().Add(-time.Hour))
+	if err != nil || count != 1 {
+		t.Errorf("Expected 1 recent report, got %d (%v)", count, err)
+	}
+
+	if err := repo.ResolveReport(report.ID, "Moderator", "warned"); err != nil {
+		t.Fatalf("Failed to resolve report: %v", err)
+	}
+	if err := repo.ResolveReport(report.ID, "Moderator", "again"); !errors.Is(err, interfaces.ErrReportNotFound) {
+		t.Errorf("Expected a resolved report not to resolve again, got %v", err)
+	}
+
+	resolved, err := repo.GetReport(report.ID)
+	if err != nil {
+		t.Fatalf("Failed to get report: %v", err)
+	}
+	if resolved.IsOpen() || resolved.ResolvedBy != "Moderator" || resolved.Resolution != "warned" {
+		t.Errorf("Expected the report to be resolved, got %+v", resolved)
+	}
+
+	open, err = repo.GetOpenReports(10)
+	if err != nil || len(open) != 0 {
+		t.Errorf("Expected no open reports, got %d (%v)", len(open), err)
+	}
+
+	if _, err := repo.GetReport(report.ID + 1); !errors.Is(err, interfaces.ErrReportNotFound) {
+		t.Errorf("Expected ErrReportNotFound, got %v", err)
+	}
+}
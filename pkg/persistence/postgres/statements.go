@@ -0,0 +1,63 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// errStatementsClosed is returned when a query is prepared after the
+// repositories have been closed
+var errStatementsClosed = errors.New("prepared statements are closed")
+
+// statements prepares the queries on the hot paths the first time they run
+// and reuses them afterwards, so the server does not parse the same SQL on
+// every call. database/sql re-prepares a statement on each pooled connection
+// as it is needed.
+type statements struct {
+	db     *sql.DB
+	mutex  sync.Mutex
+	closed bool
+	stmts  map[string]*sql.Stmt
+}
+
+func newStatements(db *sql.DB) *statements {
+	return &statements{db: db, stmts: make(map[string]*sql.Stmt)}
+}
+
+// prepare returns the prepared statement for query, preparing it first if
+// this is the first time it has been run
+func (s *statements) prepare(query string) (*sql.Stmt, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.closed {
+		return nil, errStatementsClosed
+	}
+	if stmt, ok := s.stmts[query]; ok {
+		return stmt, nil
+	}
+
+	stmt, err := s.db.Prepare(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare statement: %w", err)
+	}
+	s.stmts[query] = stmt
+	return stmt, nil
+}
+
+// Close closes every statement prepared so far. Later calls to prepare fail.
+func (s *statements) Close() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.closed = true
+
+	var errs []error
+	for query, stmt := range s.stmts {
+		if err := stmt.Close(); err != nil {
+			errs = append(errs, err)
+		}
+		delete(s.stmts, query)
+	}
+	return errors.Join(errs...)
+}
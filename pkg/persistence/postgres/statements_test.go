@@ -0,0 +1,85 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"sync/atomic"
+	"testing"
+)
+
+// countingConnector hands out connections that count the statements
+// prepared and closed on them
+type countingConnector struct {
+	prepared atomic.Int32
+	closed   atomic.Int32
+}
+
+func (c *countingConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	return &countingConn{connector: c}, nil
+}
+
+func (c *countingConnector) Driver() driver.Driver {
+	return nil
+}
+
+type countingConn struct {
+	driver.Conn
+	connector *countingConnector
+}
+
+func (c *countingConn) Prepare(query string) (driver.Stmt, error) {
+	c.connector.prepared.Add(1)
+	return &countingStmt{connector: c.connector}, nil
+}
+
+func (c *countingConn) Close() error {
+	return nil
+}
+
+type countingStmt struct {
+	driver.Stmt
+	connector *countingConnector
+}
+
+func (s *countingStmt) Close() error {
+	s.connector.closed.Add(1)
+	return nil
+}
+
+func TestStatementsPrepareOnce(t *testing.T) {
+	connector := &countingConnector{}
+	db := sql.OpenDB(connector)
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+	stmts := newStatements(db)
+
+	first, err := stmts.prepare("SELECT 1")
+	if err != nil {
+		t.Fatalf("prepare failed: %v", err)
+	}
+	second, err := stmts.prepare("SELECT 1")
+	if err != nil {
+		t.Fatalf("prepare failed: %v", err)
+	}
+	if first != second {
+		t.Errorf("Expected the same query to reuse its statement")
+	}
+	if _, err := stmts.prepare("SELECT 2"); err != nil {
+		t.Fatalf("prepare failed: %v", err)
+	}
+	if got := connector.prepared.Load(); got != 2 {
+		t.Errorf("Expected 2 statements prepared, got %d", got)
+	}
+
+	if err := stmts.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if got := connector.closed.Load(); got != 2 {
+		t.Errorf("Expected both statements closed, got %d", got)
+	}
+	if _, err := stmts.prepare("SELECT 1"); !errors.Is(err, errStatementsClosed) {
+		t.Errorf("Expected preparing after Close to fail, got %v", err)
+	}
+}
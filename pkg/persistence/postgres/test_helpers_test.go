@@ -15,7 +15,7 @@ import (
 )
 
 // setupTestDB creates a test database with schema for testing
-func setupTestDB(t *testing.T) *PostgreSQLRepositoryManager {
+func setupTestDB(t testing.TB) *PostgreSQLRepositoryManager {
 	// Generate unique database name
 	testDBName := fmt.Sprintf("dungeogo_test_%d", time.Now().UnixNano())
 
@@ -71,8 +71,7 @@ func setupTestDB(t *testing.T) *PostgreSQLRepositoryManager {
 	}
 
 	// Create schema
-	err = createTestSchema(repoManager)
-	if err != nil {
+	if _, err := repoManager.Migrate(); err != nil {
 		repoManager.Close()
 		t.Fatalf("Failed to create test schema: %v", err)
 	}
@@ -86,68 +85,6 @@ func setupTestDB(t *testing.T) *PostgreSQLRepositoryManager {
 	return repoManager
 }
 
-func createTestSchema(repoManager *PostgreSQLRepositoryManager) error {
-	schema := `
-	CREATE EXTENSION IF NOT EXISTS "pgcrypto";
-	
-	CREATE TABLE players (
-		id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
-		username VARCHAR(50) UNIQUE NOT NULL,
-		email VARCHAR(255) UNIQUE NOT NULL,
-		password_hash VARCHAR(255) NOT NULL,
-		created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
-		last_login TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
-		account_status INTEGER DEFAULT 0,
-		subscription JSONB,
-		preferences JSONB NOT NULL DEFAULT '{}',
-		max_characters INTEGER DEFAULT 5,
-		current_character_id UUID
-	);
-
-	CREATE TABLE characters (
-		id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
-		player_id UUID NOT NULL REFERENCES players(id) ON DELETE CASCADE,
-		name VARCHAR(50) UNIQUE NOT NULL,
-		race_id VARCHAR(50) NOT NULL,
-		class_id VARCHAR(50) NOT NULL,
-		stats JSONB NOT NULL DEFAULT '{}',
-		skills JSONB NOT NULL DEFAULT '{}',
-		location JSONB NOT NULL DEFAULT '{}',
-		state INTEGER DEFAULT 0,
-		created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
-		last_played TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
-		play_time INTERVAL DEFAULT '0 seconds',
-		level INTEGER DEFAULT 1,
-		experience INTEGER DEFAULT 0,
-		death_count INTEGER DEFAULT 0,
-		kill_count INTEGER DEFAULT 0,
-		description TEXT DEFAULT '',
-		appearance JSONB NOT NULL DEFAULT '{}'
-	);
-
-	CREATE TABLE item_instances (
-		id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
-		template_id VARCHAR(100) NOT NULL,
-		owner_id UUID NOT NULL,
-		quantity INTEGER DEFAULT 1,
-		durability INTEGER DEFAULT 100,
-		enchantments JSONB NOT NULL DEFAULT '[]',
-		custom_name VARCHAR(255),
-		modifications JSONB NOT NULL DEFAULT '{}',
-		created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
-		last_used TIMESTAMP WITH TIME ZONE
-	);
-
-	CREATE INDEX idx_characters_player_id ON characters(player_id);
-	CREATE INDEX idx_characters_name ON characters(name);
-	CREATE INDEX idx_item_instances_owner ON item_instances(owner_id);
-	CREATE INDEX idx_item_instances_template ON item_instances(template_id);
-	`
-
-	_, err := repoManager.GetDB().Exec(schema)
-	return err
-}
-
 func cleanupTestDatabase(dbName string) {
 	// Try containerized postgres first, then local postgres
 	adminConnStrings := []string{
@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"time"
 	
 	"github.com/elidor/dungeogo/pkg/persistence/interfaces"
 )
@@ -37,6 +38,10 @@ func (r *WorldRepository) SaveRoomState(roomID string, state *interfaces.RoomSta
 		return fmt.Errorf("failed to marshal room players: %w", err)
 	}
 	
+	if state.LastUpdate.IsZero() {
+		state.LastUpdate = time.Now()
+	}
+	
 	query := `
 		INSERT INTO room_states (room_id, items, npcs, players, flags, last_update)
 		VALUES ($1, $2, $3, $4, $5, $6)
@@ -108,6 +113,10 @@ func (r *WorldRepository) SaveNPCState(npcID string, state *interfaces.NPCState)
 		return fmt.Errorf("failed to marshal npc inventory: %w", err)
 	}
 	
+	if state.LastUpdate.IsZero() {
+		state.LastUpdate = time.Now()
+	}
+	
 	query := `
 		INSERT INTO npc_states (npc_id, template_id, health, location, inventory, state, last_update)
 		VALUES ($1, $2, $3, $4, $5, $6, $7)
@@ -138,7 +147,7 @@ func (r *WorldRepository) LoadNPCState(npcID string) (*interfaces.NPCState, erro
 	
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("npc state not found: %s", npcID)
+			return nil, fmt.Errorf("%w: %s", interfaces.ErrNPCNotFound, npcID)
 		}
 		return nil, fmt.Errorf("failed to load npc state: %w", err)
 	}
@@ -160,6 +169,15 @@ func (r *WorldRepository) SaveWorldEvent(event *interfaces.WorldEvent) error {
 		return fmt.Errorf("failed to marshal world event data: %w", err)
 	}
 	
+	if event.StartTime.IsZero() {
+		event.StartTime = time.Now()
+	}
+	
+	var endTime interface{}
+	if !event.EndTime.IsZero() {
+		endTime = event.EndTime
+	}
+	
 	query := `
 		INSERT INTO world_events (id, type, description, start_time, end_time, data)
 		VALUES ($1, $2, $3, $4, $5, $6)
@@ -167,7 +185,7 @@ func (r *WorldRepository) SaveWorldEvent(event *interfaces.WorldEvent) error {
 			type = $2, description = $3, start_time = $4, end_time = $5, data = $6`
 	
 	_, err = r.db.Exec(query, event.ID, event.Type, event.Description, 
-		event.StartTime, event.EndTime, dataJSON)
+		event.StartTime, endTime, dataJSON)
 	
 	if err != nil {
 		return fmt.Errorf("failed to save world event: %w", err)
@@ -193,12 +211,14 @@ func (r *WorldRepository) GetActiveWorldEvents() ([]*interfaces.WorldEvent, erro
 	for rows.Next() {
 		event := &interfaces.WorldEvent{}
 		var dataJSON []byte
+		var endTime sql.NullTime
 		
 		err := rows.Scan(&event.ID, &event.Type, &event.Description,
-			&event.StartTime, &event.EndTime, &dataJSON)
+			&event.StartTime, &endTime, &dataJSON)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan world event: %w", err)
 		}
+		event.EndTime = endTime.Time
 		
 		if err := json.Unmarshal(dataJSON, &event.Data); err != nil {
 			return nil, fmt.Errorf("failed to unmarshal world event data: %w", err)
@@ -0,0 +1,287 @@
+// Package transfer exports characters to self-contained JSON documents and
+// imports them again, for backups and for moving characters between servers.
+package transfer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/elidor/dungeogo/pkg/game/achievement"
+	"github.com/elidor/dungeogo/pkg/game/character"
+	"github.com/elidor/dungeogo/pkg/game/items"
+	"github.com/elidor/dungeogo/pkg/game/magic"
+	"github.com/elidor/dungeogo/pkg/game/quest"
+	"github.com/elidor/dungeogo/pkg/persistence/interfaces"
+)
+
+// FormatVersion is bumped whenever the export layout changes incompatibly.
+const FormatVersion = 1
+
+var (
+	ErrUnsupportedVersion = errors.New("unsupported export version")
+	ErrNameTaken          = errors.New("character name is already taken")
+)
+
+// CharacterExport is everything needed to recreate a character elsewhere.
+// Item IDs are only meaningful within the export: Equipment refers to them.
+type CharacterExport struct {
+	Version      int
+	ExportedAt   time.Time
+	ID           string
+	Name         string
+	RaceID       string
+	ClassID      string
+	Stats        *character.CharacterStats
+	Skills       *character.SkillSet
+	Location     *character.Location
+	State        character.CharacterState
+	CreatedAt    time.Time
+	PlayTime     time.Duration
+	Level        int
+	Experience   int
+	DeathCount   int
+	KillCount    int
+	Gold         int
+	Description  string
+	Profile      string
+	Appearance   character.CharacterAppearance
+	Quests       *quest.Log
+	Achievements *achievement.Log
+	Spellbook    *magic.Spellbook
+	TutorialStep int
+	Items        []*items.ItemInstance
+	Equipment    map[items.EquipSlot]string
+}
+
+// ImportOptions controls where an imported character ends up.
+type ImportOptions struct {
+	// PlayerID owns the imported character. Required for new characters;
+	// when overwriting it defaults to the existing owner.
+	PlayerID string
+	// OverwriteID replaces the character with this ID, keeping the ID,
+	// instead of creating a new one. Its items are replaced too.
+	OverwriteID string
+	// Items holds the templates carried items must be made from. It
+	// defaults to the templates built into the game.
+	Items *items.ItemFactory
+}
+
+// ExportCharacter returns the character with characterID, its carried items
+// and its equipment as indented JSON.
+func ExportCharacter(repoManager interfaces.RepositoryManager, characterID string) ([]byte, error) {
+	c, err := repoManager.Characters().GetCharacter(characterID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load character: %w", err)
+	}
+
+	carried, err := repoManager.Items().GetPlayerItems(characterID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load items: %w", err)
+	}
+
+	export := &CharacterExport{
+		Version:      FormatVersion,
+		ExportedAt:   time.Now(),
+		ID:           c.ID,
+		Name:         c.Name,
+		Stats:        c.Stats,
+		Skills:       c.Skills,
+		Location:     c.Location,
+		State:        c.State,
+		CreatedAt:    c.CreatedAt,
+		PlayTime:     c.PlayTime,
+		Level:        c.Level,
+		Experience:   c.Experience,
+		DeathCount:   c.DeathCount,
+		KillCount:    c.KillCount,
+		Gold:         c.Gold,
+		Description:  c.Description,
+		Profile:      c.Profile,
+		Appearance:   c.Appearance,
+		Quests:       c.Quests,
+		Achievements: c.Achievements,
+		Spellbook:    c.Spellbook,
+		TutorialStep: c.TutorialStep,
+		Items:        carried,
+		Equipment:    c.EquipmentIDs(),
+	}
+	if c.Race != nil {
+		export.RaceID = c.Race.ID
+	}
+	if c.Class != nil {
+		export.ClassID = c.Class.ID
+	}
+
+	data, err := json.MarshalIndent(export, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal character export: %w", err)
+	}
+	return data, nil
+}
+
+// ImportCharacter validates an export and stores it, either as a new
+// character with fresh IDs or over an existing one.
+func ImportCharacter(repoManager interfaces.RepositoryManager, data []byte, opts ImportOptions) (*character.Character, error) {
+	var export CharacterExport
+	if err := json.Unmarshal(data, &export); err != nil {
+		return nil, fmt.Errorf("failed to parse character export: %w", err)
+	}
+
+	if opts.Items == nil {
+		opts.Items = items.NewItemFactory()
+	}
+	c, err := buildCharacter(&export, opts.Items)
+	if err != nil {
+		return nil, err
+	}
+
+	var existing *character.Character
+	if opts.OverwriteID != "" {
+		existing, err = repoManager.Characters().GetCharacter(opts.OverwriteID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load character to overwrite: %w", err)
+		}
+		c.ID = existing.ID
+		c.PlayerID = existing.PlayerID
+	}
+	if opts.PlayerID != "" {
+		c.PlayerID = opts.PlayerID
+	}
+	if c.PlayerID == "" {
+		return nil, fmt.Errorf("an owning player is required to import a new character")
+	}
+	if _, err := repoManager.Players().GetPlayer(c.PlayerID); err != nil {
+		return nil, fmt.Errorf("failed to load owning player: %w", err)
+	}
+
+	if other, err := repoManager.Characters().GetCharacterByName(c.Name); err == nil {
+		if existing == nil || other.ID != existing.ID {
+			return nil, ErrNameTaken
+		}
+	} else if !errors.Is(err, interfaces.ErrCharacterNotFound) {
+		return nil, fmt.Errorf("failed to check character name: %w", err)
+	}
+
+	carried, equipment := copyItems(&export, c.ID)
+	c.Equipment = equipment
+
+	// Overwriting replaces the old row outright, since name, race and class
+	// are fixed once a character is created.
+	var replaceID string
+	if existing != nil {
+		replaceID = existing.ID
+	}
+	if err := repoManager.Characters().ImportCharacter(c, carried, replaceID); err != nil {
+		return nil, fmt.Errorf("failed to import character: %w", err)
+	}
+	return c, nil
+}
+
+// buildCharacter checks an export against this server's races, classes and
+// item templates and turns it into a character with a fresh ID.
+func buildCharacter(export *CharacterExport, factory *items.ItemFactory) (*character.Character, error) {
+	if export.Version != FormatVersion {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedVersion, export.Version)
+	}
+
+	name, err := character.ValidateName(strings.TrimSpace(export.Name))
+	if err != nil {
+		return nil, fmt.Errorf("invalid character name %q: %w", export.Name, err)
+	}
+
+	race, err := character.GetRaceByID(export.RaceID)
+	if err != nil {
+		return nil, fmt.Errorf("unknown race %q: %w", export.RaceID, err)
+	}
+	class, err := character.GetClassByID(export.ClassID)
+	if err != nil {
+		return nil, fmt.Errorf("unknown class %q: %w", export.ClassID, err)
+	}
+
+	carriedIDs := make(map[string]bool, len(export.Items))
+	for _, item := range export.Items {
+		if item == nil {
+			return nil, fmt.Errorf("export contains an empty item")
+		}
+		if _, err := factory.GetTemplate(item.TemplateID); err != nil {
+			return nil, fmt.Errorf("unknown item template %q: %w", item.TemplateID, err)
+		}
+		carriedIDs[item.ID] = true
+	}
+	for slot, itemID := range export.Equipment {
+		if !carriedIDs[itemID] {
+			return nil, fmt.Errorf("equipped %s item %s is not in the export", slot, itemID)
+		}
+	}
+
+	c := character.NewCharacter("", name, race, class)
+	if export.Stats != nil {
+		c.Stats = export.Stats
+	}
+	if export.Skills != nil {
+		c.Skills = export.Skills
+	}
+	if export.Location != nil {
+		c.Location = export.Location
+	}
+	if export.Quests != nil {
+		c.Quests = export.Quests
+	}
+	if export.Achievements != nil {
+		c.Achievements = export.Achievements
+	}
+	if export.Spellbook != nil {
+		c.Spellbook = export.Spellbook
+	}
+	if !export.CreatedAt.IsZero() {
+		c.CreatedAt = export.CreatedAt
+	}
+	c.State = export.State
+	c.PlayTime = export.PlayTime
+	c.Level = export.Level
+	c.Experience = export.Experience
+	c.DeathCount = export.DeathCount
+	c.KillCount = export.KillCount
+	c.Gold = export.Gold
+	if export.Description != "" {
+		if err := c.SetDescription(strings.Split(export.Description, "\n")); err != nil {
+			return nil, fmt.Errorf("invalid description: %w", err)
+		}
+	}
+	if err := c.SetProfile(export.Profile); err != nil {
+		return nil, fmt.Errorf("invalid profile: %w", err)
+	}
+	for _, field := range character.AppearanceFields {
+		value, _ := export.Appearance.Get(field)
+		if err := c.Appearance.Set(field, value); err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", field, err)
+		}
+	}
+	c.TutorialStep = export.TutorialStep
+	return c, nil
+}
+
+// copyItems gives every exported item a new ID owned by ownerID and maps the
+// exported equipment onto the copies.
+func copyItems(export *CharacterExport, ownerID string) ([]*items.ItemInstance, map[items.EquipSlot]*items.ItemInstance) {
+	copies := make([]*items.ItemInstance, 0, len(export.Items))
+	byOldID := make(map[string]*items.ItemInstance, len(export.Items))
+	for _, item := range export.Items {
+		copied := *item
+		copied.ID = uuid.New().String()
+		copied.OwnerID = ownerID
+		copies = append(copies, &copied)
+		byOldID[item.ID] = &copied
+	}
+
+	equipment := make(map[items.EquipSlot]*items.ItemInstance, len(export.Equipment))
+	for slot, itemID := range export.Equipment {
+		equipment[slot] = byOldID[itemID]
+	}
+	return copies, equipment
+}
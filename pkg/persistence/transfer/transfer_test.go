@@ -0,0 +1,162 @@
+package transfer
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/elidor/dungeogo/pkg/game/character"
+	"github.com/elidor/dungeogo/pkg/game/items"
+	"github.com/elidor/dungeogo/pkg/testutil"
+)
+
+func testExport() *CharacterExport {
+	return &CharacterExport{
+		Version: FormatVersion,
+		Name:    "Wanderer",
+		RaceID:  "elf",
+		ClassID: "mage",
+		Level:   7,
+		Gold:    120,
+		Items: []*items.ItemInstance{
+			{ID: "old-sword", TemplateID: "rusty_sword", Quantity: 1, Durability: 80},
+			{ID: "old-potion", TemplateID: "health_potion", Quantity: 3, Durability: 100},
+		},
+		Equipment: map[items.EquipSlot]string{items.SlotMainHand: "old-sword"},
+	}
+}
+
+func TestBuildCharacter(t *testing.T) {
+	c, err := buildCharacter(testExport(), items.NewItemFactory())
+	if err != nil {
+		t.Fatalf("Failed to build character: %v", err)
+	}
+
+	if c.Name != "Wanderer" || c.Race.ID != "elf" || c.Class.ID != "mage" {
+		t.Errorf("Unexpected identity: %s %s %s", c.Name, c.Race.ID, c.Class.ID)
+	}
+	if c.Level != 7 || c.Gold != 120 {
+		t.Errorf("Expected level 7 and 120 gold, got %d and %d", c.Level, c.Gold)
+	}
+	if c.ID == "" {
+		t.Errorf("Expected a fresh character ID")
+	}
+}
+
+func TestBuildCharacter_Validation(t *testing.T) {
+	tests := map[string]func(e *CharacterExport){
+		"version":   func(e *CharacterExport) { e.Version = 99 },
+		"name":      func(e *CharacterExport) { e.Name = "  " },
+		"policy":    func(e *CharacterExport) { e.Name = "Wanderer99" },
+		"profile":   func(e *CharacterExport) { e.Profile = strings.Repeat("a", character.MaxProfileLength+1) },
+		"hair":      func(e *CharacterExport) { e.Appearance.HairColor = "red\x07" },
+		"race":      func(e *CharacterExport) { e.RaceID = "dragon" },
+		"class":     func(e *CharacterExport) { e.ClassID = "bard" },
+		"template":  func(e *CharacterExport) { e.Items[0].TemplateID = "no_such_item" },
+		"equipment": func(e *CharacterExport) { e.Equipment[items.SlotHead] = "missing" },
+	}
+
+	for name, mutate := range tests {
+		export := testExport()
+		mutate(export)
+		if _, err := buildCharacter(export, items.NewItemFactory()); err == nil {
+			t.Errorf("%s: expected validation error", name)
+		}
+	}
+
+	export := testExport()
+	export.Version = 2
+	if _, err := buildCharacter(export, items.NewItemFactory()); !errors.Is(err, ErrUnsupportedVersion) {
+		t.Errorf("Expected ErrUnsupportedVersion, got %v", err)
+	}
+}
+
+func TestCopyItems_RemapsEquipment(t *testing.T) {
+	carried, equipment := copyItems(testExport(), "new-owner")
+
+	if len(carried) != 2 {
+		t.Fatalf("Expected 2 items, got %d", len(carried))
+	}
+	for _, item := range carried {
+		if item.OwnerID != "new-owner" {
+			t.Errorf("Expected item owned by new-owner, got %s", item.OwnerID)
+		}
+		if item.ID == "old-sword" || item.ID == "old-potion" {
+			t.Errorf("Expected item to get a fresh ID, kept %s", item.ID)
+		}
+	}
+
+	sword := equipment[items.SlotMainHand]
+	if sword == nil || sword.ID != carried[0].ID {
+		t.Errorf("Expected main hand to hold the copied sword, got %+v", sword)
+	}
+}
+
+func TestExportImportRoundTrip(t *testing.T) {
+	repoManager := testutil.SetupTestDB(t)
+	if repoManager == nil {
+		t.Skip("No database available for testing")
+	}
+
+	owner := testutil.CreateTestPlayer()
+	if err := repoManager.Players().CreatePlayer(owner); err != nil {
+		t.Fatalf("Failed to create player: %v", err)
+	}
+	original := testutil.CreateTestCharacter(owner.ID)
+	original.Gold = 42
+	if err := repoManager.Characters().CreateCharacter(original); err != nil {
+		t.Fatalf("Failed to create character: %v", err)
+	}
+	sword := testutil.CreateTestItemInstance("rusty_sword", original.ID)
+	if err := repoManager.Items().CreateItemInstance(sword); err != nil {
+		t.Fatalf("Failed to create item: %v", err)
+	}
+	original.Equip(items.SlotMainHand, sword)
+	if err := repoManager.Characters().UpdateCharacter(original); err != nil {
+		t.Fatalf("Failed to equip item: %v", err)
+	}
+
+	data, err := ExportCharacter(repoManager, original.ID)
+	if err != nil {
+		t.Fatalf("Failed to export: %v", err)
+	}
+
+	// Importing under the same name must fail while the original exists
+	if _, err := ImportCharacter(repoManager, data, ImportOptions{PlayerID: owner.ID}); !errors.Is(err, ErrNameTaken) {
+		t.Errorf("Expected ErrNameTaken, got %v", err)
+	}
+
+	var export CharacterExport
+	if err := json.Unmarshal(data, &export); err != nil {
+		t.Fatalf("Failed to parse export: %v", err)
+	}
+	export.Name = "TestCharCopy"
+	renamed, _ := json.Marshal(export)
+
+	imported, err := ImportCharacter(repoManager, renamed, ImportOptions{PlayerID: owner.ID})
+	if err != nil {
+		t.Fatalf("Failed to import: %v", err)
+	}
+
+	loaded, err := repoManager.Characters().GetCharacter(imported.ID)
+	if err != nil {
+		t.Fatalf("Failed to load imported character: %v", err)
+	}
+	if loaded.Gold != 42 {
+		t.Errorf("Expected 42 gold, got %d", loaded.Gold)
+	}
+	equipped := loaded.Equipment[items.SlotMainHand]
+	if equipped == nil || equipped.ID == sword.ID || equipped.OwnerID != imported.ID {
+		t.Errorf("Expected a copied, equipped sword, got %+v", equipped)
+	}
+
+	// Overwriting keeps the target's ID
+	overwritten, err := ImportCharacter(repoManager, data, ImportOptions{OverwriteID: original.ID})
+	if err != nil {
+		t.Fatalf("Failed to overwrite: %v", err)
+	}
+	if overwritten.ID != original.ID {
+		t.Errorf("Expected overwrite to keep ID %s, got %s", original.ID, overwritten.ID)
+	}
+}
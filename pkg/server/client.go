@@ -3,23 +3,52 @@ package server
 import (
 	"bufio"
 	"net"
+	"strings"
 	"sync"
 	"time"
+	
+	"github.com/elidor/dungeogo/pkg/textutil"
 )
 
+// DefaultMaxLineLength is the longest line, in bytes, a client may send when
+// MAX_LINE_LENGTH is unset
+const DefaultMaxLineLength = 512
+
+// DefaultWriteTimeout is how long a write to a client may block when
+// WRITE_TIMEOUT is unset
+const DefaultWriteTimeout = 10 * time.Second
+
+// outputQueueSize is how many writes may wait for a client before it is
+// taken to have stopped reading
+const outputQueueSize = 256
+
+// closeFlushTimeout is how long Close waits for queued output to be written
+const closeFlushTimeout = time.Second
+
 type Client struct {
 	ID         string
 	conn       net.Conn
 	reader     *bufio.Reader
 	writer     *bufio.Writer
+	output     chan func() error // Writes waiting for the writer goroutine
+	written    chan struct{}     // Closed once the writer goroutine stops
 	connected  bool
 	playerID   string
 	characterID string
+	roomID     string
 	state      ClientState
 	lastActive time.Time
 	tempUsername string // For storing username during account creation
 	tempPassword string // For storing password during confirmation
 	tempEmail    string // For storing email during account creation
+	premium      bool   // Whether the logged in player has premium
+	queuedAccount string // Premium account named while waiting in the queue
+	commandRate  int    // Commands allowed each second, 0 for no limit
+	rateWindow   time.Time
+	rateCount    int
+	maxLineLength int // Bytes of each line kept; the rest is discarded
+	writeTimeout time.Duration // How long a write may block before the client is dropped, 0 for no limit
+	sessionStart time.Time // When the current character entered the game
 	mutex      sync.RWMutex
 }
 
@@ -33,88 +62,209 @@ const (
 	StateCharacterSelection
 	StateInGame
 	StateDisconnecting
+	StateVerifyingCurrentPassword
+	StateChangingPassword
 )
 
 func NewClient(id string, conn net.Conn) *Client {
-	return &Client{
+	c := &Client{
 		ID:         id,
 		conn:       conn,
 		reader:     bufio.NewReader(conn),
 		writer:     bufio.NewWriter(conn),
+		output:     make(chan func() error, outputQueueSize),
+		written:    make(chan struct{}),
 		connected:  true,
 		state:      StateConnected,
 		lastActive: time.Now(),
+		maxLineLength: DefaultMaxLineLength,
 	}
+	go c.writeLoop()
+	return c
 }
 
+// Send queues a line for the client. It never waits on the network; a
+// client too slow to keep up is disconnected instead.
 func (c *Client) Send(message string) error {
-	c.mutex.Lock()
-	defer c.mutex.Unlock()
-	
-	if !c.connected {
-		return ErrClientDisconnected
-	}
-	
-	_, err := c.writer.WriteString(message + "\r\n")
-	if err != nil {
-		return err
+	return c.enqueue(func() error {
+		return c.write(message + "\r\n")
+	})
+}
+
+// SendLines queues several lines to be written together, so multi-line
+// output reaches the client in one write instead of one per line.
+func (c *Client) SendLines(lines []string) error {
+	if len(lines) == 0 {
+		return nil
 	}
 	
-	return c.writer.Flush()
+	text := strings.Join(lines, "\r\n") + "\r\n"
+	return c.enqueue(func() error {
+		return c.write(text)
+	})
 }
 
 func (c *Client) SendPrompt(prompt string) error {
+	return c.enqueue(func() error {
+		return c.write(prompt)
+	})
+}
+
+// enqueue queues write for the writer goroutine. A client whose queue is
+// full has stopped taking output, so it is disconnected.
+func (c *Client) enqueue(write func() error) error {
 	c.mutex.Lock()
-	defer c.mutex.Unlock()
-	
 	if !c.connected {
+		c.mutex.Unlock()
 		return ErrClientDisconnected
 	}
 	
-	_, err := c.writer.WriteString(prompt)
-	if err != nil {
+	select {
+	case c.output <- write:
+		c.mutex.Unlock()
+		return nil
+	default:
+	}
+	c.disconnect()
+	c.mutex.Unlock()
+	c.conn.Close()
+	return ErrOutputQueueFull
+}
+
+// write writes text and flushes it. Only the writer goroutine calls it.
+func (c *Client) write(text string) error {
+	if _, err := c.writer.WriteString(text); err != nil {
 		return err
 	}
-	
 	return c.writer.Flush()
 }
 
+// writeLoop runs each queued write in order until the queue is closed. A
+// write that fails or takes longer than the write timeout means the client
+// is stuck or gone, so it is disconnected rather than left to block.
+func (c *Client) writeLoop() {
+	defer close(c.written)
+	
+	for write := range c.output {
+		if timeout := c.getWriteTimeout(); timeout > 0 {
+			c.conn.SetWriteDeadline(time.Now().Add(timeout))
+		}
+		if err := write(); err != nil {
+			c.mutex.Lock()
+			if c.connected {
+				c.disconnect()
+			}
+			c.mutex.Unlock()
+			c.conn.Close()
+			return
+		}
+	}
+}
+
+// disconnect stops the client taking more output. The caller must hold the mutex, the client must be connected, and the
+// caller closes the connection once the mutex is released.
+func (c *Client) disconnect() {
+	c.connected = false
+	c.state = StateDisconnecting
+	close(c.output)
+}
+
+// ReadLine reads a line of input, cut to the client's maximum line length
+// and with control characters removed
 func (c *Client) ReadLine() (string, error) {
 	c.updateLastActive()
-	line, err := c.reader.ReadString('\n')
-	if err != nil {
-		return "", err
-	}
+	limit := c.getMaxLineLength()
 	
-	// Remove trailing newline and carriage return
-	if len(line) > 0 && line[len(line)-1] == '\n' {
-		line = line[:len(line)-1]
-	}
-	if len(line) > 0 && line[len(line)-1] == '\r' {
-		line = line[:len(line)-1]
+	// Read in buffer-sized pieces so an endless line never has to be held in
+	// memory: anything past the limit is read and thrown away
+	var line []byte
+	for {
+		chunk, err := c.reader.ReadSlice('\n')
+		if room := limit - len(line); room > 0 {
+			line = append(line, chunk[:min(len(chunk), room)]...)
+		}
+		if err == bufio.ErrBufferFull {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		break
 	}
 	
-	return line, nil
+	// Sanitizing also drops the line ending and any character the limit cut in half
+	return textutil.Sanitize(string(line)), nil
+}
+
+// SetMaxLineLength sets how many bytes of each line the client sends are kept
+func (c *Client) SetMaxLineLength(length int) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.maxLineLength = length
+}
+
+// SetWriteTimeout sets how long a write to the client may block before it
+// is disconnected; 0 lets writes block forever
+func (c *Client) SetWriteTimeout(timeout time.Duration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.writeTimeout = timeout
+}
+
+func (c *Client) getWriteTimeout() time.Duration {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.writeTimeout
+}
+
+func (c *Client) getMaxLineLength() int {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.maxLineLength
+}
+
+// echoController is implemented by connections that switch input echo
+// themselves rather than through telnet negotiation, such as WebSockets.
+type echoController interface {
+	SetEcho(enabled bool) error
+}
+
+// setEcho queues switching the client's own echo on or off, in order with
+// the output around it
+func (c *Client) setEcho(enabled bool) error {
+	return c.enqueue(func() error {
+		if controller, ok := c.conn.(echoController); ok {
+			return controller.SetEcho(enabled)
+		}
+		
+		if enabled {
+			// IAC WONT ECHO - tell client we won't handle echoing anymore
+			_, err := c.conn.Write([]byte{255, 252, 1})
+			return err
+		}
+		// IAC WILL ECHO tells the client we (server) will handle echoing
+		_, err := c.conn.Write([]byte{255, 251, 1})
+		return err
+	})
 }
 
 // ReadPassword reads a password from the client with echo disabled
 func (c *Client) ReadPassword() (string, error) {
 	c.updateLastActive()
 	
-	// Send telnet command to disable echo
-	// IAC WILL ECHO tells the client we (server) will handle echoing
-	_, err := c.conn.Write([]byte{255, 251, 1}) // IAC WILL ECHO
-	if err != nil {
+	// Disable echo while the password is typed
+	if err := c.setEcho(false); err != nil {
 		return "", err
 	}
 	
 	// Read the password, handling potential telnet control sequences
-	var line string
+	limit := c.getMaxLineLength()
+	var line []byte
 	for {
 		char, err := c.reader.ReadByte()
 		if err != nil {
 			// Re-enable echo before returning error
-			c.conn.Write([]byte{255, 252, 1}) // IAC WONT ECHO
+			c.setEcho(true)
 			return "", err
 		}
 		
@@ -136,21 +286,21 @@ func (c *Client) ReadPassword() (string, error) {
 			continue
 		}
 		
-		// Add normal character to password
-		line += string(char)
+		// Add normal character to password, keeping multi-byte characters whole
+		if len(line) < limit {
+			line = append(line, char)
+		}
 	}
 	
-	// Re-enable echo - tell client we won't handle echoing anymore
-	_, err = c.conn.Write([]byte{255, 252, 1}) // IAC WONT ECHO
-	if err != nil {
+	// Re-enable echo
+	if err := c.setEcho(true); err != nil {
 		return "", err
 	}
 	
 	// Send a newline to the client since they won't see the echo
-	c.writer.WriteString("\r\n")
-	c.writer.Flush()
+	c.SendPrompt("\r\n")
 	
-	return line, nil
+	return textutil.Sanitize(string(line)), nil
 }
 
 func (c *Client) GetID() string {
@@ -163,16 +313,21 @@ func (c *Client) IsConnected() bool {
 	return c.connected
 }
 
+// Close stops taking output and hangs up once what is already queued has
+// been written, waiting no longer than closeFlushTimeout for it.
 func (c *Client) Close() error {
 	c.mutex.Lock()
-	defer c.mutex.Unlock()
-	
 	if !c.connected {
+		c.mutex.Unlock()
 		return nil
 	}
+	c.disconnect()
+	c.mutex.Unlock()
 	
-	c.connected = false
-	c.state = StateDisconnecting
+	select {
+	case <-c.written:
+	case <-time.After(closeFlushTimeout):
+	}
 	return c.conn.Close()
 }
 
@@ -200,6 +355,33 @@ func (c *Client) SetCharacterID(characterID string) {
 	c.characterID = characterID
 }
 
+// GetRoomID returns the room the client's character was last seen in
+func (c *Client) GetRoomID() string {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.roomID
+}
+
+func (c *Client) SetRoomID(roomID string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.roomID = roomID
+}
+
+// StartSession starts the clock on the character entering the game
+func (c *Client) StartSession(now time.Time) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.sessionStart = now
+}
+
+// GetSessionStart returns when the client's character entered the game
+func (c *Client) GetSessionStart() time.Time {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.sessionStart
+}
+
 func (c *Client) GetState() ClientState {
 	c.mutex.RLock()
 	defer c.mutex.RUnlock()
@@ -277,4 +459,50 @@ func (c *Client) ClearTempData() {
 	c.tempUsername = ""
 	c.tempPassword = ""
 	c.tempEmail = ""
-}
\ No newline at end of file
+}
+
+// SetPremium records the logged in player's premium status and the command
+// rate that comes with it.
+func (c *Client) SetPremium(premium bool, commandRate int) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.premium = premium
+	c.commandRate = commandRate
+}
+
+func (c *Client) IsPremium() bool {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.premium
+}
+
+// GetQueuedAccount returns the premium account the client named to move
+// ahead in the queue, which is the only one it may then log in as
+func (c *Client) GetQueuedAccount() string {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.queuedAccount
+}
+
+func (c *Client) setQueuedAccount(account string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.queuedAccount = account
+}
+
+// AllowCommand counts a command against the client's rate, reporting whether
+// it is within the limit for the current second.
+func (c *Client) AllowCommand(now time.Time) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	
+	if c.commandRate <= 0 {
+		return true
+	}
+	if now.Sub(c.rateWindow) >= time.Second {
+		c.rateWindow = now
+		c.rateCount = 0
+	}
+	c.rateCount++
+	return c.rateCount <= c.commandRate
+}
@@ -0,0 +1,181 @@
+package server
+
+import (
+	"bufio"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClientSendLines(t *testing.T) {
+	serverConn, peer := net.Pipe()
+	defer peer.Close()
+	client := NewClient("test", serverConn)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- client.SendLines([]string{"A Simple Room", "Exits: north"})
+	}()
+
+	reader := bufio.NewReader(peer)
+	for _, want := range []string{"A Simple Room\r\n", "Exits: north\r\n"} {
+		got, err := reader.ReadString('\n')
+		if err != nil {
+			t.Fatalf("Failed to read line: %v", err)
+		}
+		if got != want {
+			t.Errorf("Expected %q, got %q", want, got)
+		}
+	}
+
+	if err := <-done; err != nil {
+		t.Errorf("SendLines failed: %v", err)
+	}
+}
+
+func TestClientSendLinesAfterClose(t *testing.T) {
+	serverConn, peer := net.Pipe()
+	defer peer.Close()
+	client := NewClient("test", serverConn)
+	client.Close()
+
+	if err := client.SendLines([]string{"hello"}); err != ErrClientDisconnected {
+		t.Errorf("Expected ErrClientDisconnected, got %v", err)
+	}
+}
+
+func TestClientSendTimesOut(t *testing.T) {
+	serverConn, peer := net.Pipe()
+	defer peer.Close()
+	client := NewClient("test", serverConn)
+	client.SetWriteTimeout(50 * time.Millisecond)
+
+	// The peer never reads, so the write cannot finish, but queueing it
+	// does not wait
+	if err := client.Send("hello"); err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+	select {
+	case <-client.written:
+	case <-time.After(time.Second):
+		t.Fatalf("Expected the writer to give up on the stalled write")
+	}
+	if client.IsConnected() {
+		t.Errorf("Expected a client that timed out to be disconnected")
+	}
+	if err := client.Send("hello"); err != ErrClientDisconnected {
+		t.Errorf("Expected ErrClientDisconnected, got %v", err)
+	}
+}
+
+func TestClientSendDropsClientWithFullQueue(t *testing.T) {
+	serverConn, peer := net.Pipe()
+	defer peer.Close()
+	client := NewClient("test", serverConn)
+
+	// The writer holds the first line; the rest fill the queue
+	var err error
+	for i := 0; i <= outputQueueSize+1 && err == nil; i++ {
+		err = client.Send("spam")
+	}
+	if err != ErrOutputQueueFull {
+		t.Fatalf("Expected ErrOutputQueueFull, got %v", err)
+	}
+	if client.IsConnected() {
+		t.Errorf("Expected a client with a full queue to be disconnected")
+	}
+}
+
+func TestClientCloseFlushesQueuedOutput(t *testing.T) {
+	serverConn, peer := net.Pipe()
+	defer peer.Close()
+	client := NewClient("test", serverConn)
+
+	client.Send("Goodbye!")
+	go client.Close()
+
+	reader := bufio.NewReader(peer)
+	if line, err := reader.ReadString('\n'); err != nil || line != "Goodbye!\r\n" {
+		t.Errorf("Expected the farewell before hanging up, got %q, %v", line, err)
+	}
+	if _, err := reader.ReadString('\n'); err == nil {
+		t.Errorf("Expected the connection to be closed")
+	}
+}
+
+func TestClientAllowCommand(t *testing.T) {
+	serverConn, peer := net.Pipe()
+	defer peer.Close()
+	client := NewClient("test", serverConn)
+	now := time.Now()
+
+	// No limit until one is set
+	for i := 0; i < 100; i++ {
+		if !client.AllowCommand(now) {
+			t.Fatalf("Expected no limit before login")
+		}
+	}
+
+	client.SetPremium(false, 2)
+	if !client.AllowCommand(now) || !client.AllowCommand(now) {
+		t.Errorf("Expected two commands to be allowed")
+	}
+	if client.AllowCommand(now.Add(500 * time.Millisecond)) {
+		t.Errorf("Expected a third command in the same second to be refused")
+	}
+	if !client.AllowCommand(now.Add(time.Second)) {
+		t.Errorf("Expected the limit to reset after a second")
+	}
+}
+
+func TestClientReadLineSanitizes(t *testing.T) {
+	serverConn, peer := net.Pipe()
+	defer peer.Close()
+	client := NewClient("test", serverConn)
+
+	go peer.Write([]byte("say café\x1b[2J\x07 ok\r\n"))
+
+	line, err := client.ReadLine()
+	if err != nil {
+		t.Fatalf("ReadLine failed: %v", err)
+	}
+	if line != "say café[2J ok" {
+		t.Errorf("Expected control characters stripped, got %q", line)
+	}
+}
+
+func TestClientReadLineTruncates(t *testing.T) {
+	serverConn, peer := net.Pipe()
+	defer peer.Close()
+	client := NewClient("test", serverConn)
+	client.SetMaxLineLength(8)
+
+	// Far more than bufio's buffer, followed by a normal line
+	go peer.Write([]byte(strings.Repeat("a", 10000) + "\nlook\n"))
+
+	line, err := client.ReadLine()
+	if err != nil {
+		t.Fatalf("ReadLine failed: %v", err)
+	}
+	if line != "aaaaaaaa" {
+		t.Errorf("Expected the line cut to 8 bytes, got %q", line)
+	}
+	if line, _ := client.ReadLine(); line != "look" {
+		t.Errorf("Expected the next line intact, got %q", line)
+	}
+}
+
+func TestClientReadLineKeepsWholeCharacters(t *testing.T) {
+	serverConn, peer := net.Pipe()
+	defer peer.Close()
+	client := NewClient("test", serverConn)
+	client.SetMaxLineLength(3)
+
+	// The limit falls in the middle of the second é
+	go peer.Write([]byte("éé\n"))
+
+	if line, _ := client.ReadLine(); line != "é" {
+		t.Errorf("Expected the cut character dropped, got %q", line)
+	}
+}
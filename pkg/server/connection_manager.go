@@ -1,23 +1,45 @@
 package server
 
 import (
+	"errors"
 	"fmt"
 	"net"
+	"net/http"
 	"sync"
 	"time"
 	
+	"github.com/elidor/dungeogo/pkg/logging"
+	"github.com/elidor/dungeogo/pkg/metrics"
 	"github.com/google/uuid"
 )
 
+// DefaultMaxClients is how many players may connect when MAX_CLIENTS is unset
+const DefaultMaxClients = 100
+
+// serverFullMessage is sent to connections turned away at capacity
+const serverFullMessage = "The realm is full right now. Please try again in a few minutes."
+
 type ConnectionManager struct {
 	clients       map[string]*Client
 	playerClients map[string]*Client // playerID -> client mapping
 	mutex         sync.RWMutex
 	listener      net.Listener
+	wsServer      *http.Server
 	handler       ClientHandler
 	running       bool
 	maxClients    int
+	// reservedSlots are extra connections beyond maxClients that only
+	// premium players may keep
+	reservedSlots int
+	atCapacity    bool // whether the capacity warning has been logged
+	// queue holds connections waiting for a slot, up to queueSize of them
+	queue         []*queuedClient
+	queueSize     int
+	premiumLogin  func(client *Client, account, password string) bool
 	idleTimeout   time.Duration
+	maxLineLength int
+	writeTimeout  time.Duration
+	logger        *logging.Logger
 }
 
 type ClientHandler interface {
@@ -30,7 +52,87 @@ func NewConnectionManager(maxClients int, idleTimeout time.Duration) *Connection
 		playerClients: make(map[string]*Client),
 		maxClients:    maxClients,
 		idleTimeout:   idleTimeout,
+		maxLineLength: DefaultMaxLineLength,
+		writeTimeout:  DefaultWriteTimeout,
+		logger:        logging.Default(),
+	}
+}
+
+func (cm *ConnectionManager) SetLogger(logger *logging.Logger) {
+	cm.logger = logger
+}
+
+// SetReservedSlots keeps slots connections beyond the client limit for
+// premium players. Everyone may connect until all slots are used, but once
+// the server is over its limit only premium players stay after logging in.
+func (cm *ConnectionManager) SetReservedSlots(slots int) {
+	cm.reservedSlots = slots
+}
+
+// SetMaxLineLength caps how many bytes of each line new clients may send;
+// longer lines are cut short
+func (cm *ConnectionManager) SetMaxLineLength(length int) {
+	cm.maxLineLength = length
+}
+
+// SetWriteTimeout sets how long a write to a new client may block before the
+// client is disconnected; 0 lets writes block forever
+func (cm *ConnectionManager) SetWriteTimeout(timeout time.Duration) {
+	cm.writeTimeout = timeout
+}
+
+// IsFull reports whether the server is over its limit for players without
+// premium.
+func (cm *ConnectionManager) IsFull() bool {
+	return cm.getClientCount() > cm.maxClients
+}
+
+// admit registers conn as a new client if there is room for it, counting the
+// reserved slots. The first connection turned away logs that the server has
+// reached capacity.
+func (cm *ConnectionManager) admit(conn net.Conn) (*Client, bool) {
+	cm.mutex.Lock()
+	defer cm.mutex.Unlock()
+	
+	full := len(cm.clients) >= cm.maxClients+cm.reservedSlots
+	if cm.queueSize > 0 {
+		// No one gets ahead of the queue, and the reserved slots are kept
+		// for premium players waiting in it
+		full = len(cm.queue) > 0 || len(cm.clients) >= cm.maxClients
 	}
+	if full {
+		if !cm.atCapacity {
+			cm.atCapacity = true
+			cm.logger.Warnf("Server at capacity with %d clients; turning new connections away", len(cm.clients))
+		}
+		return nil, false
+	}
+	
+	client := cm.newClient(conn)
+	cm.clients[client.ID] = client
+	cm.logger.Infof("New client connected: %s from %s", client.ID, conn.RemoteAddr())
+	return client, true
+}
+
+// newClient wraps conn with the manager's client settings
+func (cm *ConnectionManager) newClient(conn net.Conn) *Client {
+	client := NewClient(uuid.New().String(), conn)
+	client.SetMaxLineLength(cm.maxLineLength)
+	client.SetWriteTimeout(cm.writeTimeout)
+	return client
+}
+
+// turnAway tells conn the server is full and hangs up
+func (cm *ConnectionManager) turnAway(conn net.Conn) {
+	cm.logger.Debugf("Turned away %s: server full", conn.RemoteAddr())
+	conn.Write([]byte(serverFullMessage + "\r\n"))
+	conn.Close()
+}
+
+// serve runs the handler for client and frees its slot once it returns
+func (cm *ConnectionManager) serve(client *Client) {
+	cm.handler.HandleClient(client)
+	cm.RemoveClient(client.ID)
 }
 
 func (cm *ConnectionManager) SetHandler(handler ClientHandler) {
@@ -48,6 +150,9 @@ func (cm *ConnectionManager) Start(address string) error {
 	
 	// Start cleanup goroutine
 	go cm.cleanupClients()
+	if cm.queueSize > 0 {
+		go cm.runQueue()
+	}
 	
 	// Accept connections
 	for cm.running {
@@ -56,71 +161,118 @@ func (cm *ConnectionManager) Start(address string) error {
 			if !cm.running {
 				break // Server is shutting down
 			}
-			fmt.Printf("Failed to accept connection: %v\n", err)
+			cm.logger.Warnf("Failed to accept connection: %v", err)
 			continue
 		}
 		
-		if cm.getClientCount() >= cm.maxClients {
-			conn.Write([]byte("Server is full. Please try again later.\r\n"))
-			conn.Close()
+		client, ok := cm.admit(conn)
+		if !ok {
+			if entry, queued := cm.enqueue(conn); queued {
+				go cm.serveQueued(entry)
+			} else {
+				cm.turnAway(conn)
+			}
 			continue
 		}
-		
-		client := cm.createClient(conn)
-		go cm.handler.HandleClient(client)
+		go cm.serve(client)
 	}
 	
 	return nil
 }
 
+// StartWebSocket accepts WebSocket connections on address at /ws and hands
+// them to the same handler as telnet clients. It serves in the background.
+func (cm *ConnectionManager) StartWebSocket(address string) error {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return fmt.Errorf("failed to start websocket listener: %w", err)
+	}
+	
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ws", cm.handleWebSocket)
+	cm.wsServer = &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	
+	go func() {
+		if err := cm.wsServer.Serve(listener); err != nil && err != http.ErrServerClosed {
+			cm.logger.Errorf("WebSocket listener stopped: %v", err)
+		}
+	}()
+	return nil
+}
+
+func (cm *ConnectionManager) handleWebSocket(w http.ResponseWriter, r *http.Request) {
+	conn, err := upgradeWebSocket(w, r)
+	if err != nil {
+		cm.logger.Debugf("Rejected websocket connection from %s: %v", r.RemoteAddr, err)
+		return
+	}
+	
+	// Upgrade first so browser clients see the same message as telnet ones
+	client, ok := cm.admit(conn)
+	if !ok {
+		if entry, queued := cm.enqueue(conn); queued {
+			cm.serveQueued(entry)
+		} else {
+			cm.turnAway(conn)
+		}
+		return
+	}
+	
+	// The HTTP server no longer owns the connection, so serve it here
+	cm.serve(client)
+}
+
 func (cm *ConnectionManager) Stop() error {
 	cm.running = false
 	
 	if cm.listener != nil {
 		cm.listener.Close()
 	}
+	if cm.wsServer != nil {
+		cm.wsServer.Close()
+	}
 	
-	cm.mutex.Lock()
-	defer cm.mutex.Unlock()
-	
+	// Closing waits for each client's output to flush, so do it unlocked
+	cm.mutex.RLock()
+	clients := make([]*Client, 0, len(cm.clients)+len(cm.queue))
 	for _, client := range cm.clients {
+		clients = append(clients, client)
+	}
+	for _, entry := range cm.queue {
+		clients = append(clients, entry.client)
+	}
+	cm.mutex.RUnlock()
+	
+	for _, client := range clients {
 		client.Close()
 	}
 	
 	return nil
 }
 
-func (cm *ConnectionManager) createClient(conn net.Conn) *Client {
-	clientID := uuid.New().String()
-	client := NewClient(clientID, conn)
-	
-	cm.mutex.Lock()
-	cm.clients[clientID] = client
-	cm.mutex.Unlock()
-	
-	fmt.Printf("New client connected: %s from %s\n", clientID, conn.RemoteAddr())
-	return client
-}
-
 func (cm *ConnectionManager) RemoveClient(clientID string) {
 	cm.mutex.Lock()
-	defer cm.mutex.Unlock()
-	
 	client, exists := cm.clients[clientID]
 	if !exists {
+		cm.mutex.Unlock()
 		return
 	}
 	
-	// Remove from player mapping if exists
-	if client.GetPlayerID() != "" {
-		delete(cm.playerClients, client.GetPlayerID())
+	// Remove from player mapping if it still points at this client
+	if playerID := client.GetPlayerID(); playerID != "" && cm.playerClients[playerID] == client {
+		delete(cm.playerClients, playerID)
 	}
-	
-	// Close and remove client
-	client.Close()
 	delete(cm.clients, clientID)
 	
-	fmt.Printf("Client disconnected: %s\n", clientID)
+	cm.logger.Infof("Client disconnected: %s", clientID)
+	if cm.atCapacity && len(cm.clients) < cm.maxClients+cm.reservedSlots {
+		cm.atCapacity = false
+		cm.logger.Infof("Server below capacity again with %d clients", len(cm.clients))
+	}
+	cm.mutex.Unlock()
+	
+	// Closing waits for output to flush, so don't hold up other clients
+	client.Close()
 }
 
 func (cm *ConnectionManager) GetClient(clientID string) (*Client, bool) {
@@ -141,15 +293,16 @@ func (cm *ConnectionManager) GetPlayerClient(playerID string) (*Client, bool) {
 
 func (cm *ConnectionManager) RegisterPlayerClient(playerID string, client *Client) {
 	cm.mutex.Lock()
-	defer cm.mutex.Unlock()
+	existingClient, exists := cm.playerClients[playerID]
+	cm.playerClients[playerID] = client
+	client.SetPlayerID(playerID)
+	cm.mutex.Unlock()
 	
-	// Remove any existing mapping for this player
-	if existingClient, exists := cm.playerClients[playerID]; exists {
+	// Close any other connection for this player once unlocked, since
+	// closing waits for its output to flush
+	if exists && existingClient != client {
 		existingClient.Close()
 	}
-	
-	cm.playerClients[playerID] = client
-	client.SetPlayerID(playerID)
 }
 
 func (cm *ConnectionManager) UnregisterPlayerClient(playerID string) {
@@ -169,25 +322,136 @@ func (cm *ConnectionManager) BroadcastToAll(message string) {
 	}
 	cm.mutex.RUnlock()
 	
-	for _, client := range clients {
-		client.Send(message)
-	}
+	cm.broadcast(clients, message)
 }
 
-func (cm *ConnectionManager) BroadcastToRoom(roomID, message string) {
+// BroadcastToRoom sends message to every in-game client whose character is in
+// roomID, except the listed character IDs.
+func (cm *ConnectionManager) BroadcastToRoom(roomID, message string, excludeCharacterIDs ...string) {
+	excluded := make(map[string]bool, len(excludeCharacterIDs))
+	for _, id := range excludeCharacterIDs {
+		excluded[id] = true
+	}
+	
 	cm.mutex.RLock()
 	clients := make([]*Client, 0)
 	for _, client := range cm.clients {
-		if client.IsConnected() && client.GetState() == StateInGame {
-			// TODO: Check if client's character is in the specified room
+		if client.IsConnected() && client.GetState() == StateInGame &&
+			client.GetRoomID() == roomID && !excluded[client.GetCharacterID()] {
 			clients = append(clients, client)
 		}
 	}
 	cm.mutex.RUnlock()
 	
+	cm.broadcast(clients, message)
+}
+
+// broadcast queues message for each of clients. Sending never waits on the
+// network, so one client slow to take it holds up no one else; a client
+// whose queue has filled up is dropped.
+func (cm *ConnectionManager) broadcast(clients []*Client, message string) {
 	for _, client := range clients {
-		client.Send(message)
+		if err := client.Send(message); errors.Is(err, ErrOutputQueueFull) {
+			cm.logger.Warnf("Dropped client %s: output queue full", client.ID)
+		}
+	}
+}
+
+// GetCharacterClient returns the in-game client playing characterID
+func (cm *ConnectionManager) GetCharacterClient(characterID string) (*Client, bool) {
+	cm.mutex.RLock()
+	defer cm.mutex.RUnlock()
+	
+	for _, client := range cm.clients {
+		if client.GetState() == StateInGame && client.GetCharacterID() == characterID {
+			return client, true
+		}
 	}
+	return nil, false
+}
+
+// SendToCharacter sends message to characterID if they are in the game
+func (cm *ConnectionManager) SendToCharacter(characterID, message string) bool {
+	client, ok := cm.GetCharacterClient(characterID)
+	if !ok {
+		return false
+	}
+	return client.Send(message) == nil
+}
+
+// CharactersInRoom returns the IDs of in-game characters in roomID
+func (cm *ConnectionManager) CharactersInRoom(roomID string) []string {
+	cm.mutex.RLock()
+	defer cm.mutex.RUnlock()
+	
+	var ids []string
+	for _, client := range cm.clients {
+		if client.IsConnected() && client.GetState() == StateInGame && client.GetRoomID() == roomID {
+			ids = append(ids, client.GetCharacterID())
+		}
+	}
+	return ids
+}
+
+// SetCharacterRoom records that characterID is now in roomID, for moves
+// the world makes outside the character's own commands
+func (cm *ConnectionManager) SetCharacterRoom(characterID, roomID string) {
+	cm.mutex.RLock()
+	defer cm.mutex.RUnlock()
+	
+	for _, client := range cm.clients {
+		if client.GetCharacterID() == characterID {
+			client.SetRoomID(roomID)
+		}
+	}
+}
+
+// SessionStart returns when characterID entered the game, if they are online
+func (cm *ConnectionManager) SessionStart(characterID string) (time.Time, bool) {
+	client, ok := cm.GetCharacterClient(characterID)
+	if !ok || client.GetState() != StateInGame {
+		return time.Time{}, false
+	}
+	return client.GetSessionStart(), true
+}
+
+// OnlineCharacterIDs returns the IDs of every in-game character
+func (cm *ConnectionManager) OnlineCharacterIDs() []string {
+	cm.mutex.RLock()
+	defer cm.mutex.RUnlock()
+	
+	var ids []string
+	for _, client := range cm.clients {
+		if client.IsConnected() && client.GetState() == StateInGame {
+			ids = append(ids, client.GetCharacterID())
+		}
+	}
+	return ids
+}
+
+// OnlineCharacter describes one in-game connection
+type OnlineCharacter struct {
+	CharacterID string
+	RoomID      string
+	Idle        time.Duration
+}
+
+// OnlineCharacters returns a snapshot of every in-game connection
+func (cm *ConnectionManager) OnlineCharacters() []OnlineCharacter {
+	cm.mutex.RLock()
+	defer cm.mutex.RUnlock()
+	
+	var online []OnlineCharacter
+	for _, client := range cm.clients {
+		if client.IsConnected() && client.GetState() == StateInGame {
+			online = append(online, OnlineCharacter{
+				CharacterID: client.GetCharacterID(),
+				RoomID:      client.GetRoomID(),
+				Idle:        time.Since(client.GetLastActive()),
+			})
+		}
+	}
+	return online
 }
 
 func (cm *ConnectionManager) getClientCount() int {
@@ -204,6 +468,7 @@ func (cm *ConnectionManager) GetStats() ConnectionStats {
 		TotalClients:     len(cm.clients),
 		AuthenticatedClients: 0,
 		InGameClients:    0,
+		QueuedClients:    len(cm.queue),
 	}
 	
 	for _, client := range cm.clients {
@@ -219,6 +484,22 @@ func (cm *ConnectionManager) GetStats() ConnectionStats {
 	return stats
 }
 
+// RegisterMetrics exposes the connection counts as gauges on registry
+func (cm *ConnectionManager) RegisterMetrics(registry *metrics.Registry) {
+	registry.NewGaugeFunc("dungeogo_clients_connected", "Clients currently connected.", func() float64 {
+		return float64(cm.GetStats().TotalClients)
+	})
+	registry.NewGaugeFunc("dungeogo_clients_authenticated", "Connected clients that have logged in.", func() float64 {
+		return float64(cm.GetStats().AuthenticatedClients)
+	})
+	registry.NewGaugeFunc("dungeogo_clients_in_game", "Connected clients playing a character.", func() float64 {
+		return float64(cm.GetStats().InGameClients)
+	})
+	registry.NewGaugeFunc("dungeogo_clients_queued", "Connections waiting in the queue for a free slot.", func() float64 {
+		return float64(cm.GetStats().QueuedClients)
+	})
+}
+
 func (cm *ConnectionManager) cleanupClients() {
 	ticker := time.NewTicker(30 * time.Second)
 	defer ticker.Stop()
@@ -253,4 +534,5 @@ type ConnectionStats struct {
 	TotalClients         int
 	AuthenticatedClients int
 	InGameClients        int
+	QueuedClients        int
 }
\ No newline at end of file
@@ -0,0 +1,181 @@
+package server
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestConnectionManagerTurnsAwayWhenFull(t *testing.T) {
+	cm := NewConnectionManager(1, time.Minute)
+
+	first, firstPeer := net.Pipe()
+	defer firstPeer.Close()
+	if _, ok := cm.admit(first); !ok {
+		t.Fatalf("Expected the first connection to be admitted")
+	}
+
+	second, secondPeer := net.Pipe()
+	defer secondPeer.Close()
+	if _, ok := cm.admit(second); ok {
+		t.Fatalf("Expected a connection over the limit to be refused")
+	}
+	if !cm.atCapacity {
+		t.Errorf("Expected the manager to record that it is at capacity")
+	}
+
+	go cm.turnAway(second)
+	reader := bufio.NewReader(secondPeer)
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		t.Fatalf("Failed to read rejection: %v", err)
+	}
+	if line != serverFullMessage+"\r\n" {
+		t.Errorf("Expected %q, got %q", serverFullMessage, line)
+	}
+	if _, err := reader.ReadString('\n'); err != io.EOF {
+		t.Errorf("Expected the connection to be closed, got %v", err)
+	}
+
+	if stats := cm.GetStats(); stats.TotalClients != 1 {
+		t.Errorf("Expected 1 client, got %d", stats.TotalClients)
+	}
+}
+
+func TestConnectionManagerReservedSlots(t *testing.T) {
+	cm := NewConnectionManager(1, time.Minute)
+	cm.SetReservedSlots(1)
+
+	for i := 0; i < 2; i++ {
+		conn, peer := net.Pipe()
+		defer peer.Close()
+		if _, ok := cm.admit(conn); !ok {
+			t.Fatalf("Expected connection %d to be admitted", i+1)
+		}
+	}
+	if !cm.IsFull() {
+		t.Errorf("Expected the server to be full for players without premium")
+	}
+
+	conn, peer := net.Pipe()
+	defer peer.Close()
+	if _, ok := cm.admit(conn); ok {
+		t.Errorf("Expected a connection past the reserved slots to be refused")
+	}
+}
+
+func TestConnectionManagerRemoveClientFreesSlot(t *testing.T) {
+	cm := NewConnectionManager(1, time.Minute)
+
+	conn, peer := net.Pipe()
+	defer peer.Close()
+	client, ok := cm.admit(conn)
+	if !ok {
+		t.Fatalf("Expected the connection to be admitted")
+	}
+	other, otherPeer := net.Pipe()
+	defer otherPeer.Close()
+	if _, ok := cm.admit(other); ok {
+		t.Fatalf("Expected the second connection to be refused")
+	}
+
+	cm.RemoveClient(client.ID)
+	if cm.atCapacity {
+		t.Errorf("Expected the manager to leave capacity once a client left")
+	}
+	if _, ok := cm.admit(other); !ok {
+		t.Errorf("Expected the freed slot to admit a new connection")
+	}
+}
+
+func TestConnectionManagerSessionStart(t *testing.T) {
+	cm := NewConnectionManager(10, time.Minute)
+	conn, peer := net.Pipe()
+	defer peer.Close()
+	client, _ := cm.admit(conn)
+	client.SetCharacterID("char1")
+
+	if _, ok := cm.SessionStart("char1"); ok {
+		t.Errorf("Expected no session before the character enters the game")
+	}
+
+	start := time.Now().Add(-time.Hour)
+	client.SetState(StateInGame)
+	client.StartSession(start)
+	if got, ok := cm.SessionStart("char1"); !ok || !got.Equal(start) {
+		t.Errorf("Expected the session to start at %v, got %v (%v)", start, got, ok)
+	}
+	if _, ok := cm.SessionStart("char2"); ok {
+		t.Errorf("Expected no session for a character who is not online")
+	}
+}
+
+func TestConnectionManagerBroadcastPastStuckClient(t *testing.T) {
+	cm := NewConnectionManager(10, time.Minute)
+	cm.SetWriteTimeout(time.Second)
+
+	stuckConn, stuckPeer := net.Pipe()
+	defer stuckPeer.Close()
+	stuck, _ := cm.admit(stuckConn)
+	conn, peer := net.Pipe()
+	defer peer.Close()
+	cm.admit(conn)
+
+	done := make(chan struct{})
+	go func() {
+		cm.BroadcastToAll("The sun rises.")
+		close(done)
+	}()
+
+	// The reader gets the message well before the stuck client times out
+	peer.SetReadDeadline(time.Now().Add(500 * time.Millisecond))
+	line, err := bufio.NewReader(peer).ReadString('\n')
+	if err != nil {
+		t.Fatalf("Expected the broadcast to reach the reading client, got %v", err)
+	}
+	if line != "The sun rises.\r\n" {
+		t.Errorf("Expected the broadcast, got %q", line)
+	}
+
+	<-done
+	select {
+	case <-stuck.written:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("Expected the stuck client's write to time out")
+	}
+	if stuck.IsConnected() {
+		t.Errorf("Expected the stuck client to be disconnected")
+	}
+}
+
+func TestConnectionManagerRemoveClientDoesNotBlockOthers(t *testing.T) {
+	cm := NewConnectionManager(10, time.Minute)
+	cm.SetWriteTimeout(time.Minute)
+
+	stuckConn, stuckPeer := net.Pipe()
+	defer stuckPeer.Close()
+	stuck, _ := cm.admit(stuckConn)
+	stuck.Send("Nobody reads this.")
+
+	go cm.RemoveClient(stuck.ID)
+	for stuck.IsConnected() {
+		time.Sleep(time.Millisecond)
+	}
+
+	// Closing the stuck client waits for its output, which never drains
+	found := make(chan bool)
+	go func() {
+		_, ok := cm.GetClient(stuck.ID)
+		found <- ok
+	}()
+	select {
+	case ok := <-found:
+		if ok {
+			t.Errorf("Expected the removed client to be gone")
+		}
+	case <-time.After(closeFlushTimeout / 2):
+		t.Fatalf("Expected lookups not to wait for the removed client to flush")
+	}
+}
@@ -4,6 +4,7 @@ import "errors"
 
 var (
 	ErrClientDisconnected = errors.New("client is disconnected")
+	ErrOutputQueueFull    = errors.New("client output queue is full")
 	ErrServerNotRunning   = errors.New("server is not running")
 	ErrServerFull         = errors.New("server is full")
 	ErrInvalidCommand     = errors.New("invalid command")
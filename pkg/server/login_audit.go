@@ -0,0 +1,79 @@
+package server
+
+import (
+	"sync"
+	"time"
+
+	"github.com/elidor/dungeogo/pkg/logging"
+	"github.com/elidor/dungeogo/pkg/persistence/interfaces"
+)
+
+// DefaultAuditBuffer is how many login records may be queued before new
+// ones are dropped.
+const DefaultAuditBuffer = 256
+
+// LoginAuditor writes login history in the background so recording an
+// attempt never slows down authentication.
+type LoginAuditor struct {
+	repo    interfaces.PlayerRepository
+	logger  *logging.Logger
+	records chan *interfaces.LoginRecord
+	done    chan struct{}
+	mutex   sync.RWMutex
+	closed  bool
+}
+
+func NewLoginAuditor(repo interfaces.PlayerRepository, buffer int, logger *logging.Logger) *LoginAuditor {
+	if buffer <= 0 {
+		buffer = DefaultAuditBuffer
+	}
+
+	a := &LoginAuditor{
+		repo:    repo,
+		logger:  logger,
+		records: make(chan *interfaces.LoginRecord, buffer),
+		done:    make(chan struct{}),
+	}
+	go a.run()
+	return a
+}
+
+// Record queues a login attempt. If the queue is full the record is dropped
+// rather than blocking the caller.
+func (a *LoginAuditor) Record(record *interfaces.LoginRecord) {
+	if record.Timestamp.IsZero() {
+		record.Timestamp = time.Now()
+	}
+
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+	if a.closed {
+		return
+	}
+
+	select {
+	case a.records <- record:
+	default:
+		a.logger.Warnf("Login audit queue full, dropping record for player %s", record.PlayerID)
+	}
+}
+
+// Close stops accepting records and waits for queued ones to be written.
+func (a *LoginAuditor) Close() {
+	a.mutex.Lock()
+	if !a.closed {
+		a.closed = true
+		close(a.records)
+	}
+	a.mutex.Unlock()
+	<-a.done
+}
+
+func (a *LoginAuditor) run() {
+	defer close(a.done)
+	for record := range a.records {
+		if err := a.repo.RecordLogin(record); err != nil {
+			a.logger.Errorf("Failed to record login for player %s: %v", record.PlayerID, err)
+		}
+	}
+}
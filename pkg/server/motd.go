@@ -0,0 +1,90 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+// MOTD holds the message of the day shown to players after they log in. It is
+// backed by a text file so operators can also edit it outside the game.
+type MOTD struct {
+	path  string
+	text  string
+	mutex sync.RWMutex
+}
+
+// LoadMOTD reads the message of the day from path. A missing file yields an
+// empty message rather than an error.
+func LoadMOTD(path string) (*MOTD, error) {
+	text, err := readMOTD(path)
+	if err != nil {
+		return nil, err
+	}
+	return &MOTD{path: path, text: text}, nil
+}
+
+// readMOTD returns the message in the file at path, or "" if there is no
+// such file
+func readMOTD(path string) (string, error) {
+	if path == "" {
+		return "", nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to read motd: %w", err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+func (m *MOTD) Text() string {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	return m.text
+}
+
+// Set replaces the message and writes it back to the backing file, if any
+func (m *MOTD) Set(text string) error {
+	text = strings.TrimSpace(text)
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.path != "" {
+		if err := os.WriteFile(m.path, []byte(text+"\n"), 0644); err != nil {
+			return fmt.Errorf("failed to write motd: %w", err)
+		}
+	}
+
+	m.text = text
+	return nil
+}
+
+// Reload reads the message again from the backing file, so edits made
+// outside the game are shown without a restart. The message is kept if the
+// file cannot be read.
+func (m *MOTD) Reload() (string, error) {
+	text, err := readMOTD(m.path)
+	if err != nil {
+		return "", err
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	switch {
+	case text == m.text:
+		return "The message of the day is unchanged.", nil
+	case text == "":
+		m.text = text
+		return "The message of the day has been cleared.", nil
+	}
+	m.text = text
+	return "The message of the day has been reloaded.", nil
+}
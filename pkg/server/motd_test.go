@@ -0,0 +1,40 @@
+package server
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMOTDReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "motd.txt")
+	if err := os.WriteFile(path, []byte("Welcome!\n"), 0644); err != nil {
+		t.Fatalf("Failed to write motd: %v", err)
+	}
+	motd, err := LoadMOTD(path)
+	if err != nil {
+		t.Fatalf("Failed to load motd: %v", err)
+	}
+
+	if summary, err := motd.Reload(); err != nil || summary != "The message of the day is unchanged." {
+		t.Errorf("Expected an unchanged message, got %q (%v)", summary, err)
+	}
+
+	os.WriteFile(path, []byte("Double experience this weekend.\n"), 0644)
+	if summary, err := motd.Reload(); err != nil || summary != "The message of the day has been reloaded." {
+		t.Errorf("Expected the message to be reloaded, got %q (%v)", summary, err)
+	}
+	if motd.Text() != "Double experience this weekend." {
+		t.Errorf("Expected the new message, got %q", motd.Text())
+	}
+
+	// A file that can't be read leaves the message alone
+	os.Remove(path)
+	os.Mkdir(path, 0755)
+	if _, err := motd.Reload(); err == nil {
+		t.Errorf("Expected an unreadable file to fail")
+	}
+	if motd.Text() != "Double experience this weekend." {
+		t.Errorf("Expected the message to be kept, got %q", motd.Text())
+	}
+}
@@ -1,33 +1,136 @@
 package server
 
 import (
+	"errors"
 	"fmt"
+	"net"
 	"strings"
 	"regexp"
+	"strconv"
+	"time"
 	
 	"golang.org/x/crypto/bcrypt"
+	"github.com/elidor/dungeogo/pkg/auth"
+	"github.com/elidor/dungeogo/pkg/billing"
+	"github.com/elidor/dungeogo/pkg/commands"
 	"github.com/elidor/dungeogo/pkg/game/character"
+	"github.com/elidor/dungeogo/pkg/game/items"
 	"github.com/elidor/dungeogo/pkg/game/player"
+	"github.com/elidor/dungeogo/pkg/logging"
+	"github.com/elidor/dungeogo/pkg/mail"
+	"github.com/elidor/dungeogo/pkg/textutil"
 	"github.com/elidor/dungeogo/pkg/persistence/interfaces"
 )
 
 type SessionHandler struct {
-	repoManager interfaces.RepositoryManager
-	gameEngine  GameEngine
+	repoManager    interfaces.RepositoryManager
+	gameEngine     GameEngine
+	passwordPolicy *auth.PasswordPolicy
+	loginLimiter   *auth.LoginLimiter
+	mailer         mail.Mailer
+	verification   auth.VerificationMode
+	motd           *MOTD
+	logger         *logging.Logger
+	auditor        *LoginAuditor
+	itemFactory    *items.ItemFactory
+	clients        *ConnectionManager
+	payments       billing.Processor
+	benefits       *player.Benefits
+	starts         *character.StartLocations
 }
 
+// loginHistoryLimit is how many entries the logins command shows
+const loginHistoryLimit = 10
+
 type GameEngine interface {
-	ProcessCommand(characterID string, command string) ([]string, error)
+	ProcessCommand(characterID string, command string) (*commands.CommandResult, error)
 	GetCharacterState(characterID string) (interface{}, error)
+	EnterGame(characterID string) ([]string, error)
+	// LeaveGame lets the engine forget a character whose player has left
+	LeaveGame(characterID string)
+	// LockCharacter stops anything else changing the character until the
+	// returned function is called
+	LockCharacter(characterID string) func()
 }
 
 func NewSessionHandler(repoManager interfaces.RepositoryManager, gameEngine GameEngine) *SessionHandler {
 	return &SessionHandler{
-		repoManager: repoManager,
-		gameEngine:  gameEngine,
+		repoManager:    repoManager,
+		gameEngine:     gameEngine,
+		passwordPolicy: auth.DefaultPasswordPolicy(),
+		loginLimiter:   auth.DefaultLoginLimiter(),
+		mailer:         &mail.NoopMailer{},
+		verification:   auth.VerificationOff,
+		motd:           &MOTD{},
+		logger:         logging.Default(),
+		itemFactory:    items.NewItemFactory(),
+		benefits:       player.DefaultBenefits(),
+		starts:         character.DefaultStartLocations(),
 	}
 }
 
+// SetItemFactory sets where the items in new characters' starting kits are
+// made, so they come from the same templates as the game's
+func (sh *SessionHandler) SetItemFactory(factory *items.ItemFactory) {
+	sh.itemFactory = factory
+}
+
+// SetConnectionManager lets command results reach other connected players.
+// Without one, only the acting player sees anything.
+func (sh *SessionHandler) SetConnectionManager(cm *ConnectionManager) {
+	sh.clients = cm
+}
+
+// SetLoginLimiter replaces the tracker used to throttle failed password attempts
+func (sh *SessionHandler) SetLoginLimiter(limiter *auth.LoginLimiter) {
+	sh.loginLimiter = limiter
+}
+
+// SetEmailVerification configures whether new accounts must verify their
+// email address, and the mailer used to deliver verification codes.
+func (sh *SessionHandler) SetEmailVerification(mode auth.VerificationMode, mailer mail.Mailer) {
+	sh.verification = mode
+	sh.mailer = mailer
+}
+
+// SetMOTD sets the message of the day shown after login
+func (sh *SessionHandler) SetMOTD(motd *MOTD) {
+	sh.motd = motd
+}
+
+// SetLogger replaces the session logger. Session code never logs passwords,
+// emails, or anything derived from them, regardless of level.
+func (sh *SessionHandler) SetLogger(logger *logging.Logger) {
+	sh.logger = logger
+}
+
+// SetLoginAuditor enables login history recording. Without one, attempts
+// are not recorded.
+func (sh *SessionHandler) SetLoginAuditor(auditor *LoginAuditor) {
+	sh.auditor = auditor
+}
+
+// SetBenefits sets what premium players get
+func (sh *SessionHandler) SetBenefits(benefits *player.Benefits) {
+	sh.benefits = benefits
+}
+
+// SetPaymentProcessor sets how premium subscriptions are paid for. Until
+// one is set, premium can't be bought.
+func (sh *SessionHandler) SetPaymentProcessor(payments billing.Processor) {
+	sh.payments = payments
+}
+
+// SetStartLocations sets where new characters begin
+func (sh *SessionHandler) SetStartLocations(starts *character.StartLocations) {
+	sh.starts = starts
+}
+
+// SetPasswordPolicy replaces the policy used to validate and hash new passwords
+func (sh *SessionHandler) SetPasswordPolicy(policy *auth.PasswordPolicy) {
+	sh.passwordPolicy = policy
+}
+
 func (sh *SessionHandler) HandleClient(client *Client) {
 	defer client.Close()
 	
@@ -41,14 +144,14 @@ func (sh *SessionHandler) HandleClient(client *Client) {
 		var err error
 		
 		// Use password reading for sensitive input
-		if client.GetState() == StateAuthenticating || client.GetState() == StateConfirmingPassword {
+		if isPasswordState(client.GetState()) {
 			line, err = client.ReadPassword()
 		} else {
 			line, err = client.ReadLine()
 		}
 		
 		if err != nil {
-			fmt.Printf("Error reading from client %s: %v\n", client.GetID(), err)
+			sh.logger.Debugf("Read error from client %s: %v", client.GetID(), err)
 			break
 		}
 		
@@ -65,8 +168,69 @@ func (sh *SessionHandler) HandleClient(client *Client) {
 			sh.handleCharacterSelection(client, line)
 		case StateInGame:
 			sh.handleGameCommand(client, line)
+		case StateVerifyingCurrentPassword:
+			sh.handleCurrentPasswordVerification(client, line)
+		case StateChangingPassword:
+			sh.handleNewPassword(client, line)
 		}
 	}
+	
+	sh.disconnect(client)
+}
+
+// disconnect tidies up after a client leaves, whether they quit or their
+// connection dropped: an in-game character is saved so nothing since their
+// last save is lost, the engine forgets them and their player is released.
+func (sh *SessionHandler) disconnect(client *Client) {
+	if characterID := client.GetCharacterID(); characterID != "" {
+		if err := sh.saveCharacter(characterID); err != nil {
+			sh.logger.Warnf("Failed to save character %s on disconnect: %v", characterID, err)
+		}
+		sh.gameEngine.LeaveGame(characterID)
+	}
+	
+	// Leave the mapping alone if the player has already reconnected elsewhere
+	if playerID := client.GetPlayerID(); playerID != "" && sh.clients != nil {
+		if current, ok := sh.clients.GetPlayerClient(playerID); ok && current == client {
+			sh.clients.UnregisterPlayerClient(playerID)
+		}
+	}
+}
+
+// saveCharacter records a character's play time and saves them with
+// everything they carry
+func (sh *SessionHandler) saveCharacter(characterID string) error {
+	defer sh.gameEngine.LockCharacter(characterID)()
+	char, err := sh.repoManager.Characters().GetCharacter(characterID)
+	if err != nil {
+		return fmt.Errorf("failed to load character: %w", err)
+	}
+	
+	char.UpdatePlayTime()
+	if err := sh.repoManager.Characters().UpdateCharacter(char); err != nil {
+		return fmt.Errorf("failed to save character: %w", err)
+	}
+	
+	carried, err := sh.repoManager.Items().GetPlayerItems(characterID)
+	if err != nil {
+		return fmt.Errorf("failed to load items: %w", err)
+	}
+	for _, item := range carried {
+		if err := sh.repoManager.Items().UpdateItemInstance(item); err != nil {
+			return fmt.Errorf("failed to save item %s: %w", item.ID, err)
+		}
+	}
+	
+	return nil
+}
+
+// isPasswordState reports whether input in the given state must be read with echo disabled
+func isPasswordState(state ClientState) bool {
+	switch state {
+	case StateAuthenticating, StateConfirmingPassword, StateVerifyingCurrentPassword, StateChangingPassword:
+		return true
+	}
+	return false
 }
 
 func (sh *SessionHandler) handleLogin(client *Client, username string) {
@@ -77,12 +241,25 @@ func (sh *SessionHandler) handleLogin(client *Client, username string) {
 		return
 	}
 	
-	fmt.Printf("Login attempt for client %s: username='%s'\n", client.GetID(), username)
+	// Moving ahead in the queue as a premium player holds you to that account
+	if queued := client.GetQueuedAccount(); queued != "" && !strings.EqualFold(username, queued) {
+		client.Send(fmt.Sprintf("You waited in the queue as %s, so please log in as %s:", queued, queued))
+		client.SendPrompt("> ")
+		return
+	}
+	
+	sh.logger.Debugf("Login attempt from client %s for username %q", client.GetID(), username)
 	
 	// Check if player exists
 	existingPlayer, err := sh.repoManager.Players().GetPlayerByUsername(username)
+	if err != nil && !errors.Is(err, interfaces.ErrPlayerNotFound) {
+		sh.logger.Errorf("Failed to look up username %q for client %s: %v", username, client.GetID(), err)
+		client.Send("Unable to look up that account right now. Please enter your username:")
+		client.SendPrompt("> ")
+		return
+	}
 	if err != nil {
-		fmt.Printf("Player lookup failed for client %s, username='%s': %v\n", client.GetID(), username, err)
+		sh.logger.Debugf("No existing account for client %s, starting account creation", client.GetID())
 		// New player - create account
 		client.SetTempUsername(username)
 		client.Send("New player! Creating account for: " + username)
@@ -92,15 +269,23 @@ func (sh *SessionHandler) handleLogin(client *Client, username string) {
 		return
 	}
 	
-	fmt.Printf("Found existing player for client %s: username='%s', ID='%s'\n", 
-		client.GetID(), username, existingPlayer.ID)
+	sh.logger.Debugf("Client %s matched existing player %s", client.GetID(), existingPlayer.ID)
 	
 	if !existingPlayer.IsActive() {
+		sh.recordLogin(client, existingPlayer.ID, false, "suspended")
 		client.Send("Your account has been suspended. Please contact an administrator.")
 		client.Close()
 		return
 	}
 	
+	if locked, _ := sh.loginLimiter.IsLocked(loginLimiterKeys(client, existingPlayer.ID)...); locked {
+		sh.logger.Warnf("Rejected login for locked player %s from client %s", existingPlayer.ID, client.GetID())
+		sh.recordLogin(client, existingPlayer.ID, false, "locked")
+		client.Send("Too many failed attempts, try again later.")
+		client.Close()
+		return
+	}
+	
 	client.Send("Please enter your password:")
 	client.SetState(StateAuthenticating)
 	// Store player ID temporarily
@@ -115,15 +300,17 @@ func (sh *SessionHandler) handlePasswordAuth(client *Client, password string) {
 		return
 	}
 	
+	// handleLogin always sets the player ID before asking for a password;
+	// new players go through account creation instead.
 	playerID := client.GetPlayerID()
 	if playerID == "" {
-		// New player creation - simplified for demo
-		client.Send("Account creation not fully implemented yet.")
-		client.Close()
+		sh.logger.Warnf("Client %s reached password entry without a username", client.GetID())
+		client.Send("Please enter your username:")
+		client.SendPrompt("> ")
+		client.SetState(StateConnected)
 		return
 	}
 	
-	// Get player and verify password (simplified - use proper password hashing)
 	existingPlayer, err := sh.repoManager.Players().GetPlayer(playerID)
 	if err != nil {
 		client.Send("Authentication failed.")
@@ -131,23 +318,110 @@ func (sh *SessionHandler) handlePasswordAuth(client *Client, password string) {
 		return
 	}
 	
+	limiterKeys := loginLimiterKeys(client, playerID)
+	if locked, _ := sh.loginLimiter.IsLocked(limiterKeys...); locked {
+		sh.recordLogin(client, playerID, false, "locked")
+		client.Send("Too many failed attempts, try again later.")
+		client.Close()
+		return
+	}
+	
 	// Verify password using bcrypt
 	err = bcrypt.CompareHashAndPassword([]byte(existingPlayer.PasswordHash), []byte(password))
 	if err != nil {
+		sh.loginLimiter.RecordFailure(limiterKeys...)
+		sh.logger.Infof("Failed login for player %s from client %s", playerID, client.GetID())
+		sh.recordLogin(client, playerID, false, "bad_password")
 		client.Send("Invalid password.")
 		client.Close()
 		return
 	}
 	
-	// Authentication successful
+	// Only the account's history is cleared; the IP keeps its failures so a
+	// single good login can't be used to reset password spraying.
+	sh.loginLimiter.Reset(limiterKeys[0])
+	
+	// Authentication successful; note the previous login before it is replaced
+	summary := loginSummary(existingPlayer, time.Now())
 	existingPlayer.UpdateLastLogin()
 	sh.repoManager.Players().UpdatePlayerLogin(playerID)
+	sh.recordLogin(client, playerID, true, "")
+	
+	sh.expireSubscription(client, existingPlayer)
+	if !sh.admitPlayer(client, existingPlayer) {
+		return
+	}
 	
 	client.Send(fmt.Sprintf("Welcome back, %s!", existingPlayer.Username))
+	client.SendLines(summary)
+	sh.remindUnverified(client, existingPlayer)
+	sh.showMOTD(client, existingPlayer)
 	client.SetState(StateCharacterSelection)
 	sh.showCharacterMenu(client)
 }
 
+// loginSummary tells a returning player when they last logged in. It must
+// be built before the login time is updated.
+func loginSummary(p *player.Player, now time.Time) []string {
+	if p.LastLogin.IsZero() {
+		return nil
+	}
+	when := p.LastLogin.UTC().Format("2006-01-02 15:04 MST")
+	// Logins long ago are already described by their date
+	if relative := textutil.RelativeTime(p.LastLogin, now); !strings.HasPrefix(when, relative) {
+		when += fmt.Sprintf(" (%s)", relative)
+	}
+	return []string{"Last login: " + when}
+}
+
+// loginLimiterKeys returns the account and remote-IP keys used for attempt tracking
+func loginLimiterKeys(client *Client, playerID string) []string {
+	keys := []string{"account:" + playerID}
+	
+	host := remoteHost(client)
+	if host == "" {
+		return keys
+	}
+	return append(keys, "ip:"+host)
+}
+
+// verifyLimiterKeys returns the keys used to track wrong verification codes.
+// The account key is separate from the login one so guessing codes can't
+// lock the player out of logging in; the remote IP is shared.
+func verifyLimiterKeys(client *Client, playerID string) []string {
+	keys := loginLimiterKeys(client, playerID)
+	keys[0] = "verify:" + playerID
+	return keys
+}
+
+// remoteHost returns the client's IP address without the port
+func remoteHost(client *Client) string {
+	addr := client.GetRemoteAddr()
+	if addr == nil {
+		return ""
+	}
+	
+	host, _, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		return addr.String()
+	}
+	return host
+}
+
+// recordLogin queues a login attempt for the account's history
+func (sh *SessionHandler) recordLogin(client *Client, playerID string, success bool, reason string) {
+	if sh.auditor == nil {
+		return
+	}
+	
+	sh.auditor.Record(&interfaces.LoginRecord{
+		PlayerID:  playerID,
+		IPAddress: remoteHost(client),
+		Success:   success,
+		Reason:    reason,
+	})
+}
+
 func (sh *SessionHandler) handleCharacterSelection(client *Client, input string) {
 	input = strings.TrimSpace(input)
 	parts := strings.Fields(input)
@@ -180,6 +454,30 @@ func (sh *SessionHandler) handleCharacterSelection(client *Client, input string)
 		} else {
 			sh.deleteCharacter(client, parts[1])
 		}
+	case "verify", "v":
+		if len(parts) < 2 {
+			sh.resendVerification(client)
+		} else {
+			sh.verifyEmail(client, parts[1])
+		}
+	case "subscribe":
+		months := 1
+		if len(parts) > 1 {
+			// Anything but a number is caught as out of range
+			months, _ = strconv.Atoi(parts[1])
+		}
+		sh.subscribe(client, months)
+	case "motd":
+		sh.handleMOTDCommand(client, strings.TrimSpace(input[len(parts[0]):]))
+	case "logins":
+		if len(parts) < 2 {
+			sh.showLoginHistory(client, "")
+		} else {
+			sh.showLoginHistory(client, parts[1])
+		}
+	case "password", "p":
+		client.Send("Please enter your current password:")
+		client.SetState(StateVerifyingCurrentPassword)
 	case "quit", "q":
 		client.Send("Goodbye!")
 		client.Close()
@@ -201,17 +499,60 @@ func (sh *SessionHandler) handleGameCommand(client *Client, input string) {
 		return
 	}
 	
+	if !client.AllowCommand(time.Now()) {
+		client.Send("You are sending commands too quickly. Slow down.")
+		client.SendPrompt("> ")
+		return
+	}
+	
 	// Process command through game engine
-	responses, err := sh.gameEngine.ProcessCommand(characterID, input)
+	result, err := sh.gameEngine.ProcessCommand(characterID, input)
 	if err != nil {
 		client.Send(fmt.Sprintf("Error: %v", err))
-	} else {
-		for _, response := range responses {
-			client.Send(response)
+		client.SendPrompt("> ")
+		return
+	}
+	
+	sh.deliverResult(client, result)
+}
+
+// deliverResult shows a command's output to the actor, routes anything meant
+// for other characters, and acts on the result's signal.
+func (sh *SessionHandler) deliverResult(client *Client, result *commands.CommandResult) {
+	if result.ActorRoom != "" {
+		client.SetRoomID(result.ActorRoom)
+	}
+	client.SendLines(result.Messages)
+	if result.Prompt != "" {
+		client.Send(result.Prompt)
+	}
+	
+	if sh.clients != nil {
+		for _, msg := range result.Room {
+			sh.clients.BroadcastToRoom(msg.RoomID, msg.Text, msg.Exclude...)
+		}
+		for _, msg := range result.Targeted {
+			sh.clients.SendToCharacter(msg.CharacterID, msg.Text)
+		}
+		for _, follower := range result.Followers {
+			if followerClient, ok := sh.clients.GetCharacterClient(follower.CharacterID); ok {
+				sh.deliverResult(followerClient, follower.Result)
+			}
 		}
 	}
 	
-	client.SendPrompt("> ")
+	switch result.Signal {
+	case commands.SignalDisconnect:
+		client.Close()
+	case commands.SignalCharacterMenu:
+		sh.gameEngine.LeaveGame(client.GetCharacterID())
+		client.SetCharacterID("")
+		client.SetRoomID("")
+		client.SetState(StateCharacterSelection)
+		sh.showCharacterMenu(client)
+	default:
+		client.SendPrompt("> ")
+	}
 }
 
 func (sh *SessionHandler) showCharacterMenu(client *Client) {
@@ -221,6 +562,14 @@ func (sh *SessionHandler) showCharacterMenu(client *Client) {
 	client.Send("  select (s) <name>        - Enter game with character")
 	client.Send("  create (c) <name> <race> <class> - Create new character")
 	client.Send("  delete (d) <name>        - Delete character")
+	client.Send("  password (p)             - Change your password")
+	client.Send("  logins                   - Show recent logins to your account")
+	if sh.payments != nil {
+		client.Send("  subscribe [months]       - Buy or renew premium")
+	}
+	if sh.verification != auth.VerificationOff {
+		client.Send("  verify (v) [code]        - Verify your email (no code resends it)")
+	}
 	client.Send("  quit (q)                 - Disconnect")
 	client.Send("")
 	client.SendPrompt("Character> ")
@@ -246,13 +595,27 @@ func (sh *SessionHandler) listCharacters(client *Client) {
 		if !char.IsAlive {
 			status = "Dead"
 		}
-		client.Send(fmt.Sprintf("%-14s %-9s %-9s %-6d %-9s %s",
-			char.Name, char.Race, char.Class, char.Level, status, char.LastPlayed))
+		client.Send(fmt.Sprintf("%s %-9s %-9s %-6d %-9s %s",
+			textutil.PadRight(char.Name, 14), char.Race, char.Class, char.Level, status,
+			textutil.RelativeTime(char.LastPlayed, time.Now())))
 	}
 	client.Send("")
 }
 
 func (sh *SessionHandler) selectCharacter(client *Client, name string) {
+	if sh.verification == auth.VerificationRequired {
+		existingPlayer, err := sh.repoManager.Players().GetPlayer(client.GetPlayerID())
+		if err != nil {
+			client.Send("Error retrieving account.")
+			return
+		}
+		if !existingPlayer.EmailVerified {
+			client.Send("You must verify your email address before entering the game.")
+			client.Send("Use 'verify <code>' with the code we emailed you, or 'verify' to resend it.")
+			return
+		}
+	}
+	
 	// Get characters and find by name
 	characters, err := sh.repoManager.Characters().GetCharactersByPlayer(client.GetPlayerID())
 	if err != nil {
@@ -263,9 +626,17 @@ func (sh *SessionHandler) selectCharacter(client *Client, name string) {
 	for _, char := range characters {
 		if strings.EqualFold(char.Name, name) {
 			client.SetCharacterID(char.ID)
+			client.SetRoomID(char.Location)
 			client.SetState(StateInGame)
+			client.StartSession(time.Now())
 			client.Send(fmt.Sprintf("Welcome, %s!", char.Name))
 			client.Send("You enter the game world...")
+			
+			messages, err := sh.gameEngine.EnterGame(char.ID)
+			if err != nil {
+				sh.logger.Warnf("Failed to enter game for character %s: %v", char.ID, err)
+			}
+			client.SendLines(messages)
 			client.SendPrompt("> ")
 			return
 		}
@@ -275,11 +646,17 @@ func (sh *SessionHandler) selectCharacter(client *Client, name string) {
 }
 
 func (sh *SessionHandler) createCharacter(client *Client, name, raceStr, classStr string) {
+	name, err := character.ValidateName(name)
+	if err != nil {
+		client.Send(fmt.Sprintf("Invalid name: %s", textutil.Capitalize(err.Error())))
+		return
+	}
+	
 	// Validate race
 	race, err := character.GetRaceByID(strings.ToLower(raceStr))
 	if err != nil {
 		client.Send(fmt.Sprintf("Invalid race: %s", raceStr))
-		client.Send("Available races: human, elf, dwarf")
+		client.Send("Available races: " + strings.Join(character.RaceIDs(), ", "))
 		return
 	}
 	
@@ -287,19 +664,75 @@ func (sh *SessionHandler) createCharacter(client *Client, name, raceStr, classSt
 	class, err := character.GetClassByID(strings.ToLower(classStr))
 	if err != nil {
 		client.Send(fmt.Sprintf("Invalid class: %s", classStr))
-		client.Send("Available classes: warrior, mage, rogue")
+		client.Send("Available classes: " + strings.Join(character.ClassIDs(), ", "))
 		return
 	}
 	
-	// Create character
+	// Names are unique without regard to case, so no one can pass for
+	// another character
+	if _, err := sh.repoManager.Characters().GetCharacterByName(name); err == nil {
+		client.Send(fmt.Sprintf("The name '%s' is already taken.", name))
+		return
+	} else if !errors.Is(err, interfaces.ErrCharacterNotFound) {
+		sh.logger.Errorf("Failed to check character name %q: %v", name, err)
+		client.Send("Error creating character.")
+		return
+	}
+	
+	existing, err := sh.repoManager.Characters().GetCharactersByPlayer(client.GetPlayerID())
+	if err != nil {
+		client.Send("Error retrieving characters.")
+		return
+	}
+	
+	existingPlayer, err := sh.repoManager.Players().GetPlayer(client.GetPlayerID())
+	if err != nil {
+		client.Send("Error retrieving account.")
+		return
+	}
+	if limit := sh.benefits.MaxCharacters(existingPlayer); len(existing) >= limit {
+		client.Send(fmt.Sprintf("You already have %d characters, the most your account allows.", limit))
+		if !existingPlayer.HasPremium() && sh.benefits.ExtraCharacters > 0 && sh.payments != nil {
+			client.Send(fmt.Sprintf("Premium accounts get %d more. Type 'subscribe' to find out more.", sh.benefits.ExtraCharacters))
+		}
+		return
+	}
+	
+	// Create character; an account's first character starts in the tutorial
 	newChar := character.NewCharacter(client.GetPlayerID(), name, race, class)
+	newChar.Location = sh.starts.For(race, class)
+	if len(existing) == 0 {
+		newChar.StartTutorial()
+	}
 	err = sh.repoManager.Characters().CreateCharacter(newChar)
 	if err != nil {
 		client.Send("Error creating character. Name might already be taken.")
 		return
 	}
 	
+	if err := sh.giveStartingKit(newChar); err != nil {
+		sh.logger.Errorf("Failed to create starting kit for character %s: %v", newChar.ID, err)
+	}
+	
 	client.Send(fmt.Sprintf("Character '%s' created successfully!", name))
+	if newChar.InTutorial() {
+		client.Send("As this is your first character, they will begin in the tutorial. Type 'skip' in game to leave it.")
+	}
+}
+
+// giveStartingKit creates the class's starting items for a new character
+// in a single batch.
+func (sh *SessionHandler) giveStartingKit(c *character.Character) error {
+	var kit []*items.ItemInstance
+	for _, entry := range c.Class.StartingKit {
+		item, err := sh.itemFactory.CreateInstance(entry.TemplateID, c.ID, entry.Quantity)
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", entry.TemplateID, err)
+		}
+		kit = append(kit, item)
+	}
+	
+	return sh.repoManager.Items().CreateItemInstances(kit)
 }
 
 func (sh *SessionHandler) deleteCharacter(client *Client, name string) {
@@ -308,22 +741,22 @@ func (sh *SessionHandler) deleteCharacter(client *Client, name string) {
 
 // handleAccountCreation handles the account creation process
 func (sh *SessionHandler) handleAccountCreation(client *Client, input string) {
-	input = strings.TrimSpace(input)
+	input = player.NormalizeEmail(input)
 	if input == "" {
 		client.Send("Email cannot be empty. Please enter your email address:")
 		client.SendPrompt("Email: ")
 		return
 	}
 	
-	// Basic email validation
-	emailRegex := regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
+	// Basic email validation, allowing internationalized addresses
+	emailRegex := regexp.MustCompile(`^[\p{L}\p{N}\p{M}._%+-]+@[\p{L}\p{N}\p{M}.-]+\.[\p{L}\p{M}]{2,}$`)
 	if !emailRegex.MatchString(input) {
 		client.Send("Invalid email format. Please enter a valid email address:")
 		client.SendPrompt("Email: ")
 		return
 	}
 	
-	// Check if email is already in use
+	// Check if email is already in use, whatever its case
 	existingPlayer, err := sh.repoManager.Players().GetPlayerByEmail(input)
 	if err == nil && existingPlayer != nil {
 		client.Send("An account with this email already exists.")
@@ -333,7 +766,7 @@ func (sh *SessionHandler) handleAccountCreation(client *Client, input string) {
 	}
 	
 	client.SetTempEmail(input)
-	client.Send("Please choose a password (minimum 6 characters):")
+	client.Send(fmt.Sprintf("Please choose a password (%s):", sh.passwordPolicy.Describe()))
 	client.SetState(StateConfirmingPassword)
 }
 
@@ -341,37 +774,129 @@ func (sh *SessionHandler) handleAccountCreation(client *Client, input string) {
 func (sh *SessionHandler) handlePasswordConfirmation(client *Client, password string) {
 	password = strings.TrimSpace(password)
 	
-	fmt.Printf("Password confirmation debug - Client %s: received password length=%d\n", client.GetID(), len(password))
+	if !sh.collectNewPassword(client, password) {
+		return
+	}
 	
+	// Create the account
+	sh.createAccount(client)
+}
+
+// collectNewPassword runs the shared choose-and-confirm step for a new password.
+// The first entry is validated and held on the client; it returns true once a
+// matching confirmation has been entered, leaving the password in GetTempPassword.
+func (sh *SessionHandler) collectNewPassword(client *Client, password string) bool {
 	if client.GetTempPassword() == "" {
 		// First password entry
-		fmt.Printf("First password entry for client %s\n", client.GetID())
-		if len(password) < 6 {
-			client.Send("Password must be at least 6 characters long.")
-			client.Send("Please choose a password (minimum 6 characters):")
-			return
+		if err := sh.passwordPolicy.Validate(password, client.GetTempUsername()); err != nil {
+			client.Send(passwordGuidance(err))
+			client.Send(fmt.Sprintf("Please choose a password (%s):", sh.passwordPolicy.Describe()))
+			return false
 		}
 		
 		client.SetTempPassword(password)
-		fmt.Printf("Stored password for client %s, length=%d\n", client.GetID(), len(password))
 		client.Send("Please confirm your password:")
-		return
+		return false
 	}
 	
 	// Password confirmation
 	storedPassword := client.GetTempPassword()
-	fmt.Printf("Password confirmation for client %s: stored=%d chars, entered=%d chars, match=%v\n", 
-		client.GetID(), len(storedPassword), len(password), storedPassword == password)
 	
 	if storedPassword != password {
 		client.Send("Passwords do not match.")
 		client.SetTempPassword("") // Clear stored password
-		client.Send("Please choose a password (minimum 6 characters):")
+		client.Send(fmt.Sprintf("Please choose a password (%s):", sh.passwordPolicy.Describe()))
+		return false
+	}
+	
+	return true
+}
+
+// passwordGuidance turns a policy rejection into a sentence for the player
+func passwordGuidance(err error) string {
+	message := err.Error()
+	if idx := strings.Index(message, ": "); idx >= 0 {
+		message = message[idx+2:]
+	}
+	if message == "" {
+		return "That password is not allowed."
+	}
+	return strings.ToUpper(message[:1]) + message[1:] + "."
+}
+
+// handleCurrentPasswordVerification checks the current password before allowing a change
+func (sh *SessionHandler) handleCurrentPasswordVerification(client *Client, password string) {
+	password = strings.TrimSpace(password)
+	
+	existingPlayer, err := sh.repoManager.Players().GetPlayer(client.GetPlayerID())
+	if err != nil {
+		client.Send("Unable to change password right now.")
+		sh.returnToCharacterMenu(client)
 		return
 	}
 	
-	// Create the account
-	sh.createAccount(client)
+	limiterKeys := loginLimiterKeys(client, existingPlayer.ID)
+	if locked, _ := sh.loginLimiter.IsLocked(limiterKeys...); locked {
+		client.Send("Too many failed attempts, try again later.")
+		sh.returnToCharacterMenu(client)
+		return
+	}
+	
+	err = bcrypt.CompareHashAndPassword([]byte(existingPlayer.PasswordHash), []byte(password))
+	if err != nil {
+		sh.loginLimiter.RecordFailure(limiterKeys...)
+		sh.logger.Infof("Failed password check for player %s from client %s", existingPlayer.ID, client.GetID())
+		client.Send("Incorrect password. Your password was not changed.")
+		sh.returnToCharacterMenu(client)
+		return
+	}
+	sh.loginLimiter.Reset(limiterKeys[0])
+	
+	client.SetTempUsername(existingPlayer.Username)
+	client.Send(fmt.Sprintf("Please choose a new password (%s):", sh.passwordPolicy.Describe()))
+	client.SetState(StateChangingPassword)
+}
+
+// handleNewPassword collects and confirms the new password, then persists its hash
+func (sh *SessionHandler) handleNewPassword(client *Client, password string) {
+	password = strings.TrimSpace(password)
+	
+	if !sh.collectNewPassword(client, password) {
+		return
+	}
+	
+	newPassword := client.GetTempPassword()
+	client.ClearTempData()
+	
+	existingPlayer, err := sh.repoManager.Players().GetPlayer(client.GetPlayerID())
+	if err != nil {
+		client.Send("Unable to change password right now.")
+		sh.returnToCharacterMenu(client)
+		return
+	}
+	
+	hashedPassword, err := sh.passwordPolicy.Hash(newPassword)
+	if err != nil {
+		client.Send("Failed to change password due to internal error.")
+		sh.returnToCharacterMenu(client)
+		return
+	}
+	
+	existingPlayer.PasswordHash = hashedPassword
+	if err := sh.repoManager.Players().UpdatePlayer(existingPlayer); err != nil {
+		client.Send("Failed to change password due to internal error.")
+		sh.returnToCharacterMenu(client)
+		return
+	}
+	
+	client.Send("Your password has been changed.")
+	sh.returnToCharacterMenu(client)
+}
+
+// returnToCharacterMenu puts the client back at the character selection prompt
+func (sh *SessionHandler) returnToCharacterMenu(client *Client) {
+	client.SetState(StateCharacterSelection)
+	client.SendPrompt("Character> ")
 }
 
 // createAccount creates a new player account
@@ -380,39 +905,372 @@ func (sh *SessionHandler) createAccount(client *Client) {
 	email := client.GetTempEmail() 
 	password := client.GetTempPassword()
 	
-	fmt.Printf("Creating account for client %s: username=%s, email=%s, password_len=%d\n", 
-		client.GetID(), username, email, len(password))
+	sh.logger.Debugf("Creating account for client %s", client.GetID())
 	
 	// Hash the password using bcrypt
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	passwordHash, err := sh.passwordPolicy.Hash(password)
 	if err != nil {
-		fmt.Printf("Failed to hash password for client %s: %v\n", client.GetID(), err)
+		sh.logger.Errorf("Failed to hash password for client %s: %v", client.GetID(), err)
 		client.Send("Failed to create account due to internal error.")
 		client.Close()
 		return
 	}
-	passwordHash := string(hashedPassword)
 	
 	// Create new player
 	newPlayer := player.NewPlayer(username, email, passwordHash)
-	fmt.Printf("Created player object for client %s: ID=%s\n", client.GetID(), newPlayer.ID)
-	
+	if sh.verification == auth.VerificationOff {
+		newPlayer.EmailVerified = true
+	} else {
+		code, err := auth.GenerateVerificationCode()
+		if err != nil {
+			sh.logger.Errorf("Failed to generate verification code for client %s: %v", client.GetID(), err)
+			client.Send("Failed to create account due to internal error.")
+			client.Close()
+			return
+		}
+		newPlayer.StartEmailVerification(code)
+	}
 	err = sh.repoManager.Players().CreatePlayer(newPlayer)
 	if err != nil {
-		fmt.Printf("Failed to create player in database for client %s: %v\n", client.GetID(), err)
+		sh.logger.Warnf("Failed to create player for client %s: %v", client.GetID(), err)
 		client.Send("Failed to create account. Username might already be taken.")
 		client.Close()
 		return
 	}
 	
-	fmt.Printf("Successfully created account for client %s: %s\n", client.GetID(), username)
+	sh.logger.Infof("Created account %s for client %s", newPlayer.ID, client.GetID())
 	
 	// Clear temporary data
 	client.ClearTempData()
 	
 	// Set player ID and continue to character selection
 	client.SetPlayerID(newPlayer.ID)
+	if !sh.admitPlayer(client, newPlayer) {
+		return
+	}
 	client.Send(fmt.Sprintf("Account created successfully! Welcome to DungeoGo, %s!", username))
+	if !newPlayer.EmailVerified {
+		sh.sendVerificationEmail(newPlayer)
+		sh.remindUnverified(client, newPlayer)
+	}
+	sh.showMOTD(client, newPlayer)
 	client.SetState(StateCharacterSelection)
 	sh.showCharacterMenu(client)
-}
\ No newline at end of file
+}
+
+// showMOTD sends the message of the day wrapped to the player's screen width
+func (sh *SessionHandler) showMOTD(client *Client, p *player.Player) {
+	text := sh.motd.Text()
+	if text == "" {
+		return
+	}
+	
+	client.Send("")
+	for _, line := range textutil.Wrap(text, p.Preferences.ScreenWidth) {
+		client.Send(line)
+	}
+	client.Send("")
+}
+
+// handleMOTDCommand shows the message of the day, or lets admins change it
+// with "motd set <text>" and "motd clear".
+func (sh *SessionHandler) handleMOTDCommand(client *Client, args string) {
+	existingPlayer, err := sh.repoManager.Players().GetPlayer(client.GetPlayerID())
+	if err != nil {
+		client.Send("Error retrieving account.")
+		return
+	}
+	
+	fields := strings.Fields(args)
+	if len(fields) == 0 {
+		if sh.motd.Text() == "" {
+			client.Send("There is no message of the day.")
+			return
+		}
+		sh.showMOTD(client, existingPlayer)
+		return
+	}
+	
+	if !existingPlayer.IsAdmin() {
+		client.Send("Only administrators can change the message of the day.")
+		return
+	}
+	
+	var text string
+	switch strings.ToLower(fields[0]) {
+	case "set":
+		text = strings.TrimSpace(args[len(fields[0]):])
+		if text == "" {
+			client.Send("Usage: motd set <message>")
+			return
+		}
+	case "clear":
+		text = ""
+	default:
+		client.Send("Usage: motd [set <message> | clear]")
+		return
+	}
+	
+	if err := sh.motd.Set(text); err != nil {
+		sh.logger.Errorf("Failed to update motd: %v", err)
+		client.Send("Failed to update the message of the day.")
+		return
+	}
+	
+	sh.logger.Infof("Player %s updated the message of the day", existingPlayer.ID)
+	if text == "" {
+		client.Send("Message of the day cleared.")
+	} else {
+		client.Send("Message of the day updated.")
+	}
+}
+
+// showLoginHistory lists recent logins for the player's own account, or for
+// another account when an admin names one.
+func (sh *SessionHandler) showLoginHistory(client *Client, username string) {
+	accountID := client.GetPlayerID()
+	
+	if username != "" {
+		requester, err := sh.repoManager.Players().GetPlayer(accountID)
+		if err != nil {
+			client.Send("Error retrieving account.")
+			return
+		}
+		if !requester.IsAdmin() {
+			client.Send("Only administrators can view another account's logins.")
+			return
+		}
+		
+		target, err := sh.repoManager.Players().GetPlayerByUsername(username)
+		if err != nil {
+			client.Send(fmt.Sprintf("No account named '%s'.", username))
+			return
+		}
+		accountID = target.ID
+	}
+	
+	records, err := sh.repoManager.Players().GetRecentLogins(accountID, loginHistoryLimit)
+	if err != nil {
+		sh.logger.Errorf("Failed to load login history for player %s: %v", accountID, err)
+		client.Send("Error retrieving login history.")
+		return
+	}
+	
+	if len(records) == 0 {
+		client.Send("No logins recorded.")
+		return
+	}
+	
+	client.Send("Recent logins:")
+	for _, record := range records {
+		result := "success"
+		if !record.Success {
+			result = "failed"
+			if record.Reason != "" {
+				result += " (" + record.Reason + ")"
+			}
+		}
+		client.Send(fmt.Sprintf("  %s  %-39s %s",
+			record.Timestamp.Format("2006-01-02 15:04:05"), record.IPAddress, result))
+	}
+}
+
+// sendVerificationEmail mails the player's pending verification code
+func (sh *SessionHandler) sendVerificationEmail(p *player.Player) {
+	body := fmt.Sprintf("Welcome to DungeoGo, %s!\n\nYour verification code is: %s\n\n"+
+		"Enter 'verify %s' at the character menu to confirm your email address.",
+		p.Username, p.VerificationCode, p.VerificationCode)
+	
+	if err := sh.mailer.Send(p.Email, "Verify your DungeoGo account", body); err != nil {
+		sh.logger.Warnf("Failed to send verification email for player %s: %v", p.ID, err)
+	}
+}
+
+// remindUnverified tells the player their email still needs verifying
+func (sh *SessionHandler) remindUnverified(client *Client, p *player.Player) {
+	if sh.verification == auth.VerificationOff || p.EmailVerified {
+		return
+	}
+	
+	client.Send("Your email address is not verified yet. We sent you a verification code.")
+	if sh.verification == auth.VerificationRequired {
+		client.Send("You must verify it before entering the game: use 'verify <code>'.")
+	} else {
+		client.Send("Use 'verify <code>' at the character menu to verify it.")
+	}
+}
+
+// verifyEmail checks a verification code entered at the character menu
+func (sh *SessionHandler) verifyEmail(client *Client, code string) {
+	existingPlayer, err := sh.repoManager.Players().GetPlayer(client.GetPlayerID())
+	if err != nil {
+		client.Send("Error retrieving account.")
+		return
+	}
+	
+	if existingPlayer.EmailVerified {
+		client.Send("Your email address is already verified.")
+		return
+	}
+	
+	// Codes are short, so wrong guesses count like failed logins
+	limiterKeys := verifyLimiterKeys(client, existingPlayer.ID)
+	if locked, _ := sh.loginLimiter.IsLocked(limiterKeys...); locked {
+		client.Send("Too many failed attempts, try again later.")
+		return
+	}
+	
+	if !existingPlayer.VerifyEmail(strings.TrimSpace(code)) {
+		sh.loginLimiter.RecordFailure(limiterKeys...)
+		sh.logger.Infof("Wrong verification code for player %s from client %s", existingPlayer.ID, client.GetID())
+		client.Send("That verification code is not valid.")
+		return
+	}
+	sh.loginLimiter.Reset(limiterKeys[0])
+	
+	if err := sh.repoManager.Players().UpdatePlayer(existingPlayer); err != nil {
+		sh.logger.Errorf("Failed to save verification for player %s: %v", existingPlayer.ID, err)
+		client.Send("Error saving verification. Please try again.")
+		return
+	}
+	
+	client.Send("Thank you! Your email address is now verified.")
+}
+
+// resendVerification issues a fresh code and mails it again
+func (sh *SessionHandler) resendVerification(client *Client) {
+	if sh.verification == auth.VerificationOff {
+		client.Send("Email verification is not enabled on this server.")
+		return
+	}
+	
+	existingPlayer, err := sh.repoManager.Players().GetPlayer(client.GetPlayerID())
+	if err != nil {
+		client.Send("Error retrieving account.")
+		return
+	}
+	
+	if existingPlayer.EmailVerified {
+		client.Send("Your email address is already verified.")
+		return
+	}
+	
+	code, err := auth.GenerateVerificationCode()
+	if err != nil {
+		client.Send("Unable to send a verification code right now.")
+		return
+	}
+	
+	existingPlayer.StartEmailVerification(code)
+	if err := sh.repoManager.Players().UpdatePlayer(existingPlayer); err != nil {
+		sh.logger.Errorf("Failed to save verification code for player %s: %v", existingPlayer.ID, err)
+		client.Send("Unable to send a verification code right now.")
+		return
+	}
+	
+	sh.sendVerificationEmail(existingPlayer)
+	client.Send("A new verification code has been sent to your email address.")
+}
+
+// admitPlayer applies the player's benefits to their connection. Once the
+// server is past its limit, only premium players may use the reserved slots
+// and anyone else is disconnected. An admitted player is registered with
+// the connection manager, replacing any connection they had before.
+func (sh *SessionHandler) admitPlayer(client *Client, p *player.Player) bool {
+	premium := p.HasPremium()
+	client.SetPremium(premium, sh.benefits.CommandRateFor(p))
+	
+	if sh.clients == nil {
+		return true
+	}
+	if !premium && sh.clients.IsFull() {
+		client.Send("The server is full. Premium players may still join; please try again later.")
+		client.Close()
+		return false
+	}
+	sh.clients.RegisterPlayerClient(p.ID, client)
+	return true
+}
+
+// PremiumLogin reports whether username and password log in to an account
+// with premium, so a connection waiting in the queue for a slot may move
+// ahead. Failures count like failed logins, and the IP is checked before
+// the account is looked up.
+func (sh *SessionHandler) PremiumLogin(client *Client, username, password string) bool {
+	// The account key is unknown until it is looked up
+	ipKeys := loginLimiterKeys(client, "")[1:]
+	if locked, _ := sh.loginLimiter.IsLocked(ipKeys...); locked {
+		return false
+	}
+	
+	p, err := sh.repoManager.Players().GetPlayerByUsername(username)
+	if err != nil {
+		sh.loginLimiter.RecordFailure(ipKeys...)
+		return false
+	}
+	
+	limiterKeys := loginLimiterKeys(client, p.ID)
+	if locked, _ := sh.loginLimiter.IsLocked(limiterKeys...); locked {
+		return false
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(p.PasswordHash), []byte(password)); err != nil {
+		sh.loginLimiter.RecordFailure(limiterKeys...)
+		sh.logger.Infof("Failed queue login for player %s from client %s", p.ID, client.GetID())
+		return false
+	}
+	return p.IsActive() && p.HasPremium()
+}
+
+// maxSubscribeMonths is the most premium that can be bought at once
+const maxSubscribeMonths = 12
+
+// expireSubscription ends a premium subscription that has run out, saving the
+// change and telling the player.
+func (sh *SessionHandler) expireSubscription(client *Client, p *player.Player) {
+	if !p.ExpireSubscription(time.Now()) {
+		return
+	}
+	if err := sh.repoManager.Players().UpdatePlayer(p); err != nil {
+		sh.logger.Errorf("Failed to expire subscription for player %s: %v", p.ID, err)
+		return
+	}
+	client.Send(fmt.Sprintf("Your premium subscription has expired. Your account is back to %d character slots.", sh.benefits.MaxCharacters(p)))
+	if sh.payments != nil {
+		client.Send("Type 'subscribe' to renew it.")
+	}
+}
+
+// subscribe charges the player for months of premium and extends their
+// subscription.
+func (sh *SessionHandler) subscribe(client *Client, months int) {
+	if sh.payments == nil {
+		client.Send("Premium can't be bought on this server.")
+		return
+	}
+	if months < 1 || months > maxSubscribeMonths {
+		client.Send(fmt.Sprintf("Usage: subscribe [months], from 1 to %d", maxSubscribeMonths))
+		return
+	}
+	
+	existingPlayer, err := sh.repoManager.Players().GetPlayer(client.GetPlayerID())
+	if err != nil {
+		client.Send("Error retrieving account.")
+		return
+	}
+	
+	if err := sh.payments.Charge(existingPlayer, months); err != nil {
+		sh.logger.Infof("Payment failed for player %s: %v", existingPlayer.ID, err)
+		client.Send("Your payment could not be processed.")
+		return
+	}
+	
+	existingPlayer.RenewSubscription(months, time.Now())
+	if err := sh.repoManager.Players().UpdatePlayer(existingPlayer); err != nil {
+		sh.logger.Errorf("Failed to save subscription for player %s after payment: %v", existingPlayer.ID, err)
+		client.Send("Error saving your subscription. Please contact an administrator.")
+		return
+	}
+	client.SetPremium(true, sh.benefits.CommandRateFor(existingPlayer))
+	client.Send(fmt.Sprintf("Thank you! Your premium subscription runs until %s.",
+		existingPlayer.Subscription.ExpiresAt.Format("2006-01-02")))
+}
@@ -0,0 +1,580 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/elidor/dungeogo/pkg/auth"
+	"github.com/elidor/dungeogo/pkg/billing"
+	"github.com/elidor/dungeogo/pkg/commands"
+	"github.com/elidor/dungeogo/pkg/game/character"
+	"github.com/elidor/dungeogo/pkg/game/items"
+	"github.com/elidor/dungeogo/pkg/game/player"
+	"github.com/elidor/dungeogo/pkg/persistence/interfaces"
+)
+
+// loginPlayers is an in-memory player repository for login tests
+type loginPlayers struct {
+	byID      map[string]*player.Player
+	lookupErr error
+}
+
+func newLoginPlayers(players ...*player.Player) *loginPlayers {
+	repo := &loginPlayers{byID: make(map[string]*player.Player)}
+	for _, p := range players {
+		repo.byID[p.ID] = p
+	}
+	return repo
+}
+
+func (r *loginPlayers) CreatePlayer(p *player.Player) error {
+	r.byID[p.ID] = p
+	return nil
+}
+
+func (r *loginPlayers) GetPlayer(playerID string) (*player.Player, error) {
+	if p, ok := r.byID[playerID]; ok {
+		return p, nil
+	}
+	return nil, fmt.Errorf("%w: %s", interfaces.ErrPlayerNotFound, playerID)
+}
+
+func (r *loginPlayers) GetPlayerByUsername(username string) (*player.Player, error) {
+	if r.lookupErr != nil {
+		return nil, r.lookupErr
+	}
+	for _, p := range r.byID {
+		if p.Username == username {
+			return p, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", interfaces.ErrPlayerNotFound, username)
+}
+
+func (r *loginPlayers) GetPlayerByEmail(email string) (*player.Player, error) {
+	for _, p := range r.byID {
+		if p.Email == email {
+			return p, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", interfaces.ErrPlayerNotFound, email)
+}
+
+func (r *loginPlayers) UpdatePlayer(p *player.Player) error {
+	r.byID[p.ID] = p
+	return nil
+}
+
+func (r *loginPlayers) UpdatePlayerLogin(playerID string) error { return nil }
+
+func (r *loginPlayers) DeletePlayer(playerID string) error {
+	delete(r.byID, playerID)
+	return nil
+}
+
+func (r *loginPlayers) RecordLogin(record *interfaces.LoginRecord) error { return nil }
+
+func (r *loginPlayers) GetRecentLogins(playerID string, limit int) ([]*interfaces.LoginRecord, error) {
+	return nil, nil
+}
+
+// loginRepos only provides players; login never touches the other repositories
+type loginRepos struct {
+	players *loginPlayers
+}
+
+func (m *loginRepos) Players() interfaces.PlayerRepository       { return m.players }
+func (m *loginRepos) Characters() interfaces.CharacterRepository { return nil }
+func (m *loginRepos) Items() interfaces.ItemRepository           { return nil }
+func (m *loginRepos) World() interfaces.WorldRepository          { return nil }
+func (m *loginRepos) Reports() interfaces.ReportRepository       { return nil }
+func (m *loginRepos) Close() error                               { return nil }
+
+// newLoginClient returns a client whose output is collected until finish is
+// called, which disconnects it and returns everything it was sent.
+func newLoginClient() (client *Client, finish func() string) {
+	serverConn, peer := net.Pipe()
+	client = NewClient("test", serverConn)
+
+	output := make(chan string, 1)
+	go func() {
+		data, _ := io.ReadAll(peer)
+		output <- string(data)
+	}()
+
+	return client, func() string {
+		client.Close()
+		return <-output
+	}
+}
+
+func TestLoginUnknownUsernameCreatesAccount(t *testing.T) {
+	sh := NewSessionHandler(&loginRepos{players: newLoginPlayers()}, nil)
+	client, finish := newLoginClient()
+
+	sh.handleLogin(client, "newcomer")
+
+	if client.GetState() != StateCreatingAccount {
+		t.Errorf("Expected account creation, got state %v", client.GetState())
+	}
+	if client.GetTempUsername() != "newcomer" {
+		t.Errorf("Expected the username to be kept, got %q", client.GetTempUsername())
+	}
+	if out := finish(); !strings.Contains(out, "New player! Creating account for: newcomer") {
+		t.Errorf("Expected the new player greeting, got %q", out)
+	}
+}
+
+func TestLoginKnownUsernameAuthenticates(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("correct horse"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("Failed to hash password: %v", err)
+	}
+	existing := player.NewPlayer("veteran", "veteran@example.com", string(hash))
+	sh := NewSessionHandler(&loginRepos{players: newLoginPlayers(existing)}, nil)
+	client, finish := newLoginClient()
+
+	sh.handleLogin(client, "veteran")
+	if client.GetState() != StateAuthenticating {
+		t.Fatalf("Expected a password prompt, got state %v", client.GetState())
+	}
+	if client.GetPlayerID() != existing.ID {
+		t.Errorf("Expected player %s, got %q", existing.ID, client.GetPlayerID())
+	}
+
+	sh.handlePasswordAuth(client, "correct horse")
+	if client.GetState() != StateCharacterSelection {
+		t.Errorf("Expected character selection, got state %v", client.GetState())
+	}
+	if out := finish(); !strings.Contains(out, "Welcome back, veteran!") {
+		t.Errorf("Expected a welcome back message, got %q", out)
+	}
+}
+
+func TestLoginLookupFailureDoesNotCreateAccount(t *testing.T) {
+	players := newLoginPlayers()
+	players.lookupErr = errors.New("connection refused")
+	sh := NewSessionHandler(&loginRepos{players: players}, nil)
+	client, finish := newLoginClient()
+
+	sh.handleLogin(client, "veteran")
+
+	if client.GetState() != StateConnected {
+		t.Errorf("Expected to ask for the username again, got state %v", client.GetState())
+	}
+	if out := finish(); strings.Contains(out, "New player!") {
+		t.Errorf("Expected no account creation on a lookup failure, got %q", out)
+	}
+}
+
+func TestLoginHoldsQueuedPlayerToTheirAccount(t *testing.T) {
+	sh := NewSessionHandler(&loginRepos{players: newLoginPlayers()}, nil)
+	client, finish := newLoginClient()
+	client.setQueuedAccount("patron")
+
+	sh.handleLogin(client, "newcomer")
+
+	if client.GetState() != StateConnected {
+		t.Errorf("Expected to ask for the username again, got state %v", client.GetState())
+	}
+	if out := finish(); !strings.Contains(out, "You waited in the queue as patron, so please log in as patron:") {
+		t.Errorf("Expected to be held to the queued account, got %q", out)
+	}
+}
+
+func TestPasswordAuthWithoutUsernameRestartsLogin(t *testing.T) {
+	sh := NewSessionHandler(&loginRepos{players: newLoginPlayers()}, nil)
+	client, finish := newLoginClient()
+	client.SetState(StateAuthenticating)
+
+	sh.handlePasswordAuth(client, "secret")
+
+	if client.GetState() != StateConnected {
+		t.Errorf("Expected login to restart, got state %v", client.GetState())
+	}
+	if !client.IsConnected() {
+		t.Errorf("Expected the client to stay connected")
+	}
+	if out := finish(); !strings.Contains(out, "Please enter your username:") {
+		t.Errorf("Expected a username prompt, got %q", out)
+	}
+}
+
+// savingCharacters records the characters saved through it
+type savingCharacters struct {
+	interfaces.CharacterRepository
+	stored map[string]*character.Character
+	saves  int
+}
+
+func (r *savingCharacters) GetCharacter(characterID string) (*character.Character, error) {
+	if char, ok := r.stored[characterID]; ok {
+		return char, nil
+	}
+	return nil, fmt.Errorf("character not found: %s", characterID)
+}
+
+func (r *savingCharacters) UpdateCharacter(char *character.Character) error {
+	r.stored[char.ID] = char
+	r.saves++
+	return nil
+}
+
+// savingItems records the items saved through it
+type savingItems struct {
+	interfaces.ItemRepository
+	carried []*items.ItemInstance
+	saved   []string
+}
+
+func (r *savingItems) GetPlayerItems(characterID string) ([]*items.ItemInstance, error) {
+	return r.carried, nil
+}
+
+func (r *savingItems) UpdateItemInstance(item *items.ItemInstance) error {
+	r.saved = append(r.saved, item.ID)
+	return nil
+}
+
+type savingRepos struct {
+	characters *savingCharacters
+	items      *savingItems
+}
+
+func (m *savingRepos) Players() interfaces.PlayerRepository       { return nil }
+func (m *savingRepos) Characters() interfaces.CharacterRepository { return m.characters }
+func (m *savingRepos) Items() interfaces.ItemRepository           { return m.items }
+func (m *savingRepos) World() interfaces.WorldRepository          { return nil }
+func (m *savingRepos) Reports() interfaces.ReportRepository       { return nil }
+func (m *savingRepos) Close() error                               { return nil }
+
+// leavingEngine records which characters left the game
+type leavingEngine struct {
+	left []string
+}
+
+func (e *leavingEngine) ProcessCommand(characterID, command string) (*commands.CommandResult, error) {
+	return commands.Reply(), nil
+}
+
+func (e *leavingEngine) GetCharacterState(characterID string) (interface{}, error) { return nil, nil }
+
+func (e *leavingEngine) EnterGame(characterID string) ([]string, error) { return nil, nil }
+
+func (e *leavingEngine) LeaveGame(characterID string) {
+	e.left = append(e.left, characterID)
+}
+
+func (e *leavingEngine) LockCharacter(characterID string) func() { return func() {} }
+
+func TestDroppedConnectionSavesCharacter(t *testing.T) {
+	char := &character.Character{ID: "char1", Name: "Alice", LastPlayed: time.Now().Add(-time.Minute)}
+	repos := &savingRepos{
+		characters: &savingCharacters{stored: map[string]*character.Character{char.ID: char}},
+		items:      &savingItems{carried: []*items.ItemInstance{{ID: "item1"}, {ID: "item2"}}},
+	}
+	engine := &leavingEngine{}
+	sh := NewSessionHandler(repos, engine)
+	cm := NewConnectionManager(10, time.Minute)
+	sh.SetConnectionManager(cm)
+
+	serverConn, peer := net.Pipe()
+	client := NewClient("test", serverConn)
+	cm.RegisterPlayerClient("player1", client)
+	client.SetCharacterID(char.ID)
+	client.SetState(StateInGame)
+
+	done := make(chan struct{})
+	go func() {
+		sh.HandleClient(client)
+		close(done)
+	}()
+
+	// Read the welcome, then drop the connection without quitting
+	buf := make([]byte, 256)
+	if _, err := peer.Read(buf); err != nil {
+		t.Fatalf("Failed to read welcome: %v", err)
+	}
+	peer.Close()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("HandleClient did not return after the connection dropped")
+	}
+
+	if repos.characters.saves != 1 {
+		t.Errorf("Expected the character to be saved once, got %d saves", repos.characters.saves)
+	}
+	if char.PlayTime < time.Minute {
+		t.Errorf("Expected the session's play time to be recorded, got %v", char.PlayTime)
+	}
+	if strings.Join(repos.items.saved, ",") != "item1,item2" {
+		t.Errorf("Expected the carried items to be saved, got %v", repos.items.saved)
+	}
+	if len(engine.left) != 1 || engine.left[0] != char.ID {
+		t.Errorf("Expected the character to leave the game, got %v", engine.left)
+	}
+	if _, ok := cm.GetPlayerClient("player1"); ok {
+		t.Errorf("Expected the player to be unregistered")
+	}
+}
+
+func TestDisconnectKeepsNewerConnection(t *testing.T) {
+	sh := NewSessionHandler(&loginRepos{players: newLoginPlayers()}, &leavingEngine{})
+	cm := NewConnectionManager(10, time.Minute)
+	sh.SetConnectionManager(cm)
+
+	old, finishOld := newLoginClient()
+	cm.RegisterPlayerClient("player1", old)
+	current, finishCurrent := newLoginClient()
+	cm.RegisterPlayerClient("player1", current)
+
+	sh.disconnect(old)
+
+	if client, ok := cm.GetPlayerClient("player1"); !ok || client != current {
+		t.Errorf("Expected the newer connection to stay registered")
+	}
+	finishOld()
+	finishCurrent()
+}
+
+func TestLoginRegistersPlayerUntilDisconnect(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("correct horse"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("Failed to hash password: %v", err)
+	}
+	existing := player.NewPlayer("veteran", "veteran@example.com", string(hash))
+	sh := NewSessionHandler(&loginRepos{players: newLoginPlayers(existing)}, &leavingEngine{})
+	cm := NewConnectionManager(10, time.Minute)
+	sh.SetConnectionManager(cm)
+	client, finish := newLoginClient()
+	defer finish()
+
+	sh.handleLogin(client, "veteran")
+	sh.handlePasswordAuth(client, "correct horse")
+	if registered, ok := cm.GetPlayerClient(existing.ID); !ok || registered != client {
+		t.Fatalf("Expected the player to be registered once logged in")
+	}
+
+	sh.disconnect(client)
+	if _, ok := cm.GetPlayerClient(existing.ID); ok {
+		t.Errorf("Expected the player to be unregistered on disconnect")
+	}
+}
+
+func TestCreateCharacterRejectsInvalidName(t *testing.T) {
+	sh := NewSessionHandler(&loginRepos{players: newLoginPlayers()}, nil)
+	client, finish := newLoginClient()
+
+	sh.createCharacter(client, "Bob2", "human", "warrior")
+
+	if out := finish(); !strings.Contains(out, "Invalid name: Name may only contain letters") {
+		t.Errorf("Expected the name policy to be explained, got %q", out)
+	}
+}
+
+// namedCharacters holds characters to be found by name, ignoring case
+type namedCharacters struct {
+	interfaces.CharacterRepository
+	names []string
+}
+
+func (r *namedCharacters) GetCharacterByName(name string) (*character.Character, error) {
+	for _, existing := range r.names {
+		if strings.EqualFold(existing, name) {
+			return &character.Character{Name: existing}, nil
+		}
+	}
+	return nil, interfaces.ErrCharacterNotFound
+}
+
+type namedRepos struct {
+	loginRepos
+	characters *namedCharacters
+}
+
+func (m *namedRepos) Characters() interfaces.CharacterRepository { return m.characters }
+
+func TestCreateCharacterRejectsNameTakenInAnotherCase(t *testing.T) {
+	repos := &namedRepos{loginRepos{players: newLoginPlayers()}, &namedCharacters{names: []string{"Bob"}}}
+	sh := NewSessionHandler(repos, nil)
+	client, finish := newLoginClient()
+
+	sh.createCharacter(client, "bob", "human", "warrior")
+
+	if out := finish(); !strings.Contains(out, "The name 'bob' is already taken.") {
+		t.Errorf("Expected the name to be refused, got %q", out)
+	}
+}
+
+func TestAccountCreationAcceptsInternationalEmail(t *testing.T) {
+	sh := NewSessionHandler(&loginRepos{players: newLoginPlayers()}, nil)
+	client, finish := newLoginClient()
+	client.SetState(StateCreatingAccount)
+
+	sh.handleAccountCreation(client, "zoë@exämple.de")
+
+	if client.GetState() != StateConfirmingPassword {
+		t.Errorf("Expected a password prompt, got state %v", client.GetState())
+	}
+	if client.GetTempEmail() != "zoë@exämple.de" {
+		t.Errorf("Expected the email to be kept, got %q", client.GetTempEmail())
+	}
+	finish()
+}
+
+func TestAccountCreationRejectsMixedCaseDuplicateEmail(t *testing.T) {
+	existing := player.NewPlayer("alice", "alice@example.com", "hash")
+	sh := NewSessionHandler(&loginRepos{players: newLoginPlayers(existing)}, nil)
+	client, finish := newLoginClient()
+	client.SetState(StateCreatingAccount)
+
+	sh.handleAccountCreation(client, " Alice@Example.COM ")
+
+	if out := finish(); !strings.Contains(out, "An account with this email already exists.") {
+		t.Errorf("Expected the duplicate email to be refused, got %q", out)
+	}
+	if client.GetTempEmail() != "" {
+		t.Errorf("Expected the duplicate email not to be kept, got %q", client.GetTempEmail())
+	}
+}
+
+func TestAccountCreationNormalizesEmail(t *testing.T) {
+	sh := NewSessionHandler(&loginRepos{players: newLoginPlayers()}, nil)
+	client, finish := newLoginClient()
+	client.SetState(StateCreatingAccount)
+
+	sh.handleAccountCreation(client, " Bob@Example.COM")
+
+	if client.GetTempEmail() != "bob@example.com" {
+		t.Errorf("Expected the email to be lowercased and trimmed, got %q", client.GetTempEmail())
+	}
+	finish()
+}
+
+func TestLoginShowsPreviousLogin(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("correct horse"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("Failed to hash password: %v", err)
+	}
+	existing := player.NewPlayer("veteran", "veteran@example.com", string(hash))
+	previous := time.Date(2026, 3, 14, 9, 30, 0, 0, time.UTC)
+	existing.LastLogin = previous
+	sh := NewSessionHandler(&loginRepos{players: newLoginPlayers(existing)}, nil)
+	client, finish := newLoginClient()
+
+	sh.handleLogin(client, "veteran")
+	sh.handlePasswordAuth(client, "correct horse")
+
+	if out := finish(); !strings.Contains(out, "Last login: 2026-03-14 09:30 UTC") {
+		t.Errorf("Expected the previous login to be shown, got %q", out)
+	}
+	if !existing.LastLogin.After(previous) {
+		t.Errorf("Expected the login time to be updated afterwards, got %v", existing.LastLogin)
+	}
+}
+
+func TestLoginSummary(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	p := &player.Player{LastLogin: now.Add(-3 * time.Hour)}
+	if got := loginSummary(p, now); len(got) != 1 || got[0] != "Last login: 2026-10-17 09:00 UTC (3 hours ago)" {
+		t.Errorf("Unexpected summary %v", got)
+	}
+	if got := loginSummary(&player.Player{}, now); len(got) != 0 {
+		t.Errorf("Expected nothing for a player who never logged in, got %v", got)
+	}
+}
+
+func TestSubscribeNeedsPaymentProcessor(t *testing.T) {
+	existing := player.NewPlayer("patron", "patron@example.com", "hash")
+	sh := NewSessionHandler(&loginRepos{players: newLoginPlayers(existing)}, nil)
+
+	client, finish := newLoginClient()
+	client.SetPlayerID(existing.ID)
+	sh.subscribe(client, 1)
+	if out := finish(); !strings.Contains(out, "Premium can't be bought on this server.") || existing.HasPremium() {
+		t.Errorf("Expected no premium without a payment processor, got %q", out)
+	}
+
+	sh.SetPaymentProcessor(billing.NoopProcessor{})
+	client, finish = newLoginClient()
+	client.SetPlayerID(existing.ID)
+	sh.subscribe(client, 1)
+	if out := finish(); !strings.Contains(out, "Thank you!") || !existing.HasPremium() {
+		t.Errorf("Expected premium once payment goes through, got %q", out)
+	}
+}
+
+func TestVerifyEmailLocksAfterWrongCodes(t *testing.T) {
+	existing := player.NewPlayer("newcomer", "newcomer@example.com", "hash")
+	existing.StartEmailVerification("123456")
+	sh := NewSessionHandler(&loginRepos{players: newLoginPlayers(existing)}, nil)
+	sh.SetLoginLimiter(auth.NewLoginLimiter(3, time.Minute, time.Minute))
+
+	client, finish := newLoginClient()
+	client.SetPlayerID(existing.ID)
+	for _, code := range []string{"000000", "111111", "222222", "123456"} {
+		sh.verifyEmail(client, code)
+	}
+	if out := finish(); !strings.Contains(out, "Too many failed attempts") || existing.EmailVerified {
+		t.Errorf("Expected the right code to be refused after too many wrong ones, got %q", out)
+	}
+}
+
+func TestCurrentPasswordCheckLocksAfterFailures(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("correct horse"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("Failed to hash password: %v", err)
+	}
+	existing := player.NewPlayer("veteran", "veteran@example.com", string(hash))
+	sh := NewSessionHandler(&loginRepos{players: newLoginPlayers(existing)}, nil)
+	sh.SetLoginLimiter(auth.NewLoginLimiter(3, time.Minute, time.Minute))
+
+	client, finish := newLoginClient()
+	client.SetPlayerID(existing.ID)
+	for _, password := range []string{"wrong", "wronger", "wrongest"} {
+		sh.handleCurrentPasswordVerification(client, password)
+	}
+	sh.handleCurrentPasswordVerification(client, "correct horse")
+	if client.GetState() == StateChangingPassword {
+		t.Error("Expected the password change to be refused while locked")
+	}
+	if out := finish(); !strings.Contains(out, "Too many failed attempts") {
+		t.Errorf("Expected to be told about the lockout, got %q", out)
+	}
+}
+
+func TestPremiumLogin(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("correct horse"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("Failed to hash password: %v", err)
+	}
+	patron := player.NewPlayer("patron", "patron@example.com", string(hash))
+	patron.RenewSubscription(1, time.Now())
+	pauper := player.NewPlayer("pauper", "pauper@example.com", string(hash))
+	sh := NewSessionHandler(&loginRepos{players: newLoginPlayers(patron, pauper)}, nil)
+	client, finish := newLoginClient()
+	defer finish()
+
+	tests := []struct {
+		username, password string
+		want               bool
+	}{
+		{"patron", "correct horse", true},
+		{"patron", "wrong", false},
+		{"pauper", "correct horse", false},
+		{"nobody", "correct horse", false},
+	}
+	for _, tt := range tests {
+		if got := sh.PremiumLogin(client, tt.username, tt.password); got != tt.want {
+			t.Errorf("PremiumLogin(%q, %q) = %v, want %v", tt.username, tt.password, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/elidor/dungeogo/pkg/logging"
+	"github.com/elidor/dungeogo/pkg/persistence/interfaces"
+	"github.com/elidor/dungeogo/pkg/persistence/postgres"
+)
+
+// statusShutdownTimeout bounds how long Stop waits for in-flight requests
+const statusShutdownTimeout = 5 * time.Second
+
+// DatabaseHealth reports the latest database health check result
+type DatabaseHealth interface {
+	Status() postgres.HealthStatus
+}
+
+// ConnectionSource is what the status API needs from the connection manager
+type ConnectionSource interface {
+	GetStats() ConnectionStats
+	OnlineCharacters() []OnlineCharacter
+}
+
+// StatusServer serves read-only JSON about the running server over HTTP,
+// for dashboards and monitoring. It has no authentication, so bind it to an
+// address only operators can reach.
+type StatusServer struct {
+	connections ConnectionSource
+	characters  interfaces.CharacterRepository
+	database    DatabaseHealth
+	logger      *logging.Logger
+	startedAt   time.Time
+	server      *http.Server
+}
+
+type statusResponse struct {
+	StartedAt     time.Time      `json:"started_at"`
+	UptimeSeconds int64          `json:"uptime_seconds"`
+	Connections   connectionJSON `json:"connections"`
+	Database      databaseJSON   `json:"database"`
+}
+
+type connectionJSON struct {
+	Total         int `json:"total"`
+	Authenticated int `json:"authenticated"`
+	InGame        int `json:"in_game"`
+}
+
+type databaseJSON struct {
+	Healthy             bool      `json:"healthy"`
+	LastCheck           time.Time `json:"last_check"`
+	LastError           string    `json:"last_error,omitempty"`
+	ConsecutiveFailures int       `json:"consecutive_failures"`
+}
+
+type playerJSON struct {
+	Name        string `json:"name"`
+	Level       int    `json:"level"`
+	Race        string `json:"race"`
+	Class       string `json:"class"`
+	Room        string `json:"room"`
+	IdleSeconds int64  `json:"idle_seconds"`
+}
+
+func NewStatusServer(connections ConnectionSource, characters interfaces.CharacterRepository, database DatabaseHealth, logger *logging.Logger) *StatusServer {
+	return &StatusServer{
+		connections: connections,
+		characters:  characters,
+		database:    database,
+		logger:      logger,
+		startedAt:   time.Now(),
+	}
+}
+
+// Handler returns the API's routes
+func (s *StatusServer) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/status", s.handleStatus)
+	mux.HandleFunc("/players", s.handlePlayers)
+	return mux
+}
+
+// Start listens on address and serves in the background
+func (s *StatusServer) Start(address string) error {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return fmt.Errorf("failed to start status listener: %w", err)
+	}
+
+	s.server = &http.Server{Handler: s.Handler(), ReadHeaderTimeout: 5 * time.Second}
+	go func() {
+		if err := s.server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			s.logger.Errorf("Status server stopped: %v", err)
+		}
+	}()
+	return nil
+}
+
+// Stop shuts the status server down, waiting briefly for open requests
+func (s *StatusServer) Stop() error {
+	if s.server == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), statusShutdownTimeout)
+	defer cancel()
+	return s.server.Shutdown(ctx)
+}
+
+func (s *StatusServer) handleStatus(w http.ResponseWriter, r *http.Request) {
+	if !allowRead(w, r) {
+		return
+	}
+
+	stats := s.connections.GetStats()
+	response := statusResponse{
+		StartedAt:     s.startedAt,
+		UptimeSeconds: int64(time.Since(s.startedAt).Seconds()),
+		Connections: connectionJSON{
+			Total:         stats.TotalClients,
+			Authenticated: stats.AuthenticatedClients,
+			InGame:        stats.InGameClients,
+		},
+	}
+
+	if s.database != nil {
+		health := s.database.Status()
+		response.Database = databaseJSON{
+			Healthy:             health.Healthy,
+			LastCheck:           health.LastCheck,
+			ConsecutiveFailures: health.ConsecutiveFailures,
+		}
+		if health.LastError != nil {
+			response.Database.LastError = health.LastError.Error()
+		}
+	}
+
+	writeJSON(w, response)
+}
+
+func (s *StatusServer) handlePlayers(w http.ResponseWriter, r *http.Request) {
+	if !allowRead(w, r) {
+		return
+	}
+
+	players := []playerJSON{}
+	for _, online := range s.connections.OnlineCharacters() {
+		c, err := s.characters.GetCharacter(online.CharacterID)
+		if err != nil {
+			s.logger.Debugf("Status API skipped character %s: %v", online.CharacterID, err)
+			continue
+		}
+
+		player := playerJSON{
+			Name:        c.Name,
+			Level:       c.Level,
+			Room:        online.RoomID,
+			IdleSeconds: int64(online.Idle.Seconds()),
+		}
+		if c.Race != nil {
+			player.Race = c.Race.Name
+		}
+		if c.Class != nil {
+			player.Class = c.Class.Name
+		}
+		players = append(players, player)
+	}
+	sort.Slice(players, func(i, j int) bool { return players[i].Name < players[j].Name })
+
+	writeJSON(w, map[string]interface{}{
+		"count":   len(players),
+		"players": players,
+	})
+}
+
+// allowRead rejects anything but GET and HEAD, keeping the API read-only
+func allowRead(w http.ResponseWriter, r *http.Request) bool {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(value); err != nil {
+		http.Error(w, "failed to encode response", http.StatusInternalServerError)
+	}
+}
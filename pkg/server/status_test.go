@@ -0,0 +1,85 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/elidor/dungeogo/pkg/logging"
+	"github.com/elidor/dungeogo/pkg/persistence/postgres"
+)
+
+type fakeConnections struct {
+	stats ConnectionStats
+}
+
+func (f *fakeConnections) GetStats() ConnectionStats { return f.stats }
+
+func (f *fakeConnections) OnlineCharacters() []OnlineCharacter { return nil }
+
+type fakeHealth struct {
+	status postgres.HealthStatus
+}
+
+func (f *fakeHealth) Status() postgres.HealthStatus { return f.status }
+
+func TestStatusEndpoint(t *testing.T) {
+	connections := &fakeConnections{stats: ConnectionStats{TotalClients: 3, AuthenticatedClients: 2, InGameClients: 1}}
+	health := &fakeHealth{status: postgres.HealthStatus{
+		Healthy:             false,
+		LastCheck:           time.Now(),
+		LastError:           errors.New("connection refused"),
+		ConsecutiveFailures: 2,
+	}}
+	status := NewStatusServer(connections, nil, health, logging.Discard())
+
+	recorder := httptest.NewRecorder()
+	status.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/status", nil))
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", recorder.Code)
+	}
+
+	var body statusResponse
+	if err := json.Unmarshal(recorder.Body.Bytes(), &body); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if body.Connections.Total != 3 || body.Connections.InGame != 1 {
+		t.Errorf("Unexpected connections: %+v", body.Connections)
+	}
+	if body.Database.Healthy || body.Database.LastError != "connection refused" || body.Database.ConsecutiveFailures != 2 {
+		t.Errorf("Unexpected database status: %+v", body.Database)
+	}
+}
+
+func TestPlayersEndpointEmpty(t *testing.T) {
+	status := NewStatusServer(&fakeConnections{}, nil, nil, logging.Discard())
+
+	recorder := httptest.NewRecorder()
+	status.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/players", nil))
+
+	var body struct {
+		Count   int
+		Players []playerJSON
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &body); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if body.Count != 0 || body.Players == nil {
+		t.Errorf("Expected an empty player list, got %+v", body)
+	}
+}
+
+func TestStatusAPIIsReadOnly(t *testing.T) {
+	status := NewStatusServer(&fakeConnections{}, nil, nil, logging.Discard())
+
+	recorder := httptest.NewRecorder()
+	status.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/status", nil))
+
+	if recorder.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected 405 for POST, got %d", recorder.Code)
+	}
+}
@@ -0,0 +1,192 @@
+package server
+
+import (
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+	"time"
+)
+
+// DefaultQueueSize is how many connections may wait for a slot when
+// WAIT_QUEUE_SIZE is unset; with none, connections past capacity are turned
+// away
+const DefaultQueueSize = 0
+
+// queueInterval is how often waiting connections are let in as slots free up
+const queueInterval = time.Second
+
+// queuedClient is a connection waiting for a free slot
+type queuedClient struct {
+	client   *Client
+	premium  bool // Whether the client logged in to a premium account
+	position int  // The position the client was last told
+	admitted bool // Whether a slot has been taken for the client
+}
+
+// SetQueueSize lets up to size connections wait for a slot once the server
+// is full, rather than being turned away. Waiting connections are let in
+// first come, first served, except that premium players go ahead of everyone
+// else and may also use the reserved slots. 0 turns the queue off.
+func (cm *ConnectionManager) SetQueueSize(size int) {
+	cm.queueSize = size
+}
+
+// SetQueuePriority sets how to log in to a premium account, so connections
+// waiting in the queue can log in to theirs to move ahead. premiumLogin
+// reports whether the password is right and the account has premium.
+func (cm *ConnectionManager) SetQueuePriority(premiumLogin func(client *Client, account, password string) bool) {
+	cm.premiumLogin = premiumLogin
+}
+
+// enqueue puts conn at the back of the queue, reporting false if the queue
+// is full or turned off
+func (cm *ConnectionManager) enqueue(conn net.Conn) (*queuedClient, bool) {
+	cm.mutex.Lock()
+	defer cm.mutex.Unlock()
+
+	if len(cm.queue) >= cm.queueSize {
+		return nil, false
+	}
+
+	entry := &queuedClient{client: cm.newClient(conn), position: len(cm.queue) + 1}
+	cm.queue = append(cm.queue, entry)
+	entry.client.Send(fmt.Sprintf("The realm is full; you are position %d in the queue.", entry.position))
+	if cm.premiumLogin != nil {
+		entry.client.Send("Premium players may log in with their account name and password to move ahead.")
+	}
+	cm.logger.Infof("Queued %s at position %d", conn.RemoteAddr(), entry.position)
+	return entry, true
+}
+
+// serveQueued waits for entry to be let in, then serves it like any other
+// client
+func (cm *ConnectionManager) serveQueued(entry *queuedClient) {
+	if client, ok := cm.wait(entry); ok {
+		cm.serve(client)
+	}
+}
+
+// wait reads what entry sends until it is let in, reporting false if it
+// disconnects first. Being let in interrupts the read.
+func (cm *ConnectionManager) wait(entry *queuedClient) (*Client, bool) {
+	for {
+		line, err := entry.client.ReadLine()
+		if err != nil {
+			break
+		}
+		account := strings.TrimSpace(line)
+		if account == "" || cm.premiumLogin == nil || cm.isPremium(entry) {
+			continue
+		}
+		entry.client.SendPrompt("Password: ")
+		password, err := entry.client.ReadPassword()
+		if err != nil {
+			break
+		}
+		cm.claimAccount(entry, account, password)
+	}
+
+	cm.mutex.Lock()
+	admitted := entry.admitted
+	if !admitted {
+		cm.queue = slices.DeleteFunc(cm.queue, func(queued *queuedClient) bool { return queued == entry })
+		cm.tellPositions()
+	}
+	cm.mutex.Unlock()
+
+	if !admitted {
+		cm.logger.Debugf("Queued connection %s left", entry.client.GetRemoteAddr())
+		entry.client.Close()
+		return nil, false
+	}
+	entry.client.conn.SetReadDeadline(time.Time{})
+	entry.client.Send("A place in the realm has opened up for you.")
+	return entry.client, true
+}
+
+// isPremium reports whether entry has already moved ahead
+func (cm *ConnectionManager) isPremium(entry *queuedClient) bool {
+	cm.mutex.Lock()
+	defer cm.mutex.Unlock()
+	return entry.premium
+}
+
+// claimAccount moves entry ahead of everyone without premium if account and
+// password log in to a premium account. The client must then log in as that
+// account. Every failure gets the same reply, so the queue can't be used to
+// find out which accounts exist or have premium.
+func (cm *ConnectionManager) claimAccount(entry *queuedClient, account, password string) {
+	premium := cm.premiumLogin(entry.client, account, strings.TrimSpace(password))
+
+	cm.mutex.Lock()
+	defer cm.mutex.Unlock()
+
+	if entry.admitted || entry.premium {
+		return
+	}
+	if !premium {
+		entry.client.Send(fmt.Sprintf("Only premium players who log in move ahead; you are still position %d in the queue.", entry.position))
+		return
+	}
+
+	entry.premium = true
+	entry.client.setQueuedAccount(account)
+	cm.queue = slices.DeleteFunc(cm.queue, func(queued *queuedClient) bool { return queued == entry })
+	ahead := 0
+	for ahead < len(cm.queue) && cm.queue[ahead].premium {
+		ahead++
+	}
+	cm.queue = slices.Insert(cm.queue, ahead, entry)
+	cm.tellPositions()
+}
+
+// tellPositions tells each waiting client whose place has changed where it
+// now is. The caller must hold the mutex.
+func (cm *ConnectionManager) tellPositions() {
+	for i, entry := range cm.queue {
+		if entry.position != i+1 {
+			entry.position = i + 1
+			entry.client.Send(fmt.Sprintf("You are now position %d in the queue.", entry.position))
+		}
+	}
+}
+
+// promoteQueued lets in as many waiting clients as there are free slots.
+// Only premium players may take the reserved ones.
+func (cm *ConnectionManager) promoteQueued() {
+	cm.mutex.Lock()
+	defer cm.mutex.Unlock()
+
+	for len(cm.queue) > 0 {
+		entry := cm.queue[0]
+		limit := cm.maxClients
+		if entry.premium {
+			limit += cm.reservedSlots
+		}
+		if len(cm.clients) >= limit {
+			break
+		}
+
+		cm.queue = cm.queue[1:]
+		entry.admitted = true
+		cm.clients[entry.client.ID] = entry.client
+		// Wake the waiting read so the client can be served. A WebSocket
+		// keeps any frame it was partway through and reads the rest later.
+		entry.client.conn.SetReadDeadline(time.Now())
+		cm.logger.Infof("Queued client %s let in from %s", entry.client.ID, entry.client.GetRemoteAddr())
+	}
+	cm.tellPositions()
+}
+
+func (cm *ConnectionManager) runQueue() {
+	ticker := time.NewTicker(queueInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if !cm.running {
+			return
+		}
+		cm.promoteQueued()
+	}
+}
@@ -0,0 +1,153 @@
+package server
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"testing"
+	"time"
+)
+
+// queuedPeer is the far end of a queued connection
+type queuedPeer struct {
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+func (p *queuedPeer) expect(t *testing.T, want string) {
+	t.Helper()
+	p.conn.SetReadDeadline(time.Now().Add(time.Second))
+	line, err := p.reader.ReadString('\n')
+	if err != nil {
+		t.Fatalf("Expected %q, got %v", want, err)
+	}
+	if line != want+"\r\n" {
+		t.Errorf("Expected %q, got %q", want, line)
+	}
+}
+
+// login answers the queue's prompts with account and password
+func (p *queuedPeer) login(t *testing.T, account, password string) {
+	t.Helper()
+	p.conn.SetReadDeadline(time.Now().Add(time.Second))
+	p.conn.Write([]byte(account + "\r\n"))
+	// The prompt is followed by turning echo off, and the password by
+	// turning it back on and a newline
+	prompt := make([]byte, len("Password: ")+3)
+	if _, err := io.ReadFull(p.reader, prompt); err != nil || string(prompt) != "Password: \xff\xfb\x01" {
+		t.Fatalf("Expected a password prompt, got %q (%v)", prompt, err)
+	}
+	p.conn.Write([]byte(password + "\r\n"))
+	if _, err := io.ReadFull(p.reader, prompt[:5]); err != nil || string(prompt[:5]) != "\xff\xfc\x01\r\n" {
+		t.Fatalf("Expected echo to be turned back on, got %q (%v)", prompt[:5], err)
+	}
+}
+
+// queueConn queues a new connection on cm and waits for it in the
+// background, sending the client on admitted once it is let in
+func queueConn(t *testing.T, cm *ConnectionManager, admitted chan<- *Client) (*queuedPeer, bool) {
+	t.Helper()
+	conn, peer := net.Pipe()
+	t.Cleanup(func() { peer.Close() })
+	if _, ok := cm.admit(conn); ok {
+		t.Fatalf("Expected a connection past capacity to be refused")
+	}
+	entry, ok := cm.enqueue(conn)
+	if ok {
+		go func() {
+			client, ok := cm.wait(entry)
+			if ok {
+				admitted <- client
+			} else {
+				admitted <- nil
+			}
+		}()
+	}
+	return &queuedPeer{conn: peer, reader: bufio.NewReader(peer)}, ok
+}
+
+func TestWaitQueuePromotesPremiumFirst(t *testing.T) {
+	cm := NewConnectionManager(1, time.Minute)
+	cm.SetReservedSlots(1)
+	cm.SetQueueSize(2)
+	cm.SetQueuePriority(func(client *Client, account, password string) bool {
+		return account == "patron" && password == "secret"
+	})
+
+	first, firstPeer := net.Pipe()
+	defer firstPeer.Close()
+	if _, ok := cm.admit(first); !ok {
+		t.Fatalf("Expected the first connection to be admitted")
+	}
+
+	admitted := make(chan *Client, 2)
+	early, _ := queueConn(t, cm, admitted)
+	early.expect(t, "The realm is full; you are position 1 in the queue.")
+	early.expect(t, "Premium players may log in with their account name and password to move ahead.")
+	late, _ := queueConn(t, cm, admitted)
+	late.expect(t, "The realm is full; you are position 2 in the queue.")
+	late.expect(t, "Premium players may log in with their account name and password to move ahead.")
+	if _, ok := queueConn(t, cm, admitted); ok {
+		t.Errorf("Expected a connection past the queue to be turned away")
+	}
+
+	early.login(t, "pauper", "secret")
+	early.expect(t, "Only premium players who log in move ahead; you are still position 1 in the queue.")
+	early.login(t, "patron", "guess")
+	early.expect(t, "Only premium players who log in move ahead; you are still position 1 in the queue.")
+	late.login(t, "patron", "secret")
+	late.expect(t, "You are now position 1 in the queue.")
+	early.expect(t, "You are now position 2 in the queue.")
+
+	// Only the premium player may take the reserved slot
+	cm.promoteQueued()
+	client := <-admitted
+	if client == nil || client.GetQueuedAccount() != "patron" {
+		t.Fatalf("Expected the premium player to be let in, got %v", client)
+	}
+	late.expect(t, "A place in the realm has opened up for you.")
+	early.expect(t, "You are now position 1 in the queue.")
+	if stats := cm.GetStats(); stats.TotalClients != 2 || stats.QueuedClients != 1 {
+		t.Errorf("Expected 2 clients and 1 waiting, got %+v", stats)
+	}
+
+	// Reading carries on as normal once let in
+	go late.conn.Write([]byte("look\r\n"))
+	if line, err := client.ReadLine(); err != nil || line != "look" {
+		t.Errorf("Expected to read from the admitted client, got %q (%v)", line, err)
+	}
+
+	early.conn.Close()
+	if client := <-admitted; client != nil {
+		t.Errorf("Expected a connection that hung up to leave the queue")
+	}
+	if stats := cm.GetStats(); stats.QueuedClients != 0 {
+		t.Errorf("Expected the queue to be empty, got %d", stats.QueuedClients)
+	}
+}
+
+func TestWaitQueueKeepsOrderForNewConnections(t *testing.T) {
+	cm := NewConnectionManager(1, time.Minute)
+	cm.SetQueueSize(1)
+
+	first, firstPeer := net.Pipe()
+	defer firstPeer.Close()
+	client, _ := cm.admit(first)
+
+	admitted := make(chan *Client, 1)
+	waiting, _ := queueConn(t, cm, admitted)
+	waiting.expect(t, "The realm is full; you are position 1 in the queue.")
+
+	cm.RemoveClient(client.ID)
+	other, otherPeer := net.Pipe()
+	defer otherPeer.Close()
+	if _, ok := cm.admit(other); ok {
+		t.Errorf("Expected a new connection not to go ahead of the queue")
+	}
+
+	cm.promoteQueued()
+	if client := <-admitted; client == nil {
+		t.Fatalf("Expected the waiting connection to take the free slot")
+	}
+	waiting.expect(t, "A place in the realm has opened up for you.")
+}
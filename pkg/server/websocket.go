@@ -0,0 +1,321 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// WebSocket clients exchange one text message per line. Output arrives as
+// text frames, one for each line and one for a prompt left without a line
+// ending; binary frames carry control messages for the web client,
+// currently "echo off" and "echo on" around password prompts.
+
+const (
+	websocketGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+	// maxWebSocketMessage caps a single incoming message, which is far
+	// longer than any command a player types.
+	maxWebSocketMessage = 64 * 1024
+
+	opContinuation = 0x0
+	opText         = 0x1
+	opBinary       = 0x2
+	opClose        = 0x8
+	opPing         = 0x9
+	opPong         = 0xA
+)
+
+var (
+	errWebSocketProtocol = errors.New("websocket protocol error")
+	errWebSocketTooLarge = errors.New("websocket message too large")
+)
+
+// websocketAccept computes the Sec-WebSocket-Accept value for a client key.
+func websocketAccept(key string) string {
+	sum := sha1.Sum([]byte(key + websocketGUID))
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// upgradeWebSocket completes the opening handshake and takes over the
+// underlying connection from the HTTP server.
+func upgradeWebSocket(w http.ResponseWriter, r *http.Request) (*wsConn, error) {
+	if r.Method != http.MethodGet ||
+		!headerContains(r.Header, "Connection", "upgrade") ||
+		!headerContains(r.Header, "Upgrade", "websocket") {
+		http.Error(w, "expected a websocket upgrade", http.StatusBadRequest)
+		return nil, errWebSocketProtocol
+	}
+	if r.Header.Get("Sec-WebSocket-Version") != "13" {
+		w.Header().Set("Sec-WebSocket-Version", "13")
+		http.Error(w, "unsupported websocket version", http.StatusUpgradeRequired)
+		return nil, errWebSocketProtocol
+	}
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if key == "" {
+		http.Error(w, "missing websocket key", http.StatusBadRequest)
+		return nil, errWebSocketProtocol
+	}
+
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "websocket not supported", http.StatusInternalServerError)
+		return nil, errWebSocketProtocol
+	}
+	conn, rw, err := hijacker.Hijack()
+	if err != nil {
+		return nil, fmt.Errorf("failed to take over websocket connection: %w", err)
+	}
+	// Clear the header deadline the HTTP server may have left on the socket
+	conn.SetDeadline(time.Time{})
+
+	response := "HTTP/1.1 101 Switching Protocols\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Connection: Upgrade\r\n" +
+		"Sec-WebSocket-Accept: " + websocketAccept(key) + "\r\n\r\n"
+	if _, err := conn.Write([]byte(response)); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to complete websocket handshake: %w", err)
+	}
+
+	return newWSConn(conn, rw.Reader), nil
+}
+
+func headerContains(header http.Header, name, token string) bool {
+	for _, value := range header.Values(name) {
+		for _, part := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(part), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// wsConn presents a WebSocket as a plain line-oriented net.Conn, so Client
+// and SessionHandler work the same as they do over telnet. A read
+// interrupted by a deadline keeps what it had read of the frame and message,
+// and the next read carries on from there.
+type wsConn struct {
+	conn      net.Conn
+	reader    *bufio.Reader
+	pending   []byte
+	frame     []byte // The frame being read, so far
+	message   []byte // The fragments of the message being read, so far
+	writeLock sync.Mutex
+	closed    bool
+}
+
+func newWSConn(conn net.Conn, reader *bufio.Reader) *wsConn {
+	if reader == nil {
+		reader = bufio.NewReader(conn)
+	}
+	return &wsConn{conn: conn, reader: reader}
+}
+
+// Read returns incoming text messages, each terminated by a newline.
+func (c *wsConn) Read(p []byte) (int, error) {
+	for len(c.pending) == 0 {
+		message, err := c.readMessage()
+		if err != nil {
+			return 0, err
+		}
+		if !strings.HasSuffix(string(message), "\n") {
+			message = append(message, '\n')
+		}
+		c.pending = message
+	}
+
+	n := copy(p, c.pending)
+	c.pending = c.pending[n:]
+	return n, nil
+}
+
+// readMessage reads frames until a complete data message arrives, answering
+// pings and close requests along the way.
+func (c *wsConn) readMessage() ([]byte, error) {
+	for {
+		fin, opcode, payload, err := c.readFrame()
+		if err != nil {
+			return nil, err
+		}
+
+		switch opcode {
+		case opPing:
+			if err := c.writeFrame(opPong, payload); err != nil {
+				return nil, err
+			}
+			continue
+		case opPong:
+			continue
+		case opClose:
+			c.writeFrame(opClose, nil)
+			return nil, io.EOF
+		case opText, opBinary:
+			if c.message != nil {
+				return nil, errWebSocketProtocol
+			}
+			c.message = payload
+		case opContinuation:
+			if c.message == nil {
+				return nil, errWebSocketProtocol
+			}
+			c.message = append(c.message, payload...)
+		default:
+			return nil, errWebSocketProtocol
+		}
+
+		if len(c.message) > maxWebSocketMessage {
+			return nil, errWebSocketTooLarge
+		}
+		if fin {
+			message := c.message
+			c.message = nil
+			return message, nil
+		}
+	}
+}
+
+func (c *wsConn) readFrame() (bool, byte, []byte, error) {
+	if err := c.fillFrame(2); err != nil {
+		return false, 0, nil, err
+	}
+
+	fin := c.frame[0]&0x80 != 0
+	opcode := c.frame[0] & 0x0F
+	masked := c.frame[1]&0x80 != 0
+	length := uint64(c.frame[1] & 0x7F)
+
+	// Clients must mask every frame
+	if !masked {
+		return false, 0, nil, errWebSocketProtocol
+	}
+
+	headerLength := 2
+	switch length {
+	case 126:
+		headerLength += 2
+		if err := c.fillFrame(headerLength); err != nil {
+			return false, 0, nil, err
+		}
+		length = uint64(binary.BigEndian.Uint16(c.frame[2:headerLength]))
+	case 127:
+		headerLength += 8
+		if err := c.fillFrame(headerLength); err != nil {
+			return false, 0, nil, err
+		}
+		length = binary.BigEndian.Uint64(c.frame[2:headerLength])
+	}
+	if length > maxWebSocketMessage {
+		return false, 0, nil, errWebSocketTooLarge
+	}
+
+	headerLength += 4
+	if err := c.fillFrame(headerLength + int(length)); err != nil {
+		return false, 0, nil, err
+	}
+	mask := c.frame[headerLength-4 : headerLength]
+	payload := c.frame[headerLength:]
+	for i := range payload {
+		payload[i] ^= mask[i%4]
+	}
+	c.frame = nil
+	return fin, opcode, payload, nil
+}
+
+// fillFrame reads until the frame being read has at least n bytes. Whatever
+// is read is kept even if the read fails, so it can be carried on with.
+func (c *wsConn) fillFrame(n int) error {
+	if len(c.frame) >= n {
+		return nil
+	}
+	more := make([]byte, n-len(c.frame))
+	read, err := io.ReadFull(c.reader, more)
+	c.frame = append(c.frame, more[:read]...)
+	return err
+}
+
+// Write sends each line of p, with its line ending, as a text frame of its
+// own. Text after the last line ending, such as a prompt, gets a frame too.
+func (c *wsConn) Write(p []byte) (int, error) {
+	var frames []byte
+	for rest := p; len(rest) > 0; {
+		end := len(rest)
+		if i := bytes.IndexByte(rest, '\n'); i >= 0 {
+			end = i + 1
+		}
+		frames = appendFrame(frames, opText, rest[:end])
+		rest = rest[end:]
+	}
+	if err := c.writeFrames(frames); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// SetEcho tells the web client whether to show what the player types.
+func (c *wsConn) SetEcho(enabled bool) error {
+	if enabled {
+		return c.writeFrame(opBinary, []byte("echo on"))
+	}
+	return c.writeFrame(opBinary, []byte("echo off"))
+}
+
+func (c *wsConn) writeFrame(opcode byte, payload []byte) error {
+	return c.writeFrames(appendFrame(nil, opcode, payload))
+}
+
+// writeFrames sends already encoded frames in one write, so the frames of
+// one Write are never interleaved with another's.
+func (c *wsConn) writeFrames(frames []byte) error {
+	c.writeLock.Lock()
+	defer c.writeLock.Unlock()
+
+	if c.closed {
+		return net.ErrClosed
+	}
+	_, err := c.conn.Write(frames)
+	return err
+}
+
+// appendFrame appends an unmasked, final frame carrying payload to buf.
+func appendFrame(buf []byte, opcode byte, payload []byte) []byte {
+	buf = append(buf, 0x80|opcode)
+	switch n := len(payload); {
+	case n < 126:
+		buf = append(buf, byte(n))
+	case n <= 0xFFFF:
+		buf = append(buf, 126, byte(n>>8), byte(n))
+	default:
+		buf = append(buf, 127)
+		buf = binary.BigEndian.AppendUint64(buf, uint64(n))
+	}
+	return append(buf, payload...)
+}
+
+func (c *wsConn) Close() error {
+	// Say goodbye, but never let a stalled peer hold up the close
+	c.conn.SetWriteDeadline(time.Now().Add(time.Second))
+	c.writeFrame(opClose, nil)
+
+	c.writeLock.Lock()
+	c.closed = true
+	c.writeLock.Unlock()
+	return c.conn.Close()
+}
+
+func (c *wsConn) LocalAddr() net.Addr                { return c.conn.LocalAddr() }
+func (c *wsConn) RemoteAddr() net.Addr               { return c.conn.RemoteAddr() }
+func (c *wsConn) SetDeadline(t time.Time) error      { return c.conn.SetDeadline(t) }
+func (c *wsConn) SetReadDeadline(t time.Time) error  { return c.conn.SetReadDeadline(t) }
+func (c *wsConn) SetWriteDeadline(t time.Time) error { return c.conn.SetWriteDeadline(t) }
@@ -0,0 +1,211 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWebSocketAccept(t *testing.T) {
+	// Example from RFC 6455, section 1.3
+	if got := websocketAccept("dGhlIHNhbXBsZSBub25jZQ=="); got != "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=" {
+		t.Errorf("Unexpected accept value: %s", got)
+	}
+}
+
+// writeClientFrame writes a masked frame the way a browser would.
+func writeClientFrame(t *testing.T, w io.Writer, opcode byte, payload []byte) {
+	t.Helper()
+	mask := [4]byte{1, 2, 3, 4}
+	frame := []byte{0x80 | opcode}
+	if len(payload) < 126 {
+		frame = append(frame, 0x80|byte(len(payload)))
+	} else {
+		frame = append(frame, 0x80|126)
+		frame = binary.BigEndian.AppendUint16(frame, uint16(len(payload)))
+	}
+	frame = append(frame, mask[:]...)
+	for i, b := range payload {
+		frame = append(frame, b^mask[i%4])
+	}
+	if _, err := w.Write(frame); err != nil {
+		t.Fatalf("Failed to write frame: %v", err)
+	}
+}
+
+// readServerFrame reads one unmasked frame sent by the server.
+func readServerFrame(t *testing.T, r io.Reader) (byte, string) {
+	t.Helper()
+	var header [2]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		t.Fatalf("Failed to read frame header: %v", err)
+	}
+	length := int(header[1] & 0x7F)
+	if length == 126 {
+		var ext [2]byte
+		io.ReadFull(r, ext[:])
+		length = int(binary.BigEndian.Uint16(ext[:]))
+	}
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		t.Fatalf("Failed to read frame payload: %v", err)
+	}
+	return header[0] & 0x0F, string(payload)
+}
+
+func TestWSConnReadsLines(t *testing.T) {
+	server, client := net.Pipe()
+	defer client.Close()
+	conn := newWSConn(server, nil)
+
+	go func() {
+		writeClientFrame(t, client, opPing, []byte("hi"))
+		writeClientFrame(t, client, opText, []byte("look"))
+		writeClientFrame(t, client, opText, []byte("say hello\n"))
+	}()
+
+	// The ping is answered before the first message is returned
+	go func() {
+		opcode, payload := readServerFrame(t, client)
+		if opcode != opPong || payload != "hi" {
+			t.Errorf("Expected pong 'hi', got %x %q", opcode, payload)
+		}
+	}()
+
+	reader := bufio.NewReader(conn)
+	for _, want := range []string{"look\n", "say hello\n"} {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			t.Fatalf("Failed to read line: %v", err)
+		}
+		if line != want {
+			t.Errorf("Expected %q, got %q", want, line)
+		}
+	}
+}
+
+func TestWSConnResumesAfterDeadline(t *testing.T) {
+	server, client := net.Pipe()
+	defer client.Close()
+	conn := newWSConn(server, nil)
+
+	var frame bytes.Buffer
+	writeClientFrame(t, &frame, opText, []byte("look"))
+	data := frame.Bytes()
+
+	// A deadline, such as the one that lets a queued client in, interrupts
+	// the read after part of the frame has arrived
+	interrupted := make(chan error)
+	go func() {
+		_, err := conn.Read(make([]byte, 16))
+		interrupted <- err
+	}()
+	client.Write(data[:3])
+	server.SetReadDeadline(time.Now())
+	if err := <-interrupted; !errors.Is(err, os.ErrDeadlineExceeded) {
+		t.Fatalf("Expected the read to time out, got %v", err)
+	}
+
+	server.SetReadDeadline(time.Time{})
+	go client.Write(data[3:])
+	buf := make([]byte, 16)
+	n, err := conn.Read(buf)
+	if err != nil {
+		t.Fatalf("Failed to read line: %v", err)
+	}
+	if line := string(buf[:n]); line != "look\n" {
+		t.Errorf("Expected the frame to be read whole, got %q", line)
+	}
+}
+
+func TestWSConnWritesFramePerLine(t *testing.T) {
+	server, client := net.Pipe()
+	defer client.Close()
+	conn := newWSConn(server, nil)
+
+	go conn.Write([]byte("You hit a goblin.\r\nA goblin dies.\r\n> "))
+
+	for _, want := range []string{"You hit a goblin.\r\n", "A goblin dies.\r\n", "> "} {
+		if opcode, text := readServerFrame(t, client); opcode != opText || text != want {
+			t.Errorf("Expected text frame %q, got %x %q", want, opcode, text)
+		}
+	}
+}
+
+func TestWSConnRejectsUnmaskedFrames(t *testing.T) {
+	server, client := net.Pipe()
+	defer client.Close()
+	conn := newWSConn(server, nil)
+
+	go client.Write([]byte{0x81, 0x02, 'h', 'i'})
+
+	if _, err := conn.Read(make([]byte, 16)); err != errWebSocketProtocol {
+		t.Errorf("Expected protocol error, got %v", err)
+	}
+}
+
+func TestWebSocketClientSession(t *testing.T) {
+	var received string
+	done := make(chan struct{})
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ws", func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgradeWebSocket(w, r)
+		if err != nil {
+			t.Errorf("Upgrade failed: %v", err)
+			return
+		}
+		client := NewClient("ws", conn)
+		client.Send("Welcome to DungeoGo!")
+		received, _ = client.ReadPassword()
+		close(done)
+		client.Close()
+	})
+	httpServer := httptest.NewServer(mux)
+	defer httpServer.Close()
+
+	raw, err := net.Dial("tcp", strings.TrimPrefix(httpServer.URL, "http://"))
+	if err != nil {
+		t.Fatalf("Failed to dial: %v", err)
+	}
+	defer raw.Close()
+
+	request := "GET /ws HTTP/1.1\r\nHost: test\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n" +
+		"Sec-WebSocket-Key: dGhlIHNhbXBsZSBub25jZQ==\r\nSec-WebSocket-Version: 13\r\n\r\n"
+	raw.Write([]byte(request))
+
+	reader := bufio.NewReader(raw)
+	response, err := http.ReadResponse(reader, nil)
+	if err != nil {
+		t.Fatalf("Failed to read handshake: %v", err)
+	}
+	if response.StatusCode != http.StatusSwitchingProtocols {
+		t.Fatalf("Expected 101, got %d", response.StatusCode)
+	}
+
+	if opcode, text := readServerFrame(t, reader); opcode != opText || text != "Welcome to DungeoGo!\r\n" {
+		t.Errorf("Unexpected greeting frame: %x %q", opcode, text)
+	}
+	if opcode, text := readServerFrame(t, reader); opcode != opBinary || text != "echo off" {
+		t.Errorf("Expected echo off control frame, got %x %q", opcode, text)
+	}
+
+	writeClientFrame(t, raw, opText, []byte("s3cret"))
+	<-done
+
+	if received != "s3cret" {
+		t.Errorf("Expected password 's3cret', got %q", received)
+	}
+	if opcode, text := readServerFrame(t, reader); opcode != opBinary || text != "echo on" {
+		t.Errorf("Expected echo on control frame, got %x %q", opcode, text)
+	}
+}
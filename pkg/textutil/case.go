@@ -0,0 +1,16 @@
+package textutil
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// Capitalize upper-cases the first letter of text, so a name such as
+// "a goblin" can start a sentence.
+func Capitalize(text string) string {
+	first, size := utf8.DecodeRuneInString(text)
+	if first == utf8.RuneError {
+		return text
+	}
+	return string(unicode.ToUpper(first)) + text[size:]
+}
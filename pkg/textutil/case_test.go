@@ -0,0 +1,17 @@
+package textutil
+
+import "testing"
+
+func TestCapitalize(t *testing.T) {
+	tests := map[string]string{
+		"":         "",
+		"a goblin": "A goblin",
+		"Goblin":   "Goblin",
+		"élan":     "Élan",
+	}
+	for input, expected := range tests {
+		if got := Capitalize(input); got != expected {
+			t.Errorf("Capitalize(%q) = %q, want %q", input, got, expected)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package textutil
+
+import (
+	"strings"
+	"unicode"
+)
+
+// profaneWords are refused in player-chosen text such as titles. Matching is
+// by whole word so place names like "Scunthorpe" are not caught.
+var profaneWords = map[string]bool{
+	"arse":     true,
+	"ass":      true,
+	"asshole":  true,
+	"bastard":  true,
+	"bitch":    true,
+	"bollocks": true,
+	"cock":     true,
+	"crap":     true,
+	"cunt":     true,
+	"damn":     true,
+	"dick":     true,
+	"fuck":     true,
+	"fucker":   true,
+	"fucking":  true,
+	"piss":     true,
+	"prick":    true,
+	"shit":     true,
+	"slut":     true,
+	"twat":     true,
+	"wanker":   true,
+	"whore":    true,
+}
+
+// ContainsProfanity reports whether any word in text is on the profanity
+// list, ignoring case and punctuation.
+func ContainsProfanity(text string) bool {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	for _, word := range words {
+		if profaneWords[word] {
+			return true
+		}
+	}
+	return false
+}
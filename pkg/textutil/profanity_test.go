@@ -0,0 +1,18 @@
+package textutil
+
+import "testing"
+
+func TestContainsProfanity(t *testing.T) {
+	tests := map[string]bool{
+		"the Brave":         false,
+		"of Scunthorpe":     false,
+		"the Shit":          true,
+		"the (crap) wizard": true,
+		"":                  false,
+	}
+	for input, expected := range tests {
+		if got := ContainsProfanity(input); got != expected {
+			t.Errorf("ContainsProfanity(%q) = %v, want %v", input, got, expected)
+		}
+	}
+}
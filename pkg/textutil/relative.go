@@ -0,0 +1,67 @@
+package textutil
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// RelativeTime describes t relative to now, such as "2 hours ago". Times
+// more than a month old are shown as a date, and the zero time as "never".
+func RelativeTime(t, now time.Time) string {
+	if t.IsZero() {
+		return "never"
+	}
+
+	elapsed := now.Sub(t)
+	switch {
+	case elapsed < time.Minute:
+		return "just now"
+	case elapsed < time.Hour:
+		return plural(int(elapsed/time.Minute), "minute") + " ago"
+	case elapsed < 24*time.Hour:
+		return plural(int(elapsed/time.Hour), "hour") + " ago"
+	case elapsed < 30*24*time.Hour:
+		return plural(int(elapsed/(24*time.Hour)), "day") + " ago"
+	default:
+		return t.Format("2006-01-02")
+	}
+}
+
+func plural(n int, unit string) string {
+	if n == 1 {
+		return fmt.Sprintf("1 %s", unit)
+	}
+	return fmt.Sprintf("%d %ss", n, unit)
+}
+
+// Duration describes d in its two largest units, such as "3 hours 12
+// minutes" or "45 seconds".
+func Duration(d time.Duration) string {
+	units := []struct {
+		size time.Duration
+		name string
+	}{
+		{24 * time.Hour, "day"},
+		{time.Hour, "hour"},
+		{time.Minute, "minute"},
+		{time.Second, "second"},
+	}
+
+	var parts []string
+	for _, unit := range units {
+		if n := int(d / unit.size); n > 0 {
+			parts = append(parts, plural(n, unit.name))
+			d -= time.Duration(n) * unit.size
+		} else if len(parts) > 0 {
+			break
+		}
+		if len(parts) == 2 {
+			break
+		}
+	}
+	if len(parts) == 0 {
+		return "0 seconds"
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,51 @@
+package textutil
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRelativeTime(t *testing.T) {
+	now := time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		t        time.Time
+		expected string
+	}{
+		{time.Time{}, "never"},
+		{now.Add(-10 * time.Second), "just now"},
+		{now.Add(-time.Minute), "1 minute ago"},
+		{now.Add(-45 * time.Minute), "45 minutes ago"},
+		{now.Add(-2 * time.Hour), "2 hours ago"},
+		{now.Add(-36 * time.Hour), "1 day ago"},
+		{now.Add(-10 * 24 * time.Hour), "10 days ago"},
+		{now.Add(-60 * 24 * time.Hour), "2024-04-16"},
+	}
+
+	for _, test := range tests {
+		if actual := RelativeTime(test.t, now); actual != test.expected {
+			t.Errorf("Expected %q for %v, got %q", test.expected, test.t, actual)
+		}
+	}
+}
+
+func TestDuration(t *testing.T) {
+	tests := []struct {
+		d        time.Duration
+		expected string
+	}{
+		{0, "0 seconds"},
+		{45 * time.Second, "45 seconds"},
+		{time.Minute + 5*time.Second, "1 minute 5 seconds"},
+		{3*time.Hour + 12*time.Minute + 40*time.Second, "3 hours 12 minutes"},
+		{2 * time.Hour, "2 hours"},
+		{2*time.Hour + 30*time.Second, "2 hours"},
+		{50*time.Hour + 15*time.Minute, "2 days 2 hours"},
+	}
+
+	for _, test := range tests {
+		if actual := Duration(test.d); actual != test.expected {
+			t.Errorf("Expected %q for %v, got %q", test.expected, test.d, actual)
+		}
+	}
+}
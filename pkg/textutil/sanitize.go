@@ -0,0 +1,28 @@
+package textutil
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Sanitize removes control characters, invisible formatting characters and
+// invalid UTF-8 from text a player typed, keeping printable characters in
+// any script. Tabs become spaces.
+func Sanitize(text string) string {
+	var b strings.Builder
+	b.Grow(len(text))
+	for len(text) > 0 {
+		r, size := utf8.DecodeRuneInString(text)
+		text = text[size:]
+		switch {
+		case r == utf8.RuneError && size == 1:
+			continue
+		case r == '\t':
+			b.WriteRune(' ')
+		case unicode.IsGraphic(r):
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
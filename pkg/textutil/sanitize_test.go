@@ -0,0 +1,25 @@
+package textutil
+
+import "testing"
+
+func TestSanitize(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"say hello", "say hello"},
+		{"say \x1b[31mred\x1b[0m", "say [31mred[0m"},
+		{"bell\a and\x00 nul", "bell and nul"},
+		{"tab\there", "tab here"},
+		{"say café ☕ 你好", "say café ☕ 你好"},
+		{"bad \xff\xfe bytes", "bad  bytes"},
+		{"zero\u200bwidth", "zerowidth"},
+		{"line\r\n", "line"},
+	}
+
+	for _, test := range tests {
+		if got := Sanitize(test.input); got != test.expected {
+			t.Errorf("Sanitize(%q) = %q, expected %q", test.input, got, test.expected)
+		}
+	}
+}
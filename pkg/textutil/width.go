@@ -0,0 +1,45 @@
+package textutil
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Width is the number of terminal columns text takes up. Combining marks
+// and invisible formatting characters take none, and wide characters such
+// as Chinese, Japanese and Korean take two.
+func Width(text string) int {
+	width := 0
+	for _, r := range text {
+		width += runeWidth(r)
+	}
+	return width
+}
+
+func runeWidth(r rune) int {
+	switch {
+	case unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf) || unicode.IsControl(r):
+		return 0
+	case isWide(r):
+		return 2
+	}
+	return 1
+}
+
+// isWide reports whether r is one of the East Asian characters terminals
+// draw two columns wide
+func isWide(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul) ||
+		r >= 0x3000 && r <= 0x303f || // CJK punctuation
+		r >= 0xff00 && r <= 0xff60 || // fullwidth forms
+		r >= 0xffe0 && r <= 0xffe6
+}
+
+// PadRight pads text with spaces to width columns, for lining up tables.
+// Text already that wide is returned unchanged.
+func PadRight(text string, width int) string {
+	if gap := width - Width(text); gap > 0 {
+		return text + strings.Repeat(" ", gap)
+	}
+	return text
+}
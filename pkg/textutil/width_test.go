@@ -0,0 +1,38 @@
+package textutil
+
+import "testing"
+
+func TestWidth(t *testing.T) {
+	tests := map[string]int{
+		"":           0,
+		"Bob":        3,
+		"Zoë":        3,
+		"Zoe\u0308":  3,
+		"Алиса":      5,
+		"李娜":         4,
+		"ｆｕｌｌ":       8,
+		"zero\u200b": 4,
+	}
+	for text, expected := range tests {
+		if got := Width(text); got != expected {
+			t.Errorf("Width(%q) = %d, expected %d", text, got, expected)
+		}
+	}
+}
+
+func TestPadRight(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected string
+	}{
+		{"Bob", "Bob   |"},
+		{"Zoë", "Zoë   |"},
+		{"李娜", "李娜  |"},
+		{"Bartholomew", "Bartholomew|"},
+	}
+	for _, test := range tests {
+		if got := PadRight(test.text, 6) + "|"; got != test.expected {
+			t.Errorf("PadRight(%q, 6) = %q, expected %q", test.text, got, test.expected)
+		}
+	}
+}
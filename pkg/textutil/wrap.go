@@ -0,0 +1,40 @@
+package textutil
+
+import "strings"
+
+const DefaultWidth = 80
+
+// Wrap breaks text into lines no wider than width columns, splitting on
+// whitespace. Existing line breaks are kept, and words longer than width are
+// left on a line of their own rather than split.
+func Wrap(text string, width int) []string {
+	if width <= 0 {
+		width = DefaultWidth
+	}
+
+	var lines []string
+	for _, paragraph := range strings.Split(text, "\n") {
+		words := strings.Fields(paragraph)
+		if len(words) == 0 {
+			lines = append(lines, "")
+			continue
+		}
+
+		current := words[0]
+		currentWidth := Width(current)
+		for _, word := range words[1:] {
+			wordWidth := Width(word)
+			if currentWidth+1+wordWidth > width {
+				lines = append(lines, current)
+				current = word
+				currentWidth = wordWidth
+				continue
+			}
+			current += " " + word
+			currentWidth += 1 + wordWidth
+		}
+		lines = append(lines, current)
+	}
+
+	return lines
+}
@@ -0,0 +1,67 @@
+package textutil
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestWrap(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		width    int
+		expected []string
+	}{
+		{
+			name:     "fits on one line",
+			text:     "Welcome to the realm",
+			width:    40,
+			expected: []string{"Welcome to the realm"},
+		},
+		{
+			name:     "wraps at word boundary",
+			text:     "The quick brown fox jumps over the lazy dog",
+			width:    15,
+			expected: []string{"The quick brown", "fox jumps over", "the lazy dog"},
+		},
+		{
+			name:     "keeps paragraphs",
+			text:     "First line\n\nSecond line",
+			width:    40,
+			expected: []string{"First line", "", "Second line"},
+		},
+		{
+			name:     "long word stays whole",
+			text:     "a supercalifragilistic word",
+			width:    10,
+			expected: []string{"a", "supercalifragilistic", "word"},
+		},
+		{
+			name:     "counts accented letters as one column",
+			text:     "Zoë déjà vu à Montréal",
+			width:    11,
+			expected: []string{"Zoë déjà vu", "à Montréal"},
+		},
+		{
+			name:     "counts wide characters as two columns",
+			text:     "李娜 说 你好 世界",
+			width:    9,
+			expected: []string{"李娜 说", "你好 世界"},
+		},
+		{
+			name:     "zero width uses default",
+			text:     "short",
+			width:    0,
+			expected: []string{"short"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Wrap(tt.text, tt.width)
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Wrap(%q, %d) = %q, expected %q", tt.text, tt.width, got, tt.expected)
+			}
+		})
+	}
+}